@@ -16,6 +16,7 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/frontend"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/middleware"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/adminrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
@@ -24,7 +25,12 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/employeerep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/tagrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/ticketpurchasesrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/trashrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/adminserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
@@ -33,8 +39,17 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/buyticketserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/collectionserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/eventserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/exportserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/iiifserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/importserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/mailing"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/oaiserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/recommendserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/searcher"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/tagserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/trashserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/userlistserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/userservice"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -177,6 +192,26 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	imageStorage, err := imagestorage.NewLocalImageStorage(appCnfg.ImageStorageDir)
+	if err != nil {
+		panic(err)
+	}
+	historyRep, err := historyrep.NewHistoryRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
+	tagRep, err := tagrep.NewTagRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
+	trashRep, err := trashrep.NewTrashRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
+	userListRep, err := userlistrep.NewUserListRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
 	// ------------------------
 
 	// ----- Services -----
@@ -199,13 +234,24 @@ func main() {
 	}
 	// serv
 	userServ := userservice.NewUserService(userRep, authZ)
+	userListServ := userlistserv.NewUserListServ(userListRep, artworkRep, eventRep, authZ)
 	adminserv := adminserv.NewAdminService(employeeRep, userRep, authZ)
 	buyTicketServ, _ := buyticketserv.NewBuyTicketsServ(txRep, tPurchasesRep, *appCnfg, authZ, userRep, eventRep)
-	collectionServ := collectionserv.NewCollectionServ(collectionRep)
-	authroServ := authorserv.NewAuthorServ(authorRep)
-	artworkServ := artworkserv.NewArtworkService(artworkRep, authorRep, collectionRep)
-	eventServ := eventserv.NewEventService(eventRep, artworkRep)
+	historyServ := historyserv.NewHistoryServ(historyRep, authZ)
+	collectionServ := collectionserv.NewCollectionServ(collectionRep, historyServ)
+	authroServ := authorserv.NewAuthorServ(authorRep, historyServ, imageStorage)
+	artworkServ := artworkserv.NewArtworkService(artworkRep, authorRep, collectionRep, imageStorage, historyServ)
+	eventServ := eventserv.NewEventService(eventRep, artworkRep, authZ, historyServ)
 	searcherServ := searcher.NewSearcher(artworkRep, eventRep)
+	recommendServ := recommendserv.NewRecommendServ(artworkRep, userListRep, authZ)
+	tagServ := tagserv.NewTagServ(tagRep, artworkRep)
+	importServ := importserv.NewImportServ(artworkRep, authorRep, collectionRep)
+	exportServ := exportserv.NewExportServ(artworkRep, eventRep)
+	iiifServ := iiifserv.NewIIIFServ(artworkRep, collectionRep, appCnfg.PublicURL)
+	oaiServ := oaiserv.NewOAIServ(artworkRep, collectionRep, appCnfg.PublicURL, appCnfg.AdminEmail)
+	trashServ := trashserv.NewTrashServ(trashRep, imageStorage, appCnfg.TrashRetention)
+	// окончательное удаление записей корзины по истечении срока хранения
+	go trashServ.RunPurge(ctx, appCnfg.TrashPurgeInterval)
 	mailingServ := mailing.NewGmailSender(userRep, "museum", "museum@test.ru", "1234")
 	// --------------------
 
@@ -230,6 +276,8 @@ func main() {
 
 	userRouter := api.NewUserRouter(userGroup, userServ)
 	_ = userRouter
+	userListRouter := api.NewUserListRouter(userGroup, apiGroup, userListServ, appCnfg.PublicURL)
+	_ = userListRouter
 	employeeRouter := api.AdminRouter{}
 	employeeRouter.Init(adminGroup, adminserv, authEmployeeServ, authZ)
 
@@ -241,18 +289,39 @@ func main() {
 	_ = artworkRouter
 	eventRouter := api.NewEventRouter(employeeGroup, eventServ, authZ)
 	_ = eventRouter
+	// администратор может изменять любые мероприятия
+	adminEventRouter := api.NewEventRouter(adminGroup, eventServ, authZ)
+	_ = adminEventRouter
+	eventReviewRouter := api.NewEventReviewRouter(adminGroup, eventServ)
+	_ = eventReviewRouter
 	mailingRouter := api.NewMailingRouter(employeeGroup, mailingServ, eventServ)
 	_ = mailingRouter
 	buyTicketRouter := api.NewBuyTicketRouter(guestGroup, buyTicketServ)
 	_ = buyTicketRouter
-	searcherRouter := api.NewSearcherRouter(apiGroup, searcherServ)
+	searcherRouter := api.NewSearcherRouter(apiGroup, searcherServ, buyTicketServ, recommendServ,
+		middleware.AuthMiddleware(authUserServ, authZ, false), appCnfg.PublicURL)
 	_ = searcherRouter
+	tagRouter := api.NewTagRouter(employeeGroup, apiGroup, tagServ)
+	_ = tagRouter
+	importRouter := api.NewImportRouter(employeeGroup, importServ)
+	_ = importRouter
+	exportRouter := api.NewExportRouter(employeeGroup, exportServ)
+	_ = exportRouter
+	iiifRouter := api.NewIIIFRouter(apiGroup, iiifServ)
+	_ = iiifRouter
+	oaiRouter := api.NewOAIRouter(apiGroup, oaiServ)
+	_ = oaiRouter
+	trashRouter := api.NewTrashRouter(employeeGroup, trashServ)
+	_ = trashRouter
 	// -------------------
 
 	// ------ Cite -----
 	engine.StaticFS("/static", http.Dir("./internal/frontend/static/"))
+	// изображения произведений из локального хранилища
+	engine.StaticFS(models.ArtworkImagesURLPrefix, http.Dir(imageStorage.Root()))
 	citeGroup := engine.Group("museum")
-	citeRouter := frontend.NewCiteRouter(citeGroup, searcherServ, authroServ)
+	citeRouter := frontend.NewCiteRouter(
+		citeGroup, searcherServ, authroServ, tagServ, buyTicketServ, userListServ, recommendServ, appCnfg.PublicURL)
 	_ = citeRouter
 	emplCiteGroup := citeGroup.Group("employee")
 	emplCiteGroup.Use(middleware.AuthMiddleware(authEmployeeServ, authZ, true))
@@ -0,0 +1,100 @@
+// Импорт каталога из CSV или JSON. Запускается из корня репозитория:
+//
+//	go run ./cmd/import -file artworks.csv -entity artworks          # проверка, отчет об ошибках
+//	go run ./cmd/import -file artworks.csv -entity artworks -commit  # сохранение
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/importserv"
+)
+
+func main() {
+	path := flag.String("file", "", "файл импорта (.csv или .json)")
+	format := flag.String("format", "", "формат файла: csv или json, по умолчанию - по расширению")
+	entity := flag.String("entity", "", "раздел CSV-файла: authors, collections или artworks")
+	commit := flag.Bool("commit", false, "сохранить записи; без флага выполняется только проверка")
+	flag.Parse()
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = string(importserv.FormatByName(*path))
+	}
+	if models.ImportFormat(*format) == models.ImportCSV && !models.ImportEntity(*entity).IsValid() {
+		log.Fatalf("-entity is required for csv: authors, collections or artworks")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("cannot open import file: %v", err)
+	}
+	defer file.Close()
+	req, err := importserv.DecodeImport(file, models.ImportFormat(*format), models.ImportEntity(*entity))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ----- Config ------
+	ctx := context.Background()
+	appCnfg, err := cnfg.LoadAppConfig()
+	if err != nil {
+		panic(fmt.Errorf("cannot load AppConfig: %v", err))
+	}
+	var dbCreds *cnfg.DatebaseCredentials
+	if appCnfg.Datebase == cnfg.PostgresDB {
+		dbCreds, err = cnfg.LoadPgCredentials("./configs/")
+		if err != nil {
+			panic(fmt.Errorf("cannot load PgCredentials: %v", err))
+		}
+	} else if appCnfg.Datebase == cnfg.ClickHouseDB {
+		dbCreds, err = cnfg.LoadClickHouseCredentials()
+		if err != nil {
+			panic(fmt.Errorf("cannot load ClickHouseCredentials: %v", err))
+		}
+	}
+	dbCnfg, err := cnfg.LoadDatebaseConfig("./configs/")
+	if err != nil {
+		panic(fmt.Errorf("cannot load DatebaseConfig: %v", err))
+	}
+	// ------------------
+
+	collectionRep, err := collectionrep.NewCollectionRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
+	authorRep, err := authorrep.NewAuthorRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
+	artworkRep, err := artworkrep.NewArtworkRep(ctx, appCnfg.Datebase, dbCreds, dbCnfg)
+	if err != nil {
+		panic(err)
+	}
+	importServ := importserv.NewImportServ(artworkRep, authorRep, collectionRep)
+
+	report, err := importServ.Import(ctx, req, !*commit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report.ToImportReportResponse()); err != nil {
+		log.Fatal(err)
+	}
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
@@ -150,6 +150,178 @@ const docTemplate = `{
                 }
             }
         },
+        "/admin/events/pending": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает мероприятия в статусе pending, ожидающие решения администратора",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Согласование мероприятий"
+                ],
+                "summary": "Получить мероприятия на согласовании (админ)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав"
+                    }
+                }
+            }
+        },
+        "/admin/events/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Публикует мероприятие: оно появляется в поиске и открывается продажа билетов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Согласование мероприятий"
+                ],
+                "summary": "Одобрить мероприятие (админ)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ReviewEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Мероприятие одобрено"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    },
+                    "409": {
+                        "description": "Мероприятие не находится на согласовании"
+                    }
+                }
+            }
+        },
+        "/admin/events/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает мероприятие сотруднику на доработку. Комментарий обязателен",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Согласование мероприятий"
+                ],
+                "summary": "Отклонить мероприятие (админ)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отклонения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ReviewEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Мероприятие отклонено"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации или нет комментария"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    },
+                    "409": {
+                        "description": "Мероприятие не находится на согласовании"
+                    }
+                }
+            }
+        },
         "/admin/userlist/": {
             "get": {
                 "security": [
@@ -312,7 +484,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет произведение с новыми/существующими автором и коллекцией",
+                "description": "Обновляет произведение с новыми/существующими автором и коллекцией.\nБез dating датировка сохраняется, если не изменился creationYear; без coAuthors дополнительные авторы не меняются",
                 "consumes": [
                     "application/json"
                 ],
@@ -359,7 +531,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет произведение с уже созданными автором и коллекцией",
+                "description": "Добавляет произведение с уже созданными автором и коллекцией.\nДатировка задается точным годом creationYear или периодом dating с уточнением (около, до, после) и точностью.\nКроме основного автора можно указать дополнительных (coAuthors) с ролями: автор, приписывается, мастерская, круг, последователь, копия.\nДатировка должна соответствовать годам жизни авторов; мастерская, круг, последователи и копии могут быть созданы после смерти автора",
                 "consumes": [
                     "application/json"
                 ],
@@ -406,7 +578,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет существующее произведение искусства",
+                "description": "Переносит произведение в корзину, откуда его можно восстановить до окончательной очистки.\nПроизведение, которое участвует в ещё не завершившихся выставках, удалить нельзя - выставки возвращаются в ответе 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,25 +616,74 @@ const docTemplate = `{
                     },
                     "404": {
                         "description": "Не найдено"
+                    },
+                    "409": {
+                        "description": "Произведение участвует в выставках",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DependencyErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/employee/authors": {
+        "/employee/artworks/dimensions/migrate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Разбирает текстовый размер произведений без структурированного размера\n(например \"73,7 x 92,1 см\" или \"29 × 36 in, 3 kg\") и сохраняет высоту, ширину, глубину и вес.\nТекстовый размер перезаписывается единообразным представлением, изменения сохраняются в истории.\nВозвращает произведения, размер которых разобрать не удалось.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Экспонаты"
+                ],
+                "summary": "Перевести текстовые размеры в структурированные (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить, не сохраняя изменения",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DimensionsMigrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный параметр dry_run"
+                    }
+                }
+            }
+        },
+        "/employee/artworks/needs-attention": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех авторов",
+                "description": "Возвращает по одному, самому свежему, отчету для каждого произведения\nс незавершенной реставрацией или с плохой либо критической оценкой последнего осмотра",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Авторы"
+                    "Экспонаты"
                 ],
-                "summary": "Получить всех авторов (сотрудник)",
+                "summary": "Произведения, требующие внимания реставратора (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -478,29 +699,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/jsonreqresp.AuthorResponse"
+                                "$ref": "#/definitions/jsonreqresp.ConditionReportResponse"
                             }
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/employee/artworks/{id}/condition-reports": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет данные существующего автора",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает отчеты о состоянии с фотографиями, новые первыми",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Авторы"
+                    "Экспонаты"
                 ],
-                "summary": "Обновить автора (сотрудник)",
+                "summary": "Получить историю осмотров и реставраций произведения (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -510,24 +730,28 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления автора",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.UpdateAuthorRequest"
-                        }
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешно обновлено"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ConditionReportResponse"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверный ID"
                     },
                     "404": {
-                        "description": "Автор не найден"
+                        "description": "Произведение не найдено"
                     }
                 }
             },
@@ -537,7 +761,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает нового автора",
+                "description": "Сохраняет результат осмотра или начатую реставрацию.\nЕсли заполнено поле treatment и не указан treatmentEnd, реставрация считается незавершенной\nи произведение нельзя добавить в мероприятия, пересекающиеся с ней по датам.",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,9 +769,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Авторы"
+                    "Экспонаты"
                 ],
-                "summary": "Добавить нового автора (сотрудник)",
+                "summary": "Добавить отчет о состоянии (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -557,31 +781,46 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные автора",
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Отчет о состоянии",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonreqresp.AddAuthorRequest"
+                            "$ref": "#/definitions/jsonreqresp.ConditionReportRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Автор создан"
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConditionReportResponse"
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверные входные параметры"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/employee/artworks/{id}/condition-reports/{reportID}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет существующего автора",
+                "description": "Заменяет поля отчета, приложенные фотографии сохраняются",
                 "consumes": [
                     "application/json"
                 ],
@@ -589,9 +828,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Авторы"
+                    "Экспонаты"
                 ],
-                "summary": "Удалить автора (сотрудник)",
+                "summary": "Изменить отчет о состоянии (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -601,46 +840,63 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для удаления автора",
-                        "name": "request",
-                        "in": "body",
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID отчета",
+                        "name": "reportID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Отчет о состоянии",
+                        "name": "request",
+                        "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonreqresp.DeleteAuthorRequest"
+                            "$ref": "#/definitions/jsonreqresp.ConditionReportRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешно удалено"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConditionReportResponse"
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверные входные параметры"
                     },
                     "404": {
-                        "description": "Автор не найден"
-                    },
-                    "409": {
-                        "description": "Конфликт - у автора есть связанные произведения"
+                        "description": "Не найдено"
                     }
                 }
             }
         },
-        "/employee/collections": {
-            "get": {
+        "/employee/artworks/{id}/condition-reports/{reportID}/close": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех коллекций",
+                "description": "Отмечает дату завершения реставрации, после чего произведение снова доступно для мероприятий",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Коллекции"
+                    "Экспонаты"
                 ],
-                "summary": "Получить все коллекции (сотрудник)",
+                "summary": "Завершить реставрацию (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -648,37 +904,67 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID отчета",
+                        "name": "reportID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Дата завершения",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.CloseTreatmentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/jsonreqresp.CollectionResponse"
-                            }
+                            "$ref": "#/definitions/jsonreqresp.ConditionReportResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Неверные входные параметры"
+                    },
+                    "404": {
+                        "description": "Не найдено"
+                    },
+                    "409": {
+                        "description": "Реставрация уже завершена"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/employee/artworks/{id}/condition-reports/{reportID}/photos": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет существующую коллекцию",
+                "description": "Загружает фотографию (jpeg, png, webp, до 10 МБ), миниатюры не создаются",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Коллекции"
+                    "Экспонаты"
                 ],
-                "summary": "Обновить коллекцию (сотрудник)",
+                "summary": "Приложить фотографию к отчету о состоянии (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -688,44 +974,64 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления коллекции",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.UpdateCollectionRequest"
-                        }
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID отчета",
+                        "name": "reportID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл фотографии",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Коллекция обновлена"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConditionPhotoResponse"
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверный формат файла"
                     },
                     "404": {
-                        "description": "Коллекция не найдена"
+                        "description": "Не найдено"
+                    },
+                    "409": {
+                        "description": "Превышено количество фотографий"
+                    },
+                    "413": {
+                        "description": "Файл слишком большой"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/employee/artworks/{id}/history": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новую коллекцию",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает версии произведения в порядке возрастания с изменившимися полями, автором и временем изменения.\nИстория сохраняется и после удаления произведения.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Коллекции"
+                    "Экспонаты"
                 ],
-                "summary": "Добавить новую коллекцию (сотрудник)",
+                "summary": "Получить историю изменений произведения (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -735,41 +1041,44 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные коллекции",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.AddCollectionRequest"
-                        }
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Коллекция создана"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ChangeRecordResponse"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверный ID"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/employee/artworks/{id}/history/{version}/revert": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет существующую коллекцию",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Восстанавливает значения полей произведения из указанной версии, версия 0 - состояние до первого изменения.\nВозврат сохраняется в истории как новая версия.\nАвтор и коллекция произведения не меняются.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Коллекции"
+                    "Экспонаты"
                 ],
-                "summary": "Удалить коллекцию (сотрудник)",
+                "summary": "Вернуть произведение к версии из истории (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -779,50 +1088,62 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для удаления коллекции",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.DeleteCollectionRequest"
-                        }
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Коллекция удалена"
+                        "description": "Успешно восстановлено"
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверный ID или номер версии"
                     },
                     "404": {
-                        "description": "Коллекция не найдена"
+                        "description": "Произведение или версия не найдены"
                     }
                 }
             }
         },
-        "/employee/events": {
+        "/employee/artworks/{id}/images": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех мероприятий",
+                "description": "Возвращает изображения произведения в порядке показа с адресами миниатюр",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Получить все мероприятия (сотрудник)",
+                "summary": "Получить изображения произведения (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer токен",
+                        "description": "bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -831,66 +1152,88 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                                "$ref": "#/definitions/jsonreqresp.ArtworkImageResponse"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет существующее мероприятие",
+                "description": "Загружает изображение (jpeg, png, webp, до 10 МБ) и генерирует миниатюры.\nИзображение добавляется в конец списка, первое изображение становится основным.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Обновить мероприятие (сотрудник)",
+                "summary": "Загрузить изображение произведения (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer токен",
+                        "description": "bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления мероприятия",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.UpdateEventRequest"
-                        }
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Мероприятие успешно обновлено"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ArtworkImageResponse"
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации"
+                        "description": "Неверный формат файла"
                     },
                     "404": {
-                        "description": "Не найдено - мероприятие не найдено"
+                        "description": "Произведение не найдено"
+                    },
+                    "409": {
+                        "description": "Превышено количество изображений"
+                    },
+                    "413": {
+                        "description": "Файл слишком большой"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/employee/artworks/{id}/images/order": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новое мероприятие",
+                "description": "Задает порядок показа изображений, в списке должны быть все изображения произведения",
                 "consumes": [
                     "application/json"
                 ],
@@ -898,208 +1241,205 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Добавить новое мероприятие (сотрудник)",
+                "summary": "Изменить порядок изображений (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer токен",
+                        "description": "bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Данные мероприятия",
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID изображений в новом порядке",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonreqresp.AddEventRequest"
+                            "$ref": "#/definitions/jsonreqresp.ReorderArtworkImagesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Мероприятие успешно создано"
+                    "200": {
+                        "description": "Порядок изменен"
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации"
-                    },
-                    "401": {
-                        "description": "Не авторизован"
+                        "description": "Неверные входные параметры"
                     },
                     "404": {
-                        "description": "Не найдено - сотрудник не найден"
+                        "description": "Не найдено"
                     }
                 }
-            },
+            }
+        },
+        "/employee/artworks/{id}/images/{imageID}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет существующее мероприятие",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Удаляет изображение и его миниатюры, оставшиеся изображения перенумеровываются",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Удалить мероприятие (сотрудник)",
+                "summary": "Удалить изображение произведения (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer токен",
+                        "description": "bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Данные для удаления мероприятия",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.DeleteEventRequest"
-                        }
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID изображения",
+                        "name": "imageID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Мероприятие успешно удалено"
+                        "description": "Изображение удалено"
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации"
+                        "description": "Неверный ID"
                     },
                     "404": {
-                        "description": "Не найдено - мероприятие не найдено"
+                        "description": "Не найдено"
                     }
                 }
             }
         },
-        "/employee/events/{id}": {
+        "/employee/artworks/{id}/images/{imageID}/primary": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет произведение к существующему мероприятию",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Основное изображение показывается в списках и на странице произведения",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Добавить произведение к мероприятию (сотрудник)",
+                "summary": "Сделать изображение основным (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer токен",
+                        "description": "bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID мероприятия",
+                        "description": "ID произведения",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для связи произведения с мероприятием",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.ConArtworkEventRequest"
-                        }
+                        "type": "string",
+                        "description": "ID изображения",
+                        "name": "imageID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Произведение успешно добавлено к мероприятию"
+                        "description": "Основное изображение изменено"
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации или дублирование произведения"
+                        "description": "Неверный ID"
                     },
                     "404": {
-                        "description": "Не найдено - мероприятие или произведение не найдено"
+                        "description": "Не найдено"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/employee/artworks/{id}/provenance": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет произведение из существующего мероприятия",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает все записи провенанса, включая скрытые от посетителей, в хронологическом порядке",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Удалить произведение из мероприятия (сотрудник)",
+                "summary": "Получить историю владения произведением (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer токен",
+                        "description": "bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID мероприятия",
+                        "description": "ID произведения",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для связи произведения с мероприятием",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.ConArtworkEventRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Произведение успешно удалено из мероприятия"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ProvenanceEntryResponse"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации"
+                        "description": "Неверный ID"
                     },
                     "404": {
-                        "description": "Не найдено - мероприятие или произведение не найдено"
+                        "description": "Произведение не найдено"
                     }
                 }
-            }
-        },
-        "/employee/events/{id}/artworks": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех произведений данного мероприятия",
+                "description": "Добавляет владельца в конец истории владения.\nperiodFrom/periodTo - годы, 0 - неизвестен; dateQualifier задает точность датировки,\nownerUncertain отмечает предполагаемого владельца.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1107,9 +1447,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Мероприятия"
+                    "Экспонаты"
                 ],
-                "summary": "Получить все произведения мероприятия (сотрудник)",
+                "summary": "Добавить запись провенанса (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1120,34 +1460,48 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID мероприятия",
+                        "description": "ID произведения",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Запись провенанса",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ProvenanceEntryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
-                            }
+                            "$ref": "#/definitions/jsonreqresp.ProvenanceEntryResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации"
+                        "description": "Неверные входные параметры"
                     },
                     "404": {
-                        "description": "Не найдено - мероприятие или произведение не найдено"
+                        "description": "Произведение не найдено"
+                    },
+                    "409": {
+                        "description": "Превышено количество записей"
                     }
                 }
             }
         },
-        "/guest/tickets": {
-            "post": {
-                "description": "Покупка билетов на указанное мероприятие",
+        "/employee/artworks/{id}/provenance/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Задает хронологический порядок владельцев, в списке должны быть все записи произведения",
                 "consumes": [
                     "application/json"
                 ],
@@ -1155,48 +1509,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Билеты"
+                    "Экспонаты"
                 ],
-                "summary": "Покупка билетов",
+                "summary": "Изменить порядок записей провенанса (сотрудник)",
                 "parameters": [
                     {
-                        "description": "Данные для покупки билетов",
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID записей в новом порядке",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonreqresp.BuyTicketRequest"
+                            "$ref": "#/definitions/jsonreqresp.ReorderProvenanceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные покупки сохраняются в cookie",
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.TxTicketPurchaseResponse"
-                        }
+                        "description": "Порядок изменен"
                     },
                     "400": {
-                        "description": "Неверный формат запроса"
-                    },
-                    "401": {
-                        "description": "Не авторизован"
+                        "description": "Неверные входные параметры"
                     },
                     "404": {
-                        "description": "Мероприятие не найдено"
-                    },
-                    "409": {
-                        "description": "Нет доступных билетов"
-                    },
-                    "410": {
-                        "description": "Транзакция просрочена"
+                        "description": "Не найдено"
                     }
                 }
             }
         },
-        "/guest/tickets/cancel": {
+        "/employee/artworks/{id}/provenance/{entryID}": {
             "put": {
-                "description": "Отменяет ожидающую транзакцию покупки билетов",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заменяет поля записи, позиция в истории владения не меняется",
                 "consumes": [
                     "application/json"
                 ],
@@ -1204,79 +1565,114 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Билеты"
+                    "Экспонаты"
                 ],
-                "summary": "Отменить покупку",
+                "summary": "Изменить запись провенанса (сотрудник)",
                 "parameters": [
                     {
-                        "description": "ID транзакции",
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID записи",
+                        "name": "entryID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Запись провенанса",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/jsonreqresp.ConfirmCancelTxRequest"
+                            "$ref": "#/definitions/jsonreqresp.ProvenanceEntryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Покупка отменена"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ProvenanceEntryResponse"
+                        }
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверные входные параметры"
                     },
                     "404": {
-                        "description": "Транзакция не найдена"
-                    },
-                    "410": {
-                        "description": "Транзакция просрочена"
+                        "description": "Не найдено"
                     }
                 }
-            }
-        },
-        "/guest/tickets/confirm": {
-            "put": {
-                "description": "Подтверждает ожидающую транзакцию покупки билетов",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Удаляет запись, оставшиеся записи перенумеровываются",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Билеты"
+                    "Экспонаты"
                 ],
-                "summary": "Подтвердить покупку",
+                "summary": "Удалить запись провенанса (сотрудник)",
                 "parameters": [
                     {
-                        "description": "ID транзакции",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.ConfirmCancelTxRequest"
-                        }
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID записи",
+                        "name": "entryID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Покупка подтверждена"
+                        "description": "Запись удалена"
                     },
                     "400": {
-                        "description": "Неверный запрос"
+                        "description": "Неверный ID"
                     },
                     "404": {
-                        "description": "Транзакция не найдена"
-                    },
-                    "410": {
-                        "description": "Транзакция просрочена"
+                        "description": "Не найдено"
                     }
                 }
             }
         },
-        "/museum/artworks": {
-            "get": {
-                "description": "Возвращает список всех произведений с возможностью фильтрации",
+        "/employee/artworks/{id}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заменяет теги произведения переданным набором, пустой список снимает все теги",
                 "consumes": [
                     "application/json"
                 ],
@@ -1284,59 +1680,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "Теги"
                 ],
-                "summary": "Получить произведения",
+                "summary": "Задать теги произведения (сотрудник)",
                 "parameters": [
                     {
-                        "maxLength": 255,
                         "type": "string",
-                        "description": "Фильтр по названию произведения (макс. 255 символов)",
-                        "name": "title",
-                        "in": "query"
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "maxLength": 100,
                         "type": "string",
-                        "description": "Фильтр по имени автора (макс. 100 символов)",
-                        "name": "author_name",
-                        "in": "query"
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "maxLength": 255,
-                        "type": "string",
-                        "description": "Фильтр по названию коллекции (макс. 255 символов)",
-                        "name": "collection_title",
-                        "in": "query"
+                        "description": "ID тегов",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ArtworkTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Теги сохранены"
                     },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Фильтр по ID мероприятия",
-                        "name": "event_id",
-                        "in": "query"
+                    "400": {
+                        "description": "Неверные входные параметры"
                     },
+                    "404": {
+                        "description": "Произведение или тег не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/authors": {
+            "get": {
+                "security": [
                     {
-                        "enum": [
-                            "title",
-                            "author_name",
-                            "creationYear"
-                        ],
-                        "type": "string",
-                        "description": "Поле для сортировки",
-                        "name": "sort_field",
-                        "in": "query",
-                        "required": true
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех авторов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Получить всех авторов (сотрудник)",
+                "parameters": [
                     {
-                        "enum": [
-                            "ASC",
-                            "DESC"
-                        ],
                         "type": "string",
-                        "description": "Направление сортировки",
-                        "name": "direction_sort",
-                        "in": "query",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -1346,16 +1751,19 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                                "$ref": "#/definitions/jsonreqresp.AuthorResponse"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/museum/events": {
-            "get": {
-                "description": "Возвращает список всех мероприятий с возможностью фильтрации",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет данные существующего автора",
                 "consumes": [
                     "application/json"
                 ],
@@ -1363,57 +1771,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "Авторы"
                 ],
-                "summary": "Получить мероприятия",
+                "summary": "Обновить автора (сотрудник)",
                 "parameters": [
-                    {
-                        "maxLength": 255,
-                        "type": "string",
-                        "description": "Фильтр по названию мероприятия",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "format": "date",
-                        "description": "Фильтр по минимальной дате начала (формат: ГГГГ-ММ-ДД)",
-                        "name": "date_begin",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "format": "date",
-                        "description": "Фильтр по максимальной дате окончания (формат: ГГГГ-ММ-ДД)",
-                        "name": "date_end",
-                        "in": "query"
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Фильтр по доступности для посещения",
-                        "name": "can_visit",
-                        "in": "query"
+                        "description": "Данные для обновления автора",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UpdateAuthorRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/jsonreqresp.EventResponse"
-                            }
-                        }
+                        "description": "Успешно обновлено"
                     },
                     "400": {
-                        "description": "Неверный формат даты. Используйте ГГГГ-ММ-ДД"
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Автор не найден"
                     }
                 }
-            }
-        },
-        "/museum/events/{id}": {
-            "get": {
-                "description": "Возвращает одно мероприятие по его идентификатору",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает нового автора",
                 "consumes": [
                     "application/json"
                 ],
@@ -1421,37 +1818,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "Авторы"
                 ],
-                "summary": "Получить мероприятие по ID",
+                "summary": "Добавить нового автора (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID мероприятия",
-                        "name": "id",
-                        "in": "path",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Данные автора",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AddAuthorRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/jsonreqresp.EventResponse"
-                        }
+                    "201": {
+                        "description": "Автор создан"
                     },
                     "400": {
-                        "description": "Неверный формат ID"
-                    },
-                    "404": {
-                        "description": "Мероприятие не найдено"
+                        "description": "Неверный запрос"
                     }
                 }
-            }
-        },
-        "/museum/events/{id}/artworks": {
-            "get": {
-                "description": "Возвращает список всех произведений данного мероприятия",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Переносит автора в корзину, откуда его можно восстановить до окончательной очистки.\nАвтора, указанного у произведений вне корзины, удалить нельзя - произведения возвращаются в ответе 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1459,56 +1862,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "Авторы"
                 ],
-                "summary": "Получить все произведения мероприятия",
+                "summary": "Удалить автора (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID мероприятия",
-                        "name": "id",
-                        "in": "path",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Данные для удаления автора",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DeleteAuthorRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
-                            }
-                        }
+                        "description": "Успешно удалено"
                     },
                     "400": {
-                        "description": "Неверный запрос - ошибка валидации"
+                        "description": "Неверный запрос"
                     },
                     "404": {
-                        "description": "Не найдено - мероприятие или произведение не найдено"
+                        "description": "Автор не найден"
+                    },
+                    "409": {
+                        "description": "У автора есть связанные произведения",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DependencyErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/museum/events/{id}/statcols": {
+        "/employee/authors/duplicates": {
             "get": {
-                "description": "Возвращает список коллекиций произведения искусства из которых участвуют в выставке",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Сравнивает имена авторов без учета регистра, порядка слов, отчеств и знаков препинания,\nинициалы совпадают с полными именами, кириллица сравнивается с латиницей через транслитерацию.\nГоды жизни дублей могут различаться не больше чем на 2 года, неизвестный год смерти совместим с любым.\nПары возвращаются для проверки в порядке убывания сходства",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "Авторы"
                 ],
-                "summary": "Получить статистику по коллекциям для мероприятия",
+                "summary": "Найти вероятные дубли авторов (сотрудник)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID мероприятия",
-                        "name": "id",
-                        "in": "path",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -1518,474 +1932,6761 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/jsonreqresp.StatCollectionsResponse"
+                                "$ref": "#/definitions/jsonreqresp.AuthorDuplicateResponse"
                             }
                         }
-                    },
-                    "400": {
-                        "description": "Неверный запрос - ошибка валидации"
-                    },
-                    "404": {
-                        "description": "Не найдено - мероприятие или произведение не найдено"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.LoginAdminRequest": {
-            "type": "object",
-            "required": [
-                "login",
-                "password"
-            ],
-            "properties": {
-                "login": {
-                    "type": "string",
-                    "maxLength": 50,
+        },
+        "/employee/authors/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Передает основному автору все произведения дубля, в том числе те, где дубль дополнительный автор,\nи удаляет дубль в одной транзакции. Объединение сохраняется в истории изменений обоих авторов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Объединить дубль с автором (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Основной автор и дубль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.MergeAuthorsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.MergeAuthorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Автор не найден"
+                    },
+                    "500": {
+                        "description": "Датировка произведений дубля не соответствует годам жизни основного автора"
+                    }
+                }
+            }
+        },
+        "/employee/authors/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает автора вместе с биографией, направлениями, портретом и внешними идентификаторами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Получить автора с профилем (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AuthorProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    },
+                    "404": {
+                        "description": "Автор не найден"
+                    }
+                }
+            }
+        },
+        "/employee/authors/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает версии автора в порядке возрастания с изменившимися полями, автором и временем изменения.\nИстория сохраняется и после удаления автора.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Получить историю изменений автора (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ChangeRecordResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    }
+                }
+            }
+        },
+        "/employee/authors/{id}/history/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Восстанавливает значения полей автора из указанной версии, версия 0 - состояние до первого изменения.\nВозврат сохраняется в истории как новая версия.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Вернуть автора к версии из истории (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно восстановлено"
+                    },
+                    "400": {
+                        "description": "Неверный ID или номер версии"
+                    },
+                    "404": {
+                        "description": "Автор или версия не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/authors/{id}/portrait": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Загружает портрет (jpeg, png, webp, до 5 МБ) и заменяет прежний",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Загрузить портрет автора (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл портрета",
+                        "name": "portrait",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AuthorProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат файла"
+                    },
+                    "404": {
+                        "description": "Автор не найден"
+                    },
+                    "413": {
+                        "description": "Файл слишком большой"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Удалить портрет автора (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Портрет удален"
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    },
+                    "404": {
+                        "description": "Автор не найден"
+                    }
+                }
+            }
+        },
+        "/employee/authors/{id}/profile": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заменяет профиль автора целиком: биографию, национальность, направления, места рождения и смерти,\nидентификаторы ULAN (9 цифр, начиная с 500) и Wikidata (Q и цифры). Пустое поле очищает значение.\nИзменение сохраняется в истории автора",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Авторы"
+                ],
+                "summary": "Изменить профиль автора (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Профиль автора",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AuthorProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AuthorProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Автор не найден"
+                    }
+                }
+            }
+        },
+        "/employee/collections": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех коллекций",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Получить все коллекции (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.CollectionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет название коллекции и переносит ее в родительскую коллекцию parentId, без parentId коллекция становится корневой.\nКоллекцию нельзя вложить в саму себя или в ее вложенную коллекцию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Обновить коллекцию (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления коллекции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UpdateCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Коллекция обновлена"
+                    },
+                    "400": {
+                        "description": "Неверный запрос или родительская коллекция"
+                    },
+                    "404": {
+                        "description": "Коллекция не найдена"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую коллекцию, вложенную в parentId или корневую.\nГлубина вложенности коллекций - не более 5 уровней.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Добавить новую коллекцию (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные коллекции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AddCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Коллекция создана"
+                    },
+                    "400": {
+                        "description": "Неверный запрос или родительская коллекция"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Переносит коллекцию в корзину, откуда её можно восстановить до окончательной очистки.\nКоллекцию, в которой есть произведения или вложенные коллекции вне корзины, удалить нельзя - они возвращаются в ответе 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Удалить коллекцию (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для удаления коллекции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DeleteCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Коллекция удалена"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Коллекция не найдена"
+                    },
+                    "409": {
+                        "description": "В коллекции есть произведения или вложенные коллекции",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DependencyErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employee/collections/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает версии коллекции в порядке возрастания с изменившимися полями, автором и временем изменения.\nИстория сохраняется и после удаления коллекции.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Получить историю изменений коллекции (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ChangeRecordResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    }
+                }
+            }
+        },
+        "/employee/collections/{id}/history/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Восстанавливает значения полей коллекции из указанной версии, версия 0 - состояние до первого изменения.\nВозврат сохраняется в истории как новая версия.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Вернуть коллекцию к версии из истории (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно восстановлено"
+                    },
+                    "400": {
+                        "description": "Неверный ID или номер версии"
+                    },
+                    "404": {
+                        "description": "Коллекция или версия не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/collections/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает число произведений коллекции по авторам, векам и техникам,\nа также мероприятия, в которых участвовали произведения коллекции. Произведения в корзине не учитываются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Коллекции"
+                ],
+                "summary": "Получить статистику коллекции (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать произведения вложенных коллекций",
+                        "name": "subcollections",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.CollectionStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или параметр"
+                    },
+                    "404": {
+                        "description": "Коллекция не найдена"
+                    }
+                }
+            }
+        },
+        "/employee/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех мероприятий",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Получить все мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет существующее мероприятие. Одобренное мероприятие, измененное сотрудником, возвращается на повторное согласование",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Обновить мероприятие (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления мероприятия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UpdateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Мероприятие успешно обновлено"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем или соорганизатором"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новое мероприятие",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Добавить новое мероприятие (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные мероприятия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.AddEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Мероприятие успешно создано"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Сотрудник может создавать только от своего имени"
+                    },
+                    "404": {
+                        "description": "Не найдено - сотрудник не найден"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет существующее мероприятие",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Удалить мероприятие (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для удаления мероприятия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DeleteEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Мероприятие успешно удалено"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем или соорганизатором"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    }
+                }
+            }
+        },
+        "/employee/events/templates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех шаблонов мероприятий",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Получить шаблоны мероприятий (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventTemplateResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/employee/events/templates/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новое мероприятие по шаблону на указанные даты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Создать мероприятие по шаблону (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Даты и (необязательно) название",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.CloneEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.EventIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации или произведение занято"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Сотрудник может создавать только от своего имени"
+                    },
+                    "404": {
+                        "description": "Не найдено - шаблон или сотрудник не найдены"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет шаблон мероприятия",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Удалить шаблон мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Шаблон успешно удален"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID"
+                    },
+                    "404": {
+                        "description": "Не найдено - шаблон не найден"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет произведение к существующему мероприятию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Добавить произведение к мероприятию (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для связи произведения с мероприятием",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConArtworkEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Произведение успешно добавлено к мероприятию"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации, дублирование произведения или произведение на реставрации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем или соорганизатором"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или произведение не найдено"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет произведение из существующего мероприятия",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Удалить произведение из мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для связи произведения с мероприятием",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConArtworkEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Произведение успешно удалено из мероприятия"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем или соорганизатором"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/artworks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех произведений данного мероприятия",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Получить все произведения мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новое мероприятие с параметрами и произведениями исходного на новые даты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Клонировать мероприятие (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID исходного мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые даты и (необязательно) название",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.CloneEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.EventIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации или произведение занято"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Сотрудник может создавать только от своего имени"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или сотрудник не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает версии мероприятия в порядке возрастания с изменившимися полями, автором и временем изменения.\nИстория сохраняется и после удаления мероприятия.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Получить историю изменений мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ChangeRecordResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/history/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Восстанавливает значения полей мероприятия из указанной версии, версия 0 - состояние до первого изменения.\nВозврат сохраняется в истории как новая версия.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Вернуть мероприятие к версии из истории (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно восстановлено"
+                    },
+                    "400": {
+                        "description": "Неверный ID или номер версии"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем или соорганизатором"
+                    },
+                    "404": {
+                        "description": "Мероприятие или версия не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/organisers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает ID сотрудников, которым создатель разрешил изменять мероприятие",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Получить соорганизаторов мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.EventOrganisersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Разрешает сотруднику изменять мероприятие. Доступно создателю мероприятия и администратору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Добавить соорганизатора мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID сотрудника",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.EventOrganiserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Соорганизатор успешно добавлен"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации или сотрудник уже организует мероприятие"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем мероприятия"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или сотрудник не найдены"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отзывает у сотрудника право изменять мероприятие. Доступно создателю мероприятия и администратору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Удалить соорганизатора мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID сотрудника",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.EventOrganiserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Соорганизатор успешно удален"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем мероприятия"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или соорганизатор не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает смены статуса мероприятия в хронологическом порядке",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Получить историю согласования мероприятия (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventReviewResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Переводит черновик или отклоненное мероприятие в статус pending. До одобрения администратором мероприятие не видно посетителям",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Отправить мероприятие на согласование (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий для администратора",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ReviewEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Мероприятие отправлено на согласование"
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Нет прав - сотрудник не является создателем или соорганизатором"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие не найдено"
+                    },
+                    "409": {
+                        "description": "Мероприятие уже на согласовании или одобрено"
+                    }
+                }
+            }
+        },
+        "/employee/events/{id}/template": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Сохраняет название, адрес, доступность, количество билетов и произведения мероприятия как шаблон",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Мероприятия"
+                ],
+                "summary": "Сохранить мероприятие как шаблон (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название шаблона",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.SaveEventTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.EventIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "403": {
+                        "description": "Сотрудник может создавать только от своего имени"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или сотрудник не найдены"
+                    }
+                }
+            }
+        },
+        "/employee/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Выгружает произведения, подходящие под фильтр, вместе с авторами, коллекциями и согласованными\nмероприятиями. Форматы: csv, jsonl (jsonreqresp.ExportArtworkResponse в каждой строке),\ndc (Dublin Core XML) и lido (LIDO 1.0 XML). Ответ передается потоком по мере чтения каталога.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/xml"
+                ],
+                "tags": [
+                    "Экспорт"
+                ],
+                "summary": "Выгрузка каталога (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "dc",
+                            "lido"
+                        ],
+                        "type": "string",
+                        "description": "Формат выгрузки",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Полнотекстовый поиск (макс. 255 символов)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию произведения (макс. 255 символов)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "Фильтр по имени автора (макс. 100 символов)",
+                        "name": "author_name",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию коллекции (макс. 255 символов)",
+                        "name": "collection_title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID мероприятия",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Техника (можно несколько)",
+                        "name": "technic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Материал (можно несколько)",
+                        "name": "material",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Век создания (можно несколько)",
+                        "name": "century",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID автора (можно несколько)",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID коллекции (можно несколько)",
+                        "name": "collection_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать вложенные коллекции выбранных коллекций",
+                        "name": "subcollections",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID тега, включая дочерние теги (можно несколько)",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Год создания не раньше",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Год создания не позже",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Высота не меньше, см",
+                        "name": "height_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Высота не больше, см",
+                        "name": "height_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Ширина не меньше, см",
+                        "name": "width_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Ширина не больше, см",
+                        "name": "width_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Глубина не меньше, см",
+                        "name": "depth_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Глубина не больше, см",
+                        "name": "depth_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Вес не меньше, кг",
+                        "name": "weight_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Вес не больше, кг",
+                        "name": "weight_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл выгрузки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат или параметры фильтрации"
+                    }
+                }
+            }
+        },
+        "/employee/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Загружает авторов, коллекции и произведения. JSON-файл содержит все разделы (jsonreqresp.CatalogImportRequest),\nCSV-файл - строки одного раздела entity с заголовком из имен полей JSON.\nПроизведения ссылаются на авторов и коллекции по id или имени (названию); существующие авторы\nи коллекции с тем же id или именем не создаются повторно.\nВсе строки проверяются, ошибки возвращаются по номерам строк. Записи сохраняются, только если ошибок нет\nи не задан dry_run.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Импорт"
+                ],
+                "summary": "Импорт каталога из CSV или JSON (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл импорта",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла, по умолчанию - по расширению",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "authors",
+                            "collections",
+                            "artworks"
+                        ],
+                        "type": "string",
+                        "description": "Раздел CSV-файла",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить, не сохраняя записи",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ImportReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный файл или параметры"
+                    },
+                    "413": {
+                        "description": "Файл слишком большой"
+                    }
+                }
+            }
+        },
+        "/employee/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает все теги словаря; с параметром term - только теги, в название или синонимы которых входит термин",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Получить словарь тегов (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Термин для поиска по названию и синонимам",
+                        "name": "term",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.TagResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменяет название, вид, родителя и синонимы тега. Тег нельзя переместить внутрь его потомков",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Обновить тег (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления тега",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UpdateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тег обновлен"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Тег не найден"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает тег вида subject (сюжет), movement (направление) или keyword (ключевое слово).\nРодительский тег должен быть того же вида, глубина иерархии - не более 5 уровней.\nНазвание и синонимы не должны совпадать с терминами других тегов того же вида",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Добавить тег в словарь (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные тега",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID созданного тега",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет тег и снимает его с произведений. Тег с дочерними тегами удалить нельзя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Удалить тег (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для удаления тега",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DeleteTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тег удален"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Тег не найден"
+                    },
+                    "409": {
+                        "description": "У тега есть дочерние теги"
+                    }
+                }
+            }
+        },
+        "/employee/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает удалённые произведения, авторов и коллекции от недавно удалённых к давним.\npurgeAt - время, после которого запись будет удалена окончательно.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Корзина"
+                ],
+                "summary": "Получить содержимое корзины (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "artwork",
+                            "author",
+                            "collection"
+                        ],
+                        "type": "string",
+                        "description": "Тип записей",
+                        "name": "entityType",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.TrashItemResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный тип записей"
+                    }
+                }
+            }
+        },
+        "/employee/trash/purge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Окончательно удаляет записи, которые пробыли в корзине дольше срока хранения, не дожидаясь плановой очистки.\nАвторы и коллекции удаляются, только если на них не ссылаются произведения из корзины.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Корзина"
+                ],
+                "summary": "Очистить корзину (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.PurgeTrashResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employee/trash/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает произведение, автора или коллекцию из корзины.\nПроизведение нельзя восстановить, пока его автор или коллекция в корзине - они возвращаются в ответе 409 с inTrash = true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Корзина"
+                ],
+                "summary": "Восстановить запись из корзины (сотрудник)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Запись для восстановления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.RestoreTrashItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Запись восстановлена"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Записи нет в корзине"
+                    },
+                    "409": {
+                        "description": "Связанные записи в корзине",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.DependencyErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/tickets": {
+            "post": {
+                "description": "Покупка билетов на указанное мероприятие",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Билеты"
+                ],
+                "summary": "Покупка билетов",
+                "parameters": [
+                    {
+                        "description": "Данные для покупки билетов",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.BuyTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные покупки сохраняются в cookie",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.TxTicketPurchaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса"
+                    },
+                    "401": {
+                        "description": "Не авторизован"
+                    },
+                    "404": {
+                        "description": "Мероприятие не найдено"
+                    },
+                    "409": {
+                        "description": "Нет доступных билетов или мероприятие не одобрено к продаже"
+                    },
+                    "410": {
+                        "description": "Транзакция просрочена"
+                    }
+                }
+            }
+        },
+        "/guest/tickets/cancel": {
+            "put": {
+                "description": "Отменяет ожидающую транзакцию покупки билетов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Билеты"
+                ],
+                "summary": "Отменить покупку",
+                "parameters": [
+                    {
+                        "description": "ID транзакции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConfirmCancelTxRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Покупка отменена"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Транзакция не найдена"
+                    },
+                    "410": {
+                        "description": "Транзакция просрочена"
+                    }
+                }
+            }
+        },
+        "/guest/tickets/confirm": {
+            "put": {
+                "description": "Подтверждает ожидающую транзакцию покупки билетов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Билеты"
+                ],
+                "summary": "Подтвердить покупку",
+                "parameters": [
+                    {
+                        "description": "ID транзакции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ConfirmCancelTxRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Покупка подтверждена"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Транзакция не найдена"
+                    },
+                    "410": {
+                        "description": "Транзакция просрочена"
+                    }
+                }
+            }
+        },
+        "/iiif/artworks/{id}/manifest": {
+            "get": {
+                "description": "Возвращает манифест IIIF Presentation 3.0: метаданные произведения и холст на каждое изображение.\nУ произведения без изображений один пустой холст",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IIIF"
+                ],
+                "summary": "Манифест IIIF произведения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.IIIFManifest"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/iiif/collections": {
+            "get": {
+                "description": "Возвращает коллекцию IIIF верхнего уровня со ссылками на коллекции IIIF всех коллекций музея",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IIIF"
+                ],
+                "summary": "Коллекции музея в IIIF",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.IIIFCollection"
+                        }
+                    }
+                }
+            }
+        },
+        "/iiif/collections/{id}": {
+            "get": {
+                "description": "Возвращает коллекцию IIIF Presentation 3.0 со ссылками на манифесты всех произведений коллекции",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IIIF"
+                ],
+                "summary": "Коллекция IIIF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.IIIFCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    },
+                    "404": {
+                        "description": "Коллекция не найдена"
+                    }
+                }
+            }
+        },
+        "/museum/artworks": {
+            "get": {
+                "description": "Возвращает список всех произведений с возможностью фильтрации.\nПараметр q - полнотекстовый поиск с учетом морфологии и опечаток, результаты содержат поле highlight\nи по умолчанию упорядочены по релевантности.\nФильтры по размерам (см) и весу (кг) отбирают только произведения со структурированным размером,\nпри сортировке по размеру произведения без него считаются нулевого размера.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить произведения",
+                "parameters": [
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Полнотекстовый поиск (макс. 255 символов)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию произведения (макс. 255 символов)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "Фильтр по имени автора (макс. 100 символов)",
+                        "name": "author_name",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию коллекции (макс. 255 символов)",
+                        "name": "collection_title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID мероприятия",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Техника (можно несколько)",
+                        "name": "technic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Материал (можно несколько)",
+                        "name": "material",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Век создания (можно несколько)",
+                        "name": "century",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID автора (можно несколько)",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID коллекции (можно несколько)",
+                        "name": "collection_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать вложенные коллекции выбранных коллекций",
+                        "name": "subcollections",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID тега, включая дочерние теги (можно несколько)",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Год создания не раньше",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Год создания не позже",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Высота не меньше, см",
+                        "name": "height_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Высота не больше, см",
+                        "name": "height_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Ширина не меньше, см",
+                        "name": "width_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Ширина не больше, см",
+                        "name": "width_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Глубина не меньше, см",
+                        "name": "depth_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Глубина не больше, см",
+                        "name": "depth_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Вес не меньше, кг",
+                        "name": "weight_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Вес не больше, кг",
+                        "name": "weight_max",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "author_name",
+                            "creationYear",
+                            "collection_title",
+                            "relevance",
+                            "height",
+                            "width",
+                            "depth",
+                            "weight"
+                        ],
+                        "type": "string",
+                        "description": "Поле для сортировки",
+                        "name": "sort_field",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "description": "Направление сортировки",
+                        "name": "direction_sort",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из заголовка X-Next-Cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Курсор следующей страницы (отсутствует на последней странице)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее число произведений, подходящих под фильтр (только для первой страницы)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный размер страницы, курсор, параметры фильтрации или слишком длинный запрос"
+                    }
+                }
+            }
+        },
+        "/museum/artworks/facets": {
+            "get": {
+                "description": "Возвращает значения фасетов (техника, материал, век, автор, коллекция) с количеством произведений\nи границы года создания. Принимает те же параметры фильтрации, что и /museum/artworks.\nКоличество для значения фасета считается с учетом всех фильтров, кроме фильтра этого же фасета.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить фасеты произведений",
+                "parameters": [
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Полнотекстовый поиск (макс. 255 символов)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию произведения",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "Фильтр по имени автора",
+                        "name": "author_name",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию коллекции",
+                        "name": "collection_title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID мероприятия",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Техника (можно несколько)",
+                        "name": "technic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Материал (можно несколько)",
+                        "name": "material",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Век создания (можно несколько)",
+                        "name": "century",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID автора (можно несколько)",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID коллекции (можно несколько)",
+                        "name": "collection_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать вложенные коллекции выбранных коллекций",
+                        "name": "subcollections",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ID тега, включая дочерние теги (можно несколько)",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Год создания не раньше",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Год создания не позже",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Высота не меньше, см",
+                        "name": "height_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Высота не больше, см",
+                        "name": "height_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Ширина не меньше, см",
+                        "name": "width_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Ширина не больше, см",
+                        "name": "width_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Глубина не меньше, см",
+                        "name": "depth_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Глубина не больше, см",
+                        "name": "depth_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Вес не меньше, кг",
+                        "name": "weight_min",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Вес не больше, кг",
+                        "name": "weight_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.ArtworkFacetsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры фильтрации"
+                    }
+                }
+            }
+        },
+        "/museum/artworks/{id}": {
+            "get": {
+                "description": "Возвращает одно произведение по его идентификатору.\nС заголовком Accept: application/ld+json возвращает schema.org VisualArtwork",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/ld+json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить произведение по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.SchemaOrgVisualArtwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/artworks/{id}/events": {
+            "get": {
+                "description": "Возвращает мероприятия, в которых участвовало произведение, по возрастанию даты начала",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "История выставок произведения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Начало диапазона дат (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_begin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Конец диапазона дат (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или даты"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/artworks/{id}/provenance": {
+            "get": {
+                "description": "Возвращает опубликованные записи провенанса в хронологическом порядке",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить историю владения произведением",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ProvenanceEntryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/artworks/{id}/similar": {
+            "get": {
+                "description": "Возвращает рекомендации «Вам может понравиться» с причинами: общий автор, коллекция,\nтехника, материал, близость датировки и совместное участие в мероприятиях.\nДля авторизованного посетителя учитываются его избранные произведения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить похожие произведения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Количество рекомендаций (по умолчанию 6, не более 24)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.SimilarArtworkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или лимит"
+                    },
+                    "404": {
+                        "description": "Произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/authors/{id}/events": {
+            "get": {
+                "description": "Возвращает мероприятия, в которых участвуют произведения автора, по возрастанию даты начала",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Мероприятия с работами автора",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Начало диапазона дат (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_begin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Конец диапазона дат (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или даты"
+                    }
+                }
+            }
+        },
+        "/museum/collections/{id}/events": {
+            "get": {
+                "description": "Возвращает мероприятия, в которых участвуют произведения коллекции, по возрастанию даты начала",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Мероприятия с работами из коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Начало диапазона дат (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_begin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Конец диапазона дат (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или даты"
+                    }
+                }
+            }
+        },
+        "/museum/events": {
+            "get": {
+                "description": "Возвращает список всех мероприятий с возможностью фильтрации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить мероприятия",
+                "parameters": [
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "Фильтр по названию мероприятия",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Фильтр по минимальной дате начала (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_begin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Фильтр по максимальной дате окончания (формат: ГГГГ-ММ-ДД)",
+                        "name": "date_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Фильтр по доступности для посещения",
+                        "name": "can_visit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "dateBegin",
+                            "dateEnd",
+                            "tickets_available"
+                        ],
+                        "type": "string",
+                        "description": "Поле для сортировки",
+                        "name": "sort_field",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "description": "Направление сортировки",
+                        "name": "direction_sort",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из заголовка X-Next-Cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.EventResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Курсор следующей страницы (отсутствует на последней странице)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее число мероприятий, подходящих под фильтр"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат даты, размер страницы или курсор"
+                    }
+                }
+            }
+        },
+        "/museum/events/{id}": {
+            "get": {
+                "description": "Возвращает одно мероприятие по его идентификатору.\nС заголовком Accept: application/ld+json возвращает schema.org ExhibitionEvent с предложением билетов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/ld+json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить мероприятие по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.SchemaOrgExhibitionEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID"
+                    },
+                    "404": {
+                        "description": "Мероприятие не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/events/{id}/artworks": {
+            "get": {
+                "description": "Возвращает список всех произведений данного мероприятия",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить все произведения мероприятия",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/events/{id}/statcols": {
+            "get": {
+                "description": "Возвращает список коллекиций произведения искусства из которых участвуют в выставке",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить статистику по коллекциям для мероприятия",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID мероприятия",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.StatCollectionsResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос - ошибка валидации"
+                    },
+                    "404": {
+                        "description": "Не найдено - мероприятие или произведение не найдено"
+                    }
+                }
+            }
+        },
+        "/museum/lists/{id}": {
+            "get": {
+                "description": "Возвращает публичную подборку пользователя по ссылке; личные подборки недоступны",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Получить публичную подборку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подборка не найдена"
+                    }
+                }
+            }
+        },
+        "/museum/tags": {
+            "get": {
+                "description": "Возвращает иерархию тегов словаря с числом отмеченных произведений, включая произведения дочерних тегов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить дерево тегов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.TagNodeResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/museum/tags/{id}": {
+            "get": {
+                "description": "Возвращает тег, цепочку его родителей от корня и дочерние теги с числом произведений.\nПроизведения тега - /museum/artworks?tag_id={id}",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить тег",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тега",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.TagPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID"
+                    },
+                    "404": {
+                        "description": "Тег не найден"
+                    }
+                }
+            }
+        },
+        "/oai": {
+            "get": {
+                "description": "Сбор каталога агрегаторами по протоколу OAI-PMH 2.0: Identify, ListMetadataFormats, ListSets,\nListIdentifiers, ListRecords и GetRecord в формате oai_dc. Набор соответствует коллекции,\nfrom и until отбирают произведения по времени последнего изменения.\nОшибки протокола возвращаются в ответе с кодом 200, аргументы POST передаются как форма",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "OAI-PMH"
+                ],
+                "summary": "Точка OAI-PMH 2.0",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Глагол OAI-PMH",
+                        "name": "verb",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи oai:\u003cдомен\u003e:\u003cID произведения\u003e",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат метаданных (oai_dc)",
+                        "name": "metadataPrefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Изменены не раньше (YYYY-MM-DD или YYYY-MM-DDThh:mm:ssZ)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Изменены не позже (YYYY-MM-DD или YYYY-MM-DDThh:mm:ssZ)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Набор - ID коллекции",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен продолжения списка",
+                        "name": "resumptionToken",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ответ OAI-PMH",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверная форма запроса"
+                    }
+                }
+            },
+            "post": {
+                "description": "Сбор каталога агрегаторами по протоколу OAI-PMH 2.0: Identify, ListMetadataFormats, ListSets,\nListIdentifiers, ListRecords и GetRecord в формате oai_dc. Набор соответствует коллекции,\nfrom и until отбирают произведения по времени последнего изменения.\nОшибки протокола возвращаются в ответе с кодом 200, аргументы POST передаются как форма",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "OAI-PMH"
+                ],
+                "summary": "Точка OAI-PMH 2.0",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Глагол OAI-PMH",
+                        "name": "verb",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи oai:\u003cдомен\u003e:\u003cID произведения\u003e",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат метаданных (oai_dc)",
+                        "name": "metadataPrefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Изменены не раньше (YYYY-MM-DD или YYYY-MM-DDThh:mm:ssZ)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Изменены не позже (YYYY-MM-DD или YYYY-MM-DDThh:mm:ssZ)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Набор - ID коллекции",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен продолжения списка",
+                        "name": "resumptionToken",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ответ OAI-PMH",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверная форма запроса"
+                    }
+                }
+            }
+        },
+        "/user/favourites": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает избранные произведения и мероприятия, сначала добавленные последними.\nУдаленные записи и скрытые от посетителей мероприятия не показываются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Получить избранное",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.FavouritesResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет произведение или одобренное мероприятие в избранное. Повторное добавление не ошибка",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Добавить в избранное",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Запись каталога",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.FavouriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Запись не найдена"
+                    }
+                }
+            }
+        },
+        "/user/favourites/{type}/{entityId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Убрать из избранного",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Вид записи: artwork или event",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения или мероприятия",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Записи нет в избранном"
+                    }
+                }
+            }
+        },
+        "/user/lists": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает подборки без элементов, недавно измененные - первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Получить подборки пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jsonreqresp.UserListSummaryResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает пустую подборку; у пользователя может быть не более 50 подборок",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Создать подборку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные подборки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    }
+                }
+            }
+        },
+        "/user/lists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Получить подборку пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подборка не найдена"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменяет название, описание и видимость подборки",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Изменить подборку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные подборки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Подборка не найдена"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Удалить подборку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Подборка не найдена"
+                    }
+                }
+            }
+        },
+        "/user/lists/{id}/items": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заменяет элементы подборки; порядок элементов в запросе - порядок показа. Не более 200 элементов",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Заменить элементы подборки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Элементы подборки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListItemsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Неверный запрос"
+                    },
+                    "404": {
+                        "description": "Подборка или запись не найдена"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет произведение или одобренное мероприятие в конец подборки",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Добавить элемент в подборку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Элемент подборки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jsonreqresp.UserListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Неверный запрос или запись уже в подборке"
+                    },
+                    "404": {
+                        "description": "Подборка или запись не найдена"
+                    }
+                }
+            }
+        },
+        "/user/lists/{id}/items/{type}/{entityId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Избранное и подборки"
+                ],
+                "summary": "Убрать элемент из подборки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Вид записи: artwork или event",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID произведения или мероприятия",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Подборка или элемент не найдены"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.LoginAdminRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string",
+                    "maxLength": 50,
                     "example": "admin"
                 },
-                "password": {
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "12345678"
+                }
+            }
+        },
+        "auth.LoginEmployeeRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 4,
+                    "example": "elogin"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 4,
+                    "example": "12345678"
+                }
+            }
+        },
+        "auth.RegisterEmployeeRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 4,
+                    "example": "elogin"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 4,
+                    "example": "12345678"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "ename"
+                }
+            }
+        },
+        "jsonreqresp.AddArtworkRequest": {
+            "type": "object",
+            "required": [
+                "authorID",
+                "collectionID",
+                "material",
+                "technic",
+                "title"
+            ],
+            "properties": {
+                "authorID": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "authorRole": {
+                    "description": "AuthorRole роль основного автора, по умолчанию author",
+                    "type": "string",
+                    "enum": [
+                        "author",
+                        "attributed",
+                        "workshop",
+                        "circle",
+                        "follower",
+                        "copy_after"
+                    ],
+                    "example": "author"
+                },
+                "coAuthors": {
+                    "description": "CoAuthors дополнительные авторы в порядке показа",
+                    "type": "array",
+                    "maxItems": 9,
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.AttributionRequest"
+                    }
+                },
+                "collectionID": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "creationYear": {
+                    "description": "CreationYear можно не передавать, если задана Dating; без Dating датировка - точный год",
+                    "type": "integer",
+                    "maximum": 2100,
+                    "example": 1889
+                },
+                "dating": {
+                    "$ref": "#/definitions/jsonreqresp.ArtworkDatingRequest"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/jsonreqresp.DimensionsRequest"
+                },
+                "material": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Холст, масляные краски"
+                },
+                "size": {
+                    "description": "Size можно не передавать, если заданы Dimensions; без Dimensions размеры разбираются из Size",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "73.7 × 92.1 см"
+                },
+                "technic": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Масло, холст"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Звёздная ночь"
+                }
+            }
+        },
+        "jsonreqresp.AddAuthorRequest": {
+            "type": "object",
+            "required": [
+                "birthYear",
+                "name"
+            ],
+            "properties": {
+                "birthYear": {
+                    "description": "Обязательное, \u003e= 1000",
+                    "type": "integer",
+                    "minimum": 1000,
+                    "example": 1853
+                },
+                "deathYear": {
+                    "description": "Опциональное, \u003e= BirthYear",
+                    "type": "integer",
+                    "example": 1890
+                },
+                "name": {
+                    "description": "Обязательное, 2-100 символов",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Винсент Ван Гог"
+                }
+            }
+        },
+        "jsonreqresp.AddCollectionRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "parentId": {
+                    "description": "Опциональное, родительская коллекция",
+                    "type": "string",
+                    "example": "bb1e8400-e29b-41d4-a716-446655442222"
+                },
+                "title": {
+                    "description": "Обязательное, 2-255 символов",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2,
+                    "example": "Музей современного искусства"
+                }
+            }
+        },
+        "jsonreqresp.AddEventRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "canVisit",
+                "cntTickets",
+                "dateBegin",
+                "dateEnd",
+                "title"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "ул. Пречистенка, 12/2"
+                },
+                "artworkIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "canVisit": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cntTickets": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "dateBegin": {
+                    "type": "string",
+                    "example": "2023-06-15T10:00:00Z"
+                },
+                "dateEnd": {
+                    "type": "string",
+                    "example": "2023-09-20T18:00:00Z"
+                },
+                "employeeID": {
+                    "description": "EmployeeID создатель; указывается администратором, сотрудник создает только от своего имени",
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Ночная выставка"
+                }
+            }
+        },
+        "jsonreqresp.ArtworkDatingRequest": {
+            "type": "object",
+            "required": [
+                "yearFrom"
+            ],
+            "properties": {
+                "precision": {
+                    "type": "string",
+                    "enum": [
+                        "year",
+                        "decade",
+                        "century"
+                    ],
+                    "example": "year"
+                },
+                "qualifier": {
+                    "type": "string",
+                    "enum": [
+                        "exact",
+                        "circa",
+                        "before",
+                        "after"
+                    ],
+                    "example": "circa"
+                },
+                "yearFrom": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "example": 1890
+                },
+                "yearTo": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "example": 1895
+                }
+            }
+        },
+        "jsonreqresp.ArtworkDatingResponse": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "ок. 1890–1895"
+                },
+                "precision": {
+                    "type": "string",
+                    "example": "year"
+                },
+                "qualifier": {
+                    "type": "string",
+                    "example": "circa"
+                },
+                "yearFrom": {
+                    "type": "integer",
+                    "example": 1890
+                },
+                "yearTo": {
+                    "type": "integer",
+                    "example": 1895
+                }
+            }
+        },
+        "jsonreqresp.ArtworkFacetsResponse": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.FacetBucketResponse"
+                    }
+                },
+                "centuries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.FacetBucketResponse"
+                    }
+                },
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.FacetBucketResponse"
+                    }
+                },
+                "creationYear": {
+                    "$ref": "#/definitions/jsonreqresp.YearRangeFacetResponse"
+                },
+                "materials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.FacetBucketResponse"
+                    }
+                },
+                "technics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.FacetBucketResponse"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.ArtworkHighlightResponse": {
+            "type": "object",
+            "properties": {
+                "authorName": {
+                    "type": "string",
+                    "example": "Леонардо да Винчи"
+                },
+                "collection": {
+                    "type": "string",
+                    "example": "Итальянская живопись"
+                },
+                "material": {
+                    "type": "string",
+                    "example": "Доска"
+                },
+                "technic": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eМасло\u003c/mark\u003e"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eПортрет\u003c/mark\u003e дамы"
+                }
+            }
+        },
+        "jsonreqresp.ArtworkImageResponse": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "example": "jpeg"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa1e8400-e29b-41d4-a716-446655441111"
+                },
+                "isPrimary": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "thumbnails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.ArtworkThumbnailResponse"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/images/artworks/bb2e8400-e29b-41d4-a716-446655442222/aa1e8400-e29b-41d4-a716-446655441111/original.jpg"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 3000
+                }
+            }
+        },
+        "jsonreqresp.ArtworkResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Author основной автор; Authors - все авторы с ролями, основной - первый",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jsonreqresp.AuthorResponse"
+                        }
+                    ]
+                },
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.AttributionResponse"
+                    }
+                },
+                "collection": {
+                    "$ref": "#/definitions/jsonreqresp.CollectionResponse"
+                },
+                "creationYear": {
+                    "description": "CreationYear действующий год датировки (середина периода), по нему идут поиск и сортировка",
+                    "type": "integer",
+                    "example": 1503
+                },
+                "dating": {
+                    "$ref": "#/definitions/jsonreqresp.ArtworkDatingResponse"
+                },
+                "dimensions": {
+                    "description": "Dimensions заполняется, если размер произведения задан структурно; тогда Size формируется по нему",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jsonreqresp.DimensionsResponse"
+                        }
+                    ]
+                },
+                "highlight": {
+                    "description": "Highlight заполняется только при полнотекстовом поиске",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jsonreqresp.ArtworkHighlightResponse"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "images": {
+                    "description": "Images изображения произведения в порядке показа",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.ArtworkImageResponse"
+                    }
+                },
+                "material": {
+                    "type": "string",
+                    "example": "Poplar wood"
+                },
+                "primaryImage": {
+                    "$ref": "#/definitions/jsonreqresp.ArtworkImageResponse"
+                },
+                "size": {
+                    "type": "string",
+                    "example": "77 × 53 см"
+                },
+                "tags": {
+                    "description": "Tags теги словаря, которыми отмечено произведение",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.TagResponse"
+                    }
+                },
+                "technic": {
+                    "type": "string",
+                    "example": "Oil painting"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Mona Lisa"
+                }
+            }
+        },
+        "jsonreqresp.ArtworkTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tagIds": {
+                    "description": "До 50 тегов",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7d1e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "jsonreqresp.ArtworkThumbnailResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "/images/artworks/bb2e8400-e29b-41d4-a716-446655442222/aa1e8400-e29b-41d4-a716-446655441111/w480.jpg"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 480
+                }
+            }
+        },
+        "jsonreqresp.AttributionRequest": {
+            "type": "object",
+            "required": [
+                "authorID"
+            ],
+            "properties": {
+                "authorID": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "author",
+                        "attributed",
+                        "workshop",
+                        "circle",
+                        "follower",
+                        "copy_after"
+                    ],
+                    "example": "workshop"
+                }
+            }
+        },
+        "jsonreqresp.AttributionResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/jsonreqresp.AuthorResponse"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "workshop"
+                },
+                "roleLabel": {
+                    "type": "string",
+                    "example": "мастерская"
+                }
+            }
+        },
+        "jsonreqresp.AuthorDuplicateResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/jsonreqresp.AuthorResponse"
+                },
+                "duplicate": {
+                    "$ref": "#/definitions/jsonreqresp.AuthorResponse"
+                },
+                "sameYears": {
+                    "description": "SameYears - годы жизни совпадают точно, иначе различаются не больше чем на 2 года",
+                    "type": "boolean",
+                    "example": true
+                },
+                "score": {
+                    "description": "Score - сходство от 0 до 1",
+                    "type": "number",
+                    "example": 0.925
+                }
+            }
+        },
+        "jsonreqresp.AuthorProfileRequest": {
+            "type": "object",
+            "properties": {
+                "biography": {
+                    "type": "string",
+                    "example": "Нидерландский художник-постимпрессионист."
+                },
+                "birthPlace": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Зюндерт"
+                },
+                "deathPlace": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Овер-сюр-Уаз"
+                },
+                "movements": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Постимпрессионизм"
+                    ]
+                },
+                "nationality": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Нидерланды"
+                },
+                "ulanID": {
+                    "description": "ULANID - идентификатор в Getty ULAN",
+                    "type": "string",
+                    "example": "500115588"
+                },
+                "wikidataID": {
+                    "description": "WikidataID - идентификатор элемента Wikidata",
+                    "type": "string",
+                    "example": "Q5582"
+                }
+            }
+        },
+        "jsonreqresp.AuthorProfileResponse": {
+            "type": "object",
+            "properties": {
+                "biography": {
+                    "type": "string",
+                    "example": "Нидерландский художник-постимпрессионист."
+                },
+                "birthPlace": {
+                    "type": "string",
+                    "example": "Зюндерт"
+                },
+                "birthYear": {
+                    "type": "integer",
+                    "example": 1452
+                },
+                "deathPlace": {
+                    "type": "string",
+                    "example": "Овер-сюр-Уаз"
+                },
+                "deathYear": {
+                    "type": "integer",
+                    "example": 1519
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Постимпрессионизм"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Leonardo da Vinci"
+                },
+                "nationality": {
+                    "type": "string",
+                    "example": "Нидерланды"
+                },
+                "portraitURL": {
+                    "description": "PortraitURL - адрес портрета, пусто - портрета нет",
+                    "type": "string",
+                    "example": "/images/authors/550e8400-e29b-41d4-a716-446655440000/portrait.jpg"
+                },
+                "ulanID": {
+                    "type": "string",
+                    "example": "500115588"
+                },
+                "ulanURL": {
+                    "type": "string",
+                    "example": "https://vocab.getty.edu/page/ulan/500115588"
+                },
+                "wikidataID": {
+                    "type": "string",
+                    "example": "Q5582"
+                },
+                "wikidataURL": {
+                    "type": "string",
+                    "example": "https://www.wikidata.org/wiki/Q5582"
+                }
+            }
+        },
+        "jsonreqresp.AuthorResponse": {
+            "type": "object",
+            "properties": {
+                "birthYear": {
+                    "type": "integer",
+                    "example": 1452
+                },
+                "deathYear": {
+                    "type": "integer",
+                    "example": 1519
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Leonardo da Vinci"
+                }
+            }
+        },
+        "jsonreqresp.BuyTicketRequest": {
+            "type": "object",
+            "required": [
+                "cntTickets",
+                "eventID"
+            ],
+            "properties": {
+                "CustomerEmail": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "myname@test.ru"
+                },
+                "cntTickets": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "customerName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "myname"
+                },
+                "eventID": {
+                    "type": "string",
+                    "example": "b10f841d-ba75-48df-a9cf-c86fc9bd3041"
+                }
+            }
+        },
+        "jsonreqresp.ChangeRecordResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "changedAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "changedBy": {
+                    "description": "ChangedBy - ID сотрудника или администратора, пусто для изменений без авторизации",
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.FieldChangeResponse"
+                    }
+                },
+                "entityID": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "entityType": {
+                    "type": "string",
+                    "example": "artwork"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "ee2e8400-e29b-41d4-a716-446655445555"
+                },
+                "revertedTo": {
+                    "description": "RevertedTo - версия, к которой вернулись, только для action = revert",
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "description": "Version - номер версии после изменения, версия 0 - состояние до первого изменения",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "jsonreqresp.CloneEventRequest": {
+            "type": "object",
+            "required": [
+                "dateBegin",
+                "dateEnd"
+            ],
+            "properties": {
+                "dateBegin": {
+                    "type": "string",
+                    "example": "2023-06-15T10:00:00Z"
+                },
+                "dateEnd": {
+                    "type": "string",
+                    "example": "2023-09-20T18:00:00Z"
+                },
+                "employeeID": {
+                    "description": "EmployeeID создатель; указывается администратором, сотрудник создает только от своего имени",
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Ночная выставка"
+                }
+            }
+        },
+        "jsonreqresp.CloseTreatmentRequest": {
+            "type": "object",
+            "properties": {
+                "treatmentEnd": {
+                    "description": "TreatmentEnd - дата завершения реставрации, по умолчанию текущее время",
+                    "type": "string",
+                    "example": "2024-04-15T18:00:00Z"
+                }
+            }
+        },
+        "jsonreqresp.CollectionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "aa1e8400-e29b-41d4-a716-446655441111"
+                },
+                "parentId": {
+                    "type": "string",
+                    "example": "bb1e8400-e29b-41d4-a716-446655442222"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Louvre Museum Collection"
+                }
+            }
+        },
+        "jsonreqresp.CollectionStatsResponse": {
+            "type": "object",
+            "properties": {
+                "artworkCount": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.StatBucketResponse"
+                    }
+                },
+                "centuries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.StatBucketResponse"
+                    }
+                },
+                "collection": {
+                    "$ref": "#/definitions/jsonreqresp.CollectionResponse"
+                },
+                "exhibitedArtworkCount": {
+                    "description": "ExhibitedArtworkCount число произведений, участвовавших хотя бы в одном мероприятии",
+                    "type": "integer",
+                    "example": 17
+                },
+                "exhibitions": {
+                    "description": "Exhibitions мероприятия с числом показанных на них произведений коллекции",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.StatBucketResponse"
+                    }
+                },
+                "includesSubcollections": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "subcollectionCount": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "technics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.StatBucketResponse"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.ConArtworkEventRequest": {
+            "type": "object",
+            "required": [
+                "artworkID"
+            ],
+            "properties": {
+                "artworkID": {
+                    "type": "string"
+                }
+            }
+        },
+        "jsonreqresp.ConditionPhotoResponse": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "example": "jpeg"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "cc2e8400-e29b-41d4-a716-446655443333"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/images/conditions/550e8400-e29b-41d4-a716-446655440000/dd2e8400-e29b-41d4-a716-446655444444/cc2e8400-e29b-41d4-a716-446655443333.jpg"
+                }
+            }
+        },
+        "jsonreqresp.ConditionReportRequest": {
+            "type": "object",
+            "required": [
+                "examiner",
+                "grade"
+            ],
+            "properties": {
+                "checkedAt": {
+                    "description": "CheckedAt - дата осмотра или начала реставрации, по умолчанию текущее время",
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "examiner": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Анна Смирнова"
+                },
+                "grade": {
+                    "type": "string",
+                    "enum": [
+                        "excellent",
+                        "good",
+                        "fair",
+                        "poor",
+                        "critical"
+                    ],
+                    "example": "poor"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 4000,
+                    "example": "Кракелюр в левом верхнем углу"
+                },
+                "treatment": {
+                    "type": "string",
+                    "maxLength": 4000,
+                    "example": "Укрепление красочного слоя"
+                },
+                "treatmentEnd": {
+                    "type": "string",
+                    "example": "2024-04-15T18:00:00Z"
+                }
+            }
+        },
+        "jsonreqresp.ConditionReportResponse": {
+            "type": "object",
+            "properties": {
+                "artworkID": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "checkedAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "examiner": {
+                    "type": "string",
+                    "example": "Анна Смирнова"
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "poor"
+                },
+                "gradeLabel": {
+                    "type": "string",
+                    "example": "плохое"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "dd2e8400-e29b-41d4-a716-446655444444"
+                },
+                "needsAttention": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Кракелюр в левом верхнем углу"
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.ConditionPhotoResponse"
+                    }
+                },
+                "treatment": {
+                    "description": "Treatment - описание реставрационных работ, пусто для обычного осмотра",
+                    "type": "string",
+                    "example": "Укрепление красочного слоя"
+                },
+                "treatmentEnd": {
+                    "type": "string",
+                    "example": "2024-04-15T18:00:00Z"
+                },
+                "treatmentOpen": {
+                    "description": "TreatmentOpen - реставрация не завершена, произведение недоступно для мероприятий",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "jsonreqresp.ConfirmCancelTxRequest": {
+            "type": "object",
+            "required": [
+                "txID"
+            ],
+            "properties": {
+                "txID": {
+                    "type": "string",
+                    "example": "b10f841d-ba75-48df-a9cf-c86fc9bd3041"
+                }
+            }
+        },
+        "jsonreqresp.DeleteArtworkRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "jsonreqresp.DeleteAuthorRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                }
+            }
+        },
+        "jsonreqresp.DeleteCollectionRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                }
+            }
+        },
+        "jsonreqresp.DeleteEventRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "jsonreqresp.DeleteTagRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "jsonreqresp.DependencyErrorResponse": {
+            "type": "object",
+            "properties": {
+                "blocking": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.DependentRecordResponse"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "record is blocked by dependent records"
+                }
+            }
+        },
+        "jsonreqresp.DependentRecordResponse": {
+            "type": "object",
+            "properties": {
+                "entityType": {
+                    "type": "string",
+                    "example": "event"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "inTrash": {
+                    "description": "InTrash - запись находится в корзине и должна быть восстановлена первой",
+                    "type": "boolean",
+                    "example": false
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Импрессионисты"
+                }
+            }
+        },
+        "jsonreqresp.DimensionsMigrationResponse": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "DryRun - изменения не сохранены, Migrated показывает, сколько произведений было бы обновлено",
+                    "type": "boolean",
+                    "example": false
+                },
+                "migrated": {
+                    "type": "integer",
+                    "example": 98
+                },
+                "structured": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "unparsed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.UnparsedSizeResponse"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.DimensionsRequest": {
+            "type": "object",
+            "required": [
+                "height",
+                "lengthUnit",
+                "width"
+            ],
+            "properties": {
+                "depth": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "height": {
+                    "type": "number",
+                    "example": 73.7
+                },
+                "lengthUnit": {
+                    "type": "string",
+                    "enum": [
+                        "mm",
+                        "cm",
+                        "m",
+                        "in"
+                    ],
+                    "example": "cm"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "weightUnit": {
+                    "type": "string",
+                    "enum": [
+                        "g",
+                        "kg",
+                        "lb"
+                    ],
+                    "example": "kg"
+                },
+                "width": {
+                    "type": "number",
+                    "example": 92.1
+                }
+            }
+        },
+        "jsonreqresp.DimensionsResponse": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "type": "number",
+                    "example": 0
+                },
+                "depthCm": {
+                    "type": "number",
+                    "example": 0
+                },
+                "height": {
+                    "type": "number",
+                    "example": 77
+                },
+                "heightCm": {
+                    "type": "number",
+                    "example": 77
+                },
+                "lengthUnit": {
+                    "type": "string",
+                    "example": "cm"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 0
+                },
+                "weightKg": {
+                    "type": "number",
+                    "example": 0
+                },
+                "weightUnit": {
+                    "type": "string",
+                    "example": "kg"
+                },
+                "width": {
+                    "type": "number",
+                    "example": 53
+                },
+                "widthCm": {
+                    "type": "number",
+                    "example": 53
+                }
+            }
+        },
+        "jsonreqresp.EmployeeResponse": {
+            "type": "object",
+            "properties": {
+                "adminId": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655443333"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "login": {
+                    "type": "string",
+                    "example": "johndoe@example.com"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john doe"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "jsonreqresp.EventIDResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                }
+            }
+        },
+        "jsonreqresp.EventOrganiserRequest": {
+            "type": "object",
+            "required": [
+                "employeeID"
+            ],
+            "properties": {
+                "employeeID": {
+                    "type": "string",
+                    "example": "aa1e8400-e29b-41d4-a716-446655441111"
+                }
+            }
+        },
+        "jsonreqresp.EventOrganisersResponse": {
+            "type": "object",
+            "properties": {
+                "organiserIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.EventResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "ул. Пречистенка, 12/2"
+                },
+                "artworkIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "canVisit": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cntTickets": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "dateBegin": {
+                    "type": "string",
+                    "example": "2023-06-15T10:00:00Z"
+                },
+                "dateEnd": {
+                    "type": "string",
+                    "example": "2023-09-20T18:00:00Z"
+                },
+                "employeeID": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "approved"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Выставка импрессионистов"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "jsonreqresp.EventReviewResponse": {
+            "type": "object",
+            "properties": {
+                "actorID": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Уточните адрес проведения"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-06-15T10:00:00Z"
+                },
+                "eventID": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "rejected"
+                }
+            }
+        },
+        "jsonreqresp.EventTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "ул. Пречистенка, 12/2"
+                },
+                "artworkIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "canVisit": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cntTickets": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "employeeID": {
+                    "type": "string",
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Школьная экскурсия"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Экскурсия для школьников"
+                }
+            }
+        },
+        "jsonreqresp.FacetBucketResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Масло, холст"
+                },
+                "selected": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "value": {
+                    "type": "string",
+                    "example": "Масло, холст"
+                }
+            }
+        },
+        "jsonreqresp.FavouriteRequest": {
+            "type": "object",
+            "required": [
+                "entityId",
+                "entityType"
+            ],
+            "properties": {
+                "entityId": {
+                    "description": "ID произведения или мероприятия",
+                    "type": "string",
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
+                },
+                "entityType": {
+                    "description": "artwork или event",
+                    "type": "string",
+                    "enum": [
+                        "artwork",
+                        "event"
+                    ],
+                    "example": "artwork"
+                }
+            }
+        },
+        "jsonreqresp.FavouritesResponse": {
+            "type": "object",
+            "properties": {
+                "artworks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                    }
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.EventResponse"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "new": {
+                    "type": "string",
+                    "example": "Звёздная ночь"
+                },
+                "old": {
+                    "type": "string",
+                    "example": "Звездная ночь"
+                }
+            }
+        },
+        "jsonreqresp.IIIFAnnotation": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFImage"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "motivation": {
+                    "type": "string",
+                    "example": "painting"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Annotation"
+                }
+            }
+        },
+        "jsonreqresp.IIIFAnnotationPage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFAnnotation"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "AnnotationPage"
+                }
+            }
+        },
+        "jsonreqresp.IIIFCanvas": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 1080
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFAnnotationPage"
+                    }
+                },
+                "label": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFLangMap"
+                },
+                "thumbnail": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFImage"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Canvas"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1920
+                }
+            }
+        },
+        "jsonreqresp.IIIFCollection": {
+            "type": "object",
+            "properties": {
+                "@context": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFReference"
+                    }
+                },
+                "label": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFLangMap"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Collection"
+                }
+            }
+        },
+        "jsonreqresp.IIIFImage": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1080
+                },
+                "id": {
+                    "type": "string",
+                    "example": "http://localhost:8080/images/artworks/550e8400-e29b-41d4-a716-446655440000/660e8400-e29b-41d4-a716-446655441111/original.jpg"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Image"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1920
+                }
+            }
+        },
+        "jsonreqresp.IIIFLangMap": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "array",
+                "items": {
+                    "type": "string"
+                }
+            }
+        },
+        "jsonreqresp.IIIFManifest": {
+            "type": "object",
+            "properties": {
+                "@context": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFCanvas"
+                    }
+                },
+                "label": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFLangMap"
+                },
+                "metadata": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFMetadataEntry"
+                    }
+                },
+                "navDate": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "12345678"
+                    "example": "1889-01-01T00:00:00Z"
+                },
+                "partOf": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFReference"
+                    }
+                },
+                "thumbnail": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.IIIFImage"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Manifest"
                 }
             }
         },
-        "auth.LoginEmployeeRequest": {
+        "jsonreqresp.IIIFMetadataEntry": {
             "type": "object",
-            "required": [
-                "login",
-                "password"
-            ],
             "properties": {
-                "login": {
+                "label": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFLangMap"
+                },
+                "value": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFLangMap"
+                }
+            }
+        },
+        "jsonreqresp.IIIFReference": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 4,
-                    "example": "elogin"
+                    "example": "http://localhost:8080/api/v1/iiif/artworks/550e8400-e29b-41d4-a716-446655440000/manifest"
                 },
-                "password": {
+                "label": {
+                    "$ref": "#/definitions/jsonreqresp.IIIFLangMap"
+                },
+                "type": {
                     "type": "string",
-                    "minLength": 4,
-                    "example": "12345678"
+                    "example": "Manifest"
                 }
             }
         },
-        "auth.RegisterEmployeeRequest": {
+        "jsonreqresp.ImportCountsResponse": {
             "type": "object",
-            "required": [
-                "login",
-                "password",
-                "username"
-            ],
             "properties": {
-                "login": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 4,
-                    "example": "elogin"
+                "created": {
+                    "type": "integer",
+                    "example": 117
                 },
-                "password": {
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "matched": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "jsonreqresp.ImportReportResponse": {
+            "type": "object",
+            "properties": {
+                "artworks": {
+                    "$ref": "#/definitions/jsonreqresp.ImportCountsResponse"
+                },
+                "authors": {
+                    "$ref": "#/definitions/jsonreqresp.ImportCountsResponse"
+                },
+                "collections": {
+                    "$ref": "#/definitions/jsonreqresp.ImportCountsResponse"
+                },
+                "committed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "dryRun": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.ImportRowErrorResponse"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.ImportRowErrorResponse": {
+            "type": "object",
+            "properties": {
+                "entity": {
                     "type": "string",
-                    "minLength": 4,
-                    "example": "12345678"
+                    "example": "artworks"
                 },
-                "username": {
+                "error": {
                     "type": "string",
-                    "maxLength": 50,
-                    "example": "ename"
+                    "example": "author not found by id or name"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 14
                 }
             }
         },
-        "jsonreqresp.AddArtworkRequest": {
+        "jsonreqresp.MergeAuthorsRequest": {
             "type": "object",
             "required": [
-                "authorID",
-                "collectionID",
-                "creationYear",
-                "material",
-                "size",
-                "technic",
-                "title"
+                "duplicateID",
+                "survivorID"
             ],
             "properties": {
-                "authorID": {
+                "duplicateID": {
                     "type": "string",
-                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "collectionID": {
+                "survivorID": {
                     "type": "string",
                     "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
-                },
-                "creationYear": {
+                }
+            }
+        },
+        "jsonreqresp.MergeAuthorsResponse": {
+            "type": "object",
+            "properties": {
+                "movedArtworks": {
+                    "description": "MovedArtworks - число произведений, у которых сменился автор",
                     "type": "integer",
-                    "maximum": 2100,
-                    "example": 1889
-                },
-                "material": {
+                    "example": 12
+                }
+            }
+        },
+        "jsonreqresp.ProvenanceEntryRequest": {
+            "type": "object",
+            "required": [
+                "owner"
+            ],
+            "properties": {
+                "dateQualifier": {
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "Холст, масляные краски"
+                    "enum": [
+                        "exact",
+                        "circa",
+                        "before",
+                        "after"
+                    ],
+                    "example": "circa"
                 },
-                "size": {
+                "isPublic": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "location": {
                     "type": "string",
-                    "maxLength": 50,
-                    "example": "73.7 × 92.1 см"
+                    "maxLength": 255,
+                    "example": "Москва"
                 },
-                "technic": {
+                "notes": {
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "Масло, холст"
+                    "maxLength": 2000,
+                    "example": ""
                 },
-                "title": {
+                "owner": {
                     "type": "string",
                     "maxLength": 255,
-                    "example": "Звёздная ночь"
+                    "example": "Сергей Щукин"
+                },
+                "ownerUncertain": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "periodFrom": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 0,
+                    "example": 1908
+                },
+                "periodTo": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 0,
+                    "example": 1918
+                },
+                "sources": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Опись собрания, 1913"
+                },
+                "transferMethod": {
+                    "type": "string",
+                    "enum": [
+                        "purchase",
+                        "auction",
+                        "gift",
+                        "bequest",
+                        "inheritance",
+                        "exchange",
+                        "commission",
+                        "confiscation",
+                        "restitution",
+                        "unknown"
+                    ],
+                    "example": "purchase"
                 }
             }
         },
-        "jsonreqresp.AddAuthorRequest": {
+        "jsonreqresp.ProvenanceEntryResponse": {
             "type": "object",
-            "required": [
-                "birthYear",
-                "name"
-            ],
             "properties": {
-                "birthYear": {
-                    "description": "Обязательное, \u003e= 1000",
+                "dateQualifier": {
+                    "type": "string",
+                    "example": "circa"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "isPublic": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Москва"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": ""
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "Сергей Щукин"
+                },
+                "ownerUncertain": {
+                    "description": "OwnerUncertain - владение предполагаемое, не подтверждено документами",
+                    "type": "boolean",
+                    "example": false
+                },
+                "period": {
+                    "description": "Period - период владения для показа, например \"ок. 1908–1918\"",
+                    "type": "string",
+                    "example": "ок. 1908–1918"
+                },
+                "periodFrom": {
                     "type": "integer",
-                    "minimum": 1000,
-                    "example": 1853
+                    "example": 1908
                 },
-                "deathYear": {
-                    "description": "Опциональное, \u003e= BirthYear",
+                "periodTo": {
                     "type": "integer",
-                    "example": 1890
+                    "example": 1918
                 },
-                "name": {
-                    "description": "Обязательное, 2-100 символов",
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "sources": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2,
-                    "example": "Винсент Ван Гог"
+                    "example": "Опись собрания, 1913"
+                },
+                "transferLabel": {
+                    "type": "string",
+                    "example": "покупка"
+                },
+                "transferMethod": {
+                    "type": "string",
+                    "example": "purchase"
                 }
             }
         },
-        "jsonreqresp.AddCollectionRequest": {
+        "jsonreqresp.PurgeTrashResponse": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.TrashItemResponse"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.RecommendationReasonResponse": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "author, collection, technic, material, period, events, favourites, yourFavourites",
+                    "type": "string",
+                    "example": "author"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Тот же автор"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 5
+                }
+            }
+        },
+        "jsonreqresp.ReorderArtworkImagesRequest": {
             "type": "object",
             "required": [
-                "title"
+                "imageIDs"
             ],
             "properties": {
-                "title": {
-                    "description": "Обязательное, 2-255 символов",
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 2,
-                    "example": "Музей современного искусства"
+                "imageIDs": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aa1e8400-e29b-41d4-a716-446655441111"
+                    ]
                 }
             }
         },
-        "jsonreqresp.AddEventRequest": {
+        "jsonreqresp.ReorderProvenanceRequest": {
             "type": "object",
             "required": [
-                "address",
-                "canVisit",
-                "cntTickets",
-                "dateBegin",
-                "dateEnd",
-                "title"
+                "entryIDs"
             ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "example": "ул. Пречистенка, 12/2"
-                },
-                "artworkIDs": {
+                "entryIDs": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
                         "type": "string"
-                    }
-                },
-                "canVisit": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "cntTickets": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 100
+                    },
+                    "example": [
+                        "bb2e8400-e29b-41d4-a716-446655442222"
+                    ]
+                }
+            }
+        },
+        "jsonreqresp.RestoreTrashItemRequest": {
+            "type": "object",
+            "required": [
+                "entityType",
+                "id"
+            ],
+            "properties": {
+                "entityType": {
+                    "type": "string",
+                    "enum": [
+                        "artwork",
+                        "author",
+                        "collection"
+                    ],
+                    "example": "artwork"
                 },
-                "dateBegin": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "jsonreqresp.ReviewEventRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
                     "type": "string",
-                    "example": "2023-06-15T10:00:00Z"
-                },
-                "dateEnd": {
+                    "maxLength": 1000,
+                    "example": "Уточните адрес проведения"
+                }
+            }
+        },
+        "jsonreqresp.SaveEventTemplateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "employeeID": {
+                    "description": "EmployeeID создатель; указывается администратором, сотрудник создает только от своего имени",
                     "type": "string",
-                    "example": "2023-09-20T18:00:00Z"
+                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
                 },
-                "title": {
+                "name": {
                     "type": "string",
                     "maxLength": 255,
-                    "example": "Ночная выставка"
+                    "example": "Школьная экскурсия"
                 }
             }
         },
-        "jsonreqresp.ArtworkResponse": {
+        "jsonreqresp.SchemaOrgExhibitionEvent": {
             "type": "object",
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/jsonreqresp.AuthorResponse"
+                "@context": {
+                    "type": "string"
                 },
-                "collection": {
-                    "$ref": "#/definitions/jsonreqresp.CollectionResponse"
+                "@id": {
+                    "type": "string",
+                    "example": "http://localhost:8080/museum/events/550e8400-e29b-41d4-a716-446655440000"
                 },
-                "creationYear": {
-                    "type": "integer",
-                    "example": 1503
+                "@type": {
+                    "type": "string",
+                    "example": "ExhibitionEvent"
                 },
-                "id": {
+                "endDate": {
                     "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                    "example": "2026-06-30T18:00:00Z"
                 },
-                "material": {
+                "eventAttendanceMode": {
                     "type": "string",
-                    "example": "Poplar wood"
+                    "example": "https://schema.org/OfflineEventAttendanceMode"
                 },
-                "size": {
+                "eventStatus": {
                     "type": "string",
-                    "example": "77 cm × 53 cm"
+                    "example": "https://schema.org/EventScheduled"
                 },
-                "technic": {
+                "identifier": {
                     "type": "string",
-                    "example": "Oil painting"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "title": {
+                "location": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgReference"
+                },
+                "name": {
                     "type": "string",
-                    "example": "Mona Lisa"
+                    "example": "Импрессионисты"
+                },
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.SchemaOrgOffer"
+                    }
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2026-06-01T10:00:00Z"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "workFeatured": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.SchemaOrgReference"
+                    }
                 }
             }
         },
-        "jsonreqresp.AuthorResponse": {
+        "jsonreqresp.SchemaOrgOffer": {
             "type": "object",
             "properties": {
-                "birthYear": {
-                    "type": "integer",
-                    "example": 1452
-                },
-                "deathYear": {
-                    "type": "integer",
-                    "example": 1519
+                "@type": {
+                    "type": "string",
+                    "example": "Offer"
                 },
-                "id": {
+                "availability": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "https://schema.org/InStock"
                 },
-                "name": {
+                "inventoryLevel": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgQuantity"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "validThrough": {
                     "type": "string",
-                    "example": "Leonardo da Vinci"
+                    "example": "2026-06-30T18:00:00Z"
                 }
             }
         },
-        "jsonreqresp.BuyTicketRequest": {
+        "jsonreqresp.SchemaOrgPerson": {
             "type": "object",
-            "required": [
-                "cntTickets",
-                "eventID"
-            ],
             "properties": {
-                "CustomerEmail": {
+                "@context": {
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "myname@test.ru"
+                    "example": "https://schema.org"
                 },
-                "cntTickets": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 1
+                "@id": {
+                    "type": "string",
+                    "example": "https://museum.example.org/museum/authors/550e8400-e29b-41d4-a716-446655440000"
                 },
-                "customerName": {
+                "@type": {
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "myname"
+                    "example": "Person"
                 },
-                "eventID": {
+                "birthDate": {
                     "type": "string",
-                    "example": "b10f841d-ba75-48df-a9cf-c86fc9bd3041"
+                    "example": "1853"
+                },
+                "birthPlace": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgReference"
+                },
+                "deathDate": {
+                    "type": "string",
+                    "example": "1890"
+                },
+                "deathPlace": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgReference"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Винсент Ван Гог"
+                },
+                "nationality": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgReference"
+                },
+                "sameAs": {
+                    "description": "SameAs - страницы автора в ULAN и Wikidata",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://www.wikidata.org/wiki/Q5582"
+                    ]
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "jsonreqresp.CollectionResponse": {
+        "jsonreqresp.SchemaOrgQuantity": {
             "type": "object",
             "properties": {
-                "id": {
+                "@type": {
                     "type": "string",
-                    "example": "aa1e8400-e29b-41d4-a716-446655441111"
+                    "example": "QuantitativeValue"
                 },
-                "title": {
+                "unitCode": {
                     "type": "string",
-                    "example": "Louvre Museum Collection"
+                    "example": "CMT"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 73.7
                 }
             }
         },
-        "jsonreqresp.ConArtworkEventRequest": {
+        "jsonreqresp.SchemaOrgReference": {
             "type": "object",
-            "required": [
-                "artworkID"
-            ],
             "properties": {
-                "artworkID": {
+                "@id": {
                     "type": "string"
-                }
-            }
-        },
-        "jsonreqresp.ConfirmCancelTxRequest": {
-            "type": "object",
-            "required": [
-                "txID"
-            ],
-            "properties": {
-                "txID": {
+                },
+                "@type": {
                     "type": "string",
-                    "example": "b10f841d-ba75-48df-a9cf-c86fc9bd3041"
+                    "example": "Collection"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Постимпрессионизм"
                 }
             }
         },
-        "jsonreqresp.DeleteArtworkRequest": {
+        "jsonreqresp.SchemaOrgVisualArtwork": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
+                "@context": {
+                    "type": "string"
+                },
+                "@id": {
+                    "type": "string",
+                    "example": "http://localhost:8080/museum/artworks/550e8400-e29b-41d4-a716-446655440000"
+                },
+                "@type": {
+                    "type": "string",
+                    "example": "VisualArtwork"
+                },
+                "artMedium": {
+                    "type": "string",
+                    "example": "Масло"
+                },
+                "artworkSurface": {
+                    "type": "string",
+                    "example": "Холст"
+                },
+                "creator": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.SchemaOrgPerson"
+                    }
+                },
+                "dateCreated": {
+                    "description": "DateCreated год создания, если он известен точно; иначе период указывается в TemporalCoverage",
+                    "type": "string",
+                    "example": "1889"
+                },
+                "depth": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgQuantity"
+                },
+                "height": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgQuantity"
+                },
+                "identifier": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isPartOf": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgReference"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Звёздная ночь"
+                },
+                "temporalCoverage": {
+                    "type": "string",
+                    "example": "1884/1885"
+                },
+                "thumbnailUrl": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
+                },
+                "weight": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgQuantity"
+                },
+                "width": {
+                    "$ref": "#/definitions/jsonreqresp.SchemaOrgQuantity"
                 }
             }
         },
-        "jsonreqresp.DeleteAuthorRequest": {
+        "jsonreqresp.SimilarArtworkResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                "artwork": {
+                    "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.RecommendationReasonResponse"
+                    }
+                },
+                "score": {
+                    "type": "number",
+                    "example": 8.5
                 }
             }
         },
-        "jsonreqresp.DeleteCollectionRequest": {
+        "jsonreqresp.StatBucketResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "label": {
                     "type": "string",
-                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                    "example": "Винсент Ван Гог"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "a11e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "jsonreqresp.DeleteEventRequest": {
+        "jsonreqresp.StatCollectionsResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
+                "CntArtworks": {
+                    "type": "integer"
+                },
+                "ColID": {
+                    "type": "string"
+                },
+                "ColTitle": {
                     "type": "string"
                 }
             }
         },
-        "jsonreqresp.EmployeeResponse": {
+        "jsonreqresp.TagNodeResponse": {
             "type": "object",
             "properties": {
-                "adminId": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.TagNodeResponse"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
                     "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655443333"
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
                 },
-                "createdAt": {
+                "kind": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "subject"
                 },
-                "id": {
+                "kindLabel": {
                     "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                    "example": "Сюжет"
                 },
-                "login": {
+                "name": {
                     "type": "string",
-                    "example": "johndoe@example.com"
+                    "example": "Портрет"
                 },
-                "username": {
+                "parentId": {
                     "type": "string",
-                    "example": "john doe"
+                    "example": "8e1e8400-e29b-41d4-a716-446655440000"
                 },
-                "valid": {
-                    "type": "boolean",
-                    "example": true
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "портретная живопись"
+                    ]
                 }
             }
-        },
-        "jsonreqresp.EventResponse": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "ул. Пречистенка, 12/2"
-                },
-                "artworkIDs": {
+        },
+        "jsonreqresp.TagPageResponse": {
+            "type": "object",
+            "properties": {
+                "ancestors": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/jsonreqresp.TagResponse"
                     }
                 },
-                "canVisit": {
-                    "type": "boolean",
-                    "example": true
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.TagNodeResponse"
+                    }
                 },
-                "cntTickets": {
+                "count": {
                     "type": "integer",
-                    "example": 150
-                },
-                "dateBegin": {
-                    "type": "string",
-                    "example": "2023-06-15T10:00:00Z"
+                    "example": 12
                 },
-                "dateEnd": {
-                    "type": "string",
-                    "example": "2023-09-20T18:00:00Z"
-                },
-                "employeeID": {
+                "tag": {
+                    "$ref": "#/definitions/jsonreqresp.TagResponse"
+                }
+            }
+        },
+        "jsonreqresp.TagRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "kind": {
+                    "description": "subject, movement, keyword",
                     "type": "string",
-                    "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
+                    "enum": [
+                        "subject",
+                        "movement",
+                        "keyword"
+                    ],
+                    "example": "subject"
                 },
-                "id": {
+                "name": {
+                    "description": "Обязательное, 1-100 символов",
                     "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Портрет"
                 },
-                "title": {
+                "parentId": {
+                    "description": "Опциональное, родительский тег того же вида",
                     "type": "string",
-                    "example": "Выставка импрессионистов"
+                    "example": "8e1e8400-e29b-41d4-a716-446655440000"
                 },
-                "valid": {
-                    "type": "boolean",
-                    "example": true
+                "synonyms": {
+                    "description": "До 20 синонимов",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "портретная живопись"
+                    ]
                 }
             }
         },
-        "jsonreqresp.StatCollectionsResponse": {
+        "jsonreqresp.TagResponse": {
             "type": "object",
             "properties": {
-                "CntArtworks": {
-                    "type": "integer"
+                "id": {
+                    "type": "string",
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
                 },
-                "ColID": {
-                    "type": "string"
+                "kind": {
+                    "type": "string",
+                    "example": "subject"
                 },
-                "ColTitle": {
-                    "type": "string"
+                "kindLabel": {
+                    "type": "string",
+                    "example": "Сюжет"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Портрет"
+                },
+                "parentId": {
+                    "type": "string",
+                    "example": "8e1e8400-e29b-41d4-a716-446655440000"
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "портретная живопись"
+                    ]
                 }
             }
         },
@@ -2012,6 +8713,32 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonreqresp.TrashItemResponse": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string",
+                    "example": "2026-03-01T10:00:00Z"
+                },
+                "entityType": {
+                    "type": "string",
+                    "example": "artwork"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "purgeAt": {
+                    "description": "PurgeAt - время, после которого запись будет удалена окончательно",
+                    "type": "string",
+                    "example": "2026-03-31T10:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Звёздная ночь"
+                }
+            }
+        },
         "jsonreqresp.TxTicketPurchaseResponse": {
             "type": "object",
             "properties": {
@@ -2026,15 +8753,34 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonreqresp.UnparsedSizeResponse": {
+            "type": "object",
+            "properties": {
+                "artworkID": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "unrecognized size format: \"диаметр 82 см\": expected 2 or 3 dimensions, got 1"
+                },
+                "size": {
+                    "type": "string",
+                    "example": "диаметр 82 см"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Тондо"
+                }
+            }
+        },
         "jsonreqresp.UpdateArtworkRequest": {
             "type": "object",
             "required": [
                 "authorID",
                 "collectionID",
-                "creationYear",
                 "id",
                 "material",
-                "size",
                 "technic",
                 "title"
             ],
@@ -2043,15 +8789,43 @@ const docTemplate = `{
                     "type": "string",
                     "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
                 },
+                "authorRole": {
+                    "description": "AuthorRole роль основного автора, пустая - без изменений",
+                    "type": "string",
+                    "enum": [
+                        "author",
+                        "attributed",
+                        "workshop",
+                        "circle",
+                        "follower",
+                        "copy_after"
+                    ],
+                    "example": "author"
+                },
+                "coAuthors": {
+                    "description": "CoAuthors дополнительные авторы в порядке показа; не передан - без изменений, пустой список - удалить всех",
+                    "type": "array",
+                    "maxItems": 9,
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.AttributionRequest"
+                    }
+                },
                 "collectionID": {
                     "type": "string",
                     "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
                 },
                 "creationYear": {
+                    "description": "CreationYear можно не передавать, если задана Dating; без Dating датировка - точный год",
                     "type": "integer",
                     "maximum": 2100,
                     "example": 1889
                 },
+                "dating": {
+                    "$ref": "#/definitions/jsonreqresp.ArtworkDatingRequest"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/jsonreqresp.DimensionsRequest"
+                },
                 "id": {
                     "type": "string",
                     "example": "44a315d0-663c-4813-92a6-d7977c2f2aba"
@@ -2062,6 +8836,7 @@ const docTemplate = `{
                     "example": "Холст, масляные краски"
                 },
                 "size": {
+                    "description": "Size можно не передавать, если заданы Dimensions; без Dimensions размеры разбираются из Size",
                     "type": "string",
                     "maxLength": 50,
                     "example": "73.7 × 92.1 см"
@@ -2121,6 +8896,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "cfd9ff5d-cb37-407c-b043-288a482e9239"
                 },
+                "parentId": {
+                    "description": "Опциональное, пусто - корневая коллекция",
+                    "type": "string",
+                    "example": "bb1e8400-e29b-41d4-a716-446655442222"
+                },
                 "title": {
                     "description": "Обязательное, 2-255 символов",
                     "type": "string",
@@ -2175,6 +8955,53 @@ const docTemplate = `{
                 }
             }
         },
+        "jsonreqresp.UpdateTagRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
+                },
+                "kind": {
+                    "description": "subject, movement, keyword",
+                    "type": "string",
+                    "enum": [
+                        "subject",
+                        "movement",
+                        "keyword"
+                    ],
+                    "example": "subject"
+                },
+                "name": {
+                    "description": "Обязательное, 1-100 символов",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Портрет"
+                },
+                "parentId": {
+                    "description": "Опциональное, родительский тег того же вида",
+                    "type": "string",
+                    "example": "8e1e8400-e29b-41d4-a716-446655440000"
+                },
+                "synonyms": {
+                    "description": "До 20 синонимов",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "портретная живопись"
+                    ]
+                }
+            }
+        },
         "jsonreqresp.UpdateValidEmployeeRequest": {
             "type": "object",
             "properties": {
@@ -2187,6 +9014,211 @@ const docTemplate = `{
                     "example": true
                 }
             }
+        },
+        "jsonreqresp.UserListIDResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "5c1e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "jsonreqresp.UserListItemRequest": {
+            "type": "object",
+            "required": [
+                "entityId",
+                "entityType"
+            ],
+            "properties": {
+                "entityId": {
+                    "description": "ID произведения или мероприятия",
+                    "type": "string",
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
+                },
+                "entityType": {
+                    "description": "artwork или event",
+                    "type": "string",
+                    "enum": [
+                        "artwork",
+                        "event"
+                    ],
+                    "example": "artwork"
+                },
+                "note": {
+                    "description": "До 1000 символов",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Посмотреть вживую"
+                }
+            }
+        },
+        "jsonreqresp.UserListItemResponse": {
+            "type": "object",
+            "properties": {
+                "artwork": {
+                    "$ref": "#/definitions/jsonreqresp.ArtworkResponse"
+                },
+                "entityId": {
+                    "type": "string",
+                    "example": "7d1e8400-e29b-41d4-a716-446655440000"
+                },
+                "entityType": {
+                    "type": "string",
+                    "example": "artwork"
+                },
+                "event": {
+                    "$ref": "#/definitions/jsonreqresp.EventResponse"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Посмотреть вживую"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "jsonreqresp.UserListItemsRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "До 200 элементов",
+                    "type": "array",
+                    "maxItems": 200,
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.UserListItemRequest"
+                    }
+                }
+            }
+        },
+        "jsonreqresp.UserListRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "description": "До 2000 символов",
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Любимые работы из залов XIX века"
+                },
+                "isPublic": {
+                    "description": "Подборка доступна по публичной ссылке",
+                    "type": "boolean",
+                    "example": true
+                },
+                "title": {
+                    "description": "Обязательное, 1-100 символов",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Мои импрессионисты"
+                }
+            }
+        },
+        "jsonreqresp.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Любимые работы из залов XIX века"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5c1e8400-e29b-41d4-a716-446655440000"
+                },
+                "isPublic": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "itemCount": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsonreqresp.UserListItemResponse"
+                    }
+                },
+                "shareUrl": {
+                    "type": "string",
+                    "example": "https://museum.example.org/museum/lists/5c1e8400-e29b-41d4-a716-446655440000"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Мои импрессионисты"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-03-02T12:30:00Z"
+                }
+            }
+        },
+        "jsonreqresp.UserListSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Любимые работы из залов XIX века"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5c1e8400-e29b-41d4-a716-446655440000"
+                },
+                "isPublic": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "itemCount": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "shareUrl": {
+                    "type": "string",
+                    "example": "https://museum.example.org/museum/lists/5c1e8400-e29b-41d4-a716-446655440000"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Мои импрессионисты"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-03-02T12:30:00Z"
+                }
+            }
+        },
+        "jsonreqresp.YearRangeFacetResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer",
+                    "example": 1800
+                },
+                "max": {
+                    "type": "integer",
+                    "example": 1889
+                },
+                "min": {
+                    "type": "integer",
+                    "example": 1503
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 1900
+                }
+            }
         }
     }
 }`
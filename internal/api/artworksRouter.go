@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
@@ -27,6 +28,25 @@ func NewArtworksRouter(router *gin.RouterGroup, artworksServ artworkserv.Artwork
 	gr.POST("/", r.AddArtwork)
 	gr.PUT("/", r.UpdateArtwork)
 	gr.DELETE("/", r.DeleteArtwork)
+	gr.GET("/:id/images", r.GetImages)
+	gr.POST("/:id/images", r.UploadImage)
+	gr.PUT("/:id/images/order", r.ReorderImages)
+	gr.PUT("/:id/images/:imageID/primary", r.SetPrimaryImage)
+	gr.DELETE("/:id/images/:imageID", r.DeleteImage)
+	gr.GET("/:id/provenance", r.GetProvenance)
+	gr.POST("/:id/provenance", r.AddProvenanceEntry)
+	gr.PUT("/:id/provenance/order", r.ReorderProvenance)
+	gr.PUT("/:id/provenance/:entryID", r.UpdateProvenanceEntry)
+	gr.DELETE("/:id/provenance/:entryID", r.DeleteProvenanceEntry)
+	gr.GET("/needs-attention", r.GetArtworksNeedingAttention)
+	gr.GET("/:id/condition-reports", r.GetConditionReports)
+	gr.POST("/:id/condition-reports", r.AddConditionReport)
+	gr.PUT("/:id/condition-reports/:reportID", r.UpdateConditionReport)
+	gr.PUT("/:id/condition-reports/:reportID/close", r.CloseTreatment)
+	gr.POST("/:id/condition-reports/:reportID/photos", r.UploadConditionPhoto)
+	gr.GET("/:id/history", r.GetArtworkHistory)
+	gr.POST("/:id/history/:version/revert", r.RevertArtwork)
+	gr.POST("/dimensions/migrate", r.MigrateDimensions)
 	return r
 }
 
@@ -56,7 +76,10 @@ func (r *ArtworksRouter) GetAllArtworks(c *gin.Context) {
 
 // AddArtwork godoc
 // @Summary Добавить произведение (сотрудник)
-// @Description Добавляет произведение с уже созданными автором и коллекцией
+// @Description Добавляет произведение с уже созданными автором и коллекцией.
+// @Description Датировка задается точным годом creationYear или периодом dating с уточнением (около, до, после) и точностью.
+// @Description Кроме основного автора можно указать дополнительных (coAuthors) с ролями: автор, приписывается, мастерская, круг, последователь, копия.
+// @Description Датировка должна соответствовать годам жизни авторов; мастерская, круг, последователи и копии могут быть созданы после смерти автора
 // @Tags Экспонаты
 // @Accept json
 // @Produce json
@@ -92,7 +115,8 @@ func (r *ArtworksRouter) AddArtwork(c *gin.Context) {
 
 // Update Artwork godoc
 // @Summary Обновить произведение (сотрудник)
-// @Description Обновляет произведение с новыми/существующими автором и коллекцией
+// @Description Обновляет произведение с новыми/существующими автором и коллекцией.
+// @Description Без dating датировка сохраняется, если не изменился creationYear; без coAuthors дополнительные авторы не меняются
 // @Tags Экспонаты
 // @Accept json
 // @Produce json
@@ -121,8 +145,12 @@ func (r *ArtworksRouter) UpdateArtwork(c *gin.Context) {
 			Technic:      req.Technic,
 			Material:     req.Material,
 			Size:         req.Size,
+			Dimensions:   req.Dimensions,
+			Dating:       req.Dating,
 			AuthorID:     req.AuthorID,
 			CollectionID: req.CollectionID,
+			AuthorRole:   req.AuthorRole,
+			CoAuthors:    req.CoAuthors,
 		})
 
 	if err != nil {
@@ -142,7 +170,8 @@ func (r *ArtworksRouter) UpdateArtwork(c *gin.Context) {
 
 // DeleteArtwork godoc
 // @Summary Удалить произведение (сотрудник)
-// @Description Удаляет существующее произведение искусства
+// @Description Переносит произведение в корзину, откуда его можно восстановить до окончательной очистки.
+// @Description Произведение, которое участвует в ещё не завершившихся выставках, удалить нельзя - выставки возвращаются в ответе 409.
 // @Tags Экспонаты
 // @Accept json
 // @Produce json
@@ -152,6 +181,7 @@ func (r *ArtworksRouter) UpdateArtwork(c *gin.Context) {
 // @Success 200 "Успешно удалено"
 // @Failure 400 "Неверный запрос"
 // @Failure 404 "Не найдено"
+// @Failure 409 {object} jsonreqresp.DependencyErrorResponse "Произведение участвует в выставках"
 // @Router /employee/artworks [delete]
 func (r *ArtworksRouter) DeleteArtwork(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -164,6 +194,9 @@ func (r *ArtworksRouter) DeleteArtwork(c *gin.Context) {
 
 	err := r.artworksServ.Delete(ctx, uuid.MustParse(req.ID))
 	if err != nil {
+		if handleDependencyErr(c, err) {
+			return
+		}
 		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
@@ -173,3 +206,778 @@ func (r *ArtworksRouter) DeleteArtwork(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+// handleArtworkImageErr отвечает клиенту по ошибке работы с изображениями
+func handleArtworkImageErr(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, artworkrep.ErrArtworkNotFound) || errors.Is(err, artworkrep.ErrArtworkImageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, artworkserv.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, artworkserv.ErrTooManyImages):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrValidateArtworkImage) ||
+		errors.Is(err, artworkserv.ErrImageDecode) ||
+		errors.Is(err, artworkserv.ErrImageOrderMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetImages godoc
+// @Summary Получить изображения произведения (сотрудник)
+// @Description Возвращает изображения произведения в порядке показа с адресами миниатюр
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Success 200 {array} jsonreqresp.ArtworkImageResponse
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Произведение не найдено"
+// @Router /employee/artworks/{id}/images [get]
+func (r *ArtworksRouter) GetImages(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	images, err := r.artworksServ.GetImages(ctx, artworkID)
+	if err != nil {
+		handleArtworkImageErr(c, err)
+		return
+	}
+	imagesResp := make([]jsonreqresp.ArtworkImageResponse, len(images))
+	for i, img := range images {
+		imagesResp[i] = img.ToArtworkImageResponse()
+	}
+	c.JSON(http.StatusOK, imagesResp)
+}
+
+// UploadImage godoc
+// @Summary Загрузить изображение произведения (сотрудник)
+// @Description Загружает изображение (jpeg, png, webp, до 10 МБ) и генерирует миниатюры.
+// @Description Изображение добавляется в конец списка, первое изображение становится основным.
+// @Tags Экспонаты
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param image formData file true "Файл изображения"
+// @Success 201 {object} jsonreqresp.ArtworkImageResponse
+// @Failure 400 "Неверный формат файла"
+// @Failure 404 "Произведение не найдено"
+// @Failure 409 "Превышено количество изображений"
+// @Failure 413 "Файл слишком большой"
+// @Router /employee/artworks/{id}/images [post]
+func (r *ArtworksRouter) UploadImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	// запас на заголовки multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.ArtworkImageMaxSize+1<<20)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": artworkserv.ErrImageTooLarge.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if fileHeader.Size > models.ArtworkImageMaxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": artworkserv.ErrImageTooLarge.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	img, err := r.artworksServ.AddImage(ctx, artworkID, file)
+	if err != nil {
+		handleArtworkImageErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, img.ToArtworkImageResponse())
+}
+
+// ReorderImages godoc
+// @Summary Изменить порядок изображений (сотрудник)
+// @Description Задает порядок показа изображений, в списке должны быть все изображения произведения
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param request body jsonreqresp.ReorderArtworkImagesRequest true "ID изображений в новом порядке"
+// @Success 200 "Порядок изменен"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/images/order [put]
+func (r *ArtworksRouter) ReorderImages(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	var req jsonreqresp.ReorderArtworkImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	imageIDs := make(uuid.UUIDs, len(req.ImageIDs))
+	for i, id := range req.ImageIDs {
+		imageIDs[i] = uuid.MustParse(id)
+	}
+
+	if err := r.artworksServ.ReorderImages(ctx, artworkID, imageIDs); err != nil {
+		handleArtworkImageErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetPrimaryImage godoc
+// @Summary Сделать изображение основным (сотрудник)
+// @Description Основное изображение показывается в списках и на странице произведения
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param imageID path string true "ID изображения"
+// @Success 200 "Основное изображение изменено"
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/images/{imageID}/primary [put]
+func (r *ArtworksRouter) SetPrimaryImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	imageID, err := uuid.Parse(c.Param("imageID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid image id"})
+		return
+	}
+
+	if err := r.artworksServ.SetPrimaryImage(ctx, artworkID, imageID); err != nil {
+		handleArtworkImageErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteImage godoc
+// @Summary Удалить изображение произведения (сотрудник)
+// @Description Удаляет изображение и его миниатюры, оставшиеся изображения перенумеровываются
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param imageID path string true "ID изображения"
+// @Success 200 "Изображение удалено"
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/images/{imageID} [delete]
+func (r *ArtworksRouter) DeleteImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	imageID, err := uuid.Parse(c.Param("imageID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid image id"})
+		return
+	}
+
+	if err := r.artworksServ.DeleteImage(ctx, artworkID, imageID); err != nil {
+		handleArtworkImageErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// handleProvenanceErr отвечает клиенту по ошибке работы с провенансом
+func handleProvenanceErr(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, artworkrep.ErrArtworkNotFound) || errors.Is(err, artworkrep.ErrProvenanceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, artworkserv.ErrTooManyProvenanceEntries):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrValidateProvenance) ||
+		errors.Is(err, artworkserv.ErrProvenanceOrderMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetProvenance godoc
+// @Summary Получить историю владения произведением (сотрудник)
+// @Description Возвращает все записи провенанса, включая скрытые от посетителей, в хронологическом порядке
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Success 200 {array} jsonreqresp.ProvenanceEntryResponse
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Произведение не найдено"
+// @Router /employee/artworks/{id}/provenance [get]
+func (r *ArtworksRouter) GetProvenance(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	entries, err := r.artworksServ.GetProvenance(ctx, artworkID)
+	if err != nil {
+		handleProvenanceErr(c, err)
+		return
+	}
+	entriesResp := make([]jsonreqresp.ProvenanceEntryResponse, len(entries))
+	for i, e := range entries {
+		entriesResp[i] = e.ToProvenanceEntryResponse()
+	}
+	c.JSON(http.StatusOK, entriesResp)
+}
+
+// AddProvenanceEntry godoc
+// @Summary Добавить запись провенанса (сотрудник)
+// @Description Добавляет владельца в конец истории владения.
+// @Description periodFrom/periodTo - годы, 0 - неизвестен; dateQualifier задает точность датировки,
+// @Description ownerUncertain отмечает предполагаемого владельца.
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param request body jsonreqresp.ProvenanceEntryRequest true "Запись провенанса"
+// @Success 201 {object} jsonreqresp.ProvenanceEntryResponse
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Произведение не найдено"
+// @Failure 409 "Превышено количество записей"
+// @Router /employee/artworks/{id}/provenance [post]
+func (r *ArtworksRouter) AddProvenanceEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	var req jsonreqresp.ProvenanceEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := r.artworksServ.AddProvenanceEntry(ctx, artworkID, req)
+	if err != nil {
+		handleProvenanceErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, entry.ToProvenanceEntryResponse())
+}
+
+// UpdateProvenanceEntry godoc
+// @Summary Изменить запись провенанса (сотрудник)
+// @Description Заменяет поля записи, позиция в истории владения не меняется
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param entryID path string true "ID записи"
+// @Param request body jsonreqresp.ProvenanceEntryRequest true "Запись провенанса"
+// @Success 200 {object} jsonreqresp.ProvenanceEntryResponse
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/provenance/{entryID} [put]
+func (r *ArtworksRouter) UpdateProvenanceEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	entryID, err := uuid.Parse(c.Param("entryID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid provenance entry id"})
+		return
+	}
+
+	var req jsonreqresp.ProvenanceEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := r.artworksServ.UpdateProvenanceEntry(ctx, artworkID, entryID, req)
+	if err != nil {
+		handleProvenanceErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entry.ToProvenanceEntryResponse())
+}
+
+// ReorderProvenance godoc
+// @Summary Изменить порядок записей провенанса (сотрудник)
+// @Description Задает хронологический порядок владельцев, в списке должны быть все записи произведения
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param request body jsonreqresp.ReorderProvenanceRequest true "ID записей в новом порядке"
+// @Success 200 "Порядок изменен"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/provenance/order [put]
+func (r *ArtworksRouter) ReorderProvenance(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	var req jsonreqresp.ReorderProvenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entryIDs := make(uuid.UUIDs, len(req.EntryIDs))
+	for i, id := range req.EntryIDs {
+		entryIDs[i] = uuid.MustParse(id)
+	}
+
+	if err := r.artworksServ.ReorderProvenance(ctx, artworkID, entryIDs); err != nil {
+		handleProvenanceErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteProvenanceEntry godoc
+// @Summary Удалить запись провенанса (сотрудник)
+// @Description Удаляет запись, оставшиеся записи перенумеровываются
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param entryID path string true "ID записи"
+// @Success 200 "Запись удалена"
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/provenance/{entryID} [delete]
+func (r *ArtworksRouter) DeleteProvenanceEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	entryID, err := uuid.Parse(c.Param("entryID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid provenance entry id"})
+		return
+	}
+
+	if err := r.artworksServ.DeleteProvenanceEntry(ctx, artworkID, entryID); err != nil {
+		handleProvenanceErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// handleConditionReportErr отвечает клиенту по ошибке работы с отчетами о состоянии
+func handleConditionReportErr(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, artworkrep.ErrArtworkNotFound) || errors.Is(err, artworkrep.ErrConditionReportNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, artworkserv.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrConditionReportTooManyPhotos) ||
+		errors.Is(err, models.ErrConditionReportTreatmentEnded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrValidateConditionReport) ||
+		errors.Is(err, models.ErrValidateConditionPhoto) ||
+		errors.Is(err, models.ErrValidateArtworkImage) ||
+		errors.Is(err, artworkserv.ErrImageDecode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func conditionReportsResponse(reports []*models.ConditionReport) []jsonreqresp.ConditionReportResponse {
+	reportsResp := make([]jsonreqresp.ConditionReportResponse, len(reports))
+	for i, report := range reports {
+		reportsResp[i] = report.ToConditionReportResponse()
+	}
+	return reportsResp
+}
+
+// GetArtworksNeedingAttention godoc
+// @Summary Произведения, требующие внимания реставратора (сотрудник)
+// @Description Возвращает по одному, самому свежему, отчету для каждого произведения
+// @Description с незавершенной реставрацией или с плохой либо критической оценкой последнего осмотра
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Success 200 {array} jsonreqresp.ConditionReportResponse
+// @Router /employee/artworks/needs-attention [get]
+func (r *ArtworksRouter) GetArtworksNeedingAttention(c *gin.Context) {
+	ctx := c.Request.Context()
+	reports, err := r.artworksServ.GetConditionReportsNeedingAttention(ctx)
+	if err != nil {
+		handleConditionReportErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, conditionReportsResponse(reports))
+}
+
+// GetConditionReports godoc
+// @Summary Получить историю осмотров и реставраций произведения (сотрудник)
+// @Description Возвращает отчеты о состоянии с фотографиями, новые первыми
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Success 200 {array} jsonreqresp.ConditionReportResponse
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Произведение не найдено"
+// @Router /employee/artworks/{id}/condition-reports [get]
+func (r *ArtworksRouter) GetConditionReports(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	reports, err := r.artworksServ.GetConditionReports(ctx, artworkID)
+	if err != nil {
+		handleConditionReportErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, conditionReportsResponse(reports))
+}
+
+// AddConditionReport godoc
+// @Summary Добавить отчет о состоянии (сотрудник)
+// @Description Сохраняет результат осмотра или начатую реставрацию.
+// @Description Если заполнено поле treatment и не указан treatmentEnd, реставрация считается незавершенной
+// @Description и произведение нельзя добавить в мероприятия, пересекающиеся с ней по датам.
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param request body jsonreqresp.ConditionReportRequest true "Отчет о состоянии"
+// @Success 201 {object} jsonreqresp.ConditionReportResponse
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Произведение не найдено"
+// @Router /employee/artworks/{id}/condition-reports [post]
+func (r *ArtworksRouter) AddConditionReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	var req jsonreqresp.ConditionReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := r.artworksServ.AddConditionReport(ctx, artworkID, req)
+	if err != nil {
+		handleConditionReportErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, report.ToConditionReportResponse())
+}
+
+// UpdateConditionReport godoc
+// @Summary Изменить отчет о состоянии (сотрудник)
+// @Description Заменяет поля отчета, приложенные фотографии сохраняются
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param reportID path string true "ID отчета"
+// @Param request body jsonreqresp.ConditionReportRequest true "Отчет о состоянии"
+// @Success 200 {object} jsonreqresp.ConditionReportResponse
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Не найдено"
+// @Router /employee/artworks/{id}/condition-reports/{reportID} [put]
+func (r *ArtworksRouter) UpdateConditionReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid condition report id"})
+		return
+	}
+
+	var req jsonreqresp.ConditionReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := r.artworksServ.UpdateConditionReport(ctx, artworkID, reportID, req)
+	if err != nil {
+		handleConditionReportErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report.ToConditionReportResponse())
+}
+
+// CloseTreatment godoc
+// @Summary Завершить реставрацию (сотрудник)
+// @Description Отмечает дату завершения реставрации, после чего произведение снова доступно для мероприятий
+// @Tags Экспонаты
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param reportID path string true "ID отчета"
+// @Param request body jsonreqresp.CloseTreatmentRequest false "Дата завершения"
+// @Success 200 {object} jsonreqresp.ConditionReportResponse
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Не найдено"
+// @Failure 409 "Реставрация уже завершена"
+// @Router /employee/artworks/{id}/condition-reports/{reportID}/close [put]
+func (r *ArtworksRouter) CloseTreatment(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid condition report id"})
+		return
+	}
+
+	var req jsonreqresp.CloseTreatmentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	report, err := r.artworksServ.CloseTreatment(ctx, artworkID, reportID, req.TreatmentEnd)
+	if err != nil {
+		handleConditionReportErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report.ToConditionReportResponse())
+}
+
+// UploadConditionPhoto godoc
+// @Summary Приложить фотографию к отчету о состоянии (сотрудник)
+// @Description Загружает фотографию (jpeg, png, webp, до 10 МБ), миниатюры не создаются
+// @Tags Экспонаты
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param reportID path string true "ID отчета"
+// @Param photo formData file true "Файл фотографии"
+// @Success 201 {object} jsonreqresp.ConditionPhotoResponse
+// @Failure 400 "Неверный формат файла"
+// @Failure 404 "Не найдено"
+// @Failure 409 "Превышено количество фотографий"
+// @Failure 413 "Файл слишком большой"
+// @Router /employee/artworks/{id}/condition-reports/{reportID}/photos [post]
+func (r *ArtworksRouter) UploadConditionPhoto(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid condition report id"})
+		return
+	}
+
+	// запас на заголовки multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.ArtworkImageMaxSize+1<<20)
+	fileHeader, err := c.FormFile("photo")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": artworkserv.ErrImageTooLarge.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if fileHeader.Size > models.ArtworkImageMaxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": artworkserv.ErrImageTooLarge.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	photo, err := r.artworksServ.AddConditionPhoto(ctx, artworkID, reportID, file)
+	if err != nil {
+		handleConditionReportErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, photo.ToConditionPhotoResponse())
+}
+
+// GetArtworkHistory godoc
+// @Summary Получить историю изменений произведения (сотрудник)
+// @Description Возвращает версии произведения в порядке возрастания с изменившимися полями, автором и временем изменения.
+// @Description История сохраняется и после удаления произведения.
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Success 200 {array} jsonreqresp.ChangeRecordResponse
+// @Failure 400 "Неверный ID"
+// @Router /employee/artworks/{id}/history [get]
+func (r *ArtworksRouter) GetArtworkHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	history, err := r.artworksServ.GetHistory(ctx, artworkID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeHistoryResponse(history))
+}
+
+// RevertArtwork godoc
+// @Summary Вернуть произведение к версии из истории (сотрудник)
+// @Description Восстанавливает значения полей произведения из указанной версии, версия 0 - состояние до первого изменения.
+// @Description Возврат сохраняется в истории как новая версия.
+// @Description Автор и коллекция произведения не меняются.
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param version path int true "Номер версии"
+// @Success 200 "Успешно восстановлено"
+// @Failure 400 "Неверный ID или номер версии"
+// @Failure 404 "Произведение или версия не найдены"
+// @Router /employee/artworks/{id}/history/{version}/revert [post]
+func (r *ArtworksRouter) RevertArtwork(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+	version, ok := parseVersionParam(c)
+	if !ok {
+		return
+	}
+
+	err = r.artworksServ.Revert(ctx, artworkID, version)
+	if err != nil {
+		if handleVersionNotFoundErr(c, err) {
+			return
+		} else if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// MigrateDimensions godoc
+// @Summary Перевести текстовые размеры в структурированные (сотрудник)
+// @Description Разбирает текстовый размер произведений без структурированного размера
+// @Description (например "73,7 x 92,1 см" или "29 × 36 in, 3 kg") и сохраняет высоту, ширину, глубину и вес.
+// @Description Текстовый размер перезаписывается единообразным представлением, изменения сохраняются в истории.
+// @Description Возвращает произведения, размер которых разобрать не удалось.
+// @Tags Экспонаты
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param dry_run query bool false "Только проверить, не сохраняя изменения"
+// @Success 200 {object} jsonreqresp.DimensionsMigrationResponse
+// @Failure 400 "Неверный параметр dry_run"
+// @Router /employee/artworks/dimensions/migrate [post]
+func (r *ArtworksRouter) MigrateDimensions(c *gin.Context) {
+	ctx := c.Request.Context()
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
+			return
+		}
+	}
+
+	report, err := r.artworksServ.MigrateDimensions(ctx, dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report.ToDimensionsMigrationResponse())
+}
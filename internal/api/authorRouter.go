@@ -25,6 +25,14 @@ func NewAuthorRouter(router *gin.RouterGroup, authorServ authorserv.AuthorServ)
 	gr.POST("", r.AddAuthor)
 	gr.PUT("", r.UpdateAuthor)
 	gr.DELETE("", r.DeleteAuthor)
+	gr.GET("/:id/history", r.GetAuthorHistory)
+	gr.POST("/:id/history/:version/revert", r.RevertAuthor)
+	gr.GET("/duplicates", r.GetAuthorDuplicates)
+	gr.POST("/merge", r.MergeAuthors)
+	gr.GET("/:id", r.GetAuthor)
+	gr.PUT("/:id/profile", r.UpdateAuthorProfile)
+	gr.PUT("/:id/portrait", r.UploadAuthorPortrait)
+	gr.DELETE("/:id/portrait", r.DeleteAuthorPortrait)
 	return r
 }
 
@@ -128,7 +136,8 @@ func (r *AuthorRouter) UpdateAuthor(c *gin.Context) {
 
 // DeleteAuthor godoc
 // @Summary Удалить автора (сотрудник)
-// @Description Удаляет существующего автора
+// @Description Переносит автора в корзину, откуда его можно восстановить до окончательной очистки.
+// @Description Автора, указанного у произведений вне корзины, удалить нельзя - произведения возвращаются в ответе 409.
 // @Tags Авторы
 // @Accept json
 // @Produce json
@@ -138,7 +147,7 @@ func (r *AuthorRouter) UpdateAuthor(c *gin.Context) {
 // @Success 200 "Успешно удалено"
 // @Failure 400 "Неверный запрос"
 // @Failure 404 "Автор не найден"
-// @Failure 409 "Конфликт - у автора есть связанные произведения"
+// @Failure 409 {object} jsonreqresp.DependencyErrorResponse "У автора есть связанные произведения"
 // @Router /employee/authors [delete]
 func (r *AuthorRouter) DeleteAuthor(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -151,10 +160,11 @@ func (r *AuthorRouter) DeleteAuthor(c *gin.Context) {
 
 	err := r.authorServ.Delete(ctx, uuid.MustParse(req.ID))
 	if err != nil {
+		if handleDependencyErr(c, err) {
+			return
+		}
 		if errors.Is(err, authorrep.ErrAuthorNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if errors.Is(err, authorserv.ErrHasLinkedArtworks) {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -162,3 +172,302 @@ func (r *AuthorRouter) DeleteAuthor(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+// GetAuthorHistory godoc
+// @Summary Получить историю изменений автора (сотрудник)
+// @Description Возвращает версии автора в порядке возрастания с изменившимися полями, автором и временем изменения.
+// @Description История сохраняется и после удаления автора.
+// @Tags Авторы
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID автора"
+// @Success 200 {array} jsonreqresp.ChangeRecordResponse
+// @Failure 400 "Неверный ID"
+// @Router /employee/authors/{id}/history [get]
+func (r *AuthorRouter) GetAuthorHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author id"})
+		return
+	}
+
+	history, err := r.authorServ.GetHistory(ctx, authorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeHistoryResponse(history))
+}
+
+// RevertAuthor godoc
+// @Summary Вернуть автора к версии из истории (сотрудник)
+// @Description Восстанавливает значения полей автора из указанной версии, версия 0 - состояние до первого изменения.
+// @Description Возврат сохраняется в истории как новая версия.
+// @Tags Авторы
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID автора"
+// @Param version path int true "Номер версии"
+// @Success 200 "Успешно восстановлено"
+// @Failure 400 "Неверный ID или номер версии"
+// @Failure 404 "Автор или версия не найдены"
+// @Router /employee/authors/{id}/history/{version}/revert [post]
+func (r *AuthorRouter) RevertAuthor(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author id"})
+		return
+	}
+	version, ok := parseVersionParam(c)
+	if !ok {
+		return
+	}
+
+	err = r.authorServ.Revert(ctx, authorID, version)
+	if err != nil {
+		if handleVersionNotFoundErr(c, err) {
+			return
+		} else if errors.Is(err, authorrep.ErrAuthorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetAuthorDuplicates godoc
+// @Summary Найти вероятные дубли авторов (сотрудник)
+// @Description Сравнивает имена авторов без учета регистра, порядка слов, отчеств и знаков препинания,
+// @Description инициалы совпадают с полными именами, кириллица сравнивается с латиницей через транслитерацию.
+// @Description Годы жизни дублей могут различаться не больше чем на 2 года, неизвестный год смерти совместим с любым.
+// @Description Пары возвращаются для проверки в порядке убывания сходства
+// @Tags Авторы
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Success 200 {array} jsonreqresp.AuthorDuplicateResponse
+// @Router /employee/authors/duplicates [get]
+func (r *AuthorRouter) GetAuthorDuplicates(c *gin.Context) {
+	ctx := c.Request.Context()
+	duplicates, err := r.authorServ.FindDuplicates(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	duplicatesResp := make([]jsonreqresp.AuthorDuplicateResponse, len(duplicates))
+	for i, d := range duplicates {
+		duplicatesResp[i] = d.ToAuthorDuplicateResponse()
+	}
+	c.JSON(http.StatusOK, duplicatesResp)
+}
+
+// MergeAuthors godoc
+// @Summary Объединить дубль с автором (сотрудник)
+// @Description Передает основному автору все произведения дубля, в том числе те, где дубль дополнительный автор,
+// @Description и удаляет дубль в одной транзакции. Объединение сохраняется в истории изменений обоих авторов
+// @Tags Авторы
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.MergeAuthorsRequest true "Основной автор и дубль"
+// @Success 200 {object} jsonreqresp.MergeAuthorsResponse
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Автор не найден"
+// @Failure 500 "Датировка произведений дубля не соответствует годам жизни основного автора"
+// @Router /employee/authors/merge [post]
+func (r *AuthorRouter) MergeAuthors(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req jsonreqresp.MergeAuthorsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	moved, err := r.authorServ.Merge(ctx, uuid.MustParse(req.SurvivorID), uuid.MustParse(req.DuplicateID))
+	if err != nil {
+		if errors.Is(err, authorrep.ErrAuthorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if errors.Is(err, authorserv.ErrMergeSameAuthor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, jsonreqresp.MergeAuthorsResponse{MovedArtworks: moved})
+}
+
+// GetAuthor godoc
+// @Summary Получить автора с профилем (сотрудник)
+// @Description Возвращает автора вместе с биографией, направлениями, портретом и внешними идентификаторами
+// @Tags Авторы
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID автора"
+// @Success 200 {object} jsonreqresp.AuthorProfileResponse
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Автор не найден"
+// @Router /employee/authors/{id} [get]
+func (r *AuthorRouter) GetAuthor(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author id"})
+		return
+	}
+
+	author, err := r.authorServ.GetByID(ctx, authorID)
+	if err != nil {
+		handleAuthorErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, author.ToAuthorProfileResponse())
+}
+
+// UpdateAuthorProfile godoc
+// @Summary Изменить профиль автора (сотрудник)
+// @Description Заменяет профиль автора целиком: биографию, национальность, направления, места рождения и смерти,
+// @Description идентификаторы ULAN (9 цифр, начиная с 500) и Wikidata (Q и цифры). Пустое поле очищает значение.
+// @Description Изменение сохраняется в истории автора
+// @Tags Авторы
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID автора"
+// @Param request body jsonreqresp.AuthorProfileRequest true "Профиль автора"
+// @Success 200 {object} jsonreqresp.AuthorProfileResponse
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Автор не найден"
+// @Router /employee/authors/{id}/profile [put]
+func (r *AuthorRouter) UpdateAuthorProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author id"})
+		return
+	}
+
+	var req jsonreqresp.AuthorProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.authorServ.UpdateProfile(ctx, authorID, req); err != nil {
+		handleAuthorErr(c, err)
+		return
+	}
+	author, err := r.authorServ.GetByID(ctx, authorID)
+	if err != nil {
+		handleAuthorErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, author.ToAuthorProfileResponse())
+}
+
+// UploadAuthorPortrait godoc
+// @Summary Загрузить портрет автора (сотрудник)
+// @Description Загружает портрет (jpeg, png, webp, до 5 МБ) и заменяет прежний
+// @Tags Авторы
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID автора"
+// @Param portrait formData file true "Файл портрета"
+// @Success 200 {object} jsonreqresp.AuthorProfileResponse
+// @Failure 400 "Неверный формат файла"
+// @Failure 404 "Автор не найден"
+// @Failure 413 "Файл слишком большой"
+// @Router /employee/authors/{id}/portrait [put]
+func (r *AuthorRouter) UploadAuthorPortrait(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author id"})
+		return
+	}
+
+	// запас на заголовки multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.AuthorPortraitMaxSize+1<<20)
+	fileHeader, err := c.FormFile("portrait")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": authorserv.ErrPortraitTooLarge.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if fileHeader.Size > models.AuthorPortraitMaxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": authorserv.ErrPortraitTooLarge.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	if err := r.authorServ.SetPortrait(ctx, authorID, file); err != nil {
+		handleAuthorErr(c, err)
+		return
+	}
+	author, err := r.authorServ.GetByID(ctx, authorID)
+	if err != nil {
+		handleAuthorErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, author.ToAuthorProfileResponse())
+}
+
+// DeleteAuthorPortrait godoc
+// @Summary Удалить портрет автора (сотрудник)
+// @Tags Авторы
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID автора"
+// @Success 200 "Портрет удален"
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Автор не найден"
+// @Router /employee/authors/{id}/portrait [delete]
+func (r *AuthorRouter) DeleteAuthorPortrait(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author id"})
+		return
+	}
+
+	if err := r.authorServ.DeletePortrait(ctx, authorID); err != nil {
+		handleAuthorErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func handleAuthorErr(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authorrep.ErrAuthorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrValidateAuthorProfile) || errors.Is(err, authorserv.ErrPortraitDecode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, authorserv.ErrPortraitTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
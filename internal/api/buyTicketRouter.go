@@ -46,7 +46,7 @@ func NewBuyTicketRouter(router *gin.RouterGroup, buyTicketServ buyticketserv.Buy
 // @Failure 400 "Неверный формат запроса"
 // @Failure 401 "Не авторизован"
 // @Failure 404 "Мероприятие не найдено"
-// @Failure 409 "Нет доступных билетов"
+// @Failure 409 "Нет доступных билетов или мероприятие не одобрено к продаже"
 // @Failure 410 "Транзакция просрочена"
 // @Router /guest/tickets [post]
 func (r *BuyTicketRouter) BuyTickets(c *gin.Context) {
@@ -61,7 +61,7 @@ func (r *BuyTicketRouter) BuyTickets(c *gin.Context) {
 		ctx, uuid.MustParse(req.EventID), req.CntTickets,
 		req.CustomerName, req.CustomerEmail)
 	if err != nil {
-		if errors.Is(err, buyticketserv.ErrNoFreeTicket) {
+		if errors.Is(err, buyticketserv.ErrNoFreeTicket) || errors.Is(err, buyticketserv.ErrEventNotOnSale) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		} else if errors.Is(err, buyticketserv.ErrNoUserData) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
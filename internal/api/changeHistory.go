@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/gin-gonic/gin"
+)
+
+func changeHistoryResponse(records []*models.ChangeRecord) []jsonreqresp.ChangeRecordResponse {
+	resp := make([]jsonreqresp.ChangeRecordResponse, len(records))
+	for i, record := range records {
+		resp[i] = record.ToChangeRecordResponse()
+	}
+	return resp
+}
+
+// parseVersionParam читает номер версии из пути; при ошибке отвечает 400 и возвращает false
+func parseVersionParam(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return 0, false
+	}
+	return version, true
+}
+
+// handleVersionNotFoundErr отвечает 404, если версии нет в истории; возвращает false, если ошибка другая
+func handleVersionNotFoundErr(c *gin.Context, err error) bool {
+	if errors.Is(err, historyserv.ErrVersionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return true
+	}
+	return false
+}
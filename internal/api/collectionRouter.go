@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
@@ -23,6 +24,9 @@ func (r *CollectionRouter) Init(router *gin.RouterGroup, collectionServ collecti
 	gr.POST("/", r.AddCollection)
 	gr.PUT("/", r.UpdateCollection)
 	gr.DELETE("/", r.DeleteCollection)
+	gr.GET("/:id/stats", r.GetCollectionStats)
+	gr.GET("/:id/history", r.GetCollectionHistory)
+	gr.POST("/:id/history/:version/revert", r.RevertCollection)
 }
 
 // GetAllCollections godoc
@@ -50,7 +54,8 @@ func (r *CollectionRouter) GetAllCollections(c *gin.Context) {
 
 // AddCollection godoc
 // @Summary Добавить новую коллекцию (сотрудник)
-// @Description Создает новую коллекцию
+// @Description Создает новую коллекцию, вложенную в parentId или корневую.
+// @Description Глубина вложенности коллекций - не более 5 уровней.
 // @Tags Коллекции
 // @Accept json
 // @Produce json
@@ -58,7 +63,7 @@ func (r *CollectionRouter) GetAllCollections(c *gin.Context) {
 // @Param Authorization header string true "bearer {token}"
 // @Param request body jsonreqresp.AddCollectionRequest true "Данные коллекции"
 // @Success 201 "Коллекция создана"
-// @Failure 400 "Неверный запрос"
+// @Failure 400 "Неверный запрос или родительская коллекция"
 // @Router /employee/collections [post]
 func (r *CollectionRouter) AddCollection(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -74,10 +79,18 @@ func (r *CollectionRouter) AddCollection(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := col.SetParent(collectionParentID(req.ParentID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	err = r.collectionServ.Add(ctx, &col)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, models.ErrValidateCollection) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{})
@@ -85,7 +98,8 @@ func (r *CollectionRouter) AddCollection(c *gin.Context) {
 
 // UpdateCollection godoc
 // @Summary Обновить коллекцию (сотрудник)
-// @Description Обновляет существующую коллекцию
+// @Description Обновляет название коллекции и переносит ее в родительскую коллекцию parentId, без parentId коллекция становится корневой.
+// @Description Коллекцию нельзя вложить в саму себя или в ее вложенную коллекцию.
 // @Tags Коллекции
 // @Accept json
 // @Produce json
@@ -93,7 +107,7 @@ func (r *CollectionRouter) AddCollection(c *gin.Context) {
 // @Param Authorization header string true "bearer {token}"
 // @Param request body jsonreqresp.UpdateCollectionRequest true "Данные для обновления коллекции"
 // @Success 200 "Коллекция обновлена"
-// @Failure 400 "Неверный запрос"
+// @Failure 400 "Неверный запрос или родительская коллекция"
 // @Failure 404 "Коллекция не найдена"
 // @Router /employee/collections [put]
 func (r *CollectionRouter) UpdateCollection(c *gin.Context) {
@@ -105,9 +119,14 @@ func (r *CollectionRouter) UpdateCollection(c *gin.Context) {
 		return
 	}
 
-	err := r.collectionServ.Update(ctx, uuid.MustParse(req.ID), models.CollectionUpdateReq{Title: req.Title})
+	err := r.collectionServ.Update(ctx, uuid.MustParse(req.ID), models.CollectionUpdateReq{
+		Title:    req.Title,
+		ParentID: collectionParentID(req.ParentID),
+	})
 	if err != nil {
-		if errors.Is(err, collectionrep.ErrCollectionNotFound) {
+		if errors.Is(err, models.ErrValidateCollection) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, collectionrep.ErrCollectionNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -119,7 +138,8 @@ func (r *CollectionRouter) UpdateCollection(c *gin.Context) {
 
 // DeleteCollection godoc
 // @Summary Удалить коллекцию (сотрудник)
-// @Description Удаляет существующую коллекцию
+// @Description Переносит коллекцию в корзину, откуда её можно восстановить до окончательной очистки.
+// @Description Коллекцию, в которой есть произведения или вложенные коллекции вне корзины, удалить нельзя - они возвращаются в ответе 409.
 // @Tags Коллекции
 // @Accept json
 // @Produce json
@@ -129,6 +149,7 @@ func (r *CollectionRouter) UpdateCollection(c *gin.Context) {
 // @Success 200 "Коллекция удалена"
 // @Failure 400 "Неверный запрос"
 // @Failure 404 "Коллекция не найдена"
+// @Failure 409 {object} jsonreqresp.DependencyErrorResponse "В коллекции есть произведения или вложенные коллекции"
 // @Router /employee/collections [delete]
 func (r *CollectionRouter) DeleteCollection(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -140,6 +161,51 @@ func (r *CollectionRouter) DeleteCollection(c *gin.Context) {
 	}
 
 	err := r.collectionServ.Delete(ctx, uuid.MustParse(req.ID))
+	if err != nil {
+		if handleDependencyErr(c, err) {
+			return
+		}
+		if errors.Is(err, collectionrep.ErrCollectionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+
+}
+
+// GetCollectionStats godoc
+// @Summary Получить статистику коллекции (сотрудник)
+// @Description Возвращает число произведений коллекции по авторам, векам и техникам,
+// @Description а также мероприятия, в которых участвовали произведения коллекции. Произведения в корзине не учитываются.
+// @Tags Коллекции
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID коллекции"
+// @Param subcollections query bool false "Учитывать произведения вложенных коллекций"
+// @Success 200 {object} jsonreqresp.CollectionStatsResponse
+// @Failure 400 "Неверный ID или параметр"
+// @Failure 404 "Коллекция не найдена"
+// @Router /employee/collections/{id}/stats [get]
+func (r *CollectionRouter) GetCollectionStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection id"})
+		return
+	}
+	includeSubcollections := false
+	if value := c.Query(jsonreqresp.SubcollectionsParamArtwork); value != "" {
+		if includeSubcollections, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subcollections parameter"})
+			return
+		}
+	}
+
+	stats, err := r.collectionServ.GetStats(ctx, collectionID, includeSubcollections)
 	if err != nil {
 		if errors.Is(err, collectionrep.ErrCollectionNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -148,6 +214,82 @@ func (r *CollectionRouter) DeleteCollection(c *gin.Context) {
 		}
 		return
 	}
+	c.JSON(http.StatusOK, stats.ToCollectionStatsResponse())
+}
+
+// GetCollectionHistory godoc
+// @Summary Получить историю изменений коллекции (сотрудник)
+// @Description Возвращает версии коллекции в порядке возрастания с изменившимися полями, автором и временем изменения.
+// @Description История сохраняется и после удаления коллекции.
+// @Tags Коллекции
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID коллекции"
+// @Success 200 {array} jsonreqresp.ChangeRecordResponse
+// @Failure 400 "Неверный ID"
+// @Router /employee/collections/{id}/history [get]
+func (r *CollectionRouter) GetCollectionHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection id"})
+		return
+	}
+
+	history, err := r.collectionServ.GetHistory(ctx, collectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeHistoryResponse(history))
+}
+
+// RevertCollection godoc
+// @Summary Вернуть коллекцию к версии из истории (сотрудник)
+// @Description Восстанавливает значения полей коллекции из указанной версии, версия 0 - состояние до первого изменения.
+// @Description Возврат сохраняется в истории как новая версия.
+// @Tags Коллекции
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID коллекции"
+// @Param version path int true "Номер версии"
+// @Success 200 "Успешно восстановлено"
+// @Failure 400 "Неверный ID или номер версии"
+// @Failure 404 "Коллекция или версия не найдены"
+// @Router /employee/collections/{id}/history/{version}/revert [post]
+func (r *CollectionRouter) RevertCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection id"})
+		return
+	}
+	version, ok := parseVersionParam(c)
+	if !ok {
+		return
+	}
+
+	err = r.collectionServ.Revert(ctx, collectionID, version)
+	if err != nil {
+		if handleVersionNotFoundErr(c, err) {
+			return
+		} else if errors.Is(err, collectionrep.ErrCollectionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{})
+}
 
+// collectionParentID возвращает родительскую коллекцию из запроса, пустая строка - корневая коллекция.
+// Формат uuid проверяется при разборе запроса
+func collectionParentID(parentID string) uuid.UUID {
+	if parentID == "" {
+		return uuid.Nil
+	}
+	return uuid.MustParse(parentID)
 }
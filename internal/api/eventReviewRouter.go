@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/eventserv"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type EventReviewRouter struct {
+	eventServ eventserv.EventService
+}
+
+func NewEventReviewRouter(router *gin.RouterGroup, eventServ eventserv.EventService) EventReviewRouter {
+	r := EventReviewRouter{
+		eventServ: eventServ,
+	}
+	gr := router.Group("events")
+	gr.GET("/pending", r.GetPendingEvents)
+	gr.POST("/:id/approve", r.ApproveEvent)
+	gr.POST("/:id/reject", r.RejectEvent)
+	return r
+}
+
+func handleEventReviewErr(c *gin.Context, err error) {
+	if handleEventPermissionErr(c, err) {
+		return
+	} else if errors.Is(err, eventrep.ErrEventNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if errors.Is(err, models.ErrEventStatusChange) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	} else if errors.Is(err, models.ErrValidateEventReview) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetPendingEvents godoc
+// @Summary Получить мероприятия на согласовании (админ)
+// @Description Возвращает мероприятия в статусе pending, ожидающие решения администратора
+// @Tags Согласование мероприятий
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Success 200 {array} jsonreqresp.EventResponse
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав"
+// @Router /admin/events/pending [get]
+func (r *EventReviewRouter) GetPendingEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	events, err := r.eventServ.GetPendingReview(ctx)
+	if err != nil {
+		handleEventReviewErr(c, err)
+		return
+	}
+	eventsResp := make([]jsonreqresp.EventResponse, len(events))
+	for i, e := range events {
+		eventsResp[i] = e.ToEventResponse()
+	}
+	c.JSON(http.StatusOK, eventsResp)
+}
+
+// ApproveEvent godoc
+// @Summary Одобрить мероприятие (админ)
+// @Description Публикует мероприятие: оно появляется в поиске и открывается продажа билетов
+// @Tags Согласование мероприятий
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param request body jsonreqresp.ReviewEventRequest false "Комментарий"
+// @Success 200 "Мероприятие одобрено"
+// @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав"
+// @Failure 404 "Не найдено - мероприятие не найдено"
+// @Failure 409 "Мероприятие не находится на согласовании"
+// @Router /admin/events/{id}/approve [post]
+func (r *EventReviewRouter) ApproveEvent(c *gin.Context) {
+	r.review(c, r.eventServ.Approve)
+}
+
+// RejectEvent godoc
+// @Summary Отклонить мероприятие (админ)
+// @Description Возвращает мероприятие сотруднику на доработку. Комментарий обязателен
+// @Tags Согласование мероприятий
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param request body jsonreqresp.ReviewEventRequest true "Причина отклонения"
+// @Success 200 "Мероприятие отклонено"
+// @Failure 400 "Неверный запрос - ошибка валидации или нет комментария"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав"
+// @Failure 404 "Не найдено - мероприятие не найдено"
+// @Failure 409 "Мероприятие не находится на согласовании"
+// @Router /admin/events/{id}/reject [post]
+func (r *EventReviewRouter) RejectEvent(c *gin.Context) {
+	r.review(c, r.eventServ.Reject)
+}
+
+func (r *EventReviewRouter) review(c *gin.Context, decide func(ctx context.Context, eventID uuid.UUID, comment string) error) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	var req jsonreqresp.ReviewEventRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := decide(ctx, eventID, req.Comment); err != nil {
+		handleEventReviewErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
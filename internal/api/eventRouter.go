@@ -32,9 +32,33 @@ func NewEventRouter(router *gin.RouterGroup, eventServ eventserv.EventService, a
 	gr.PUT("/:id", r.AddArtworkToEvent)
 	gr.DELETE("/:id", r.DeleteArtworkFromEvent)
 	gr.GET("/:id/artworks", r.GetArtworkFromEvent)
+	gr.POST("/:id/clone", r.CloneEvent)
+	gr.POST("/:id/template", r.SaveEventAsTemplate)
+	gr.POST("/:id/submit", r.SubmitEvent)
+	gr.GET("/:id/reviews", r.GetEventReviews)
+	gr.GET("/:id/organisers", r.GetEventOrganisers)
+	gr.POST("/:id/organisers", r.AddEventOrganiser)
+	gr.DELETE("/:id/organisers", r.DeleteEventOrganiser)
+	gr.GET("/:id/history", r.GetEventHistory)
+	gr.POST("/:id/history/:version/revert", r.RevertEvent)
+	gr.GET("/templates", r.GetEventTemplates)
+	gr.POST("/templates/:id", r.AddEventFromTemplate)
+	gr.DELETE("/templates/:id", r.DeleteEventTemplate)
 	return r
 }
 
+// handleEventPermissionErr отвечает 401/403 на ошибки проверки прав; возвращает false, если ошибка другая
+func handleEventPermissionErr(c *gin.Context, err error) bool {
+	if errors.Is(err, auth.ErrNotAuthZ) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	} else if errors.Is(err, eventserv.ErrEventForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	} else {
+		return false
+	}
+	return true
+}
+
 // GetAllEvents godoc
 // @Summary Получить все мероприятия (сотрудник)
 // @Description Возвращает список всех мероприятий
@@ -71,6 +95,7 @@ func (r *EventRouter) GetAllEvents(c *gin.Context) {
 // @Success 201 "Мероприятие успешно создано"
 // @Failure 400 "Неверный запрос - ошибка валидации"
 // @Failure 401 "Не авторизован"
+// @Failure 403 "Сотрудник может создавать только от своего имени"
 // @Failure 404 "Не найдено - сотрудник не найден"
 // @Router /employee/events [post]
 func (r *EventRouter) AddEvent(c *gin.Context) {
@@ -81,22 +106,20 @@ func (r *EventRouter) AddEvent(c *gin.Context) {
 		return
 	}
 
-	employeeID, err := r.authZ.EmployeeIDFromContext(ctx)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-		return
-	}
 	addReq := jsonreqresp.EventAdd{
 		Title:      req.Title,
 		DateBegin:  req.DateBegin,
 		DateEnd:    req.DateEnd,
 		Address:    req.Address,
 		CanVisit:   *req.CanVisit,
-		EmployeeID: employeeID,
+		EmployeeID: jsonreqresp.CreatorID(req.EmployeeID),
 		CntTickets: *req.CntTickets,
 		ArtworkIDs: req.ArtworkIDs,
 	}
 	if err := r.eventServ.Add(ctx, &addReq); err != nil {
+		if handleEventPermissionErr(c, err) {
+			return
+		}
 		if errors.Is(err, eventrep.ErrAddNoEmployee) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else if errors.Is(err, models.ErrValidateEvent) || errors.Is(err, eventserv.ErrArtworkBusy) {
@@ -120,6 +143,8 @@ func (r *EventRouter) AddEvent(c *gin.Context) {
 // @Param request body jsonreqresp.DeleteEventRequest true "Данные для удаления мероприятия"
 // @Success 200 "Мероприятие успешно удалено"
 // @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем или соорганизатором"
 // @Failure 404 "Не найдено - мероприятие не найдено"
 // @Router /employee/events [delete]
 func (r *EventRouter) DeleteEvent(c *gin.Context) {
@@ -131,7 +156,9 @@ func (r *EventRouter) DeleteEvent(c *gin.Context) {
 	}
 
 	if err := r.eventServ.Delete(ctx, uuid.MustParse(req.ID)); err != nil {
-		if errors.Is(err, eventrep.ErrEventNotFound) {
+		if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, eventrep.ErrEventNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -143,7 +170,7 @@ func (r *EventRouter) DeleteEvent(c *gin.Context) {
 
 // UpdateEvent godoc
 // @Summary Обновить мероприятие (сотрудник)
-// @Description Обновляет существующее мероприятие
+// @Description Обновляет существующее мероприятие. Одобренное мероприятие, измененное сотрудником, возвращается на повторное согласование
 // @Tags Мероприятия
 // @Accept json
 // @Produce json
@@ -152,6 +179,8 @@ func (r *EventRouter) DeleteEvent(c *gin.Context) {
 // @Param request body jsonreqresp.UpdateEventRequest true "Данные для обновления мероприятия"
 // @Success 200 "Мероприятие успешно обновлено"
 // @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем или соорганизатором"
 // @Failure 404 "Не найдено - мероприятие не найдено"
 // @Router /employee/events [put]
 func (r *EventRouter) UpdateEvent(c *gin.Context) {
@@ -174,7 +203,9 @@ func (r *EventRouter) UpdateEvent(c *gin.Context) {
 			// Valid:      req.Valid,
 		})
 	if err != nil {
-		if errors.Is(err, eventrep.ErrEventNotFound) {
+		if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, eventrep.ErrEventNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -195,7 +226,9 @@ func (r *EventRouter) UpdateEvent(c *gin.Context) {
 // @Param id path string true "ID мероприятия"
 // @Param request body jsonreqresp.ConArtworkEventRequest true "Данные для связи произведения с мероприятием"
 // @Success 200 "Произведение успешно добавлено к мероприятию"
-// @Failure 400 "Неверный запрос - ошибка валидации или дублирование произведения"
+// @Failure 400 "Неверный запрос - ошибка валидации, дублирование произведения или произведение на реставрации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем или соорганизатором"
 // @Failure 404 "Не найдено - мероприятие или произведение не найдено"
 // @Router /employee/events/{id} [PUT]
 func (r *EventRouter) AddArtworkToEvent(c *gin.Context) {
@@ -216,7 +249,10 @@ func (r *EventRouter) AddArtworkToEvent(c *gin.Context) {
 	artworkIDs := uuid.UUIDs{uuid.MustParse(req.ArtworkID)}
 	err = r.eventServ.AddArtworksToEvent(ctx, eventID, artworkIDs)
 	if err != nil {
-		if errors.Is(err, models.ErrDuplicateArtwokIDs) || errors.Is(err, models.ErrAddArtwork) {
+		if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, models.ErrDuplicateArtwokIDs) || errors.Is(err, models.ErrAddArtwork) ||
+			errors.Is(err, eventserv.ErrArtworkBusy) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else if errors.Is(err, eventrep.ErrEventNotFound) || errors.Is(err, artworkrep.ErrArtworkNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -240,6 +276,8 @@ func (r *EventRouter) AddArtworkToEvent(c *gin.Context) {
 // @Param request body jsonreqresp.ConArtworkEventRequest true "Данные для связи произведения с мероприятием"
 // @Success 200 "Произведение успешно удалено из мероприятия"
 // @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем или соорганизатором"
 // @Failure 404 "Не найдено - мероприятие или произведение не найдено"
 // @Router /employee/events/{id} [delete]
 func (r *EventRouter) DeleteArtworkFromEvent(c *gin.Context) {
@@ -259,7 +297,9 @@ func (r *EventRouter) DeleteArtworkFromEvent(c *gin.Context) {
 
 	err = r.eventServ.DeleteArtworkFromEvent(ctx, eventID, uuid.MustParse(req.ArtworkID))
 	if err != nil {
-		if errors.Is(err, models.ErrDuplicateArtwokIDs) || errors.Is(err, models.ErrAddArtwork) {
+		if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, models.ErrDuplicateArtwokIDs) || errors.Is(err, models.ErrAddArtwork) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else if errors.Is(err, eventrep.ErrEventNotFound) ||
 			errors.Is(err, artworkrep.ErrArtworkNotFound) ||
@@ -312,3 +352,475 @@ func (r *EventRouter) GetArtworkFromEvent(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, artworksResp)
 }
+
+// SubmitEvent godoc
+// @Summary Отправить мероприятие на согласование (сотрудник)
+// @Description Переводит черновик или отклоненное мероприятие в статус pending. До одобрения администратором мероприятие не видно посетителям
+// @Tags Мероприятия
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param request body jsonreqresp.ReviewEventRequest false "Комментарий для администратора"
+// @Success 200 "Мероприятие отправлено на согласование"
+// @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем или соорганизатором"
+// @Failure 404 "Не найдено - мероприятие не найдено"
+// @Failure 409 "Мероприятие уже на согласовании или одобрено"
+// @Router /employee/events/{id}/submit [post]
+func (r *EventRouter) SubmitEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	var req jsonreqresp.ReviewEventRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := r.eventServ.Submit(ctx, eventID, req.Comment); err != nil {
+		handleEventReviewErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetEventReviews godoc
+// @Summary Получить историю согласования мероприятия (сотрудник)
+// @Description Возвращает смены статуса мероприятия в хронологическом порядке
+// @Tags Мероприятия
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Success 200 {array} jsonreqresp.EventReviewResponse
+// @Failure 400 "Неверный формат ID"
+// @Failure 404 "Не найдено - мероприятие не найдено"
+// @Router /employee/events/{id}/reviews [get]
+func (r *EventRouter) GetEventReviews(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	reviews, err := r.eventServ.GetReviews(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, eventrep.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	resp := make([]jsonreqresp.EventReviewResponse, len(reviews))
+	for i, review := range reviews {
+		resp[i] = review.ToEventReviewResponse()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetEventOrganisers godoc
+// @Summary Получить соорганизаторов мероприятия (сотрудник)
+// @Description Возвращает ID сотрудников, которым создатель разрешил изменять мероприятие
+// @Tags Мероприятия
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Success 200 {object} jsonreqresp.EventOrganisersResponse
+// @Failure 400 "Неверный формат ID"
+// @Failure 404 "Не найдено - мероприятие не найдено"
+// @Router /employee/events/{id}/organisers [get]
+func (r *EventRouter) GetEventOrganisers(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	organiserIDs, err := r.eventServ.GetOrganisers(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, eventrep.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	resp := jsonreqresp.EventOrganisersResponse{OrganiserIDs: make([]string, len(organiserIDs))}
+	for i, id := range organiserIDs {
+		resp.OrganiserIDs[i] = id.String()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddEventOrganiser godoc
+// @Summary Добавить соорганизатора мероприятия (сотрудник)
+// @Description Разрешает сотруднику изменять мероприятие. Доступно создателю мероприятия и администратору
+// @Tags Мероприятия
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param request body jsonreqresp.EventOrganiserRequest true "ID сотрудника"
+// @Success 200 "Соорганизатор успешно добавлен"
+// @Failure 400 "Неверный запрос - ошибка валидации или сотрудник уже организует мероприятие"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем мероприятия"
+// @Failure 404 "Не найдено - мероприятие или сотрудник не найдены"
+// @Router /employee/events/{id}/organisers [post]
+func (r *EventRouter) AddEventOrganiser(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	var req jsonreqresp.EventOrganiserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = r.eventServ.AddOrganiser(ctx, eventID, uuid.MustParse(req.EmployeeID))
+	if err != nil {
+		if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, eventserv.ErrOrganiserExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, eventrep.ErrEventNotFound) || errors.Is(err, eventrep.ErrAddNoEmployee) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteEventOrganiser godoc
+// @Summary Удалить соорганизатора мероприятия (сотрудник)
+// @Description Отзывает у сотрудника право изменять мероприятие. Доступно создателю мероприятия и администратору
+// @Tags Мероприятия
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param request body jsonreqresp.EventOrganiserRequest true "ID сотрудника"
+// @Success 200 "Соорганизатор успешно удален"
+// @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем мероприятия"
+// @Failure 404 "Не найдено - мероприятие или соорганизатор не найдены"
+// @Router /employee/events/{id}/organisers [delete]
+func (r *EventRouter) DeleteEventOrganiser(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	var req jsonreqresp.EventOrganiserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = r.eventServ.DeleteOrganiser(ctx, eventID, uuid.MustParse(req.EmployeeID))
+	if err != nil {
+		if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, eventrep.ErrEventNotFound) || errors.Is(err, eventrep.ErrOrganiserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (r *EventRouter) handleCopyEventErr(c *gin.Context, err error) {
+	if handleEventPermissionErr(c, err) {
+		return
+	}
+	if errors.Is(err, eventrep.ErrAddNoEmployee) ||
+		errors.Is(err, eventrep.ErrEventNotFound) ||
+		errors.Is(err, eventrep.ErrEventTemplateNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if errors.Is(err, models.ErrValidateEvent) ||
+		errors.Is(err, models.ErrValidateEventTemplate) ||
+		errors.Is(err, eventserv.ErrArtworkBusy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CloneEvent godoc
+// @Summary Клонировать мероприятие (сотрудник)
+// @Description Создает новое мероприятие с параметрами и произведениями исходного на новые даты
+// @Tags Мероприятия
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID исходного мероприятия"
+// @Param request body jsonreqresp.CloneEventRequest true "Новые даты и (необязательно) название"
+// @Success 201 {object} jsonreqresp.EventIDResponse
+// @Failure 400 "Неверный запрос - ошибка валидации или произведение занято"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Сотрудник может создавать только от своего имени"
+// @Failure 404 "Не найдено - мероприятие или сотрудник не найдены"
+// @Router /employee/events/{id}/clone [post]
+func (r *EventRouter) CloneEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	var req jsonreqresp.CloneEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	newID, err := r.eventServ.Clone(ctx, eventID, &jsonreqresp.EventCopy{
+		Title:      req.Title,
+		DateBegin:  req.DateBegin,
+		DateEnd:    req.DateEnd,
+		EmployeeID: jsonreqresp.CreatorID(req.EmployeeID),
+	})
+	if err != nil {
+		r.handleCopyEventErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, jsonreqresp.EventIDResponse{ID: newID.String()})
+}
+
+// SaveEventAsTemplate godoc
+// @Summary Сохранить мероприятие как шаблон (сотрудник)
+// @Description Сохраняет название, адрес, доступность, количество билетов и произведения мероприятия как шаблон
+// @Tags Мероприятия
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param request body jsonreqresp.SaveEventTemplateRequest true "Название шаблона"
+// @Success 201 {object} jsonreqresp.EventIDResponse
+// @Failure 400 "Неверный запрос - ошибка валидации"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Сотрудник может создавать только от своего имени"
+// @Failure 404 "Не найдено - мероприятие или сотрудник не найдены"
+// @Router /employee/events/{id}/template [post]
+func (r *EventRouter) SaveEventAsTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+
+	var req jsonreqresp.SaveEventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmplID, err := r.eventServ.SaveAsTemplate(ctx, eventID, req.Name, jsonreqresp.CreatorID(req.EmployeeID))
+	if err != nil {
+		r.handleCopyEventErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, jsonreqresp.EventIDResponse{ID: tmplID.String()})
+}
+
+// GetEventTemplates godoc
+// @Summary Получить шаблоны мероприятий (сотрудник)
+// @Description Возвращает список всех шаблонов мероприятий
+// @Tags Мероприятия
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Success 200 {array} jsonreqresp.EventTemplateResponse
+// @Router /employee/events/templates [get]
+func (r *EventRouter) GetEventTemplates(c *gin.Context) {
+	ctx := c.Request.Context()
+	templates, err := r.eventServ.GetTemplates(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]jsonreqresp.EventTemplateResponse, len(templates))
+	for i, t := range templates {
+		resp[i] = t.ToEventTemplateResponse()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddEventFromTemplate godoc
+// @Summary Создать мероприятие по шаблону (сотрудник)
+// @Description Создает новое мероприятие по шаблону на указанные даты
+// @Tags Мероприятия
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID шаблона"
+// @Param request body jsonreqresp.CloneEventRequest true "Даты и (необязательно) название"
+// @Success 201 {object} jsonreqresp.EventIDResponse
+// @Failure 400 "Неверный запрос - ошибка валидации или произведение занято"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Сотрудник может создавать только от своего имени"
+// @Failure 404 "Не найдено - шаблон или сотрудник не найдены"
+// @Router /employee/events/templates/{id} [post]
+func (r *EventRouter) AddEventFromTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID format"})
+		return
+	}
+
+	var req jsonreqresp.CloneEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	newID, err := r.eventServ.AddFromTemplate(ctx, templateID, &jsonreqresp.EventCopy{
+		Title:      req.Title,
+		DateBegin:  req.DateBegin,
+		DateEnd:    req.DateEnd,
+		EmployeeID: jsonreqresp.CreatorID(req.EmployeeID),
+	})
+	if err != nil {
+		r.handleCopyEventErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, jsonreqresp.EventIDResponse{ID: newID.String()})
+}
+
+// DeleteEventTemplate godoc
+// @Summary Удалить шаблон мероприятия (сотрудник)
+// @Description Удаляет шаблон мероприятия. Удалить шаблон может его создатель или администратор
+// @Tags Мероприятия
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID шаблона"
+// @Success 200 "Шаблон успешно удален"
+// @Failure 400 "Неверный формат ID"
+// @Failure 403 "Доступ запрещен - шаблон создан другим сотрудником"
+// @Failure 404 "Не найдено - шаблон не найден"
+// @Router /employee/events/templates/{id} [delete]
+func (r *EventRouter) DeleteEventTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID format"})
+		return
+	}
+
+	if err := r.eventServ.DeleteTemplate(ctx, templateID); err != nil {
+		if errors.Is(err, eventrep.ErrEventTemplateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if errors.Is(err, eventserv.ErrEventForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetEventHistory godoc
+// @Summary Получить историю изменений мероприятия (сотрудник)
+// @Description Возвращает версии мероприятия в порядке возрастания с изменившимися полями, автором и временем изменения.
+// @Description История сохраняется и после удаления мероприятия.
+// @Tags Мероприятия
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Success 200 {array} jsonreqresp.ChangeRecordResponse
+// @Failure 400 "Неверный ID"
+// @Router /employee/events/{id}/history [get]
+func (r *EventRouter) GetEventHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return
+	}
+
+	history, err := r.eventServ.GetHistory(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeHistoryResponse(history))
+}
+
+// RevertEvent godoc
+// @Summary Вернуть мероприятие к версии из истории (сотрудник)
+// @Description Восстанавливает значения полей мероприятия из указанной версии, версия 0 - состояние до первого изменения.
+// @Description Возврат сохраняется в истории как новая версия.
+// @Tags Мероприятия
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID мероприятия"
+// @Param version path int true "Номер версии"
+// @Success 200 "Успешно восстановлено"
+// @Failure 400 "Неверный ID или номер версии"
+// @Failure 401 "Не авторизован"
+// @Failure 403 "Нет прав - сотрудник не является создателем или соорганизатором"
+// @Failure 404 "Мероприятие или версия не найдены"
+// @Router /employee/events/{id}/history/{version}/revert [post]
+func (r *EventRouter) RevertEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return
+	}
+	version, ok := parseVersionParam(c)
+	if !ok {
+		return
+	}
+
+	err = r.eventServ.Revert(ctx, eventID, version)
+	if err != nil {
+		if handleVersionNotFoundErr(c, err) {
+			return
+		} else if handleEventPermissionErr(c, err) {
+			return
+		} else if errors.Is(err, eventrep.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
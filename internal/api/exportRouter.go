@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/exportserv"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportRouter struct {
+	exportServ exportserv.ExportServ
+}
+
+func NewExportRouter(router *gin.RouterGroup, exportServ exportserv.ExportServ) ExportRouter {
+	r := ExportRouter{
+		exportServ: exportServ,
+	}
+	router.GET("/export", r.ExportCatalog)
+	return r
+}
+
+// exportResponseWriter выставляет заголовки файла выгрузки перед первой записью,
+// чтобы до начала выгрузки можно было ответить ошибкой в JSON
+type exportResponseWriter struct {
+	c      *gin.Context
+	format models.ExportFormat
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	if !w.c.Writer.Written() {
+		filename := "catalog-" + time.Now().Format("2006-01-02") + w.format.FileExtension()
+		w.c.Header("Content-Type", w.format.ContentType())
+		w.c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// ExportCatalog godoc
+// @Summary Выгрузка каталога (сотрудник)
+// @Description Выгружает произведения, подходящие под фильтр, вместе с авторами, коллекциями и согласованными
+// @Description мероприятиями. Форматы: csv, jsonl (jsonreqresp.ExportArtworkResponse в каждой строке),
+// @Description dc (Dublin Core XML) и lido (LIDO 1.0 XML). Ответ передается потоком по мере чтения каталога.
+// @Tags Экспорт
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/xml
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param format           query string     true   "Формат выгрузки" Enums(csv, jsonl, dc, lido)
+// @Param q                query string     false  "Полнотекстовый поиск (макс. 255 символов)"  maxLength(255)
+// @Param title            query string     false  "Фильтр по названию произведения (макс. 255 символов)"  maxLength(255)
+// @Param author_name      query string     false  "Фильтр по имени автора (макс. 100 символов)"    maxLength(100)
+// @Param collection_title query string     false  "Фильтр по названию коллекции (макс. 255 символов)" maxLength(255)
+// @Param event_id         query string     false  "Фильтр по ID мероприятия" format(uuid)
+// @Param technic          query []string   false  "Техника (можно несколько)"  collectionFormat(multi)
+// @Param material         query []string   false  "Материал (можно несколько)"  collectionFormat(multi)
+// @Param century          query []int      false  "Век создания (можно несколько)"  collectionFormat(multi)
+// @Param author_id        query []string   false  "ID автора (можно несколько)"  collectionFormat(multi)
+// @Param collection_id    query []string   false  "ID коллекции (можно несколько)"  collectionFormat(multi)
+// @Param subcollections   query bool       false  "Учитывать вложенные коллекции выбранных коллекций"
+// @Param tag_id           query []string   false  "ID тега, включая дочерние теги (можно несколько)"  collectionFormat(multi)
+// @Param year_from        query int        false  "Год создания не раньше" minimum(1)
+// @Param year_to          query int        false  "Год создания не позже" minimum(1)
+// @Param height_min       query number     false  "Высота не меньше, см" minimum(0)
+// @Param height_max       query number     false  "Высота не больше, см" minimum(0)
+// @Param width_min        query number     false  "Ширина не меньше, см" minimum(0)
+// @Param width_max        query number     false  "Ширина не больше, см" minimum(0)
+// @Param depth_min        query number     false  "Глубина не меньше, см" minimum(0)
+// @Param depth_max        query number     false  "Глубина не больше, см" minimum(0)
+// @Param weight_min       query number     false  "Вес не меньше, кг" minimum(0)
+// @Param weight_max       query number     false  "Вес не больше, кг" minimum(0)
+// @Success 200 {file} file "Файл выгрузки"
+// @Failure 400 "Неверный формат или параметры фильтрации"
+// @Router /employee/export [get]
+func (r *ExportRouter) ExportCatalog(c *gin.Context) {
+	ctx := c.Request.Context()
+	format := models.ExportFormat(c.Query("format"))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": models.ErrExportFormat.Error()})
+		return
+	}
+	filterOps, err := artworkFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	w := &exportResponseWriter{c: c, format: format}
+	err = r.exportServ.Export(ctx, w, format, &filterOps)
+	if err == nil {
+		// пустая выгрузка в jsonl ничего не пишет, заголовки файла отправляются отдельно
+		_, _ = w.Write(nil)
+		return
+	}
+	if c.Writer.Written() {
+		// заголовки уже отправлены, выгрузка обрывается
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	if errors.Is(err, jsonreqresp.ErrSearchQueryTooLong) ||
+		errors.Is(err, jsonreqresp.ErrArtworkYearRange) ||
+		errors.Is(err, jsonreqresp.ErrArtworkDimensionRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/iiifserv"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IIIFRouter struct {
+	iiifServ iiifserv.IIIFServ
+}
+
+func NewIIIFRouter(router *gin.RouterGroup, iiifServ iiifserv.IIIFServ) IIIFRouter {
+	r := IIIFRouter{
+		iiifServ: iiifServ,
+	}
+	gr := router.Group("iiif")
+	gr.GET("/artworks/:id/manifest", r.GetManifest)
+	gr.GET("/collections", r.GetCollections)
+	gr.GET("/collections/:id", r.GetCollection)
+	return r
+}
+
+// iiifJSON отвечает документом IIIF с типом содержимого по спецификации Presentation 3.0
+func iiifJSON(c *gin.Context, doc any) {
+	c.Header("Content-Type", jsonreqresp.IIIFContentType)
+	c.JSON(http.StatusOK, doc)
+}
+
+// GetManifest godoc
+// @Summary Манифест IIIF произведения
+// @Description Возвращает манифест IIIF Presentation 3.0: метаданные произведения и холст на каждое изображение.
+// @Description У произведения без изображений один пустой холст
+// @Tags IIIF
+// @Produce json
+// @Param id path string true "ID произведения"
+// @Success 200 {object} jsonreqresp.IIIFManifest
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Произведение не найдено"
+// @Router /iiif/artworks/{id}/manifest [get]
+func (r *IIIFRouter) GetManifest(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork ID format"})
+		return
+	}
+	manifest, err := r.iiifServ.GetManifest(ctx, artworkID)
+	if err != nil {
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	iiifJSON(c, manifest)
+}
+
+// GetCollection godoc
+// @Summary Коллекция IIIF
+// @Description Возвращает коллекцию IIIF Presentation 3.0 со ссылками на манифесты всех произведений коллекции
+// @Tags IIIF
+// @Produce json
+// @Param id path string true "ID коллекции"
+// @Success 200 {object} jsonreqresp.IIIFCollection
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Коллекция не найдена"
+// @Router /iiif/collections/{id} [get]
+func (r *IIIFRouter) GetCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection ID format"})
+		return
+	}
+	collection, err := r.iiifServ.GetCollection(ctx, collectionID)
+	if err != nil {
+		if errors.Is(err, collectionrep.ErrCollectionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	iiifJSON(c, collection)
+}
+
+// GetCollections godoc
+// @Summary Коллекции музея в IIIF
+// @Description Возвращает коллекцию IIIF верхнего уровня со ссылками на коллекции IIIF всех коллекций музея
+// @Tags IIIF
+// @Produce json
+// @Success 200 {object} jsonreqresp.IIIFCollection
+// @Router /iiif/collections [get]
+func (r *IIIFRouter) GetCollections(c *gin.Context) {
+	ctx := c.Request.Context()
+	collections, err := r.iiifServ.GetCollections(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	iiifJSON(c, collections)
+}
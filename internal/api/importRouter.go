@@ -0,0 +1,100 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/importserv"
+	"github.com/gin-gonic/gin"
+)
+
+type ImportRouter struct {
+	importServ importserv.ImportServ
+}
+
+func NewImportRouter(router *gin.RouterGroup, importServ importserv.ImportServ) ImportRouter {
+	r := ImportRouter{
+		importServ: importServ,
+	}
+	router.POST("/import", r.ImportCatalog)
+	return r
+}
+
+// ImportCatalog godoc
+// @Summary Импорт каталога из CSV или JSON (сотрудник)
+// @Description Загружает авторов, коллекции и произведения. JSON-файл содержит все разделы (jsonreqresp.CatalogImportRequest),
+// @Description CSV-файл - строки одного раздела entity с заголовком из имен полей JSON.
+// @Description Произведения ссылаются на авторов и коллекции по id или имени (названию); существующие авторы
+// @Description и коллекции с тем же id или именем не создаются повторно.
+// @Description Все строки проверяются, ошибки возвращаются по номерам строк. Записи сохраняются, только если ошибок нет
+// @Description и не задан dry_run.
+// @Tags Импорт
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param file formData file true "Файл импорта"
+// @Param format query string false "Формат файла, по умолчанию - по расширению" Enums(csv, json)
+// @Param entity query string false "Раздел CSV-файла" Enums(authors, collections, artworks)
+// @Param dry_run query bool false "Только проверить, не сохраняя записи"
+// @Success 200 {object} jsonreqresp.ImportReportResponse
+// @Failure 400 "Неверный файл или параметры"
+// @Failure 413 "Файл слишком большой"
+// @Router /employee/import [post]
+func (r *ImportRouter) ImportCatalog(c *gin.Context) {
+	ctx := c.Request.Context()
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
+			return
+		}
+	}
+
+	// запас на заголовки multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.ImportMaxSize+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": models.ErrImportTooLarge.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if fileHeader.Size > models.ImportMaxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": models.ErrImportTooLarge.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	format := models.ImportFormat(c.Query("format"))
+	if format == "" {
+		format = importserv.FormatByName(fileHeader.Filename)
+	}
+	req, err := importserv.DecodeImport(file, format, models.ImportEntity(c.Query("entity")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := r.importServ.Import(ctx, req, dryRun)
+	if err != nil {
+		if errors.Is(err, models.ErrImportEmpty) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, report.ToImportReportResponse())
+}
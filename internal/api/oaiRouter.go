@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/oaiserv"
+	"github.com/gin-gonic/gin"
+)
+
+type OAIRouter struct {
+	oaiServ oaiserv.OAIServ
+}
+
+func NewOAIRouter(router *gin.RouterGroup, oaiServ oaiserv.OAIServ) OAIRouter {
+	r := OAIRouter{
+		oaiServ: oaiServ,
+	}
+	router.GET("/oai", r.Handle)
+	router.POST("/oai", r.Handle)
+	return r
+}
+
+// Handle godoc
+// @Summary Точка OAI-PMH 2.0
+// @Description Сбор каталога агрегаторами по протоколу OAI-PMH 2.0: Identify, ListMetadataFormats, ListSets,
+// @Description ListIdentifiers, ListRecords и GetRecord в формате oai_dc. Набор соответствует коллекции,
+// @Description from и until отбирают произведения по времени последнего изменения.
+// @Description Ошибки протокола возвращаются в ответе с кодом 200, аргументы POST передаются как форма
+// @Tags OAI-PMH
+// @Accept x-www-form-urlencoded
+// @Produce xml
+// @Param verb query string true "Глагол OAI-PMH"
+// @Param identifier query string false "Идентификатор записи oai:<домен>:<ID произведения>"
+// @Param metadataPrefix query string false "Формат метаданных (oai_dc)"
+// @Param from query string false "Изменены не раньше (YYYY-MM-DD или YYYY-MM-DDThh:mm:ssZ)"
+// @Param until query string false "Изменены не позже (YYYY-MM-DD или YYYY-MM-DDThh:mm:ssZ)"
+// @Param set query string false "Набор - ID коллекции"
+// @Param resumptionToken query string false "Токен продолжения списка"
+// @Success 200 {string} string "Ответ OAI-PMH"
+// @Failure 400 "Неверная форма запроса"
+// @Router /oai [get]
+// @Router /oai [post]
+func (r *OAIRouter) Handle(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resp, err := r.oaiServ.Handle(c.Request.Context(), c.Request.Form)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	body, err := xml.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, "text/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
@@ -2,43 +2,78 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/buyticketserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/recommendserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/searcher"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type SearcherRouter struct {
-	serv searcher.Searcher
+	serv          searcher.Searcher
+	buyTicketServ buyticketserv.BuyTicketsServ
+	recommendServ recommendserv.RecommendServ
+	// publicURL внешний адрес сервиса для ссылок в разметке schema.org
+	publicURL string
 }
 
-func NewSearcherRouter(router *gin.RouterGroup, serv searcher.Searcher) SearcherRouter {
+func NewSearcherRouter(
+	router *gin.RouterGroup, serv searcher.Searcher, buyTicketServ buyticketserv.BuyTicketsServ,
+	recommendServ recommendserv.RecommendServ, optionalAuth gin.HandlerFunc, publicURL string,
+) SearcherRouter {
 	r := SearcherRouter{
-		serv: serv,
+		serv:          serv,
+		buyTicketServ: buyTicketServ,
+		recommendServ: recommendServ,
+		publicURL:     publicURL,
 	}
 	gr := router.Group("museum")
 	gr.GET("/artworks", r.GetAllArtworks)
+	gr.GET("/artworks/facets", r.GetArtworkFacets)
+	gr.GET("/artworks/:id", r.GetArtwork)
 	gr.GET("/events", r.GetAllEvents)
 	gr.GET("/events/:id", r.GetEvent)
 	gr.GET("/events/:id/artworks", r.GetArtworkFromEvent)
 	gr.GET("/events/:id/statcols", r.GetCollectionsStat)
+	gr.GET("/artworks/:id/events", r.GetArtworkEvents)
+	gr.GET("/artworks/:id/provenance", r.GetArtworkProvenance)
+	// авторизация необязательна: для вошедшего посетителя учитываются его избранные
+	gr.GET("/artworks/:id/similar", optionalAuth, r.GetSimilarArtworks)
+	gr.GET("/authors/:id/events", r.GetAuthorEvents)
+	gr.GET("/collections/:id/events", r.GetCollectionEvents)
 	return r
 }
 
+// wantsJSONLD проверяет, что клиент запросил разметку schema.org (Accept: application/ld+json)
+func wantsJSONLD(c *gin.Context) bool {
+	return c.NegotiateFormat(gin.MIMEJSON, jsonreqresp.JSONLDContentType) == jsonreqresp.JSONLDContentType
+}
+
+// jsonLD отвечает документом JSON-LD
+func jsonLD(c *gin.Context, doc any) {
+	c.Header("Content-Type", jsonreqresp.JSONLDContentType)
+	c.JSON(http.StatusOK, doc)
+}
+
 // GetEvent godoc
 // @Summary Получить мероприятие по ID
-// @Description Возвращает одно мероприятие по его идентификатору
+// @Description Возвращает одно мероприятие по его идентификатору.
+// @Description С заголовком Accept: application/ld+json возвращает schema.org ExhibitionEvent с предложением билетов
 // @Tags Поиск
 // @Accept json
-// @Produce json
+// @Produce json,application/ld+json
 // @Param id path string true "ID мероприятия"
 // @Success 200 {object} jsonreqresp.EventResponse
+// @Success 200 {object} jsonreqresp.SchemaOrgExhibitionEvent
 // @Failure 400 "Неверный формат ID"
 // @Failure 404 "Мероприятие не найдено"
 // @Router /museum/events/{id} [GET]
@@ -59,8 +94,58 @@ func (r *SearcherRouter) GetEvent(c *gin.Context) {
 		}
 		return
 	}
-	c.JSON(http.StatusOK, event.ToEventResponse())
+	if !wantsJSONLD(c) {
+		c.JSON(http.StatusOK, event.ToEventResponse())
+		return
+	}
 
+	artworks, err := r.serv.GetArtworksFromEvent(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	freeTickets, err := r.buyTicketServ.GetFreeTicketCount(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	jsonLD(c, event.ToSchemaOrg(r.publicURL, freeTickets, artworks))
+}
+
+// GetArtwork godoc
+// @Summary Получить произведение по ID
+// @Description Возвращает одно произведение по его идентификатору.
+// @Description С заголовком Accept: application/ld+json возвращает schema.org VisualArtwork
+// @Tags Поиск
+// @Accept json
+// @Produce json,application/ld+json
+// @Param id path string true "ID произведения"
+// @Success 200 {object} jsonreqresp.ArtworkResponse
+// @Success 200 {object} jsonreqresp.SchemaOrgVisualArtwork
+// @Failure 400 "Неверный формат ID"
+// @Failure 404 "Произведение не найдено"
+// @Router /museum/artworks/{id} [GET]
+func (r *SearcherRouter) GetArtwork(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork ID format"})
+		return
+	}
+	artwork, err := r.serv.GetArtwork(ctx, artworkID)
+	if err != nil {
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if wantsJSONLD(c) {
+		jsonLD(c, artwork.ToSchemaOrg(r.publicURL))
+		return
+	}
+	c.JSON(http.StatusOK, artwork.ToArtworkResponse())
 }
 
 // GetArtworkFromEvent godoc
@@ -103,31 +188,52 @@ func (r *SearcherRouter) GetArtworkFromEvent(c *gin.Context) {
 
 // getArtworks godoc
 // @Summary Получить произведения
-// @Description Возвращает список всех произведений с возможностью фильтрации
+// @Description Возвращает список всех произведений с возможностью фильтрации.
+// @Description Параметр q - полнотекстовый поиск с учетом морфологии и опечаток, результаты содержат поле highlight
+// @Description и по умолчанию упорядочены по релевантности.
+// @Description Фильтры по размерам (см) и весу (кг) отбирают только произведения со структурированным размером,
+// @Description при сортировке по размеру произведения без него считаются нулевого размера.
 // @Tags Поиск
 // @Accept json
 // @Produce json
+// @Param q                query string     false  "Полнотекстовый поиск (макс. 255 символов)"  maxLength(255)
 // @Param title            query string     false  "Фильтр по названию произведения (макс. 255 символов)"  maxLength(255)
 // @Param author_name      query string     false  "Фильтр по имени автора (макс. 100 символов)"    maxLength(100)
 // @Param collection_title query string     false  "Фильтр по названию коллекции (макс. 255 символов)" maxLength(255)
 // @Param event_id         query string     false  "Фильтр по ID мероприятия" format(uuid)
-// @Param sort_field       query string     true   "Поле для сортировки"  Enums(title, author_name, creationYear)
+// @Param technic          query []string   false  "Техника (можно несколько)"  collectionFormat(multi)
+// @Param material         query []string   false  "Материал (можно несколько)"  collectionFormat(multi)
+// @Param century          query []int      false  "Век создания (можно несколько)"  collectionFormat(multi)
+// @Param author_id        query []string   false  "ID автора (можно несколько)"  collectionFormat(multi)
+// @Param collection_id    query []string   false  "ID коллекции (можно несколько)"  collectionFormat(multi)
+// @Param subcollections   query bool       false  "Учитывать вложенные коллекции выбранных коллекций"
+// @Param tag_id           query []string   false  "ID тега, включая дочерние теги (можно несколько)"  collectionFormat(multi)
+// @Param year_from        query int        false  "Год создания не раньше" minimum(1)
+// @Param year_to          query int        false  "Год создания не позже" minimum(1)
+// @Param height_min       query number     false  "Высота не меньше, см" minimum(0)
+// @Param height_max       query number     false  "Высота не больше, см" minimum(0)
+// @Param width_min        query number     false  "Ширина не меньше, см" minimum(0)
+// @Param width_max        query number     false  "Ширина не больше, см" minimum(0)
+// @Param depth_min        query number     false  "Глубина не меньше, см" minimum(0)
+// @Param depth_max        query number     false  "Глубина не больше, см" minimum(0)
+// @Param weight_min       query number     false  "Вес не меньше, кг" minimum(0)
+// @Param weight_max       query number     false  "Вес не больше, кг" minimum(0)
+// @Param sort_field       query string     true   "Поле для сортировки"  Enums(title, author_name, creationYear, collection_title, relevance, height, width, depth, weight)
 // @Param direction_sort   query string     true   "Направление сортировки"  Enums(ASC, DESC)
+// @Param limit            query int        false  "Размер страницы (по умолчанию 20, не более 100)" minimum(0)
+// @Param cursor           query string     false  "Курсор следующей страницы из заголовка X-Next-Cursor"
 // @Success 200 {array} jsonreqresp.ArtworkResponse
+// @Header 200 {integer} X-Total-Count "Общее число произведений, подходящих под фильтр (только для первой страницы)"
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы (отсутствует на последней странице)"
+// @Failure 400 "Неверный размер страницы, курсор, параметры фильтрации или слишком длинный запрос"
 // @Router /museum/artworks [get]
 func (r *SearcherRouter) GetAllArtworks(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	// Читаем параметры из URL
-	event_id := uuid.Nil
-	if c.Query("event_id") != "" {
-		event_id = uuid.MustParse(c.Query("event_id"))
-	}
-	filterOps := jsonreqresp.ArtworkFilter{
-		Title:      c.Query("title"),
-		AuthorName: c.Query("author_name"),
-		Collection: c.Query("collection_title"),
-		EventID:    event_id,
+	filterOps, err := artworkFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	sortOps := jsonreqresp.ArtworkSortOps{
@@ -135,21 +241,194 @@ func (r *SearcherRouter) GetAllArtworks(c *gin.Context) {
 		Direction: c.Query("direction_sort"),
 	}
 
+	page, err := jsonreqresp.NewPageRequest(c.Query("limit"), c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Устанавливаем заголовки для кэширования (например, на 1 час)
 	// c.Header("Cache-Control", "public, max-age=3600")
 
-	artworks, err := r.serv.GetAllArtworks(ctx, &filterOps, &sortOps)
+	artworks, pageInfo, err := r.serv.GetAllArtworks(ctx, &filterOps, &sortOps, &page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, jsonreqresp.ErrPageLimit) ||
+			errors.Is(err, jsonreqresp.ErrPageCursor) ||
+			errors.Is(err, jsonreqresp.ErrSearchQueryTooLong) ||
+			errors.Is(err, jsonreqresp.ErrArtworkYearRange) ||
+			errors.Is(err, jsonreqresp.ErrArtworkDimensionRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 	artworksResp := make([]jsonreqresp.ArtworkResponse, len(artworks))
 	for i, a := range artworks {
 		artworksResp[i] = a.ToArtworkResponse()
 	}
+	setPageHeaders(c, pageInfo)
 	c.JSON(http.StatusOK, artworksResp)
 }
 
+// artworkFilterFromQuery читает фильтр произведений из параметров запроса
+func artworkFilterFromQuery(c *gin.Context) (jsonreqresp.ArtworkFilter, error) {
+	eventID := uuid.Nil
+	if c.Query("event_id") != "" {
+		id, err := uuid.Parse(c.Query("event_id"))
+		if err != nil {
+			return jsonreqresp.ArtworkFilter{}, fmt.Errorf("%w: event_id", jsonreqresp.ErrArtworkFilterParam)
+		}
+		eventID = id
+	}
+	filterOps := jsonreqresp.ArtworkFilter{
+		Title:      c.Query("title"),
+		AuthorName: c.Query("author_name"),
+		Collection: c.Query("collection_title"),
+		EventID:    eventID,
+		Query:      c.Query("q"),
+	}
+	if err := filterOps.ParseFacetQuery(c.Request.URL.Query()); err != nil {
+		return jsonreqresp.ArtworkFilter{}, err
+	}
+	return filterOps, nil
+}
+
+// GetArtworkFacets godoc
+// @Summary Получить фасеты произведений
+// @Description Возвращает значения фасетов (техника, материал, век, автор, коллекция) с количеством произведений
+// @Description и границы года создания. Принимает те же параметры фильтрации, что и /museum/artworks.
+// @Description Количество для значения фасета считается с учетом всех фильтров, кроме фильтра этого же фасета.
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Param q                query string   false  "Полнотекстовый поиск (макс. 255 символов)"  maxLength(255)
+// @Param title            query string   false  "Фильтр по названию произведения"  maxLength(255)
+// @Param author_name      query string   false  "Фильтр по имени автора"    maxLength(100)
+// @Param collection_title query string   false  "Фильтр по названию коллекции" maxLength(255)
+// @Param event_id         query string   false  "Фильтр по ID мероприятия" format(uuid)
+// @Param technic          query []string false  "Техника (можно несколько)"  collectionFormat(multi)
+// @Param material         query []string false  "Материал (можно несколько)"  collectionFormat(multi)
+// @Param century          query []int    false  "Век создания (можно несколько)"  collectionFormat(multi)
+// @Param author_id        query []string false  "ID автора (можно несколько)"  collectionFormat(multi)
+// @Param collection_id    query []string false  "ID коллекции (можно несколько)"  collectionFormat(multi)
+// @Param subcollections   query bool     false  "Учитывать вложенные коллекции выбранных коллекций"
+// @Param tag_id           query []string false  "ID тега, включая дочерние теги (можно несколько)"  collectionFormat(multi)
+// @Param year_from        query int      false  "Год создания не раньше" minimum(1)
+// @Param year_to          query int      false  "Год создания не позже" minimum(1)
+// @Param height_min       query number   false  "Высота не меньше, см" minimum(0)
+// @Param height_max       query number   false  "Высота не больше, см" minimum(0)
+// @Param width_min        query number   false  "Ширина не меньше, см" minimum(0)
+// @Param width_max        query number   false  "Ширина не больше, см" minimum(0)
+// @Param depth_min        query number   false  "Глубина не меньше, см" minimum(0)
+// @Param depth_max        query number   false  "Глубина не больше, см" minimum(0)
+// @Param weight_min       query number   false  "Вес не меньше, кг" minimum(0)
+// @Param weight_max       query number   false  "Вес не больше, кг" minimum(0)
+// @Success 200 {object} jsonreqresp.ArtworkFacetsResponse
+// @Failure 400 "Неверные параметры фильтрации"
+// @Router /museum/artworks/facets [get]
+func (r *SearcherRouter) GetArtworkFacets(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	filterOps, err := artworkFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	facets, err := r.serv.GetArtworkFacets(ctx, &filterOps)
+	if err != nil {
+		if errors.Is(err, jsonreqresp.ErrSearchQueryTooLong) ||
+			errors.Is(err, jsonreqresp.ErrArtworkYearRange) ||
+			errors.Is(err, jsonreqresp.ErrArtworkDimensionRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, facets.ToArtworkFacetsResponse(&filterOps))
+}
+
+// GetArtworkProvenance godoc
+// @Summary Получить историю владения произведением
+// @Description Возвращает опубликованные записи провенанса в хронологическом порядке
+// @Tags Поиск
+// @Produce json
+// @Param id path string true "ID произведения"
+// @Success 200 {array} jsonreqresp.ProvenanceEntryResponse
+// @Failure 400 "Неверный ID"
+// @Failure 404 "Произведение не найдено"
+// @Router /museum/artworks/{id}/provenance [get]
+func (r *SearcherRouter) GetArtworkProvenance(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork ID format"})
+		return
+	}
+
+	entries, err := r.serv.GetArtworkProvenance(ctx, artworkID)
+	if err != nil {
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	entriesResp := make([]jsonreqresp.ProvenanceEntryResponse, len(entries))
+	for i, e := range entries {
+		entriesResp[i] = e.ToProvenanceEntryResponse()
+	}
+	c.JSON(http.StatusOK, entriesResp)
+}
+
+// GetSimilarArtworks godoc
+// @Summary Получить похожие произведения
+// @Description Возвращает рекомендации «Вам может понравиться» с причинами: общий автор, коллекция,
+// @Description техника, материал, близость датировки и совместное участие в мероприятиях.
+// @Description Для авторизованного посетителя учитываются его избранные произведения
+// @Tags Поиск
+// @Produce json
+// @Param id    path  string true  "ID произведения"
+// @Param limit query int    false "Количество рекомендаций (по умолчанию 6, не более 24)" minimum(1)
+// @Success 200 {array} jsonreqresp.SimilarArtworkResponse
+// @Failure 400 "Неверный ID или лимит"
+// @Failure 404 "Произведение не найдено"
+// @Router /museum/artworks/{id}/similar [get]
+func (r *SearcherRouter) GetSimilarArtworks(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork ID format"})
+		return
+	}
+	limit := models.SimilarDefaultLimit
+	if l := c.Query("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	recs, err := r.recommendServ.GetSimilarArtworks(ctx, artworkID, limit)
+	if err != nil {
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	recsResp := make([]jsonreqresp.SimilarArtworkResponse, len(recs))
+	for i, rec := range recs {
+		recsResp[i] = rec.ToSimilarArtworkResponse()
+	}
+	c.JSON(http.StatusOK, recsResp)
+}
+
 // getAllEvents godoc
 // @Summary Получить мероприятия
 // @Description Возвращает список всех мероприятий с возможностью фильтрации
@@ -160,8 +439,14 @@ func (r *SearcherRouter) GetAllArtworks(c *gin.Context) {
 // @Param date_begin query string  false  "Фильтр по минимальной дате начала (формат: ГГГГ-ММ-ДД)"  format(date)
 // @Param date_end   query string  false  "Фильтр по максимальной дате окончания (формат: ГГГГ-ММ-ДД)"    format(date)
 // @Param can_visit  query boolean false  "Фильтр по доступности для посещения"
+// @Param sort_field     query string false "Поле для сортировки"  Enums(title, dateBegin, dateEnd, tickets_available)
+// @Param direction_sort query string false "Направление сортировки"  Enums(ASC, DESC)
+// @Param limit      query int     false  "Размер страницы (по умолчанию 20, не более 100)" minimum(0)
+// @Param cursor     query string  false  "Курсор следующей страницы из заголовка X-Next-Cursor"
 // @Success 200 {array} jsonreqresp.EventResponse
-// @Failure 400 "Неверный формат даты. Используйте ГГГГ-ММ-ДД"
+// @Header 200 {integer} X-Total-Count "Общее число мероприятий, подходящих под фильтр"
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы (отсутствует на последней странице)"
+// @Failure 400 "Неверный формат даты, размер страницы или курсор"
 // @Router /museum/events [get]
 func (r *SearcherRouter) GetAllEvents(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -197,9 +482,21 @@ func (r *SearcherRouter) GetAllEvents(c *gin.Context) {
 		filterOps.CanVisit = canVisitStr
 	}
 
-	events, err := r.serv.GetAllEvents(ctx, &filterOps)
+	sortOps := jsonreqresp.EventSortOps{
+		Field:     c.Query("sort_field"),
+		Direction: c.Query("direction_sort"),
+	}
+	page, err := jsonreqresp.NewPageRequest(c.Query("limit"), c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, pageInfo, err := r.serv.GetAllEvents(ctx, &filterOps, &sortOps, &page)
 	if err != nil {
-		if errors.Is(err, jsonreqresp.ErrEventFilterDate) {
+		if errors.Is(err, jsonreqresp.ErrEventFilterDate) ||
+			errors.Is(err, jsonreqresp.ErrPageLimit) ||
+			errors.Is(err, jsonreqresp.ErrPageCursor) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -210,6 +507,7 @@ func (r *SearcherRouter) GetAllEvents(c *gin.Context) {
 	for i, a := range events {
 		eventsResp[i] = a.ToEventResponse()
 	}
+	setPageHeaders(c, pageInfo)
 	c.JSON(http.StatusOK, eventsResp)
 }
 
@@ -251,3 +549,120 @@ func (r *SearcherRouter) GetCollectionsStat(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// parseDateRange читает из запроса диапазон дат date_begin и date_end (ГГГГ-ММ-ДД)
+func parseDateRange(c *gin.Context) (jsonreqresp.EventFilter, error) {
+	filterOps := jsonreqresp.EventFilter{Valid: "true"}
+	if dateBeginStr := c.Query("date_begin"); dateBeginStr != "" {
+		dateBegin, err := time.Parse("2006-01-02", dateBeginStr)
+		if err != nil {
+			return filterOps, errors.New("invalid date_begin format. Use YYYY-MM-DD")
+		}
+		filterOps.DateBegin = dateBegin
+	}
+	if dateEndStr := c.Query("date_end"); dateEndStr != "" {
+		dateEnd, err := time.Parse("2006-01-02", dateEndStr)
+		if err != nil {
+			return filterOps, errors.New("invalid date_end format. Use YYYY-MM-DD")
+		}
+		filterOps.DateEnd = dateEnd
+	}
+	return filterOps, nil
+}
+
+func (r *SearcherRouter) handleEventsHistory(
+	c *gin.Context,
+	getEvents func(uuid.UUID, *jsonreqresp.EventFilter) ([]*models.Event, error),
+) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	filterOps, err := parseDateRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := getEvents(id, &filterOps)
+	if err != nil {
+		if errors.Is(err, jsonreqresp.ErrEventFilterDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	eventsResp := make([]jsonreqresp.EventResponse, len(events))
+	for i, e := range events {
+		eventsResp[i] = e.ToEventResponse()
+	}
+	c.JSON(http.StatusOK, eventsResp)
+}
+
+// GetArtworkEvents godoc
+// @Summary История выставок произведения
+// @Description Возвращает мероприятия, в которых участвовало произведение, по возрастанию даты начала
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Param id         path  string true  "ID произведения"
+// @Param date_begin query string false "Начало диапазона дат (формат: ГГГГ-ММ-ДД)" format(date)
+// @Param date_end   query string false "Конец диапазона дат (формат: ГГГГ-ММ-ДД)" format(date)
+// @Success 200 {array} jsonreqresp.EventResponse
+// @Failure 400 "Неверный формат ID или даты"
+// @Failure 404 "Произведение не найдено"
+// @Router /museum/artworks/{id}/events [get]
+func (r *SearcherRouter) GetArtworkEvents(c *gin.Context) {
+	r.handleEventsHistory(c, func(id uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+		return r.serv.GetArtworkEvents(c.Request.Context(), id, filterOps)
+	})
+}
+
+// GetAuthorEvents godoc
+// @Summary Мероприятия с работами автора
+// @Description Возвращает мероприятия, в которых участвуют произведения автора, по возрастанию даты начала
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Param id         path  string true  "ID автора"
+// @Param date_begin query string false "Начало диапазона дат (формат: ГГГГ-ММ-ДД)" format(date)
+// @Param date_end   query string false "Конец диапазона дат (формат: ГГГГ-ММ-ДД)" format(date)
+// @Success 200 {array} jsonreqresp.EventResponse
+// @Failure 400 "Неверный формат ID или даты"
+// @Router /museum/authors/{id}/events [get]
+func (r *SearcherRouter) GetAuthorEvents(c *gin.Context) {
+	r.handleEventsHistory(c, func(id uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+		return r.serv.GetAuthorEvents(c.Request.Context(), id, filterOps)
+	})
+}
+
+// GetCollectionEvents godoc
+// @Summary Мероприятия с работами из коллекции
+// @Description Возвращает мероприятия, в которых участвуют произведения коллекции, по возрастанию даты начала
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Param id         path  string true  "ID коллекции"
+// @Param date_begin query string false "Начало диапазона дат (формат: ГГГГ-ММ-ДД)" format(date)
+// @Param date_end   query string false "Конец диапазона дат (формат: ГГГГ-ММ-ДД)" format(date)
+// @Success 200 {array} jsonreqresp.EventResponse
+// @Failure 400 "Неверный формат ID или даты"
+// @Router /museum/collections/{id}/events [get]
+func (r *SearcherRouter) GetCollectionEvents(c *gin.Context) {
+	r.handleEventsHistory(c, func(id uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+		return r.serv.GetCollectionEvents(c.Request.Context(), id, filterOps)
+	})
+}
+
+func setPageHeaders(c *gin.Context, pageInfo jsonreqresp.PageInfo) {
+	if pageInfo.HasTotal {
+		c.Header(jsonreqresp.TotalCountHeader, strconv.Itoa(pageInfo.Total))
+	}
+	if pageInfo.NextCursor != "" {
+		c.Header(jsonreqresp.NextCursorHeader, pageInfo.NextCursor)
+	}
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/tagrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/tagserv"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagRouter struct {
+	tagServ tagserv.TagServ
+}
+
+// NewTagRouter регистрирует управление словарем тегов для сотрудников и публичный просмотр тегов
+func NewTagRouter(employeeRouter *gin.RouterGroup, publicRouter *gin.RouterGroup, tagServ tagserv.TagServ) TagRouter {
+	r := TagRouter{
+		tagServ: tagServ,
+	}
+	gr := employeeRouter.Group("tags")
+	gr.GET("", r.GetAllTags)
+	gr.POST("", r.AddTag)
+	gr.PUT("", r.UpdateTag)
+	gr.DELETE("", r.DeleteTag)
+	employeeRouter.PUT("/artworks/:id/tags", r.SetArtworkTags)
+
+	pub := publicRouter.Group("museum")
+	pub.GET("/tags", r.GetTagTree)
+	pub.GET("/tags/:id", r.GetTag)
+	return r
+}
+
+func handleTagErr(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tagrep.ErrTagNotFound) || errors.Is(err, artworkrep.ErrArtworkNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrTagHasChildren):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrValidateTag) || errors.Is(err, models.ErrValidateArtwork):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetAllTags godoc
+// @Summary Получить словарь тегов (сотрудник)
+// @Description Возвращает все теги словаря; с параметром term - только теги, в название или синонимы которых входит термин
+// @Tags Теги
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param term query string false "Термин для поиска по названию и синонимам"
+// @Success 200 {array} jsonreqresp.TagResponse
+// @Router /employee/tags [get]
+func (r *TagRouter) GetAllTags(c *gin.Context) {
+	ctx := c.Request.Context()
+	var tags []*models.Tag
+	var err error
+	if term := c.Query("term"); term != "" {
+		tags, err = r.tagServ.Search(ctx, term)
+	} else {
+		tags, err = r.tagServ.GetAll(ctx)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	tagsResp := make([]jsonreqresp.TagResponse, len(tags))
+	for i, t := range tags {
+		tagsResp[i] = t.ToTagResponse()
+	}
+	c.JSON(http.StatusOK, tagsResp)
+}
+
+// AddTag godoc
+// @Summary Добавить тег в словарь (сотрудник)
+// @Description Создает тег вида subject (сюжет), movement (направление) или keyword (ключевое слово).
+// @Description Родительский тег должен быть того же вида, глубина иерархии - не более 5 уровней.
+// @Description Название и синонимы не должны совпадать с терминами других тегов того же вида
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.TagRequest true "Данные тега"
+// @Success 201 {object} map[string]string "ID созданного тега"
+// @Failure 400 "Неверный запрос"
+// @Router /employee/tags [post]
+func (r *TagRouter) AddTag(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req jsonreqresp.TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := r.tagServ.Add(ctx, req)
+	if err != nil {
+		handleTagErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": id.String()})
+}
+
+// UpdateTag godoc
+// @Summary Обновить тег (сотрудник)
+// @Description Изменяет название, вид, родителя и синонимы тега. Тег нельзя переместить внутрь его потомков
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.UpdateTagRequest true "Данные для обновления тега"
+// @Success 200 "Тег обновлен"
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Тег не найден"
+// @Router /employee/tags [put]
+func (r *TagRouter) UpdateTag(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req jsonreqresp.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.tagServ.Update(ctx, uuid.MustParse(req.ID), req.TagRequest); err != nil {
+		handleTagErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteTag godoc
+// @Summary Удалить тег (сотрудник)
+// @Description Удаляет тег и снимает его с произведений. Тег с дочерними тегами удалить нельзя
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.DeleteTagRequest true "Данные для удаления тега"
+// @Success 200 "Тег удален"
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Тег не найден"
+// @Failure 409 "У тега есть дочерние теги"
+// @Router /employee/tags [delete]
+func (r *TagRouter) DeleteTag(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req jsonreqresp.DeleteTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.tagServ.Delete(ctx, uuid.MustParse(req.ID)); err != nil {
+		handleTagErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetArtworkTags godoc
+// @Summary Задать теги произведения (сотрудник)
+// @Description Заменяет теги произведения переданным набором, пустой список снимает все теги
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID произведения"
+// @Param request body jsonreqresp.ArtworkTagsRequest true "ID тегов"
+// @Success 200 "Теги сохранены"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 404 "Произведение или тег не найдены"
+// @Router /employee/artworks/{id}/tags [put]
+func (r *TagRouter) SetArtworkTags(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork id"})
+		return
+	}
+
+	var req jsonreqresp.ArtworkTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tagIDs := make(uuid.UUIDs, len(req.TagIDs))
+	for i, id := range req.TagIDs {
+		tagIDs[i] = uuid.MustParse(id)
+	}
+
+	if err := r.tagServ.SetArtworkTags(ctx, artworkID, tagIDs); err != nil {
+		handleTagErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetTagTree godoc
+// @Summary Получить дерево тегов
+// @Description Возвращает иерархию тегов словаря с числом отмеченных произведений, включая произведения дочерних тегов
+// @Tags Поиск
+// @Produce json
+// @Success 200 {array} jsonreqresp.TagNodeResponse
+// @Router /museum/tags [get]
+func (r *TagRouter) GetTagTree(c *gin.Context) {
+	ctx := c.Request.Context()
+	tree, err := r.tagServ.GetTree(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	treeResp := make([]jsonreqresp.TagNodeResponse, len(tree))
+	for i, node := range tree {
+		treeResp[i] = node.ToTagNodeResponse()
+	}
+	c.JSON(http.StatusOK, treeResp)
+}
+
+// GetTag godoc
+// @Summary Получить тег
+// @Description Возвращает тег, цепочку его родителей от корня и дочерние теги с числом произведений.
+// @Description Произведения тега - /museum/artworks?tag_id={id}
+// @Tags Поиск
+// @Produce json
+// @Param id path string true "ID тега"
+// @Success 200 {object} jsonreqresp.TagPageResponse
+// @Failure 400 "Неверный формат ID"
+// @Failure 404 "Тег не найден"
+// @Router /museum/tags/{id} [get]
+func (r *TagRouter) GetTag(c *gin.Context) {
+	ctx := c.Request.Context()
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag ID format"})
+		return
+	}
+	node, ancestors, err := r.tagServ.GetNode(ctx, tagID)
+	if err != nil {
+		handleTagErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tagPageResponse(node, ancestors))
+}
+
+func tagPageResponse(node *models.TagNode, ancestors []*models.Tag) jsonreqresp.TagPageResponse {
+	nodeResp := node.ToTagNodeResponse()
+	resp := jsonreqresp.TagPageResponse{
+		Tag:       nodeResp.TagResponse,
+		Ancestors: make([]jsonreqresp.TagResponse, len(ancestors)),
+		Children:  nodeResp.Children,
+		Count:     nodeResp.Count,
+	}
+	for i, a := range ancestors {
+		resp.Ancestors[i] = a.ToTagResponse()
+	}
+	return resp
+}
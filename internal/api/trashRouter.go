@@ -0,0 +1,133 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/trashrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/trashserv"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrashRouter struct {
+	trashServ trashserv.TrashServ
+}
+
+// NewTrashRouter регистрирует просмотр, восстановление и очистку корзины для сотрудников
+func NewTrashRouter(employeeRouter *gin.RouterGroup, trashServ trashserv.TrashServ) TrashRouter {
+	r := TrashRouter{
+		trashServ: trashServ,
+	}
+	gr := employeeRouter.Group("trash")
+	gr.GET("", r.GetTrash)
+	gr.POST("/restore", r.RestoreTrashItem)
+	gr.POST("/purge", r.PurgeTrash)
+	return r
+}
+
+// handleDependencyErr отвечает 409 со списком связанных записей; возвращает false, если ошибка другая
+func handleDependencyErr(c *gin.Context, err error) bool {
+	var depErr *models.DependencyError
+	if errors.As(err, &depErr) {
+		c.JSON(http.StatusConflict, depErr.ToDependencyErrorResponse())
+		return true
+	}
+	return false
+}
+
+func (r *TrashRouter) trashResponse(items []*models.TrashItem) []jsonreqresp.TrashItemResponse {
+	resp := make([]jsonreqresp.TrashItemResponse, len(items))
+	for i, item := range items {
+		resp[i] = item.ToTrashItemResponse(r.trashServ.Retention())
+	}
+	return resp
+}
+
+// GetTrash godoc
+// @Summary Получить содержимое корзины (сотрудник)
+// @Description Возвращает удалённые произведения, авторов и коллекции от недавно удалённых к давним.
+// @Description purgeAt - время, после которого запись будет удалена окончательно.
+// @Tags Корзина
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param entityType query string false "Тип записей" Enums(artwork, author, collection)
+// @Success 200 {array} jsonreqresp.TrashItemResponse
+// @Failure 400 "Неверный тип записей"
+// @Router /employee/trash [get]
+func (r *TrashRouter) GetTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	entityType := models.EntityType(c.Query(jsonreqresp.EntityTypeParamTrash))
+	items, err := r.trashServ.GetItems(ctx, entityType)
+	if err != nil {
+		if errors.Is(err, models.ErrTrashItemEntityType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, r.trashResponse(items))
+}
+
+// RestoreTrashItem godoc
+// @Summary Восстановить запись из корзины (сотрудник)
+// @Description Возвращает произведение, автора или коллекцию из корзины.
+// @Description Произведение нельзя восстановить, пока его автор или коллекция в корзине - они возвращаются в ответе 409 с inTrash = true.
+// @Tags Корзина
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.RestoreTrashItemRequest true "Запись для восстановления"
+// @Success 200 "Запись восстановлена"
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Записи нет в корзине"
+// @Failure 409 {object} jsonreqresp.DependencyErrorResponse "Связанные записи в корзине"
+// @Router /employee/trash/restore [post]
+func (r *TrashRouter) RestoreTrashItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req jsonreqresp.RestoreTrashItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := r.trashServ.Restore(ctx, models.EntityType(req.EntityType), uuid.MustParse(req.ID))
+	if err != nil {
+		if handleDependencyErr(c, err) {
+			return
+		}
+		if errors.Is(err, trashrep.ErrTrashItemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// PurgeTrash godoc
+// @Summary Очистить корзину (сотрудник)
+// @Description Окончательно удаляет записи, которые пробыли в корзине дольше срока хранения, не дожидаясь плановой очистки.
+// @Description Авторы и коллекции удаляются, только если на них не ссылаются произведения из корзины.
+// @Tags Корзина
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Success 200 {object} jsonreqresp.PurgeTrashResponse
+// @Router /employee/trash/purge [post]
+func (r *TrashRouter) PurgeTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	purged, err := r.trashServ.Purge(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, jsonreqresp.PurgeTrashResponse{Purged: r.trashResponse(purged)})
+}
@@ -0,0 +1,378 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/userlistserv"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UserListRouter struct {
+	userListServ userlistserv.UserListServ
+	// publicURL внешний адрес сервиса для ссылок на публичные подборки
+	publicURL string
+}
+
+// NewUserListRouter регистрирует избранное и подборки пользователя и публичный просмотр подборок
+func NewUserListRouter(
+	userRouter *gin.RouterGroup, publicRouter *gin.RouterGroup, userListServ userlistserv.UserListServ, publicURL string,
+) UserListRouter {
+	r := UserListRouter{
+		userListServ: userListServ,
+		publicURL:    publicURL,
+	}
+	fav := userRouter.Group("favourites")
+	fav.GET("", r.GetFavourites)
+	fav.POST("", r.AddFavourite)
+	fav.DELETE("/:type/:entityId", r.DeleteFavourite)
+
+	gr := userRouter.Group("lists")
+	gr.GET("", r.GetLists)
+	gr.POST("", r.AddList)
+	gr.GET("/:id", r.GetList)
+	gr.PUT("/:id", r.UpdateList)
+	gr.DELETE("/:id", r.DeleteList)
+	gr.PUT("/:id/items", r.SetItems)
+	gr.POST("/:id/items", r.AddItem)
+	gr.DELETE("/:id/items/:type/:entityId", r.RemoveItem)
+
+	pub := publicRouter.Group("museum")
+	pub.GET("/lists/:id", r.GetPublicList)
+	return r
+}
+
+func handleUserListErr(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, userlistrep.ErrUserListNotFound) || errors.Is(err, userlistrep.ErrUserListItemNotFound) ||
+		errors.Is(err, userlistrep.ErrFavouriteNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, artworkrep.ErrArtworkNotFound) || errors.Is(err, eventrep.ErrEventNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrValidateUserList):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// userItemParams разбирает вид и ID записи каталога из пути запроса
+func userItemParams(c *gin.Context) (models.EntityType, uuid.UUID, bool) {
+	entityType := models.EntityType(c.Param("type"))
+	entityID, err := uuid.Parse(c.Param("entityId"))
+	if err != nil || !models.IsUserItemEntity(entityType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entity type or ID"})
+		return "", uuid.Nil, false
+	}
+	return entityType, entityID, true
+}
+
+// GetFavourites godoc
+// @Summary Получить избранное
+// @Description Возвращает избранные произведения и мероприятия, сначала добавленные последними.
+// @Description Удаленные записи и скрытые от посетителей мероприятия не показываются
+// @Tags Избранное и подборки
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Success 200 {object} jsonreqresp.FavouritesResponse
+// @Router /user/favourites [get]
+func (r *UserListRouter) GetFavourites(c *gin.Context) {
+	favourites, records, err := r.userListServ.GetFavourites(c.Request.Context())
+	if err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.ToFavouritesResponse(favourites, records))
+}
+
+// AddFavourite godoc
+// @Summary Добавить в избранное
+// @Description Добавляет произведение или одобренное мероприятие в избранное. Повторное добавление не ошибка
+// @Tags Избранное и подборки
+// @Accept json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.FavouriteRequest true "Запись каталога"
+// @Success 200 "OK"
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Запись не найдена"
+// @Router /user/favourites [post]
+func (r *UserListRouter) AddFavourite(c *gin.Context) {
+	var req jsonreqresp.FavouriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.userListServ.AddFavourite(c.Request.Context(), req); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteFavourite godoc
+// @Summary Убрать из избранного
+// @Tags Избранное и подборки
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param type path string true "Вид записи: artwork или event"
+// @Param entityId path string true "ID произведения или мероприятия"
+// @Success 200 "OK"
+// @Failure 404 "Записи нет в избранном"
+// @Router /user/favourites/{type}/{entityId} [delete]
+func (r *UserListRouter) DeleteFavourite(c *gin.Context) {
+	entityType, entityID, ok := userItemParams(c)
+	if !ok {
+		return
+	}
+	if err := r.userListServ.DeleteFavourite(c.Request.Context(), entityType, entityID); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetLists godoc
+// @Summary Получить подборки пользователя
+// @Description Возвращает подборки без элементов, недавно измененные - первыми
+// @Tags Избранное и подборки
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Success 200 {array} jsonreqresp.UserListSummaryResponse
+// @Router /user/lists [get]
+func (r *UserListRouter) GetLists(c *gin.Context) {
+	lists, err := r.userListServ.GetLists(c.Request.Context())
+	if err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	listsResp := make([]jsonreqresp.UserListSummaryResponse, len(lists))
+	for i, l := range lists {
+		listsResp[i] = l.ToUserListSummaryResponse(r.publicURL)
+	}
+	c.JSON(http.StatusOK, listsResp)
+}
+
+// AddList godoc
+// @Summary Создать подборку
+// @Description Создает пустую подборку; у пользователя может быть не более 50 подборок
+// @Tags Избранное и подборки
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param request body jsonreqresp.UserListRequest true "Данные подборки"
+// @Success 201 {object} jsonreqresp.UserListIDResponse
+// @Failure 400 "Неверный запрос"
+// @Router /user/lists [post]
+func (r *UserListRouter) AddList(c *gin.Context) {
+	var req jsonreqresp.UserListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := r.userListServ.AddList(c.Request.Context(), req)
+	if err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, jsonreqresp.UserListIDResponse{ID: id.String()})
+}
+
+// GetList godoc
+// @Summary Получить подборку пользователя
+// @Tags Избранное и подборки
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID подборки"
+// @Success 200 {object} jsonreqresp.UserListResponse
+// @Failure 404 "Подборка не найдена"
+// @Router /user/lists/{id} [get]
+func (r *UserListRouter) GetList(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	l, records, err := r.userListServ.GetList(c.Request.Context(), id)
+	if err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, l.ToUserListResponse(r.publicURL, records))
+}
+
+// UpdateList godoc
+// @Summary Изменить подборку
+// @Description Изменяет название, описание и видимость подборки
+// @Tags Избранное и подборки
+// @Accept json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID подборки"
+// @Param request body jsonreqresp.UserListRequest true "Данные подборки"
+// @Success 200 "OK"
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Подборка не найдена"
+// @Router /user/lists/{id} [put]
+func (r *UserListRouter) UpdateList(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	var req jsonreqresp.UserListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.userListServ.UpdateList(c.Request.Context(), id, req); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteList godoc
+// @Summary Удалить подборку
+// @Tags Избранное и подборки
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID подборки"
+// @Success 200 "OK"
+// @Failure 404 "Подборка не найдена"
+// @Router /user/lists/{id} [delete]
+func (r *UserListRouter) DeleteList(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	if err := r.userListServ.DeleteList(c.Request.Context(), id); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetItems godoc
+// @Summary Заменить элементы подборки
+// @Description Заменяет элементы подборки; порядок элементов в запросе - порядок показа. Не более 200 элементов
+// @Tags Избранное и подборки
+// @Accept json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID подборки"
+// @Param request body jsonreqresp.UserListItemsRequest true "Элементы подборки"
+// @Success 200 "OK"
+// @Failure 400 "Неверный запрос"
+// @Failure 404 "Подборка или запись не найдена"
+// @Router /user/lists/{id}/items [put]
+func (r *UserListRouter) SetItems(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	var req jsonreqresp.UserListItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.userListServ.SetItems(c.Request.Context(), id, req); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// AddItem godoc
+// @Summary Добавить элемент в подборку
+// @Description Добавляет произведение или одобренное мероприятие в конец подборки
+// @Tags Избранное и подборки
+// @Accept json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID подборки"
+// @Param request body jsonreqresp.UserListItemRequest true "Элемент подборки"
+// @Success 200 "OK"
+// @Failure 400 "Неверный запрос или запись уже в подборке"
+// @Failure 404 "Подборка или запись не найдена"
+// @Router /user/lists/{id}/items [post]
+func (r *UserListRouter) AddItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	var req jsonreqresp.UserListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.userListServ.AddItem(c.Request.Context(), id, req); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// RemoveItem godoc
+// @Summary Убрать элемент из подборки
+// @Tags Избранное и подборки
+// @Security ApiKeyAuth
+// @Param Authorization header string true "bearer {token}"
+// @Param id path string true "ID подборки"
+// @Param type path string true "Вид записи: artwork или event"
+// @Param entityId path string true "ID произведения или мероприятия"
+// @Success 200 "OK"
+// @Failure 404 "Подборка или элемент не найдены"
+// @Router /user/lists/{id}/items/{type}/{entityId} [delete]
+func (r *UserListRouter) RemoveItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	entityType, entityID, ok := userItemParams(c)
+	if !ok {
+		return
+	}
+	if err := r.userListServ.RemoveItem(c.Request.Context(), id, entityType, entityID); err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetPublicList godoc
+// @Summary Получить публичную подборку
+// @Description Возвращает публичную подборку пользователя по ссылке; личные подборки недоступны
+// @Tags Избранное и подборки
+// @Produce json
+// @Param id path string true "ID подборки"
+// @Success 200 {object} jsonreqresp.UserListResponse
+// @Failure 404 "Подборка не найдена"
+// @Router /museum/lists/{id} [get]
+func (r *UserListRouter) GetPublicList(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+	l, records, err := r.userListServ.GetPublicList(c.Request.Context(), id)
+	if err != nil {
+		handleUserListErr(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, l.ToUserListResponse(r.publicURL, records))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -23,8 +24,23 @@ type AppConfig struct {
 	AccessTokenDuration          time.Duration `mapstructure:"access_token_duration"`
 	BuyTicketTransactionDuration time.Duration `mapstructure:"buy_ticket_transaction_duration"`
 	Port                         int           `mapstructure:"port"`
+	ImageStorageDir              string        `mapstructure:"image_storage_dir"`
+	// PublicURL внешний адрес сервиса для абсолютных ссылок (манифесты IIIF),
+	// по умолчанию http://localhost:<port>
+	PublicURL string `mapstructure:"public_url"`
+	// AdminEmail адрес администратора каталога, публикуется в ответе Identify OAI-PMH
+	AdminEmail string `mapstructure:"admin_email"`
+	// TrashRetention срок хранения удалённых записей в корзине, по умолчанию 30 дней
+	TrashRetention time.Duration `mapstructure:"trash_retention"`
+	// TrashPurgeInterval как часто записи старше TrashRetention удаляются окончательно, по умолчанию раз в час
+	TrashPurgeInterval time.Duration `mapstructure:"trash_purge_interval"`
 }
 
+const (
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = time.Hour
+)
+
 type DatebaseConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
@@ -77,6 +93,16 @@ func LoadAppConfig() (config *AppConfig, err error) {
 	if config.Datebase != PostgresDB && config.Datebase != ClickHouseDB {
 		return nil, ErrUnknownDB
 	}
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+	if config.PublicURL == "" {
+		config.PublicURL = fmt.Sprintf("http://localhost:%d", config.Port)
+	}
+	if config.TrashRetention <= 0 {
+		config.TrashRetention = defaultTrashRetention
+	}
+	if config.TrashPurgeInterval <= 0 {
+		config.TrashPurgeInterval = defaultTrashPurgeInterval
+	}
 
 	return config, nil
 }
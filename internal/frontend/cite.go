@@ -3,29 +3,53 @@ package frontend
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/frontend/components"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/frontend/gintemplrenderer"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/tagrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/authorserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/buyticketserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/recommendserv"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/searcher"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/tagserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/userlistserv"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type CiteRouter struct {
-	searcherServ searcher.Searcher
-	authorServ   authorserv.AuthorServ
+	searcherServ  searcher.Searcher
+	authorServ    authorserv.AuthorServ
+	tagServ       tagserv.TagServ
+	buyTicketServ buyticketserv.BuyTicketsServ
+	userListServ  userlistserv.UserListServ
+	recommendServ recommendserv.RecommendServ
+	// publicURL внешний адрес сервиса для ссылок в разметке schema.org
+	publicURL string
 }
 
-func NewCiteRouter(router *gin.RouterGroup, searcherServ searcher.Searcher, authorServ authorserv.AuthorServ) CiteRouter {
+func NewCiteRouter(
+	router *gin.RouterGroup, searcherServ searcher.Searcher, authorServ authorserv.AuthorServ, tagServ tagserv.TagServ,
+	buyTicketServ buyticketserv.BuyTicketsServ, userListServ userlistserv.UserListServ,
+	recommendServ recommendserv.RecommendServ, publicURL string,
+) CiteRouter {
 	r := CiteRouter{
-		searcherServ: searcherServ,
-		authorServ:   authorServ,
+		searcherServ:  searcherServ,
+		authorServ:    authorServ,
+		tagServ:       tagServ,
+		buyTicketServ: buyTicketServ,
+		userListServ:  userListServ,
+		recommendServ: recommendServ,
+		publicURL:     publicURL,
 	}
 
 	gr := router.Group("/")
@@ -33,6 +57,11 @@ func NewCiteRouter(router *gin.RouterGroup, searcherServ searcher.Searcher, auth
 	gr.GET("/events", r.GetAllEvents)
 	gr.GET("/login", r.ShowEmployeeLoginPage)
 	gr.GET("/events/:id", r.GetEvent)
+	gr.GET("/artworks/:id", r.GetArtwork)
+	gr.GET("/tags", r.GetTags)
+	gr.GET("/tags/:id", r.GetTag)
+	gr.GET("/authors/:id", r.GetAuthor)
+	gr.GET("/lists/:id", r.GetUserList)
 
 	return r
 }
@@ -43,8 +72,18 @@ func (r *CiteRouter) ShowEmployeeLoginPage(c *gin.Context) {
 	c.Render(http.StatusOK, rend)
 }
 
+// nextPageURL возвращает адрес следующей страницы с сохранением параметров фильтрации и сортировки
+func nextPageURL(c *gin.Context, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	query := c.Request.URL.Query()
+	query.Set("cursor", cursor)
+	return c.Request.URL.Path + "?" + query.Encode()
+}
+
 func (r *CiteRouter) allArtworksResp(c *gin.Context) (
-	[]jsonreqresp.ArtworkResponse, jsonreqresp.ArtworkFilter, jsonreqresp.ArtworkSortOps,
+	[]jsonreqresp.ArtworkResponse, jsonreqresp.ArtworkFilter, jsonreqresp.ArtworkSortOps, jsonreqresp.PageInfo,
 ) {
 	ctx := c.Request.Context()
 
@@ -58,33 +97,59 @@ func (r *CiteRouter) allArtworksResp(c *gin.Context) (
 		AuthorName: c.Query("author_name"),
 		Collection: c.Query("collection_title"),
 		EventID:    event_id,
+		Query:      c.Query("q"),
+	}
+	if err := filterOps.ParseFacetQuery(c.Request.URL.Query()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, jsonreqresp.ArtworkFilter{}, jsonreqresp.ArtworkSortOps{}, jsonreqresp.PageInfo{}
 	}
 
 	sortOps := jsonreqresp.ArtworkSortOps{
 		Field:     c.Query("sort_field"),
 		Direction: c.Query("direction_sort"),
 	}
+	page, err := jsonreqresp.NewPageRequest(c.Query("limit"), c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, jsonreqresp.ArtworkFilter{}, jsonreqresp.ArtworkSortOps{}, jsonreqresp.PageInfo{}
+	}
 	// Устанавливаем заголовки для кэширования (например, на 1 час)
 	// c.Header("Cache-Control", "public, max-age=3600")
 
-	artworks, err := r.searcherServ.GetAllArtworks(ctx, &filterOps, &sortOps)
+	artworks, pageInfo, err := r.searcherServ.GetAllArtworks(ctx, &filterOps, &sortOps, &page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return nil, jsonreqresp.ArtworkFilter{}, jsonreqresp.ArtworkSortOps{}
+		if errors.Is(err, jsonreqresp.ErrPageLimit) ||
+			errors.Is(err, jsonreqresp.ErrPageCursor) ||
+			errors.Is(err, jsonreqresp.ErrSearchQueryTooLong) ||
+			errors.Is(err, jsonreqresp.ErrArtworkYearRange) ||
+			errors.Is(err, jsonreqresp.ErrArtworkDimensionRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return nil, jsonreqresp.ArtworkFilter{}, jsonreqresp.ArtworkSortOps{}, jsonreqresp.PageInfo{}
 	}
 	artworksResp := make([]jsonreqresp.ArtworkResponse, len(artworks))
 	for i, a := range artworks {
 		artworksResp[i] = a.ToArtworkResponse()
 	}
-	return artworksResp, filterOps, sortOps
+	return artworksResp, filterOps, sortOps, pageInfo
 }
 
 func (r *CiteRouter) GetAllArtworks(c *gin.Context) {
-	artworksResp, filterOps, sortOps := r.allArtworksResp(c)
-	if artworksResp != nil {
-		rend := gintemplrenderer.New(c.Request.Context(), http.StatusOK, components.ArtworksPage(artworksResp, filterOps, sortOps))
-		c.Render(http.StatusOK, rend)
+	artworksResp, filterOps, sortOps, pageInfo := r.allArtworksResp(c)
+	if artworksResp == nil {
+		return
 	}
+	facets, err := r.searcherServ.GetArtworkFacets(c.Request.Context(), &filterOps)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	rend := gintemplrenderer.New(c.Request.Context(), http.StatusOK, components.ArtworksPage(
+		artworksResp, filterOps, facets.ToArtworkFacetsResponse(&filterOps), sortOps, pageInfo,
+		nextPageURL(c, pageInfo.NextCursor)))
+	c.Render(http.StatusOK, rend)
 }
 
 // func (r *CiteRouter) GetAllArtworksEmpl(c *gin.Context) {
@@ -96,7 +161,7 @@ func (r *CiteRouter) GetAllArtworks(c *gin.Context) {
 // }
 
 func (r *CiteRouter) allEventsResp(c *gin.Context) (
-	[]jsonreqresp.EventResponse, jsonreqresp.EventFilter,
+	[]jsonreqresp.EventResponse, jsonreqresp.EventFilter, jsonreqresp.EventSortOps, jsonreqresp.PageInfo,
 ) {
 	ctx := c.Request.Context()
 
@@ -110,7 +175,7 @@ func (r *CiteRouter) allEventsResp(c *gin.Context) (
 		dateBegin, err := parseDate(dateBeginStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date_begin format. Use DD-MM-YYYY"})
-			return nil, jsonreqresp.EventFilter{}
+			return nil, jsonreqresp.EventFilter{}, jsonreqresp.EventSortOps{}, jsonreqresp.PageInfo{}
 		}
 		filterOps.DateBegin = dateBegin
 	}
@@ -118,7 +183,7 @@ func (r *CiteRouter) allEventsResp(c *gin.Context) (
 		dateEnd, err := parseDate(dateEndStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date_begin format. Use DD-MM-YYYY"})
-			return nil, jsonreqresp.EventFilter{}
+			return nil, jsonreqresp.EventFilter{}, jsonreqresp.EventSortOps{}, jsonreqresp.PageInfo{}
 		}
 		filterOps.DateEnd = dateEnd
 	}
@@ -126,31 +191,44 @@ func (r *CiteRouter) allEventsResp(c *gin.Context) (
 		_, err := strconv.ParseBool(canVisitStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid can_visit value (use true/false)"})
-			return nil, jsonreqresp.EventFilter{}
+			return nil, jsonreqresp.EventFilter{}, jsonreqresp.EventSortOps{}, jsonreqresp.PageInfo{}
 		}
 		filterOps.CanVisit = canVisitStr
 	}
 
-	events, err := r.searcherServ.GetAllEvents(ctx, &filterOps)
+	sortOps := jsonreqresp.EventSortOps{
+		Field:     c.Query("sort_field"),
+		Direction: c.Query("direction_sort"),
+	}
+	page, err := jsonreqresp.NewPageRequest(c.Query("limit"), c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, jsonreqresp.EventFilter{}, jsonreqresp.EventSortOps{}, jsonreqresp.PageInfo{}
+	}
+
+	events, pageInfo, err := r.searcherServ.GetAllEvents(ctx, &filterOps, &sortOps, &page)
 	if err != nil {
-		if errors.Is(err, jsonreqresp.ErrEventFilterDate) {
+		if errors.Is(err, jsonreqresp.ErrEventFilterDate) ||
+			errors.Is(err, jsonreqresp.ErrPageLimit) ||
+			errors.Is(err, jsonreqresp.ErrPageCursor) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		return nil, jsonreqresp.EventFilter{}
+		return nil, jsonreqresp.EventFilter{}, jsonreqresp.EventSortOps{}, jsonreqresp.PageInfo{}
 	}
 	eventsResp := make([]jsonreqresp.EventResponse, len(events))
 	for i, a := range events {
 		eventsResp[i] = a.ToEventResponse()
 	}
-	return eventsResp, filterOps
+	return eventsResp, filterOps, sortOps, pageInfo
 }
 
 func (r *CiteRouter) GetAllEvents(c *gin.Context) {
-	eventsResp, filterOps := r.allEventsResp(c)
+	eventsResp, filterOps, sortOps, pageInfo := r.allEventsResp(c)
 	if eventsResp != nil {
-		rend := gintemplrenderer.New(c.Request.Context(), http.StatusOK, components.EventsPage(eventsResp, filterOps))
+		rend := gintemplrenderer.New(c.Request.Context(), http.StatusOK, components.EventsPage(
+			eventsResp, filterOps, sortOps, pageInfo, nextPageURL(c, pageInfo.NextCursor)))
 		c.Render(http.StatusOK, rend)
 	}
 }
@@ -204,6 +282,12 @@ func (r *CiteRouter) GetEvent(c *gin.Context) {
 		statCollections[i] = v.ToResponse()
 	}
 
+	freeTickets, err := r.buyTicketServ.GetFreeTicketCount(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	rend := gintemplrenderer.New(
 		c.Request.Context(),
 		http.StatusOK,
@@ -211,11 +295,210 @@ func (r *CiteRouter) GetEvent(c *gin.Context) {
 			TokenLocalstorage, event.GetTitle(),
 			event.ToEventResponse(), artworksResp,
 			statCollections,
+			event.ToSchemaOrg(r.publicURL, freeTickets, artworks),
 		),
 	)
 	c.Render(http.StatusOK, rend)
 }
 
+func (r *CiteRouter) GetArtwork(c *gin.Context) {
+	ctx := c.Request.Context()
+	artworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artwork ID format"})
+		return
+	}
+
+	artwork, err := r.searcherServ.GetArtwork(ctx, artworkID)
+	if err != nil {
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	events, err := r.searcherServ.GetArtworkEvents(ctx, artworkID, &jsonreqresp.EventFilter{Valid: "true"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// Делим выставки на прошедшие и текущие/предстоящие
+	now := time.Now()
+	var upcomingEvents, pastEvents []jsonreqresp.EventResponse
+	for _, e := range events {
+		if e.GetDateEnd().Before(now) {
+			pastEvents = append(pastEvents, e.ToEventResponse())
+		} else {
+			upcomingEvents = append(upcomingEvents, e.ToEventResponse())
+		}
+	}
+	slices.Reverse(pastEvents)
+
+	provenance, err := r.searcherServ.GetArtworkProvenance(ctx, artworkID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	provenanceResp := make([]jsonreqresp.ProvenanceEntryResponse, len(provenance))
+	for i, e := range provenance {
+		provenanceResp[i] = e.ToProvenanceEntryResponse()
+	}
+
+	similar, err := r.recommendServ.GetSimilarArtworks(ctx, artworkID, models.SimilarDefaultLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	similarResp := make([]jsonreqresp.SimilarArtworkResponse, len(similar))
+	for i, s := range similar {
+		similarResp[i] = s.ToSimilarArtworkResponse()
+	}
+
+	rend := gintemplrenderer.New(
+		c.Request.Context(),
+		http.StatusOK,
+		components.ArtworkDetailsPage(artwork.ToArtworkResponse(), provenanceResp, upcomingEvents, pastEvents,
+			similarResp, artwork.ToSchemaOrg(r.publicURL)),
+	)
+	c.Render(http.StatusOK, rend)
+}
+
+func (r *CiteRouter) GetAuthor(c *gin.Context) {
+	ctx := c.Request.Context()
+	authorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author ID format"})
+		return
+	}
+
+	author, err := r.authorServ.GetByID(ctx, authorID)
+	if err != nil {
+		if errors.Is(err, authorrep.ErrAuthorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	artworks, pageInfo, err := r.searcherServ.GetAllArtworks(ctx,
+		&jsonreqresp.ArtworkFilter{AuthorIDs: uuid.UUIDs{authorID}},
+		&jsonreqresp.ArtworkSortOps{
+			Field:     jsonreqresp.CreationYearSortFieldArtwork,
+			Direction: jsonreqresp.ASCDirection,
+		},
+		&jsonreqresp.PageRequest{Limit: jsonreqresp.MaxPageSize},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	artworksResp := make([]jsonreqresp.ArtworkResponse, len(artworks))
+	for i, a := range artworks {
+		artworksResp[i] = a.ToArtworkResponse()
+	}
+	// на странице автора показывается первая страница, остальные произведения доступны в каталоге
+	var allArtworksURL string
+	if pageInfo.Total > len(artworks) {
+		allArtworksURL = "/museum/artworks?" + jsonreqresp.AuthorFacetArtwork + "=" + authorID.String()
+	}
+
+	events, err := r.searcherServ.GetAuthorEvents(ctx, authorID, &jsonreqresp.EventFilter{Valid: "true"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	var upcomingEvents, pastEvents []jsonreqresp.EventResponse
+	for _, e := range events {
+		if e.GetDateEnd().Before(now) {
+			pastEvents = append(pastEvents, e.ToEventResponse())
+		} else {
+			upcomingEvents = append(upcomingEvents, e.ToEventResponse())
+		}
+	}
+	slices.Reverse(pastEvents)
+
+	rend := gintemplrenderer.New(
+		ctx,
+		http.StatusOK,
+		components.AuthorDetailsPage(author.ToAuthorProfileResponse(), artworksResp, allArtworksURL,
+			upcomingEvents, pastEvents, author.ToSchemaOrgProfile(r.publicURL)),
+	)
+	c.Render(http.StatusOK, rend)
+}
+
+func (r *CiteRouter) GetTags(c *gin.Context) {
+	tree, err := r.tagServ.GetTree(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	treeResp := make([]jsonreqresp.TagNodeResponse, len(tree))
+	for i, node := range tree {
+		treeResp[i] = node.ToTagNodeResponse()
+	}
+	rend := gintemplrenderer.New(c.Request.Context(), http.StatusOK, components.TagsPage(treeResp))
+	c.Render(http.StatusOK, rend)
+}
+
+func (r *CiteRouter) GetTag(c *gin.Context) {
+	ctx := c.Request.Context()
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag ID format"})
+		return
+	}
+
+	node, ancestors, err := r.tagServ.GetNode(ctx, tagID)
+	if err != nil {
+		if errors.Is(err, tagrep.ErrTagNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	nodeResp := node.ToTagNodeResponse()
+	page := jsonreqresp.TagPageResponse{
+		Tag:       nodeResp.TagResponse,
+		Ancestors: make([]jsonreqresp.TagResponse, len(ancestors)),
+		Children:  nodeResp.Children,
+		Count:     nodeResp.Count,
+	}
+	for i, a := range ancestors {
+		page.Ancestors[i] = a.ToTagResponse()
+	}
+
+	rend := gintemplrenderer.New(ctx, http.StatusOK, components.TagPage(page))
+	c.Render(http.StatusOK, rend)
+}
+
+// GetUserList публичная подборка пользователя, личные подборки для посетителей не существуют
+func (r *CiteRouter) GetUserList(c *gin.Context) {
+	ctx := c.Request.Context()
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID format"})
+		return
+	}
+
+	l, records, err := r.userListServ.GetPublicList(ctx, listID)
+	if err != nil {
+		if errors.Is(err, userlistrep.ErrUserListNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	rend := gintemplrenderer.New(ctx, http.StatusOK, components.UserListPage(l.ToUserListResponse(r.publicURL, records)))
+	c.Render(http.StatusOK, rend)
+}
+
 // func (r *CiteRouter) GetAllEventsEmpl(c *gin.Context) {
 // 	eventsResp, filterOps := r.allEventsResp(c)
 // 	if eventsResp != nil {
@@ -99,13 +99,23 @@ func (r *EmployeeCiteRouter) allEventsResp(c *gin.Context) []jsonreqresp.EventRe
 	return eventsResp
 }
 
+func (r *EmployeeCiteRouter) allTemplatesResp(c *gin.Context) []jsonreqresp.EventTemplateResponse {
+	templates, _ := r.eventServ.GetTemplates(c.Request.Context())
+	var templatesResp []jsonreqresp.EventTemplateResponse
+	for _, t := range templates {
+		templatesResp = append(templatesResp, t.ToEventTemplateResponse())
+	}
+	return templatesResp
+}
+
 func (r *EmployeeCiteRouter) EventsCRUDPage(c *gin.Context) {
 	artsResp := r.allArtworksResp(c)
 	eventsResp := r.allEventsResp(c)
+	templatesResp := r.allTemplatesResp(c)
 
 	rend := gintemplrenderer.New(
 		c.Request.Context(),
 		http.StatusOK,
-		components.EventsCRUDPage(TokenLocalstorage, eventsResp, artsResp))
+		components.EventsCRUDPage(TokenLocalstorage, eventsResp, artsResp, templatesResp))
 	c.Render(http.StatusOK, rend)
 }
@@ -2,7 +2,11 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"github.com/google/uuid"
@@ -15,8 +19,17 @@ type Artwork struct {
 	technic      string
 	material     string
 	size         string
+	dimensions   Dimensions
+	dating       ArtworkDating
 	author       *Author
+	authorRole   AttributionRole
+	coAuthors    []Attribution
 	collection   *Collection
+	tags         []*Tag
+	images       []*ArtworkImage
+	highlight    *ArtworkHighlight
+	// updatedAt время последнего изменения записи, выставляется хранилищем
+	updatedAt time.Time
 }
 
 var (
@@ -33,8 +46,11 @@ var (
 	ErrArtworkInvalidAuthor     = errors.New("invalid author reference")
 	ErrArtworkInvalidCollection = errors.New("invalid collection reference")
 	ErrArtworkYearNotInRange    = errors.New("creation year not in author's lifetime")
+	ErrArtworkTooManyAuthors    = errors.New("too many attributed authors (10 max)")
+	ErrArtworkDuplicateAuthor   = errors.New("author is attributed more than once")
 )
 
+// NewArtwork создает произведение с точным годом создания и единственным автором
 func NewArtwork(
 	id uuid.UUID,
 	title string,
@@ -44,6 +60,23 @@ func NewArtwork(
 	creationYear int,
 	author *Author,
 	collection *Collection,
+) (Artwork, error) {
+	return NewDatedArtwork(id, title, technic, material, size, ExactYear(creationYear),
+		Attribution{author: author, role: RoleAuthor}, nil, collection)
+}
+
+// NewDatedArtwork создает произведение с датировкой периодом и несколькими авторами.
+// author - основной автор, coAuthors - дополнительные авторы в порядке показа
+func NewDatedArtwork(
+	id uuid.UUID,
+	title string,
+	technic string,
+	material string,
+	size string,
+	dating ArtworkDating,
+	author Attribution,
+	coAuthors []Attribution,
+	collection *Collection,
 ) (Artwork, error) {
 	artwork := Artwork{
 		id:           id,
@@ -51,8 +84,11 @@ func NewArtwork(
 		technic:      strings.TrimSpace(technic),
 		material:     strings.TrimSpace(material),
 		size:         strings.TrimSpace(size),
-		creationYear: creationYear,
-		author:       author,
+		creationYear: dating.EffectiveYear(),
+		dating:       dating,
+		author:       author.author,
+		authorRole:   author.role,
+		coAuthors:    coAuthors,
 		collection:   collection,
 	}
 
@@ -79,27 +115,66 @@ func (a *Artwork) validate() error {
 		return ErrArtworkMaterialTooLong
 	case a.size == "":
 		return ErrArtworkEmptySize
-	case len(a.size) > 50:
+	case len(a.size) > MaxSizeLen:
 		return ErrArtworkSizeTooLong
-	case a.creationYear < 0:
+	case a.dating.validate() != nil:
 		return ErrArtworkInvalidYear
 	case a.author == nil:
 		return ErrArtworkInvalidAuthor
+	case !a.authorRole.IsValid():
+		return ErrAttributionInvalidRole
 	case a.collection == nil:
 		return ErrArtworkInvalidCollection
+	case len(a.coAuthors) >= ArtworkMaxAuthors:
+		return ErrArtworkTooManyAuthors
 	}
 
-	// validateWithAuthor
-	birthYear := a.author.GetBirthYear()
-	deathYear := a.author.GetDeathYear()
-	if a.creationYear < birthYear || (deathYear > 0 && a.creationYear > deathYear) {
+	// validateWithAuthors
+	attributed := map[uuid.UUID]struct{}{a.author.GetID(): {}}
+	if !a.dating.fitsLifetime(a.authorRole, a.author.GetBirthYear(), a.author.GetDeathYear()) {
 		return ErrArtworkYearNotInRange
 	}
+	for _, co := range a.coAuthors {
+		switch {
+		case co.author == nil:
+			return ErrAttributionInvalidAuthor
+		case !co.role.IsValid():
+			return ErrAttributionInvalidRole
+		}
+		if _, ok := attributed[co.author.GetID()]; ok {
+			return ErrArtworkDuplicateAuthor
+		}
+		attributed[co.author.GetID()] = struct{}{}
+		if !a.dating.fitsLifetime(co.role, co.author.GetBirthYear(), co.author.GetDeathYear()) {
+			return ErrArtworkYearNotInRange
+		}
+	}
 	return nil
 }
 
+// ResolveDating возвращает датировку из запроса, а если ее нет - точный год creationYear
+func ResolveDating(req *jsonreqresp.ArtworkDatingRequest, creationYear int) (ArtworkDating, error) {
+	if req != nil {
+		return NewArtworkDatingFromRequest(req)
+	}
+	return ExactYear(creationYear), nil
+}
+
+// ResolveDimensions возвращает размеры из запроса, а если их нет - разобранные из текстового размера.
+// Нераспознанный текстовый размер не ошибка: возвращается нулевое значение.
+func ResolveDimensions(req *jsonreqresp.DimensionsRequest, size string) (Dimensions, error) {
+	if req != nil {
+		return NewDimensionsFromRequest(req)
+	}
+	dimensions, err := ParseDimensions(size)
+	if err != nil {
+		return Dimensions{}, nil
+	}
+	return dimensions, nil
+}
+
 func (a *Artwork) ToArtworkResponse() jsonreqresp.ArtworkResponse {
-	return jsonreqresp.ArtworkResponse{
+	resp := jsonreqresp.ArtworkResponse{
 		ID:           a.id.String(),
 		Title:        a.title,
 		CreationYear: a.creationYear,
@@ -108,7 +183,32 @@ func (a *Artwork) ToArtworkResponse() jsonreqresp.ArtworkResponse {
 		Size:         a.size,
 		Author:       a.GetAuthor().ToAuthorResponse(),
 		Collection:   a.GetCollection().ToCollectionResponse(),
+		Tags:         make([]jsonreqresp.TagResponse, len(a.tags)),
+		Images:       make([]jsonreqresp.ArtworkImageResponse, len(a.images)),
+	}
+	for i, tag := range a.tags {
+		resp.Tags[i] = tag.ToTagResponse()
+	}
+	resp.Dating = a.dating.ToArtworkDatingResponse()
+	resp.Authors = make([]jsonreqresp.AttributionResponse, 0, len(a.coAuthors)+1)
+	for _, attribution := range a.GetAttributions() {
+		resp.Authors = append(resp.Authors, attribution.ToAttributionResponse())
+	}
+	if !a.dimensions.IsZero() {
+		dimensions := a.dimensions.ToDimensionsResponse()
+		resp.Dimensions = &dimensions
+	}
+	for i, img := range a.images {
+		resp.Images[i] = img.ToArtworkImageResponse()
+		if img.IsPrimary() {
+			resp.PrimaryImage = &resp.Images[i]
+		}
+	}
+	if a.highlight != nil {
+		highlight := a.highlight.ToArtworkHighlightResponse()
+		resp.Highlight = &highlight
 	}
+	return resp
 }
 
 func (a *Artwork) GetID() uuid.UUID {
@@ -123,10 +223,41 @@ func (a *Artwork) GetCreationYear() int {
 	return a.creationYear
 }
 
+// GetDating возвращает датировку произведения; GetCreationYear - ее действующий год
+func (a *Artwork) GetDating() ArtworkDating {
+	return a.dating
+}
+
+// GetAuthor возвращает основного автора произведения
 func (a *Artwork) GetAuthor() *Author {
 	return a.author
 }
 
+func (a *Artwork) GetAuthorRole() AttributionRole {
+	return a.authorRole
+}
+
+// GetCoAuthors возвращает дополнительных авторов произведения в порядке показа
+func (a *Artwork) GetCoAuthors() []Attribution {
+	return a.coAuthors
+}
+
+// GetAttributions возвращает всех авторов произведения, основной автор - первый
+func (a *Artwork) GetAttributions() []Attribution {
+	return append([]Attribution{{author: a.author, role: a.authorRole}}, a.coAuthors...)
+}
+
+// SetCoAuthors заменяет дополнительных авторов, проверяя их годы жизни по датировке произведения
+func (a *Artwork) SetCoAuthors(coAuthors []Attribution) error {
+	copyA := *a
+	copyA.coAuthors = coAuthors
+	if err := copyA.validate(); err != nil {
+		return err
+	}
+	*a = copyA
+	return nil
+}
+
 func (a *Artwork) GetCollection() *Collection {
 	return a.collection
 }
@@ -135,6 +266,20 @@ func (a *Artwork) GetSize() string {
 	return a.size
 }
 
+// GetDimensions возвращает структурированный размер, нулевое значение - размер задан только текстом
+func (a *Artwork) GetDimensions() Dimensions {
+	return a.dimensions
+}
+
+// SetDimensions задает структурированный размер, текстовый размер формируется по нему.
+// Нулевое значение оставляет только текстовый размер.
+func (a *Artwork) SetDimensions(d Dimensions) {
+	a.dimensions = d
+	if !d.IsZero() {
+		a.size = d.String()
+	}
+}
+
 func (a *Artwork) GetMaterial() string {
 	return a.material
 }
@@ -143,13 +288,83 @@ func (a *Artwork) GetTechnic() string {
 	return a.technic
 }
 
+// GetImages возвращает изображения произведения, упорядоченные по позиции
+func (a *Artwork) GetImages() []*ArtworkImage {
+	return a.images
+}
+
+func (a *Artwork) GetPrimaryImage() *ArtworkImage {
+	for _, img := range a.images {
+		if img.IsPrimary() {
+			return img
+		}
+	}
+	return nil
+}
+
+func (a *Artwork) SetImages(images []*ArtworkImage) {
+	a.images = images
+}
+
+// GetTags возвращает теги произведения, упорядоченные по виду и названию
+func (a *Artwork) GetTags() []*Tag {
+	return a.tags
+}
+
+func (a *Artwork) SetTags(tags []*Tag) {
+	a.tags = tags
+}
+
+// GetHighlight возвращает подсветку совпадений, если произведение найдено полнотекстовым поиском
+func (a *Artwork) GetUpdatedAt() time.Time {
+	return a.updatedAt
+}
+
+func (a *Artwork) SetUpdatedAt(updatedAt time.Time) {
+	a.updatedAt = updatedAt
+}
+
+func (a *Artwork) GetHighlight() *ArtworkHighlight {
+	return a.highlight
+}
+
+func (a *Artwork) SetHighlight(highlight *ArtworkHighlight) {
+	a.highlight = highlight
+}
+
 func (a *Artwork) Update(updateReq jsonreqresp.ArtworkUpdate) error {
+	return a.UpdateWithCoAuthors(updateReq, a.coAuthors)
+}
+
+// UpdateWithCoAuthors изменяет поля произведения и заменяет дополнительных авторов,
+// датировка и годы жизни всех авторов проверяются вместе.
+// Без Dating в запросе текущая датировка сохраняется, если CreationYear не изменился, иначе становится точным годом.
+// Без Dimensions текущие размеры сохраняются, если Size не изменился, иначе разбираются из Size.
+// Пустая AuthorRole оставляет текущую роль основного автора
+func (a *Artwork) UpdateWithCoAuthors(updateReq jsonreqresp.ArtworkUpdate, coAuthors []Attribution) error {
 	copyA := *a
 	copyA.title = updateReq.Title
-	copyA.creationYear = updateReq.CreationYear
 	copyA.technic = updateReq.Technic
 	copyA.material = updateReq.Material
-	copyA.size = updateReq.Size
+	copyA.size = strings.TrimSpace(updateReq.Size)
+	var err error
+	if updateReq.Dimensions != nil || copyA.size != a.size {
+		dimensions, err := ResolveDimensions(updateReq.Dimensions, copyA.size)
+		if err != nil {
+			return err
+		}
+		copyA.SetDimensions(dimensions)
+	}
+	if updateReq.Dating != nil || updateReq.CreationYear != a.creationYear {
+		if copyA.dating, err = ResolveDating(updateReq.Dating, updateReq.CreationYear); err != nil {
+			return err
+		}
+		copyA.creationYear = copyA.dating.EffectiveYear()
+	}
+	if updateReq.AuthorRole != "" {
+		copyA.authorRole = AttributionRole(updateReq.AuthorRole)
+	}
+	copyA.coAuthors = coAuthors
 
 	if err := copyA.validate(); err != nil {
 		return err
@@ -158,3 +373,175 @@ func (a *Artwork) Update(updateReq jsonreqresp.ArtworkUpdate) error {
 	*a = copyA
 	return nil
 }
+
+// Snapshot возвращает изменяемые через Update поля для истории изменений
+func (a *Artwork) Snapshot() Snapshot {
+	return Snapshot{
+		"title":         a.title,
+		"creationYear":  strconv.Itoa(a.creationYear),
+		"technic":       a.technic,
+		"material":      a.material,
+		"size":          a.size,
+		"yearFrom":      strconv.Itoa(a.dating.yearFrom),
+		"yearTo":        strconv.Itoa(a.dating.yearTo),
+		"dateQualifier": string(a.dating.qualifier),
+		"datePrecision": string(a.dating.precision),
+		"authorRole":    string(a.authorRole),
+		"coAuthors":     coAuthorsSnapshot(a.coAuthors),
+		"heightCm":      snapshotFloat(a.dimensions.heightCm),
+		"widthCm":       snapshotFloat(a.dimensions.widthCm),
+		"depthCm":       snapshotFloat(a.dimensions.depthCm),
+		"weightKg":      snapshotFloat(a.dimensions.weightKg),
+		"lengthUnit":    string(a.dimensions.lengthUnit),
+		"weightUnit":    string(a.dimensions.weightUnit),
+	}
+}
+
+func snapshotFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// snapshotDimensions возвращает размеры из снимка произведения, ok = false - снимок сделан
+// до появления размеров в истории
+func snapshotDimensions(s Snapshot) (d Dimensions, ok bool, err error) {
+	if _, found := s["heightCm"]; !found {
+		return Dimensions{}, false, nil
+	}
+	if d.heightCm, err = s.Float("heightCm"); err != nil {
+		return Dimensions{}, false, err
+	}
+	if d.heightCm == 0 {
+		return Dimensions{}, true, nil
+	}
+	if d.widthCm, err = s.Float("widthCm"); err != nil {
+		return Dimensions{}, false, err
+	}
+	if d.depthCm, err = s.Float("depthCm"); err != nil {
+		return Dimensions{}, false, err
+	}
+	if d.weightKg, err = s.Float("weightKg"); err != nil {
+		return Dimensions{}, false, err
+	}
+	lengthUnit, err := s.String("lengthUnit")
+	if err != nil {
+		return Dimensions{}, false, err
+	}
+	weightUnit, err := s.String("weightUnit")
+	if err != nil {
+		return Dimensions{}, false, err
+	}
+	d, err = NewDimensions(d.heightCm, d.widthCm, d.depthCm, d.weightKg, LengthUnit(lengthUnit), WeightUnit(weightUnit))
+	if err != nil {
+		return Dimensions{}, false, fmt.Errorf("%w: dimensions: %v", ErrSnapshotField, err)
+	}
+	return d, true, nil
+}
+
+// coAuthorsSnapshot записывает дополнительных авторов строками "id:роль:позиция" через перевод строки
+func coAuthorsSnapshot(coAuthors []Attribution) string {
+	lines := make([]string, len(coAuthors))
+	for i, co := range coAuthors {
+		lines[i] = fmt.Sprintf("%s:%s:%d", co.author.GetID(), co.role, i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SnapshotCoAuthors возвращает дополнительных авторов из снимка произведения в порядке показа.
+// Для снимков, сделанных до появления дополнительных авторов в истории, возвращает nil
+func SnapshotCoAuthors(s Snapshot) ([]jsonreqresp.AttributionRequest, error) {
+	v, ok := s["coAuthors"]
+	if !ok {
+		return nil, nil
+	}
+	type position struct {
+		req jsonreqresp.AttributionRequest
+		pos int
+	}
+	var positions []position
+	for _, line := range strings.Split(v, "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%w: coAuthors: invalid entry %q", ErrSnapshotField, line)
+		}
+		if _, err := uuid.Parse(parts[0]); err != nil {
+			return nil, fmt.Errorf("%w: coAuthors: %v", ErrSnapshotField, err)
+		}
+		pos, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: coAuthors: %v", ErrSnapshotField, err)
+		}
+		positions = append(positions, position{
+			req: jsonreqresp.AttributionRequest{AuthorID: parts[0], Role: parts[1]},
+			pos: pos,
+		})
+	}
+	slices.SortStableFunc(positions, func(a, b position) int { return a.pos - b.pos })
+	coAuthors := make([]jsonreqresp.AttributionRequest, len(positions))
+	for i, p := range positions {
+		coAuthors[i] = p.req
+	}
+	return coAuthors, nil
+}
+
+// Restore возвращает поля произведения к сохраненному состоянию, авторы и коллекция не меняются.
+// В снимках, сделанных до появления датировки, она восстанавливается точным годом
+func (a *Artwork) Restore(s Snapshot) error {
+	return a.RestoreWithCoAuthors(s, a.coAuthors)
+}
+
+// RestoreWithCoAuthors возвращает поля произведения к сохраненному состоянию и заменяет
+// дополнительных авторов на coAuthors из того же снимка, см. SnapshotCoAuthors.
+// В снимках, сделанных до появления размеров в истории, они определяются по Size, как в UpdateWithCoAuthors
+func (a *Artwork) RestoreWithCoAuthors(s Snapshot, coAuthors []Attribution) error {
+	var req jsonreqresp.ArtworkUpdate
+	var err error
+	dimensions, hasDimensions, err := snapshotDimensions(s)
+	if err != nil {
+		return err
+	}
+	if _, ok := s["yearFrom"]; ok {
+		req.Dating = &jsonreqresp.ArtworkDatingRequest{}
+		if req.Dating.YearFrom, err = s.Int("yearFrom"); err != nil {
+			return err
+		}
+		if req.Dating.YearTo, err = s.Int("yearTo"); err != nil {
+			return err
+		}
+		if req.Dating.Qualifier, err = s.String("dateQualifier"); err != nil {
+			return err
+		}
+		if req.Dating.Precision, err = s.String("datePrecision"); err != nil {
+			return err
+		}
+		if req.AuthorRole, err = s.String("authorRole"); err != nil {
+			return err
+		}
+	}
+	if req.Title, err = s.String("title"); err != nil {
+		return err
+	}
+	if req.CreationYear, err = s.Int("creationYear"); err != nil {
+		return err
+	}
+	if req.Technic, err = s.String("technic"); err != nil {
+		return err
+	}
+	if req.Material, err = s.String("material"); err != nil {
+		return err
+	}
+	if req.Size, err = s.String("size"); err != nil {
+		return err
+	}
+	copyA := *a
+	if err := copyA.UpdateWithCoAuthors(req, coAuthors); err != nil {
+		return err
+	}
+	if hasDimensions {
+		copyA.SetDimensions(dimensions)
+	}
+	*a = copyA
+	return nil
+}
@@ -0,0 +1,291 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// DatePrecision точность датировки произведения
+type DatePrecision string
+
+const (
+	PrecisionYear    DatePrecision = "year"
+	PrecisionDecade  DatePrecision = "decade"
+	PrecisionCentury DatePrecision = "century"
+)
+
+func (p DatePrecision) IsValid() bool {
+	switch p {
+	case PrecisionYear, PrecisionDecade, PrecisionCentury:
+		return true
+	}
+	return false
+}
+
+// circaToleranceYears на сколько лет датировка "около" может выходить за годы жизни автора
+const circaToleranceYears = 10
+
+// ArtworkDating датировка произведения: период создания [yearFrom, yearTo], точность и уточнение.
+// Для точности decade и century период совпадает с границами десятилетия или века.
+// before - создано не позже периода, after - не раньше.
+type ArtworkDating struct {
+	yearFrom  int
+	yearTo    int
+	qualifier DateQualifier
+	precision DatePrecision
+}
+
+var (
+	ErrDatingInvalidPeriod    = errors.New("invalid creation period")
+	ErrDatingInvalidQualifier = errors.New("invalid date qualifier (exact, circa, before, after)")
+	ErrDatingInvalidPrecision = errors.New("invalid date precision (year, decade, century)")
+)
+
+func NewArtworkDating(yearFrom int, yearTo int, qualifier DateQualifier, precision DatePrecision) (ArtworkDating, error) {
+	if qualifier == "" {
+		qualifier = DateExact
+	}
+	if precision == "" {
+		precision = PrecisionYear
+	}
+	d := ArtworkDating{
+		yearFrom:  yearFrom,
+		yearTo:    yearTo,
+		qualifier: qualifier,
+		precision: precision,
+	}
+
+	if err := d.validate(); err != nil {
+		return ArtworkDating{}, err
+	}
+
+	return d, nil
+}
+
+// ExactYear возвращает точную датировку одним годом
+func ExactYear(year int) ArtworkDating {
+	return ArtworkDating{yearFrom: year, yearTo: year, qualifier: DateExact, precision: PrecisionYear}
+}
+
+// NewArtworkDatingFromRequest приводит границы периода к десятилетиям или векам согласно точности.
+// YearTo = 0 означает период из одного года (десятилетия, века)
+func NewArtworkDatingFromRequest(req *jsonreqresp.ArtworkDatingRequest) (ArtworkDating, error) {
+	from, to := req.YearFrom, req.YearTo
+	if to == 0 {
+		to = from
+	}
+	precision := DatePrecision(req.Precision)
+	switch precision {
+	case PrecisionDecade:
+		from, to = from/10*10, to/10*10+9
+	case PrecisionCentury:
+		from, _ = CenturyYears(CenturyOf(from))
+		_, to = CenturyYears(CenturyOf(to))
+	}
+	return NewArtworkDating(from, to, DateQualifier(req.Qualifier), precision)
+}
+
+func (d *ArtworkDating) validate() error {
+	switch {
+	case d.yearFrom <= 0 || d.yearTo < d.yearFrom:
+		return ErrDatingInvalidPeriod
+	case !d.qualifier.IsValid():
+		return ErrDatingInvalidQualifier
+	case !d.precision.IsValid():
+		return ErrDatingInvalidPrecision
+	case d.precision == PrecisionDecade && (d.yearFrom%10 != 0 || d.yearTo%10 != 9):
+		return ErrDatingInvalidPeriod
+	case d.precision == PrecisionCentury && (d.yearFrom%100 != 1 || d.yearTo%100 != 0):
+		return ErrDatingInvalidPeriod
+	}
+	return nil
+}
+
+// EffectiveYear год для поиска и сортировки - середина периода создания
+func (d ArtworkDating) EffectiveYear() int {
+	return (d.yearFrom + d.yearTo) / 2
+}
+
+// fitsLifetime проверяет, что датировка совместима с годами жизни автора.
+// Произведение не может быть создано до рождения автора, а для ролей, требующих
+// личного участия автора, - и после его смерти. deathYear = 0 - автор жив.
+func (d ArtworkDating) fitsLifetime(role AttributionRole, birthYear int, deathYear int) bool {
+	from, to := d.yearFrom, d.yearTo
+	switch d.qualifier {
+	case DateCirca:
+		from, to = from-circaToleranceYears, to+circaToleranceYears
+	case DateBefore:
+		from = math.MinInt
+	case DateAfter:
+		to = math.MaxInt
+	}
+	if to < birthYear {
+		return false
+	}
+	return !role.boundToLifetime() || deathYear == 0 || from <= deathYear
+}
+
+// Label возвращает датировку для показа, например "ок. 1650", "1890-е", "XVII век"
+func (d ArtworkDating) Label() string {
+	var period string
+	switch d.precision {
+	case PrecisionDecade:
+		period = strconv.Itoa(d.yearFrom) + "-е"
+		if last := d.yearTo - 9; last != d.yearFrom {
+			period += "–" + strconv.Itoa(last) + "-е"
+		}
+	case PrecisionCentury:
+		from, to := CenturyOf(d.yearFrom), CenturyOf(d.yearTo)
+		period = CenturyLabel(from)
+		if to != from {
+			period = CenturyLabel(from) + " – " + CenturyLabel(to)
+		}
+	default:
+		period = strconv.Itoa(d.yearFrom)
+		if d.yearTo != d.yearFrom {
+			period += "–" + strconv.Itoa(d.yearTo)
+		}
+	}
+	switch d.qualifier {
+	case DateCirca:
+		return "ок. " + period
+	case DateBefore:
+		return "до " + period
+	case DateAfter:
+		return "после " + period
+	}
+	return period
+}
+
+// EDTF возвращает датировку в формате Extended Date/Time Format (ISO 8601-2) для обмена с внешними каталогами,
+// например "1889", "1884/1885", "1650~", "../1700"
+func (d ArtworkDating) EDTF() string {
+	from, to := strconv.Itoa(d.yearFrom), strconv.Itoa(d.yearTo)
+	if d.qualifier == DateCirca {
+		from, to = from+"~", to+"~"
+	}
+	switch {
+	case d.qualifier == DateBefore:
+		return "../" + to
+	case d.qualifier == DateAfter:
+		return from + "/.."
+	case d.yearFrom == d.yearTo:
+		return from
+	}
+	return from + "/" + to
+}
+
+func (d ArtworkDating) ToArtworkDatingResponse() jsonreqresp.ArtworkDatingResponse {
+	return jsonreqresp.ArtworkDatingResponse{
+		YearFrom:  d.yearFrom,
+		YearTo:    d.yearTo,
+		Qualifier: string(d.qualifier),
+		Precision: string(d.precision),
+		Label:     d.Label(),
+	}
+}
+
+func (d ArtworkDating) GetYearFrom() int {
+	return d.yearFrom
+}
+
+func (d ArtworkDating) GetYearTo() int {
+	return d.yearTo
+}
+
+func (d ArtworkDating) GetQualifier() DateQualifier {
+	return d.qualifier
+}
+
+func (d ArtworkDating) GetPrecision() DatePrecision {
+	return d.precision
+}
+
+// AttributionRole характер связи автора с произведением
+type AttributionRole string
+
+const (
+	RoleAuthor     AttributionRole = "author"
+	RoleAttributed AttributionRole = "attributed"
+	RoleWorkshop   AttributionRole = "workshop"
+	RoleCircle     AttributionRole = "circle"
+	RoleFollower   AttributionRole = "follower"
+	RoleCopyAfter  AttributionRole = "copy_after"
+)
+
+var attributionRoleLabels = map[AttributionRole]string{
+	RoleAuthor:     "автор",
+	RoleAttributed: "приписывается",
+	RoleWorkshop:   "мастерская",
+	RoleCircle:     "круг",
+	RoleFollower:   "последователь",
+	RoleCopyAfter:  "копия с оригинала",
+}
+
+func (r AttributionRole) IsValid() bool {
+	_, ok := attributionRoleLabels[r]
+	return ok
+}
+
+func (r AttributionRole) Label() string {
+	return attributionRoleLabels[r]
+}
+
+// boundToLifetime сообщает, что произведение с такой ролью создано самим автором при жизни
+func (r AttributionRole) boundToLifetime() bool {
+	return r == RoleAuthor || r == RoleAttributed
+}
+
+// ArtworkMaxAuthors максимальное число авторов, связанных с одним произведением
+const ArtworkMaxAuthors = 10
+
+// Attribution автор произведения и его роль
+type Attribution struct {
+	author *Author
+	role   AttributionRole
+}
+
+var (
+	ErrAttributionInvalidAuthor = errors.New("invalid attributed author reference")
+	ErrAttributionInvalidRole   = errors.New("invalid attribution role (author, attributed, workshop, circle, follower, copy_after)")
+)
+
+func NewAttribution(author *Author, role AttributionRole) (Attribution, error) {
+	if role == "" {
+		role = RoleAuthor
+	}
+	switch {
+	case author == nil:
+		return Attribution{}, ErrAttributionInvalidAuthor
+	case !role.IsValid():
+		return Attribution{}, ErrAttributionInvalidRole
+	}
+	return Attribution{author: author, role: role}, nil
+}
+
+func (a Attribution) GetAuthor() *Author {
+	return a.author
+}
+
+func (a Attribution) GetRole() AttributionRole {
+	return a.role
+}
+
+// Label возвращает имя автора и роль, если он не автор в собственном смысле
+func (a Attribution) Label() string {
+	if a.role == RoleAuthor {
+		return a.author.GetName()
+	}
+	return a.author.GetName() + " (" + a.role.Label() + ")"
+}
+
+func (a Attribution) ToAttributionResponse() jsonreqresp.AttributionResponse {
+	return jsonreqresp.AttributionResponse{
+		Author:    a.author.ToAuthorResponse(),
+		Role:      string(a.role),
+		RoleLabel: a.role.Label(),
+	}
+}
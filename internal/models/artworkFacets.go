@@ -0,0 +1,119 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// ArtworkFacetMaxBuckets максимальное число значений, возвращаемых для одного фасета
+const ArtworkFacetMaxBuckets = 30
+
+// FacetBucket значение фасета и количество произведений с ним
+type FacetBucket struct {
+	Value string
+	Label string
+	Count int
+}
+
+// ArtworkFacets значения фасетов для текущего фильтра произведений
+type ArtworkFacets struct {
+	Technics    []FacetBucket
+	Materials   []FacetBucket
+	Centuries   []FacetBucket
+	Authors     []FacetBucket
+	Collections []FacetBucket
+	// YearMin и YearMax границы года создания, 0 - подходящих произведений нет
+	YearMin int
+	YearMax int
+}
+
+// CenturyOf возвращает век года создания (1-100 - I век)
+func CenturyOf(year int) int {
+	return (year-1)/100 + 1
+}
+
+// CenturyYears возвращает первый и последний год века
+func CenturyYears(century int) (int, int) {
+	return (century-1)*100 + 1, century * 100
+}
+
+// CenturyLabel возвращает название века римскими цифрами, например "XIX век"
+func CenturyLabel(century int) string {
+	numerals := []struct {
+		value  int
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+		{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	}
+	var b strings.Builder
+	for _, n := range numerals {
+		for century >= n.value {
+			b.WriteString(n.symbol)
+			century -= n.value
+		}
+	}
+	return b.String() + " век"
+}
+
+// ToArtworkFacetsResponse отмечает выбранные в фильтре значения.
+// Выбранные значения, для которых не нашлось произведений, добавляются с нулевым количеством,
+// чтобы выбор можно было снять
+func (f *ArtworkFacets) ToArtworkFacetsResponse(filter *jsonreqresp.ArtworkFilter) jsonreqresp.ArtworkFacetsResponse {
+	centuries := make([]string, len(filter.Centuries))
+	for i, c := range filter.Centuries {
+		centuries[i] = strconv.Itoa(c)
+	}
+	return jsonreqresp.ArtworkFacetsResponse{
+		Technics:    facetBucketsResponse(f.Technics, filter.Technics, nil),
+		Materials:   facetBucketsResponse(f.Materials, filter.Materials, nil),
+		Centuries:   facetBucketsResponse(f.Centuries, centuries, centuryValueLabel),
+		Authors:     facetBucketsResponse(f.Authors, filter.AuthorIDs.Strings(), nil),
+		Collections: facetBucketsResponse(f.Collections, filter.CollectionIDs.Strings(), nil),
+		CreationYear: jsonreqresp.YearRangeFacetResponse{
+			Min:  f.YearMin,
+			Max:  f.YearMax,
+			From: filter.YearFrom,
+			To:   filter.YearTo,
+		},
+	}
+}
+
+func centuryValueLabel(value string) string {
+	century, err := strconv.Atoi(value)
+	if err != nil {
+		return value
+	}
+	return CenturyLabel(century)
+}
+
+func facetBucketsResponse(buckets []FacetBucket, selected []string, label func(string) string) []jsonreqresp.FacetBucketResponse {
+	isSelected := make(map[string]bool, len(selected))
+	for _, v := range selected {
+		isSelected[v] = true
+	}
+	res := make([]jsonreqresp.FacetBucketResponse, 0, len(buckets)+len(selected))
+	found := make(map[string]bool, len(buckets))
+	for _, b := range buckets {
+		found[b.Value] = true
+		res = append(res, jsonreqresp.FacetBucketResponse{
+			Value:    b.Value,
+			Label:    b.Label,
+			Count:    b.Count,
+			Selected: isSelected[b.Value],
+		})
+	}
+	for _, v := range selected {
+		if found[v] {
+			continue
+		}
+		bucketLabel := v
+		if label != nil {
+			bucketLabel = label(v)
+		}
+		res = append(res, jsonreqresp.FacetBucketResponse{Value: v, Label: bucketLabel, Selected: true})
+	}
+	return res
+}
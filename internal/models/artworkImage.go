@@ -0,0 +1,193 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+type ImageFormat string
+
+const (
+	ImageFormatJPEG ImageFormat = "jpeg"
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatWEBP ImageFormat = "webp"
+)
+
+func (f ImageFormat) IsValid() bool {
+	switch f {
+	case ImageFormatJPEG, ImageFormatPNG, ImageFormatWEBP:
+		return true
+	}
+	return false
+}
+
+// Extension возвращает расширение файла для формата изображения
+func (f ImageFormat) Extension() string {
+	if f == ImageFormatJPEG {
+		return "jpg"
+	}
+	return string(f)
+}
+
+const (
+	// ArtworkImageMaxSize максимальный размер загружаемого файла (10 МБ)
+	ArtworkImageMaxSize = 10 << 20
+	// ArtworkImageMaxPixels ограничивает размер изображения в пикселях,
+	// чтобы не распаковывать в память слишком большие картинки
+	ArtworkImageMaxPixels = 50_000_000
+	// ArtworkImageMaxCount максимальное количество изображений у одного произведения
+	ArtworkImageMaxCount = 20
+	// ArtworkImagesURLPrefix префикс, по которому раздаются файлы изображений
+	ArtworkImagesURLPrefix = "/images/"
+)
+
+// ArtworkThumbnailWidths ширины (в пикселях) генерируемых миниатюр
+var ArtworkThumbnailWidths = []int{160, 480, 1024}
+
+type ArtworkImage struct {
+	id        uuid.UUID
+	artworkID uuid.UUID
+	position  int
+	isPrimary bool
+	format    ImageFormat
+	width     int
+	height    int
+	createdAt time.Time
+}
+
+var (
+	ErrValidateArtworkImage        = errors.New("invalid artwork image")
+	ErrArtworkImageInvalidArtwork  = errors.New("invalid artwork reference")
+	ErrArtworkImageInvalidFormat   = errors.New("unsupported image format (jpeg, png, webp)")
+	ErrArtworkImageInvalidSize     = errors.New("invalid image dimensions")
+	ErrArtworkImageTooLarge        = errors.New("image dimensions exceed the limit")
+	ErrArtworkImageInvalidPosition = errors.New("invalid image position")
+)
+
+func NewArtworkImage(
+	id uuid.UUID,
+	artworkID uuid.UUID,
+	position int,
+	isPrimary bool,
+	format ImageFormat,
+	width int,
+	height int,
+	createdAt time.Time,
+) (ArtworkImage, error) {
+	img := ArtworkImage{
+		id:        id,
+		artworkID: artworkID,
+		position:  position,
+		isPrimary: isPrimary,
+		format:    format,
+		width:     width,
+		height:    height,
+		createdAt: createdAt,
+	}
+
+	if err := img.validate(); err != nil {
+		return ArtworkImage{}, err
+	}
+
+	return img, nil
+}
+
+func (i *ArtworkImage) validate() error {
+	switch {
+	case i.artworkID == uuid.Nil:
+		return ErrArtworkImageInvalidArtwork
+	case !i.format.IsValid():
+		return ErrArtworkImageInvalidFormat
+	case i.width <= 0 || i.height <= 0:
+		return ErrArtworkImageInvalidSize
+	case i.width*i.height > ArtworkImageMaxPixels:
+		return ErrArtworkImageTooLarge
+	case i.position < 0:
+		return ErrArtworkImageInvalidPosition
+	}
+	return nil
+}
+
+// ArtworkImagesDir возвращает каталог хранилища со всеми изображениями произведения
+func ArtworkImagesDir(artworkID uuid.UUID) string {
+	return path.Join("artworks", artworkID.String())
+}
+
+// StorageDir возвращает каталог хранилища с оригиналом и миниатюрами изображения
+func (i *ArtworkImage) StorageDir() string {
+	return path.Join(ArtworkImagesDir(i.artworkID), i.id.String())
+}
+
+func (i *ArtworkImage) OriginalKey() string {
+	return path.Join(i.StorageDir(), "original."+i.format.Extension())
+}
+
+// ThumbnailKey возвращает ключ миниатюры заданной ширины, миниатюры всегда хранятся в jpeg
+func (i *ArtworkImage) ThumbnailKey(width int) string {
+	return path.Join(i.StorageDir(), fmt.Sprintf("w%d.jpg", width))
+}
+
+func (i *ArtworkImage) ToArtworkImageResponse() jsonreqresp.ArtworkImageResponse {
+	thumbnails := make([]jsonreqresp.ArtworkThumbnailResponse, len(ArtworkThumbnailWidths))
+	for j, w := range ArtworkThumbnailWidths {
+		thumbnails[j] = jsonreqresp.ArtworkThumbnailResponse{
+			Width: w,
+			URL:   ArtworkImagesURLPrefix + i.ThumbnailKey(w),
+		}
+	}
+	return jsonreqresp.ArtworkImageResponse{
+		ID:         i.id.String(),
+		Position:   i.position,
+		IsPrimary:  i.isPrimary,
+		Format:     string(i.format),
+		Width:      i.width,
+		Height:     i.height,
+		URL:        ArtworkImagesURLPrefix + i.OriginalKey(),
+		Thumbnails: thumbnails,
+	}
+}
+
+func (i *ArtworkImage) GetID() uuid.UUID {
+	return i.id
+}
+
+func (i *ArtworkImage) GetArtworkID() uuid.UUID {
+	return i.artworkID
+}
+
+func (i *ArtworkImage) GetPosition() int {
+	return i.position
+}
+
+func (i *ArtworkImage) IsPrimary() bool {
+	return i.isPrimary
+}
+
+func (i *ArtworkImage) GetFormat() ImageFormat {
+	return i.format
+}
+
+func (i *ArtworkImage) GetWidth() int {
+	return i.width
+}
+
+func (i *ArtworkImage) GetHeight() int {
+	return i.height
+}
+
+func (i *ArtworkImage) GetCreatedAt() time.Time {
+	return i.createdAt
+}
+
+func (i *ArtworkImage) SetPosition(position int) {
+	i.position = position
+}
+
+func (i *ArtworkImage) SetPrimary(isPrimary bool) {
+	i.isPrimary = isPrimary
+}
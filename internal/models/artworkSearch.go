@@ -0,0 +1,136 @@
+package models
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+const (
+	// HighlightStartSel и HighlightStopSel - служебные символы, которыми СУБД отмечает совпадения.
+	// Символы из области частного использования Unicode не встречаются в данных.
+	HighlightStartSel = "\uE000"
+	HighlightStopSel  = "\uE001"
+
+	highlightStartTag = "<mark>"
+	highlightStopTag  = "</mark>"
+
+	minSearchTermLen = 2
+	minStemLen       = 3
+)
+
+// ArtworkHighlight поля произведения с выделенными совпадениями поискового запроса (HTML)
+type ArtworkHighlight struct {
+	Title      string
+	AuthorName string
+	Collection string
+	Technic    string
+	Material   string
+}
+
+func (h *ArtworkHighlight) ToArtworkHighlightResponse() jsonreqresp.ArtworkHighlightResponse {
+	return jsonreqresp.ArtworkHighlightResponse{
+		Title:      h.Title,
+		AuthorName: h.AuthorName,
+		Collection: h.Collection,
+		Technic:    h.Technic,
+		Material:   h.Material,
+	}
+}
+
+// окончания для упрощенного стемминга, отсортированы по убыванию длины при инициализации
+var searchSuffixes = []string{
+	// русские
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "ией", "ов", "ев", "ей", "ой",
+	"ом", "ем", "ам", "ям", "ах", "ях", "ую", "юю", "ая", "яя", "ое", "ее", "ые", "ие", "ый", "ий",
+	"а", "я", "о", "е", "ы", "и", "у", "ю", "ь",
+	// английские
+	"ing", "ed", "es", "s",
+}
+
+func init() {
+	sort.SliceStable(searchSuffixes, func(i, j int) bool {
+		return utf8.RuneCountInString(searchSuffixes[i]) > utf8.RuneCountInString(searchSuffixes[j])
+	})
+}
+
+// stemWord отсекает типичное окончание слова, оставляя основу не короче minStemLen символов
+func stemWord(word string) string {
+	wordLen := utf8.RuneCountInString(word)
+	for _, suffix := range searchSuffixes {
+		if strings.HasSuffix(word, suffix) && wordLen-utf8.RuneCountInString(suffix) >= minStemLen {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// SearchTerms разбивает запрос на слова и возвращает их основы в нижнем регистре.
+// Используется там, где СУБД не умеет морфологию (ClickHouse), и для подсветки совпадений.
+func SearchTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool { return !isWordRune(r) }) {
+		if utf8.RuneCountInString(word) < minSearchTermLen {
+			continue
+		}
+		stem := stemWord(word)
+		if !seen[stem] {
+			seen[stem] = true
+			terms = append(terms, stem)
+		}
+	}
+	return terms
+}
+
+// HighlightTerms экранирует текст и выделяет слова, начинающиеся с одной из основ terms
+func HighlightTerms(text string, terms []string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i
+		if !isWordRune(runes[i]) {
+			for j < len(runes) && !isWordRune(runes[j]) {
+				j++
+			}
+			b.WriteString(html.EscapeString(string(runes[i:j])))
+			i = j
+			continue
+		}
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		lower := strings.ToLower(word)
+		matched := false
+		for _, term := range terms {
+			if strings.HasPrefix(lower, term) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			b.WriteString(highlightStartTag + html.EscapeString(word) + highlightStopTag)
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// HighlightMarked экранирует текст, в котором совпадения отмечены HighlightStartSel/HighlightStopSel,
+// и заменяет служебные символы тегами <mark>
+func HighlightMarked(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = strings.ReplaceAll(escaped, HighlightStartSel, highlightStartTag)
+	return strings.ReplaceAll(escaped, HighlightStopSel, highlightStopTag)
+}
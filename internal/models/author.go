@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
@@ -13,6 +14,9 @@ type Author struct {
 	name      string
 	birthYear int
 	deathYear int
+	profile   AuthorProfile
+	// portrait ключ портрета в хранилище изображений
+	portrait string
 }
 
 type AuthorUpdateReq struct {
@@ -96,3 +100,98 @@ func (a *Author) Update(updateReq AuthorUpdateReq) error {
 	*a = copyA
 	return nil
 }
+
+// Snapshot возвращает изменяемые через Update и SetProfile поля для истории изменений.
+// Направления записываются через перевод строки
+func (a *Author) Snapshot() Snapshot {
+	return Snapshot{
+		"name":        a.name,
+		"birthYear":   strconv.Itoa(a.birthYear),
+		"deathYear":   strconv.Itoa(a.deathYear),
+		"biography":   a.profile.Biography,
+		"nationality": a.profile.Nationality,
+		"movements":   strings.Join(a.profile.Movements, "\n"),
+		"birthPlace":  a.profile.BirthPlace,
+		"deathPlace":  a.profile.DeathPlace,
+		"ulanID":      a.profile.ULANID,
+		"wikidataID":  a.profile.WikidataID,
+	}
+}
+
+// поля снимка, которыми объединение дублей отмечается в истории авторов
+const (
+	authorMergedIntoField    = "mergedInto"
+	authorMergedFromField    = "mergedFrom"
+	authorMovedArtworksField = "movedArtworks"
+)
+
+// MergedIntoSnapshot возвращает снимок дубля после объединения с основным автором survivorID
+func (a *Author) MergedIntoSnapshot(survivorID uuid.UUID, moved int) Snapshot {
+	return a.mergedSnapshot(authorMergedIntoField, survivorID, moved)
+}
+
+// MergedFromSnapshot возвращает снимок основного автора после объединения с дублем duplicateID
+func (a *Author) MergedFromSnapshot(duplicateID uuid.UUID, moved int) Snapshot {
+	return a.mergedSnapshot(authorMergedFromField, duplicateID, moved)
+}
+
+func (a *Author) mergedSnapshot(field string, otherID uuid.UUID, moved int) Snapshot {
+	s := a.Snapshot()
+	s[field] = otherID.String()
+	s[authorMovedArtworksField] = strconv.Itoa(moved)
+	return s
+}
+
+// Restore возвращает поля автора к сохраненному состоянию
+func (a *Author) Restore(s Snapshot) error {
+	var req AuthorUpdateReq
+	var err error
+	if req.Name, err = s.String("name"); err != nil {
+		return err
+	}
+	if req.BirthYear, err = s.Int("birthYear"); err != nil {
+		return err
+	}
+	if req.DeathYear, err = s.Int("deathYear"); err != nil {
+		return err
+	}
+	// версии до появления профиля его не содержат, профиль тогда не меняется
+	if _, ok := s["biography"]; ok {
+		var profileReq jsonreqresp.AuthorProfileRequest
+		fields := []struct {
+			name string
+			dst  *string
+		}{
+			{"biography", &profileReq.Biography},
+			{"nationality", &profileReq.Nationality},
+			{"birthPlace", &profileReq.BirthPlace},
+			{"deathPlace", &profileReq.DeathPlace},
+			{"ulanID", &profileReq.ULANID},
+			{"wikidataID", &profileReq.WikidataID},
+		}
+		for _, f := range fields {
+			if *f.dst, err = s.String(f.name); err != nil {
+				return err
+			}
+		}
+		movements, err := s.String("movements")
+		if err != nil {
+			return err
+		}
+		if movements != "" {
+			profileReq.Movements = strings.Split(movements, "\n")
+		}
+		profile, err := NewAuthorProfile(profileReq)
+		if err != nil {
+			return err
+		}
+		copyA := *a
+		if err := copyA.Update(req); err != nil {
+			return err
+		}
+		copyA.profile = profile
+		*a = copyA
+		return nil
+	}
+	return a.Update(req)
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// AuthorDuplicateMinScore минимальная оценка сходства, начиная с которой пара авторов считается вероятным дублем
+const AuthorDuplicateMinScore = 0.8
+
+// допустимое расхождение годов жизни дублей: годы часто известны неточно
+const authorDuplicateYearTolerance = 2
+
+// оценка совпадения инициала с полным именем и штраф за неточно совпавшие годы жизни
+const (
+	authorInitialScore    = 0.85
+	authorNearYearsFactor = 0.9
+	authorTokenMinScore   = 0.8
+)
+
+// AuthorDuplicate пара авторов, которые, вероятно, являются одним человеком
+type AuthorDuplicate struct {
+	Author    *Author
+	Duplicate *Author
+	// Score сходство от 0 до 1
+	Score float64
+	// SameYears годы жизни совпадают точно
+	SameYears bool
+}
+
+func (d *AuthorDuplicate) ToAuthorDuplicateResponse() jsonreqresp.AuthorDuplicateResponse {
+	return jsonreqresp.AuthorDuplicateResponse{
+		Author:    d.Author.ToAuthorResponse(),
+		Duplicate: d.Duplicate.ToAuthorResponse(),
+		Score:     d.Score,
+		SameYears: d.SameYears,
+	}
+}
+
+var cyrillicTranslit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// latinSpellings сводит разные латинские записи одного звука к одной: Ilja, Ilia и Ilya, Vasilij и Vasiliy
+var latinSpellings = strings.NewReplacer(
+	"iy", "y", "ij", "y", "yj", "y", "ia", "ya", "ja", "ya", "ju", "yu", "jo", "e", "yo", "e", "x", "ks", "w", "v",
+)
+
+// NormalizeAuthorName приводит имя автора к сравнимым словам: нижний регистр, транслитерация кириллицы,
+// без знаков препинания; запись "Фамилия, Имя" разворачивается в "Имя Фамилия"
+func NormalizeAuthorName(name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if surname, rest, ok := strings.Cut(name, ","); ok {
+		name = rest + " " + surname
+	}
+	var b strings.Builder
+	for _, r := range name {
+		if translit, ok := cyrillicTranslit[r]; ok {
+			b.WriteString(translit)
+			continue
+		}
+		switch {
+		case unicode.IsLetter(r):
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+		default:
+			b.WriteByte(' ')
+		}
+	}
+	tokens := strings.Fields(b.String())
+	for i, t := range tokens {
+		if len(t) > 1 {
+			tokens[i] = latinSpellings.Replace(t)
+		}
+	}
+	return tokens
+}
+
+// levenshtein расстояние редактирования между словами
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// tokenSimilarity сходство двух слов имени; инициал совпадает с любым словом на ту же букву
+func tokenSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 1 || len(rb) == 1 {
+		if ra[0] == rb[0] {
+			if len(ra) == len(rb) {
+				return 1
+			}
+			return authorInitialScore
+		}
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(max(len(ra), len(rb)))
+}
+
+// AuthorNameSimilarity сходство имен от 0 до 1. Каждое слово более короткого имени сопоставляется
+// с наиболее похожим свободным словом другого имени независимо от порядка, отчества и второстепенные
+// слова более длинного имени не учитываются. Совпадение только по инициалам сходством не считается
+func AuthorNameSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	used := make([]bool, len(b))
+	total := 0.0
+	fullWordMatched := false
+	for _, ta := range a {
+		best, bestIdx := 0.0, -1
+		for j, tb := range b {
+			if used[j] {
+				continue
+			}
+			if s := tokenSimilarity(ta, tb); s > best {
+				best, bestIdx = s, j
+			}
+		}
+		if best < authorTokenMinScore {
+			return 0
+		}
+		used[bestIdx] = true
+		total += best
+		if len([]rune(ta)) > 1 && len([]rune(b[bestIdx])) > 1 {
+			fullWordMatched = true
+		}
+	}
+	if !fullWordMatched {
+		return 0
+	}
+	return total / float64(len(a))
+}
+
+// yearsCompatible годы различаются не больше допуска, неизвестный год смерти (0) совместим с любым
+func yearsCompatible(a, b int, unknownAllowed bool) bool {
+	if unknownAllowed && (a == 0 || b == 0) {
+		return true
+	}
+	return abs(a-b) <= authorDuplicateYearTolerance
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FindAuthorDuplicates возвращает пары вероятных дублей со сходством не ниже minScore
+// в порядке убывания сходства. Авторы с несовместимыми годами жизни дублями не считаются
+func FindAuthorDuplicates(authors []*Author, minScore float64) []AuthorDuplicate {
+	names := make([][]string, len(authors))
+	for i, a := range authors {
+		names[i] = NormalizeAuthorName(a.name)
+	}
+	var res []AuthorDuplicate
+	for i := 0; i < len(authors); i++ {
+		for j := i + 1; j < len(authors); j++ {
+			a, b := authors[i], authors[j]
+			if !yearsCompatible(a.birthYear, b.birthYear, false) || !yearsCompatible(a.deathYear, b.deathYear, true) {
+				continue
+			}
+			sameYears := a.birthYear == b.birthYear && a.deathYear == b.deathYear
+			score := AuthorNameSimilarity(names[i], names[j])
+			if !sameYears {
+				score *= authorNearYearsFactor
+			}
+			if score < minScore {
+				continue
+			}
+			res = append(res, AuthorDuplicate{Author: a, Duplicate: b, Score: score, SameYears: sameYears})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Score > res[j].Score })
+	return res
+}
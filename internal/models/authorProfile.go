@@ -0,0 +1,186 @@
+package models
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+const (
+	// AuthorMaxMovements максимальное число направлений в профиле автора
+	AuthorMaxMovements      = 20
+	authorBiographyMaxLen   = 20000
+	authorProfileMaxTextLen = 200
+	// AuthorPortraitMaxSize максимальный размер файла портрета
+	AuthorPortraitMaxSize = 5 << 20
+)
+
+// адреса внешних справочников по идентификатору автора
+const (
+	ulanURLPrefix     = "https://vocab.getty.edu/page/ulan/"
+	wikidataURLPrefix = "https://www.wikidata.org/wiki/"
+)
+
+var (
+	ulanIDPattern     = regexp.MustCompile(`^500\d{6}$`)
+	wikidataIDPattern = regexp.MustCompile(`^Q[1-9]\d*$`)
+)
+
+// AuthorProfile сведения об авторе для публичной страницы. Все поля необязательные
+type AuthorProfile struct {
+	Biography   string
+	Nationality string
+	// Movements художественные направления, порядок задает сотрудник
+	Movements  []string
+	BirthPlace string
+	DeathPlace string
+	// ULANID идентификатор в Union List of Artist Names (Getty), девять цифр, начиная с 500
+	ULANID string
+	// WikidataID идентификатор элемента Wikidata вида Q123
+	WikidataID string
+}
+
+var (
+	ErrValidateAuthorProfile      = errors.New("invalid author profile")
+	ErrAuthorBiographyTooLong     = errors.New("biography exceeds maximum length (20000 chars)")
+	ErrAuthorProfileFieldTooLong  = errors.New("nationality and places must be a single line up to 200 chars")
+	ErrAuthorTooManyMovements     = errors.New("too many movements (20 max)")
+	ErrAuthorInvalidMovement      = errors.New("movement must be a non-empty single line up to 100 chars")
+	ErrAuthorInvalidULANID        = errors.New("invalid ULAN ID (9 digits starting with 500)")
+	ErrAuthorInvalidWikidataID    = errors.New("invalid Wikidata ID (Q followed by digits)")
+	ErrAuthorPortraitInvalidImage = errors.New("invalid author portrait")
+)
+
+// NewAuthorProfile убирает пробелы по краям полей и повторы направлений и проверяет профиль
+func NewAuthorProfile(req jsonreqresp.AuthorProfileRequest) (AuthorProfile, error) {
+	p := AuthorProfile{
+		Biography:   strings.TrimSpace(req.Biography),
+		Nationality: strings.TrimSpace(req.Nationality),
+		Movements:   normalizeSynonyms(req.Movements),
+		BirthPlace:  strings.TrimSpace(req.BirthPlace),
+		DeathPlace:  strings.TrimSpace(req.DeathPlace),
+		ULANID:      strings.TrimSpace(req.ULANID),
+		WikidataID:  strings.ToUpper(strings.TrimSpace(req.WikidataID)),
+	}
+	if err := p.validate(); err != nil {
+		return AuthorProfile{}, err
+	}
+	return p, nil
+}
+
+func isProfileLine(s string, maxLen int) bool {
+	return len([]rune(s)) <= maxLen && !strings.ContainsAny(s, "\r\n")
+}
+
+func (p *AuthorProfile) validate() error {
+	switch {
+	case len([]rune(p.Biography)) > authorBiographyMaxLen:
+		return ErrAuthorBiographyTooLong
+	case !isProfileLine(p.Nationality, authorProfileMaxTextLen) ||
+		!isProfileLine(p.BirthPlace, authorProfileMaxTextLen) ||
+		!isProfileLine(p.DeathPlace, authorProfileMaxTextLen):
+		return ErrAuthorProfileFieldTooLong
+	case len(p.Movements) > AuthorMaxMovements:
+		return ErrAuthorTooManyMovements
+	case p.ULANID != "" && !ulanIDPattern.MatchString(p.ULANID):
+		return ErrAuthorInvalidULANID
+	case p.WikidataID != "" && !wikidataIDPattern.MatchString(p.WikidataID):
+		return ErrAuthorInvalidWikidataID
+	}
+	for _, m := range p.Movements {
+		if m == "" || !isProfileLine(m, 100) {
+			return ErrAuthorInvalidMovement
+		}
+	}
+	return nil
+}
+
+// ULANURL возвращает страницу автора в ULAN или пустую строку, если идентификатор не задан
+func (p *AuthorProfile) ULANURL() string {
+	if p.ULANID == "" {
+		return ""
+	}
+	return ulanURLPrefix + p.ULANID
+}
+
+// WikidataURL возвращает страницу автора в Wikidata или пустую строку, если идентификатор не задан
+func (p *AuthorProfile) WikidataURL() string {
+	if p.WikidataID == "" {
+		return ""
+	}
+	return wikidataURLPrefix + p.WikidataID
+}
+
+// sameAs ссылки на автора во внешних справочниках
+func (p *AuthorProfile) sameAs() []string {
+	var res []string
+	for _, url := range []string{p.ULANURL(), p.WikidataURL()} {
+		if url != "" {
+			res = append(res, url)
+		}
+	}
+	return res
+}
+
+// AuthorImagesDir возвращает каталог хранилища с изображениями автора
+func AuthorImagesDir(authorID uuid.UUID) string {
+	return path.Join("authors", authorID.String())
+}
+
+// AuthorPortraitKey возвращает новый ключ портрета в хранилище. Каждый загруженный портрет
+// получает свой ключ, чтобы браузеры не показывали закэшированный прежний портрет
+func AuthorPortraitKey(authorID uuid.UUID, format ImageFormat) string {
+	return path.Join(AuthorImagesDir(authorID), "portrait-"+uuid.NewString()+"."+format.Extension())
+}
+
+func (a *Author) GetProfile() AuthorProfile {
+	p := a.profile
+	p.Movements = append([]string(nil), a.profile.Movements...)
+	return p
+}
+
+// SetProfile заменяет профиль автора
+func (a *Author) SetProfile(p AuthorProfile) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	a.profile = p
+	return nil
+}
+
+// GetPortrait возвращает ключ портрета в хранилище изображений, пустой - портрета нет
+func (a *Author) GetPortrait() string {
+	return a.portrait
+}
+
+func (a *Author) SetPortrait(key string) {
+	a.portrait = key
+}
+
+// PortraitURL возвращает адрес портрета или пустую строку
+func (a *Author) PortraitURL() string {
+	if a.portrait == "" {
+		return ""
+	}
+	return ArtworkImagesURLPrefix + a.portrait
+}
+
+func (a *Author) ToAuthorProfileResponse() jsonreqresp.AuthorProfileResponse {
+	return jsonreqresp.AuthorProfileResponse{
+		AuthorResponse: a.ToAuthorResponse(),
+		Biography:      a.profile.Biography,
+		Nationality:    a.profile.Nationality,
+		Movements:      a.GetProfile().Movements,
+		BirthPlace:     a.profile.BirthPlace,
+		DeathPlace:     a.profile.DeathPlace,
+		PortraitURL:    a.PortraitURL(),
+		ULANID:         a.profile.ULANID,
+		ULANURL:        a.profile.ULANURL(),
+		WikidataID:     a.profile.WikidataID,
+		WikidataURL:    a.profile.WikidataURL(),
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// ExportFormat формат выгрузки каталога
+type ExportFormat string
+
+const (
+	ExportCSV   ExportFormat = "csv"
+	ExportJSONL ExportFormat = "jsonl"
+	// ExportDublinCore записи Dublin Core (элементы dc и dcterms)
+	ExportDublinCore ExportFormat = "dc"
+	// ExportLIDO записи LIDO 1.0 - стандарта обмена описаниями музейных предметов
+	ExportLIDO ExportFormat = "lido"
+)
+
+var ErrExportFormat = errors.New("unknown export format (csv, jsonl, dc, lido)")
+
+var exportContentTypes = map[ExportFormat]string{
+	ExportCSV:        "text/csv; charset=utf-8",
+	ExportJSONL:      "application/x-ndjson",
+	ExportDublinCore: "application/xml; charset=utf-8",
+	ExportLIDO:       "application/xml; charset=utf-8",
+}
+
+var exportExtensions = map[ExportFormat]string{
+	ExportCSV:        ".csv",
+	ExportJSONL:      ".jsonl",
+	ExportDublinCore: ".dc.xml",
+	ExportLIDO:       ".lido.xml",
+}
+
+func (f ExportFormat) IsValid() bool {
+	_, ok := exportContentTypes[f]
+	return ok
+}
+
+func (f ExportFormat) ContentType() string {
+	return exportContentTypes[f]
+}
+
+// FileExtension возвращает расширение файла выгрузки вместе с точкой
+func (f ExportFormat) FileExtension() string {
+	return exportExtensions[f]
+}
+
+// ArtworkExport произведение с мероприятиями, в которых оно участвует, для выгрузки каталога
+type ArtworkExport struct {
+	Artwork *Artwork
+	Events  []*Event
+}
+
+func (e *ArtworkExport) ToExportArtworkResponse() jsonreqresp.ExportArtworkResponse {
+	resp := jsonreqresp.ExportArtworkResponse{
+		ArtworkResponse: e.Artwork.ToArtworkResponse(),
+		Events:          make([]jsonreqresp.ExportEventResponse, len(e.Events)),
+	}
+	for i, event := range e.Events {
+		resp.Events[i] = jsonreqresp.ExportEventResponse{
+			ID:        event.GetID().String(),
+			Title:     event.GetTitle(),
+			DateBegin: event.GetDateBegin(),
+			DateEnd:   event.GetDateEnd(),
+			Address:   event.GetAddress(),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"errors"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// ImportEntity раздел файла импорта каталога
+type ImportEntity string
+
+const (
+	ImportAuthors     ImportEntity = "authors"
+	ImportCollections ImportEntity = "collections"
+	ImportArtworks    ImportEntity = "artworks"
+)
+
+func (e ImportEntity) IsValid() bool {
+	switch e {
+	case ImportAuthors, ImportCollections, ImportArtworks:
+		return true
+	}
+	return false
+}
+
+// ImportFormat формат файла импорта: JSON со всеми разделами или CSV одного раздела
+type ImportFormat string
+
+const (
+	ImportJSON ImportFormat = "json"
+	ImportCSV  ImportFormat = "csv"
+)
+
+// ImportMaxSize максимальный размер файла импорта
+const ImportMaxSize = 20 << 20
+
+var (
+	ErrImportFormat             = errors.New("malformed import file")
+	ErrImportTooLarge           = errors.New("import file exceeds maximum size (20 MB)")
+	ErrImportEmpty              = errors.New("import file has no rows")
+	ErrImportInvalidID          = errors.New("invalid id")
+	ErrImportDuplicateRow       = errors.New("duplicates another row of the import")
+	ErrImportIDExists           = errors.New("id is already used by another record")
+	ErrImportAuthorNotFound     = errors.New("author not found by id or name")
+	ErrImportAmbiguous          = errors.New("name matches several records, use id")
+	ErrImportCollectionNotFound = errors.New("collection not found by id or title")
+)
+
+// CatalogImport проверенные записи импорта, которые нужно создать.
+// Авторы и коллекции сохраняются раньше произведений, ссылающихся на них
+type CatalogImport struct {
+	Authors     []*Author
+	Collections []*Collection
+	Artworks    []*Artwork
+}
+
+// ImportRowError ошибка строки импорта, Row - номер строки раздела начиная с 1
+type ImportRowError struct {
+	Entity ImportEntity
+	Row    int
+	Err    error
+}
+
+// ImportCounts итог раздела импорта: Created - будут или были созданы,
+// Matched - совпали с существующими записями, Failed - строки с ошибками
+type ImportCounts struct {
+	Total   int
+	Created int
+	Matched int
+	Failed  int
+}
+
+// ImportReport итог импорта каталога. Если есть ошибки, ничего не сохраняется
+type ImportReport struct {
+	DryRun      bool
+	Committed   bool
+	Authors     ImportCounts
+	Collections ImportCounts
+	Artworks    ImportCounts
+	Errors      []ImportRowError
+}
+
+// AddError добавляет ошибку строки и учитывает ее в итоге раздела
+func (r *ImportReport) AddError(entity ImportEntity, row int, err error) {
+	r.Errors = append(r.Errors, ImportRowError{Entity: entity, Row: row, Err: err})
+	r.counts(entity).Failed++
+}
+
+func (r *ImportReport) counts(entity ImportEntity) *ImportCounts {
+	switch entity {
+	case ImportAuthors:
+		return &r.Authors
+	case ImportCollections:
+		return &r.Collections
+	default:
+		return &r.Artworks
+	}
+}
+
+func (r *ImportReport) ToImportReportResponse() jsonreqresp.ImportReportResponse {
+	resp := jsonreqresp.ImportReportResponse{
+		DryRun:      r.DryRun,
+		Committed:   r.Committed,
+		Authors:     r.Authors.toResponse(),
+		Collections: r.Collections.toResponse(),
+		Artworks:    r.Artworks.toResponse(),
+		Errors:      make([]jsonreqresp.ImportRowErrorResponse, len(r.Errors)),
+	}
+	for i, e := range r.Errors {
+		resp.Errors[i] = jsonreqresp.ImportRowErrorResponse{
+			Entity: string(e.Entity),
+			Row:    e.Row,
+			Error:  e.Err.Error(),
+		}
+	}
+	return resp
+}
+
+func (c ImportCounts) toResponse() jsonreqresp.ImportCountsResponse {
+	return jsonreqresp.ImportCountsResponse{
+		Total:   c.Total,
+		Created: c.Created,
+		Matched: c.Matched,
+		Failed:  c.Failed,
+	}
+}
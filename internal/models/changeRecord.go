@@ -0,0 +1,307 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// EntityType тип сущности каталога, изменения которой сохраняются в истории
+type EntityType string
+
+const (
+	EntityArtwork    EntityType = "artwork"
+	EntityAuthor     EntityType = "author"
+	EntityCollection EntityType = "collection"
+	EntityEvent      EntityType = "event"
+)
+
+func (t EntityType) IsValid() bool {
+	switch t {
+	case EntityArtwork, EntityAuthor, EntityCollection, EntityEvent:
+		return true
+	}
+	return false
+}
+
+// ChangeAction вид изменения
+type ChangeAction string
+
+const (
+	ChangeUpdate ChangeAction = "update"
+	ChangeRevert ChangeAction = "revert"
+	// ChangeMerge объединение дублей: запись есть и у сохраненной сущности, и у удаленного дубля
+	ChangeMerge ChangeAction = "merge"
+)
+
+func (a ChangeAction) IsValid() bool {
+	return a == ChangeUpdate || a == ChangeRevert || a == ChangeMerge
+}
+
+// Snapshot значения изменяемых полей сущности, приведенные к строкам
+type Snapshot map[string]string
+
+var ErrSnapshotField = errors.New("invalid snapshot field")
+
+func (s Snapshot) String(field string) (string, error) {
+	v, ok := s[field]
+	if !ok {
+		return "", fmt.Errorf("%w: %s is missing", ErrSnapshotField, field)
+	}
+	return v, nil
+}
+
+func (s Snapshot) Int(field string) (int, error) {
+	v, err := s.String(field)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrSnapshotField, field, err)
+	}
+	return n, nil
+}
+
+func (s Snapshot) Float(field string) (float64, error) {
+	v, err := s.String(field)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrSnapshotField, field, err)
+	}
+	return f, nil
+}
+
+func (s Snapshot) Bool(field string) (bool, error) {
+	v, err := s.String(field)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s: %v", ErrSnapshotField, field, err)
+	}
+	return b, nil
+}
+
+func (s Snapshot) Time(field string) (time.Time, error) {
+	v, err := s.String(field)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s: %v", ErrSnapshotField, field, err)
+	}
+	return t, nil
+}
+
+func snapshotTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// FieldChange изменение одного поля
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// DiffSnapshots возвращает изменившиеся поля, упорядоченные по имени
+func DiffSnapshots(before Snapshot, after Snapshot) []FieldChange {
+	fields := make(map[string]struct{}, len(after))
+	for f := range before {
+		fields[f] = struct{}{}
+	}
+	for f := range after {
+		fields[f] = struct{}{}
+	}
+	var changes []FieldChange
+	for f := range fields {
+		if before[f] != after[f] {
+			changes = append(changes, FieldChange{Field: f, Old: before[f], New: after[f]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// ChangeRecord версия сущности каталога.
+// Версия N - состояние после N-го изменения, версия 0 - состояние до первого сохраненного изменения.
+// changedBy равен uuid.Nil, если изменение сделано без авторизации.
+type ChangeRecord struct {
+	id         uuid.UUID
+	entityType EntityType
+	entityID   uuid.UUID
+	version    int
+	action     ChangeAction
+	revertedTo int
+	changedBy  uuid.UUID
+	changedAt  time.Time
+	before     Snapshot
+	after      Snapshot
+}
+
+var (
+	ErrValidateChangeRecord    = errors.New("invalid change record")
+	ErrChangeRecordEntityType  = errors.New("invalid entity type (artwork, author, collection, event)")
+	ErrChangeRecordEntity      = errors.New("invalid entity reference")
+	ErrChangeRecordVersion     = errors.New("invalid version")
+	ErrChangeRecordAction      = errors.New("invalid change action (update, revert, merge)")
+	ErrChangeRecordNoChanges   = errors.New("change record has no changed fields")
+	ErrChangeRecordEmptyFields = errors.New("empty snapshot")
+)
+
+func NewChangeRecord(
+	id uuid.UUID,
+	entityType EntityType,
+	entityID uuid.UUID,
+	version int,
+	action ChangeAction,
+	revertedTo int,
+	changedBy uuid.UUID,
+	changedAt time.Time,
+	before Snapshot,
+	after Snapshot,
+) (ChangeRecord, error) {
+	record := ChangeRecord{
+		id:         id,
+		entityType: entityType,
+		entityID:   entityID,
+		version:    version,
+		action:     action,
+		revertedTo: revertedTo,
+		changedBy:  changedBy,
+		changedAt:  changedAt,
+		before:     before,
+		after:      after,
+	}
+
+	if err := record.validate(); err != nil {
+		return ChangeRecord{}, err
+	}
+
+	return record, nil
+}
+
+func (r *ChangeRecord) validate() error {
+	switch {
+	case !r.entityType.IsValid():
+		return ErrChangeRecordEntityType
+	case r.entityID == uuid.Nil:
+		return ErrChangeRecordEntity
+	case r.version <= 0:
+		return ErrChangeRecordVersion
+	case !r.action.IsValid():
+		return ErrChangeRecordAction
+	case r.action == ChangeRevert && (r.revertedTo < 0 || r.revertedTo >= r.version):
+		return ErrChangeRecordVersion
+	case len(r.before) == 0 || len(r.after) == 0:
+		return ErrChangeRecordEmptyFields
+	case len(DiffSnapshots(r.before, r.after)) == 0:
+		return ErrChangeRecordNoChanges
+	}
+	return nil
+}
+
+func (r *ChangeRecord) Changes() []FieldChange {
+	return DiffSnapshots(r.before, r.after)
+}
+
+func (r *ChangeRecord) ToChangeRecordResponse() jsonreqresp.ChangeRecordResponse {
+	changes := r.Changes()
+	changesResp := make([]jsonreqresp.FieldChangeResponse, len(changes))
+	for i, c := range changes {
+		changesResp[i] = jsonreqresp.FieldChangeResponse{Field: c.Field, Old: c.Old, New: c.New}
+	}
+	resp := jsonreqresp.ChangeRecordResponse{
+		ID:         r.id.String(),
+		EntityType: string(r.entityType),
+		EntityID:   r.entityID.String(),
+		Version:    r.version,
+		Action:     string(r.action),
+		ChangedAt:  r.changedAt,
+		Changes:    changesResp,
+	}
+	if r.changedBy != uuid.Nil {
+		resp.ChangedBy = r.changedBy.String()
+	}
+	if r.action == ChangeRevert {
+		revertedTo := r.revertedTo
+		resp.RevertedTo = &revertedTo
+	}
+	return resp
+}
+
+func (r *ChangeRecord) GetID() uuid.UUID {
+	return r.id
+}
+
+func (r *ChangeRecord) GetEntityType() EntityType {
+	return r.entityType
+}
+
+func (r *ChangeRecord) GetEntityID() uuid.UUID {
+	return r.entityID
+}
+
+func (r *ChangeRecord) GetVersion() int {
+	return r.version
+}
+
+func (r *ChangeRecord) GetAction() ChangeAction {
+	return r.action
+}
+
+// GetRevertedTo возвращает версию, к которой вернулись, имеет смысл только для ChangeRevert
+func (r *ChangeRecord) GetRevertedTo() int {
+	return r.revertedTo
+}
+
+func (r *ChangeRecord) GetChangedBy() uuid.UUID {
+	return r.changedBy
+}
+
+func (r *ChangeRecord) GetChangedAt() time.Time {
+	return r.changedAt
+}
+
+func (r *ChangeRecord) GetBefore() Snapshot {
+	return r.before
+}
+
+func (r *ChangeRecord) GetAfter() Snapshot {
+	return r.after
+}
+
+// Change описывает изменение записи каталога до его сохранения.
+// Хранилище записывает его в историю вместе с самим изменением и назначает следующую версию.
+type Change struct {
+	Action     ChangeAction
+	RevertedTo int
+	ChangedBy  uuid.UUID
+	ChangedAt  time.Time
+}
+
+// NewRecord создает версию version записи entityID. Если поля не изменились, возвращает nil.
+func (c *Change) NewRecord(
+	entityType EntityType, entityID uuid.UUID, version int, before Snapshot, after Snapshot,
+) (*ChangeRecord, error) {
+	if len(DiffSnapshots(before, after)) == 0 {
+		return nil, nil
+	}
+	record, err := NewChangeRecord(
+		uuid.New(), entityType, entityID, version, c.Action, c.RevertedTo, c.ChangedBy, c.ChangedAt, before, after)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidateChangeRecord, err)
+	}
+	return &record, nil
+}
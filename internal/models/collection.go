@@ -2,24 +2,36 @@ package models
 
 import (
 	"errors"
+	"sort"
 	"strings"
 
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"github.com/google/uuid"
 )
 
+// CollectionMaxDepth максимальная глубина вложенности коллекций, корневая коллекция - уровень 1
+const CollectionMaxDepth = 5
+
+// Collection коллекция музея; коллекции образуют иерархию, parentID = uuid.Nil - корневая коллекция
 type Collection struct {
-	id    uuid.UUID
-	title string
+	id       uuid.UUID
+	title    string
+	parentID uuid.UUID
 }
 
 type CollectionUpdateReq struct {
-	Title string `json:"title" example:"Louvre Museum Collection"`
+	Title    string    `json:"title" example:"Louvre Museum Collection"`
+	ParentID uuid.UUID `json:"parentId"`
 }
 
 var (
-	ErrCollectionEmptyTitle   = errors.New("empty title")
-	ErrCollectionTitleTooLong = errors.New("title exceeds maximum length (255 chars)")
+	ErrValidateCollection       = errors.New("invalid collection")
+	ErrCollectionEmptyTitle     = errors.New("empty title")
+	ErrCollectionTitleTooLong   = errors.New("title exceeds maximum length (255 chars)")
+	ErrCollectionSelfParent     = errors.New("collection cannot be its own parent")
+	ErrCollectionParentNotFound = errors.New("parent collection not found")
+	ErrCollectionCycle          = errors.New("collection cannot be moved under its own descendant")
+	ErrCollectionTooDeep        = errors.New("collection hierarchy is too deep (5 levels max)")
 )
 
 func NewCollection(id uuid.UUID, title string) (Collection, error) {
@@ -41,15 +53,21 @@ func (c *Collection) validate() error {
 		return ErrCollectionEmptyTitle
 	case len(c.title) > 255:
 		return ErrCollectionTitleTooLong
+	case c.parentID == c.id:
+		return ErrCollectionSelfParent
 	}
 	return nil
 }
 
 func (c *Collection) ToCollectionResponse() jsonreqresp.CollectionResponse {
-	return jsonreqresp.CollectionResponse{
+	resp := jsonreqresp.CollectionResponse{
 		ID:    c.id.String(),
 		Title: c.title,
 	}
+	if c.parentID != uuid.Nil {
+		resp.ParentID = c.parentID.String()
+	}
+	return resp
 }
 
 // func FromCollectionRequest(req jsonreqresp.CollectionAddRequest) (Collection, error) {
@@ -74,12 +92,161 @@ func (c *Collection) GetTitle() string {
 	return c.title
 }
 
+// GetParentID возвращает родительскую коллекцию, uuid.Nil - коллекция корневая
+func (c *Collection) GetParentID() uuid.UUID {
+	return c.parentID
+}
+
+// SetParent переносит коллекцию в другую, uuid.Nil - делает коллекцию корневой
+func (c *Collection) SetParent(parentID uuid.UUID) error {
+	if parentID == c.id {
+		return ErrCollectionSelfParent
+	}
+	c.parentID = parentID
+	return nil
+}
+
 func (c *Collection) Update(updateReq CollectionUpdateReq) error {
 	copyC := *c
-	copyC.title = updateReq.Title
+	copyC.title = strings.TrimSpace(updateReq.Title)
+	copyC.parentID = updateReq.ParentID
 	if err := copyC.validate(); err != nil {
 		return err
 	}
 	*c = copyC
 	return nil
 }
+
+// Snapshot возвращает изменяемые через Update поля для истории изменений
+func (c *Collection) Snapshot() Snapshot {
+	parentID := ""
+	if c.parentID != uuid.Nil {
+		parentID = c.parentID.String()
+	}
+	return Snapshot{"title": c.title, "parentID": parentID}
+}
+
+// Restore возвращает поля коллекции к сохраненному состоянию.
+// В снимках, сделанных до появления вложенных коллекций, родителя нет - он не меняется
+func (c *Collection) Restore(s Snapshot) error {
+	title, err := s.String("title")
+	if err != nil {
+		return err
+	}
+	req := CollectionUpdateReq{Title: title, ParentID: c.parentID}
+	if _, ok := s["parentID"]; ok {
+		parentID, err := s.String("parentID")
+		if err != nil {
+			return err
+		}
+		req.ParentID = uuid.Nil
+		if parentID != "" {
+			if req.ParentID, err = uuid.Parse(parentID); err != nil {
+				return ErrCollectionParentNotFound
+			}
+		}
+	}
+	return c.Update(req)
+}
+
+// CollectionHierarchy коллекции музея для проверки и обхода иерархии
+type CollectionHierarchy struct {
+	byID     map[uuid.UUID]*Collection
+	children map[uuid.UUID][]*Collection
+}
+
+func NewCollectionHierarchy(collections []*Collection) *CollectionHierarchy {
+	h := &CollectionHierarchy{
+		byID:     make(map[uuid.UUID]*Collection, len(collections)),
+		children: make(map[uuid.UUID][]*Collection),
+	}
+	for _, c := range collections {
+		h.byID[c.id] = c
+		h.children[c.parentID] = append(h.children[c.parentID], c)
+	}
+	return h
+}
+
+func (h *CollectionHierarchy) Get(id uuid.UUID) (*Collection, bool) {
+	c, ok := h.byID[id]
+	return c, ok
+}
+
+// Children возвращает дочерние коллекции, для uuid.Nil - корневые; коллекции упорядочены по названию
+func (h *CollectionHierarchy) Children(id uuid.UUID) []*Collection {
+	children := append([]*Collection(nil), h.children[id]...)
+	sort.Slice(children, func(i, j int) bool { return children[i].title < children[j].title })
+	return children
+}
+
+// Ancestors возвращает цепочку родительских коллекций от корня к непосредственному родителю
+func (h *CollectionHierarchy) Ancestors(id uuid.UUID) []*Collection {
+	var ancestors []*Collection
+	c, ok := h.byID[id]
+	for ok && c.parentID != uuid.Nil && len(ancestors) < CollectionMaxDepth {
+		if c, ok = h.byID[c.parentID]; ok {
+			ancestors = append([]*Collection{c}, ancestors...)
+		}
+	}
+	return ancestors
+}
+
+// Descendants возвращает переданные коллекции вместе со всеми вложенными в них
+func (h *CollectionHierarchy) Descendants(ids uuid.UUIDs) uuid.UUIDs {
+	var res uuid.UUIDs
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	queue := append(uuid.UUIDs(nil), ids...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+		for _, child := range h.children[id] {
+			queue = append(queue, child.id)
+		}
+	}
+	return res
+}
+
+// subtreeDepth возвращает число уровней поддерева с корнем в коллекции id.
+// Пройденные коллекции не обходятся повторно, поэтому цикл в данных не зацикливает обход
+func (h *CollectionHierarchy) subtreeDepth(id uuid.UUID) int {
+	return h.subtreeDepthVisited(id, make(map[uuid.UUID]struct{}))
+}
+
+func (h *CollectionHierarchy) subtreeDepthVisited(id uuid.UUID, visited map[uuid.UUID]struct{}) int {
+	if _, ok := visited[id]; ok {
+		return 0
+	}
+	visited[id] = struct{}{}
+	depth := 0
+	for _, child := range h.children[id] {
+		depth = max(depth, h.subtreeDepthVisited(child.id, visited))
+	}
+	return depth + 1
+}
+
+// CheckCollection проверяет, что новую или перенесенную коллекцию можно поместить в иерархию:
+// родитель существует и не вложен в саму коллекцию, глубина вложенности не превышает CollectionMaxDepth
+func (h *CollectionHierarchy) CheckCollection(c *Collection) error {
+	if c.parentID == uuid.Nil {
+		return nil
+	}
+	parent, ok := h.byID[c.parentID]
+	if !ok {
+		return ErrCollectionParentNotFound
+	}
+	ancestors := h.Ancestors(parent.id)
+	for _, a := range append(ancestors, parent) {
+		if a.id == c.id {
+			return ErrCollectionCycle
+		}
+	}
+	if len(ancestors)+1+h.subtreeDepth(c.id) > CollectionMaxDepth {
+		return ErrCollectionTooDeep
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// CollectionStats статистика произведений коллекции: по авторам, векам, техникам и участию в мероприятиях.
+// Value корзин: id автора, номер века, техника, id мероприятия
+type CollectionStats struct {
+	Collection             *Collection
+	IncludesSubcollections bool
+	SubcollectionCount     int
+	ArtworkCount           int
+	ExhibitedArtworkCount  int
+	Authors                []FacetBucket
+	Centuries              []FacetBucket
+	Technics               []FacetBucket
+	Exhibitions            []FacetBucket
+}
+
+func (s *CollectionStats) ToCollectionStatsResponse() jsonreqresp.CollectionStatsResponse {
+	resp := jsonreqresp.CollectionStatsResponse{
+		IncludesSubcollections: s.IncludesSubcollections,
+		SubcollectionCount:     s.SubcollectionCount,
+		ArtworkCount:           s.ArtworkCount,
+		ExhibitedArtworkCount:  s.ExhibitedArtworkCount,
+		Authors:                statBucketsResponse(s.Authors),
+		Centuries:              statBucketsResponse(s.Centuries),
+		Technics:               statBucketsResponse(s.Technics),
+		Exhibitions:            statBucketsResponse(s.Exhibitions),
+	}
+	if s.Collection != nil {
+		resp.Collection = s.Collection.ToCollectionResponse()
+	}
+	return resp
+}
+
+func statBucketsResponse(buckets []FacetBucket) []jsonreqresp.StatBucketResponse {
+	res := make([]jsonreqresp.StatBucketResponse, len(buckets))
+	for i, b := range buckets {
+		res[i] = jsonreqresp.StatBucketResponse{Value: b.Value, Label: b.Label, Count: b.Count}
+	}
+	return res
+}
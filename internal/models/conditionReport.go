@@ -0,0 +1,323 @@
+package models
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// ConditionGrade оценка сохранности произведения
+type ConditionGrade string
+
+const (
+	ConditionExcellent ConditionGrade = "excellent"
+	ConditionGood      ConditionGrade = "good"
+	ConditionFair      ConditionGrade = "fair"
+	ConditionPoor      ConditionGrade = "poor"
+	ConditionCritical  ConditionGrade = "critical"
+)
+
+var conditionGradeLabels = map[ConditionGrade]string{
+	ConditionExcellent: "отличное",
+	ConditionGood:      "хорошее",
+	ConditionFair:      "удовлетворительное",
+	ConditionPoor:      "плохое",
+	ConditionCritical:  "критическое",
+}
+
+func (g ConditionGrade) IsValid() bool {
+	_, ok := conditionGradeLabels[g]
+	return ok
+}
+
+func (g ConditionGrade) Label() string {
+	return conditionGradeLabels[g]
+}
+
+// NeedsAttention сообщает, что при такой оценке произведению требуется реставратор
+func (g ConditionGrade) NeedsAttention() bool {
+	return g == ConditionPoor || g == ConditionCritical
+}
+
+const (
+	// ConditionReportMaxPhotos максимальное количество фотографий в одном отчете о состоянии
+	ConditionReportMaxPhotos  = 10
+	conditionReportMaxTextLen = 4000
+)
+
+// ConditionPhoto фотография, приложенная к отчету о состоянии
+type ConditionPhoto struct {
+	id        uuid.UUID
+	reportID  uuid.UUID
+	artworkID uuid.UUID
+	format    ImageFormat
+	createdAt time.Time
+}
+
+var (
+	ErrValidateConditionPhoto     = errors.New("invalid condition report photo")
+	ErrConditionPhotoInvalidOwner = errors.New("invalid condition report reference")
+)
+
+func NewConditionPhoto(
+	id uuid.UUID,
+	reportID uuid.UUID,
+	artworkID uuid.UUID,
+	format ImageFormat,
+	createdAt time.Time,
+) (ConditionPhoto, error) {
+	photo := ConditionPhoto{
+		id:        id,
+		reportID:  reportID,
+		artworkID: artworkID,
+		format:    format,
+		createdAt: createdAt,
+	}
+	switch {
+	case reportID == uuid.Nil || artworkID == uuid.Nil:
+		return ConditionPhoto{}, ErrConditionPhotoInvalidOwner
+	case !format.IsValid():
+		return ConditionPhoto{}, ErrArtworkImageInvalidFormat
+	}
+	return photo, nil
+}
+
+// ConditionReportsDir возвращает каталог хранилища с фотографиями всех отчетов произведения
+func ConditionReportsDir(artworkID uuid.UUID) string {
+	return path.Join("conditions", artworkID.String())
+}
+
+// Key возвращает ключ файла фотографии в хранилище изображений
+func (p *ConditionPhoto) Key() string {
+	return path.Join(ConditionReportsDir(p.artworkID), p.reportID.String(), p.id.String()+"."+p.format.Extension())
+}
+
+func (p *ConditionPhoto) ToConditionPhotoResponse() jsonreqresp.ConditionPhotoResponse {
+	return jsonreqresp.ConditionPhotoResponse{
+		ID:     p.id.String(),
+		Format: string(p.format),
+		URL:    ArtworkImagesURLPrefix + p.Key(),
+	}
+}
+
+func (p *ConditionPhoto) GetID() uuid.UUID {
+	return p.id
+}
+
+func (p *ConditionPhoto) GetReportID() uuid.UUID {
+	return p.reportID
+}
+
+func (p *ConditionPhoto) GetArtworkID() uuid.UUID {
+	return p.artworkID
+}
+
+func (p *ConditionPhoto) GetFormat() ImageFormat {
+	return p.format
+}
+
+func (p *ConditionPhoto) GetCreatedAt() time.Time {
+	return p.createdAt
+}
+
+// ConditionReport отчет об осмотре произведения или о проведенной реставрации.
+// Если treatment не пуст, отчет описывает реставрацию, начатую в checkedAt;
+// нулевой treatmentEnd означает, что работы еще идут.
+type ConditionReport struct {
+	id           uuid.UUID
+	artworkID    uuid.UUID
+	checkedAt    time.Time
+	examiner     string
+	grade        ConditionGrade
+	notes        string
+	treatment    string
+	treatmentEnd time.Time
+	photos       []*ConditionPhoto
+}
+
+var (
+	ErrValidateConditionReport       = errors.New("invalid condition report")
+	ErrConditionReportInvalidArtwork = errors.New("invalid artwork reference")
+	ErrConditionReportEmptyDate      = errors.New("empty check date")
+	ErrConditionReportEmptyExaminer  = errors.New("empty examiner")
+	ErrConditionReportExaminerLong   = errors.New("examiner exceeds maximum length (255 chars)")
+	ErrConditionReportInvalidGrade   = errors.New("invalid condition grade (excellent, good, fair, poor, critical)")
+	ErrConditionReportTextTooLong    = errors.New("notes or treatment exceed maximum length (4000 chars)")
+	ErrConditionReportInvalidEnd     = errors.New("treatment end is before its start")
+	ErrConditionReportNoTreatment    = errors.New("report describes no treatment")
+	ErrConditionReportTreatmentEnded = errors.New("treatment is already finished")
+	ErrConditionReportTooManyPhotos  = errors.New("report already has maximum number of photos")
+)
+
+func NewConditionReport(
+	id uuid.UUID,
+	artworkID uuid.UUID,
+	checkedAt time.Time,
+	examiner string,
+	grade ConditionGrade,
+	notes string,
+	treatment string,
+	treatmentEnd time.Time,
+	photos []*ConditionPhoto,
+) (ConditionReport, error) {
+	report := ConditionReport{
+		id:           id,
+		artworkID:    artworkID,
+		checkedAt:    checkedAt,
+		examiner:     strings.TrimSpace(examiner),
+		grade:        grade,
+		notes:        strings.TrimSpace(notes),
+		treatment:    strings.TrimSpace(treatment),
+		treatmentEnd: treatmentEnd,
+		photos:       photos,
+	}
+
+	if err := report.validate(); err != nil {
+		return ConditionReport{}, err
+	}
+
+	return report, nil
+}
+
+func (r *ConditionReport) validate() error {
+	switch {
+	case r.artworkID == uuid.Nil:
+		return ErrConditionReportInvalidArtwork
+	case r.checkedAt.IsZero():
+		return ErrConditionReportEmptyDate
+	case r.examiner == "":
+		return ErrConditionReportEmptyExaminer
+	case utf8.RuneCountInString(r.examiner) > 255:
+		return ErrConditionReportExaminerLong
+	case !r.grade.IsValid():
+		return ErrConditionReportInvalidGrade
+	case utf8.RuneCountInString(r.notes) > conditionReportMaxTextLen ||
+		utf8.RuneCountInString(r.treatment) > conditionReportMaxTextLen:
+		return ErrConditionReportTextTooLong
+	case r.treatment == "" && !r.treatmentEnd.IsZero():
+		return ErrConditionReportNoTreatment
+	case !r.treatmentEnd.IsZero() && r.treatmentEnd.Before(r.checkedAt):
+		return ErrConditionReportInvalidEnd
+	case len(r.photos) > ConditionReportMaxPhotos:
+		return ErrConditionReportTooManyPhotos
+	}
+	return nil
+}
+
+// IsTreatment сообщает, что отчет описывает реставрацию, а не только осмотр
+func (r *ConditionReport) IsTreatment() bool {
+	return r.treatment != ""
+}
+
+// IsTreatmentOpen сообщает, что реставрация еще не завершена
+func (r *ConditionReport) IsTreatmentOpen() bool {
+	return r.IsTreatment() && r.treatmentEnd.IsZero()
+}
+
+// NeedsAttention сообщает, что произведению требуется внимание реставратора
+func (r *ConditionReport) NeedsAttention() bool {
+	return r.IsTreatmentOpen() || r.grade.NeedsAttention()
+}
+
+// BlocksPeriod сообщает, что реставрация пересекается с периодом [dateBeg, dateEnd]
+// и произведение в это время не может участвовать в мероприятиях
+func (r *ConditionReport) BlocksPeriod(dateBeg time.Time, dateEnd time.Time) bool {
+	if !r.IsTreatment() || r.checkedAt.After(dateEnd) {
+		return false
+	}
+	return r.treatmentEnd.IsZero() || !r.treatmentEnd.Before(dateBeg)
+}
+
+// CloseTreatment отмечает завершение реставрации
+func (r *ConditionReport) CloseTreatment(end time.Time) error {
+	switch {
+	case !r.IsTreatment():
+		return ErrConditionReportNoTreatment
+	case !r.IsTreatmentOpen():
+		return ErrConditionReportTreatmentEnded
+	case end.Before(r.checkedAt):
+		return ErrConditionReportInvalidEnd
+	}
+	r.treatmentEnd = end
+	return nil
+}
+
+// AddPhoto прикладывает фотографию к отчету
+func (r *ConditionReport) AddPhoto(photo *ConditionPhoto) error {
+	if len(r.photos) >= ConditionReportMaxPhotos {
+		return ErrConditionReportTooManyPhotos
+	}
+	r.photos = append(r.photos, photo)
+	return nil
+}
+
+func (r *ConditionReport) ToConditionReportResponse() jsonreqresp.ConditionReportResponse {
+	photos := make([]jsonreqresp.ConditionPhotoResponse, len(r.photos))
+	for i, p := range r.photos {
+		photos[i] = p.ToConditionPhotoResponse()
+	}
+	resp := jsonreqresp.ConditionReportResponse{
+		ID:             r.id.String(),
+		ArtworkID:      r.artworkID.String(),
+		CheckedAt:      r.checkedAt,
+		Examiner:       r.examiner,
+		Grade:          string(r.grade),
+		GradeLabel:     r.grade.Label(),
+		Notes:          r.notes,
+		Treatment:      r.treatment,
+		TreatmentOpen:  r.IsTreatmentOpen(),
+		NeedsAttention: r.NeedsAttention(),
+		Photos:         photos,
+	}
+	if !r.treatmentEnd.IsZero() {
+		end := r.treatmentEnd
+		resp.TreatmentEnd = &end
+	}
+	return resp
+}
+
+func (r *ConditionReport) GetID() uuid.UUID {
+	return r.id
+}
+
+func (r *ConditionReport) GetArtworkID() uuid.UUID {
+	return r.artworkID
+}
+
+func (r *ConditionReport) GetCheckedAt() time.Time {
+	return r.checkedAt
+}
+
+func (r *ConditionReport) GetExaminer() string {
+	return r.examiner
+}
+
+func (r *ConditionReport) GetGrade() ConditionGrade {
+	return r.grade
+}
+
+func (r *ConditionReport) GetNotes() string {
+	return r.notes
+}
+
+func (r *ConditionReport) GetTreatment() string {
+	return r.treatment
+}
+
+// GetTreatmentEnd возвращает дату завершения реставрации, нулевое время - работы не завершены
+func (r *ConditionReport) GetTreatmentEnd() time.Time {
+	return r.treatmentEnd
+}
+
+func (r *ConditionReport) GetPhotos() []*ConditionPhoto {
+	return r.photos
+}
+
+func (r *ConditionReport) SetPhotos(photos []*ConditionPhoto) {
+	r.photos = photos
+}
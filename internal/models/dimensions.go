@@ -0,0 +1,452 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// LengthUnit единица измерения высоты, ширины и глубины
+type LengthUnit string
+
+const (
+	LengthMillimeter LengthUnit = "mm"
+	LengthCentimeter LengthUnit = "cm"
+	LengthMeter      LengthUnit = "m"
+	LengthInch       LengthUnit = "in"
+)
+
+func (u LengthUnit) IsValid() bool {
+	switch u {
+	case LengthMillimeter, LengthCentimeter, LengthMeter, LengthInch:
+		return true
+	}
+	return false
+}
+
+// centimeters возвращает число сантиметров в одной единице
+func (u LengthUnit) centimeters() float64 {
+	switch u {
+	case LengthMillimeter:
+		return 0.1
+	case LengthMeter:
+		return 100
+	case LengthInch:
+		return 2.54
+	}
+	return 1
+}
+
+// ToCm переводит значение в единицах u в сантиметры
+func (u LengthUnit) ToCm(v float64) float64 {
+	return v * u.centimeters()
+}
+
+// FromCm переводит сантиметры в единицы u
+func (u LengthUnit) FromCm(cm float64) float64 {
+	return cm / u.centimeters()
+}
+
+func (u LengthUnit) Label() string {
+	switch u {
+	case LengthMillimeter:
+		return "мм"
+	case LengthMeter:
+		return "м"
+	case LengthInch:
+		return "дюйм."
+	}
+	return "см"
+}
+
+// WeightUnit единица измерения веса
+type WeightUnit string
+
+const (
+	WeightGram     WeightUnit = "g"
+	WeightKilogram WeightUnit = "kg"
+	WeightPound    WeightUnit = "lb"
+)
+
+func (u WeightUnit) IsValid() bool {
+	switch u {
+	case WeightGram, WeightKilogram, WeightPound:
+		return true
+	}
+	return false
+}
+
+// kilograms возвращает число килограммов в одной единице
+func (u WeightUnit) kilograms() float64 {
+	switch u {
+	case WeightGram:
+		return 0.001
+	case WeightPound:
+		return 0.45359237
+	}
+	return 1
+}
+
+// ToKg переводит значение в единицах u в килограммы
+func (u WeightUnit) ToKg(v float64) float64 {
+	return v * u.kilograms()
+}
+
+// FromKg переводит килограммы в единицы u
+func (u WeightUnit) FromKg(kg float64) float64 {
+	return kg / u.kilograms()
+}
+
+func (u WeightUnit) Label() string {
+	switch u {
+	case WeightGram:
+		return "г"
+	case WeightPound:
+		return "фунт."
+	}
+	return "кг"
+}
+
+// MaxSizeLen максимальная длина текстового размера произведения
+const MaxSizeLen = 50
+
+// maxDimensionCm и maxWeightKg - верхние границы размеров и веса
+const (
+	maxDimensionCm = 100000
+	maxWeightKg    = 1000000
+)
+
+// Dimensions размеры и вес произведения.
+// Значения хранятся в сантиметрах и килограммах, единицы определяют только представление.
+// Высота и ширина обязательны, глубина и вес равны 0, если не заданы.
+type Dimensions struct {
+	heightCm   float64
+	widthCm    float64
+	depthCm    float64
+	weightKg   float64
+	lengthUnit LengthUnit
+	weightUnit WeightUnit
+}
+
+var (
+	ErrDimensionsHeight     = errors.New("height must be positive")
+	ErrDimensionsWidth      = errors.New("width must be positive")
+	ErrDimensionsDepth      = errors.New("depth must not be negative")
+	ErrDimensionsWeight     = errors.New("weight must not be negative")
+	ErrDimensionsTooLarge   = errors.New("dimension value is too large")
+	ErrDimensionsLengthUnit = errors.New("invalid length unit (mm, cm, m, in)")
+	ErrDimensionsWeightUnit = errors.New("invalid weight unit (g, kg, lb)")
+	ErrDimensionsParse      = errors.New("unrecognized size format")
+)
+
+// NewDimensions создает размеры по значениям в сантиметрах и килограммах
+func NewDimensions(
+	heightCm float64,
+	widthCm float64,
+	depthCm float64,
+	weightKg float64,
+	lengthUnit LengthUnit,
+	weightUnit WeightUnit,
+) (Dimensions, error) {
+	d := Dimensions{
+		heightCm:   heightCm,
+		widthCm:    widthCm,
+		depthCm:    depthCm,
+		weightKg:   weightKg,
+		lengthUnit: lengthUnit,
+		weightUnit: weightUnit,
+	}
+	if d.weightKg == 0 && d.weightUnit == "" {
+		d.weightUnit = WeightKilogram
+	}
+
+	if err := d.validate(); err != nil {
+		return Dimensions{}, err
+	}
+
+	return d, nil
+}
+
+// NewDimensionsFromRequest создает размеры по значениям в указанных в запросе единицах
+func NewDimensionsFromRequest(req *jsonreqresp.DimensionsRequest) (Dimensions, error) {
+	lengthUnit, weightUnit := LengthUnit(req.LengthUnit), WeightUnit(req.WeightUnit)
+	if weightUnit == "" {
+		weightUnit = WeightKilogram
+	}
+	return NewDimensions(
+		lengthUnit.ToCm(req.Height),
+		lengthUnit.ToCm(req.Width),
+		lengthUnit.ToCm(req.Depth),
+		weightUnit.ToKg(req.Weight),
+		lengthUnit,
+		weightUnit,
+	)
+}
+
+func (d *Dimensions) validate() error {
+	switch {
+	case !d.lengthUnit.IsValid():
+		return ErrDimensionsLengthUnit
+	case !d.weightUnit.IsValid():
+		return ErrDimensionsWeightUnit
+	case d.heightCm <= 0:
+		return ErrDimensionsHeight
+	case d.widthCm <= 0:
+		return ErrDimensionsWidth
+	case d.depthCm < 0:
+		return ErrDimensionsDepth
+	case d.weightKg < 0:
+		return ErrDimensionsWeight
+	case d.heightCm > maxDimensionCm || d.widthCm > maxDimensionCm || d.depthCm > maxDimensionCm ||
+		d.weightKg > maxWeightKg:
+		return ErrDimensionsTooLarge
+	case len(d.String()) > MaxSizeLen:
+		return ErrArtworkSizeTooLong
+	}
+	return nil
+}
+
+// IsZero сообщает, что размеры не заданы
+func (d Dimensions) IsZero() bool {
+	return d.heightCm == 0
+}
+
+func (d Dimensions) GetHeightCm() float64 {
+	return d.heightCm
+}
+
+func (d Dimensions) GetWidthCm() float64 {
+	return d.widthCm
+}
+
+// GetDepthCm возвращает глубину, 0 - не задана
+func (d Dimensions) GetDepthCm() float64 {
+	return d.depthCm
+}
+
+// GetWeightKg возвращает вес, 0 - не задан
+func (d Dimensions) GetWeightKg() float64 {
+	return d.weightKg
+}
+
+func (d Dimensions) GetLengthUnit() LengthUnit {
+	return d.lengthUnit
+}
+
+func (d Dimensions) GetWeightUnit() WeightUnit {
+	return d.weightUnit
+}
+
+// roundDimension округляет значение до сотых, чтобы убрать погрешность перевода единиц
+func roundDimension(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func formatDimension(v float64) string {
+	return strconv.FormatFloat(roundDimension(v), 'f', -1, 64)
+}
+
+// String возвращает размер в единицах произведения, например "73.7 × 92.1 см, 3 кг"
+func (d Dimensions) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	parts := []string{formatDimension(d.lengthUnit.FromCm(d.heightCm)), formatDimension(d.lengthUnit.FromCm(d.widthCm))}
+	if d.depthCm > 0 {
+		parts = append(parts, formatDimension(d.lengthUnit.FromCm(d.depthCm)))
+	}
+	s := strings.Join(parts, " × ") + " " + d.lengthUnit.Label()
+	if d.weightKg > 0 {
+		s += ", " + formatDimension(d.weightUnit.FromKg(d.weightKg)) + " " + d.weightUnit.Label()
+	}
+	return s
+}
+
+func (d Dimensions) ToDimensionsResponse() jsonreqresp.DimensionsResponse {
+	return jsonreqresp.DimensionsResponse{
+		Height:     roundDimension(d.lengthUnit.FromCm(d.heightCm)),
+		Width:      roundDimension(d.lengthUnit.FromCm(d.widthCm)),
+		Depth:      roundDimension(d.lengthUnit.FromCm(d.depthCm)),
+		LengthUnit: string(d.lengthUnit),
+		Weight:     roundDimension(d.weightUnit.FromKg(d.weightKg)),
+		WeightUnit: string(d.weightUnit),
+		HeightCm:   roundDimension(d.heightCm),
+		WidthCm:    roundDimension(d.widthCm),
+		DepthCm:    roundDimension(d.depthCm),
+		WeightKg:   roundDimension(d.weightKg),
+	}
+}
+
+var (
+	// decimalCommaRe - десятичная запятая между цифрами: "73,7"
+	decimalCommaRe = regexp.MustCompile(`(\d),(\d)`)
+	// dimensionSepRe - разделитель измерений: латинская или русская x, знак умножения, звездочка
+	dimensionSepRe = regexp.MustCompile(`[×xх*]`)
+	// dimensionValueRe - число с необязательной единицей измерения
+	dimensionValueRe = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(\S*)$`)
+)
+
+var lengthUnitNames = map[string]LengthUnit{
+	"mm": LengthMillimeter, "мм": LengthMillimeter,
+	"cm": LengthCentimeter, "см": LengthCentimeter,
+	"m": LengthMeter, "м": LengthMeter,
+	"in": LengthInch, "inch": LengthInch, "inches": LengthInch, `"`: LengthInch, "″": LengthInch,
+	"дюйм": LengthInch, "дюйма": LengthInch, "дюймов": LengthInch,
+}
+
+var weightUnitNames = map[string]WeightUnit{
+	"g": WeightGram, "г": WeightGram, "гр": WeightGram,
+	"kg": WeightKilogram, "кг": WeightKilogram,
+	"lb": WeightPound, "lbs": WeightPound, "фунт": WeightPound, "фунта": WeightPound, "фунтов": WeightPound,
+}
+
+// ParseDimensions разбирает текстовый размер произведения.
+// Поддерживаются 2 или 3 измерения через x, х, × или *, единица после каждого числа
+// или одна после последнего (мм, см, м, дюймы) и вес через запятую или точку с запятой:
+// "73,7 x 92,1 см", "50 cm × 40 cm × 3 cm; 2.5 kg", "29 × 36 in".
+func ParseDimensions(s string) (Dimensions, error) {
+	src := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = decimalCommaRe.ReplaceAllString(s, "$1.$2")
+
+	var lengths []float64
+	var lengthUnit LengthUnit
+	var weightKg float64
+	var weightUnit WeightUnit
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if v, unit, ok := parseWeight(part); ok {
+			if weightUnit != "" {
+				return Dimensions{}, fmt.Errorf("%w: %q: several weights", ErrDimensionsParse, src)
+			}
+			weightKg, weightUnit = unit.ToKg(v), unit
+			continue
+		}
+		if lengths != nil {
+			return Dimensions{}, fmt.Errorf("%w: %q: unexpected %q", ErrDimensionsParse, src, part)
+		}
+		var err error
+		if lengths, lengthUnit, err = parseLengths(part); err != nil {
+			return Dimensions{}, fmt.Errorf("%w: %q: %v", ErrDimensionsParse, src, err)
+		}
+	}
+	if lengths == nil {
+		return Dimensions{}, fmt.Errorf("%w: %q: no dimensions", ErrDimensionsParse, src)
+	}
+
+	var depthCm float64
+	if len(lengths) == 3 {
+		depthCm = lengths[2]
+	}
+	d, err := NewDimensions(lengths[0], lengths[1], depthCm, weightKg, lengthUnit, weightUnit)
+	if err != nil {
+		return Dimensions{}, fmt.Errorf("%w: %q: %v", ErrDimensionsParse, src, err)
+	}
+	return d, nil
+}
+
+// parseWeight разбирает вес вида "3 кг"
+func parseWeight(part string) (float64, WeightUnit, bool) {
+	m := dimensionValueRe.FindStringSubmatch(part)
+	if m == nil {
+		return 0, "", false
+	}
+	unit, ok := weightUnitNames[strings.TrimSuffix(m[2], ".")]
+	if !ok {
+		return 0, "", false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return v, unit, true
+}
+
+// parseLengths разбирает измерения и возвращает их в сантиметрах вместе с единицей представления.
+// Измерение без единицы получает единицу следующего за ним измерения.
+func parseLengths(part string) ([]float64, LengthUnit, error) {
+	values := dimensionSepRe.Split(part, -1)
+	if len(values) < 2 || len(values) > 3 {
+		return nil, "", fmt.Errorf("expected 2 or 3 dimensions, got %d", len(values))
+	}
+
+	nums := make([]float64, len(values))
+	units := make([]LengthUnit, len(values))
+	for i, value := range values {
+		m := dimensionValueRe.FindStringSubmatch(strings.TrimSpace(value))
+		if m == nil {
+			return nil, "", fmt.Errorf("invalid dimension %q", strings.TrimSpace(value))
+		}
+		v, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid dimension %q", m[1])
+		}
+		nums[i] = v
+		if m[2] != "" {
+			unit, ok := lengthUnitNames[strings.TrimSuffix(m[2], ".")]
+			if !ok {
+				return nil, "", fmt.Errorf("unknown unit %q", m[2])
+			}
+			units[i] = unit
+		}
+	}
+
+	last := units[len(units)-1]
+	if last == "" {
+		return nil, "", errors.New("no unit")
+	}
+	lengths := make([]float64, len(nums))
+	for i := len(nums) - 1; i >= 0; i-- {
+		if units[i] == "" {
+			units[i] = units[i+1]
+		}
+		lengths[i] = units[i].ToCm(nums[i])
+	}
+	return lengths, last, nil
+}
+
+// UnparsedSize произведение, текстовый размер которого не удалось разобрать
+type UnparsedSize struct {
+	ArtworkID uuid.UUID
+	Title     string
+	Size      string
+	Reason    string
+}
+
+// DimensionsMigration итог перевода текстовых размеров в структурированные
+type DimensionsMigration struct {
+	DryRun bool
+	// Total - число произведений, Structured - уже имевших структурированный размер
+	Total      int
+	Structured int
+	Migrated   int
+	Unparsed   []UnparsedSize
+}
+
+func (m *DimensionsMigration) ToDimensionsMigrationResponse() jsonreqresp.DimensionsMigrationResponse {
+	resp := jsonreqresp.DimensionsMigrationResponse{
+		DryRun:     m.DryRun,
+		Total:      m.Total,
+		Structured: m.Structured,
+		Migrated:   m.Migrated,
+		Unparsed:   make([]jsonreqresp.UnparsedSizeResponse, len(m.Unparsed)),
+	}
+	for i, u := range m.Unparsed {
+		resp.Unparsed[i] = jsonreqresp.UnparsedSizeResponse{
+			ArtworkID: u.ArtworkID.String(),
+			Title:     u.Title,
+			Size:      u.Size,
+			Reason:    u.Reason,
+		}
+	}
+	return resp
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +23,26 @@ type Event struct {
 	cntTickets int
 	artworkIDs uuid.UUIDs
 	valid      bool
+	status     EventStatus
+}
+
+// EventStatus - этап согласования мероприятия.
+// Публичными (в поиске и продаже билетов) являются только одобренные мероприятия.
+type EventStatus string
+
+const (
+	EventStatusDraft    EventStatus = "draft"
+	EventStatusPending  EventStatus = "pending"
+	EventStatusApproved EventStatus = "approved"
+	EventStatusRejected EventStatus = "rejected"
+)
+
+func (s EventStatus) IsValid() bool {
+	switch s {
+	case EventStatusDraft, EventStatusPending, EventStatusApproved, EventStatusRejected:
+		return true
+	}
+	return false
 }
 
 var (
@@ -37,6 +58,8 @@ var (
 	ErrEventInvalidEmployee = errors.New("invalid employee ID")
 	ErrEventNegativeTickets = errors.New("ticket count cannot be negative")
 	ErrDuplicateArtwokIDs   = errors.New("duplicate artwork ids")
+	ErrEventInvalidStatus   = errors.New("invalid event status")
+	ErrEventStatusChange    = errors.New("event status can't be changed")
 )
 
 func NewEvent(
@@ -62,6 +85,7 @@ func NewEvent(
 		cntTickets: cntTickets,
 		artworkIDs: artworkIDs,
 		valid:      valid,
+		status:     EventStatusDraft,
 	}
 
 	if err := event.validate(); err != nil {
@@ -158,6 +182,49 @@ func (e *Event) IsValid() bool {
 	return e.valid
 }
 
+func (e *Event) GetStatus() EventStatus {
+	return e.status
+}
+
+func (e *Event) IsApproved() bool {
+	return e.status == EventStatusApproved
+}
+
+// SetStatus восстанавливает статус, сохраненный в хранилище
+func (e *Event) SetStatus(status EventStatus) error {
+	if !status.IsValid() {
+		return fmt.Errorf("%w: %q", ErrEventInvalidStatus, status)
+	}
+	e.status = status
+	return nil
+}
+
+func (e *Event) changeStatus(to EventStatus, from ...EventStatus) error {
+	if !slices.Contains(from, e.status) {
+		return fmt.Errorf("%w: %s -> %s", ErrEventStatusChange, e.status, to)
+	}
+	e.status = to
+	return nil
+}
+
+// Submit отправляет черновик или отклоненное мероприятие на согласование
+func (e *Event) Submit() error {
+	return e.changeStatus(EventStatusPending, EventStatusDraft, EventStatusRejected)
+}
+
+func (e *Event) Approve() error {
+	return e.changeStatus(EventStatusApproved, EventStatusPending)
+}
+
+func (e *Event) Reject() error {
+	return e.changeStatus(EventStatusRejected, EventStatusPending)
+}
+
+// Reopen возвращает одобренное мероприятие на повторное согласование после его изменения
+func (e *Event) Reopen() error {
+	return e.changeStatus(EventStatusPending, EventStatusApproved)
+}
+
 func (e *Event) AddArtworks(idArts uuid.UUIDs) error {
 	for _, oldID := range e.artworkIDs {
 		if slices.Contains(idArts, oldID) {
@@ -178,6 +245,28 @@ func (e *Event) DeleteArtwork(idArt uuid.UUID) error {
 	return ErrDeleteArtwork
 }
 
+// Clone создает копию мероприятия с новыми датами и списком тех же произведений.
+// Пустой title означает название исходного мероприятия.
+func (e *Event) Clone(
+	id uuid.UUID, title string, dateBegin time.Time, dateEnd time.Time, employeeID uuid.UUID,
+) (Event, error) {
+	if strings.TrimSpace(title) == "" {
+		title = e.title
+	}
+	return NewEvent(
+		id,
+		title,
+		dateBegin,
+		dateEnd,
+		e.address,
+		e.canVisit,
+		employeeID,
+		e.cntTickets,
+		true,
+		slices.Clone(e.artworkIDs),
+	)
+}
+
 func (e *Event) Update(updateReq *jsonreqresp.EventUpdate) error {
 	copyE := *e
 	copyE.title = updateReq.Title
@@ -195,6 +284,43 @@ func (e *Event) Update(updateReq *jsonreqresp.EventUpdate) error {
 	return nil
 }
 
+// Snapshot возвращает изменяемые через Update поля для истории изменений
+func (e *Event) Snapshot() Snapshot {
+	return Snapshot{
+		"title":      e.title,
+		"dateBegin":  snapshotTime(e.dateBegin),
+		"dateEnd":    snapshotTime(e.dateEnd),
+		"address":    e.address,
+		"canVisit":   strconv.FormatBool(e.canVisit),
+		"cntTickets": strconv.Itoa(e.cntTickets),
+	}
+}
+
+// Restore возвращает поля мероприятия к сохраненному состоянию
+func (e *Event) Restore(s Snapshot) error {
+	req := &jsonreqresp.EventUpdate{}
+	var err error
+	if req.Title, err = s.String("title"); err != nil {
+		return err
+	}
+	if req.DateBegin, err = s.Time("dateBegin"); err != nil {
+		return err
+	}
+	if req.DateEnd, err = s.Time("dateEnd"); err != nil {
+		return err
+	}
+	if req.Address, err = s.String("address"); err != nil {
+		return err
+	}
+	if req.CanVisit, err = s.Bool("canVisit"); err != nil {
+		return err
+	}
+	if req.CntTickets, err = s.Int("cntTickets"); err != nil {
+		return err
+	}
+	return e.Update(req)
+}
+
 func (e *Event) ToEventResponse() jsonreqresp.EventResponse {
 	return jsonreqresp.EventResponse{
 		ID:         e.id.String(),
@@ -206,6 +332,7 @@ func (e *Event) ToEventResponse() jsonreqresp.EventResponse {
 		EmployeeID: e.employeeID.String(),
 		CntTickets: e.cntTickets,
 		Valid:      e.valid,
+		Status:     string(e.status),
 		ArtworkIDs: e.GetArtworkIDs().Strings(),
 	}
 }
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// EventReview - запись истории согласования мероприятия: кто и с каким комментарием перевел его в статус status
+type EventReview struct {
+	id        uuid.UUID
+	eventID   uuid.UUID
+	actorID   uuid.UUID
+	status    EventStatus
+	comment   string
+	createdAt time.Time
+}
+
+var (
+	ErrValidateEventReview        = errors.New("invalid model EventReview")
+	ErrEventReviewInvalidActor    = errors.New("invalid actor ID")
+	ErrEventReviewCommentTooLong  = errors.New("comment exceeds maximum length (1000 chars)")
+	ErrEventReviewRejectNoComment = errors.New("reject requires comment")
+)
+
+func NewEventReview(
+	id uuid.UUID,
+	eventID uuid.UUID,
+	actorID uuid.UUID,
+	status EventStatus,
+	comment string,
+	createdAt time.Time,
+) (EventReview, error) {
+	review := EventReview{
+		id:        id,
+		eventID:   eventID,
+		actorID:   actorID,
+		status:    status,
+		comment:   strings.TrimSpace(comment),
+		createdAt: createdAt,
+	}
+
+	if err := review.validate(); err != nil {
+		return EventReview{}, err
+	}
+
+	return review, nil
+}
+
+func (r *EventReview) validate() error {
+	switch {
+	case r.actorID == uuid.Nil:
+		return ErrEventReviewInvalidActor
+	case !r.status.IsValid():
+		return ErrEventInvalidStatus
+	case len([]rune(r.comment)) > 1000:
+		return ErrEventReviewCommentTooLong
+	case r.status == EventStatusRejected && r.comment == "":
+		return ErrEventReviewRejectNoComment
+	}
+	return nil
+}
+
+func (r *EventReview) GetID() uuid.UUID {
+	return r.id
+}
+
+func (r *EventReview) GetEventID() uuid.UUID {
+	return r.eventID
+}
+
+func (r *EventReview) GetActorID() uuid.UUID {
+	return r.actorID
+}
+
+func (r *EventReview) GetStatus() EventStatus {
+	return r.status
+}
+
+func (r *EventReview) GetComment() string {
+	return r.comment
+}
+
+func (r *EventReview) GetCreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *EventReview) ToEventReviewResponse() jsonreqresp.EventReviewResponse {
+	return jsonreqresp.EventReviewResponse{
+		ID:        r.id.String(),
+		EventID:   r.eventID.String(),
+		ActorID:   r.actorID.String(),
+		Status:    string(r.status),
+		Comment:   r.comment,
+		CreatedAt: r.createdAt,
+	}
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+type EventTemplate struct {
+	id         uuid.UUID
+	name       string
+	title      string
+	address    string
+	canVisit   bool
+	employeeID uuid.UUID
+	cntTickets int
+	artworkIDs uuid.UUIDs
+}
+
+var (
+	ErrValidateEventTemplate    = errors.New("invalid model EventTemplate")
+	ErrEventTemplateEmptyName   = errors.New("empty template name")
+	ErrEventTemplateNameTooLong = errors.New("template name exceeds maximum length (255 chars)")
+)
+
+func NewEventTemplate(
+	id uuid.UUID,
+	name string,
+	title string,
+	address string,
+	canVisit bool,
+	employeeID uuid.UUID,
+	cntTickets int,
+	artworkIDs uuid.UUIDs,
+) (EventTemplate, error) {
+	tmpl := EventTemplate{
+		id:         id,
+		name:       strings.TrimSpace(name),
+		title:      strings.TrimSpace(title),
+		address:    strings.TrimSpace(address),
+		canVisit:   canVisit,
+		employeeID: employeeID,
+		cntTickets: cntTickets,
+		artworkIDs: artworkIDs,
+	}
+
+	if err := tmpl.validate(); err != nil {
+		return EventTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// NewEventTemplateFromEvent сохраняет параметры мероприятия (без дат) как шаблон
+func NewEventTemplateFromEvent(id uuid.UUID, name string, employeeID uuid.UUID, e *Event) (EventTemplate, error) {
+	return NewEventTemplate(
+		id,
+		name,
+		e.GetTitle(),
+		e.GetAddress(),
+		e.GetAccess(),
+		employeeID,
+		e.GetTicketCount(),
+		slices.Clone(e.GetArtworkIDs()),
+	)
+}
+
+func (t *EventTemplate) validate() error {
+	switch {
+	case t.name == "":
+		return ErrEventTemplateEmptyName
+	case len(t.name) > 255:
+		return ErrEventTemplateNameTooLong
+	case t.title == "":
+		return ErrEventEmptyTitle
+	case len(t.title) > 255:
+		return ErrEventTitleTooLong
+	case t.address == "":
+		return ErrEventEmptyAddress
+	case len(t.address) > 255:
+		return ErrEventAddressTooLong
+	case t.employeeID == uuid.Nil:
+		return ErrEventInvalidEmployee
+	case t.cntTickets < 0:
+		return ErrEventNegativeTickets
+	case HasDuplicateUUIDs(t.artworkIDs):
+		return ErrDuplicateArtwokIDs
+	}
+	return nil
+}
+
+// NewEvent создает мероприятие по шаблону на указанные даты.
+// Пустой title означает название из шаблона.
+func (t *EventTemplate) NewEvent(
+	id uuid.UUID, title string, dateBegin time.Time, dateEnd time.Time, employeeID uuid.UUID,
+) (Event, error) {
+	if strings.TrimSpace(title) == "" {
+		title = t.title
+	}
+	return NewEvent(
+		id,
+		title,
+		dateBegin,
+		dateEnd,
+		t.address,
+		t.canVisit,
+		employeeID,
+		t.cntTickets,
+		true,
+		slices.Clone(t.artworkIDs),
+	)
+}
+
+func (t *EventTemplate) GetID() uuid.UUID {
+	return t.id
+}
+
+func (t *EventTemplate) GetName() string {
+	return t.name
+}
+
+func (t *EventTemplate) GetTitle() string {
+	return t.title
+}
+
+func (t *EventTemplate) GetAddress() string {
+	return t.address
+}
+
+func (t *EventTemplate) GetAccess() bool {
+	return t.canVisit
+}
+
+func (t *EventTemplate) GetEmployeeID() uuid.UUID {
+	return t.employeeID
+}
+
+func (t *EventTemplate) GetTicketCount() int {
+	return t.cntTickets
+}
+
+func (t *EventTemplate) GetArtworkIDs() uuid.UUIDs {
+	return t.artworkIDs
+}
+
+func (t *EventTemplate) AddArtworks(idArts uuid.UUIDs) error {
+	for _, oldID := range t.artworkIDs {
+		if slices.Contains(idArts, oldID) {
+			return ErrAddArtwork
+		}
+	}
+	t.artworkIDs = append(t.artworkIDs, idArts...)
+	return nil
+}
+
+func (t *EventTemplate) ToEventTemplateResponse() jsonreqresp.EventTemplateResponse {
+	return jsonreqresp.EventTemplateResponse{
+		ID:         t.id.String(),
+		Name:       t.name,
+		Title:      t.title,
+		Address:    t.address,
+		CanVisit:   t.canVisit,
+		EmployeeID: t.employeeID.String(),
+		CntTickets: t.cntTickets,
+		ArtworkIDs: t.artworkIDs.Strings(),
+	}
+}
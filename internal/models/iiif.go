@@ -0,0 +1,209 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// IIIFPathPrefix путь ресурсов IIIF относительно адреса сервиса
+const IIIFPathPrefix = "/api/v1/iiif"
+
+// iiifThumbnailWidth ширина миниатюры, которую показывают просмотрщики IIIF
+const iiifThumbnailWidth = 480
+
+// iiifPlaceholderSize сторона пустого холста произведения без изображений, если размер произведения не задан
+const iiifPlaceholderSize = 1000
+
+func IIIFManifestID(baseURL string, artworkID uuid.UUID) string {
+	return baseURL + IIIFPathPrefix + "/artworks/" + artworkID.String() + "/manifest"
+}
+
+func IIIFCollectionID(baseURL string, collectionID uuid.UUID) string {
+	return baseURL + IIIFPathPrefix + "/collections/" + collectionID.String()
+}
+
+// IIIFCollectionsID адрес коллекции IIIF со всеми коллекциями музея
+func IIIFCollectionsID(baseURL string) string {
+	return baseURL + IIIFPathPrefix + "/collections"
+}
+
+func iiifText(lang string, values ...string) jsonreqresp.IIIFLangMap {
+	return jsonreqresp.IIIFLangMap{lang: values}
+}
+
+func iiifMetadata(ru, en string, value jsonreqresp.IIIFLangMap) jsonreqresp.IIIFMetadataEntry {
+	return jsonreqresp.IIIFMetadataEntry{
+		Label: jsonreqresp.IIIFLangMap{"ru": {ru}, "en": {en}},
+		Value: value,
+	}
+}
+
+// ToIIIFManifest возвращает манифест IIIF Presentation 3.0 с холстом на каждое изображение произведения.
+// baseURL - внешний адрес сервиса без завершающего "/"
+func (a *Artwork) ToIIIFManifest(baseURL string) jsonreqresp.IIIFManifest {
+	manifestID := IIIFManifestID(baseURL, a.id)
+	creators := make([]string, 0, 1+len(a.coAuthors))
+	for _, attr := range a.GetAttributions() {
+		creators = append(creators, attr.Label())
+	}
+
+	metadata := []jsonreqresp.IIIFMetadataEntry{
+		iiifMetadata("Автор", "Creator", iiifText("none", creators...)),
+		iiifMetadata("Дата создания", "Date", iiifText("ru", a.dating.Label())),
+	}
+	if a.technic != "" {
+		metadata = append(metadata, iiifMetadata("Техника", "Technique", iiifText("ru", a.technic)))
+	}
+	if a.material != "" {
+		metadata = append(metadata, iiifMetadata("Материал", "Material", iiifText("ru", a.material)))
+	}
+	if a.size != "" {
+		metadata = append(metadata, iiifMetadata("Размер", "Dimensions", iiifText("none", a.size)))
+	}
+	metadata = append(metadata, iiifMetadata("Коллекция", "Collection", iiifText("ru", a.collection.GetTitle())))
+
+	manifest := jsonreqresp.IIIFManifest{
+		Context:  jsonreqresp.IIIFContext,
+		ID:       manifestID,
+		Type:     "Manifest",
+		Label:    iiifText("ru", a.title),
+		Metadata: metadata,
+		PartOf: []jsonreqresp.IIIFReference{{
+			ID:    IIIFCollectionID(baseURL, a.collection.GetID()),
+			Type:  "Collection",
+			Label: iiifText("ru", a.collection.GetTitle()),
+		}},
+	}
+	if year := a.dating.GetYearFrom(); year > 0 && year <= 9999 {
+		manifest.NavDate = fmt.Sprintf("%04d-01-01T00:00:00Z", year)
+	}
+	if primary := a.GetPrimaryImage(); primary != nil {
+		manifest.Thumbnail = []jsonreqresp.IIIFImage{primary.toIIIFThumbnail(baseURL)}
+	}
+
+	manifest.Items = make([]jsonreqresp.IIIFCanvas, len(a.images))
+	for i, img := range a.images {
+		manifest.Items[i] = img.toIIIFCanvas(baseURL, manifestID, i+1)
+	}
+	if len(manifest.Items) == 0 {
+		// манифест по спецификации содержит хотя бы один холст
+		manifest.Items = []jsonreqresp.IIIFCanvas{a.iiifPlaceholderCanvas(manifestID)}
+	}
+	return manifest
+}
+
+// iiifPlaceholderCanvas возвращает холст без изображения с пропорциями произведения (1 единица = 1 мм)
+func (a *Artwork) iiifPlaceholderCanvas(manifestID string) jsonreqresp.IIIFCanvas {
+	width, height := iiifPlaceholderSize, iiifPlaceholderSize
+	if a.dimensions.GetWidthCm() > 0 && a.dimensions.GetHeightCm() > 0 {
+		width = int(math.Ceil(a.dimensions.GetWidthCm() * 10))
+		height = int(math.Ceil(a.dimensions.GetHeightCm() * 10))
+	}
+	canvasID := manifestID + "/canvas/p1"
+	return jsonreqresp.IIIFCanvas{
+		ID:     canvasID,
+		Type:   "Canvas",
+		Label:  iiifText("none", "1"),
+		Width:  width,
+		Height: height,
+		Items: []jsonreqresp.IIIFAnnotationPage{{
+			ID:    canvasID + "/page",
+			Type:  "AnnotationPage",
+			Items: []jsonreqresp.IIIFAnnotation{},
+		}},
+	}
+}
+
+// MIMEType возвращает тип содержимого файла изображения
+func (f ImageFormat) MIMEType() string {
+	return "image/" + string(f)
+}
+
+func (i *ArtworkImage) iiifURL(baseURL string, key string) string {
+	return baseURL + ArtworkImagesURLPrefix + key
+}
+
+func (i *ArtworkImage) toIIIFCanvas(baseURL string, manifestID string, n int) jsonreqresp.IIIFCanvas {
+	canvasID := manifestID + "/canvas/p" + strconv.Itoa(n)
+	return jsonreqresp.IIIFCanvas{
+		ID:        canvasID,
+		Type:      "Canvas",
+		Label:     iiifText("none", strconv.Itoa(n)),
+		Width:     i.width,
+		Height:    i.height,
+		Thumbnail: []jsonreqresp.IIIFImage{i.toIIIFThumbnail(baseURL)},
+		Items: []jsonreqresp.IIIFAnnotationPage{{
+			ID:   canvasID + "/page",
+			Type: "AnnotationPage",
+			Items: []jsonreqresp.IIIFAnnotation{{
+				ID:         canvasID + "/page/image",
+				Type:       "Annotation",
+				Motivation: "painting",
+				Body: jsonreqresp.IIIFImage{
+					ID:     i.iiifURL(baseURL, i.OriginalKey()),
+					Type:   "Image",
+					Format: i.format.MIMEType(),
+					Width:  i.width,
+					Height: i.height,
+				},
+				Target: canvasID,
+			}},
+		}},
+	}
+}
+
+// toIIIFThumbnail возвращает миниатюру; ее размеры считаются так же, как при генерации:
+// изображение уменьшается до ширины iiifThumbnailWidth и не увеличивается
+func (i *ArtworkImage) toIIIFThumbnail(baseURL string) jsonreqresp.IIIFImage {
+	width := min(iiifThumbnailWidth, i.width)
+	height := max(int(math.Round(float64(i.height)*float64(width)/float64(i.width))), 1)
+	return jsonreqresp.IIIFImage{
+		ID:     i.iiifURL(baseURL, i.ThumbnailKey(iiifThumbnailWidth)),
+		Type:   "Image",
+		Format: ImageFormatJPEG.MIMEType(),
+		Width:  width,
+		Height: height,
+	}
+}
+
+// ToIIIFCollection возвращает коллекцию IIIF со ссылками на манифесты произведений коллекции
+func (c *Collection) ToIIIFCollection(baseURL string, artworks []*Artwork) jsonreqresp.IIIFCollection {
+	items := make([]jsonreqresp.IIIFReference, len(artworks))
+	for i, a := range artworks {
+		items[i] = jsonreqresp.IIIFReference{
+			ID:    IIIFManifestID(baseURL, a.GetID()),
+			Type:  "Manifest",
+			Label: iiifText("ru", a.GetTitle()),
+		}
+	}
+	return jsonreqresp.IIIFCollection{
+		Context: jsonreqresp.IIIFContext,
+		ID:      IIIFCollectionID(baseURL, c.id),
+		Type:    "Collection",
+		Label:   iiifText("ru", c.title),
+		Items:   items,
+	}
+}
+
+// ToIIIFCollections возвращает коллекцию IIIF верхнего уровня со ссылками на все коллекции музея
+func ToIIIFCollections(baseURL string, collections []*Collection) jsonreqresp.IIIFCollection {
+	items := make([]jsonreqresp.IIIFReference, len(collections))
+	for i, c := range collections {
+		items[i] = jsonreqresp.IIIFReference{
+			ID:    IIIFCollectionID(baseURL, c.GetID()),
+			Type:  "Collection",
+			Label: iiifText("ru", c.GetTitle()),
+		}
+	}
+	return jsonreqresp.IIIFCollection{
+		Context: jsonreqresp.IIIFContext,
+		ID:      IIIFCollectionsID(baseURL),
+		Type:    "Collection",
+		Label:   iiifText("ru", "Коллекции музея"),
+		Items:   items,
+	}
+}
@@ -0,0 +1,219 @@
+package jsonreqresp
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Названия фасетов произведений, совпадают с именами параметров запроса
+const (
+	TechnicFacetArtwork    = "technic"
+	MaterialFacetArtwork   = "material"
+	CenturyFacetArtwork    = "century"
+	AuthorFacetArtwork     = "author_id"
+	CollectionFacetArtwork = "collection_id"
+	TagParamArtwork        = "tag_id"
+	YearFromParamArtwork   = "year_from"
+	YearToParamArtwork     = "year_to"
+	// SubcollectionsParamArtwork включает в фильтр по коллекциям вложенные коллекции
+	SubcollectionsParamArtwork = "subcollections"
+)
+
+// Параметры запроса диапазонов размеров (см) и веса (кг)
+const (
+	HeightMinParamArtwork = "height_min"
+	HeightMaxParamArtwork = "height_max"
+	WidthMinParamArtwork  = "width_min"
+	WidthMaxParamArtwork  = "width_max"
+	DepthMinParamArtwork  = "depth_min"
+	DepthMaxParamArtwork  = "depth_max"
+	WeightMinParamArtwork = "weight_min"
+	WeightMaxParamArtwork = "weight_max"
+)
+
+// MaxFacetValues ограничивает число выбранных значений одного фасета в запросе
+const MaxFacetValues = 50
+
+type FacetBucketResponse struct {
+	Value    string `json:"value" example:"Масло, холст"`
+	Label    string `json:"label" example:"Масло, холст"`
+	Count    int    `json:"count" example:"12"`
+	Selected bool   `json:"selected" example:"false"`
+}
+
+// YearRangeFacetResponse границы года создания среди подходящих произведений, 0 - произведений нет
+type YearRangeFacetResponse struct {
+	Min  int `json:"min" example:"1503"`
+	Max  int `json:"max" example:"1889"`
+	From int `json:"from,omitempty" example:"1800"`
+	To   int `json:"to,omitempty" example:"1900"`
+}
+
+// ArtworkFacetsResponse значения фасетов с количеством произведений.
+// Количество для значения фасета считается с учетом всех фильтров, кроме фильтра этого же фасета
+type ArtworkFacetsResponse struct {
+	Technics     []FacetBucketResponse  `json:"technics"`
+	Materials    []FacetBucketResponse  `json:"materials"`
+	Centuries    []FacetBucketResponse  `json:"centuries"`
+	Authors      []FacetBucketResponse  `json:"authors"`
+	Collections  []FacetBucketResponse  `json:"collections"`
+	CreationYear YearRangeFacetResponse `json:"creationYear"`
+}
+
+// ParseFacetQuery заполняет фасетные фильтры из параметров запроса.
+// Многозначные параметры передаются повторением: ?technic=a&technic=b
+func (f *ArtworkFilter) ParseFacetQuery(query url.Values) error {
+	f.Technics = facetStrings(query[TechnicFacetArtwork])
+	f.Materials = facetStrings(query[MaterialFacetArtwork])
+	if len(f.Technics) > MaxFacetValues || len(f.Materials) > MaxFacetValues {
+		return fmt.Errorf("%w: too many values", ErrArtworkFilterParam)
+	}
+
+	var err error
+	if f.Centuries, err = facetInts(CenturyFacetArtwork, query[CenturyFacetArtwork]); err != nil {
+		return err
+	}
+	if f.AuthorIDs, err = facetUUIDs(AuthorFacetArtwork, query[AuthorFacetArtwork]); err != nil {
+		return err
+	}
+	if f.CollectionIDs, err = facetUUIDs(CollectionFacetArtwork, query[CollectionFacetArtwork]); err != nil {
+		return err
+	}
+	if f.IncludeSubcollections, err = boolParam(SubcollectionsParamArtwork, query.Get(SubcollectionsParamArtwork)); err != nil {
+		return err
+	}
+	if f.TagIDs, err = facetUUIDs(TagParamArtwork, query[TagParamArtwork]); err != nil {
+		return err
+	}
+	if f.YearFrom, err = yearParam(YearFromParamArtwork, query.Get(YearFromParamArtwork)); err != nil {
+		return err
+	}
+	if f.YearTo, err = yearParam(YearToParamArtwork, query.Get(YearToParamArtwork)); err != nil {
+		return err
+	}
+	ranges := []struct {
+		dest     *DimensionRange
+		minParam string
+		maxParam string
+	}{
+		{&f.Height, HeightMinParamArtwork, HeightMaxParamArtwork},
+		{&f.Width, WidthMinParamArtwork, WidthMaxParamArtwork},
+		{&f.Depth, DepthMinParamArtwork, DepthMaxParamArtwork},
+		{&f.Weight, WeightMinParamArtwork, WeightMaxParamArtwork},
+	}
+	for _, r := range ranges {
+		if r.dest.Min, err = dimensionParam(r.minParam, query.Get(r.minParam)); err != nil {
+			return err
+		}
+		if r.dest.Max, err = dimensionParam(r.maxParam, query.Get(r.maxParam)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithoutFacet возвращает копию фильтра без условия указанного фасета
+func (f ArtworkFilter) WithoutFacet(facet string) *ArtworkFilter {
+	switch facet {
+	case TechnicFacetArtwork:
+		f.Technics = nil
+	case MaterialFacetArtwork:
+		f.Materials = nil
+	case CenturyFacetArtwork:
+		f.Centuries = nil
+	case AuthorFacetArtwork:
+		f.AuthorIDs = nil
+	case CollectionFacetArtwork:
+		f.CollectionIDs = nil
+	case YearFromParamArtwork, YearToParamArtwork:
+		f.YearFrom, f.YearTo = 0, 0
+	}
+	return &f
+}
+
+// facetStrings убирает пустые значения и повторы
+func facetStrings(values []string) []string {
+	var res []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func facetInts(param string, values []string) ([]int, error) {
+	values = facetStrings(values)
+	if len(values) > MaxFacetValues {
+		return nil, fmt.Errorf("%w: too many values of %s", ErrArtworkFilterParam, param)
+	}
+	var res []int
+	for _, v := range values {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%w: %s=%q", ErrArtworkFilterParam, param, v)
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+func facetUUIDs(param string, values []string) (uuid.UUIDs, error) {
+	values = facetStrings(values)
+	if len(values) > MaxFacetValues {
+		return nil, fmt.Errorf("%w: too many values of %s", ErrArtworkFilterParam, param)
+	}
+	var res uuid.UUIDs
+	for _, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s=%q", ErrArtworkFilterParam, param, v)
+		}
+		res = append(res, id)
+	}
+	return res, nil
+}
+
+func yearParam(param string, value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil || year <= 0 {
+		return 0, fmt.Errorf("%w: %s=%q", ErrArtworkFilterParam, param, value)
+	}
+	return year, nil
+}
+
+func boolParam(param string, value string) (bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false, nil
+	}
+	res, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s=%q", ErrArtworkFilterParam, param, value)
+	}
+	return res, nil
+}
+
+func dimensionParam(param string, value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+		return 0, fmt.Errorf("%w: %s=%q", ErrArtworkFilterParam, param, value)
+	}
+	return v, nil
+}
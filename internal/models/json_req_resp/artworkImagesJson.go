@@ -0,0 +1,21 @@
+package jsonreqresp
+
+type ArtworkThumbnailResponse struct {
+	Width int    `json:"width" example:"480"`
+	URL   string `json:"url" example:"/images/artworks/bb2e8400-e29b-41d4-a716-446655442222/aa1e8400-e29b-41d4-a716-446655441111/w480.jpg"`
+}
+
+type ArtworkImageResponse struct {
+	ID         string                     `json:"id" example:"aa1e8400-e29b-41d4-a716-446655441111"`
+	Position   int                        `json:"position" example:"0"`
+	IsPrimary  bool                       `json:"isPrimary" example:"true"`
+	Format     string                     `json:"format" example:"jpeg"`
+	Width      int                        `json:"width" example:"3000"`
+	Height     int                        `json:"height" example:"2000"`
+	URL        string                     `json:"url" example:"/images/artworks/bb2e8400-e29b-41d4-a716-446655442222/aa1e8400-e29b-41d4-a716-446655441111/original.jpg"`
+	Thumbnails []ArtworkThumbnailResponse `json:"thumbnails"`
+}
+
+type ReorderArtworkImagesRequest struct {
+	ImageIDs []string `json:"imageIDs" binding:"required,min=1,dive,uuid" example:"aa1e8400-e29b-41d4-a716-446655441111"`
+}
@@ -1,14 +1,111 @@
 package jsonreqresp
 
 type ArtworkResponse struct {
-	ID           string             `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
-	Title        string             `json:"title" example:"Mona Lisa"`
-	CreationYear int                `json:"creationYear" example:"1503"`
-	Technic      string             `json:"technic" example:"Oil painting"`
-	Material     string             `json:"material" example:"Poplar wood"`
-	Size         string             `json:"size" example:"77 cm × 53 cm"`
-	Author       AuthorResponse     `json:"author"`
-	Collection   CollectionResponse `json:"collection"`
+	ID    string `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Title string `json:"title" example:"Mona Lisa"`
+	// CreationYear действующий год датировки (середина периода), по нему идут поиск и сортировка
+	CreationYear int                   `json:"creationYear" example:"1503"`
+	Dating       ArtworkDatingResponse `json:"dating"`
+	Technic      string                `json:"technic" example:"Oil painting"`
+	Material     string                `json:"material" example:"Poplar wood"`
+	Size         string                `json:"size" example:"77 × 53 см"`
+	// Dimensions заполняется, если размер произведения задан структурно; тогда Size формируется по нему
+	Dimensions *DimensionsResponse `json:"dimensions,omitempty"`
+	// Author основной автор; Authors - все авторы с ролями, основной - первый
+	Author     AuthorResponse        `json:"author"`
+	Authors    []AttributionResponse `json:"authors"`
+	Collection CollectionResponse    `json:"collection"`
+	// Tags теги словаря, которыми отмечено произведение
+	Tags []TagResponse `json:"tags"`
+	// Images изображения произведения в порядке показа
+	Images       []ArtworkImageResponse `json:"images"`
+	PrimaryImage *ArtworkImageResponse  `json:"primaryImage,omitempty"`
+	// Highlight заполняется только при полнотекстовом поиске
+	Highlight *ArtworkHighlightResponse `json:"highlight,omitempty"`
+}
+
+// ArtworkHighlightResponse поля произведения с выделенными совпадениями.
+// Значения - HTML, экранированный текст с найденными словами в тегах <mark>
+type ArtworkHighlightResponse struct {
+	Title      string `json:"title" example:"<mark>Портрет</mark> дамы"`
+	AuthorName string `json:"authorName" example:"Леонардо да Винчи"`
+	Collection string `json:"collection" example:"Итальянская живопись"`
+	Technic    string `json:"technic" example:"<mark>Масло</mark>"`
+	Material   string `json:"material" example:"Доска"`
+}
+
+// ArtworkDatingResponse датировка произведения, Label - для показа
+type ArtworkDatingResponse struct {
+	YearFrom  int    `json:"yearFrom" example:"1890"`
+	YearTo    int    `json:"yearTo" example:"1895"`
+	Qualifier string `json:"qualifier" example:"circa"`
+	Precision string `json:"precision" example:"year"`
+	Label     string `json:"label" example:"ок. 1890–1895"`
+}
+
+// ArtworkDatingRequest датировка произведения периодом.
+// YearTo можно не передавать для одного года; при точности decade и century
+// границы периода расширяются до границ десятилетия или века
+type ArtworkDatingRequest struct {
+	YearFrom  int    `json:"yearFrom" binding:"required,gt=0,lte=2100" example:"1890"`
+	YearTo    int    `json:"yearTo,omitempty" binding:"omitempty,gtefield=YearFrom,lte=2100" example:"1895"`
+	Qualifier string `json:"qualifier,omitempty" binding:"omitempty,oneof=exact circa before after" example:"circa"`
+	Precision string `json:"precision,omitempty" binding:"omitempty,oneof=year decade century" example:"year"`
+}
+
+// AttributionResponse автор произведения и его роль
+type AttributionResponse struct {
+	Author    AuthorResponse `json:"author"`
+	Role      string         `json:"role" example:"workshop"`
+	RoleLabel string         `json:"roleLabel" example:"мастерская"`
+}
+
+// AttributionRequest дополнительный автор произведения, пустая роль - author
+type AttributionRequest struct {
+	AuthorID string `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	Role     string `json:"role,omitempty" binding:"omitempty,oneof=author attributed workshop circle follower copy_after" example:"workshop"`
+}
+
+// DimensionsResponse размеры в единицах произведения и те же размеры в сантиметрах и килограммах.
+// Глубина и вес равны 0, если не заданы
+type DimensionsResponse struct {
+	Height     float64 `json:"height" example:"77"`
+	Width      float64 `json:"width" example:"53"`
+	Depth      float64 `json:"depth" example:"0"`
+	LengthUnit string  `json:"lengthUnit" example:"cm"`
+	Weight     float64 `json:"weight" example:"0"`
+	WeightUnit string  `json:"weightUnit" example:"kg"`
+	HeightCm   float64 `json:"heightCm" example:"77"`
+	WidthCm    float64 `json:"widthCm" example:"53"`
+	DepthCm    float64 `json:"depthCm" example:"0"`
+	WeightKg   float64 `json:"weightKg" example:"0"`
+}
+
+// DimensionsRequest размеры и вес произведения в указанных единицах
+type DimensionsRequest struct {
+	Height     float64 `json:"height" binding:"required,gt=0" example:"73.7"`
+	Width      float64 `json:"width" binding:"required,gt=0" example:"92.1"`
+	Depth      float64 `json:"depth,omitempty" binding:"omitempty,gte=0" example:"0"`
+	LengthUnit string  `json:"lengthUnit" binding:"required,oneof=mm cm m in" example:"cm"`
+	Weight     float64 `json:"weight,omitempty" binding:"omitempty,gte=0" example:"0"`
+	WeightUnit string  `json:"weightUnit,omitempty" binding:"omitempty,oneof=g kg lb" example:"kg"`
+}
+
+// DimensionsMigrationResponse итог перевода текстовых размеров в структурированные
+type DimensionsMigrationResponse struct {
+	// DryRun - изменения не сохранены, Migrated показывает, сколько произведений было бы обновлено
+	DryRun     bool                   `json:"dryRun" example:"false"`
+	Total      int                    `json:"total" example:"120"`
+	Structured int                    `json:"structured" example:"15"`
+	Migrated   int                    `json:"migrated" example:"98"`
+	Unparsed   []UnparsedSizeResponse `json:"unparsed"`
+}
+
+type UnparsedSizeResponse struct {
+	ArtworkID string `json:"artworkID" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Title     string `json:"title" example:"Тондо"`
+	Size      string `json:"size" example:"диаметр 82 см"`
+	Reason    string `json:"reason" example:"unrecognized size format: \"диаметр 82 см\": expected 2 or 3 dimensions, got 1"`
 }
 
 type ArtworkRequest struct {
@@ -17,34 +114,58 @@ type ArtworkRequest struct {
 }
 
 type AddArtworkRequest struct {
-	Title        string `json:"title" binding:"required,max=255" example:"Звёздная ночь"`
-	CreationYear int    `json:"creationYear" binding:"required,gt=0,lte=2100" example:"1889"`
-	Technic      string `json:"technic" binding:"required,max=100" example:"Масло, холст"`
-	Material     string `json:"material" binding:"required,max=100" example:"Холст, масляные краски"`
-	Size         string `json:"size" binding:"required,max=50" example:"73.7 × 92.1 см"`
-	AuthorID     string `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
-	CollectionID string `json:"collectionID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	Title string `json:"title" binding:"required,max=255" example:"Звёздная ночь"`
+	// CreationYear можно не передавать, если задана Dating; без Dating датировка - точный год
+	CreationYear int                   `json:"creationYear" binding:"required_without=Dating,omitempty,gt=0,lte=2100" example:"1889"`
+	Dating       *ArtworkDatingRequest `json:"dating,omitempty"`
+	Technic      string                `json:"technic" binding:"required,max=100" example:"Масло, холст"`
+	Material     string                `json:"material" binding:"required,max=100" example:"Холст, масляные краски"`
+	// Size можно не передавать, если заданы Dimensions; без Dimensions размеры разбираются из Size
+	Size         string             `json:"size" binding:"required_without=Dimensions,max=50" example:"73.7 × 92.1 см"`
+	Dimensions   *DimensionsRequest `json:"dimensions,omitempty"`
+	AuthorID     string             `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	CollectionID string             `json:"collectionID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	// AuthorRole роль основного автора, по умолчанию author
+	AuthorRole string `json:"authorRole,omitempty" binding:"omitempty,oneof=author attributed workshop circle follower copy_after" example:"author"`
+	// CoAuthors дополнительные авторы в порядке показа
+	CoAuthors []AttributionRequest `json:"coAuthors,omitempty" binding:"omitempty,max=9,dive"`
 }
 
 type ArtworkUpdate struct {
-	Title        string `json:"title" binding:"required,max=255" example:"Звёздная ночь"`
-	CreationYear int    `json:"creationYear" binding:"required,gt=0,lte=2100" example:"1889"`
-	Technic      string `json:"technic" binding:"required,max=100" example:"Масло, холст"`
-	Material     string `json:"material" binding:"required,max=100" example:"Холст, масляные краски"`
-	Size         string `json:"size" binding:"required,max=50" example:"73.7 × 92.1 см"`
-	AuthorID     string `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
-	CollectionID string `json:"collectionID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	Title string `json:"title" binding:"required,max=255" example:"Звёздная ночь"`
+	// CreationYear можно не передавать, если задана Dating; без Dating датировка - точный год
+	CreationYear int                   `json:"creationYear" binding:"required_without=Dating,omitempty,gt=0,lte=2100" example:"1889"`
+	Dating       *ArtworkDatingRequest `json:"dating,omitempty"`
+	Technic      string                `json:"technic" binding:"required,max=100" example:"Масло, холст"`
+	Material     string                `json:"material" binding:"required,max=100" example:"Холст, масляные краски"`
+	// Size можно не передавать, если заданы Dimensions; без Dimensions размеры разбираются из Size
+	Size         string             `json:"size" binding:"required_without=Dimensions,max=50" example:"73.7 × 92.1 см"`
+	Dimensions   *DimensionsRequest `json:"dimensions,omitempty"`
+	AuthorID     string             `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	CollectionID string             `json:"collectionID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	// AuthorRole роль основного автора, пустая - без изменений
+	AuthorRole string `json:"authorRole,omitempty" binding:"omitempty,oneof=author attributed workshop circle follower copy_after" example:"author"`
+	// CoAuthors дополнительные авторы в порядке показа; не передан - без изменений, пустой список - удалить всех
+	CoAuthors []AttributionRequest `json:"coAuthors" binding:"omitempty,max=9,dive"`
 }
 
 type UpdateArtworkRequest struct {
-	ID           string `json:"id" binding:"required,uuid" example:"44a315d0-663c-4813-92a6-d7977c2f2aba"`
-	Title        string `json:"title" binding:"required,max=255" example:"Звёздная ночь"`
-	CreationYear int    `json:"creationYear" binding:"required,gt=0,lte=2100" example:"1889"`
-	Technic      string `json:"technic" binding:"required,max=100" example:"Масло, холст"`
-	Material     string `json:"material" binding:"required,max=100" example:"Холст, масляные краски"`
-	Size         string `json:"size" binding:"required,max=50" example:"73.7 × 92.1 см"`
-	AuthorID     string `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
-	CollectionID string `json:"collectionID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	ID    string `json:"id" binding:"required,uuid" example:"44a315d0-663c-4813-92a6-d7977c2f2aba"`
+	Title string `json:"title" binding:"required,max=255" example:"Звёздная ночь"`
+	// CreationYear можно не передавать, если задана Dating; без Dating датировка - точный год
+	CreationYear int                   `json:"creationYear" binding:"required_without=Dating,omitempty,gt=0,lte=2100" example:"1889"`
+	Dating       *ArtworkDatingRequest `json:"dating,omitempty"`
+	Technic      string                `json:"technic" binding:"required,max=100" example:"Масло, холст"`
+	Material     string                `json:"material" binding:"required,max=100" example:"Холст, масляные краски"`
+	// Size можно не передавать, если заданы Dimensions; без Dimensions размеры разбираются из Size
+	Size         string             `json:"size" binding:"required_without=Dimensions,max=50" example:"73.7 × 92.1 см"`
+	Dimensions   *DimensionsRequest `json:"dimensions,omitempty"`
+	AuthorID     string             `json:"authorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	CollectionID string             `json:"collectionID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	// AuthorRole роль основного автора, пустая - без изменений
+	AuthorRole string `json:"authorRole,omitempty" binding:"omitempty,oneof=author attributed workshop circle follower copy_after" example:"author"`
+	// CoAuthors дополнительные авторы в порядке показа; не передан - без изменений, пустой список - удалить всех
+	CoAuthors []AttributionRequest `json:"coAuthors" binding:"omitempty,max=9,dive"`
 }
 
 type DeleteArtworkRequest struct {
@@ -20,10 +20,61 @@ type UpdateAuthorRequest struct {
 	DeathYear int    `json:"deathYear,omitempty" binding:"omitempty,gtefield=BirthYear" example:"1890"` // Опциональное, >= BirthYear
 }
 
+// AuthorProfileRequest сведения об авторе для публичной страницы, все поля необязательные
+type AuthorProfileRequest struct {
+	Biography   string   `json:"biography" example:"Нидерландский художник-постимпрессионист."`
+	Nationality string   `json:"nationality" binding:"max=200" example:"Нидерланды"`
+	Movements   []string `json:"movements" binding:"max=20,dive,min=1,max=100" example:"Постимпрессионизм"`
+	BirthPlace  string   `json:"birthPlace" binding:"max=200" example:"Зюндерт"`
+	DeathPlace  string   `json:"deathPlace" binding:"max=200" example:"Овер-сюр-Уаз"`
+	// ULANID - идентификатор в Getty ULAN
+	ULANID string `json:"ulanID" example:"500115588"`
+	// WikidataID - идентификатор элемента Wikidata
+	WikidataID string `json:"wikidataID" example:"Q5582"`
+}
+
+// AuthorProfileResponse автор со сведениями для публичной страницы
+type AuthorProfileResponse struct {
+	AuthorResponse
+	Biography   string   `json:"biography" example:"Нидерландский художник-постимпрессионист."`
+	Nationality string   `json:"nationality" example:"Нидерланды"`
+	Movements   []string `json:"movements" example:"Постимпрессионизм"`
+	BirthPlace  string   `json:"birthPlace" example:"Зюндерт"`
+	DeathPlace  string   `json:"deathPlace" example:"Овер-сюр-Уаз"`
+	// PortraitURL - адрес портрета, пусто - портрета нет
+	PortraitURL string `json:"portraitURL,omitempty" example:"/images/authors/550e8400-e29b-41d4-a716-446655440000/portrait.jpg"`
+	ULANID      string `json:"ulanID,omitempty" example:"500115588"`
+	ULANURL     string `json:"ulanURL,omitempty" example:"https://vocab.getty.edu/page/ulan/500115588"`
+	WikidataID  string `json:"wikidataID,omitempty" example:"Q5582"`
+	WikidataURL string `json:"wikidataURL,omitempty" example:"https://www.wikidata.org/wiki/Q5582"`
+}
+
 type DeleteAuthorRequest struct {
 	ID string `json:"id" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
 }
 
+// AuthorDuplicateResponse пара авторов, которые, вероятно, являются одним человеком
+type AuthorDuplicateResponse struct {
+	Author    AuthorResponse `json:"author"`
+	Duplicate AuthorResponse `json:"duplicate"`
+	// Score - сходство от 0 до 1
+	Score float64 `json:"score" example:"0.925"`
+	// SameYears - годы жизни совпадают точно, иначе различаются не больше чем на 2 года
+	SameYears bool `json:"sameYears" example:"true"`
+}
+
+// MergeAuthorsRequest объединение дубля с основным автором: произведения дубля переходят к основному автору,
+// дубль удаляется
+type MergeAuthorsRequest struct {
+	SurvivorID  string `json:"survivorID" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	DuplicateID string `json:"duplicateID" binding:"required,uuid,nefield=SurvivorID" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type MergeAuthorsResponse struct {
+	// MovedArtworks - число произведений, у которых сменился автор
+	MovedArtworks int `json:"movedArtworks" example:"12"`
+}
+
 // type AuthorRequest struct {
 // 	ID        string `json:"id,omitempty" example:"ba1df957-ed5e-4694-8766-c5ec5806e5e7"`
 // 	Name      string `json:"name" binding:"required,min=2,max=100" example:"Винсент Ван Гог"`           // Обязательное, 2-100 символов
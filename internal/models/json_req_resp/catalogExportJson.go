@@ -0,0 +1,17 @@
+package jsonreqresp
+
+import "time"
+
+// ExportArtworkResponse строка выгрузки JSON Lines: произведение и мероприятия, в которых оно участвует
+type ExportArtworkResponse struct {
+	ArtworkResponse
+	Events []ExportEventResponse `json:"events"`
+}
+
+type ExportEventResponse struct {
+	ID        string    `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Title     string    `json:"title" example:"Выставка импрессионистов"`
+	DateBegin time.Time `json:"dateBegin" example:"2023-06-15T10:00:00Z"`
+	DateEnd   time.Time `json:"dateEnd" example:"2023-09-20T18:00:00Z"`
+	Address   string    `json:"address" example:"ул. Пречистенка, 12/2"`
+}
@@ -0,0 +1,64 @@
+package jsonreqresp
+
+// CatalogImportRequest строки импорта каталога. Произведения ссылаются на авторов и коллекции
+// по id или по имени (названию), в том числе на создаваемые этим же импортом
+type CatalogImportRequest struct {
+	Authors     []ImportAuthorRow     `json:"authors"`
+	Collections []ImportCollectionRow `json:"collections"`
+	Artworks    []ImportArtworkRow    `json:"artworks"`
+}
+
+// ImportAuthorRow автор для импорта. Автор с тем же id или именем не создается повторно
+type ImportAuthorRow struct {
+	ID        string `json:"id,omitempty" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	Name      string `json:"name" example:"Винсент Ван Гог"`
+	BirthYear int    `json:"birthYear" example:"1853"`
+	DeathYear int    `json:"deathYear,omitempty" example:"1890"`
+}
+
+// ImportCollectionRow коллекция для импорта. Коллекция с тем же id или названием не создается повторно
+type ImportCollectionRow struct {
+	ID    string `json:"id,omitempty" example:"aa1e8400-e29b-41d4-a716-446655441111"`
+	Title string `json:"title" example:"Постимпрессионизм"`
+}
+
+// ImportArtworkRow произведение для импорта. Author и Collection - id или имя (название).
+// Датировка задается годом CreationYear или периодом YearFrom-YearTo, размеры разбираются из Size
+type ImportArtworkRow struct {
+	ID            string `json:"id,omitempty" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Title         string `json:"title" example:"Звёздная ночь"`
+	Technic       string `json:"technic" example:"Масло, холст"`
+	Material      string `json:"material" example:"Холст, масляные краски"`
+	Size          string `json:"size" example:"73.7 × 92.1 см"`
+	CreationYear  int    `json:"creationYear,omitempty" example:"1889"`
+	YearFrom      int    `json:"yearFrom,omitempty" example:"0"`
+	YearTo        int    `json:"yearTo,omitempty" example:"0"`
+	DateQualifier string `json:"dateQualifier,omitempty" example:"exact"`
+	DatePrecision string `json:"datePrecision,omitempty" example:"year"`
+	Author        string `json:"author" example:"Винсент Ван Гог"`
+	AuthorRole    string `json:"authorRole,omitempty" example:"author"`
+	Collection    string `json:"collection" example:"Постимпрессионизм"`
+}
+
+type ImportCountsResponse struct {
+	Total   int `json:"total" example:"120"`
+	Created int `json:"created" example:"117"`
+	Matched int `json:"matched" example:"1"`
+	Failed  int `json:"failed" example:"2"`
+}
+
+type ImportRowErrorResponse struct {
+	Entity string `json:"entity" example:"artworks"`
+	Row    int    `json:"row" example:"14"`
+	Error  string `json:"error" example:"author not found by id or name"`
+}
+
+// ImportReportResponse итог импорта. Committed - записи сохранены; при ошибках не сохраняется ничего
+type ImportReportResponse struct {
+	DryRun      bool                     `json:"dryRun" example:"true"`
+	Committed   bool                     `json:"committed" example:"false"`
+	Authors     ImportCountsResponse     `json:"authors"`
+	Collections ImportCountsResponse     `json:"collections"`
+	Artworks    ImportCountsResponse     `json:"artworks"`
+	Errors      []ImportRowErrorResponse `json:"errors"`
+}
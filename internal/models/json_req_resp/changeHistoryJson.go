@@ -0,0 +1,24 @@
+package jsonreqresp
+
+import "time"
+
+type FieldChangeResponse struct {
+	Field string `json:"field" example:"title"`
+	Old   string `json:"old" example:"Звездная ночь"`
+	New   string `json:"new" example:"Звёздная ночь"`
+}
+
+type ChangeRecordResponse struct {
+	ID         string `json:"id" example:"ee2e8400-e29b-41d4-a716-446655445555"`
+	EntityType string `json:"entityType" example:"artwork"`
+	EntityID   string `json:"entityID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Version - номер версии после изменения, версия 0 - состояние до первого изменения
+	Version int    `json:"version" example:"3"`
+	Action  string `json:"action" example:"update"`
+	// RevertedTo - версия, к которой вернулись, только для action = revert
+	RevertedTo *int `json:"revertedTo,omitempty" example:"1"`
+	// ChangedBy - ID сотрудника или администратора, пусто для изменений без авторизации
+	ChangedBy string                `json:"changedBy,omitempty" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	ChangedAt time.Time             `json:"changedAt" example:"2024-03-01T10:00:00Z"`
+	Changes   []FieldChangeResponse `json:"changes"`
+}
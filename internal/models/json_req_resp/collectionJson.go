@@ -1,19 +1,44 @@
 package jsonreqresp
 
 type CollectionResponse struct {
-	ID    string `json:"id" example:"aa1e8400-e29b-41d4-a716-446655441111"`
-	Title string `json:"title" example:"Louvre Museum Collection"`
+	ID       string `json:"id" example:"aa1e8400-e29b-41d4-a716-446655441111"`
+	Title    string `json:"title" example:"Louvre Museum Collection"`
+	ParentID string `json:"parentId,omitempty" example:"bb1e8400-e29b-41d4-a716-446655442222"`
 }
 
 type AddCollectionRequest struct {
-	Title string `json:"title" binding:"required,min=2,max=255" example:"Музей современного искусства"` // Обязательное, 2-255 символов
+	Title    string `json:"title" binding:"required,min=2,max=255" example:"Музей современного искусства"`              // Обязательное, 2-255 символов
+	ParentID string `json:"parentId,omitempty" binding:"omitempty,uuid" example:"bb1e8400-e29b-41d4-a716-446655442222"` // Опциональное, родительская коллекция
 }
 
 type UpdateCollectionRequest struct {
-	ID    string `json:"id" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
-	Title string `json:"title" binding:"required,min=2,max=255" example:"Музей современного искусства"` // Обязательное, 2-255 символов
+	ID       string `json:"id" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	Title    string `json:"title" binding:"required,min=2,max=255" example:"Музей современного искусства"`              // Обязательное, 2-255 символов
+	ParentID string `json:"parentId,omitempty" binding:"omitempty,uuid" example:"bb1e8400-e29b-41d4-a716-446655442222"` // Опциональное, пусто - корневая коллекция
 }
 
 type DeleteCollectionRequest struct {
 	ID string `json:"id" binding:"required,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
 }
+
+// StatBucketResponse значение и число произведений с ним
+type StatBucketResponse struct {
+	Value string `json:"value" example:"a11e8400-e29b-41d4-a716-446655440000"`
+	Label string `json:"label" example:"Винсент Ван Гог"`
+	Count int    `json:"count" example:"12"`
+}
+
+// CollectionStatsResponse статистика произведений коллекции, произведения в корзине не учитываются
+type CollectionStatsResponse struct {
+	Collection             CollectionResponse `json:"collection"`
+	IncludesSubcollections bool               `json:"includesSubcollections" example:"true"`
+	SubcollectionCount     int                `json:"subcollectionCount" example:"3"`
+	ArtworkCount           int                `json:"artworkCount" example:"42"`
+	// ExhibitedArtworkCount число произведений, участвовавших хотя бы в одном мероприятии
+	ExhibitedArtworkCount int                  `json:"exhibitedArtworkCount" example:"17"`
+	Authors               []StatBucketResponse `json:"authors"`
+	Centuries             []StatBucketResponse `json:"centuries"`
+	Technics              []StatBucketResponse `json:"technics"`
+	// Exhibitions мероприятия с числом показанных на них произведений коллекции
+	Exhibitions []StatBucketResponse `json:"exhibitions"`
+}
@@ -0,0 +1,41 @@
+package jsonreqresp
+
+import "time"
+
+type ConditionPhotoResponse struct {
+	ID     string `json:"id" example:"cc2e8400-e29b-41d4-a716-446655443333"`
+	Format string `json:"format" example:"jpeg"`
+	URL    string `json:"url" example:"/images/conditions/550e8400-e29b-41d4-a716-446655440000/dd2e8400-e29b-41d4-a716-446655444444/cc2e8400-e29b-41d4-a716-446655443333.jpg"`
+}
+
+type ConditionReportResponse struct {
+	ID         string    `json:"id" example:"dd2e8400-e29b-41d4-a716-446655444444"`
+	ArtworkID  string    `json:"artworkID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CheckedAt  time.Time `json:"checkedAt" example:"2024-03-01T10:00:00Z"`
+	Examiner   string    `json:"examiner" example:"Анна Смирнова"`
+	Grade      string    `json:"grade" example:"poor"`
+	GradeLabel string    `json:"gradeLabel" example:"плохое"`
+	Notes      string    `json:"notes,omitempty" example:"Кракелюр в левом верхнем углу"`
+	// Treatment - описание реставрационных работ, пусто для обычного осмотра
+	Treatment    string     `json:"treatment,omitempty" example:"Укрепление красочного слоя"`
+	TreatmentEnd *time.Time `json:"treatmentEnd,omitempty" example:"2024-04-15T18:00:00Z"`
+	// TreatmentOpen - реставрация не завершена, произведение недоступно для мероприятий
+	TreatmentOpen  bool                     `json:"treatmentOpen" example:"true"`
+	NeedsAttention bool                     `json:"needsAttention" example:"true"`
+	Photos         []ConditionPhotoResponse `json:"photos"`
+}
+
+type ConditionReportRequest struct {
+	// CheckedAt - дата осмотра или начала реставрации, по умолчанию текущее время
+	CheckedAt    time.Time  `json:"checkedAt" example:"2024-03-01T10:00:00Z"`
+	Examiner     string     `json:"examiner" binding:"required,max=255" example:"Анна Смирнова"`
+	Grade        string     `json:"grade" binding:"required,oneof=excellent good fair poor critical" example:"poor"`
+	Notes        string     `json:"notes" binding:"max=4000" example:"Кракелюр в левом верхнем углу"`
+	Treatment    string     `json:"treatment" binding:"max=4000" example:"Укрепление красочного слоя"`
+	TreatmentEnd *time.Time `json:"treatmentEnd" example:"2024-04-15T18:00:00Z"`
+}
+
+type CloseTreatmentRequest struct {
+	// TreatmentEnd - дата завершения реставрации, по умолчанию текущее время
+	TreatmentEnd time.Time `json:"treatmentEnd" example:"2024-04-15T18:00:00Z"`
+}
@@ -16,6 +16,7 @@ type EventResponse struct {
 	EmployeeID string    `json:"employeeID" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
 	CntTickets int       `json:"cntTickets" example:"150"`
 	Valid      bool      `json:"valid" example:"true"`
+	Status     string    `json:"status" example:"approved"`
 	ArtworkIDs []string  `json:"artworkIDs"`
 }
 
@@ -48,6 +49,8 @@ type AddEventRequest struct {
 	CanVisit   *bool     `json:"canVisit" binding:"required" example:"true"`
 	CntTickets *int      `json:"cntTickets" binding:"required,min=0" example:"100"`
 	ArtworkIDs []string  `json:"artworkIDs"`
+	// EmployeeID создатель; указывается администратором, сотрудник создает только от своего имени
+	EmployeeID string `json:"employeeID" binding:"omitempty,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
 }
 
 type UpdateEventRequest struct {
@@ -68,3 +71,68 @@ type DeleteEventRequest struct {
 type ConArtworkEventRequest struct {
 	ArtworkID string `json:"artworkID" binding:"required,uuid"`
 }
+
+type EventTemplateResponse struct {
+	ID         string   `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Name       string   `json:"name" example:"Школьная экскурсия"`
+	Title      string   `json:"title" example:"Экскурсия для школьников"`
+	Address    string   `json:"address" example:"ул. Пречистенка, 12/2"`
+	CanVisit   bool     `json:"canVisit" example:"true"`
+	EmployeeID string   `json:"employeeID" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	CntTickets int      `json:"cntTickets" example:"30"`
+	ArtworkIDs []string `json:"artworkIDs"`
+}
+
+type EventCopy struct {
+	Title      string
+	DateBegin  time.Time
+	DateEnd    time.Time
+	EmployeeID uuid.UUID
+}
+
+type CloneEventRequest struct {
+	Title     string    `json:"title" binding:"omitempty,max=255" example:"Ночная выставка"`
+	DateBegin time.Time `json:"dateBegin" binding:"required" example:"2023-06-15T10:00:00Z"`
+	DateEnd   time.Time `json:"dateEnd" binding:"required" example:"2023-09-20T18:00:00Z"`
+	// EmployeeID создатель; указывается администратором, сотрудник создает только от своего имени
+	EmployeeID string `json:"employeeID" binding:"omitempty,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+}
+
+type SaveEventTemplateRequest struct {
+	Name string `json:"name" binding:"required,max=255" example:"Школьная экскурсия"`
+	// EmployeeID создатель; указывается администратором, сотрудник создает только от своего имени
+	EmployeeID string `json:"employeeID" binding:"omitempty,uuid" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+}
+
+// CreatorID возвращает создателя, указанный в запросе, или uuid.Nil, если он не указан
+func CreatorID(employeeID string) uuid.UUID {
+	if employeeID == "" {
+		return uuid.Nil
+	}
+	return uuid.MustParse(employeeID)
+}
+
+type EventIDResponse struct {
+	ID string `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+}
+
+type EventOrganiserRequest struct {
+	EmployeeID string `json:"employeeID" binding:"required,uuid" example:"aa1e8400-e29b-41d4-a716-446655441111"`
+}
+
+type EventOrganisersResponse struct {
+	OrganiserIDs []string `json:"organiserIDs"`
+}
+
+type ReviewEventRequest struct {
+	Comment string `json:"comment" binding:"max=1000" example:"Уточните адрес проведения"`
+}
+
+type EventReviewResponse struct {
+	ID        string    `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	EventID   string    `json:"eventID" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	ActorID   string    `json:"actorID" example:"cfd9ff5d-cb37-407c-b043-288a482e9239"`
+	Status    string    `json:"status" example:"rejected"`
+	Comment   string    `json:"comment" example:"Уточните адрес проведения"`
+	CreatedAt time.Time `json:"createdAt" example:"2023-06-15T10:00:00Z"`
+}
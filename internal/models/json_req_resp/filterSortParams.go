@@ -2,7 +2,9 @@ package jsonreqresp
 
 import (
 	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -12,6 +14,67 @@ type ArtworkFilter struct {
 	AuthorName string
 	Collection string
 	EventID    uuid.UUID
+	// Query полнотекстовый запрос по названию, технике, материалу, автору и коллекции
+	Query string
+	// фасетные фильтры: значения одного фасета объединяются через ИЛИ, разные фасеты - через И
+	Technics      []string
+	Materials     []string
+	Centuries     []int
+	AuthorIDs     uuid.UUIDs
+	CollectionIDs uuid.UUIDs
+	// IncludeSubcollections к CollectionIDs добавляются все вложенные в них коллекции
+	IncludeSubcollections bool
+	// TagIDs теги словаря: подходят произведения, отмеченные любым из тегов или их потомков
+	TagIDs uuid.UUIDs
+	// диапазон года создания включительно, 0 - граница не задана
+	YearFrom int
+	YearTo   int
+	// диапазоны размеров в сантиметрах и веса в килограммах,
+	// произведения без структурированного размера под такие фильтры не подходят
+	Height DimensionRange
+	Width  DimensionRange
+	Depth  DimensionRange
+	Weight DimensionRange
+	// период последнего изменения записи [UpdatedFrom, UpdatedBefore), нулевое время - граница не задана
+	UpdatedFrom   time.Time
+	UpdatedBefore time.Time
+}
+
+// DimensionRange диапазон значений включительно, 0 - граница не задана
+type DimensionRange struct {
+	Min float64
+	Max float64
+}
+
+func (r DimensionRange) IsSet() bool {
+	return r.Min > 0 || r.Max > 0
+}
+
+// MaxSearchQueryLen максимальная длина полнотекстового запроса
+const MaxSearchQueryLen = 255
+
+var (
+	ErrSearchQueryTooLong    = errors.New("search query exceeds maximum length (255 chars)")
+	ErrArtworkFilterParam    = errors.New("invalid artwork filter parameter")
+	ErrArtworkYearRange      = errors.New("year_from must not exceed year_to")
+	ErrArtworkDimensionRange = errors.New("dimension filter min must not exceed max")
+)
+
+// Check нормализует поисковый запрос и проверяет диапазоны года создания и размеров
+func (f *ArtworkFilter) Check() error {
+	f.Query = strings.TrimSpace(f.Query)
+	if utf8.RuneCountInString(f.Query) > MaxSearchQueryLen {
+		return ErrSearchQueryTooLong
+	}
+	if f.YearFrom > 0 && f.YearTo > 0 && f.YearFrom > f.YearTo {
+		return ErrArtworkYearRange
+	}
+	for _, r := range []DimensionRange{f.Height, f.Width, f.Depth, f.Weight} {
+		if r.Min > 0 && r.Max > 0 && r.Min > r.Max {
+			return ErrArtworkDimensionRange
+		}
+	}
+	return nil
 }
 
 var (
@@ -24,17 +87,38 @@ type EventFilter struct {
 	DateEnd   time.Time
 	CanVisit  string
 	Valid     string
+	Status    string // этап согласования; пустое значение - любой
 }
 
 const (
-	TitleSortFieldArtwork        = "title"
-	AuthorNameSortFieldArtwork   = "author_name"
-	CreationYearSortFieldArtwork = "creationYear"
-	ASCDirection                 = "ASC"
-	DESCDirection                = "DESC"
+	TitleSortFieldArtwork           = "title"
+	AuthorNameSortFieldArtwork      = "author_name"
+	CreationYearSortFieldArtwork    = "creationYear"
+	CollectionTitleSortFieldArtwork = "collection_title"
+	// RelevanceSortFieldArtwork сортировка по релевантности полнотекстовому запросу
+	RelevanceSortFieldArtwork = "relevance"
+	// сортировка по структурированному размеру, произведения без него считаются нулевого размера
+	HeightSortFieldArtwork = "height"
+	WidthSortFieldArtwork  = "width"
+	DepthSortFieldArtwork  = "depth"
+	WeightSortFieldArtwork = "weight"
+	ASCDirection           = "ASC"
+	DESCDirection          = "DESC"
 )
 
 type ArtworkSortOps struct {
-	Field     string `json:"field,omitempty" binding:"omitempty,oneof=title author_name creationYear" example:""` // обязательное, одно из значений
-	Direction string `json:"direction,omitempty" binding:"omitempty,oneof=ASC DESC" example:""`                   // обязательное, только ASC или DESC
+	Field     string `json:"field,omitempty" binding:"omitempty,oneof=title author_name creationYear collection_title relevance height width depth weight" example:""` // обязательное, одно из значений
+	Direction string `json:"direction,omitempty" binding:"omitempty,oneof=ASC DESC" example:""`                                                                        // обязательное, только ASC или DESC
+}
+
+const (
+	TitleSortFieldEvent            = "title"
+	DateBeginSortFieldEvent        = "dateBegin"
+	DateEndSortFieldEvent          = "dateEnd"
+	TicketsAvailableSortFieldEvent = "tickets_available"
+)
+
+type EventSortOps struct {
+	Field     string `json:"field,omitempty" binding:"omitempty,oneof=title dateBegin dateEnd tickets_available" example:""`
+	Direction string `json:"direction,omitempty" binding:"omitempty,oneof=ASC DESC" example:""`
 }
@@ -0,0 +1,77 @@
+package jsonreqresp
+
+// IIIFContext контекст JSON-LD IIIF Presentation API 3.0
+const IIIFContext = "http://iiif.io/api/presentation/3/context.json"
+
+// IIIFContentType тип ответа с документом IIIF Presentation 3.0
+const IIIFContentType = `application/ld+json;profile="` + IIIFContext + `"`
+
+// IIIFLangMap строки по языкам; "none" - значение без языка (имена, годы, размеры)
+type IIIFLangMap map[string][]string
+
+type IIIFMetadataEntry struct {
+	Label IIIFLangMap `json:"label"`
+	Value IIIFLangMap `json:"value"`
+}
+
+// IIIFReference ссылка на манифест или коллекцию IIIF
+type IIIFReference struct {
+	ID    string      `json:"id" example:"http://localhost:8080/api/v1/iiif/artworks/550e8400-e29b-41d4-a716-446655440000/manifest"`
+	Type  string      `json:"type" example:"Manifest"`
+	Label IIIFLangMap `json:"label,omitempty"`
+}
+
+// IIIFImage файл изображения: содержимое холста или миниатюра
+type IIIFImage struct {
+	ID     string `json:"id" example:"http://localhost:8080/images/artworks/550e8400-e29b-41d4-a716-446655440000/660e8400-e29b-41d4-a716-446655441111/original.jpg"`
+	Type   string `json:"type" example:"Image"`
+	Format string `json:"format" example:"image/jpeg"`
+	Width  int    `json:"width,omitempty" example:"1920"`
+	Height int    `json:"height,omitempty" example:"1080"`
+}
+
+type IIIFAnnotation struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type" example:"Annotation"`
+	Motivation string    `json:"motivation" example:"painting"`
+	Body       IIIFImage `json:"body"`
+	Target     string    `json:"target"`
+}
+
+type IIIFAnnotationPage struct {
+	ID    string           `json:"id"`
+	Type  string           `json:"type" example:"AnnotationPage"`
+	Items []IIIFAnnotation `json:"items"`
+}
+
+type IIIFCanvas struct {
+	ID        string               `json:"id"`
+	Type      string               `json:"type" example:"Canvas"`
+	Label     IIIFLangMap          `json:"label,omitempty"`
+	Width     int                  `json:"width" example:"1920"`
+	Height    int                  `json:"height" example:"1080"`
+	Thumbnail []IIIFImage          `json:"thumbnail,omitempty"`
+	Items     []IIIFAnnotationPage `json:"items"`
+}
+
+// IIIFManifest манифест IIIF Presentation 3.0 произведения
+type IIIFManifest struct {
+	Context   string              `json:"@context"`
+	ID        string              `json:"id"`
+	Type      string              `json:"type" example:"Manifest"`
+	Label     IIIFLangMap         `json:"label"`
+	Metadata  []IIIFMetadataEntry `json:"metadata"`
+	NavDate   string              `json:"navDate,omitempty" example:"1889-01-01T00:00:00Z"`
+	Thumbnail []IIIFImage         `json:"thumbnail,omitempty"`
+	PartOf    []IIIFReference     `json:"partOf,omitempty"`
+	Items     []IIIFCanvas        `json:"items"`
+}
+
+// IIIFCollection коллекция IIIF: манифесты произведений коллекции музея или список коллекций
+type IIIFCollection struct {
+	Context string          `json:"@context"`
+	ID      string          `json:"id"`
+	Type    string          `json:"type" example:"Collection"`
+	Label   IIIFLangMap     `json:"label"`
+	Items   []IIIFReference `json:"items"`
+}
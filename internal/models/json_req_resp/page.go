@@ -0,0 +1,133 @@
+package jsonreqresp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// CursorTimeLayout - формат дат в значении курсора (совпадает с текстовым представлением в БД)
+	CursorTimeLayout = "2006-01-02 15:04:05.999999999"
+
+	TotalCountHeader = "X-Total-Count"
+	NextCursorHeader = "X-Next-Cursor"
+)
+
+var (
+	ErrPageCursor = errors.New("invalid page cursor")
+	ErrPageLimit  = errors.New("invalid page limit")
+)
+
+// PageRequest - параметры keyset-пагинации.
+// Cursor - непрозрачная строка из PageInfo.NextCursor предыдущей страницы.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// Total считается только для первой страницы (без курсора), HasTotal показывает, что он посчитан
+type PageInfo struct {
+	Total      int    `json:"total" example:"120"`
+	HasTotal   bool   `json:"-"`
+	Limit      int    `json:"limit" example:"20"`
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJ2IjoiMTg4OSIsImlkIjoiLi4uIn0"`
+}
+
+// PageCursor - значение поля сортировки и id последней записи страницы
+type PageCursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// NewPageRequest разбирает параметры запроса limit и cursor
+func NewPageRequest(limit string, cursor string) (PageRequest, error) {
+	page := PageRequest{Cursor: cursor}
+	if limit != "" {
+		v, err := strconv.Atoi(limit)
+		if err != nil || v < 0 {
+			return PageRequest{}, ErrPageLimit
+		}
+		page.Limit = v
+	}
+	if _, err := page.DecodeCursor(); err != nil {
+		return PageRequest{}, err
+	}
+	return page, nil
+}
+
+// PageLimit возвращает размер страницы с учетом значения по умолчанию и ограничения сверху
+func (p *PageRequest) PageLimit() int {
+	if p == nil || p.Limit <= 0 {
+		return DefaultPageSize
+	}
+	if p.Limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return p.Limit
+}
+
+// IsFirst - запрошена первая страница, курсор не передан
+func (p *PageRequest) IsFirst() bool {
+	return p == nil || p.Cursor == ""
+}
+
+func (p *PageRequest) DecodeCursor() (*PageCursor, error) {
+	if p == nil || p.Cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return nil, ErrPageCursor
+	}
+	var cursor PageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, ErrPageCursor
+	}
+	return &cursor, nil
+}
+
+func (c *PageCursor) IntValue() (int, error) {
+	v, err := strconv.Atoi(c.Value)
+	if err != nil {
+		return 0, ErrPageCursor
+	}
+	return v, nil
+}
+
+func (c *PageCursor) FloatValue() (float64, error) {
+	v, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return 0, ErrPageCursor
+	}
+	return v, nil
+}
+
+func (c *PageCursor) TimeValue() (time.Time, error) {
+	v, err := time.Parse(CursorTimeLayout, c.Value)
+	if err != nil {
+		return time.Time{}, ErrPageCursor
+	}
+	return v, nil
+}
+
+func EncodeCursor(value string, id uuid.UUID) string {
+	raw, _ := json.Marshal(PageCursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// NormSortDirection приводит направление сортировки к ASC/DESC, по умолчанию ASC
+func NormSortDirection(direction string) string {
+	if strings.ToUpper(direction) == DESCDirection {
+		return DESCDirection
+	}
+	return ASCDirection
+}
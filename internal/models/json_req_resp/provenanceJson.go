@@ -0,0 +1,37 @@
+package jsonreqresp
+
+type ProvenanceEntryResponse struct {
+	ID       string `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Position int    `json:"position" example:"0"`
+	Owner    string `json:"owner" example:"Сергей Щукин"`
+	// OwnerUncertain - владение предполагаемое, не подтверждено документами
+	OwnerUncertain bool   `json:"ownerUncertain" example:"false"`
+	Location       string `json:"location" example:"Москва"`
+	PeriodFrom     int    `json:"periodFrom,omitempty" example:"1908"`
+	PeriodTo       int    `json:"periodTo,omitempty" example:"1918"`
+	DateQualifier  string `json:"dateQualifier" example:"circa"`
+	// Period - период владения для показа, например "ок. 1908–1918"
+	Period         string `json:"period" example:"ок. 1908–1918"`
+	TransferMethod string `json:"transferMethod" example:"purchase"`
+	TransferLabel  string `json:"transferLabel" example:"покупка"`
+	Sources        string `json:"sources,omitempty" example:"Опись собрания, 1913"`
+	Notes          string `json:"notes,omitempty" example:""`
+	IsPublic       bool   `json:"isPublic" example:"true"`
+}
+
+type ProvenanceEntryRequest struct {
+	Owner          string `json:"owner" binding:"required,max=255" example:"Сергей Щукин"`
+	OwnerUncertain bool   `json:"ownerUncertain" example:"false"`
+	Location       string `json:"location" binding:"max=255" example:"Москва"`
+	PeriodFrom     int    `json:"periodFrom" binding:"gte=0,lte=2100" example:"1908"`
+	PeriodTo       int    `json:"periodTo" binding:"gte=0,lte=2100" example:"1918"`
+	DateQualifier  string `json:"dateQualifier" binding:"omitempty,oneof=exact circa before after" example:"circa"`
+	TransferMethod string `json:"transferMethod" binding:"omitempty,oneof=purchase auction gift bequest inheritance exchange commission confiscation restitution unknown" example:"purchase"`
+	Sources        string `json:"sources" binding:"max=2000" example:"Опись собрания, 1913"`
+	Notes          string `json:"notes" binding:"max=2000" example:""`
+	IsPublic       bool   `json:"isPublic" example:"true"`
+}
+
+type ReorderProvenanceRequest struct {
+	EntryIDs []string `json:"entryIDs" binding:"required,min=1,dive,uuid" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+}
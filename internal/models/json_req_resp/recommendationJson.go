@@ -0,0 +1,15 @@
+package jsonreqresp
+
+// RecommendationReasonResponse причина рекомендации и ее вклад в оценку
+type RecommendationReasonResponse struct {
+	Kind   string  `json:"kind" example:"author"` // author, collection, technic, material, period, events, favourites, yourFavourites
+	Label  string  `json:"label" example:"Тот же автор"`
+	Weight float64 `json:"weight" example:"5"`
+}
+
+// SimilarArtworkResponse рекомендованное произведение, причины упорядочены по вкладу в оценку
+type SimilarArtworkResponse struct {
+	Artwork ArtworkResponse                `json:"artwork"`
+	Score   float64                        `json:"score" example:"8.5"`
+	Reasons []RecommendationReasonResponse `json:"reasons"`
+}
@@ -0,0 +1,99 @@
+package jsonreqresp
+
+// SchemaOrgContext контекст JSON-LD словаря schema.org
+const SchemaOrgContext = "https://schema.org"
+
+// JSONLDContentType тип ответа с документом JSON-LD
+const JSONLDContentType = "application/ld+json"
+
+// доступность предложения по словарю schema.org ItemAvailability
+const (
+	SchemaOrgInStock             = "https://schema.org/InStock"
+	SchemaOrgLimitedAvailability = "https://schema.org/LimitedAvailability"
+	SchemaOrgSoldOut             = "https://schema.org/SoldOut"
+	SchemaOrgDiscontinued        = "https://schema.org/Discontinued"
+)
+
+// SchemaOrgQuantity значение с единицей измерения UN/CEFACT (CMT - сантиметр, KGM - килограмм)
+type SchemaOrgQuantity struct {
+	Type     string  `json:"@type" example:"QuantitativeValue"`
+	Value    float64 `json:"value" example:"73.7"`
+	UnitCode string  `json:"unitCode,omitempty" example:"CMT"`
+}
+
+// SchemaOrgPerson автор; сведения профиля и ссылки заполняются только на странице автора
+type SchemaOrgPerson struct {
+	Context     string              `json:"@context,omitempty" example:"https://schema.org"`
+	Type        string              `json:"@type" example:"Person"`
+	ID          string              `json:"@id,omitempty" example:"https://museum.example.org/museum/authors/550e8400-e29b-41d4-a716-446655440000"`
+	URL         string              `json:"url,omitempty"`
+	Identifier  string              `json:"identifier" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string              `json:"name" example:"Винсент Ван Гог"`
+	BirthDate   string              `json:"birthDate,omitempty" example:"1853"`
+	DeathDate   string              `json:"deathDate,omitempty" example:"1890"`
+	BirthPlace  *SchemaOrgReference `json:"birthPlace,omitempty"`
+	DeathPlace  *SchemaOrgReference `json:"deathPlace,omitempty"`
+	Nationality *SchemaOrgReference `json:"nationality,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Image       string              `json:"image,omitempty"`
+	// SameAs - страницы автора в ULAN и Wikidata
+	SameAs []string `json:"sameAs,omitempty" example:"https://www.wikidata.org/wiki/Q5582"`
+}
+
+// SchemaOrgReference краткая ссылка на сущность: коллекцию, место, произведение мероприятия
+type SchemaOrgReference struct {
+	Type    string `json:"@type" example:"Collection"`
+	ID      string `json:"@id,omitempty"`
+	Name    string `json:"name,omitempty" example:"Постимпрессионизм"`
+	Address string `json:"address,omitempty"`
+}
+
+// SchemaOrgVisualArtwork произведение в словаре schema.org
+type SchemaOrgVisualArtwork struct {
+	Context    string            `json:"@context,omitempty"`
+	Type       string            `json:"@type" example:"VisualArtwork"`
+	ID         string            `json:"@id" example:"http://localhost:8080/museum/artworks/550e8400-e29b-41d4-a716-446655440000"`
+	URL        string            `json:"url"`
+	Identifier string            `json:"identifier" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string            `json:"name" example:"Звёздная ночь"`
+	Creator    []SchemaOrgPerson `json:"creator"`
+	// DateCreated год создания, если он известен точно; иначе период указывается в TemporalCoverage
+	DateCreated      string              `json:"dateCreated,omitempty" example:"1889"`
+	TemporalCoverage string              `json:"temporalCoverage,omitempty" example:"1884/1885"`
+	ArtMedium        string              `json:"artMedium,omitempty" example:"Масло"`
+	ArtworkSurface   string              `json:"artworkSurface,omitempty" example:"Холст"`
+	Height           *SchemaOrgQuantity  `json:"height,omitempty"`
+	Width            *SchemaOrgQuantity  `json:"width,omitempty"`
+	Depth            *SchemaOrgQuantity  `json:"depth,omitempty"`
+	Weight           *SchemaOrgQuantity  `json:"weight,omitempty"`
+	Image            []string            `json:"image,omitempty"`
+	ThumbnailURL     string              `json:"thumbnailUrl,omitempty"`
+	Keywords         []string            `json:"keywords,omitempty"`
+	IsPartOf         *SchemaOrgReference `json:"isPartOf,omitempty"`
+}
+
+// SchemaOrgOffer предложение билетов на мероприятие
+type SchemaOrgOffer struct {
+	Type           string             `json:"@type" example:"Offer"`
+	URL            string             `json:"url"`
+	Availability   string             `json:"availability" example:"https://schema.org/InStock"`
+	InventoryLevel *SchemaOrgQuantity `json:"inventoryLevel,omitempty"`
+	ValidThrough   string             `json:"validThrough,omitempty" example:"2026-06-30T18:00:00Z"`
+}
+
+// SchemaOrgExhibitionEvent мероприятие в словаре schema.org
+type SchemaOrgExhibitionEvent struct {
+	Context             string               `json:"@context,omitempty"`
+	Type                string               `json:"@type" example:"ExhibitionEvent"`
+	ID                  string               `json:"@id" example:"http://localhost:8080/museum/events/550e8400-e29b-41d4-a716-446655440000"`
+	URL                 string               `json:"url"`
+	Identifier          string               `json:"identifier" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name                string               `json:"name" example:"Импрессионисты"`
+	StartDate           string               `json:"startDate" example:"2026-06-01T10:00:00Z"`
+	EndDate             string               `json:"endDate" example:"2026-06-30T18:00:00Z"`
+	EventStatus         string               `json:"eventStatus" example:"https://schema.org/EventScheduled"`
+	EventAttendanceMode string               `json:"eventAttendanceMode" example:"https://schema.org/OfflineEventAttendanceMode"`
+	Location            SchemaOrgReference   `json:"location"`
+	Offers              []SchemaOrgOffer     `json:"offers,omitempty"`
+	WorkFeatured        []SchemaOrgReference `json:"workFeatured,omitempty"`
+}
@@ -0,0 +1,46 @@
+package jsonreqresp
+
+type TagResponse struct {
+	ID        string   `json:"id" example:"7d1e8400-e29b-41d4-a716-446655440000"`
+	Name      string   `json:"name" example:"Портрет"`
+	Kind      string   `json:"kind" example:"subject"`
+	KindLabel string   `json:"kindLabel" example:"Сюжет"`
+	ParentID  string   `json:"parentId,omitempty" example:"8e1e8400-e29b-41d4-a716-446655440000"`
+	Synonyms  []string `json:"synonyms" example:"портретная живопись"`
+}
+
+// TagNodeResponse тег с потомками и числом отмеченных произведений
+type TagNodeResponse struct {
+	TagResponse
+	Count    int               `json:"count" example:"12"`
+	Children []TagNodeResponse `json:"children"`
+}
+
+// TagPageResponse тег с цепочкой родителей и непосредственными потомками
+type TagPageResponse struct {
+	Tag       TagResponse       `json:"tag"`
+	Ancestors []TagResponse     `json:"ancestors"`
+	Children  []TagNodeResponse `json:"children"`
+	Count     int               `json:"count" example:"12"`
+}
+
+type TagRequest struct {
+	Name     string   `json:"name" binding:"required,min=1,max=100" example:"Портрет"`                                        // Обязательное, 1-100 символов
+	Kind     string   `json:"kind" binding:"required,oneof=subject movement keyword" example:"subject"`                       // subject, movement, keyword
+	ParentID string   `json:"parentId,omitempty" binding:"omitempty,uuid" example:"8e1e8400-e29b-41d4-a716-446655440000"`     // Опциональное, родительский тег того же вида
+	Synonyms []string `json:"synonyms,omitempty" binding:"omitempty,max=20,dive,min=1,max=100" example:"портретная живопись"` // До 20 синонимов
+}
+
+type UpdateTagRequest struct {
+	ID string `json:"id" binding:"required,uuid" example:"7d1e8400-e29b-41d4-a716-446655440000"`
+	TagRequest
+}
+
+type DeleteTagRequest struct {
+	ID string `json:"id" binding:"required,uuid" example:"7d1e8400-e29b-41d4-a716-446655440000"`
+}
+
+// ArtworkTagsRequest полный набор тегов произведения, заменяет текущий
+type ArtworkTagsRequest struct {
+	TagIDs []string `json:"tagIds" binding:"max=50,dive,uuid" example:"7d1e8400-e29b-41d4-a716-446655440000"` // До 50 тегов
+}
@@ -0,0 +1,42 @@
+package jsonreqresp
+
+import "time"
+
+// параметры корзины
+const (
+	// EntityTypeParamTrash тип записей корзины: artwork, author или collection, пусто - все
+	EntityTypeParamTrash = "entityType"
+)
+
+type TrashItemResponse struct {
+	EntityType string    `json:"entityType" example:"artwork"`
+	ID         string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title      string    `json:"title" example:"Звёздная ночь"`
+	DeletedAt  time.Time `json:"deletedAt" example:"2026-03-01T10:00:00Z"`
+	// PurgeAt - время, после которого запись будет удалена окончательно
+	PurgeAt time.Time `json:"purgeAt" example:"2026-03-31T10:00:00Z"`
+}
+
+type RestoreTrashItemRequest struct {
+	EntityType string `json:"entityType" binding:"required,oneof=artwork author collection" example:"artwork"`
+	ID         string `json:"id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// DependentRecordResponse запись, из-за которой нельзя удалить или восстановить другую запись
+type DependentRecordResponse struct {
+	EntityType string `json:"entityType" example:"event"`
+	ID         string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title      string `json:"title" example:"Импрессионисты"`
+	// InTrash - запись находится в корзине и должна быть восстановлена первой
+	InTrash bool `json:"inTrash" example:"false"`
+}
+
+// DependencyErrorResponse ответ 409 со связанными записями, которые мешают удалению или восстановлению
+type DependencyErrorResponse struct {
+	Error    string                    `json:"error" example:"record is blocked by dependent records"`
+	Blocking []DependentRecordResponse `json:"blocking"`
+}
+
+type PurgeTrashResponse struct {
+	Purged []TrashItemResponse `json:"purged"`
+}
@@ -0,0 +1,66 @@
+package jsonreqresp
+
+import "time"
+
+// FavouriteRequest произведение или мероприятие для избранного
+type FavouriteRequest struct {
+	EntityType string `json:"entityType" binding:"required,oneof=artwork event" example:"artwork"`             // artwork или event
+	EntityID   string `json:"entityId" binding:"required,uuid" example:"7d1e8400-e29b-41d4-a716-446655440000"` // ID произведения или мероприятия
+}
+
+// FavouritesResponse избранное пользователя, сначала добавленные последними.
+// Удаленные записи и скрытые от посетителей мероприятия не показываются
+type FavouritesResponse struct {
+	Artworks []ArtworkResponse `json:"artworks"`
+	Events   []EventResponse   `json:"events"`
+}
+
+type UserListRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=100" example:"Мои импрессионисты"`                 // Обязательное, 1-100 символов
+	Description string `json:"description,omitempty" binding:"max=2000" example:"Любимые работы из залов XIX века"` // До 2000 символов
+	IsPublic    bool   `json:"isPublic" example:"true"`                                                             // Подборка доступна по публичной ссылке
+}
+
+type UserListItemRequest struct {
+	EntityType string `json:"entityType" binding:"required,oneof=artwork event" example:"artwork"`             // artwork или event
+	EntityID   string `json:"entityId" binding:"required,uuid" example:"7d1e8400-e29b-41d4-a716-446655440000"` // ID произведения или мероприятия
+	Note       string `json:"note,omitempty" binding:"max=1000" example:"Посмотреть вживую"`                   // До 1000 символов
+}
+
+// UserListItemsRequest полный упорядоченный набор элементов подборки, заменяет текущий
+type UserListItemsRequest struct {
+	Items []UserListItemRequest `json:"items" binding:"max=200,dive"` // До 200 элементов
+}
+
+type UserListIDResponse struct {
+	ID string `json:"id" example:"5c1e8400-e29b-41d4-a716-446655440000"`
+}
+
+// UserListSummaryResponse подборка без элементов, shareUrl - только у публичной подборки
+type UserListSummaryResponse struct {
+	ID          string    `json:"id" example:"5c1e8400-e29b-41d4-a716-446655440000"`
+	Title       string    `json:"title" example:"Мои импрессионисты"`
+	Description string    `json:"description,omitempty" example:"Любимые работы из залов XIX века"`
+	IsPublic    bool      `json:"isPublic" example:"true"`
+	ShareURL    string    `json:"shareUrl,omitempty" example:"https://museum.example.org/museum/lists/5c1e8400-e29b-41d4-a716-446655440000"`
+	ItemCount   int       `json:"itemCount" example:"12"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-03-01T10:00:00Z"`
+	UpdatedAt   time.Time `json:"updatedAt" example:"2024-03-02T12:30:00Z"`
+}
+
+// UserListItemResponse элемент подборки, заполнено одно из полей artwork и event
+type UserListItemResponse struct {
+	EntityType string           `json:"entityType" example:"artwork"`
+	EntityID   string           `json:"entityId" example:"7d1e8400-e29b-41d4-a716-446655440000"`
+	Position   int              `json:"position" example:"1"`
+	Note       string           `json:"note,omitempty" example:"Посмотреть вживую"`
+	Artwork    *ArtworkResponse `json:"artwork,omitempty"`
+	Event      *EventResponse   `json:"event,omitempty"`
+}
+
+// UserListResponse подборка с элементами в порядке показа.
+// Удаленные записи и скрытые от посетителей мероприятия не показываются
+type UserListResponse struct {
+	UserListSummaryResponse
+	Items []UserListItemResponse `json:"items"`
+}
@@ -0,0 +1,276 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// TransferMethod способ перехода произведения к владельцу
+type TransferMethod string
+
+const (
+	TransferPurchase     TransferMethod = "purchase"
+	TransferAuction      TransferMethod = "auction"
+	TransferGift         TransferMethod = "gift"
+	TransferBequest      TransferMethod = "bequest"
+	TransferInheritance  TransferMethod = "inheritance"
+	TransferExchange     TransferMethod = "exchange"
+	TransferCommission   TransferMethod = "commission"
+	TransferConfiscation TransferMethod = "confiscation"
+	TransferRestitution  TransferMethod = "restitution"
+	TransferUnknown      TransferMethod = "unknown"
+)
+
+var transferMethodLabels = map[TransferMethod]string{
+	TransferPurchase:     "покупка",
+	TransferAuction:      "аукцион",
+	TransferGift:         "дар",
+	TransferBequest:      "завещание",
+	TransferInheritance:  "наследование",
+	TransferExchange:     "обмен",
+	TransferCommission:   "заказ у автора",
+	TransferConfiscation: "конфискация",
+	TransferRestitution:  "реституция",
+	TransferUnknown:      "неизвестно",
+}
+
+func (m TransferMethod) IsValid() bool {
+	_, ok := transferMethodLabels[m]
+	return ok
+}
+
+func (m TransferMethod) Label() string {
+	return transferMethodLabels[m]
+}
+
+// DateQualifier точность датировки периода владения
+type DateQualifier string
+
+const (
+	DateExact  DateQualifier = "exact"
+	DateCirca  DateQualifier = "circa"
+	DateBefore DateQualifier = "before"
+	DateAfter  DateQualifier = "after"
+)
+
+func (q DateQualifier) IsValid() bool {
+	switch q {
+	case DateExact, DateCirca, DateBefore, DateAfter:
+		return true
+	}
+	return false
+}
+
+const (
+	// ProvenanceMaxEntries максимальное количество записей провенанса у одного произведения
+	ProvenanceMaxEntries = 100
+	provenanceMaxTextLen = 2000
+)
+
+// ProvenanceEntry запись истории владения произведением.
+// periodFrom и periodTo - годы начала и окончания владения, 0 - год неизвестен.
+type ProvenanceEntry struct {
+	id             uuid.UUID
+	artworkID      uuid.UUID
+	position       int
+	owner          string
+	ownerUncertain bool
+	location       string
+	periodFrom     int
+	periodTo       int
+	dateQualifier  DateQualifier
+	transferMethod TransferMethod
+	sources        string
+	notes          string
+	isPublic       bool
+}
+
+var (
+	ErrValidateProvenance         = errors.New("invalid provenance entry")
+	ErrProvenanceInvalidArtwork   = errors.New("invalid artwork reference")
+	ErrProvenanceEmptyOwner       = errors.New("empty owner")
+	ErrProvenanceOwnerTooLong     = errors.New("owner exceeds maximum length (255 chars)")
+	ErrProvenanceLocationTooLong  = errors.New("location exceeds maximum length (255 chars)")
+	ErrProvenanceInvalidPeriod    = errors.New("invalid ownership period")
+	ErrProvenanceInvalidQualifier = errors.New("invalid date qualifier (exact, circa, before, after)")
+	ErrProvenanceInvalidTransfer  = errors.New("invalid transfer method")
+	ErrProvenanceTextTooLong      = errors.New("sources or notes exceed maximum length (2000 chars)")
+	ErrProvenanceInvalidPosition  = errors.New("invalid provenance position")
+)
+
+func NewProvenanceEntry(
+	id uuid.UUID,
+	artworkID uuid.UUID,
+	position int,
+	owner string,
+	ownerUncertain bool,
+	location string,
+	periodFrom int,
+	periodTo int,
+	dateQualifier DateQualifier,
+	transferMethod TransferMethod,
+	sources string,
+	notes string,
+	isPublic bool,
+) (ProvenanceEntry, error) {
+	if dateQualifier == "" {
+		dateQualifier = DateExact
+	}
+	if transferMethod == "" {
+		transferMethod = TransferUnknown
+	}
+	entry := ProvenanceEntry{
+		id:             id,
+		artworkID:      artworkID,
+		position:       position,
+		owner:          strings.TrimSpace(owner),
+		ownerUncertain: ownerUncertain,
+		location:       strings.TrimSpace(location),
+		periodFrom:     periodFrom,
+		periodTo:       periodTo,
+		dateQualifier:  dateQualifier,
+		transferMethod: transferMethod,
+		sources:        strings.TrimSpace(sources),
+		notes:          strings.TrimSpace(notes),
+		isPublic:       isPublic,
+	}
+
+	if err := entry.validate(); err != nil {
+		return ProvenanceEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (p *ProvenanceEntry) validate() error {
+	switch {
+	case p.artworkID == uuid.Nil:
+		return ErrProvenanceInvalidArtwork
+	case p.owner == "":
+		return ErrProvenanceEmptyOwner
+	case utf8.RuneCountInString(p.owner) > 255:
+		return ErrProvenanceOwnerTooLong
+	case utf8.RuneCountInString(p.location) > 255:
+		return ErrProvenanceLocationTooLong
+	case p.periodFrom < 0 || p.periodTo < 0:
+		return ErrProvenanceInvalidPeriod
+	case p.periodFrom > 0 && p.periodTo > 0 && p.periodFrom > p.periodTo:
+		return ErrProvenanceInvalidPeriod
+	case !p.dateQualifier.IsValid():
+		return ErrProvenanceInvalidQualifier
+	case !p.transferMethod.IsValid():
+		return ErrProvenanceInvalidTransfer
+	case utf8.RuneCountInString(p.sources) > provenanceMaxTextLen ||
+		utf8.RuneCountInString(p.notes) > provenanceMaxTextLen:
+		return ErrProvenanceTextTooLong
+	case p.position < 0:
+		return ErrProvenanceInvalidPosition
+	}
+	return nil
+}
+
+// PeriodLabel возвращает период владения с учетом точности датировки, например "ок. 1890–1910"
+func (p *ProvenanceEntry) PeriodLabel() string {
+	var period string
+	switch {
+	case p.periodFrom > 0 && p.periodTo > 0 && p.periodFrom == p.periodTo:
+		period = strconv.Itoa(p.periodFrom)
+	case p.periodFrom > 0 && p.periodTo > 0:
+		period = strconv.Itoa(p.periodFrom) + "–" + strconv.Itoa(p.periodTo)
+	case p.periodFrom > 0:
+		period = "с " + strconv.Itoa(p.periodFrom)
+	case p.periodTo > 0:
+		period = "по " + strconv.Itoa(p.periodTo)
+	default:
+		return "даты неизвестны"
+	}
+	switch p.dateQualifier {
+	case DateCirca:
+		return "ок. " + period
+	case DateBefore:
+		return "до " + period
+	case DateAfter:
+		return "после " + period
+	}
+	return period
+}
+
+func (p *ProvenanceEntry) ToProvenanceEntryResponse() jsonreqresp.ProvenanceEntryResponse {
+	return jsonreqresp.ProvenanceEntryResponse{
+		ID:             p.id.String(),
+		Position:       p.position,
+		Owner:          p.owner,
+		OwnerUncertain: p.ownerUncertain,
+		Location:       p.location,
+		PeriodFrom:     p.periodFrom,
+		PeriodTo:       p.periodTo,
+		DateQualifier:  string(p.dateQualifier),
+		Period:         p.PeriodLabel(),
+		TransferMethod: string(p.transferMethod),
+		TransferLabel:  p.transferMethod.Label(),
+		Sources:        p.sources,
+		Notes:          p.notes,
+		IsPublic:       p.isPublic,
+	}
+}
+
+func (p *ProvenanceEntry) GetID() uuid.UUID {
+	return p.id
+}
+
+func (p *ProvenanceEntry) GetArtworkID() uuid.UUID {
+	return p.artworkID
+}
+
+func (p *ProvenanceEntry) GetPosition() int {
+	return p.position
+}
+
+func (p *ProvenanceEntry) GetOwner() string {
+	return p.owner
+}
+
+func (p *ProvenanceEntry) IsOwnerUncertain() bool {
+	return p.ownerUncertain
+}
+
+func (p *ProvenanceEntry) GetLocation() string {
+	return p.location
+}
+
+func (p *ProvenanceEntry) GetPeriodFrom() int {
+	return p.periodFrom
+}
+
+func (p *ProvenanceEntry) GetPeriodTo() int {
+	return p.periodTo
+}
+
+func (p *ProvenanceEntry) GetDateQualifier() DateQualifier {
+	return p.dateQualifier
+}
+
+func (p *ProvenanceEntry) GetTransferMethod() TransferMethod {
+	return p.transferMethod
+}
+
+func (p *ProvenanceEntry) GetSources() string {
+	return p.sources
+}
+
+func (p *ProvenanceEntry) GetNotes() string {
+	return p.notes
+}
+
+func (p *ProvenanceEntry) IsPublic() bool {
+	return p.isPublic
+}
+
+func (p *ProvenanceEntry) SetPosition(position int) {
+	p.position = position
+}
@@ -0,0 +1,212 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+const (
+	// SimilarDefaultLimit и SimilarMaxLimit число рекомендаций по умолчанию и наибольшее
+	SimilarDefaultLimit = 6
+	SimilarMaxLimit     = 24
+	// SimilarCandidateFactor во сколько раз больше кандидатов отбирается в репозитории,
+	// чтобы после учета избранного посетителя осталось достаточно рекомендаций
+	SimilarCandidateFactor = 3
+	// SimilarPeriodWindow разница лет создания, в пределах которой произведения считаются близкими по времени
+	SimilarPeriodWindow = 50
+	// SimilarMaxSharedEvents и SimilarMaxCoFavourites ограничивают вклад общих мероприятий и совместного избранного
+	SimilarMaxSharedEvents = 3
+	SimilarMaxCoFavourites = 5
+	// SimilarMaxUserFavourites число последних записей избранного посетителя, учитываемых в рекомендациях
+	SimilarMaxUserFavourites = 20
+)
+
+// SimilarityReason признак сходства произведений
+type SimilarityReason string
+
+const (
+	ReasonSameAuthor     SimilarityReason = "author"
+	ReasonSameCollection SimilarityReason = "collection"
+	ReasonSameTechnic    SimilarityReason = "technic"
+	ReasonSameMaterial   SimilarityReason = "material"
+	ReasonClosePeriod    SimilarityReason = "period"
+	ReasonSharedEvents   SimilarityReason = "events"
+	ReasonCoFavourites   SimilarityReason = "favourites"
+	ReasonYourFavourites SimilarityReason = "yourFavourites"
+)
+
+// SimilarityWeights веса признаков сходства. Одни и те же веса используются при отборе кандидатов
+// в репозитории и при оценке, порядок кандидатов отличается от итогового только учетом избранного посетителя
+type SimilarityWeights struct {
+	Author         float64
+	Collection     float64
+	Technic        float64
+	Material       float64
+	Period         float64
+	SharedEvent    float64
+	CoFavourite    float64
+	YourFavourites float64
+}
+
+var DefaultSimilarityWeights = SimilarityWeights{
+	Author:         5,
+	Collection:     2,
+	Technic:        1.5,
+	Material:       1,
+	Period:         2,
+	SharedEvent:    1.5,
+	CoFavourite:    1,
+	YourFavourites: 2,
+}
+
+// SimilarityCandidate произведение-кандидат и его признаки сходства с исходным произведением
+type SimilarityCandidate struct {
+	ArtworkID      uuid.UUID
+	AuthorID       uuid.UUID
+	CollectionID   uuid.UUID
+	SameAuthor     bool
+	SameCollection bool
+	SameTechnic    bool
+	SameMaterial   bool
+	// YearDiff разница лет создания
+	YearDiff int
+	// SharedEvents число мероприятий, где произведения выставлялись вместе
+	SharedEvents int
+	// CoFavourites число пользователей, у которых оба произведения в избранном
+	CoFavourites int
+}
+
+// RecommendationReason причина рекомендации и ее вклад в оценку
+type RecommendationReason struct {
+	Kind   SimilarityReason
+	Label  string
+	Weight float64
+}
+
+// Recommendation рекомендованное произведение с оценкой и причинами
+type Recommendation struct {
+	Artwork *Artwork
+	Score   float64
+	Reasons []RecommendationReason
+}
+
+// UserTaste авторы и коллекции произведений из избранного посетителя
+type UserTaste struct {
+	Favourites  map[uuid.UUID]struct{}
+	Authors     map[uuid.UUID]struct{}
+	Collections map[uuid.UUID]struct{}
+}
+
+func NewUserTaste() *UserTaste {
+	return &UserTaste{
+		Favourites:  make(map[uuid.UUID]struct{}),
+		Authors:     make(map[uuid.UUID]struct{}),
+		Collections: make(map[uuid.UUID]struct{}),
+	}
+}
+
+// AddArtwork учитывает произведение из избранного
+func (t *UserTaste) AddArtwork(a *Artwork) {
+	t.Favourites[a.GetID()] = struct{}{}
+	t.Authors[a.GetAuthor().GetID()] = struct{}{}
+	t.Collections[a.GetCollection().GetID()] = struct{}{}
+}
+
+// matches сообщает, что кандидат того же автора или из той же коллекции, что и избранное
+func (t *UserTaste) matches(c *SimilarityCandidate) bool {
+	if _, ok := t.Authors[c.AuthorID]; ok {
+		return true
+	}
+	_, ok := t.Collections[c.CollectionID]
+	return ok
+}
+
+// Contains сообщает, что произведение уже в избранном посетителя
+func (t *UserTaste) Contains(artworkID uuid.UUID) bool {
+	_, ok := t.Favourites[artworkID]
+	return ok
+}
+
+// PeriodScore доля веса близости по времени: 1 для одного года, 0 от SimilarPeriodWindow лет
+func PeriodScore(yearDiff int) float64 {
+	if yearDiff < 0 {
+		yearDiff = -yearDiff
+	}
+	if yearDiff >= SimilarPeriodWindow {
+		return 0
+	}
+	return float64(SimilarPeriodWindow-yearDiff) / SimilarPeriodWindow
+}
+
+// Reasons возвращает причины рекомендации кандидата с вкладом в оценку, начиная с наибольшего.
+// taste - избранное посетителя, nil - посетитель не авторизован
+func (c *SimilarityCandidate) Reasons(w SimilarityWeights, taste *UserTaste) []RecommendationReason {
+	var reasons []RecommendationReason
+	add := func(kind SimilarityReason, label string, weight float64) {
+		if weight > 0 {
+			reasons = append(reasons, RecommendationReason{Kind: kind, Label: label, Weight: weight})
+		}
+	}
+	if c.SameAuthor {
+		add(ReasonSameAuthor, "Тот же автор", w.Author)
+	}
+	if c.SameCollection {
+		add(ReasonSameCollection, "Та же коллекция", w.Collection)
+	}
+	if c.SameTechnic {
+		add(ReasonSameTechnic, "Та же техника", w.Technic)
+	}
+	if c.SameMaterial {
+		add(ReasonSameMaterial, "Тот же материал", w.Material)
+	}
+	if period := PeriodScore(c.YearDiff); period > 0 {
+		label := "Создано в тот же год"
+		if c.YearDiff != 0 {
+			label = fmt.Sprintf("Создано с разницей в %d г.", c.YearDiff)
+		}
+		add(ReasonClosePeriod, label, w.Period*period)
+	}
+	if c.SharedEvents > 0 {
+		add(ReasonSharedEvents, fmt.Sprintf("Выставлялись вместе: %d", c.SharedEvents),
+			w.SharedEvent*float64(min(c.SharedEvents, SimilarMaxSharedEvents)))
+	}
+	if c.CoFavourites > 0 {
+		add(ReasonCoFavourites, fmt.Sprintf("В избранном вместе у посетителей: %d", c.CoFavourites),
+			w.CoFavourite*float64(min(c.CoFavourites, SimilarMaxCoFavourites)))
+	}
+	if taste != nil && taste.matches(c) {
+		add(ReasonYourFavourites, "Похоже на ваше избранное", w.YourFavourites)
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		return reasons[i].Weight > reasons[j].Weight
+	})
+	return reasons
+}
+
+// Score суммирует вклад причин рекомендации
+func Score(reasons []RecommendationReason) float64 {
+	var score float64
+	for _, r := range reasons {
+		score += r.Weight
+	}
+	return score
+}
+
+func (r *Recommendation) ToSimilarArtworkResponse() jsonreqresp.SimilarArtworkResponse {
+	resp := jsonreqresp.SimilarArtworkResponse{
+		Artwork: r.Artwork.ToArtworkResponse(),
+		Score:   r.Score,
+		Reasons: make([]jsonreqresp.RecommendationReasonResponse, len(r.Reasons)),
+	}
+	for i, reason := range r.Reasons {
+		resp.Reasons[i] = jsonreqresp.RecommendationReasonResponse{
+			Kind:   string(reason.Kind),
+			Label:  reason.Label,
+			Weight: reason.Weight,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// пути публичных страниц, на которые ссылаются документы schema.org
+const (
+	ArtworkPagePath = "/museum/artworks/"
+	EventPagePath   = "/museum/events/"
+	AuthorPagePath  = "/museum/authors/"
+)
+
+// schemaOrgThumbnailWidth ширина миниатюры для thumbnailUrl
+const schemaOrgThumbnailWidth = 480
+
+// schemaOrgLimitedShare доля свободных билетов, начиная с которой доступность считается ограниченной
+const schemaOrgLimitedShare = 0.1
+
+func ArtworkPageURL(baseURL string, artworkID uuid.UUID) string {
+	return baseURL + ArtworkPagePath + artworkID.String()
+}
+
+func EventPageURL(baseURL string, eventID uuid.UUID) string {
+	return baseURL + EventPagePath + eventID.String()
+}
+
+func AuthorPageURL(baseURL string, authorID uuid.UUID) string {
+	return baseURL + AuthorPagePath + authorID.String()
+}
+
+func schemaOrgQuantity(value float64, unitCode string) *jsonreqresp.SchemaOrgQuantity {
+	if value <= 0 {
+		return nil
+	}
+	return &jsonreqresp.SchemaOrgQuantity{Type: "QuantitativeValue", Value: value, UnitCode: unitCode}
+}
+
+func (a *Author) ToSchemaOrgPerson() jsonreqresp.SchemaOrgPerson {
+	p := jsonreqresp.SchemaOrgPerson{
+		Type:       "Person",
+		Identifier: a.id.String(),
+		Name:       a.name,
+		BirthDate:  strconv.Itoa(a.birthYear),
+	}
+	if a.deathYear > 0 {
+		p.DeathDate = strconv.Itoa(a.deathYear)
+	}
+	return p
+}
+
+// ToSchemaOrgProfile возвращает автора со сведениями профиля как отдельный документ schema.org Person
+// для публичной страницы автора
+func (a *Author) ToSchemaOrgProfile(baseURL string) jsonreqresp.SchemaOrgPerson {
+	pageURL := AuthorPageURL(baseURL, a.id)
+	p := a.ToSchemaOrgPerson()
+	p.Context = jsonreqresp.SchemaOrgContext
+	p.ID = pageURL
+	p.URL = pageURL
+	p.Description = a.profile.Biography
+	p.SameAs = a.profile.sameAs()
+	if a.portrait != "" {
+		p.Image = baseURL + a.PortraitURL()
+	}
+	if a.profile.Nationality != "" {
+		p.Nationality = &jsonreqresp.SchemaOrgReference{Type: "Country", Name: a.profile.Nationality}
+	}
+	if a.profile.BirthPlace != "" {
+		p.BirthPlace = &jsonreqresp.SchemaOrgReference{Type: "Place", Name: a.profile.BirthPlace}
+	}
+	if a.profile.DeathPlace != "" {
+		p.DeathPlace = &jsonreqresp.SchemaOrgReference{Type: "Place", Name: a.profile.DeathPlace}
+	}
+	return p
+}
+
+// ToSchemaOrg возвращает произведение как schema.org VisualArtwork; baseURL - внешний адрес сервиса без завершающего "/".
+// Размеры указываются в сантиметрах и килограммах независимо от единиц, в которых они введены
+func (a *Artwork) ToSchemaOrg(baseURL string) jsonreqresp.SchemaOrgVisualArtwork {
+	pageURL := ArtworkPageURL(baseURL, a.id)
+	art := jsonreqresp.SchemaOrgVisualArtwork{
+		Context:        jsonreqresp.SchemaOrgContext,
+		Type:           "VisualArtwork",
+		ID:             pageURL,
+		URL:            pageURL,
+		Identifier:     a.id.String(),
+		Name:           a.title,
+		ArtMedium:      a.technic,
+		ArtworkSurface: a.material,
+		Height:         schemaOrgQuantity(a.dimensions.GetHeightCm(), "CMT"),
+		Width:          schemaOrgQuantity(a.dimensions.GetWidthCm(), "CMT"),
+		Depth:          schemaOrgQuantity(a.dimensions.GetDepthCm(), "CMT"),
+		Weight:         schemaOrgQuantity(a.dimensions.GetWeightKg(), "KGM"),
+		IsPartOf: &jsonreqresp.SchemaOrgReference{
+			Type: "Collection",
+			Name: a.collection.GetTitle(),
+		},
+	}
+	if a.dating.GetQualifier() == DateExact && a.dating.GetYearFrom() == a.dating.GetYearTo() {
+		art.DateCreated = strconv.Itoa(a.dating.GetYearFrom())
+	} else {
+		art.TemporalCoverage = a.dating.EDTF()
+	}
+	for _, attr := range a.GetAttributions() {
+		art.Creator = append(art.Creator, attr.GetAuthor().ToSchemaOrgPerson())
+	}
+	for _, img := range a.images {
+		art.Image = append(art.Image, baseURL+ArtworkImagesURLPrefix+img.OriginalKey())
+	}
+	if primary := a.GetPrimaryImage(); primary != nil {
+		art.ThumbnailURL = baseURL + ArtworkImagesURLPrefix + primary.ThumbnailKey(schemaOrgThumbnailWidth)
+	}
+	for _, tag := range a.tags {
+		art.Keywords = append(art.Keywords, tag.GetName())
+	}
+	return art
+}
+
+// ToSchemaOrg возвращает мероприятие как schema.org ExhibitionEvent с предложением билетов.
+// freeTickets - число билетов, которые еще можно купить; artworks - произведения мероприятия.
+// Мероприятие, закрытое для посещения, предложений не содержит
+func (e *Event) ToSchemaOrg(baseURL string, freeTickets int, artworks []*Artwork) jsonreqresp.SchemaOrgExhibitionEvent {
+	pageURL := EventPageURL(baseURL, e.id)
+	event := jsonreqresp.SchemaOrgExhibitionEvent{
+		Context:             jsonreqresp.SchemaOrgContext,
+		Type:                "ExhibitionEvent",
+		ID:                  pageURL,
+		URL:                 pageURL,
+		Identifier:          e.id.String(),
+		Name:                e.title,
+		StartDate:           e.dateBegin.Format(time.RFC3339),
+		EndDate:             e.dateEnd.Format(time.RFC3339),
+		EventStatus:         "https://schema.org/EventScheduled",
+		EventAttendanceMode: "https://schema.org/OfflineEventAttendanceMode",
+		Location:            jsonreqresp.SchemaOrgReference{Type: "Place", Name: e.address, Address: e.address},
+	}
+	if e.canVisit {
+		event.Offers = []jsonreqresp.SchemaOrgOffer{{
+			Type:           "Offer",
+			URL:            pageURL,
+			Availability:   e.schemaOrgAvailability(freeTickets, time.Now()),
+			InventoryLevel: &jsonreqresp.SchemaOrgQuantity{Type: "QuantitativeValue", Value: float64(freeTickets)},
+			ValidThrough:   e.dateEnd.Format(time.RFC3339),
+		}}
+	}
+	for _, art := range artworks {
+		event.WorkFeatured = append(event.WorkFeatured, jsonreqresp.SchemaOrgReference{
+			Type: "VisualArtwork",
+			ID:   ArtworkPageURL(baseURL, art.GetID()),
+			Name: art.GetTitle(),
+		})
+	}
+	return event
+}
+
+// schemaOrgAvailability доступность билетов: после окончания мероприятия продажа прекращена,
+// при остатке не больше schemaOrgLimitedShare билетов доступность ограничена
+func (e *Event) schemaOrgAvailability(freeTickets int, now time.Time) string {
+	switch {
+	case e.dateEnd.Before(now):
+		return jsonreqresp.SchemaOrgDiscontinued
+	case freeTickets <= 0:
+		return jsonreqresp.SchemaOrgSoldOut
+	case float64(freeTickets) <= float64(e.cntTickets)*schemaOrgLimitedShare:
+		return jsonreqresp.SchemaOrgLimitedAvailability
+	}
+	return jsonreqresp.SchemaOrgInStock
+}
@@ -0,0 +1,373 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// TagKind вид тега контролируемого словаря
+type TagKind string
+
+const (
+	TagSubject  TagKind = "subject"
+	TagMovement TagKind = "movement"
+	TagKeyword  TagKind = "keyword"
+)
+
+var tagKindLabels = map[TagKind]string{
+	TagSubject:  "Сюжет",
+	TagMovement: "Направление",
+	TagKeyword:  "Ключевое слово",
+}
+
+// TagKinds виды тегов в порядке показа
+var TagKinds = []TagKind{TagSubject, TagMovement, TagKeyword}
+
+func (k TagKind) IsValid() bool {
+	_, ok := tagKindLabels[k]
+	return ok
+}
+
+func (k TagKind) Label() string {
+	return tagKindLabels[k]
+}
+
+const (
+	// TagMaxSynonyms максимальное число синонимов тега
+	TagMaxSynonyms = 20
+	// TagMaxDepth максимальная глубина иерархии тегов, корневой тег - уровень 1
+	TagMaxDepth = 5
+	// ArtworkMaxTags максимальное число тегов одного произведения
+	ArtworkMaxTags = 50
+)
+
+// Tag термин контролируемого словаря: сюжет, направление или ключевое слово.
+// Теги одного вида образуют иерархию, parentID = uuid.Nil - корневой тег
+type Tag struct {
+	id       uuid.UUID
+	name     string
+	kind     TagKind
+	parentID uuid.UUID
+	synonyms []string
+}
+
+var (
+	ErrValidateTag        = errors.New("invalid tag")
+	ErrTagEmptyName       = errors.New("empty tag name")
+	ErrTagNameTooLong     = errors.New("tag name exceeds maximum length (100 chars)")
+	ErrTagInvalidKind     = errors.New("invalid tag kind (subject, movement, keyword)")
+	ErrTagSelfParent      = errors.New("tag cannot be its own parent")
+	ErrTagTooManySynonyms = errors.New("too many tag synonyms (20 max)")
+	ErrTagInvalidSynonym  = errors.New("tag synonym must be a non-empty single line up to 100 chars")
+	ErrTagDuplicateName   = errors.New("tag name or synonym is already used in the vocabulary")
+	ErrTagParentNotFound  = errors.New("parent tag not found")
+	ErrTagParentKind      = errors.New("parent tag must be of the same kind")
+	ErrTagCycle           = errors.New("tag cannot be moved under its own descendant")
+	ErrTagTooDeep         = errors.New("tag hierarchy is too deep (5 levels max)")
+	ErrTagHasChildren     = errors.New("tag has child tags")
+	ErrArtworkTooManyTags = errors.New("too many artwork tags (50 max)")
+)
+
+func NewTag(id uuid.UUID, name string, kind TagKind, parentID uuid.UUID, synonyms []string) (Tag, error) {
+	tag := Tag{
+		id:       id,
+		name:     strings.TrimSpace(name),
+		kind:     kind,
+		parentID: parentID,
+		synonyms: normalizeSynonyms(synonyms),
+	}
+
+	if err := tag.validate(); err != nil {
+		return Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// NewTagFromRequest создает тег из запроса сотрудника
+func NewTagFromRequest(id uuid.UUID, req jsonreqresp.TagRequest) (Tag, error) {
+	parentID := uuid.Nil
+	if req.ParentID != "" {
+		var err error
+		if parentID, err = uuid.Parse(req.ParentID); err != nil {
+			return Tag{}, ErrTagParentNotFound
+		}
+	}
+	return NewTag(id, req.Name, TagKind(req.Kind), parentID, req.Synonyms)
+}
+
+// normalizeSynonyms убирает пробелы по краям и повторы синонимов без учета регистра
+func normalizeSynonyms(synonyms []string) []string {
+	res := make([]string, 0, len(synonyms))
+	seen := make(map[string]struct{}, len(synonyms))
+	for _, s := range synonyms {
+		s = strings.TrimSpace(s)
+		key := strings.ToLower(s)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, s)
+	}
+	return res
+}
+
+func (t *Tag) validate() error {
+	switch {
+	case t.name == "":
+		return ErrTagEmptyName
+	case len(t.name) > 100:
+		return ErrTagNameTooLong
+	case !t.kind.IsValid():
+		return ErrTagInvalidKind
+	case t.parentID == t.id:
+		return ErrTagSelfParent
+	case len(t.synonyms) > TagMaxSynonyms:
+		return ErrTagTooManySynonyms
+	}
+	for _, s := range t.synonyms {
+		if s == "" || len(s) > 100 || strings.ContainsAny(s, "\r\n") {
+			return ErrTagInvalidSynonym
+		}
+		if strings.EqualFold(s, t.name) {
+			return ErrTagDuplicateName
+		}
+	}
+	return nil
+}
+
+func (t *Tag) ToTagResponse() jsonreqresp.TagResponse {
+	resp := jsonreqresp.TagResponse{
+		ID:        t.id.String(),
+		Name:      t.name,
+		Kind:      string(t.kind),
+		KindLabel: t.kind.Label(),
+		Synonyms:  t.synonyms,
+	}
+	if t.parentID != uuid.Nil {
+		resp.ParentID = t.parentID.String()
+	}
+	return resp
+}
+
+func (t *Tag) GetID() uuid.UUID {
+	return t.id
+}
+
+func (t *Tag) GetName() string {
+	return t.name
+}
+
+func (t *Tag) GetKind() TagKind {
+	return t.kind
+}
+
+// GetParentID возвращает родительский тег, uuid.Nil - тег корневой
+func (t *Tag) GetParentID() uuid.UUID {
+	return t.parentID
+}
+
+func (t *Tag) GetSynonyms() []string {
+	return t.synonyms
+}
+
+// Terms возвращает название и синонимы тега
+func (t *Tag) Terms() []string {
+	return append([]string{t.name}, t.synonyms...)
+}
+
+// Matches сообщает, совпадает ли термин с названием или синонимом тега без учета регистра
+func (t *Tag) Matches(term string) bool {
+	term = strings.TrimSpace(term)
+	for _, s := range t.Terms() {
+		if strings.EqualFold(s, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains сообщает, входит ли термин в название или синоним тега без учета регистра
+func (t *Tag) Contains(term string) bool {
+	term = strings.ToLower(strings.TrimSpace(term))
+	for _, s := range t.Terms() {
+		if strings.Contains(strings.ToLower(s), term) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tag) Update(req jsonreqresp.TagRequest) error {
+	updated, err := NewTagFromRequest(t.id, req)
+	if err != nil {
+		return err
+	}
+	*t = updated
+	return nil
+}
+
+// TagVocabulary словарь тегов для проверки иерархии и уникальности терминов
+type TagVocabulary struct {
+	byID     map[uuid.UUID]*Tag
+	children map[uuid.UUID][]*Tag
+}
+
+func NewTagVocabulary(tags []*Tag) *TagVocabulary {
+	v := &TagVocabulary{
+		byID:     make(map[uuid.UUID]*Tag, len(tags)),
+		children: make(map[uuid.UUID][]*Tag),
+	}
+	for _, t := range tags {
+		v.byID[t.id] = t
+		v.children[t.parentID] = append(v.children[t.parentID], t)
+	}
+	return v
+}
+
+func (v *TagVocabulary) Get(id uuid.UUID) (*Tag, bool) {
+	t, ok := v.byID[id]
+	return t, ok
+}
+
+// Children возвращает дочерние теги, для uuid.Nil - корневые теги; теги упорядочены по названию
+func (v *TagVocabulary) Children(id uuid.UUID) []*Tag {
+	children := append([]*Tag(nil), v.children[id]...)
+	sort.Slice(children, func(i, j int) bool { return children[i].name < children[j].name })
+	return children
+}
+
+// Ancestors возвращает цепочку родительских тегов от корня к непосредственному родителю
+func (v *TagVocabulary) Ancestors(id uuid.UUID) []*Tag {
+	var ancestors []*Tag
+	t, ok := v.byID[id]
+	for ok && t.parentID != uuid.Nil && len(ancestors) < TagMaxDepth {
+		if t, ok = v.byID[t.parentID]; ok {
+			ancestors = append([]*Tag{t}, ancestors...)
+		}
+	}
+	return ancestors
+}
+
+// Descendants возвращает переданные теги вместе со всеми их потомками
+func (v *TagVocabulary) Descendants(ids uuid.UUIDs) uuid.UUIDs {
+	var res uuid.UUIDs
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	queue := append(uuid.UUIDs(nil), ids...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+		for _, child := range v.children[id] {
+			queue = append(queue, child.id)
+		}
+	}
+	return res
+}
+
+// subtreeDepth возвращает число уровней поддерева с корнем в теге id
+func (v *TagVocabulary) subtreeDepth(id uuid.UUID) int {
+	depth := 0
+	for _, child := range v.children[id] {
+		depth = max(depth, v.subtreeDepth(child.id))
+	}
+	return depth + 1
+}
+
+// CheckTag проверяет, что новый или измененный тег можно поместить в словарь:
+// его термины не заняты другими тегами того же вида, родитель и потомки того же вида,
+// родитель существует и не является потомком тега, глубина иерархии не превышает TagMaxDepth
+func (v *TagVocabulary) CheckTag(t *Tag) error {
+	for _, other := range v.byID {
+		if other.id == t.id || other.kind != t.kind {
+			continue
+		}
+		for _, term := range t.Terms() {
+			if other.Matches(term) {
+				return ErrTagDuplicateName
+			}
+		}
+	}
+	for _, child := range v.children[t.id] {
+		if child.kind != t.kind {
+			return ErrTagParentKind
+		}
+	}
+	if t.parentID == uuid.Nil {
+		return nil
+	}
+	parent, ok := v.byID[t.parentID]
+	switch {
+	case !ok:
+		return ErrTagParentNotFound
+	case parent.kind != t.kind:
+		return ErrTagParentKind
+	}
+	for _, a := range append(v.Ancestors(parent.id), parent) {
+		if a.id == t.id {
+			return ErrTagCycle
+		}
+	}
+	if len(v.Ancestors(parent.id))+1+v.subtreeDepth(t.id) > TagMaxDepth {
+		return ErrTagTooDeep
+	}
+	return nil
+}
+
+// TagNode тег словаря с потомками и числом произведений для публичного просмотра
+type TagNode struct {
+	Tag      *Tag
+	Count    int
+	Children []*TagNode
+}
+
+// BuildTagTree строит дерево тегов; direct - число произведений, отмеченных непосредственно тегом.
+// Count узла учитывает произведения потомков, одно произведение может быть посчитано несколько раз
+func (v *TagVocabulary) BuildTagTree(direct map[uuid.UUID]int) []*TagNode {
+	var build func(parentID uuid.UUID) []*TagNode
+	build = func(parentID uuid.UUID) []*TagNode {
+		var nodes []*TagNode
+		for _, t := range v.Children(parentID) {
+			node := &TagNode{Tag: t, Count: direct[t.id], Children: build(t.id)}
+			for _, child := range node.Children {
+				node.Count += child.Count
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(uuid.Nil)
+}
+
+func (n *TagNode) ToTagNodeResponse() jsonreqresp.TagNodeResponse {
+	resp := jsonreqresp.TagNodeResponse{
+		TagResponse: n.Tag.ToTagResponse(),
+		Count:       n.Count,
+		Children:    make([]jsonreqresp.TagNodeResponse, len(n.Children)),
+	}
+	for i, child := range n.Children {
+		resp.Children[i] = child.ToTagNodeResponse()
+	}
+	return resp
+}
+
+// FindTagNode ищет узел тега в дереве
+func FindTagNode(nodes []*TagNode, id uuid.UUID) *TagNode {
+	for _, n := range nodes {
+		if n.Tag.id == id {
+			return n
+		}
+		if found := FindTagNode(n.Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
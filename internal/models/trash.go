@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+// TrashItem запись каталога в корзине: произведение, автор или коллекция
+type TrashItem struct {
+	entityType EntityType
+	id         uuid.UUID
+	title      string
+	deletedAt  time.Time
+}
+
+var (
+	ErrTrashItemEntityType = errors.New("invalid trash entity type (artwork, author, collection)")
+	ErrTrashItemEntity     = errors.New("invalid trash entity reference")
+)
+
+// IsTrashable в корзину попадают только произведения, авторы и коллекции
+func (t EntityType) IsTrashable() bool {
+	return t == EntityArtwork || t == EntityAuthor || t == EntityCollection
+}
+
+func NewTrashItem(entityType EntityType, id uuid.UUID, title string, deletedAt time.Time) (TrashItem, error) {
+	if !entityType.IsTrashable() {
+		return TrashItem{}, ErrTrashItemEntityType
+	}
+	if id == uuid.Nil {
+		return TrashItem{}, ErrTrashItemEntity
+	}
+	return TrashItem{entityType: entityType, id: id, title: title, deletedAt: deletedAt}, nil
+}
+
+// ToTrashItemResponse retention - срок хранения в корзине, после которого запись удаляется окончательно
+func (t *TrashItem) ToTrashItemResponse(retention time.Duration) jsonreqresp.TrashItemResponse {
+	return jsonreqresp.TrashItemResponse{
+		EntityType: string(t.entityType),
+		ID:         t.id.String(),
+		Title:      t.title,
+		DeletedAt:  t.deletedAt,
+		PurgeAt:    t.deletedAt.Add(retention),
+	}
+}
+
+func (t *TrashItem) GetEntityType() EntityType {
+	return t.entityType
+}
+
+func (t *TrashItem) GetID() uuid.UUID {
+	return t.id
+}
+
+func (t *TrashItem) GetTitle() string {
+	return t.title
+}
+
+func (t *TrashItem) GetDeletedAt() time.Time {
+	return t.deletedAt
+}
+
+// DependentRecord запись, из-за которой нельзя удалить или восстановить другую запись каталога
+type DependentRecord struct {
+	EntityType EntityType
+	ID         uuid.UUID
+	Title      string
+	// InTrash запись сама находится в корзине
+	InTrash bool
+}
+
+func (d DependentRecord) ToDependentRecordResponse() jsonreqresp.DependentRecordResponse {
+	return jsonreqresp.DependentRecordResponse{
+		EntityType: string(d.EntityType),
+		ID:         d.ID.String(),
+		Title:      d.Title,
+		InTrash:    d.InTrash,
+	}
+}
+
+var ErrHasDependents = errors.New("record is blocked by dependent records")
+
+// DependencyError удаление или восстановление запрещено связанными записями Records
+type DependencyError struct {
+	Records []DependentRecord
+}
+
+func (e *DependencyError) Error() string {
+	refs := make([]string, len(e.Records))
+	for i, r := range e.Records {
+		refs[i] = fmt.Sprintf("%s %s", r.EntityType, r.ID)
+	}
+	return fmt.Sprintf("%v: %s", ErrHasDependents, strings.Join(refs, ", "))
+}
+
+func (e *DependencyError) Unwrap() error {
+	return ErrHasDependents
+}
+
+func (e *DependencyError) ToDependencyErrorResponse() jsonreqresp.DependencyErrorResponse {
+	records := make([]jsonreqresp.DependentRecordResponse, len(e.Records))
+	for i, r := range e.Records {
+		records[i] = r.ToDependentRecordResponse()
+	}
+	return jsonreqresp.DependencyErrorResponse{Error: ErrHasDependents.Error(), Blocking: records}
+}
+
+// CheckDependents возвращает DependencyError, если есть связанные записи
+func CheckDependents(records []DependentRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return &DependencyError{Records: records}
+}
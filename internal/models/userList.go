@@ -0,0 +1,349 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+const (
+	// UserMaxLists максимальное число подборок одного пользователя
+	UserMaxLists = 50
+	// UserListMaxItems максимальное число элементов подборки
+	UserListMaxItems = 200
+	// UserListPagePath путь публичной страницы подборки на сайте музея
+	UserListPagePath = "/museum/lists/"
+)
+
+var (
+	ErrValidateUserList           = errors.New("invalid user list")
+	ErrUserItemEntityType         = errors.New("only artworks and events can be added to favourites and lists")
+	ErrUserListEmptyTitle         = errors.New("empty list title")
+	ErrUserListTitleTooLong       = errors.New("list title exceeds maximum length (100 chars)")
+	ErrUserListDescriptionTooLong = errors.New("list description exceeds maximum length (2000 chars)")
+	ErrUserListNoteTooLong        = errors.New("list item note exceeds maximum length (1000 chars)")
+	ErrUserListTooManyItems       = errors.New("too many list items (200 max)")
+	ErrUserListDuplicateItem      = errors.New("item is already in the list")
+	ErrUserTooManyLists           = errors.New("too many lists (50 max)")
+)
+
+// IsUserItemEntity сообщает, можно ли добавить запись каталога в избранное и подборки пользователя
+func IsUserItemEntity(t EntityType) bool {
+	return t == EntityArtwork || t == EntityEvent
+}
+
+// Favourite произведение или мероприятие в избранном пользователя
+type Favourite struct {
+	userID     uuid.UUID
+	entityType EntityType
+	entityID   uuid.UUID
+	createdAt  time.Time
+}
+
+func NewFavourite(userID uuid.UUID, entityType EntityType, entityID uuid.UUID, createdAt time.Time) (Favourite, error) {
+	if !IsUserItemEntity(entityType) {
+		return Favourite{}, ErrUserItemEntityType
+	}
+	return Favourite{
+		userID:     userID,
+		entityType: entityType,
+		entityID:   entityID,
+		createdAt:  createdAt,
+	}, nil
+}
+
+func (f *Favourite) GetUserID() uuid.UUID {
+	return f.userID
+}
+
+func (f *Favourite) GetEntityType() EntityType {
+	return f.entityType
+}
+
+func (f *Favourite) GetEntityID() uuid.UUID {
+	return f.entityID
+}
+
+func (f *Favourite) GetCreatedAt() time.Time {
+	return f.createdAt
+}
+
+// UserListItem элемент подборки: произведение или мероприятие с заметкой пользователя
+type UserListItem struct {
+	entityType EntityType
+	entityID   uuid.UUID
+	note       string
+}
+
+func NewUserListItem(entityType EntityType, entityID uuid.UUID, note string) (UserListItem, error) {
+	item := UserListItem{
+		entityType: entityType,
+		entityID:   entityID,
+		note:       strings.TrimSpace(note),
+	}
+	switch {
+	case !IsUserItemEntity(item.entityType):
+		return UserListItem{}, ErrUserItemEntityType
+	case len(item.note) > 1000:
+		return UserListItem{}, ErrUserListNoteTooLong
+	}
+	return item, nil
+}
+
+// NewUserListItemFromRequest создает элемент подборки из запроса пользователя
+func NewUserListItemFromRequest(req jsonreqresp.UserListItemRequest) (UserListItem, error) {
+	entityID, err := uuid.Parse(req.EntityID)
+	if err != nil {
+		return UserListItem{}, ErrUserItemEntityType
+	}
+	return NewUserListItem(EntityType(req.EntityType), entityID, req.Note)
+}
+
+func (i *UserListItem) GetEntityType() EntityType {
+	return i.entityType
+}
+
+func (i *UserListItem) GetEntityID() uuid.UUID {
+	return i.entityID
+}
+
+func (i *UserListItem) GetNote() string {
+	return i.note
+}
+
+// UserList именованная подборка пользователя; публичная подборка доступна всем по ссылке
+type UserList struct {
+	id          uuid.UUID
+	userID      uuid.UUID
+	title       string
+	description string
+	isPublic    bool
+	createdAt   time.Time
+	updatedAt   time.Time
+	items       []UserListItem
+}
+
+func NewUserList(
+	id uuid.UUID,
+	userID uuid.UUID,
+	title string,
+	description string,
+	isPublic bool,
+	createdAt time.Time,
+	updatedAt time.Time,
+	items []UserListItem,
+) (UserList, error) {
+	list := UserList{
+		id:          id,
+		userID:      userID,
+		title:       strings.TrimSpace(title),
+		description: strings.TrimSpace(description),
+		isPublic:    isPublic,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		items:       items,
+	}
+
+	if err := list.validate(); err != nil {
+		return UserList{}, err
+	}
+
+	return list, nil
+}
+
+// NewUserListFromRequest создает пустую подборку пользователя
+func NewUserListFromRequest(id uuid.UUID, userID uuid.UUID, req jsonreqresp.UserListRequest, now time.Time) (UserList, error) {
+	return NewUserList(id, userID, req.Title, req.Description, req.IsPublic, now, now, nil)
+}
+
+func (l *UserList) validate() error {
+	switch {
+	case l.title == "":
+		return ErrUserListEmptyTitle
+	case len(l.title) > 100:
+		return ErrUserListTitleTooLong
+	case len(l.description) > 2000:
+		return ErrUserListDescriptionTooLong
+	case len(l.items) > UserListMaxItems:
+		return ErrUserListTooManyItems
+	}
+	seen := make(map[UserListItem]struct{}, len(l.items))
+	for _, item := range l.items {
+		key := UserListItem{entityType: item.entityType, entityID: item.entityID}
+		if _, ok := seen[key]; ok {
+			return ErrUserListDuplicateItem
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+func (l *UserList) GetID() uuid.UUID {
+	return l.id
+}
+
+func (l *UserList) GetUserID() uuid.UUID {
+	return l.userID
+}
+
+func (l *UserList) GetTitle() string {
+	return l.title
+}
+
+func (l *UserList) GetDescription() string {
+	return l.description
+}
+
+func (l *UserList) IsPublic() bool {
+	return l.isPublic
+}
+
+func (l *UserList) GetCreatedAt() time.Time {
+	return l.createdAt
+}
+
+func (l *UserList) GetUpdatedAt() time.Time {
+	return l.updatedAt
+}
+
+// GetItems возвращает элементы подборки в порядке показа
+func (l *UserList) GetItems() []UserListItem {
+	return l.items
+}
+
+// CanView сообщает, может ли пользователь открыть подборку: публичную - любой, личную - только владелец.
+// uuid.Nil - посетитель без авторизации
+func (l *UserList) CanView(userID uuid.UUID) bool {
+	return l.isPublic || (userID != uuid.Nil && l.userID == userID)
+}
+
+// PageURL возвращает публичную ссылку на подборку
+func (l *UserList) PageURL(baseURL string) string {
+	return strings.TrimSuffix(baseURL, "/") + UserListPagePath + l.id.String()
+}
+
+// Update меняет название, описание и видимость подборки
+func (l *UserList) Update(req jsonreqresp.UserListRequest, now time.Time) error {
+	copyL := *l
+	copyL.title = strings.TrimSpace(req.Title)
+	copyL.description = strings.TrimSpace(req.Description)
+	copyL.isPublic = req.IsPublic
+	if err := copyL.validate(); err != nil {
+		return err
+	}
+	copyL.updatedAt = now
+	*l = copyL
+	return nil
+}
+
+// SetItems заменяет элементы подборки, порядок элементов сохраняется
+func (l *UserList) SetItems(items []UserListItem, now time.Time) error {
+	copyL := *l
+	copyL.items = items
+	if err := copyL.validate(); err != nil {
+		return err
+	}
+	copyL.updatedAt = now
+	*l = copyL
+	return nil
+}
+
+// AddItem добавляет элемент в конец подборки
+func (l *UserList) AddItem(item UserListItem, now time.Time) error {
+	items := append(append([]UserListItem(nil), l.items...), item)
+	return l.SetItems(items, now)
+}
+
+// RemoveItem убирает элемент из подборки, false - элемента в подборке нет
+func (l *UserList) RemoveItem(entityType EntityType, entityID uuid.UUID, now time.Time) bool {
+	for i, item := range l.items {
+		if item.entityType == entityType && item.entityID == entityID {
+			l.items = append(append([]UserListItem(nil), l.items[:i]...), l.items[i+1:]...)
+			l.updatedAt = now
+			return true
+		}
+	}
+	return false
+}
+
+// UserItemRecords записи каталога из избранного и подборок, доступные посетителям.
+// Записи, которых нет в наборе, удалены или скрыты и не показываются
+type UserItemRecords struct {
+	Artworks map[uuid.UUID]*Artwork
+	Events   map[uuid.UUID]*Event
+}
+
+func NewUserItemRecords() *UserItemRecords {
+	return &UserItemRecords{
+		Artworks: make(map[uuid.UUID]*Artwork),
+		Events:   make(map[uuid.UUID]*Event),
+	}
+}
+
+func (l *UserList) ToUserListSummaryResponse(baseURL string) jsonreqresp.UserListSummaryResponse {
+	resp := jsonreqresp.UserListSummaryResponse{
+		ID:          l.id.String(),
+		Title:       l.title,
+		Description: l.description,
+		IsPublic:    l.isPublic,
+		ItemCount:   len(l.items),
+		CreatedAt:   l.createdAt,
+		UpdatedAt:   l.updatedAt,
+	}
+	if l.isPublic {
+		resp.ShareURL = l.PageURL(baseURL)
+	}
+	return resp
+}
+
+// ToUserListResponse возвращает подборку с доступными записями каталога, позиции элементов считаются с 1
+func (l *UserList) ToUserListResponse(baseURL string, records *UserItemRecords) jsonreqresp.UserListResponse {
+	resp := jsonreqresp.UserListResponse{
+		UserListSummaryResponse: l.ToUserListSummaryResponse(baseURL),
+		Items:                   []jsonreqresp.UserListItemResponse{},
+	}
+	for _, item := range l.items {
+		itemResp := jsonreqresp.UserListItemResponse{
+			EntityType: string(item.entityType),
+			EntityID:   item.entityID.String(),
+			Note:       item.note,
+		}
+		if a, ok := records.Artworks[item.entityID]; ok && item.entityType == EntityArtwork {
+			artwork := a.ToArtworkResponse()
+			itemResp.Artwork = &artwork
+		} else if e, ok := records.Events[item.entityID]; ok && item.entityType == EntityEvent {
+			event := e.ToEventResponse()
+			itemResp.Event = &event
+		} else {
+			continue
+		}
+		itemResp.Position = len(resp.Items) + 1
+		resp.Items = append(resp.Items, itemResp)
+	}
+	resp.ItemCount = len(resp.Items)
+	return resp
+}
+
+// ToFavouritesResponse возвращает доступные записи из избранного в порядке добавления, последние - первыми
+func ToFavouritesResponse(favourites []*Favourite, records *UserItemRecords) jsonreqresp.FavouritesResponse {
+	resp := jsonreqresp.FavouritesResponse{
+		Artworks: []jsonreqresp.ArtworkResponse{},
+		Events:   []jsonreqresp.EventResponse{},
+	}
+	for _, f := range favourites {
+		switch f.entityType {
+		case EntityArtwork:
+			if a, ok := records.Artworks[f.entityID]; ok {
+				resp.Artworks = append(resp.Artworks, a.ToArtworkResponse())
+			}
+		case EntityEvent:
+			if e, ok := records.Events[f.entityID]; ok {
+				resp.Events = append(resp.Events, e.ToEventResponse())
+			}
+		}
+	}
+	return resp
+}
@@ -2,8 +2,12 @@ package artworkrep
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
@@ -14,19 +18,423 @@ import (
 var (
 	ErrArtworkNotFound = errors.New("the Artwork was not found in the repository")
 	ErrUpdateArtwork   = errors.New("err update artwork params")
+
+	ErrArtworkImageNotFound = errors.New("the Artwork image was not found in the repository")
+	ErrProvenanceNotFound   = errors.New("the provenance entry was not found in the repository")
+
+	ErrConditionReportNotFound = errors.New("the condition report was not found in the repository")
 )
 
 type ArtworkRep interface {
 	GetAllArtworks(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps) ([]*models.Artwork, error)
+	// GetArtworksPage возвращает страницу произведений; общее число подходящих под фильтр считается только для первой страницы
+	GetArtworksPage(
+		ctx context.Context,
+		filterOps *jsonreqresp.ArtworkFilter,
+		sortOps *jsonreqresp.ArtworkSortOps,
+		page *jsonreqresp.PageRequest,
+	) ([]*models.Artwork, jsonreqresp.PageInfo, error)
+	// GetArtworkFacets возвращает значения фасетов с количеством произведений, подходящих под фильтр.
+	// Количество для значения фасета считается без учета фильтра этого же фасета
+	GetArtworkFacets(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter) (*models.ArtworkFacets, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Artwork, error)
+	// GetSimilarCandidates возвращает до limit произведений, похожих на исходное, с признаками сходства.
+	// Произведения упорядочены по оценке models.DefaultSimilarityWeights
+	GetSimilarCandidates(ctx context.Context, artworkID uuid.UUID, limit int) ([]*models.SimilarityCandidate, error)
+	// GetEarliestUpdatedAt возвращает самое раннее время изменения произведения, нулевое время - произведений нет
+	GetEarliestUpdatedAt(ctx context.Context) (time.Time, error)
+	// GetExistingIDs возвращает id из ids, занятые произведениями, в том числе перенесенными в корзину
+	GetExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error)
 	//
 	Add(ctx context.Context, aw *models.Artwork) error
+	// Delete переносит произведение в корзину, текущие и будущие мероприятия с ним возвращаются в models.DependencyError
 	Delete(ctx context.Context, id uuid.UUID) error
-	Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Artwork) (*models.Artwork, error)) error
+	// Update сохраняет изменение вместе с версией change в истории изменений; nil - изменение без истории
+	Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Artwork) (*models.Artwork, error), change *models.Change) error
+	// ImportCatalog сохраняет авторов, коллекции и произведения импорта пачками по importBatchSize строк.
+	// В PostgreSQL импорт выполняется в одной транзакции
+	ImportCatalog(ctx context.Context, batch *models.CatalogImport) error
+	// изображения произведений, упорядоченные по позиции
+	GetImages(ctx context.Context, artworkID uuid.UUID) ([]*models.ArtworkImage, error)
+	AddImage(ctx context.Context, img *models.ArtworkImage) error
+	DeleteImage(ctx context.Context, artworkID uuid.UUID, imageID uuid.UUID) error
+	// UpdateImages сохраняет позиции и признак основного изображения для переданных изображений
+	UpdateImages(ctx context.Context, artworkID uuid.UUID, images []*models.ArtworkImage) error
+	// история владения произведением, упорядоченная по позиции
+	GetProvenance(ctx context.Context, artworkID uuid.UUID) ([]*models.ProvenanceEntry, error)
+	AddProvenanceEntry(ctx context.Context, entry *models.ProvenanceEntry) error
+	DeleteProvenanceEntry(ctx context.Context, artworkID uuid.UUID, entryID uuid.UUID) error
+	// UpdateProvenance сохраняет все поля переданных записей провенанса
+	UpdateProvenance(ctx context.Context, artworkID uuid.UUID, entries []*models.ProvenanceEntry) error
+	// отчеты о состоянии произведения вместе с фотографиями, новые первыми
+	GetConditionReports(ctx context.Context, artworkID uuid.UUID) ([]*models.ConditionReport, error)
+	GetConditionReport(ctx context.Context, artworkID uuid.UUID, reportID uuid.UUID) (*models.ConditionReport, error)
+	AddConditionReport(ctx context.Context, report *models.ConditionReport) error
+	// UpdateConditionReport сохраняет все поля отчета, кроме фотографий
+	UpdateConditionReport(ctx context.Context, report *models.ConditionReport) error
+	AddConditionPhoto(ctx context.Context, photo *models.ConditionPhoto) error
+	// GetTreatmentsOnDate возвращает реставрации произведения, пересекающиеся с периодом [dateBeg, dateEnd]
+	GetTreatmentsOnDate(ctx context.Context, artworkID uuid.UUID, dateBeg time.Time, dateEnd time.Time) ([]*models.ConditionReport, error)
+	// GetConditionReportsNeedingAttention возвращает незавершенные реставрации и
+	// последние отчеты произведений с плохой или критической оценкой
+	GetConditionReportsNeedingAttention(ctx context.Context) ([]*models.ConditionReport, error)
 	Ping(ctx context.Context) error
 	Close()
 }
 
+// importBatchSize число строк в одном запросе вставки при импорте каталога
+const importBatchSize = 500
+
+// importChunks делит записи импорта на пачки по importBatchSize
+func importChunks[T any](items []T) [][]T {
+	var chunks [][]T
+	for beg := 0; beg < len(items); beg += importBatchSize {
+		chunks = append(chunks, items[beg:min(beg+importBatchSize, len(items))])
+	}
+	return chunks
+}
+
+// deathYearValue возвращает NULL для живущих авторов
+func deathYearValue(a *models.Author) interface{} {
+	if a.GetDeathYear() == 0 {
+		return nil
+	}
+	return a.GetDeathYear()
+}
+
+// artworkColumns столбцы таблицы Artworks при вставке
+var artworkColumns = append(append([]string{
+	"id", "title", "technic", "material", "size", "creationYear", "authorID", "collectionID",
+}, dimensionsColumns...), datingColumns...)
+
+func artworkValues(a *models.Artwork) []interface{} {
+	values := append([]interface{}{a.GetID(), a.GetTitle(), a.GetTechnic(), a.GetMaterial(), a.GetSize(), a.GetCreationYear(),
+		a.GetAuthor().GetID(), a.GetCollection().GetID()}, dimensionsValues(a.GetDimensions())...)
+	return append(values, datingValues(a)...)
+}
+
+// provenanceColumns столбцы таблицы Artwork_provenance
+var provenanceColumns = []string{
+	"id", "artworkID", "position", "owner", "ownerUncertain", "location",
+	"periodFrom", "periodTo", "dateQualifier", "transferMethod", "sources", "notes", "isPublic",
+}
+
+// parseProvenanceRows разбирает строки выборки провенанса в порядке столбцов provenanceColumns
+func parseProvenanceRows(rows *sql.Rows) ([]*models.ProvenanceEntry, error) {
+	var entries []*models.ProvenanceEntry
+	for rows.Next() {
+		var id, artworkID uuid.UUID
+		var position, periodFrom, periodTo int
+		var owner, location, dateQualifier, transferMethod, sources, notes string
+		var ownerUncertain, isPublic bool
+		if err := rows.Scan(&id, &artworkID, &position, &owner, &ownerUncertain, &location,
+			&periodFrom, &periodTo, &dateQualifier, &transferMethod, &sources, &notes, &isPublic); err != nil {
+			return nil, fmt.Errorf("parseProvenanceRows: scan error: %v", err)
+		}
+		entry, err := models.NewProvenanceEntry(id, artworkID, position, owner, ownerUncertain, location,
+			periodFrom, periodTo, models.DateQualifier(dateQualifier), models.TransferMethod(transferMethod),
+			sources, notes, isPublic)
+		if err != nil {
+			return nil, fmt.Errorf("parseProvenanceRows: %w: %v", models.ErrValidateProvenance, err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseProvenanceRows: rows iteration error: %v", err)
+	}
+	return entries, nil
+}
+
+// provenanceValues возвращает значения записи провенанса в порядке столбцов provenanceColumns
+func provenanceValues(entry *models.ProvenanceEntry) []interface{} {
+	return []interface{}{
+		entry.GetID(), entry.GetArtworkID(), entry.GetPosition(), entry.GetOwner(), entry.IsOwnerUncertain(),
+		entry.GetLocation(), entry.GetPeriodFrom(), entry.GetPeriodTo(), string(entry.GetDateQualifier()),
+		string(entry.GetTransferMethod()), entry.GetSources(), entry.GetNotes(), entry.IsPublic(),
+	}
+}
+
+// conditionReportColumns столбцы таблицы Condition_reports
+var conditionReportColumns = []string{
+	"id", "artworkID", "checkedAt", "examiner", "grade", "notes", "treatment", "treatmentEnd",
+}
+
+// conditionPhotoColumns столбцы таблицы Condition_report_photos
+var conditionPhotoColumns = []string{"id", "reportID", "artworkID", "format", "createdAt"}
+
+// attentionGrades оценки состояния, при которых произведению требуется реставратор
+var attentionGrades = []string{string(models.ConditionPoor), string(models.ConditionCritical)}
+
+// parseConditionReportRows разбирает строки выборки отчетов в порядке столбцов conditionReportColumns
+func parseConditionReportRows(rows *sql.Rows) ([]*models.ConditionReport, error) {
+	var reports []*models.ConditionReport
+	for rows.Next() {
+		var id, artworkID uuid.UUID
+		var checkedAt time.Time
+		var treatmentEnd sql.NullTime
+		var examiner, grade, notes, treatment string
+		if err := rows.Scan(&id, &artworkID, &checkedAt, &examiner, &grade, &notes, &treatment, &treatmentEnd); err != nil {
+			return nil, fmt.Errorf("parseConditionReportRows: scan error: %v", err)
+		}
+		report, err := models.NewConditionReport(id, artworkID, checkedAt, examiner,
+			models.ConditionGrade(grade), notes, treatment, treatmentEnd.Time, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parseConditionReportRows: %w: %v", models.ErrValidateConditionReport, err)
+		}
+		reports = append(reports, &report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseConditionReportRows: rows iteration error: %v", err)
+	}
+	return reports, nil
+}
+
+// parseConditionPhotoRows разбирает строки выборки фотографий в порядке столбцов conditionPhotoColumns
+func parseConditionPhotoRows(rows *sql.Rows) ([]*models.ConditionPhoto, error) {
+	var photos []*models.ConditionPhoto
+	for rows.Next() {
+		var id, reportID, artworkID uuid.UUID
+		var format string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &reportID, &artworkID, &format, &createdAt); err != nil {
+			return nil, fmt.Errorf("parseConditionPhotoRows: scan error: %v", err)
+		}
+		photo, err := models.NewConditionPhoto(id, reportID, artworkID, models.ImageFormat(format), createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parseConditionPhotoRows: %w: %v", models.ErrValidateConditionPhoto, err)
+		}
+		photos = append(photos, &photo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseConditionPhotoRows: rows iteration error: %v", err)
+	}
+	return photos, nil
+}
+
+// conditionReportValues возвращает значения отчета в порядке столбцов conditionReportColumns
+func conditionReportValues(report *models.ConditionReport) []interface{} {
+	return []interface{}{
+		report.GetID(), report.GetArtworkID(), report.GetCheckedAt(), report.GetExaminer(),
+		string(report.GetGrade()), report.GetNotes(), report.GetTreatment(), optionalTime(report.GetTreatmentEnd()),
+	}
+}
+
+// optionalTime переводит нулевое время в NULL
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// reportIDs возвращает ID отчетов для выборки их фотографий
+func reportIDs(reports []*models.ConditionReport) uuid.UUIDs {
+	ids := make(uuid.UUIDs, len(reports))
+	for i, r := range reports {
+		ids[i] = r.GetID()
+	}
+	return ids
+}
+
+// attachConditionPhotos раскладывает фотографии по отчетам
+func attachConditionPhotos(reports []*models.ConditionReport, photos []*models.ConditionPhoto) {
+	byReport := make(map[uuid.UUID][]*models.ConditionPhoto, len(reports))
+	for _, p := range photos {
+		byReport[p.GetReportID()] = append(byReport[p.GetReportID()], p)
+	}
+	for _, r := range reports {
+		r.SetPhotos(byReport[r.GetID()])
+	}
+}
+
+// dimensionsColumns столбцы структурированного размера в таблице Artworks
+var dimensionsColumns = []string{"heightCm", "widthCm", "depthCm", "weightKg", "lengthUnit", "weightUnit"}
+
+// dimensionsRow значения столбцов dimensionsColumns, NULL - размер задан только текстом
+type dimensionsRow struct {
+	heightCm, widthCm, depthCm, weightKg sql.NullFloat64
+	lengthUnit, weightUnit               sql.NullString
+}
+
+func (r *dimensionsRow) dest() []any {
+	return []any{&r.heightCm, &r.widthCm, &r.depthCm, &r.weightKg, &r.lengthUnit, &r.weightUnit}
+}
+
+func (r *dimensionsRow) toDimensions() (models.Dimensions, error) {
+	if !r.heightCm.Valid {
+		return models.Dimensions{}, nil
+	}
+	return models.NewDimensions(r.heightCm.Float64, r.widthCm.Float64, r.depthCm.Float64, r.weightKg.Float64,
+		models.LengthUnit(r.lengthUnit.String), models.WeightUnit(r.weightUnit.String))
+}
+
+// dimensionsValues возвращает значения размера в порядке столбцов dimensionsColumns
+func dimensionsValues(d models.Dimensions) []interface{} {
+	if d.IsZero() {
+		return []interface{}{nil, nil, nil, nil, nil, nil}
+	}
+	return []interface{}{
+		d.GetHeightCm(), d.GetWidthCm(), d.GetDepthCm(), d.GetWeightKg(),
+		string(d.GetLengthUnit()), string(d.GetWeightUnit()),
+	}
+}
+
+// datingColumns столбцы датировки произведения и роли основного автора в таблице Artworks
+var datingColumns = []string{"yearFrom", "yearTo", "dateQualifier", "datePrecision", "authorRole"}
+
+// datingRow значения столбцов datingColumns
+type datingRow struct {
+	yearFrom, yearTo                 int
+	qualifier, precision, authorRole string
+}
+
+func (r *datingRow) dest() []any {
+	return []any{&r.yearFrom, &r.yearTo, &r.qualifier, &r.precision, &r.authorRole}
+}
+
+func (r *datingRow) toDating() (models.ArtworkDating, error) {
+	return models.NewArtworkDating(r.yearFrom, r.yearTo,
+		models.DateQualifier(r.qualifier), models.DatePrecision(r.precision))
+}
+
+// datingValues возвращает датировку и роль основного автора в порядке столбцов datingColumns
+func datingValues(a *models.Artwork) []interface{} {
+	d := a.GetDating()
+	return []interface{}{
+		d.GetYearFrom(), d.GetYearTo(), string(d.GetQualifier()), string(d.GetPrecision()), string(a.GetAuthorRole()),
+	}
+}
+
+// parseCoAuthorRows разбирает строки выборки дополнительных авторов:
+// artworkID, role и столбцы автора, упорядоченные по позиции
+func parseCoAuthorRows(rows *sql.Rows) (map[uuid.UUID][]models.Attribution, error) {
+	byArtwork := make(map[uuid.UUID][]models.Attribution)
+	for rows.Next() {
+		var artworkID, authorID uuid.UUID
+		var role, name string
+		var birthYear int
+		var deathYear sql.NullInt64
+		if err := rows.Scan(&artworkID, &role, &authorID, &name, &birthYear, &deathYear); err != nil {
+			return nil, fmt.Errorf("parseCoAuthorRows: scan error: %v", err)
+		}
+		author, err := models.NewAuthor(authorID, name, birthYear, int(deathYear.Int64))
+		if err != nil {
+			return nil, fmt.Errorf("parseCoAuthorRows: %v", err)
+		}
+		attribution, err := models.NewAttribution(&author, models.AttributionRole(role))
+		if err != nil {
+			return nil, fmt.Errorf("parseCoAuthorRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		byArtwork[artworkID] = append(byArtwork[artworkID], attribution)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseCoAuthorRows: rows iteration error: %v", err)
+	}
+	return byArtwork, nil
+}
+
+// setCoAuthors раскладывает дополнительных авторов по произведениям
+func setCoAuthors(arts []*models.Artwork, byArtwork map[uuid.UUID][]models.Attribution) error {
+	for _, a := range arts {
+		if err := a.SetCoAuthors(byArtwork[a.GetID()]); err != nil {
+			return fmt.Errorf("setCoAuthors: %w: %v", models.ErrValidateArtwork, err)
+		}
+	}
+	return nil
+}
+
+// parseArtworkTagRows разбирает строки выборки тегов произведений:
+// artworkID, id, name, kind, parentID и синонимы через перевод строки
+func parseArtworkTagRows(rows *sql.Rows) (map[uuid.UUID][]*models.Tag, error) {
+	byArtwork := make(map[uuid.UUID][]*models.Tag)
+	for rows.Next() {
+		var artworkID, id uuid.UUID
+		var parentID uuid.NullUUID
+		var name, kind, synonyms string
+		if err := rows.Scan(&artworkID, &id, &name, &kind, &parentID, &synonyms); err != nil {
+			return nil, fmt.Errorf("parseArtworkTagRows: scan error: %v", err)
+		}
+		tag, err := models.NewTag(id, name, models.TagKind(kind), parentID.UUID, splitSynonyms(synonyms))
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworkTagRows: %w: %v", models.ErrValidateTag, err)
+		}
+		byArtwork[artworkID] = append(byArtwork[artworkID], &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseArtworkTagRows: rows iteration error: %v", err)
+	}
+	return byArtwork, nil
+}
+
+func splitSynonyms(synonyms string) []string {
+	if synonyms == "" {
+		return nil
+	}
+	return strings.Split(synonyms, "\n")
+}
+
+// setTags раскладывает теги по произведениям
+func setTags(arts []*models.Artwork, byArtwork map[uuid.UUID][]*models.Tag) {
+	for _, a := range arts {
+		a.SetTags(byArtwork[a.GetID()])
+	}
+}
+
+// dimensionSortColumns столбцы размера для полей сортировки по размеру
+var dimensionSortColumns = map[string]string{
+	jsonreqresp.HeightSortFieldArtwork: "heightCm",
+	jsonreqresp.WidthSortFieldArtwork:  "widthCm",
+	jsonreqresp.DepthSortFieldArtwork:  "depthCm",
+	jsonreqresp.WeightSortFieldArtwork: "weightKg",
+}
+
+// dimensionFilter фильтр по диапазону значений столбца размера
+type dimensionFilter struct {
+	column string
+	bounds jsonreqresp.DimensionRange
+}
+
+// dimensionFilters возвращает заданные в фильтре диапазоны размеров
+func dimensionFilters(filterOps *jsonreqresp.ArtworkFilter) []dimensionFilter {
+	var filters []dimensionFilter
+	for _, f := range []dimensionFilter{
+		{"heightCm", filterOps.Height},
+		{"widthCm", filterOps.Width},
+		{"depthCm", filterOps.Depth},
+		{"weightKg", filterOps.Weight},
+	} {
+		if f.bounds.IsSet() {
+			filters = append(filters, f)
+		}
+	}
+	return filters
+}
+
+// labelCenturies заменяет номер века в подписи значения фасета его названием
+func labelCenturies(buckets []models.FacetBucket) {
+	for i := range buckets {
+		if century, err := strconv.Atoi(buckets[i].Value); err == nil {
+			buckets[i].Label = models.CenturyLabel(century)
+		}
+	}
+}
+
+// parseDependentRows читает пары (id, название) записей, которые мешают удалить произведение
+func parseDependentRows(rows *sql.Rows, entityType models.EntityType) ([]models.DependentRecord, error) {
+	var res []models.DependentRecord
+	for rows.Next() {
+		r := models.DependentRecord{EntityType: entityType}
+		if err := rows.Scan(&r.ID, &r.Title); err != nil {
+			return nil, fmt.Errorf("parseDependentRows: scan error: %v", err)
+		}
+		res = append(res, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return res, nil
+}
+
 func NewArtworkRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (ArtworkRep, error) {
 	if datebaseType == cnfg.PostgresDB {
 		return NewPgArtworkRep(ctx, pgCreds, dbConf)
@@ -4,12 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/google/uuid"
 )
@@ -60,17 +63,28 @@ func NewCHArtworkRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, d
 	return chInstance, nil
 }
 
-func (ch *CHArtworkRep) parseArtworksRows(rows *sql.Rows) ([]*models.Artwork, error) {
+// parseArtworksRows разбирает строки выборки произведений.
+// Если sortKeys не nil, последним столбцом ожидается ключ сортировки для курсора страницы.
+func (ch *CHArtworkRep) parseArtworksRows(rows *sql.Rows, sortKeys *[]string) ([]*models.Artwork, error) {
 	var resArtworks []*models.Artwork
 	for rows.Next() {
 		var id, authorID, collectionID uuid.UUID
-		var title, authorName, collectionTitle, size, material, technic string
-		var creationYear, authorBirthYear int32
+		var title, authorName, collectionTitle, size, material, technic, sortKey string
+		var authorBirthYear int32
 		var authorDeathYear sql.NullInt32
+		var dimensions dimensionsRow
+		var dating datingRow
+		var updatedAt time.Time
 
-		if err := rows.Scan(&id, &title, &technic, &material, &size, &creationYear,
-			&authorID, &authorName, &authorBirthYear, &authorDeathYear,
-			&collectionID, &collectionTitle); err != nil {
+		dest := append([]any{&id, &title, &technic, &material, &size}, dimensions.dest()...)
+		dest = append(dest, dating.dest()...)
+		dest = append(dest, &updatedAt)
+		dest = append(dest, &authorID, &authorName, &authorBirthYear, &authorDeathYear,
+			&collectionID, &collectionTitle)
+		if sortKeys != nil {
+			dest = append(dest, &sortKey)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("parseArtworksRows: scan error: %v", err)
 		}
 
@@ -89,11 +103,28 @@ func (ch *CHArtworkRep) parseArtworksRows(rows *sql.Rows) ([]*models.Artwork, er
 			return nil, fmt.Errorf("parseArtworksRows: %v", err)
 		}
 
-		artwork, err := models.NewArtwork(id, title, technic, material, size, int(creationYear), &author, &collection)
+		artDating, err := dating.toDating()
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		attribution, err := models.NewAttribution(&author, models.AttributionRole(dating.authorRole))
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		artwork, err := models.NewDatedArtwork(id, title, technic, material, size, artDating, attribution, nil, &collection)
 		if err != nil {
 			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
 		}
+		artDimensions, err := dimensions.toDimensions()
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		artwork.SetDimensions(artDimensions)
+		artwork.SetUpdatedAt(updatedAt)
 		resArtworks = append(resArtworks, &artwork)
+		if sortKeys != nil {
+			*sortKeys = append(*sortKeys, sortKey)
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("parseArtworksRows: rows iteration error: %v", err)
@@ -101,8 +132,52 @@ func (ch *CHArtworkRep) parseArtworksRows(rows *sql.Rows) ([]*models.Artwork, er
 	return resArtworks, nil
 }
 
+const (
+	// chSearchText - текст произведения для поиска, совпадает с выражением индексов пропуска
+	chSearchText = "lowerUTF8(concat(Artworks.title, ' ', ifNull(Artworks.technic, ''), ' ', ifNull(Artworks.material, '')))"
+	// chFuzzyThreshold - доля n-грамм запроса, которые должны найтись в имени автора или названии
+	chFuzzyThreshold = 0.6
+)
+
+// buildSearchCondition возвращает условие полнотекстового поиска: каждая основа слова запроса
+// должна встретиться в произведении, авторе или коллекции, либо запрос нечетко совпадает
+// с именем автора или названием (опечатки)
+func (ch *CHArtworkRep) buildSearchCondition(searchQuery string) (string, []interface{}) {
+	var termConds []string
+	var args []interface{}
+	for _, term := range models.SearchTerms(searchQuery) {
+		termConds = append(termConds, "(positionUTF8("+chSearchText+", ?) > 0 OR "+
+			"positionUTF8(lowerUTF8(Author.name), ?) > 0 OR positionUTF8(lowerUTF8(Collection.title), ?) > 0)")
+		args = append(args, term, term, term)
+	}
+
+	fuzzy := fmt.Sprintf("ngramSearchCaseInsensitiveUTF8(Author.name, ?) > %[1]v OR "+
+		"ngramSearchCaseInsensitiveUTF8(Artworks.title, ?) > %[1]v", chFuzzyThreshold)
+	if len(termConds) == 0 {
+		return "(" + fuzzy + ")", append(args, searchQuery, searchQuery)
+	}
+	return "((" + joinConditions(termConds, " AND ") + ") OR " + fuzzy + ")", append(args, searchQuery, searchQuery)
+}
+
+// buildRankExpr возвращает выражение релевантности: совпадения в названии весят больше,
+// чем в авторе, коллекции, технике и материале
+func (ch *CHArtworkRep) buildRankExpr(searchQuery string) (string, []interface{}) {
+	parts := []string{"0.5 * greatest(ngramSearchCaseInsensitiveUTF8(Author.name, ?), ngramSearchCaseInsensitiveUTF8(Artworks.title, ?))"}
+	args := []interface{}{searchQuery, searchQuery}
+	for _, term := range models.SearchTerms(searchQuery) {
+		parts = append(parts,
+			"(positionUTF8(lowerUTF8(Artworks.title), ?) > 0) * 1.0",
+			"(positionUTF8(lowerUTF8(Author.name), ?) > 0) * 0.4",
+			"(positionUTF8(lowerUTF8(Collection.title), ?) > 0) * 0.2",
+			"(positionUTF8("+chSearchText+", ?) > 0) * 0.1")
+		args = append(args, term, term, term, term)
+	}
+	return "(" + joinConditions(parts, " + ") + ")", args
+}
+
 func (ch *CHArtworkRep) buildFilterConditions(filterOps *jsonreqresp.ArtworkFilter) (string, []interface{}) {
-	var conditions []string
+	// произведения из корзины не показываются
+	conditions := []string{"Artworks.deletedAt IS NULL"}
 	var args []interface{}
 
 	if filterOps.Title != "" {
@@ -110,8 +185,11 @@ func (ch *CHArtworkRep) buildFilterConditions(filterOps *jsonreqresp.ArtworkFilt
 		args = append(args, "%"+filterOps.Title+"%")
 	}
 	if filterOps.AuthorName != "" {
-		conditions = append(conditions, "Author.name LIKE ?")
-		args = append(args, "%"+filterOps.AuthorName+"%")
+		// имя основного или дополнительного автора
+		conditions = append(conditions, "(Author.name LIKE ? OR Artworks.id IN ("+
+			"SELECT aa.artworkID FROM Artwork_authors aa JOIN Author coauthor ON aa.authorID = coauthor.id "+
+			"WHERE coauthor.name LIKE ?))")
+		args = append(args, "%"+filterOps.AuthorName+"%", "%"+filterOps.AuthorName+"%")
 	}
 	if filterOps.Collection != "" {
 		conditions = append(conditions, "Collection.title LIKE ?")
@@ -121,6 +199,75 @@ func (ch *CHArtworkRep) buildFilterConditions(filterOps *jsonreqresp.ArtworkFilt
 		conditions = append(conditions, "EXISTS (SELECT 1 FROM Artwork_event ae WHERE Artworks.id = ae.artworkID AND ae.eventID = ?)")
 		args = append(args, filterOps.EventID)
 	}
+	if filterOps.Query != "" {
+		condition, searchArgs := ch.buildSearchCondition(filterOps.Query)
+		conditions = append(conditions, condition)
+		args = append(args, searchArgs...)
+	}
+	if len(filterOps.Technics) > 0 {
+		condition, inArgs := inCondition("Artworks.technic", filterOps.Technics)
+		conditions = append(conditions, condition)
+		args = append(args, inArgs...)
+	}
+	if len(filterOps.Materials) > 0 {
+		condition, inArgs := inCondition("Artworks.material", filterOps.Materials)
+		conditions = append(conditions, condition)
+		args = append(args, inArgs...)
+	}
+	if len(filterOps.Centuries) > 0 {
+		centuries := make([]string, len(filterOps.Centuries))
+		for i, century := range filterOps.Centuries {
+			from, to := models.CenturyYears(century)
+			centuries[i] = "Artworks.creationYear BETWEEN ? AND ?"
+			args = append(args, from, to)
+		}
+		conditions = append(conditions, "("+joinConditions(centuries, " OR ")+")")
+	}
+	if len(filterOps.AuthorIDs) > 0 {
+		condition, inArgs := inCondition("Artworks.authorID", filterOps.AuthorIDs)
+		conditions = append(conditions, condition)
+		args = append(args, inArgs...)
+	}
+	if len(filterOps.CollectionIDs) > 0 && filterOps.IncludeSubcollections {
+		condition, colArgs := collectionSubtreeCondition(filterOps.CollectionIDs)
+		conditions = append(conditions, condition)
+		args = append(args, colArgs...)
+	} else if len(filterOps.CollectionIDs) > 0 {
+		condition, inArgs := inCondition("Artworks.collectionID", filterOps.CollectionIDs)
+		conditions = append(conditions, condition)
+		args = append(args, inArgs...)
+	}
+	if len(filterOps.TagIDs) > 0 {
+		condition, tagArgs := tagSubtreeCondition(filterOps.TagIDs)
+		conditions = append(conditions, condition)
+		args = append(args, tagArgs...)
+	}
+	if filterOps.YearFrom > 0 {
+		conditions = append(conditions, "Artworks.creationYear >= ?")
+		args = append(args, filterOps.YearFrom)
+	}
+	if filterOps.YearTo > 0 {
+		conditions = append(conditions, "Artworks.creationYear <= ?")
+		args = append(args, filterOps.YearTo)
+	}
+	if !filterOps.UpdatedFrom.IsZero() {
+		conditions = append(conditions, "Artworks.updatedAt >= ?")
+		args = append(args, filterOps.UpdatedFrom)
+	}
+	if !filterOps.UpdatedBefore.IsZero() {
+		conditions = append(conditions, "Artworks.updatedAt < ?")
+		args = append(args, filterOps.UpdatedBefore)
+	}
+	for _, f := range dimensionFilters(filterOps) {
+		if f.bounds.Min > 0 {
+			conditions = append(conditions, "Artworks."+f.column+" >= ?")
+			args = append(args, f.bounds.Min)
+		}
+		if f.bounds.Max > 0 {
+			conditions = append(conditions, "Artworks."+f.column+" <= ?")
+			args = append(args, f.bounds.Max)
+		}
+	}
 
 	if len(conditions) == 0 {
 		return "", nil
@@ -129,6 +276,53 @@ func (ch *CHArtworkRep) buildFilterConditions(filterOps *jsonreqresp.ArtworkFilt
 	return "WHERE " + joinConditions(conditions, " AND "), args
 }
 
+// tagSubtreeCondition отбирает произведения, отмеченные одним из тегов или любым их потомком.
+// Рекурсивные запросы не используются: цепочка предков тега разворачивается на models.TagMaxDepth уровней
+func tagSubtreeCondition(tagIDs uuid.UUIDs) (string, []interface{}) {
+	joins := "Artwork_tags at JOIN Tags t0 ON at.tagID = t0.id"
+	matches := make([]string, models.TagMaxDepth)
+	var args []interface{}
+	for level := 0; level < models.TagMaxDepth; level++ {
+		alias := "t" + strconv.Itoa(level)
+		if level > 0 {
+			joins += " LEFT JOIN Tags " + alias + " ON t" + strconv.Itoa(level-1) + ".parentID = " + alias + ".id"
+		}
+		condition, inArgs := inCondition(alias+".id", tagIDs)
+		matches[level] = condition
+		args = append(args, inArgs...)
+	}
+	return "Artworks.id IN (SELECT at.artworkID FROM " + joins + " WHERE " + joinConditions(matches, " OR ") + ")", args
+}
+
+// collectionSubtreeCondition отбирает произведения из коллекций или любых вложенных в них коллекций,
+// цепочка родителей коллекции разворачивается на models.CollectionMaxDepth уровней
+func collectionSubtreeCondition(collectionIDs uuid.UUIDs) (string, []interface{}) {
+	joins := "Collection c0"
+	matches := make([]string, models.CollectionMaxDepth)
+	var args []interface{}
+	for level := 0; level < models.CollectionMaxDepth; level++ {
+		alias := "c" + strconv.Itoa(level)
+		if level > 0 {
+			joins += " LEFT JOIN Collection " + alias + " ON c" + strconv.Itoa(level-1) + ".parentID = " + alias + ".id"
+		}
+		condition, inArgs := inCondition(alias+".id", collectionIDs)
+		matches[level] = condition
+		args = append(args, inArgs...)
+	}
+	return "Artworks.collectionID IN (SELECT c0.id FROM " + joins + " WHERE " + joinConditions(matches, " OR ") + ")", args
+}
+
+// inCondition возвращает условие column IN (?, ...) для непустого списка значений
+func inCondition[T any](column string, values []T) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		placeholders[i] = "?"
+		args[i] = v
+	}
+	return column + " IN (" + joinConditions(placeholders, ", ") + ")", args
+}
+
 func joinConditions(conditions []string, sep string) string {
 	if len(conditions) == 0 {
 		return ""
@@ -144,28 +338,111 @@ func joinConditions(conditions []string, sep string) string {
 	return result
 }
 
-func (ch *CHArtworkRep) buildSortClause(sortOps *jsonreqresp.ArtworkSortOps) string {
+func (ch *CHArtworkRep) buildSortClause(
+	filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps,
+) (string, []interface{}) {
 	if sortOps == nil {
-		return ""
+		return "", nil
 	}
 
 	switch sortOps.Field {
 	case jsonreqresp.TitleSortFieldArtwork:
-		return "ORDER BY Artworks.title " + sortOps.Direction
+		return "ORDER BY Artworks.title " + sortOps.Direction, nil
 	case jsonreqresp.AuthorNameSortFieldArtwork:
-		return "ORDER BY Author.name " + sortOps.Direction
+		return "ORDER BY Author.name " + sortOps.Direction, nil
 	case jsonreqresp.CreationYearSortFieldArtwork:
-		return "ORDER BY Artworks.creationYear " + sortOps.Direction
-	default:
-		return ""
+		return "ORDER BY Artworks.creationYear " + sortOps.Direction, nil
+	case jsonreqresp.CollectionTitleSortFieldArtwork:
+		return "ORDER BY Collection.title " + sortOps.Direction, nil
+	case jsonreqresp.RelevanceSortFieldArtwork, jsonreqresp.HeightSortFieldArtwork, jsonreqresp.WidthSortFieldArtwork,
+		jsonreqresp.DepthSortFieldArtwork, jsonreqresp.WeightSortFieldArtwork:
+		if expr, args := ch.sortExpr(sortOps.Field, filterOps); expr != "" {
+			return "ORDER BY " + expr + " " + jsonreqresp.NormSortDirection(sortOps.Direction), args
+		}
 	}
+	return "", nil
+}
+
+// sortExpr возвращает выражение поля сортировки и его аргументы
+func (ch *CHArtworkRep) sortExpr(field string, filterOps *jsonreqresp.ArtworkFilter) (string, []interface{}) {
+	switch field {
+	case jsonreqresp.TitleSortFieldArtwork:
+		return "Artworks.title", nil
+	case jsonreqresp.AuthorNameSortFieldArtwork:
+		return "Author.name", nil
+	case jsonreqresp.CreationYearSortFieldArtwork:
+		return "Artworks.creationYear", nil
+	case jsonreqresp.CollectionTitleSortFieldArtwork:
+		return "Collection.title", nil
+	case jsonreqresp.RelevanceSortFieldArtwork:
+		// без поискового запроса релевантность не определена
+		if filterOps != nil && filterOps.Query != "" {
+			return ch.buildRankExpr(filterOps.Query)
+		}
+	case jsonreqresp.HeightSortFieldArtwork, jsonreqresp.WidthSortFieldArtwork,
+		jsonreqresp.DepthSortFieldArtwork, jsonreqresp.WeightSortFieldArtwork:
+		return "ifNull(Artworks." + dimensionSortColumns[field] + ", 0)", nil
+	}
+	return "", nil
+}
+
+// buildPageClause возвращает условие keyset-пагинации и порядок (поле сортировки, id)
+func (ch *CHArtworkRep) buildPageClause(
+	filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps, cursor *jsonreqresp.PageCursor,
+) (string, string, []interface{}, []interface{}, error) {
+	expr, exprArgs := ch.sortExpr(sortOps.Field, filterOps)
+	direction := jsonreqresp.NormSortDirection(sortOps.Direction)
+	op := ">"
+	if direction == jsonreqresp.DESCDirection {
+		op = "<"
+	}
+
+	var condition string
+	var args []interface{}
+	if cursor != nil {
+		switch {
+		case expr == "":
+			condition = "Artworks.id " + op + " toUUID(?)"
+			args = append(args, cursor.ID.String())
+		case sortOps.Field == jsonreqresp.CreationYearSortFieldArtwork:
+			year, err := cursor.IntValue()
+			if err != nil {
+				return "", "", nil, nil, err
+			}
+			condition = "(" + expr + ", Artworks.id) " + op + " (?, toUUID(?))"
+			args = append(args, year, cursor.ID.String())
+		case sortOps.Field == jsonreqresp.RelevanceSortFieldArtwork || dimensionSortColumns[sortOps.Field] != "":
+			value, err := cursor.FloatValue()
+			if err != nil {
+				return "", "", nil, nil, err
+			}
+			condition = "(" + expr + ", Artworks.id) " + op + " (?, toUUID(?))"
+			args = append(append(args, exprArgs...), value, cursor.ID.String())
+		default:
+			condition = "(" + expr + ", Artworks.id) " + op + " (?, toUUID(?))"
+			args = append(args, cursor.Value, cursor.ID.String())
+		}
+	}
+
+	order := "ORDER BY "
+	var orderArgs []interface{}
+	if expr != "" {
+		order += expr + " " + direction + ", "
+		orderArgs = exprArgs
+	}
+	order += "Artworks.id " + direction
+	return condition, order, args, orderArgs, nil
 }
 
 func (ch *CHArtworkRep) GetAllArtworks(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps) ([]*models.Artwork, error) {
 	baseQuery := `
 		SELECT 
 			Artworks.id, Artworks.title, Artworks.technic, Artworks.material,
-			Artworks.size, Artworks.creationYear,
+			Artworks.size,
+			Artworks.heightCm, Artworks.widthCm, Artworks.depthCm, Artworks.weightKg,
+			Artworks.lengthUnit, Artworks.weightUnit,
+			Artworks.yearFrom, Artworks.yearTo, Artworks.dateQualifier, Artworks.datePrecision, Artworks.authorRole,
+			Artworks.updatedAt,
 			Author.id, Author.name, Author.birthYear, Author.deathYear,
 			Collection.id, Collection.title
 		FROM Artworks
@@ -173,7 +450,7 @@ func (ch *CHArtworkRep) GetAllArtworks(ctx context.Context, filterOps *jsonreqre
 		JOIN Collection ON Artworks.collectionID = Collection.id`
 
 	filterClause, filterArgs := ch.buildFilterConditions(filterOps)
-	sortClause := ch.buildSortClause(sortOps)
+	sortClause, sortArgs := ch.buildSortClause(filterOps, sortOps)
 
 	query := baseQuery
 	if filterClause != "" {
@@ -183,30 +460,269 @@ func (ch *CHArtworkRep) GetAllArtworks(ctx context.Context, filterOps *jsonreqre
 		query += " " + sortClause
 	}
 
-	rows, err := ch.db.QueryContext(ctx, query, filterArgs...)
+	rows, err := ch.db.QueryContext(ctx, query, append(filterArgs, sortArgs...)...)
 	if err != nil {
 		return nil, fmt.Errorf("CHArtworkRep.GetAllArtworks: %w: %v", ErrQueryExec, err)
 	}
 	defer rows.Close()
 
-	arts, err := ch.parseArtworksRows(rows)
+	arts, err := ch.parseArtworksRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("CHArtworkRep.GetAllArtworks: %w", err)
 	}
+	if err := ch.loadImages(ctx, arts); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetAllArtworks: %w", err)
+	}
+	if err := ch.loadCoAuthors(ctx, arts); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetAllArtworks: %w", err)
+	}
+	if err := ch.loadTags(ctx, arts); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetAllArtworks: %w", err)
+	}
 	return arts, nil
 }
 
+func (ch *CHArtworkRep) GetArtworksPage(
+	ctx context.Context,
+	filterOps *jsonreqresp.ArtworkFilter,
+	sortOps *jsonreqresp.ArtworkSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Artwork, jsonreqresp.PageInfo, error) {
+	if sortOps == nil {
+		sortOps = &jsonreqresp.ArtworkSortOps{}
+	}
+	limit := page.PageLimit()
+	pageInfo := jsonreqresp.PageInfo{Limit: limit}
+	cursor, err := page.DecodeCursor()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w", err)
+	}
+
+	fromClause := `
+		FROM Artworks
+		JOIN Author ON Artworks.authorID = Author.id
+		JOIN Collection ON Artworks.collectionID = Collection.id`
+	filterClause, filterArgs := ch.buildFilterConditions(filterOps)
+
+	// полный подсчет по фильтру нужен только первой странице
+	if page.IsFirst() {
+		var total uint64
+		countQuery := "SELECT count()" + fromClause + " " + filterClause
+		if err := ch.db.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&total); err != nil {
+			return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w: %v", ErrQueryExec, err)
+		}
+		pageInfo.Total = int(total)
+		pageInfo.HasTotal = true
+	}
+
+	pageCondition, orderClause, pageArgs, orderArgs, err := ch.buildPageClause(filterOps, sortOps, cursor)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w", err)
+	}
+	if pageCondition != "" {
+		if filterClause == "" {
+			filterClause = "WHERE " + pageCondition
+		} else {
+			filterClause += " AND " + pageCondition
+		}
+	}
+
+	keyExpr := "''"
+	keyArgs, queryArgs := []interface{}{}, []interface{}{}
+	if expr, args := ch.sortExpr(sortOps.Field, filterOps); expr != "" {
+		keyExpr = "toString(" + expr + ")"
+		keyArgs = args
+	}
+	query := `
+		SELECT
+			Artworks.id, Artworks.title, Artworks.technic, Artworks.material,
+			Artworks.size,
+			Artworks.heightCm, Artworks.widthCm, Artworks.depthCm, Artworks.weightKg,
+			Artworks.lengthUnit, Artworks.weightUnit,
+			Artworks.yearFrom, Artworks.yearTo, Artworks.dateQualifier, Artworks.datePrecision, Artworks.authorRole,
+			Artworks.updatedAt,
+			Author.id, Author.name, Author.birthYear, Author.deathYear,
+			Collection.id, Collection.title, ` + keyExpr + fromClause + " " + filterClause + " " +
+		orderClause + fmt.Sprintf(" LIMIT %d", limit+1)
+
+	queryArgs = append(queryArgs, keyArgs...)
+	queryArgs = append(queryArgs, filterArgs...)
+	queryArgs = append(queryArgs, pageArgs...)
+	queryArgs = append(queryArgs, orderArgs...)
+	rows, err := ch.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var sortKeys []string
+	arts, err := ch.parseArtworksRows(rows, &sortKeys)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w", err)
+	}
+	if len(arts) > limit {
+		arts = arts[:limit]
+		pageInfo.NextCursor = jsonreqresp.EncodeCursor(sortKeys[limit-1], arts[limit-1].GetID())
+	}
+	if err := ch.loadImages(ctx, arts); err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w", err)
+	}
+	if err := ch.loadCoAuthors(ctx, arts); err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w", err)
+	}
+	if err := ch.loadTags(ctx, arts); err != nil {
+		return nil, pageInfo, fmt.Errorf("CHArtworkRep.GetArtworksPage: %w", err)
+	}
+	ch.setHighlights(arts, filterOps.Query)
+	return arts, pageInfo, nil
+}
+
+// facetBuckets считает произведения, подходящие под фильтр, по значениям выражения valueExpr
+func (ch *CHArtworkRep) facetBuckets(
+	ctx context.Context,
+	filterOps *jsonreqresp.ArtworkFilter,
+	valueExpr string,
+	labelExpr string,
+	orderBy string,
+) ([]models.FacetBucket, error) {
+	filterClause, filterArgs := ch.buildFilterConditions(filterOps)
+	valueCondition := "ifNull(" + valueExpr + ", '') != ''"
+	if filterClause == "" {
+		filterClause = "WHERE " + valueCondition
+	} else {
+		filterClause += " AND " + valueCondition
+	}
+	query := `
+		SELECT ` + valueExpr + `, ` + labelExpr + `, count()
+		FROM Artworks
+		JOIN Author ON Artworks.authorID = Author.id
+		JOIN Collection ON Artworks.collectionID = Collection.id
+		` + filterClause + `
+		GROUP BY ` + valueExpr + `, ` + labelExpr + `
+		ORDER BY ` + orderBy + fmt.Sprintf(" LIMIT %d", models.ArtworkFacetMaxBuckets)
+
+	rows, err := ch.db.QueryContext(ctx, query, filterArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var buckets []models.FacetBucket
+	for rows.Next() {
+		var bucket models.FacetBucket
+		var value, label sql.NullString
+		var count uint64
+		if err := rows.Scan(&value, &label, &count); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+		}
+		bucket.Value, bucket.Label, bucket.Count = value.String, label.String, int(count)
+		buckets = append(buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return buckets, nil
+}
+
+func (ch *CHArtworkRep) GetArtworkFacets(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter) (*models.ArtworkFacets, error) {
+	const centuryExpr = "toString(intDiv(Artworks.creationYear - 1, 100) + 1)"
+	facets := &models.ArtworkFacets{}
+	specs := []struct {
+		facet     string
+		dest      *[]models.FacetBucket
+		valueExpr string
+		labelExpr string
+		orderBy   string
+	}{
+		{jsonreqresp.TechnicFacetArtwork, &facets.Technics, "Artworks.technic", "Artworks.technic", "count() DESC, Artworks.technic"},
+		{jsonreqresp.MaterialFacetArtwork, &facets.Materials, "Artworks.material", "Artworks.material", "count() DESC, Artworks.material"},
+		{jsonreqresp.CenturyFacetArtwork, &facets.Centuries, centuryExpr, centuryExpr, "min(Artworks.creationYear)"},
+		{jsonreqresp.AuthorFacetArtwork, &facets.Authors, "toString(Author.id)", "Author.name", "count() DESC, Author.name"},
+		{jsonreqresp.CollectionFacetArtwork, &facets.Collections, "toString(Collection.id)", "Collection.title", "count() DESC, Collection.title"},
+	}
+	for _, spec := range specs {
+		buckets, err := ch.facetBuckets(ctx, filterOps.WithoutFacet(spec.facet), spec.valueExpr, spec.labelExpr, spec.orderBy)
+		if err != nil {
+			return nil, fmt.Errorf("CHArtworkRep.GetArtworkFacets: %s: %w", spec.facet, err)
+		}
+		*spec.dest = buckets
+	}
+	labelCenturies(facets.Centuries)
+
+	filterClause, filterArgs := ch.buildFilterConditions(filterOps.WithoutFacet(jsonreqresp.YearFromParamArtwork))
+	yearQuery := `
+		SELECT min(Artworks.creationYear), max(Artworks.creationYear)
+		FROM Artworks
+		JOIN Author ON Artworks.authorID = Author.id
+		JOIN Collection ON Artworks.collectionID = Collection.id
+		` + filterClause
+	var yearMin, yearMax int32
+	if err := ch.db.QueryRowContext(ctx, yearQuery, filterArgs...).Scan(&yearMin, &yearMax); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetArtworkFacets: %w: %v", ErrQueryExec, err)
+	}
+	facets.YearMin, facets.YearMax = int(yearMin), int(yearMax)
+	return facets, nil
+}
+
+func (ch *CHArtworkRep) GetEarliestUpdatedAt(ctx context.Context) (time.Time, error) {
+	// min() по пустой таблице в ClickHouse возвращает начало эпохи, поэтому количество читается вместе с ним
+	var cnt uint64
+	var earliest time.Time
+	if err := ch.db.QueryRowContext(ctx, "SELECT count(), min(updatedAt) FROM Artworks WHERE deletedAt IS NULL").Scan(&cnt, &earliest); err != nil {
+		return time.Time{}, fmt.Errorf("CHArtworkRep.GetEarliestUpdatedAt: %w: %v", ErrQueryExec, err)
+	}
+	if cnt == 0 {
+		return time.Time{}, nil
+	}
+	return earliest, nil
+}
+
+func (ch *CHArtworkRep) GetExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error) {
+	var existing uuid.UUIDs
+	for _, chunk := range importChunks(ids) {
+		placeholders := make([]string, len(chunk))
+		args := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+		query := "SELECT id FROM Artworks WHERE id IN (" + strings.Join(placeholders, ", ") + ")"
+		rows, err := ch.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("CHArtworkRep.GetExistingIDs: %w: %v", ErrQueryExec, err)
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("CHArtworkRep.GetExistingIDs: %v", err)
+			}
+			existing = append(existing, id)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("CHArtworkRep.GetExistingIDs rows iteration error: %v", err)
+		}
+	}
+	return existing, nil
+}
+
 func (ch *CHArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artwork, error) {
 	query := `
 		SELECT 
 			Artworks.id, Artworks.title, Artworks.technic, Artworks.material,
-			Artworks.size, Artworks.creationYear,
+			Artworks.size,
+			Artworks.heightCm, Artworks.widthCm, Artworks.depthCm, Artworks.weightKg,
+			Artworks.lengthUnit, Artworks.weightUnit,
+			Artworks.yearFrom, Artworks.yearTo, Artworks.dateQualifier, Artworks.datePrecision, Artworks.authorRole,
+			Artworks.updatedAt,
 			Author.id, Author.name, Author.birthYear, Author.deathYear,
 			Collection.id, Collection.title
 		FROM Artworks
 		JOIN Author ON Artworks.authorID = Author.id
 		JOIN Collection ON Artworks.collectionID = Collection.id
-		WHERE Artworks.id = ?`
+		WHERE Artworks.id = ? AND Artworks.deletedAt IS NULL`
 
 	rows, err := ch.db.QueryContext(ctx, query, id)
 	if err != nil {
@@ -214,7 +730,7 @@ func (ch *CHArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artw
 	}
 	defer rows.Close()
 
-	arts, err := ch.parseArtworksRows(rows)
+	arts, err := ch.parseArtworksRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("CHArtworkRep.GetByID: %w", err)
 	}
@@ -224,6 +740,15 @@ func (ch *CHArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artw
 	} else if len(arts) > 1 {
 		return nil, fmt.Errorf("CHArtworkRep.GetByID: %w", ErrExpectedOneArtwork)
 	}
+	if err := ch.loadImages(ctx, arts); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetByID: %w", err)
+	}
+	if err := ch.loadCoAuthors(ctx, arts); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetByID: %w", err)
+	}
+	if err := ch.loadTags(ctx, arts); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetByID: %w", err)
+	}
 	return arts[0], nil
 }
 
@@ -244,10 +769,12 @@ func (ch *CHArtworkRep) execChangeQuery(ctx context.Context, query string, args
 func (ch *CHArtworkRep) Add(ctx context.Context, a *models.Artwork) error {
 	query := `
 		INSERT INTO Artworks 
-		(id, title, technic, material, size, creationYear, authorID, collectionID) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		(id, title, technic, material, size, creationYear, authorID, collectionID,
+		heightCm, widthCm, depthCm, weightKg, lengthUnit, weightUnit,
+		yearFrom, yearTo, dateQualifier, datePrecision, authorRole) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	err := ch.execChangeQuery(ctx, query,
+	args := append([]interface{}{
 		a.GetID(),
 		a.GetTitle(),
 		a.GetTechnic(),
@@ -256,16 +783,92 @@ func (ch *CHArtworkRep) Add(ctx context.Context, a *models.Artwork) error {
 		a.GetCreationYear(),
 		a.GetAuthor().GetID(),
 		a.GetCollection().GetID(),
-	)
+	}, dimensionsValues(a.GetDimensions())...)
+	err := ch.execChangeQuery(ctx, query, append(args, datingValues(a)...)...)
 	if err != nil {
 		return fmt.Errorf("CHArtworkRep.Add: %w", err)
 	}
+	if err := ch.insertCoAuthors(ctx, a); err != nil {
+		return fmt.Errorf("CHArtworkRep.Add: %w", err)
+	}
 	return nil
 }
 
+// ImportCatalog вставляет записи импорта пачками; транзакций в ClickHouse нет,
+// поэтому при ошибке уже вставленные пачки остаются
+func (ch *CHArtworkRep) ImportCatalog(ctx context.Context, batch *models.CatalogImport) error {
+	for _, chunk := range importChunks(batch.Authors) {
+		rows := make([][]interface{}, len(chunk))
+		for i, a := range chunk {
+			rows[i] = []interface{}{a.GetID(), a.GetName(), a.GetBirthYear(), deathYearValue(a)}
+		}
+		if err := ch.insertRows(ctx, "Author", []string{"id", "name", "birthYear", "deathYear"}, rows); err != nil {
+			return fmt.Errorf("CHArtworkRep.ImportCatalog: %w", err)
+		}
+	}
+	for _, chunk := range importChunks(batch.Collections) {
+		rows := make([][]interface{}, len(chunk))
+		for i, c := range chunk {
+			rows[i] = []interface{}{c.GetID(), c.GetTitle()}
+		}
+		if err := ch.insertRows(ctx, "Collection", []string{"id", "title"}, rows); err != nil {
+			return fmt.Errorf("CHArtworkRep.ImportCatalog: %w", err)
+		}
+	}
+	for _, chunk := range importChunks(batch.Artworks) {
+		rows := make([][]interface{}, len(chunk))
+		for i, a := range chunk {
+			rows[i] = artworkValues(a)
+		}
+		if err := ch.insertRows(ctx, "Artworks", artworkColumns, rows); err != nil {
+			return fmt.Errorf("CHArtworkRep.ImportCatalog: %w", err)
+		}
+	}
+	for _, a := range batch.Artworks {
+		if err := ch.insertCoAuthors(ctx, a); err != nil {
+			return fmt.Errorf("CHArtworkRep.ImportCatalog: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertRows вставляет строки одним запросом INSERT ... VALUES
+func (ch *CHArtworkRep) insertRows(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	placeholder := "(?" + strings.Repeat(", ?", len(columns)-1) + ")"
+	placeholders := make([]string, len(rows))
+	var args []interface{}
+	for i, row := range rows {
+		placeholders[i] = placeholder
+		args = append(args, row...)
+	}
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES " +
+		strings.Join(placeholders, ", ")
+	return ch.execChangeQuery(ctx, query, args...)
+}
+
+// Delete в ClickHouse проверяет мероприятия и помечает произведение без транзакции
 func (ch *CHArtworkRep) Delete(ctx context.Context, idArt uuid.UUID) error {
-	query := "ALTER TABLE Artworks DELETE WHERE id = ?"
-	err := ch.execChangeQuery(ctx, query, idArt)
+	if _, err := ch.GetByID(ctx, idArt); err != nil {
+		return fmt.Errorf("CHArtworkRep.Delete: %w", err)
+	}
+	rows, err := ch.db.QueryContext(ctx, `
+		SELECT e.id, e.title
+		FROM Events e
+		JOIN Artwork_event ae ON ae.eventID = e.id
+		WHERE ae.artworkID = ? AND e.valid AND e.dateEnd >= now()
+		ORDER BY e.dateBegin`, idArt)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	dependents, err := parseDependentRows(rows, models.EntityEvent)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.Delete: %w", err)
+	}
+	if err := models.CheckDependents(dependents); err != nil {
+		return fmt.Errorf("CHArtworkRep.Delete: %w", err)
+	}
+	err = ch.execChangeQuery(ctx, "ALTER TABLE Artworks UPDATE deletedAt = now() WHERE id = ?", idArt)
 	if err != nil {
 		return fmt.Errorf("CHArtworkRep.Delete: %w", err)
 	}
@@ -275,16 +878,22 @@ func (ch *CHArtworkRep) Delete(ctx context.Context, idArt uuid.UUID) error {
 func (ch *CHArtworkRep) Update(ctx context.Context,
 	idArt uuid.UUID,
 	funcUpdate func(*models.Artwork) (*models.Artwork, error),
+	change *models.Change,
 ) error {
 	art, err := ch.GetByID(ctx, idArt)
 	if err != nil {
 		return fmt.Errorf("CHArtworkRep.Update: %w", err)
 	}
 
+	before := art.Snapshot()
 	updatedArtwork, err := funcUpdate(art)
 	if err != nil {
 		return fmt.Errorf("CHArtworkRep.Update: %w", ErrUpdateArtwork)
 	}
+	err = historyrep.CHAddChange(ctx, ch.db, models.EntityArtwork, idArt, change, before, updatedArtwork.Snapshot())
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.Update: %w", err)
+	}
 
 	query := `
 		ALTER TABLE Artworks UPDATE 
@@ -294,10 +903,22 @@ func (ch *CHArtworkRep) Update(ctx context.Context,
 		size = ?, 
 		creationYear = ?, 
 		authorID = ?, 
-		collectionID = ? 
+		collectionID = ?, 
+		heightCm = ?, 
+		widthCm = ?, 
+		depthCm = ?, 
+		weightKg = ?, 
+		lengthUnit = ?, 
+		weightUnit = ?, 
+		yearFrom = ?, 
+		yearTo = ?, 
+		dateQualifier = ?, 
+		datePrecision = ?, 
+		authorRole = ?, 
+		updatedAt = now() 
 		WHERE id = ?`
 
-	err = ch.execChangeQuery(ctx, query,
+	args := []interface{}{
 		updatedArtwork.GetTitle(),
 		updatedArtwork.GetMaterial(),
 		updatedArtwork.GetTechnic(),
@@ -305,14 +926,498 @@ func (ch *CHArtworkRep) Update(ctx context.Context,
 		updatedArtwork.GetCreationYear(),
 		updatedArtwork.GetAuthor().GetID(),
 		updatedArtwork.GetCollection().GetID(),
-		idArt,
-	)
+	}
+	args = append(args, dimensionsValues(updatedArtwork.GetDimensions())...)
+	args = append(args, datingValues(updatedArtwork)...)
+	err = ch.execChangeQuery(ctx, query, append(args, idArt)...)
 	if err != nil {
 		return fmt.Errorf("CHArtworkRep.Update: %w", err)
 	}
+	err = ch.execChangeQuery(ctx, "ALTER TABLE Artwork_authors DELETE WHERE artworkID = ?", idArt)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.Update: %w", err)
+	}
+	if err := ch.insertCoAuthors(ctx, updatedArtwork); err != nil {
+		return fmt.Errorf("CHArtworkRep.Update: %w", err)
+	}
+	return nil
+}
+
+// insertCoAuthors сохраняет дополнительных авторов произведения в порядке показа
+func (ch *CHArtworkRep) insertCoAuthors(ctx context.Context, a *models.Artwork) error {
+	if len(a.GetCoAuthors()) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(a.GetCoAuthors()))
+	var args []interface{}
+	for i, co := range a.GetCoAuthors() {
+		placeholders[i] = "(?, ?, ?, ?)"
+		args = append(args, a.GetID(), co.GetAuthor().GetID(), string(co.GetRole()), i)
+	}
+	query := "INSERT INTO Artwork_authors (artworkID, authorID, role, position) VALUES " +
+		joinConditions(placeholders, ", ")
+	return ch.execChangeQuery(ctx, query, args...)
+}
+
+// loadCoAuthors одним запросом подгружает дополнительных авторов для всех переданных произведений
+func (ch *CHArtworkRep) loadCoAuthors(ctx context.Context, arts []*models.Artwork) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(arts))
+	args := make([]interface{}, len(arts))
+	for i, a := range arts {
+		placeholders[i] = "?"
+		args[i] = a.GetID()
+	}
+	query := `
+		SELECT aa.artworkID, aa.role, Author.id, Author.name, Author.birthYear, Author.deathYear
+		FROM Artwork_authors aa
+		JOIN Author ON aa.authorID = Author.id
+		WHERE aa.artworkID IN (` + joinConditions(placeholders, ", ") + `)
+		ORDER BY aa.artworkID, aa.position`
+	rows, err := ch.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	byArtwork, err := parseCoAuthorRows(rows)
+	if err != nil {
+		return err
+	}
+	return setCoAuthors(arts, byArtwork)
+}
+
+// loadTags одним запросом подгружает теги для всех переданных произведений
+func (ch *CHArtworkRep) loadTags(ctx context.Context, arts []*models.Artwork) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(arts))
+	args := make([]interface{}, len(arts))
+	for i, a := range arts {
+		placeholders[i] = "?"
+		args[i] = a.GetID()
+	}
+	query := `
+		SELECT at.artworkID, Tags.id, Tags.name, Tags.kind, Tags.parentID, Tags.synonyms
+		FROM Artwork_tags at
+		JOIN Tags ON at.tagID = Tags.id
+		WHERE at.artworkID IN (` + joinConditions(placeholders, ", ") + `)
+		ORDER BY at.artworkID, Tags.kind, Tags.name`
+	rows, err := ch.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	byArtwork, err := parseArtworkTagRows(rows)
+	if err != nil {
+		return err
+	}
+	setTags(arts, byArtwork)
+	return nil
+}
+
+func (ch *CHArtworkRep) selectImages(ctx context.Context, query string, args ...interface{}) ([]*models.ArtworkImage, error) {
+	rows, err := ch.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var images []*models.ArtworkImage
+	for rows.Next() {
+		var id, artworkID uuid.UUID
+		var position, width, height int32
+		var isPrimary bool
+		var format string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &artworkID, &position, &isPrimary, &format, &width, &height, &createdAt); err != nil {
+			return nil, fmt.Errorf("selectImages: scan error: %v", err)
+		}
+		img, err := models.NewArtworkImage(id, artworkID, int(position), isPrimary,
+			models.ImageFormat(format), int(width), int(height), createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("selectImages: %w: %v", models.ErrValidateArtworkImage, err)
+		}
+		images = append(images, &img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("selectImages: rows iteration error: %v", err)
+	}
+	return images, nil
+}
+
+// loadImages одним запросом подгружает изображения для всех переданных произведений
+func (ch *CHArtworkRep) loadImages(ctx context.Context, arts []*models.Artwork) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(arts))
+	args := make([]interface{}, len(arts))
+	for i, a := range arts {
+		placeholders[i] = "?"
+		args[i] = a.GetID()
+	}
+	query := `
+		SELECT id, artworkID, position, isPrimary, format, width, height, createdAt
+		FROM Artwork_images
+		WHERE artworkID IN (` + joinConditions(placeholders, ", ") + `)
+		ORDER BY artworkID, position, createdAt`
+	images, err := ch.selectImages(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	byArtwork := make(map[uuid.UUID][]*models.ArtworkImage, len(arts))
+	for _, img := range images {
+		byArtwork[img.GetArtworkID()] = append(byArtwork[img.GetArtworkID()], img)
+	}
+	for _, a := range arts {
+		a.SetImages(byArtwork[a.GetID()])
+	}
+	return nil
+}
+
+// setHighlights выделяет в полях найденных произведений слова с основами из поискового запроса
+func (ch *CHArtworkRep) setHighlights(arts []*models.Artwork, searchQuery string) {
+	if searchQuery == "" {
+		return
+	}
+	terms := models.SearchTerms(searchQuery)
+	for _, a := range arts {
+		a.SetHighlight(&models.ArtworkHighlight{
+			Title:      models.HighlightTerms(a.GetTitle(), terms),
+			AuthorName: models.HighlightTerms(a.GetAuthor().GetName(), terms),
+			Collection: models.HighlightTerms(a.GetCollection().GetTitle(), terms),
+			Technic:    models.HighlightTerms(a.GetTechnic(), terms),
+			Material:   models.HighlightTerms(a.GetMaterial(), terms),
+		})
+	}
+}
+
+func (ch *CHArtworkRep) GetImages(ctx context.Context, artworkID uuid.UUID) ([]*models.ArtworkImage, error) {
+	query := `
+		SELECT id, artworkID, position, isPrimary, format, width, height, createdAt
+		FROM Artwork_images
+		WHERE artworkID = ?
+		ORDER BY position, createdAt`
+	images, err := ch.selectImages(ctx, query, artworkID)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetImages: %w", err)
+	}
+	return images, nil
+}
+
+func (ch *CHArtworkRep) AddImage(ctx context.Context, img *models.ArtworkImage) error {
+	query := `
+		INSERT INTO Artwork_images 
+		(id, artworkID, position, isPrimary, format, width, height, createdAt) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	err := ch.execChangeQuery(ctx, query,
+		img.GetID(),
+		img.GetArtworkID(),
+		img.GetPosition(),
+		img.IsPrimary(),
+		string(img.GetFormat()),
+		img.GetWidth(),
+		img.GetHeight(),
+		img.GetCreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.AddImage: %w", err)
+	}
+	return nil
+}
+
+// imageExists проверяет наличие изображения, т.к. мутации ClickHouse не сообщают о затронутых строках
+func (ch *CHArtworkRep) imageExists(ctx context.Context, artworkID uuid.UUID, imageID uuid.UUID) (bool, error) {
+	var cnt uint64
+	query := "SELECT count() FROM Artwork_images WHERE id = ? AND artworkID = ?"
+	if err := ch.db.QueryRowContext(ctx, query, imageID, artworkID).Scan(&cnt); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return cnt > 0, nil
+}
+
+func (ch *CHArtworkRep) DeleteImage(ctx context.Context, artworkID uuid.UUID, imageID uuid.UUID) error {
+	exists, err := ch.imageExists(ctx, artworkID, imageID)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.DeleteImage: %w", err)
+	} else if !exists {
+		return fmt.Errorf("CHArtworkRep.DeleteImage: %w", ErrArtworkImageNotFound)
+	}
+
+	query := "ALTER TABLE Artwork_images DELETE WHERE id = ? AND artworkID = ?"
+	if err := ch.execChangeQuery(ctx, query, imageID, artworkID); err != nil {
+		return fmt.Errorf("CHArtworkRep.DeleteImage: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHArtworkRep) UpdateImages(ctx context.Context, artworkID uuid.UUID, images []*models.ArtworkImage) error {
+	for _, img := range images {
+		exists, err := ch.imageExists(ctx, artworkID, img.GetID())
+		if err != nil {
+			return fmt.Errorf("CHArtworkRep.UpdateImages: %w", err)
+		} else if !exists {
+			return fmt.Errorf("CHArtworkRep.UpdateImages: %w", ErrArtworkImageNotFound)
+		}
+	}
+
+	for _, img := range images {
+		query := "ALTER TABLE Artwork_images UPDATE position = ?, isPrimary = ? WHERE id = ? AND artworkID = ?"
+		err := ch.execChangeQuery(ctx, query, img.GetPosition(), img.IsPrimary(), img.GetID(), artworkID)
+		if err != nil {
+			return fmt.Errorf("CHArtworkRep.UpdateImages: %w", err)
+		}
+	}
+	return nil
+}
+
+func (ch *CHArtworkRep) GetProvenance(ctx context.Context, artworkID uuid.UUID) ([]*models.ProvenanceEntry, error) {
+	query := "SELECT " + joinConditions(provenanceColumns, ", ") +
+		" FROM Artwork_provenance WHERE artworkID = ? ORDER BY position, id"
+	rows, err := ch.db.QueryContext(ctx, query, artworkID)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetProvenance: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	entries, err := parseProvenanceRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetProvenance: %w", err)
+	}
+	return entries, nil
+}
+
+func (ch *CHArtworkRep) AddProvenanceEntry(ctx context.Context, entry *models.ProvenanceEntry) error {
+	placeholders := make([]string, len(provenanceColumns))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	query := "INSERT INTO Artwork_provenance (" + joinConditions(provenanceColumns, ", ") +
+		") VALUES (" + joinConditions(placeholders, ", ") + ")"
+	if err := ch.execChangeQuery(ctx, query, provenanceValues(entry)...); err != nil {
+		return fmt.Errorf("CHArtworkRep.AddProvenanceEntry: %w", err)
+	}
+	return nil
+}
+
+// provenanceExists проверяет наличие записи провенанса, т.к. мутации ClickHouse не сообщают о затронутых строках
+func (ch *CHArtworkRep) provenanceExists(ctx context.Context, artworkID uuid.UUID, entryID uuid.UUID) (bool, error) {
+	var cnt uint64
+	query := "SELECT count() FROM Artwork_provenance WHERE id = ? AND artworkID = ?"
+	if err := ch.db.QueryRowContext(ctx, query, entryID, artworkID).Scan(&cnt); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return cnt > 0, nil
+}
+
+func (ch *CHArtworkRep) DeleteProvenanceEntry(ctx context.Context, artworkID uuid.UUID, entryID uuid.UUID) error {
+	exists, err := ch.provenanceExists(ctx, artworkID, entryID)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.DeleteProvenanceEntry: %w", err)
+	} else if !exists {
+		return fmt.Errorf("CHArtworkRep.DeleteProvenanceEntry: %w", ErrProvenanceNotFound)
+	}
+
+	query := "ALTER TABLE Artwork_provenance DELETE WHERE id = ? AND artworkID = ?"
+	if err := ch.execChangeQuery(ctx, query, entryID, artworkID); err != nil {
+		return fmt.Errorf("CHArtworkRep.DeleteProvenanceEntry: %w", err)
+	}
 	return nil
 }
 
+func (ch *CHArtworkRep) UpdateProvenance(ctx context.Context, artworkID uuid.UUID, entries []*models.ProvenanceEntry) error {
+	for _, entry := range entries {
+		exists, err := ch.provenanceExists(ctx, artworkID, entry.GetID())
+		if err != nil {
+			return fmt.Errorf("CHArtworkRep.UpdateProvenance: %w", err)
+		} else if !exists {
+			return fmt.Errorf("CHArtworkRep.UpdateProvenance: %w", ErrProvenanceNotFound)
+		}
+	}
+
+	for _, entry := range entries {
+		query := `
+			ALTER TABLE Artwork_provenance UPDATE
+				position = ?, owner = ?, ownerUncertain = ?, location = ?,
+				periodFrom = ?, periodTo = ?, dateQualifier = ?, transferMethod = ?,
+				sources = ?, notes = ?, isPublic = ?
+			WHERE id = ? AND artworkID = ?`
+		err := ch.execChangeQuery(ctx, query,
+			entry.GetPosition(),
+			entry.GetOwner(),
+			entry.IsOwnerUncertain(),
+			entry.GetLocation(),
+			entry.GetPeriodFrom(),
+			entry.GetPeriodTo(),
+			string(entry.GetDateQualifier()),
+			string(entry.GetTransferMethod()),
+			entry.GetSources(),
+			entry.GetNotes(),
+			entry.IsPublic(),
+			entry.GetID(),
+			artworkID,
+		)
+		if err != nil {
+			return fmt.Errorf("CHArtworkRep.UpdateProvenance: %w", err)
+		}
+	}
+	return nil
+}
+
+// selectConditionReports выполняет выборку отчетов и загружает их фотографии
+func (ch *CHArtworkRep) selectConditionReports(ctx context.Context, query string, args ...interface{}) ([]*models.ConditionReport, error) {
+	rows, err := ch.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+	reports, err := parseConditionReportRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return reports, nil
+	}
+
+	cond, photoArgs := inCondition("reportID", reportIDs(reports))
+	photoQuery := "SELECT " + joinConditions(conditionPhotoColumns, ", ") +
+		" FROM Condition_report_photos WHERE " + cond + " ORDER BY createdAt, id"
+	photoRows, err := ch.db.QueryContext(ctx, photoQuery, photoArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer photoRows.Close()
+	photos, err := parseConditionPhotoRows(photoRows)
+	if err != nil {
+		return nil, err
+	}
+	attachConditionPhotos(reports, photos)
+	return reports, nil
+}
+
+func (ch *CHArtworkRep) GetConditionReports(ctx context.Context, artworkID uuid.UUID) ([]*models.ConditionReport, error) {
+	query := "SELECT " + joinConditions(conditionReportColumns, ", ") +
+		" FROM Condition_reports WHERE artworkID = ? ORDER BY checkedAt DESC, id"
+	reports, err := ch.selectConditionReports(ctx, query, artworkID)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetConditionReports: %w", err)
+	}
+	return reports, nil
+}
+
+func (ch *CHArtworkRep) GetConditionReport(ctx context.Context, artworkID uuid.UUID, reportID uuid.UUID) (*models.ConditionReport, error) {
+	query := "SELECT " + joinConditions(conditionReportColumns, ", ") +
+		" FROM Condition_reports WHERE id = ? AND artworkID = ?"
+	reports, err := ch.selectConditionReports(ctx, query, reportID, artworkID)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetConditionReport: %w", err)
+	} else if len(reports) == 0 {
+		return nil, fmt.Errorf("CHArtworkRep.GetConditionReport: %w", ErrConditionReportNotFound)
+	}
+	return reports[0], nil
+}
+
+func (ch *CHArtworkRep) AddConditionReport(ctx context.Context, report *models.ConditionReport) error {
+	placeholders := make([]string, len(conditionReportColumns))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	query := "INSERT INTO Condition_reports (" + joinConditions(conditionReportColumns, ", ") +
+		") VALUES (" + joinConditions(placeholders, ", ") + ")"
+	if err := ch.execChangeQuery(ctx, query, conditionReportValues(report)...); err != nil {
+		return fmt.Errorf("CHArtworkRep.AddConditionReport: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHArtworkRep) UpdateConditionReport(ctx context.Context, report *models.ConditionReport) error {
+	// мутации ClickHouse не сообщают о затронутых строках
+	var cnt uint64
+	countQuery := "SELECT count() FROM Condition_reports WHERE id = ? AND artworkID = ?"
+	if err := ch.db.QueryRowContext(ctx, countQuery, report.GetID(), report.GetArtworkID()).Scan(&cnt); err != nil {
+		return fmt.Errorf("CHArtworkRep.UpdateConditionReport: %w: %v", ErrQueryExec, err)
+	} else if cnt == 0 {
+		return fmt.Errorf("CHArtworkRep.UpdateConditionReport: %w", ErrConditionReportNotFound)
+	}
+
+	query := `
+		ALTER TABLE Condition_reports UPDATE
+			checkedAt = ?, examiner = ?, grade = ?, notes = ?, treatment = ?, treatmentEnd = ?
+		WHERE id = ? AND artworkID = ?`
+	err := ch.execChangeQuery(ctx, query,
+		report.GetCheckedAt(),
+		report.GetExaminer(),
+		string(report.GetGrade()),
+		report.GetNotes(),
+		report.GetTreatment(),
+		optionalTime(report.GetTreatmentEnd()),
+		report.GetID(),
+		report.GetArtworkID(),
+	)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.UpdateConditionReport: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHArtworkRep) AddConditionPhoto(ctx context.Context, photo *models.ConditionPhoto) error {
+	query := `
+		INSERT INTO Condition_report_photos
+		(id, reportID, artworkID, format, createdAt)
+		VALUES (?, ?, ?, ?, ?)`
+	err := ch.execChangeQuery(ctx, query,
+		photo.GetID(),
+		photo.GetReportID(),
+		photo.GetArtworkID(),
+		string(photo.GetFormat()),
+		photo.GetCreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("CHArtworkRep.AddConditionPhoto: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHArtworkRep) GetTreatmentsOnDate(
+	ctx context.Context, artworkID uuid.UUID, dateBeg time.Time, dateEnd time.Time,
+) ([]*models.ConditionReport, error) {
+	query := "SELECT " + joinConditions(conditionReportColumns, ", ") + `
+		FROM Condition_reports
+		WHERE artworkID = ? AND treatment != '' AND checkedAt <= ?
+			AND (treatmentEnd IS NULL OR treatmentEnd >= ?)
+		ORDER BY checkedAt DESC, id`
+	reports, err := ch.selectConditionReports(ctx, query, artworkID, dateEnd, dateBeg)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetTreatmentsOnDate: %w", err)
+	}
+	return reports, nil
+}
+
+func (ch *CHArtworkRep) GetConditionReportsNeedingAttention(ctx context.Context) ([]*models.ConditionReport, error) {
+	gradeCond, args := inCondition("grade", attentionGrades)
+	// коррелированные подзапросы в ClickHouse не поддерживаются,
+	// поэтому последний отчет произведения ищется через группировку
+	query := "SELECT " + joinConditions(conditionReportColumns, ", ") + `
+		FROM Condition_reports
+		WHERE artworkID IN (SELECT id FROM Artworks WHERE deletedAt IS NULL)
+			AND ((treatment != '' AND treatmentEnd IS NULL)
+			OR (` + gradeCond + ` AND (artworkID, checkedAt) IN
+				(SELECT artworkID, max(checkedAt) FROM Condition_reports GROUP BY artworkID)))
+		ORDER BY checkedAt DESC, id`
+	reports, err := ch.selectConditionReports(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetConditionReportsNeedingAttention: %w", err)
+	}
+	return reports, nil
+}
+
 func (ch *CHArtworkRep) Ping(ctx context.Context) error {
 	return ch.db.PingContext(ctx)
 }
@@ -320,3 +1425,79 @@ func (ch *CHArtworkRep) Ping(ctx context.Context) error {
 func (ch *CHArtworkRep) Close() {
 	ch.db.Close()
 }
+
+// GetSimilarCandidates: без совпадения в LEFT JOIN ClickHouse возвращает cnt = 0, а не NULL
+func (ch *CHArtworkRep) GetSimilarCandidates(ctx context.Context, artworkID uuid.UUID, limit int) ([]*models.SimilarityCandidate, error) {
+	w := models.DefaultSimilarityWeights
+	query := `
+		SELECT a.id, a.authorID, a.collectionID,
+			a.authorID = src.authorID,
+			a.collectionID = src.collectionID,
+			a.technic != '' AND a.technic = src.technic,
+			a.material != '' AND a.material = src.material,
+			toInt32(abs(a.creationYear - src.creationYear)),
+			se.cnt,
+			cf.cnt
+		FROM Artworks AS a
+		CROSS JOIN (
+			SELECT id, authorID, collectionID, technic, material, creationYear
+			FROM Artworks WHERE id = ? AND deletedAt IS NULL
+		) AS src
+		LEFT JOIN (
+			SELECT ae.artworkID AS artworkID, count() AS cnt
+			FROM Artwork_event AS ae JOIN Events ON ae.eventID = Events.id
+			WHERE Events.valid = 1 AND Events.status = 'approved'
+				AND ae.eventID IN (SELECT eventID FROM Artwork_event WHERE artworkID = ?)
+			GROUP BY ae.artworkID
+		) AS se ON se.artworkID = a.id
+		LEFT JOIN (
+			SELECT entityID AS artworkID, count() AS cnt
+			FROM User_favourites
+			WHERE entityType = 'artwork' AND userID IN (
+				SELECT userID FROM User_favourites WHERE entityType = 'artwork' AND entityID = ?)
+			GROUP BY entityID
+		) AS cf ON cf.artworkID = a.id
+		WHERE a.id != src.id AND a.deletedAt IS NULL
+			AND (a.authorID = src.authorID OR a.collectionID = src.collectionID
+				OR (a.technic != '' AND a.technic = src.technic) OR (a.material != '' AND a.material = src.material)
+				OR abs(a.creationYear - src.creationYear) < ? OR se.cnt > 0 OR cf.cnt > 0)
+		ORDER BY if(a.authorID = src.authorID, ?, 0)
+			+ if(a.collectionID = src.collectionID, ?, 0)
+			+ if(a.technic != '' AND a.technic = src.technic, ?, 0)
+			+ if(a.material != '' AND a.material = src.material, ?, 0)
+			+ ? * greatest(? - abs(a.creationYear - src.creationYear), 0) / ?
+			+ ? * least(se.cnt, ?)
+			+ ? * least(cf.cnt, ?) DESC, a.id
+		LIMIT ?`
+	rows, err := ch.db.QueryContext(ctx, query,
+		artworkID, artworkID, artworkID, models.SimilarPeriodWindow,
+		w.Author, w.Collection, w.Technic, w.Material,
+		w.Period, models.SimilarPeriodWindow, float64(models.SimilarPeriodWindow),
+		w.SharedEvent, models.SimilarMaxSharedEvents,
+		w.CoFavourite, models.SimilarMaxCoFavourites,
+		limit)
+	if err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var res []*models.SimilarityCandidate
+	for rows.Next() {
+		var sameAuthor, sameCollection, sameTechnic, sameMaterial uint8
+		var yearDiff int32
+		var sharedEvents, coFavourites uint64
+		c := &models.SimilarityCandidate{}
+		if err := rows.Scan(&c.ArtworkID, &c.AuthorID, &c.CollectionID, &sameAuthor, &sameCollection,
+			&sameTechnic, &sameMaterial, &yearDiff, &sharedEvents, &coFavourites); err != nil {
+			return nil, fmt.Errorf("CHArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryExec, err)
+		}
+		c.SameAuthor, c.SameCollection = sameAuthor == 1, sameCollection == 1
+		c.SameTechnic, c.SameMaterial = sameTechnic == 1, sameMaterial == 1
+		c.YearDiff, c.SharedEvents, c.CoFavourites = int(yearDiff), int(sharedEvents), int(coFavourites)
+		res = append(res, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("CHArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryExec, err)
+	}
+	return res, nil
+}
@@ -2,6 +2,7 @@ package artworkrep
 
 import (
 	"context"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
@@ -18,11 +19,44 @@ func (m *MockArtworkRep) GetAllArtworks(ctx context.Context, filterOps *jsonreqr
 	return args.Get(0).([]*models.Artwork), args.Error(1)
 }
 
+func (m *MockArtworkRep) GetArtworksPage(
+	ctx context.Context,
+	filterOps *jsonreqresp.ArtworkFilter,
+	sortOps *jsonreqresp.ArtworkSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Artwork, jsonreqresp.PageInfo, error) {
+	args := m.Called(ctx, filterOps, sortOps, page)
+	return args.Get(0).([]*models.Artwork), args.Get(1).(jsonreqresp.PageInfo), args.Error(2)
+}
+
+func (m *MockArtworkRep) GetArtworkFacets(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter) (*models.ArtworkFacets, error) {
+	args := m.Called(ctx, filterOps)
+	return args.Get(0).(*models.ArtworkFacets), args.Error(1)
+}
+
 func (m *MockArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artwork, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*models.Artwork), args.Error(1)
 }
 
+func (m *MockArtworkRep) GetSimilarCandidates(ctx context.Context, artworkID uuid.UUID, limit int) ([]*models.SimilarityCandidate, error) {
+	args := m.Called(ctx, artworkID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SimilarityCandidate), args.Error(1)
+}
+
+func (m *MockArtworkRep) GetEarliestUpdatedAt(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockArtworkRep) GetExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(uuid.UUIDs), args.Error(1)
+}
+
 func (m *MockArtworkRep) Add(ctx context.Context, aw *models.Artwork) error {
 	args := m.Called(ctx, aw)
 	return args.Error(0)
@@ -33,8 +67,35 @@ func (m *MockArtworkRep) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockArtworkRep) Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Artwork) (*models.Artwork, error)) error {
-	args := m.Called(ctx, id, funcUpdate)
+func (m *MockArtworkRep) Update(
+	ctx context.Context, id uuid.UUID, funcUpdate func(*models.Artwork) (*models.Artwork, error), change *models.Change,
+) error {
+	args := m.Called(ctx, id, funcUpdate, change)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) ImportCatalog(ctx context.Context, batch *models.CatalogImport) error {
+	args := m.Called(ctx, batch)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) GetImages(ctx context.Context, artworkID uuid.UUID) ([]*models.ArtworkImage, error) {
+	args := m.Called(ctx, artworkID)
+	return args.Get(0).([]*models.ArtworkImage), args.Error(1)
+}
+
+func (m *MockArtworkRep) AddImage(ctx context.Context, img *models.ArtworkImage) error {
+	args := m.Called(ctx, img)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) DeleteImage(ctx context.Context, artworkID uuid.UUID, imageID uuid.UUID) error {
+	args := m.Called(ctx, artworkID, imageID)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) UpdateImages(ctx context.Context, artworkID uuid.UUID, images []*models.ArtworkImage) error {
+	args := m.Called(ctx, artworkID, images)
 	return args.Error(0)
 }
 
@@ -46,3 +107,61 @@ func (m *MockArtworkRep) Ping(ctx context.Context) error {
 func (m *MockArtworkRep) Close() {
 	m.Called()
 }
+
+func (m *MockArtworkRep) GetProvenance(ctx context.Context, artworkID uuid.UUID) ([]*models.ProvenanceEntry, error) {
+	args := m.Called(ctx, artworkID)
+	return args.Get(0).([]*models.ProvenanceEntry), args.Error(1)
+}
+
+func (m *MockArtworkRep) AddProvenanceEntry(ctx context.Context, entry *models.ProvenanceEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) DeleteProvenanceEntry(ctx context.Context, artworkID uuid.UUID, entryID uuid.UUID) error {
+	args := m.Called(ctx, artworkID, entryID)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) UpdateProvenance(ctx context.Context, artworkID uuid.UUID, entries []*models.ProvenanceEntry) error {
+	args := m.Called(ctx, artworkID, entries)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) GetConditionReports(ctx context.Context, artworkID uuid.UUID) ([]*models.ConditionReport, error) {
+	args := m.Called(ctx, artworkID)
+	return args.Get(0).([]*models.ConditionReport), args.Error(1)
+}
+
+func (m *MockArtworkRep) GetConditionReport(ctx context.Context, artworkID uuid.UUID, reportID uuid.UUID) (*models.ConditionReport, error) {
+	args := m.Called(ctx, artworkID, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ConditionReport), args.Error(1)
+}
+
+func (m *MockArtworkRep) AddConditionReport(ctx context.Context, report *models.ConditionReport) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) UpdateConditionReport(ctx context.Context, report *models.ConditionReport) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) AddConditionPhoto(ctx context.Context, photo *models.ConditionPhoto) error {
+	args := m.Called(ctx, photo)
+	return args.Error(0)
+}
+
+func (m *MockArtworkRep) GetTreatmentsOnDate(ctx context.Context, artworkID uuid.UUID, dateBeg time.Time, dateEnd time.Time) ([]*models.ConditionReport, error) {
+	args := m.Called(ctx, artworkID, dateBeg, dateEnd)
+	return args.Get(0).([]*models.ConditionReport), args.Error(1)
+}
+
+func (m *MockArtworkRep) GetConditionReportsNeedingAttention(ctx context.Context) ([]*models.ConditionReport, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.ConditionReport), args.Error(1)
+}
@@ -11,6 +11,7 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -20,6 +21,11 @@ type PgArtworkRep struct {
 	db *sql.DB
 }
 
+// pgQuerier - пул соединений или транзакция
+type pgQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
 var (
 	pgInstance *PgArtworkRep
 	pgOnce     sync.Once
@@ -64,16 +70,27 @@ func NewPgArtworkRep(ctx context.Context, pgCreds *cnfg.DatebaseCredentials, dbC
 	return pgInstance, nil
 }
 
-func (pg *PgArtworkRep) parseArtworksRows(rows *sql.Rows) ([]*models.Artwork, error) {
+// parseArtworksRows разбирает строки выборки произведений.
+// Если sortKeys не nil, последним столбцом ожидается ключ сортировки для курсора страницы.
+func (pg *PgArtworkRep) parseArtworksRows(rows *sql.Rows, sortKeys *[]string) ([]*models.Artwork, error) {
 	var resArtworks []*models.Artwork
 	for rows.Next() {
 		var id, authorID, collectionID uuid.UUID
-		var title, authorName, collectionTitle, size, material, technic string
-		var creationYear, authorBirthYear int
+		var title, authorName, collectionTitle, size, material, technic, sortKey string
+		var authorBirthYear int
 		var authorDeathYear sql.NullInt64
-		if err := rows.Scan(&id, &title, &technic, &material, &size, &creationYear,
-			&authorID, &authorName, &authorBirthYear, &authorDeathYear,
-			&collectionID, &collectionTitle); err != nil {
+		var dimensions dimensionsRow
+		var dating datingRow
+		var updatedAt time.Time
+		dest := append([]any{&id, &title, &technic, &material, &size}, dimensions.dest()...)
+		dest = append(dest, dating.dest()...)
+		dest = append(dest, &updatedAt)
+		dest = append(dest, &authorID, &authorName, &authorBirthYear, &authorDeathYear,
+			&collectionID, &collectionTitle)
+		if sortKeys != nil {
+			dest = append(dest, &sortKey)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("parseArtworksRows: scan error: %v", err)
 		}
 		deathYear := 0
@@ -88,11 +105,28 @@ func (pg *PgArtworkRep) parseArtworksRows(rows *sql.Rows) ([]*models.Artwork, er
 		if err != nil {
 			return nil, fmt.Errorf("parseArtworksRows: %v", err)
 		}
-		user, err := models.NewArtwork(id, title, technic, material, size, creationYear, &author, &collection)
+		artDating, err := dating.toDating()
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		attribution, err := models.NewAttribution(&author, models.AttributionRole(dating.authorRole))
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		user, err := models.NewDatedArtwork(id, title, technic, material, size, artDating, attribution, nil, &collection)
+		if err != nil {
+			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
+		}
+		artDimensions, err := dimensions.toDimensions()
 		if err != nil {
 			return nil, fmt.Errorf("parseArtworksRows: %w: %v", models.ErrValidateArtwork, err)
 		}
+		user.SetDimensions(artDimensions)
+		user.SetUpdatedAt(updatedAt)
 		resArtworks = append(resArtworks, &user)
+		if sortKeys != nil {
+			*sortKeys = append(*sortKeys, sortKey)
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("parseArtworksRows: rows iteration error: %v", err)
@@ -100,12 +134,33 @@ func (pg *PgArtworkRep) parseArtworksRows(rows *sql.Rows) ([]*models.Artwork, er
 	return resArtworks, nil
 }
 
+const (
+	// pgSearchTsQuery разбирает запрос посетителя (поддерживает кавычки, OR и минус)
+	pgSearchTsQuery = "websearch_to_tsquery('russian', ?)"
+	// pgSearchRankExpr - релевантность: ранг полнотекстового совпадения
+	// плюс нечеткое совпадение имени автора или названия для запросов с опечатками
+	pgSearchRankExpr = "(ts_rank(artworks.searchVector, " + pgSearchTsQuery + ") + " +
+		"0.5 * GREATEST(word_similarity(?, author.name), word_similarity(?, artworks.title)))"
+	pgHeadlineOptions = "HighlightAll=true, StartSel=" + models.HighlightStartSel + ", StopSel=" + models.HighlightStopSel
+)
+
 func (pg *PgArtworkRep) addFilterParams(query sq.SelectBuilder, filterOps *jsonreqresp.ArtworkFilter) sq.SelectBuilder {
+	// произведения из корзины не показываются
+	query = query.Where(sq.Eq{"artworks.deletedAt": nil})
 	if filterOps.Title != "" {
 		query = query.Where(sq.ILike{"artworks.title": "%" + filterOps.Title + "%"})
 	}
 	if filterOps.AuthorName != "" {
-		query = query.Where(sq.ILike{"author.name": "%" + filterOps.AuthorName + "%"}) // Поиск подстроки
+		// Поиск подстроки в имени основного или дополнительного автора
+		coAuthorSubQuery := sq.Select("1").
+			From("Artwork_authors aa").
+			Join("Author coauthor ON aa.authorID = coauthor.id").
+			Where("aa.artworkID = artworks.id").
+			Where(sq.ILike{"coauthor.name": "%" + filterOps.AuthorName + "%"})
+		query = query.Where(sq.Or{
+			sq.ILike{"author.name": "%" + filterOps.AuthorName + "%"},
+			sq.Expr("EXISTS (?)", coAuthorSubQuery),
+		})
 	}
 	if filterOps.Collection != "" {
 		query = query.Where(sq.ILike{"collection.title": "%" + filterOps.Collection + "%"})
@@ -117,10 +172,77 @@ func (pg *PgArtworkRep) addFilterParams(query sq.SelectBuilder, filterOps *jsonr
 			Where(sq.Eq{"ae.eventID": filterOps.EventID})
 		query = query.Where(sq.Expr("EXISTS (?)", existsSubQuery))
 	}
+	if filterOps.Query != "" {
+		query = query.Where(sq.Expr(
+			"(artworks.searchVector @@ "+pgSearchTsQuery+" OR ? <% author.name OR ? <% artworks.title)",
+			filterOps.Query, filterOps.Query, filterOps.Query))
+	}
+	if len(filterOps.Technics) > 0 {
+		query = query.Where(sq.Eq{"artworks.technic": filterOps.Technics})
+	}
+	if len(filterOps.Materials) > 0 {
+		query = query.Where(sq.Eq{"artworks.material": filterOps.Materials})
+	}
+	if len(filterOps.Centuries) > 0 {
+		centuries := sq.Or{}
+		for _, century := range filterOps.Centuries {
+			from, to := models.CenturyYears(century)
+			centuries = append(centuries, sq.And{
+				sq.GtOrEq{"artworks.creationYear": from},
+				sq.LtOrEq{"artworks.creationYear": to},
+			})
+		}
+		query = query.Where(centuries)
+	}
+	if len(filterOps.AuthorIDs) > 0 {
+		query = query.Where(sq.Eq{"artworks.authorID": []uuid.UUID(filterOps.AuthorIDs)})
+	}
+	if len(filterOps.CollectionIDs) > 0 && filterOps.IncludeSubcollections {
+		// произведение из одной из коллекций или любой вложенной в них коллекции
+		rootsSQL, rootsArgs, _ := sq.Eq{"id": []uuid.UUID(filterOps.CollectionIDs)}.ToSql()
+		query = query.Where(sq.Expr("artworks.collectionID IN (WITH RECURSIVE subtree AS ("+
+			"SELECT id FROM Collection WHERE "+rootsSQL+
+			" UNION SELECT c.id FROM Collection c JOIN subtree s ON c.parentID = s.id) "+
+			"SELECT id FROM subtree)", rootsArgs...))
+	} else if len(filterOps.CollectionIDs) > 0 {
+		query = query.Where(sq.Eq{"artworks.collectionID": []uuid.UUID(filterOps.CollectionIDs)})
+	}
+	if len(filterOps.TagIDs) > 0 {
+		// произведение отмечено одним из тегов или любым их потомком
+		rootsSQL, rootsArgs, _ := sq.Eq{"id": []uuid.UUID(filterOps.TagIDs)}.ToSql()
+		query = query.Where(sq.Expr("EXISTS (WITH RECURSIVE subtree AS ("+
+			"SELECT id FROM Tags WHERE "+rootsSQL+
+			" UNION SELECT t.id FROM Tags t JOIN subtree s ON t.parentID = s.id) "+
+			"SELECT 1 FROM Artwork_tags at JOIN subtree ON at.tagID = subtree.id "+
+			"WHERE at.artworkID = artworks.id)", rootsArgs...))
+	}
+	if filterOps.YearFrom > 0 {
+		query = query.Where(sq.GtOrEq{"artworks.creationYear": filterOps.YearFrom})
+	}
+	if filterOps.YearTo > 0 {
+		query = query.Where(sq.LtOrEq{"artworks.creationYear": filterOps.YearTo})
+	}
+	if !filterOps.UpdatedFrom.IsZero() {
+		query = query.Where(sq.GtOrEq{"artworks.updatedAt": filterOps.UpdatedFrom})
+	}
+	if !filterOps.UpdatedBefore.IsZero() {
+		query = query.Where(sq.Lt{"artworks.updatedAt": filterOps.UpdatedBefore})
+	}
+	for _, f := range dimensionFilters(filterOps) {
+		column := "artworks." + f.column
+		if f.bounds.Min > 0 {
+			query = query.Where(sq.GtOrEq{column: f.bounds.Min})
+		}
+		if f.bounds.Max > 0 {
+			query = query.Where(sq.LtOrEq{column: f.bounds.Max})
+		}
+	}
 	return query
 }
 
-func (pg *PgArtworkRep) addSortParams(query sq.SelectBuilder, sortOps *jsonreqresp.ArtworkSortOps) sq.SelectBuilder {
+func (pg *PgArtworkRep) addSortParams(
+	query sq.SelectBuilder, filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps,
+) sq.SelectBuilder {
 	switch sortOps.Field {
 	case jsonreqresp.TitleSortFieldArtwork:
 		query = query.OrderBy("artworks.title " + sortOps.Direction)
@@ -128,10 +250,82 @@ func (pg *PgArtworkRep) addSortParams(query sq.SelectBuilder, sortOps *jsonreqre
 		query = query.OrderBy("author.name " + sortOps.Direction)
 	case jsonreqresp.CreationYearSortFieldArtwork:
 		query = query.OrderBy("artworks.creationYear " + sortOps.Direction)
+	case jsonreqresp.CollectionTitleSortFieldArtwork:
+		query = query.OrderBy("collection.title " + sortOps.Direction)
+	case jsonreqresp.RelevanceSortFieldArtwork, jsonreqresp.HeightSortFieldArtwork, jsonreqresp.WidthSortFieldArtwork,
+		jsonreqresp.DepthSortFieldArtwork, jsonreqresp.WeightSortFieldArtwork:
+		if expr, args := pg.sortExpr(sortOps.Field, filterOps); expr != "" {
+			query = query.OrderByClause(expr+" "+jsonreqresp.NormSortDirection(sortOps.Direction), args...)
+		}
 	}
 	return query
 }
 
+// sortExpr возвращает выражение поля сортировки и его аргументы
+func (pg *PgArtworkRep) sortExpr(field string, filterOps *jsonreqresp.ArtworkFilter) (string, []interface{}) {
+	switch field {
+	case jsonreqresp.TitleSortFieldArtwork:
+		return "artworks.title", nil
+	case jsonreqresp.AuthorNameSortFieldArtwork:
+		return "author.name", nil
+	case jsonreqresp.CreationYearSortFieldArtwork:
+		return "COALESCE(artworks.creationYear, 0)", nil
+	case jsonreqresp.CollectionTitleSortFieldArtwork:
+		return "collection.title", nil
+	case jsonreqresp.RelevanceSortFieldArtwork:
+		// без поискового запроса релевантность не определена
+		if filterOps != nil && filterOps.Query != "" {
+			return pgSearchRankExpr, []interface{}{filterOps.Query, filterOps.Query, filterOps.Query}
+		}
+	case jsonreqresp.HeightSortFieldArtwork, jsonreqresp.WidthSortFieldArtwork,
+		jsonreqresp.DepthSortFieldArtwork, jsonreqresp.WeightSortFieldArtwork:
+		return "COALESCE(artworks." + dimensionSortColumns[field] + ", 0)", nil
+	}
+	return "", nil
+}
+
+// addPageParams добавляет к запросу условие keyset-пагинации и порядок (поле сортировки, id)
+func (pg *PgArtworkRep) addPageParams(
+	query sq.SelectBuilder,
+	filterOps *jsonreqresp.ArtworkFilter,
+	sortOps *jsonreqresp.ArtworkSortOps,
+	cursor *jsonreqresp.PageCursor,
+	limit int,
+) (sq.SelectBuilder, error) {
+	expr, exprArgs := pg.sortExpr(sortOps.Field, filterOps)
+	direction := jsonreqresp.NormSortDirection(sortOps.Direction)
+	op := ">"
+	if direction == jsonreqresp.DESCDirection {
+		op = "<"
+	}
+
+	if cursor != nil {
+		switch {
+		case expr == "":
+			query = query.Where(sq.Expr("artworks.id "+op+" ?", cursor.ID))
+		case sortOps.Field == jsonreqresp.CreationYearSortFieldArtwork:
+			year, err := cursor.IntValue()
+			if err != nil {
+				return query, err
+			}
+			query = query.Where(sq.Expr("("+expr+", artworks.id) "+op+" (?, ?)", year, cursor.ID))
+		case sortOps.Field == jsonreqresp.RelevanceSortFieldArtwork || dimensionSortColumns[sortOps.Field] != "":
+			value, err := cursor.FloatValue()
+			if err != nil {
+				return query, err
+			}
+			query = query.Where(sq.Expr("("+expr+", artworks.id) "+op+" (?, ?)", append(exprArgs, value, cursor.ID)...))
+		default:
+			query = query.Where(sq.Expr("("+expr+", artworks.id) "+op+" (?, ?)", cursor.Value, cursor.ID))
+		}
+	}
+
+	if expr != "" {
+		query = query.OrderByClause(expr+" "+direction, exprArgs...)
+	}
+	return query.OrderBy("artworks.id " + direction).Limit(uint64(limit + 1)), nil
+}
+
 func (pg *PgArtworkRep) execSelectQuery(ctx context.Context, query sq.SelectBuilder) ([]*models.Artwork, error) {
 	querySQL, args, err := query.ToSql()
 	if err != nil {
@@ -144,7 +338,7 @@ func (pg *PgArtworkRep) execSelectQuery(ctx context.Context, query sq.SelectBuil
 	}
 	defer rows.Close()
 
-	arts, err := pg.parseArtworksRows(rows)
+	arts, err := pg.parseArtworksRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
@@ -155,7 +349,11 @@ func (pg *PgArtworkRep) GetAllArtworks(ctx context.Context, filterOps *jsonreqre
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	query := psql.Select(
 		"artworks.id", "artworks.title", "artworks.technic", "artworks.material",
-		"artworks.size", "artworks.creationYear",
+		"artworks.size",
+		"artworks.heightCm", "artworks.widthCm", "artworks.depthCm", "artworks.weightKg",
+		"artworks.lengthUnit", "artworks.weightUnit",
+		"artworks.yearFrom", "artworks.yearTo", "artworks.dateQualifier", "artworks.datePrecision", "artworks.authorRole",
+		"artworks.updatedAt",
 		"author.id", "author.name", "author.birthyear", "author.deathyear",
 		"collection.id", "collection.title").
 		From("artworks").
@@ -163,28 +361,267 @@ func (pg *PgArtworkRep) GetAllArtworks(ctx context.Context, filterOps *jsonreqre
 		Join("collection ON artworks.collectionid = collection.id")
 
 	query = pg.addFilterParams(query, filterOps)
-	query = pg.addSortParams(query, sortOps)
+	query = pg.addSortParams(query, filterOps, sortOps)
 	arts, err := pg.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("PgArtworkRep.GetAllArtworks: %w", err)
 	}
+	if err := pg.loadImages(ctx, arts); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetAllArtworks: %w", err)
+	}
+	if err := pg.loadCoAuthors(ctx, pg.db, arts); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetAllArtworks: %w", err)
+	}
+	if err := pg.loadTags(ctx, arts); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetAllArtworks: %w", err)
+	}
 	return arts, nil
 }
 
-func (pg *PgArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artwork, error) {
+func (pg *PgArtworkRep) GetArtworksPage(
+	ctx context.Context,
+	filterOps *jsonreqresp.ArtworkFilter,
+	sortOps *jsonreqresp.ArtworkSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Artwork, jsonreqresp.PageInfo, error) {
+	if sortOps == nil {
+		sortOps = &jsonreqresp.ArtworkSortOps{}
+	}
+	limit := page.PageLimit()
+	pageInfo := jsonreqresp.PageInfo{Limit: limit}
+	cursor, err := page.DecodeCursor()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	// полный подсчет по фильтру нужен только первой странице
+	if page.IsFirst() {
+		countQuery := psql.Select("COUNT(*)").
+			From("artworks").
+			Join("author ON artworks.authorid = author.id").
+			Join("collection ON artworks.collectionid = collection.id")
+		countQuery = pg.addFilterParams(countQuery, filterOps)
+		countSQL, countArgs, err := countQuery.ToSql()
+		if err != nil {
+			return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w: %v", ErrQueryBuilds, err)
+		}
+		if err := pg.db.QueryRowContext(ctx, countSQL, countArgs...).Scan(&pageInfo.Total); err != nil {
+			return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w: %v", ErrQueryExec, err)
+		}
+		pageInfo.HasTotal = true
+	}
+
+	keyExpr := sq.Expr("''")
+	if expr, args := pg.sortExpr(sortOps.Field, filterOps); expr != "" {
+		keyExpr = sq.Expr("CAST("+expr+" AS text)", args...)
+	}
 	query := psql.Select(
+		"artworks.id", "artworks.title", "artworks.technic", "artworks.material",
+		"artworks.size",
+		"artworks.heightCm", "artworks.widthCm", "artworks.depthCm", "artworks.weightKg",
+		"artworks.lengthUnit", "artworks.weightUnit",
+		"artworks.yearFrom", "artworks.yearTo", "artworks.dateQualifier", "artworks.datePrecision", "artworks.authorRole",
+		"artworks.updatedAt",
+		"author.id", "author.name", "author.birthyear", "author.deathyear",
+		"collection.id", "collection.title").
+		Column(keyExpr).
+		From("artworks").
+		Join("author ON artworks.authorid = author.id").
+		Join("collection ON artworks.collectionid = collection.id")
+	query = pg.addFilterParams(query, filterOps)
+	query, err = pg.addPageParams(query, filterOps, sortOps, cursor, limit)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var sortKeys []string
+	arts, err := pg.parseArtworksRows(rows, &sortKeys)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+	if len(arts) > limit {
+		arts = arts[:limit]
+		pageInfo.NextCursor = jsonreqresp.EncodeCursor(sortKeys[limit-1], arts[limit-1].GetID())
+	}
+	if err := pg.loadImages(ctx, arts); err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+	if err := pg.loadCoAuthors(ctx, pg.db, arts); err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+	if err := pg.loadTags(ctx, arts); err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+	if err := pg.loadHighlights(ctx, arts, filterOps.Query); err != nil {
+		return nil, pageInfo, fmt.Errorf("PgArtworkRep.GetArtworksPage: %w", err)
+	}
+	return arts, pageInfo, nil
+}
+
+// facetBuckets считает произведения, подходящие под фильтр, по значениям выражения valueExpr
+func (pg *PgArtworkRep) facetBuckets(
+	ctx context.Context,
+	filterOps *jsonreqresp.ArtworkFilter,
+	valueExpr string,
+	labelExpr string,
+	orderBy string,
+) ([]models.FacetBucket, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select(valueExpr, labelExpr, "COUNT(*)").
+		From("artworks").
+		Join("author ON artworks.authorid = author.id").
+		Join("collection ON artworks.collectionid = collection.id")
+	query = pg.addFilterParams(query, filterOps)
+	query = query.Where(valueExpr+" <> ''").
+		GroupBy(valueExpr, labelExpr).
+		OrderBy(orderBy).
+		Limit(models.ArtworkFacetMaxBuckets)
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var buckets []models.FacetBucket
+	for rows.Next() {
+		var bucket models.FacetBucket
+		if err := rows.Scan(&bucket.Value, &bucket.Label, &bucket.Count); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+		}
+		buckets = append(buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return buckets, nil
+}
+
+func (pg *PgArtworkRep) GetArtworkFacets(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter) (*models.ArtworkFacets, error) {
+	const centuryExpr = "CAST((artworks.creationYear - 1) / 100 + 1 AS text)"
+	facets := &models.ArtworkFacets{}
+	specs := []struct {
+		facet     string
+		dest      *[]models.FacetBucket
+		valueExpr string
+		labelExpr string
+		orderBy   string
+	}{
+		{jsonreqresp.TechnicFacetArtwork, &facets.Technics, "artworks.technic", "artworks.technic", "COUNT(*) DESC, artworks.technic"},
+		{jsonreqresp.MaterialFacetArtwork, &facets.Materials, "artworks.material", "artworks.material", "COUNT(*) DESC, artworks.material"},
+		{jsonreqresp.CenturyFacetArtwork, &facets.Centuries, centuryExpr, centuryExpr, "MIN(artworks.creationYear)"},
+		{jsonreqresp.AuthorFacetArtwork, &facets.Authors, "CAST(author.id AS text)", "author.name", "COUNT(*) DESC, author.name"},
+		{jsonreqresp.CollectionFacetArtwork, &facets.Collections, "CAST(collection.id AS text)", "collection.title", "COUNT(*) DESC, collection.title"},
+	}
+	for _, spec := range specs {
+		buckets, err := pg.facetBuckets(ctx, filterOps.WithoutFacet(spec.facet), spec.valueExpr, spec.labelExpr, spec.orderBy)
+		if err != nil {
+			return nil, fmt.Errorf("PgArtworkRep.GetArtworkFacets: %s: %w", spec.facet, err)
+		}
+		*spec.dest = buckets
+	}
+	labelCenturies(facets.Centuries)
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	yearQuery := psql.Select("COALESCE(MIN(artworks.creationYear), 0)", "COALESCE(MAX(artworks.creationYear), 0)").
+		From("artworks").
+		Join("author ON artworks.authorid = author.id").
+		Join("collection ON artworks.collectionid = collection.id")
+	yearQuery = pg.addFilterParams(yearQuery, filterOps.WithoutFacet(jsonreqresp.YearFromParamArtwork))
+	yearSQL, yearArgs, err := yearQuery.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetArtworkFacets: %w: %v", ErrQueryBuilds, err)
+	}
+	if err := pg.db.QueryRowContext(ctx, yearSQL, yearArgs...).Scan(&facets.YearMin, &facets.YearMax); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetArtworkFacets: %w: %v", ErrQueryExec, err)
+	}
+	return facets, nil
+}
+
+func (pg *PgArtworkRep) GetEarliestUpdatedAt(ctx context.Context) (time.Time, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select("MIN(updatedAt)").
+		From("artworks").
+		Where(sq.Eq{"deletedAt": nil}).
+		ToSql()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("PgArtworkRep.GetEarliestUpdatedAt: %w: %v", ErrQueryBuilds, err)
+	}
+	var earliest sql.NullTime
+	if err := pg.db.QueryRowContext(ctx, querySQL, args...).Scan(&earliest); err != nil {
+		return time.Time{}, fmt.Errorf("PgArtworkRep.GetEarliestUpdatedAt: %w: %v", ErrQueryExec, err)
+	}
+	return earliest.Time, nil
+}
+
+func (pg *PgArtworkRep) GetExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	var existing uuid.UUIDs
+	for _, chunk := range importChunks(ids) {
+		querySQL, args, err := psql.Select("id").
+			From("artworks").
+			Where(sq.Eq{"id": chunk}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("PgArtworkRep.GetExistingIDs: %w: %v", ErrQueryBuilds, err)
+		}
+		rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+		if err != nil {
+			return nil, fmt.Errorf("PgArtworkRep.GetExistingIDs: %w: %v", ErrQueryExec, err)
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("PgArtworkRep.GetExistingIDs: %v", err)
+			}
+			existing = append(existing, id)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("PgArtworkRep.GetExistingIDs rows iteration error: %v", err)
+		}
+	}
+	return existing, nil
+}
+
+// byIDQuery выбирает произведение по id без изображений, тегов и дополнительных авторов
+func byIDQuery(id uuid.UUID) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select(
 		"art.id", "art.title", "art.technic", "art.material",
-		"art.size", "art.creationYear",
+		"art.size",
+		"art.heightCm", "art.widthCm", "art.depthCm", "art.weightKg",
+		"art.lengthUnit", "art.weightUnit",
+		"art.yearFrom", "art.yearTo", "art.dateQualifier", "art.datePrecision", "art.authorRole",
+		"art.updatedAt",
 		"au.id", "au.name", "au.birthyear", "au.deathyear",
 		"col.id", "col.title",
 	).
 		From("artworks art").
 		Join("author au ON art.authorid = au.id").
 		Join("collection col ON art.collectionid = col.id").
-		Where(sq.Eq{"art.id": id})
+		Where(sq.Eq{"art.id": id, "art.deletedAt": nil})
+}
 
-	arts, err := pg.execSelectQuery(ctx, query)
+func (pg *PgArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artwork, error) {
+	arts, err := pg.execSelectQuery(ctx, byIDQuery(id))
 	if err != nil {
 		return nil, fmt.Errorf("PgArtworkRep.GetByID: %w", err)
 	}
@@ -194,6 +631,40 @@ func (pg *PgArtworkRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Artw
 	} else if len(arts) > 1 {
 		return nil, fmt.Errorf("PgArtworkRep.GetByID: %w", ErrExpectedOneArtwork)
 	}
+	if err := pg.loadImages(ctx, arts); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetByID: %w", err)
+	}
+	if err := pg.loadCoAuthors(ctx, pg.db, arts); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetByID: %w", err)
+	}
+	if err := pg.loadTags(ctx, arts); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetByID: %w", err)
+	}
+	return arts[0], nil
+}
+
+// getForUpdate читает произведение с дополнительными авторами в транзакции tx
+// и блокирует его строку до конца транзакции
+func (pg *PgArtworkRep) getForUpdate(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.Artwork, error) {
+	querySQL, args, err := byIDQuery(id).Suffix("FOR UPDATE OF art").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := tx.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	arts, err := pg.parseArtworksRows(rows, nil)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(arts) == 0 {
+		return nil, ErrArtworkNotFound
+	}
+	if err := pg.loadCoAuthors(ctx, tx, arts); err != nil {
+		return nil, err
+	}
 	return arts[0], nil
 }
 
@@ -218,25 +689,136 @@ func (pg *PgArtworkRep) execChangeQuery(ctx context.Context, query sq.Sqlizer) e
 }
 
 func (pg *PgArtworkRep) Add(ctx context.Context, e *models.Artwork) error {
-	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Insert("Artworks").
-		Columns("id", "title", "technic", "material", "size", "creationYear", "authorID", "collectionID").
-		Values(e.GetID(), e.GetTitle(), e.GetTechnic(), e.GetMaterial(), e.GetSize(), e.GetCreationYear(), e.GetAuthor().GetID(), e.GetCollection().GetID())
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Add: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
 
-	err := pg.execChangeQuery(ctx, query)
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Insert("Artworks").
+		Columns(artworkColumns...).
+		Values(artworkValues(e)...).
+		ToSql()
 	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Add: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("PgArtworkRep.Add: %w: %v", ErrQueryExec, err)
+	}
+	if err := pg.insertCoAuthors(ctx, tx, e); err != nil {
 		return fmt.Errorf("PgArtworkRep.Add: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgArtworkRep.Add: %w: %v", ErrQueryExec, err)
+	}
 	return nil
 }
 
+func (pg *PgArtworkRep) ImportCatalog(ctx context.Context, batch *models.CatalogImport) error {
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.ImportCatalog: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	var queries []sq.InsertBuilder
+	for _, chunk := range importChunks(batch.Authors) {
+		query := psql.Insert("Author").Columns("id", "name", "birthYear", "deathYear")
+		for _, a := range chunk {
+			query = query.Values(a.GetID(), a.GetName(), a.GetBirthYear(), deathYearValue(a))
+		}
+		queries = append(queries, query)
+	}
+	for _, chunk := range importChunks(batch.Collections) {
+		query := psql.Insert("Collection").Columns("id", "title")
+		for _, c := range chunk {
+			query = query.Values(c.GetID(), c.GetTitle())
+		}
+		queries = append(queries, query)
+	}
+	for _, chunk := range importChunks(batch.Artworks) {
+		query := psql.Insert("Artworks").Columns(artworkColumns...)
+		for _, a := range chunk {
+			query = query.Values(artworkValues(a)...)
+		}
+		queries = append(queries, query)
+	}
+	for _, query := range queries {
+		querySQL, args, err := query.ToSql()
+		if err != nil {
+			return fmt.Errorf("PgArtworkRep.ImportCatalog: %w: %v", ErrQueryBuilds, err)
+		}
+		if _, err := tx.ExecContext(ctx, querySQL, args...); err != nil {
+			return fmt.Errorf("PgArtworkRep.ImportCatalog: %w: %v", ErrQueryExec, err)
+		}
+	}
+	for _, a := range batch.Artworks {
+		if err := pg.insertCoAuthors(ctx, tx, a); err != nil {
+			return fmt.Errorf("PgArtworkRep.ImportCatalog: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgArtworkRep.ImportCatalog: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// Delete переносит произведение в корзину. Произведение текущего или будущего мероприятия не удаляется,
+// такие мероприятия возвращаются в models.DependencyError
 func (pg *PgArtworkRep) Delete(ctx context.Context, idArt uuid.UUID) error {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Delete("Artworks").
-		Where(sq.Eq{"id": idArt})
-	err := pg.execChangeQuery(ctx, query)
+	tx, err := pg.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("PgArtworkRep.Delete: %w:", err)
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	eventsSQL, eventsArgs, err := psql.Select("e.id", "e.title").
+		From("Events e").
+		Join("Artwork_event ae ON ae.eventID = e.id").
+		Where(sq.Eq{"ae.artworkID": idArt, "e.valid": true}).
+		Where("e.dateEnd >= now()").
+		OrderBy("e.dateBegin").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := tx.QueryContext(ctx, eventsSQL, eventsArgs...)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	dependents, err := parseDependentRows(rows, models.EntityEvent)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w", err)
+	}
+	if err := models.CheckDependents(dependents); err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w", err)
+	}
+
+	deleteSQL, deleteArgs, err := psql.Update("Artworks").
+		Set("deletedAt", sq.Expr("now()")).
+		Where(sq.Eq{"id": idArt, "deletedAt": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	result, err := tx.ExecContext(ctx, deleteSQL, deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrRowsAffected, err)
+	} else if n == 0 {
+		return fmt.Errorf("PgArtworkRep.Delete: %w", ErrArtworkNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgArtworkRep.Delete: %w: %v", ErrQueryExec, err)
 	}
 	return nil
 }
@@ -244,13 +826,22 @@ func (pg *PgArtworkRep) Delete(ctx context.Context, idArt uuid.UUID) error {
 func (pg *PgArtworkRep) Update(ctx context.Context,
 	idArt uuid.UUID,
 	funcUpdate func(*models.Artwork) (*models.Artwork, error),
+	change *models.Change,
 ) error {
-	art, err := pg.GetByID(ctx, idArt)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	// состояние до изменения читается под блокировкой строки, чтобы параллельные изменения не искажали историю
+	art, err := pg.getForUpdate(ctx, tx, idArt)
 	if err != nil {
 		return fmt.Errorf("PgArtworkRep.Update: %w", err)
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	before := art.Snapshot()
 	updatedArtwork, err := funcUpdate(art)
 	if err != nil {
 		return fmt.Errorf("PgArtworkRep.Update: %w", ErrUpdateArtwork)
@@ -263,14 +854,583 @@ func (pg *PgArtworkRep) Update(ctx context.Context,
 		Set("creationYear", updatedArtwork.GetCreationYear()).
 		Set("authorID", updatedArtwork.GetAuthor().GetID()).
 		Set("collectionID", updatedArtwork.GetCollection().GetID()).
+		Set("updatedAt", sq.Expr("now()")).
 		Where(sq.Eq{"id": idArt})
-	err = pg.execChangeQuery(ctx, query)
+	for i, value := range dimensionsValues(updatedArtwork.GetDimensions()) {
+		query = query.Set(dimensionsColumns[i], value)
+	}
+	for i, value := range datingValues(updatedArtwork) {
+		query = query.Set(datingColumns[i], value)
+	}
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrQueryBuilds, err)
+	}
+
+	result, err := tx.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("PgArtworkRep.Update: %w: no artwork updated", ErrRowsAffected)
+	}
+	deleteSQL, deleteArgs, err := psql.Delete("Artwork_authors").Where(sq.Eq{"artworkID": idArt}).ToSql()
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, deleteSQL, deleteArgs...); err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	if err := pg.insertCoAuthors(ctx, tx, updatedArtwork); err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w", err)
+	}
+	err = historyrep.PgAddChange(ctx, tx, models.EntityArtwork, idArt, change, before, updatedArtwork.Snapshot())
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgArtworkRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// insertCoAuthors сохраняет дополнительных авторов произведения в порядке показа
+func (pg *PgArtworkRep) insertCoAuthors(ctx context.Context, tx *sql.Tx, a *models.Artwork) error {
+	if len(a.GetCoAuthors()) == 0 {
+		return nil
+	}
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Artwork_authors").Columns("artworkID", "authorID", "role", "position")
+	for i, co := range a.GetCoAuthors() {
+		query = query.Values(a.GetID(), co.GetAuthor().GetID(), string(co.GetRole()), i)
+	}
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, querySQL, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// loadCoAuthors одним запросом подгружает дополнительных авторов для всех переданных произведений
+func (pg *PgArtworkRep) loadCoAuthors(ctx context.Context, q pgQuerier, arts []*models.Artwork) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	ids := make(uuid.UUIDs, len(arts))
+	for i, a := range arts {
+		ids[i] = a.GetID()
+	}
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select("aa.artworkID", "aa.role", "au.id", "au.name", "au.birthyear", "au.deathyear").
+		From("Artwork_authors aa").
+		Join("Author au ON aa.authorID = au.id").
+		Where(sq.Eq{"aa.artworkID": []uuid.UUID(ids)}).
+		OrderBy("aa.artworkID", "aa.position").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := q.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	byArtwork, err := parseCoAuthorRows(rows)
+	if err != nil {
+		return err
+	}
+	return setCoAuthors(arts, byArtwork)
+}
+
+// loadTags одним запросом подгружает теги для всех переданных произведений
+func (pg *PgArtworkRep) loadTags(ctx context.Context, arts []*models.Artwork) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	ids := make(uuid.UUIDs, len(arts))
+	for i, a := range arts {
+		ids[i] = a.GetID()
+	}
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select("at.artworkID", "t.id", "t.name", "t.kind", "t.parentID", "t.synonyms").
+		From("Artwork_tags at").
+		Join("Tags t ON at.tagID = t.id").
+		Where(sq.Eq{"at.artworkID": []uuid.UUID(ids)}).
+		OrderBy("at.artworkID", "t.kind", "t.name").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	byArtwork, err := parseArtworkTagRows(rows)
+	if err != nil {
+		return err
+	}
+	setTags(arts, byArtwork)
+	return nil
+}
+
+func (pg *PgArtworkRep) selectImages(ctx context.Context, query sq.SelectBuilder) ([]*models.ArtworkImage, error) {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var images []*models.ArtworkImage
+	for rows.Next() {
+		var id, artworkID uuid.UUID
+		var position, width, height int
+		var isPrimary bool
+		var format string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &artworkID, &position, &isPrimary, &format, &width, &height, &createdAt); err != nil {
+			return nil, fmt.Errorf("selectImages: scan error: %v", err)
+		}
+		img, err := models.NewArtworkImage(id, artworkID, position, isPrimary, models.ImageFormat(format), width, height, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("selectImages: %w: %v", models.ErrValidateArtworkImage, err)
+		}
+		images = append(images, &img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("selectImages: rows iteration error: %v", err)
+	}
+	return images, nil
+}
+
+func (pg *PgArtworkRep) imagesQuery() sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select("id", "artworkID", "position", "isPrimary", "format", "width", "height", "createdAt").
+		From("Artwork_images").
+		OrderBy("artworkID", "position", "createdAt")
+}
+
+// loadImages одним запросом подгружает изображения для всех переданных произведений
+func (pg *PgArtworkRep) loadImages(ctx context.Context, arts []*models.Artwork) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	ids := make(uuid.UUIDs, len(arts))
+	for i, a := range arts {
+		ids[i] = a.GetID()
+	}
+	images, err := pg.selectImages(ctx, pg.imagesQuery().Where(sq.Eq{"artworkID": []uuid.UUID(ids)}))
+	if err != nil {
+		return err
+	}
+
+	byArtwork := make(map[uuid.UUID][]*models.ArtworkImage, len(arts))
+	for _, img := range images {
+		byArtwork[img.GetArtworkID()] = append(byArtwork[img.GetArtworkID()], img)
+	}
+	for _, a := range arts {
+		a.SetImages(byArtwork[a.GetID()])
+	}
+	return nil
+}
+
+// loadHighlights выделяет в полях найденных произведений слова, совпавшие с поисковым запросом
+func (pg *PgArtworkRep) loadHighlights(ctx context.Context, arts []*models.Artwork, searchQuery string) error {
+	if searchQuery == "" || len(arts) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(arts))
+	for i, a := range arts {
+		ids[i] = a.GetID()
+	}
+	headline := func(field string) sq.Sqlizer {
+		return sq.Expr("ts_headline('russian', COALESCE("+field+", ''), "+pgSearchTsQuery+", ?)",
+			searchQuery, pgHeadlineOptions)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select("artworks.id").
+		Column(headline("artworks.title")).
+		Column(headline("author.name")).
+		Column(headline("collection.title")).
+		Column(headline("artworks.technic")).
+		Column(headline("artworks.material")).
+		From("artworks").
+		Join("author ON artworks.authorid = author.id").
+		Join("collection ON artworks.collectionid = collection.id").
+		Where(sq.Eq{"artworks.id": ids})
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
 	if err != nil {
-		return fmt.Errorf("PgArtworkRep.Update %w", err)
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	highlights := make(map[uuid.UUID]*models.ArtworkHighlight, len(arts))
+	for rows.Next() {
+		var id uuid.UUID
+		var h models.ArtworkHighlight
+		if err := rows.Scan(&id, &h.Title, &h.AuthorName, &h.Collection, &h.Technic, &h.Material); err != nil {
+			return fmt.Errorf("loadHighlights: scan error: %v", err)
+		}
+		h.Title = models.HighlightMarked(h.Title)
+		h.AuthorName = models.HighlightMarked(h.AuthorName)
+		h.Collection = models.HighlightMarked(h.Collection)
+		h.Technic = models.HighlightMarked(h.Technic)
+		h.Material = models.HighlightMarked(h.Material)
+		highlights[id] = &h
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("loadHighlights: rows iteration error: %v", err)
+	}
+	for _, a := range arts {
+		a.SetHighlight(highlights[a.GetID()])
 	}
 	return nil
 }
 
+func (pg *PgArtworkRep) GetImages(ctx context.Context, artworkID uuid.UUID) ([]*models.ArtworkImage, error) {
+	images, err := pg.selectImages(ctx, pg.imagesQuery().Where(sq.Eq{"artworkID": artworkID}))
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetImages: %w", err)
+	}
+	return images, nil
+}
+
+func (pg *PgArtworkRep) AddImage(ctx context.Context, img *models.ArtworkImage) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Artwork_images").
+		Columns("id", "artworkID", "position", "isPrimary", "format", "width", "height", "createdAt").
+		Values(img.GetID(), img.GetArtworkID(), img.GetPosition(), img.IsPrimary(),
+			string(img.GetFormat()), img.GetWidth(), img.GetHeight(), img.GetCreatedAt())
+
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.AddImage: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) DeleteImage(ctx context.Context, artworkID uuid.UUID, imageID uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Delete("Artwork_images").
+		Where(sq.Eq{"id": imageID, "artworkID": artworkID})
+	err := pg.execChangeQuery(ctx, query)
+	if errors.Is(err, ErrRowsAffected) {
+		return fmt.Errorf("PgArtworkRep.DeleteImage: %w", ErrArtworkImageNotFound)
+	} else if err != nil {
+		return fmt.Errorf("PgArtworkRep.DeleteImage: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) UpdateImages(ctx context.Context, artworkID uuid.UUID, images []*models.ArtworkImage) error {
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	// сначала снимаем признак основного, чтобы не нарушить уникальный индекс
+	query, args, err := psql.Update("Artwork_images").
+		Set("isPrimary", false).
+		Where(sq.Eq{"artworkID": artworkID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrQueryExec, err)
+	}
+
+	for _, img := range images {
+		query, args, err := psql.Update("Artwork_images").
+			Set("position", img.GetPosition()).
+			Set("isPrimary", img.IsPrimary()).
+			Where(sq.Eq{"id": img.GetID(), "artworkID": artworkID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrQueryBuilds, err)
+		}
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrQueryExec, err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrRowsAffected, err)
+		} else if rowsAffected == 0 {
+			return fmt.Errorf("PgArtworkRep.UpdateImages: %w", ErrArtworkImageNotFound)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateImages: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) GetProvenance(ctx context.Context, artworkID uuid.UUID) ([]*models.ProvenanceEntry, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select(provenanceColumns...).
+		From("Artwork_provenance").
+		Where(sq.Eq{"artworkID": artworkID}).
+		OrderBy("position", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetProvenance: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetProvenance: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	entries, err := parseProvenanceRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetProvenance: %w", err)
+	}
+	return entries, nil
+}
+
+func (pg *PgArtworkRep) AddProvenanceEntry(ctx context.Context, entry *models.ProvenanceEntry) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Artwork_provenance").
+		Columns(provenanceColumns...).
+		Values(provenanceValues(entry)...)
+
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.AddProvenanceEntry: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) DeleteProvenanceEntry(ctx context.Context, artworkID uuid.UUID, entryID uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Delete("Artwork_provenance").
+		Where(sq.Eq{"id": entryID, "artworkID": artworkID})
+	err := pg.execChangeQuery(ctx, query)
+	if errors.Is(err, ErrRowsAffected) {
+		return fmt.Errorf("PgArtworkRep.DeleteProvenanceEntry: %w", ErrProvenanceNotFound)
+	} else if err != nil {
+		return fmt.Errorf("PgArtworkRep.DeleteProvenanceEntry: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) UpdateProvenance(ctx context.Context, artworkID uuid.UUID, entries []*models.ProvenanceEntry) error {
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateProvenance: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	for _, entry := range entries {
+		query, args, err := psql.Update("Artwork_provenance").
+			Set("position", entry.GetPosition()).
+			Set("owner", entry.GetOwner()).
+			Set("ownerUncertain", entry.IsOwnerUncertain()).
+			Set("location", entry.GetLocation()).
+			Set("periodFrom", entry.GetPeriodFrom()).
+			Set("periodTo", entry.GetPeriodTo()).
+			Set("dateQualifier", string(entry.GetDateQualifier())).
+			Set("transferMethod", string(entry.GetTransferMethod())).
+			Set("sources", entry.GetSources()).
+			Set("notes", entry.GetNotes()).
+			Set("isPublic", entry.IsPublic()).
+			Where(sq.Eq{"id": entry.GetID(), "artworkID": artworkID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("PgArtworkRep.UpdateProvenance: %w: %v", ErrQueryBuilds, err)
+		}
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("PgArtworkRep.UpdateProvenance: %w: %v", ErrQueryExec, err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("PgArtworkRep.UpdateProvenance: %w: %v", ErrRowsAffected, err)
+		} else if rowsAffected == 0 {
+			return fmt.Errorf("PgArtworkRep.UpdateProvenance: %w", ErrProvenanceNotFound)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateProvenance: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// selectConditionReports выполняет выборку отчетов и загружает их фотографии
+func (pg *PgArtworkRep) selectConditionReports(ctx context.Context, query sq.SelectBuilder) ([]*models.ConditionReport, error) {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+	reports, err := parseConditionReportRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return reports, nil
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err = psql.Select(conditionPhotoColumns...).
+		From("Condition_report_photos").
+		Where(sq.Eq{"reportID": reportIDs(reports)}).
+		OrderBy("createdAt", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	photoRows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer photoRows.Close()
+	photos, err := parseConditionPhotoRows(photoRows)
+	if err != nil {
+		return nil, err
+	}
+	attachConditionPhotos(reports, photos)
+	return reports, nil
+}
+
+func (pg *PgArtworkRep) GetConditionReports(ctx context.Context, artworkID uuid.UUID) ([]*models.ConditionReport, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select(conditionReportColumns...).
+		From("Condition_reports").
+		Where(sq.Eq{"artworkID": artworkID}).
+		OrderBy("checkedAt DESC", "id")
+	reports, err := pg.selectConditionReports(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetConditionReports: %w", err)
+	}
+	return reports, nil
+}
+
+func (pg *PgArtworkRep) GetConditionReport(ctx context.Context, artworkID uuid.UUID, reportID uuid.UUID) (*models.ConditionReport, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select(conditionReportColumns...).
+		From("Condition_reports").
+		Where(sq.Eq{"id": reportID, "artworkID": artworkID})
+	reports, err := pg.selectConditionReports(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetConditionReport: %w", err)
+	} else if len(reports) == 0 {
+		return nil, fmt.Errorf("PgArtworkRep.GetConditionReport: %w", ErrConditionReportNotFound)
+	}
+	return reports[0], nil
+}
+
+func (pg *PgArtworkRep) AddConditionReport(ctx context.Context, report *models.ConditionReport) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Condition_reports").
+		Columns(conditionReportColumns...).
+		Values(conditionReportValues(report)...)
+
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.AddConditionReport: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) UpdateConditionReport(ctx context.Context, report *models.ConditionReport) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Update("Condition_reports").
+		Set("checkedAt", report.GetCheckedAt()).
+		Set("examiner", report.GetExaminer()).
+		Set("grade", string(report.GetGrade())).
+		Set("notes", report.GetNotes()).
+		Set("treatment", report.GetTreatment()).
+		Set("treatmentEnd", optionalTime(report.GetTreatmentEnd())).
+		Where(sq.Eq{"id": report.GetID(), "artworkID": report.GetArtworkID()})
+	err := pg.execChangeQuery(ctx, query)
+	if errors.Is(err, ErrRowsAffected) {
+		return fmt.Errorf("PgArtworkRep.UpdateConditionReport: %w", ErrConditionReportNotFound)
+	} else if err != nil {
+		return fmt.Errorf("PgArtworkRep.UpdateConditionReport: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) AddConditionPhoto(ctx context.Context, photo *models.ConditionPhoto) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Condition_report_photos").
+		Columns(conditionPhotoColumns...).
+		Values(photo.GetID(), photo.GetReportID(), photo.GetArtworkID(), string(photo.GetFormat()), photo.GetCreatedAt())
+
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgArtworkRep.AddConditionPhoto: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgArtworkRep) GetTreatmentsOnDate(
+	ctx context.Context, artworkID uuid.UUID, dateBeg time.Time, dateEnd time.Time,
+) ([]*models.ConditionReport, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select(conditionReportColumns...).
+		From("Condition_reports").
+		Where(sq.Eq{"artworkID": artworkID}).
+		Where(sq.NotEq{"treatment": ""}).
+		Where(sq.LtOrEq{"checkedAt": dateEnd}).
+		Where(sq.Or{sq.Eq{"treatmentEnd": nil}, sq.GtOrEq{"treatmentEnd": dateBeg}}).
+		OrderBy("checkedAt DESC", "id")
+	reports, err := pg.selectConditionReports(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetTreatmentsOnDate: %w", err)
+	}
+	return reports, nil
+}
+
+func (pg *PgArtworkRep) GetConditionReportsNeedingAttention(ctx context.Context) ([]*models.ConditionReport, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select(conditionReportColumns...).
+		From("Condition_reports r").
+		// произведения из корзины не требуют внимания
+		Where("r.artworkID IN (SELECT id FROM Artworks WHERE deletedAt IS NULL)").
+		Where(sq.Or{
+			sq.And{sq.NotEq{"treatment": ""}, sq.Eq{"treatmentEnd": nil}},
+			sq.And{
+				sq.Eq{"grade": attentionGrades},
+				// оценка учитывается только из последнего отчета произведения
+				sq.Expr(`NOT EXISTS (SELECT 1 FROM Condition_reports n
+					WHERE n.artworkID = r.artworkID AND n.checkedAt > r.checkedAt)`),
+			},
+		}).
+		OrderBy("checkedAt DESC", "id")
+	reports, err := pg.selectConditionReports(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetConditionReportsNeedingAttention: %w", err)
+	}
+	return reports, nil
+}
+
 func (pg *PgArtworkRep) Ping(ctx context.Context) error {
 	return pg.db.PingContext(ctx)
 }
@@ -278,3 +1438,78 @@ func (pg *PgArtworkRep) Ping(ctx context.Context) error {
 func (pg *PgArtworkRep) Close() {
 	pg.db.Close()
 }
+
+// GetSimilarCandidates отбирает только произведения, у которых есть хотя бы один признак сходства
+func (pg *PgArtworkRep) GetSimilarCandidates(ctx context.Context, artworkID uuid.UUID, limit int) ([]*models.SimilarityCandidate, error) {
+	w := models.DefaultSimilarityWeights
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select(
+		"a.id", "a.authorID", "a.collectionID",
+		"a.authorID = src.authorID",
+		"a.collectionID = src.collectionID",
+		"COALESCE(a.technic <> '' AND a.technic = src.technic, false)",
+		"COALESCE(a.material <> '' AND a.material = src.material, false)",
+		"ABS(a.creationYear - src.creationYear)",
+		"COALESCE(se.cnt, 0)",
+		"COALESCE(cf.cnt, 0)").
+		Prefix(`WITH src AS (
+				SELECT id, authorID, collectionID, technic, material, creationYear
+				FROM Artworks WHERE id = ? AND deletedAt IS NULL
+			), shared_events AS (
+				SELECT ae.artworkID, COUNT(*) AS cnt
+				FROM Artwork_event ae JOIN Events ON ae.eventID = Events.id
+				WHERE Events.valid AND Events.status = 'approved'
+					AND ae.eventID IN (SELECT eventID FROM Artwork_event WHERE artworkID = ?)
+				GROUP BY ae.artworkID
+			), co_favourites AS (
+				SELECT f.entityID AS artworkID, COUNT(*) AS cnt
+				FROM User_favourites f
+				WHERE f.entityType = 'artwork' AND f.userID IN (
+					SELECT userID FROM User_favourites WHERE entityType = 'artwork' AND entityID = ?)
+				GROUP BY f.entityID
+			)`, artworkID, artworkID, artworkID).
+		From("Artworks a").
+		JoinClause("CROSS JOIN src").
+		LeftJoin("shared_events se ON se.artworkID = a.id").
+		LeftJoin("co_favourites cf ON cf.artworkID = a.id").
+		Where("a.id <> src.id AND a.deletedAt IS NULL").
+		Where(`(a.authorID = src.authorID OR a.collectionID = src.collectionID
+			OR (a.technic <> '' AND a.technic = src.technic) OR (a.material <> '' AND a.material = src.material)
+			OR ABS(a.creationYear - src.creationYear) < ? OR se.cnt IS NOT NULL OR cf.cnt IS NOT NULL)`,
+			models.SimilarPeriodWindow).
+		OrderByClause(`(CASE WHEN a.authorID = src.authorID THEN ?::float8 ELSE 0 END)
+			+ (CASE WHEN a.collectionID = src.collectionID THEN ?::float8 ELSE 0 END)
+			+ (CASE WHEN a.technic <> '' AND a.technic = src.technic THEN ?::float8 ELSE 0 END)
+			+ (CASE WHEN a.material <> '' AND a.material = src.material THEN ?::float8 ELSE 0 END)
+			+ ?::float8 * GREATEST(?::int - ABS(a.creationYear - src.creationYear), 0) / ?::float8
+			+ ?::float8 * LEAST(COALESCE(se.cnt, 0), ?::int)
+			+ ?::float8 * LEAST(COALESCE(cf.cnt, 0), ?::int) DESC, a.id`,
+			w.Author, w.Collection, w.Technic, w.Material,
+			w.Period, models.SimilarPeriodWindow, models.SimilarPeriodWindow,
+			w.SharedEvent, models.SimilarMaxSharedEvents,
+			w.CoFavourite, models.SimilarMaxCoFavourites).
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var res []*models.SimilarityCandidate
+	for rows.Next() {
+		c := &models.SimilarityCandidate{}
+		if err := rows.Scan(&c.ArtworkID, &c.AuthorID, &c.CollectionID, &c.SameAuthor, &c.SameCollection,
+			&c.SameTechnic, &c.SameMaterial, &c.YearDiff, &c.SharedEvents, &c.CoFavourites); err != nil {
+			return nil, fmt.Errorf("PgArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryExec, err)
+		}
+		res = append(res, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PgArtworkRep.GetSimilarCandidates: %w: %v", ErrQueryExec, err)
+	}
+	return res, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
@@ -322,7 +323,7 @@ func TestPgArtworkRep_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			artwork, _, _ := th.createAndAddArtwork(t, 1)
 
-			err := th.arep.Update(th.ctx, artwork.GetID(), tt.updateFunc)
+			err := th.arep.Update(th.ctx, artwork.GetID(), tt.updateFunc, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -337,3 +338,696 @@ func TestPgArtworkRep_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestPgArtworkRep_ConcurrentUpdate(t *testing.T) {
+	th := setupTestHelper(t)
+	artwork, _, _ := th.createAndAddArtwork(t, 1)
+
+	const updates = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- th.arep.Update(th.ctx, artwork.GetID(), func(a *models.Artwork) (*models.Artwork, error) {
+				return a, a.Update(jsonreqresp.ArtworkUpdate{
+					Title:        a.GetTitle(),
+					Technic:      a.GetTechnic(),
+					Material:     a.GetMaterial(),
+					Size:         a.GetSize(),
+					CreationYear: a.GetCreationYear() + 1,
+				})
+			}, nil)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// каждое изменение видит результат предыдущего
+	dbArtwork, err := th.arep.GetByID(th.ctx, artwork.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, artwork.GetCreationYear()+updates, dbArtwork.GetCreationYear())
+}
+
+func TestArtworkRep_GetArtworksPage(t *testing.T) {
+	th := setupTestHelper(t)
+
+	var artworks []*models.Artwork
+	for i := 1; i <= 5; i++ {
+		art, _, _ := th.createAndAddArtwork(t, i)
+		artworks = append(artworks, art)
+	}
+	defer th.deleteArtwork(t, artworks)
+
+	filter := &jsonreqresp.ArtworkFilter{}
+	sortOps := &jsonreqresp.ArtworkSortOps{
+		Field:     jsonreqresp.CreationYearSortFieldArtwork,
+		Direction: jsonreqresp.DESCDirection,
+	}
+
+	page := &jsonreqresp.PageRequest{Limit: 2}
+	var got []*models.Artwork
+	for {
+		arts, info, err := th.arep.GetArtworksPage(th.ctx, filter, sortOps, page)
+		require.NoError(t, err)
+		// общее число считается только для первой страницы
+		assert.Equal(t, page.IsFirst(), info.HasTotal)
+		if info.HasTotal {
+			assert.Equal(t, 5, info.Total)
+		}
+		assert.LessOrEqual(t, len(arts), 2)
+		got = append(got, arts...)
+		if info.NextCursor == "" {
+			break
+		}
+		page = &jsonreqresp.PageRequest{Limit: 2, Cursor: info.NextCursor}
+	}
+
+	require.Len(t, got, 5)
+	for i := range got {
+		assert.Equal(t, artworks[len(artworks)-1-i].GetID(), got[i].GetID())
+	}
+
+	_, _, err := th.arep.GetArtworksPage(th.ctx, filter, sortOps, &jsonreqresp.PageRequest{Cursor: "bad"})
+	assert.ErrorIs(t, err, jsonreqresp.ErrPageCursor)
+}
+
+func TestArtworkRep_ImageOperations(t *testing.T) {
+	th := setupTestHelper(t)
+	artwork, _, _ := th.createAndAddArtwork(t, 1)
+
+	images := make([]*models.ArtworkImage, 3)
+	for i := range images {
+		img, err := models.NewArtworkImage(uuid.New(), artwork.GetID(), i, i == 0,
+			models.ImageFormatJPEG, 800, 600, time.Now().Truncate(time.Microsecond))
+		require.NoError(t, err)
+		require.NoError(t, th.arep.AddImage(th.ctx, &img))
+		images[i] = &img
+	}
+
+	t.Run("images loaded with artwork in order", func(t *testing.T) {
+		got, err := th.arep.GetByID(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		require.Len(t, got.GetImages(), 3)
+		for i, img := range got.GetImages() {
+			assert.Equal(t, images[i].GetID(), img.GetID())
+		}
+		assert.Equal(t, images[0].GetID(), got.GetPrimaryImage().GetID())
+
+		page, _, err := th.arep.GetArtworksPage(th.ctx, &jsonreqresp.ArtworkFilter{}, nil, &jsonreqresp.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Len(t, page[0].GetImages(), 3)
+	})
+
+	t.Run("reorder and change primary", func(t *testing.T) {
+		images[0].SetPosition(2)
+		images[0].SetPrimary(false)
+		images[2].SetPosition(0)
+		images[2].SetPrimary(true)
+		require.NoError(t, th.arep.UpdateImages(th.ctx, artwork.GetID(), images))
+
+		got, err := th.arep.GetImages(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, images[2].GetID(), got[0].GetID())
+		assert.True(t, got[0].IsPrimary())
+		assert.Equal(t, images[0].GetID(), got[2].GetID())
+		assert.False(t, got[2].IsPrimary())
+	})
+
+	t.Run("delete image", func(t *testing.T) {
+		require.NoError(t, th.arep.DeleteImage(th.ctx, artwork.GetID(), images[1].GetID()))
+		err := th.arep.DeleteImage(th.ctx, artwork.GetID(), images[1].GetID())
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkImageNotFound)
+
+		got, err := th.arep.GetImages(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("update unknown image", func(t *testing.T) {
+		other, err := models.NewArtworkImage(uuid.New(), artwork.GetID(), 0, false,
+			models.ImageFormatPNG, 10, 10, time.Now())
+		require.NoError(t, err)
+		err = th.arep.UpdateImages(th.ctx, artwork.GetID(), []*models.ArtworkImage{&other})
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkImageNotFound)
+	})
+}
+
+func TestArtworkRep_FullTextSearch(t *testing.T) {
+	th := setupTestHelper(t)
+
+	gogh, err := models.NewAuthor(uuid.New(), "Винсент Ван Гог", 1853, 1890)
+	require.NoError(t, err)
+	require.NoError(t, th.authorRep.Add(th.ctx, &gogh))
+	collection := th.createTestCollection(1)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, collection))
+
+	newArtwork := func(title, technic string) *models.Artwork {
+		art, err := models.NewArtwork(uuid.New(), title, technic, "Холст", "50x60 cm", 1888, &gogh, collection)
+		require.NoError(t, err)
+		require.NoError(t, th.arep.Add(th.ctx, &art))
+		return &art
+	}
+	portrait := newArtwork("Портрет почтальона Рулена", "Масло")
+	// другое слово с тем же корнем не должно находиться по основе "портрет"
+	newArtwork("Автопортрет", "Масло")
+	sunflowers := newArtwork("Подсолнухи", "Oil painting")
+
+	search := func(q string) []*models.Artwork {
+		arts, _, err := th.arep.GetArtworksPage(th.ctx,
+			&jsonreqresp.ArtworkFilter{Query: q},
+			&jsonreqresp.ArtworkSortOps{Field: jsonreqresp.RelevanceSortFieldArtwork, Direction: jsonreqresp.DESCDirection},
+			&jsonreqresp.PageRequest{})
+		require.NoError(t, err)
+		return arts
+	}
+
+	t.Run("russian stemming", func(t *testing.T) {
+		arts := search("портреты маслом")
+		require.Len(t, arts, 1)
+		assert.Equal(t, portrait.GetID(), arts[0].GetID())
+		require.NotNil(t, arts[0].GetHighlight())
+		assert.Contains(t, arts[0].GetHighlight().Title, "<mark>Портрет</mark>")
+		assert.Contains(t, arts[0].GetHighlight().Technic, "<mark>Масло</mark>")
+	})
+
+	t.Run("english stemming", func(t *testing.T) {
+		arts := search("paintings")
+		require.Len(t, arts, 1)
+		assert.Equal(t, sunflowers.GetID(), arts[0].GetID())
+	})
+
+	t.Run("misspelled author name", func(t *testing.T) {
+		arts := search("Ван Гок")
+		assert.Len(t, arts, 3)
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		assert.Empty(t, search("скульптура"))
+	})
+
+	t.Run("author rename updates search", func(t *testing.T) {
+		renamed, err := models.NewAuthor(gogh.GetID(), "Поль Гоген", 1853, 1890)
+		require.NoError(t, err)
+		require.NoError(t, th.authorRep.Update(th.ctx, gogh.GetID(), func(*models.Author) (*models.Author, error) {
+			return &renamed, nil
+		}, nil))
+		arts := search("Гоген")
+		assert.Len(t, arts, 3)
+	})
+}
+
+func TestArtworkRep_Facets(t *testing.T) {
+	th := setupTestHelper(t)
+
+	author, err := models.NewAuthor(uuid.New(), "Иван Айвазовский", 1750, 1950)
+	require.NoError(t, err)
+	require.NoError(t, th.authorRep.Add(th.ctx, &author))
+	collection := th.createTestCollection(1)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, collection))
+
+	newArtwork := func(title, technic, material string, year int) {
+		art, err := models.NewArtwork(uuid.New(), title, technic, material, "50x60 cm", year, &author, collection)
+		require.NoError(t, err)
+		require.NoError(t, th.arep.Add(th.ctx, &art))
+	}
+	newArtwork("Девятый вал", "Масло", "Холст", 1850)
+	newArtwork("Радуга", "Масло", "Холст", 1873)
+	newArtwork("Буря", "Акварель", "Бумага", 1800)
+	newArtwork("Море", "Масло", "Картон", 1900)
+
+	bucket := func(buckets []models.FacetBucket, value string) int {
+		for _, b := range buckets {
+			if b.Value == value {
+				return b.Count
+			}
+		}
+		return 0
+	}
+
+	t.Run("without filter", func(t *testing.T) {
+		facets, err := th.arep.GetArtworkFacets(th.ctx, &jsonreqresp.ArtworkFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, bucket(facets.Technics, "Масло"))
+		assert.Equal(t, 1, bucket(facets.Technics, "Акварель"))
+		assert.Equal(t, 4, bucket(facets.Centuries, "19"))
+		assert.Equal(t, "XIX век", facets.Centuries[0].Label)
+		assert.Equal(t, 4, bucket(facets.Authors, author.GetID().String()))
+		assert.Equal(t, 1800, facets.YearMin)
+		assert.Equal(t, 1900, facets.YearMax)
+	})
+
+	t.Run("own facet filter is ignored", func(t *testing.T) {
+		filter := &jsonreqresp.ArtworkFilter{Technics: []string{"Масло"}}
+		facets, err := th.arep.GetArtworkFacets(th.ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 1, bucket(facets.Technics, "Акварель"))
+		assert.Equal(t, 2, bucket(facets.Materials, "Холст"))
+		assert.Equal(t, 0, bucket(facets.Materials, "Бумага"))
+	})
+
+	t.Run("year range filters results", func(t *testing.T) {
+		filter := &jsonreqresp.ArtworkFilter{YearFrom: 1850, YearTo: 1899, Materials: []string{"Холст", "Бумага"}}
+		arts, pageInfo, err := th.arep.GetArtworksPage(th.ctx, filter, &jsonreqresp.ArtworkSortOps{}, &jsonreqresp.PageRequest{})
+		require.NoError(t, err)
+		assert.Len(t, arts, 2)
+		assert.Equal(t, 2, pageInfo.Total)
+
+		facets, err := th.arep.GetArtworkFacets(th.ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 1800, facets.YearMin)
+		assert.Equal(t, 1873, facets.YearMax)
+	})
+}
+
+func TestArtworkRep_Dimensions(t *testing.T) {
+	th := setupTestHelper(t)
+
+	author := th.createTestAuthor(1)
+	require.NoError(t, th.authorRep.Add(th.ctx, author))
+	collection := th.createTestCollection(1)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, collection))
+
+	newArtwork := func(title, size string) *models.Artwork {
+		art, err := models.NewArtwork(uuid.New(), title, "Масло", "Холст", size, 1920, author, collection)
+		require.NoError(t, err)
+		if d, err := models.ParseDimensions(size); err == nil {
+			art.SetDimensions(d)
+		}
+		require.NoError(t, th.arep.Add(th.ctx, &art))
+		return &art
+	}
+	small := newArtwork("Этюд", "30 x 40 cm")
+	large := newArtwork("Панорама", "2 x 5 m, 80 kg")
+	newArtwork("Тондо", "диаметр 82 см")
+
+	t.Run("dimensions are stored", func(t *testing.T) {
+		art, err := th.arep.GetByID(th.ctx, large.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "2 × 5 м, 80 кг", art.GetSize())
+		assert.Equal(t, models.LengthMeter, art.GetDimensions().GetLengthUnit())
+		assert.InDelta(t, 500, art.GetDimensions().GetWidthCm(), 1e-9)
+		assert.InDelta(t, 80, art.GetDimensions().GetWeightKg(), 1e-9)
+	})
+
+	t.Run("filter by height", func(t *testing.T) {
+		filter := &jsonreqresp.ArtworkFilter{Height: jsonreqresp.DimensionRange{Max: 100}}
+		arts, pageInfo, err := th.arep.GetArtworksPage(th.ctx, filter, &jsonreqresp.ArtworkSortOps{}, &jsonreqresp.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, small.GetID(), arts[0].GetID())
+		assert.Equal(t, 1, pageInfo.Total)
+	})
+
+	t.Run("sort by width with cursor", func(t *testing.T) {
+		sortOps := &jsonreqresp.ArtworkSortOps{Field: jsonreqresp.WidthSortFieldArtwork, Direction: jsonreqresp.DESCDirection}
+		arts, pageInfo, err := th.arep.GetArtworksPage(th.ctx, &jsonreqresp.ArtworkFilter{}, sortOps, &jsonreqresp.PageRequest{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, large.GetID(), arts[0].GetID())
+
+		arts, _, err = th.arep.GetArtworksPage(th.ctx, &jsonreqresp.ArtworkFilter{}, sortOps,
+			&jsonreqresp.PageRequest{Limit: 1, Cursor: pageInfo.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, small.GetID(), arts[0].GetID())
+	})
+}
+
+func TestArtworkRep_DatingAndCoAuthors(t *testing.T) {
+	th := setupTestHelper(t)
+
+	author := th.createTestAuthor(1)
+	require.NoError(t, th.authorRep.Add(th.ctx, author))
+	workshop := th.createTestAuthor(2)
+	require.NoError(t, th.authorRep.Add(th.ctx, workshop))
+	collection := th.createTestCollection(1)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, collection))
+
+	dating, err := models.NewArtworkDating(1930, 1939, models.DateCirca, models.PrecisionDecade)
+	require.NoError(t, err)
+	primary, err := models.NewAttribution(author, models.RoleAttributed)
+	require.NoError(t, err)
+	coAuthor, err := models.NewAttribution(workshop, models.RoleWorkshop)
+	require.NoError(t, err)
+	art, err := models.NewDatedArtwork(uuid.New(), "Натюрморт", "Масло", "Холст", "50 x 60 cm",
+		dating, primary, []models.Attribution{coAuthor}, collection)
+	require.NoError(t, err)
+	require.NoError(t, th.arep.Add(th.ctx, &art))
+
+	t.Run("dating and co-authors are stored", func(t *testing.T) {
+		stored, err := th.arep.GetByID(th.ctx, art.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, dating, stored.GetDating())
+		assert.Equal(t, 1934, stored.GetCreationYear())
+		assert.Equal(t, models.RoleAttributed, stored.GetAuthorRole())
+		require.Len(t, stored.GetCoAuthors(), 1)
+		assert.Equal(t, workshop.GetID(), stored.GetCoAuthors()[0].GetAuthor().GetID())
+		assert.Equal(t, models.RoleWorkshop, stored.GetCoAuthors()[0].GetRole())
+	})
+
+	t.Run("filter by co-author name", func(t *testing.T) {
+		arts, err := th.arep.GetAllArtworks(th.ctx, &jsonreqresp.ArtworkFilter{AuthorName: workshop.GetName()}, &jsonreqresp.ArtworkSortOps{})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, art.GetID(), arts[0].GetID())
+	})
+
+	t.Run("update replaces co-authors", func(t *testing.T) {
+		err := th.arep.Update(th.ctx, art.GetID(), func(a *models.Artwork) (*models.Artwork, error) {
+			return a, a.SetCoAuthors(nil)
+		}, nil)
+		require.NoError(t, err)
+		stored, err := th.arep.GetByID(th.ctx, art.GetID())
+		require.NoError(t, err)
+		assert.Empty(t, stored.GetCoAuthors())
+		assert.Equal(t, dating, stored.GetDating())
+	})
+}
+
+func TestArtworkRep_UpdatedAt(t *testing.T) {
+	th := setupTestHelper(t)
+
+	art, author, collection := th.createAndAddArtwork(t, 1)
+	other, _, _ := th.createAndAddArtwork(t, 2)
+	stored, err := th.arep.GetByID(th.ctx, art.GetID())
+	require.NoError(t, err)
+	created := stored.GetUpdatedAt()
+	require.False(t, created.IsZero())
+
+	t.Run("earliest datestamp", func(t *testing.T) {
+		earliest, err := th.arep.GetEarliestUpdatedAt(th.ctx)
+		require.NoError(t, err)
+		assert.False(t, earliest.After(created))
+	})
+
+	t.Run("update moves datestamp", func(t *testing.T) {
+		err := th.arep.Update(th.ctx, other.GetID(), func(a *models.Artwork) (*models.Artwork, error) {
+			return a, nil
+		}, nil)
+		require.NoError(t, err)
+		updated, err := th.arep.GetByID(th.ctx, other.GetID())
+		require.NoError(t, err)
+		assert.True(t, updated.GetUpdatedAt().After(created))
+
+		filter := &jsonreqresp.ArtworkFilter{UpdatedFrom: updated.GetUpdatedAt()}
+		arts, pageInfo, err := th.arep.GetArtworksPage(th.ctx, filter, &jsonreqresp.ArtworkSortOps{}, &jsonreqresp.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, other.GetID(), arts[0].GetID())
+		assert.Equal(t, 1, pageInfo.Total)
+
+		filter = &jsonreqresp.ArtworkFilter{UpdatedBefore: updated.GetUpdatedAt()}
+		arts, _, err = th.arep.GetArtworksPage(th.ctx, filter, &jsonreqresp.ArtworkSortOps{}, &jsonreqresp.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, art.GetID(), arts[0].GetID())
+	})
+
+	t.Run("author and collection renames move datestamp", func(t *testing.T) {
+		coAuthor, err := models.NewAttribution(author, models.RoleWorkshop)
+		require.NoError(t, err)
+		err = th.arep.Update(th.ctx, other.GetID(), func(a *models.Artwork) (*models.Artwork, error) {
+			return a, a.SetCoAuthors([]models.Attribution{coAuthor})
+		}, nil)
+		require.NoError(t, err)
+		datestamp := func(id uuid.UUID) time.Time {
+			stored, err := th.arep.GetByID(th.ctx, id)
+			require.NoError(t, err)
+			return stored.GetUpdatedAt()
+		}
+
+		artBefore, otherBefore := datestamp(art.GetID()), datestamp(other.GetID())
+		err = th.authorRep.Update(th.ctx, author.GetID(), func(a *models.Author) (*models.Author, error) {
+			return a, a.Update(models.AuthorUpdateReq{Name: "Renamed author", BirthYear: a.GetBirthYear(), DeathYear: a.GetDeathYear()})
+		}, nil)
+		require.NoError(t, err)
+		assert.True(t, datestamp(art.GetID()).After(artBefore))
+		assert.True(t, datestamp(other.GetID()).After(otherBefore))
+
+		artBefore, otherBefore = datestamp(art.GetID()), datestamp(other.GetID())
+		err = th.colRep.UpdateCollection(th.ctx, collection.GetID(), func(c *models.Collection) (*models.Collection, error) {
+			return c, c.Update(models.CollectionUpdateReq{Title: "Renamed collection", ParentID: c.GetParentID()})
+		}, nil)
+		require.NoError(t, err)
+		assert.True(t, datestamp(art.GetID()).After(artBefore))
+		assert.Equal(t, otherBefore, datestamp(other.GetID()))
+	})
+}
+
+func TestArtworkRep_SoftDelete(t *testing.T) {
+	th := setupTestHelper(t)
+
+	art, author, collection := th.createAndAddArtwork(t, 1)
+
+	t.Run("author and collection are blocked by artwork", func(t *testing.T) {
+		var depErr *models.DependencyError
+		err := th.authorRep.Delete(th.ctx, author.GetID())
+		require.ErrorAs(t, err, &depErr)
+		require.Len(t, depErr.Records, 1)
+		assert.Equal(t, art.GetID(), depErr.Records[0].ID)
+
+		err = th.colRep.DeleteCollection(th.ctx, collection.GetID())
+		assert.ErrorIs(t, err, models.ErrHasDependents)
+	})
+
+	t.Run("trashed artwork is hidden", func(t *testing.T) {
+		require.NoError(t, th.arep.Delete(th.ctx, art.GetID()))
+		_, err := th.arep.GetByID(th.ctx, art.GetID())
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+		arts, err := th.arep.GetAllArtworks(th.ctx, &jsonreqresp.ArtworkFilter{}, &jsonreqresp.ArtworkSortOps{})
+		require.NoError(t, err)
+		assert.Empty(t, arts)
+
+		err = th.arep.Delete(th.ctx, art.GetID())
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+	})
+
+	t.Run("trashed artwork id is still taken", func(t *testing.T) {
+		ids, err := th.arep.GetExistingIDs(th.ctx, uuid.UUIDs{art.GetID(), uuid.New()})
+		require.NoError(t, err)
+		assert.Equal(t, uuid.UUIDs{art.GetID()}, ids)
+	})
+
+	t.Run("author can be trashed after artwork", func(t *testing.T) {
+		require.NoError(t, th.authorRep.Delete(th.ctx, author.GetID()))
+		require.NoError(t, th.colRep.DeleteCollection(th.ctx, collection.GetID()))
+	})
+}
+
+func TestArtworkRep_ImportCatalog(t *testing.T) {
+	th := setupTestHelper(t)
+
+	existing := th.createTestAuthor(0)
+	require.NoError(t, th.authorRep.Add(th.ctx, existing))
+	batch := &models.CatalogImport{}
+	for i := 1; i <= 3; i++ {
+		batch.Authors = append(batch.Authors, th.createTestAuthor(i))
+	}
+	collection := th.createTestCollection(1)
+	batch.Collections = []*models.Collection{collection}
+	// больше одной пачки вставки
+	for i := 0; i < 600; i++ {
+		author := existing
+		if i%2 == 0 {
+			author = batch.Authors[i%3]
+		}
+		batch.Artworks = append(batch.Artworks, th.createTestArtwork(i%30, author, collection))
+	}
+
+	t.Run("all records are stored", func(t *testing.T) {
+		require.NoError(t, th.arep.ImportCatalog(th.ctx, batch))
+
+		authors, err := th.authorRep.GetAll(th.ctx)
+		require.NoError(t, err)
+		assert.Len(t, authors, 4)
+		arts, err := th.arep.GetAllArtworks(th.ctx, &jsonreqresp.ArtworkFilter{}, &jsonreqresp.ArtworkSortOps{})
+		require.NoError(t, err)
+		assert.Len(t, arts, 600)
+		stored, err := th.arep.GetByID(th.ctx, batch.Artworks[2].GetID())
+		require.NoError(t, err)
+		assert.Equal(t, batch.Authors[2].GetID(), stored.GetAuthor().GetID())
+		assert.Equal(t, collection.GetID(), stored.GetCollection().GetID())
+	})
+
+	t.Run("failed import is rolled back", func(t *testing.T) {
+		author := th.createTestAuthor(10)
+		failed := &models.CatalogImport{
+			Authors: []*models.Author{author},
+			// произведение с уже существующим id
+			Artworks: []*models.Artwork{batch.Artworks[0]},
+		}
+		assert.ErrorIs(t, th.arep.ImportCatalog(th.ctx, failed), artworkrep.ErrQueryExec)
+
+		_, err := th.authorRep.GetByID(th.ctx, author.GetID())
+		assert.Error(t, err)
+	})
+}
+
+func TestArtworkRep_Provenance(t *testing.T) {
+	th := setupTestHelper(t)
+	artwork, _, _ := th.createAndAddArtwork(t, 1)
+
+	entries := make([]*models.ProvenanceEntry, 3)
+	for i := range entries {
+		entry, err := models.NewProvenanceEntry(uuid.New(), artwork.GetID(), i, "Owner", false, "Москва",
+			1900+i, 1901+i, models.DateCirca, models.TransferPurchase, "", "", i != 1)
+		require.NoError(t, err)
+		require.NoError(t, th.arep.AddProvenanceEntry(th.ctx, &entry))
+		entries[i] = &entry
+	}
+
+	t.Run("entries ordered by position", func(t *testing.T) {
+		got, err := th.arep.GetProvenance(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		for i, e := range got {
+			assert.Equal(t, entries[i].GetID(), e.GetID())
+			assert.Equal(t, entries[i].IsPublic(), e.IsPublic())
+		}
+		assert.Equal(t, models.DateCirca, got[0].GetDateQualifier())
+	})
+
+	t.Run("update and reorder", func(t *testing.T) {
+		entries[0].SetPosition(2)
+		entries[2].SetPosition(0)
+		require.NoError(t, th.arep.UpdateProvenance(th.ctx, artwork.GetID(), []*models.ProvenanceEntry{entries[0], entries[2]}))
+
+		got, err := th.arep.GetProvenance(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, entries[2].GetID(), got[0].GetID())
+		assert.Equal(t, entries[0].GetID(), got[2].GetID())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, th.arep.DeleteProvenanceEntry(th.ctx, artwork.GetID(), entries[1].GetID()))
+		got, err := th.arep.GetProvenance(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+
+		err = th.arep.DeleteProvenanceEntry(th.ctx, artwork.GetID(), entries[1].GetID())
+		assert.ErrorIs(t, err, artworkrep.ErrProvenanceNotFound)
+	})
+}
+
+func TestArtworkRep_ConditionReports(t *testing.T) {
+	th := setupTestHelper(t)
+	artwork, _, _ := th.createAndAddArtwork(t, 1)
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	check, err := models.NewConditionReport(uuid.New(), artwork.GetID(), start.AddDate(0, -1, 0), "Анна Смирнова",
+		models.ConditionPoor, "Кракелюр", "", time.Time{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, th.arep.AddConditionReport(th.ctx, &check))
+	treatment, err := models.NewConditionReport(uuid.New(), artwork.GetID(), start, "Анна Смирнова",
+		models.ConditionFair, "", "Укрепление красочного слоя", time.Time{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, th.arep.AddConditionReport(th.ctx, &treatment))
+
+	photo, err := models.NewConditionPhoto(uuid.New(), treatment.GetID(), artwork.GetID(), models.ImageFormatJPEG,
+		time.Now().Truncate(time.Microsecond))
+	require.NoError(t, err)
+	require.NoError(t, th.arep.AddConditionPhoto(th.ctx, &photo))
+
+	t.Run("history newest first with photos", func(t *testing.T) {
+		reports, err := th.arep.GetConditionReports(th.ctx, artwork.GetID())
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, treatment.GetID(), reports[0].GetID())
+		require.Len(t, reports[0].GetPhotos(), 1)
+		assert.Equal(t, photo.GetID(), reports[0].GetPhotos()[0].GetID())
+		assert.True(t, reports[0].IsTreatmentOpen())
+	})
+
+	t.Run("open treatment blocks any later period", func(t *testing.T) {
+		reports, err := th.arep.GetTreatmentsOnDate(th.ctx, artwork.GetID(), start.AddDate(1, 0, 0), start.AddDate(1, 0, 1))
+		require.NoError(t, err)
+		assert.Len(t, reports, 1)
+
+		reports, err = th.arep.GetTreatmentsOnDate(th.ctx, artwork.GetID(), start.AddDate(0, -2, 0), start.AddDate(0, 0, -1))
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+	})
+
+	t.Run("needs attention: open treatment only", func(t *testing.T) {
+		reports, err := th.arep.GetConditionReportsNeedingAttention(th.ctx)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, treatment.GetID(), reports[0].GetID())
+	})
+
+	t.Run("closed treatment", func(t *testing.T) {
+		require.NoError(t, treatment.CloseTreatment(start.AddDate(0, 1, 0)))
+		require.NoError(t, th.arep.UpdateConditionReport(th.ctx, &treatment))
+
+		reports, err := th.arep.GetTreatmentsOnDate(th.ctx, artwork.GetID(), start.AddDate(0, 2, 0), start.AddDate(0, 2, 1))
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+		reports, err = th.arep.GetTreatmentsOnDate(th.ctx, artwork.GetID(), start.AddDate(0, 0, 10), start.AddDate(0, 0, 11))
+		require.NoError(t, err)
+		assert.Len(t, reports, 1)
+
+		reports, err = th.arep.GetConditionReportsNeedingAttention(th.ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+	})
+
+	t.Run("report not found", func(t *testing.T) {
+		_, err := th.arep.GetConditionReport(th.ctx, artwork.GetID(), uuid.New())
+		assert.ErrorIs(t, err, artworkrep.ErrConditionReportNotFound)
+	})
+}
+
+func TestArtworkRep_GetSimilarCandidates(t *testing.T) {
+	th := setupTestHelper(t)
+
+	aivazovsky, err := models.NewAuthor(uuid.New(), "Иван Айвазовский", 1817, 1900)
+	require.NoError(t, err)
+	require.NoError(t, th.authorRep.Add(th.ctx, &aivazovsky))
+	rublev, err := models.NewAuthor(uuid.New(), "Андрей Рублев", 1360, 1430)
+	require.NoError(t, err)
+	require.NoError(t, th.authorRep.Add(th.ctx, &rublev))
+	marine := th.createTestCollection(1)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, marine))
+	icons := th.createTestCollection(2)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, icons))
+
+	newArtwork := func(title, technic, material string, year int, author *models.Author, col *models.Collection) *models.Artwork {
+		art, err := models.NewArtwork(uuid.New(), title, technic, material, "50x60 cm", year, author, col)
+		require.NoError(t, err)
+		require.NoError(t, th.arep.Add(th.ctx, &art))
+		return &art
+	}
+	ninthWave := newArtwork("Девятый вал", "Масло", "Холст", 1850, &aivazovsky, marine)
+	rainbow := newArtwork("Радуга", "Масло", "Холст", 1873, &aivazovsky, marine)
+	trinity := newArtwork("Троица", "Темпера", "Доска", 1411, &rublev, icons)
+	savior := newArtwork("Спас", "Масло", "Доска", 1420, &rublev, icons)
+
+	candidates, err := th.arep.GetSimilarCandidates(th.ctx, ninthWave.GetID(), 10)
+	require.NoError(t, err)
+
+	ids := make(uuid.UUIDs, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ArtworkID
+	}
+	assert.Equal(t, uuid.UUIDs{rainbow.GetID(), savior.GetID()}, ids)
+	assert.NotContains(t, ids, trinity.GetID())
+
+	top := candidates[0]
+	assert.True(t, top.SameAuthor)
+	assert.True(t, top.SameCollection)
+	assert.True(t, top.SameTechnic)
+	assert.True(t, top.SameMaterial)
+	assert.Equal(t, 23, top.YearDiff)
+	assert.Equal(t, aivazovsky.GetID(), top.AuthorID)
+
+	assert.True(t, candidates[1].SameTechnic)
+	assert.False(t, candidates[1].SameAuthor)
+}
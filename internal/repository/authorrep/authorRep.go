@@ -2,8 +2,10 @@ package authorrep
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
@@ -19,9 +21,16 @@ type AuthorRep interface {
 	GetAll(ctx context.Context) ([]*models.Author, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Author, error)
 	Add(ctx context.Context, a *models.Author) error
+	// Delete переносит автора в корзину. Если автор указан у произведений, которые не в корзине,
+	// автор не удаляется, произведения возвращаются в models.DependencyError
 	Delete(ctx context.Context, idAuthor uuid.UUID) error
-	Update(ctx context.Context, idAuthor uuid.UUID, funcUpdate func(*models.Author) (*models.Author, error)) error
+	// Update сохраняет изменение вместе с версией change в истории изменений; nil - изменение без истории
+	Update(ctx context.Context, idAuthor uuid.UUID, funcUpdate func(*models.Author) (*models.Author, error), change *models.Change) error
 	HasArtworks(ctx context.Context, authorID uuid.UUID) (bool, error)
+	// Merge передает произведения дубля duplicateID, в том числе те, где он дополнительный автор,
+	// автору survivorID и удаляет дубль. Объединение сохраняется с версией change в истории обоих авторов.
+	// Возвращает число произведений, у которых сменился автор
+	Merge(ctx context.Context, survivorID uuid.UUID, duplicateID uuid.UUID, change *models.Change) (int, error)
 }
 
 func NewAuthorRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (AuthorRep, error) {
@@ -34,3 +43,65 @@ func NewAuthorRep(ctx context.Context, datebaseType string, pgCreds *cnfg.Dateba
 	}
 	// return &MockAdminRep{}, nil
 }
+
+// parseDependentRows читает пары (id, название) произведений, которые мешают удалить запись
+func parseDependentRows(rows *sql.Rows) ([]models.DependentRecord, error) {
+	var res []models.DependentRecord
+	for rows.Next() {
+		r := models.DependentRecord{EntityType: models.EntityArtwork}
+		if err := rows.Scan(&r.ID, &r.Title); err != nil {
+			return nil, fmt.Errorf("parseDependentRows: scan error: %v", err)
+		}
+		res = append(res, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return res, nil
+}
+
+// authorProfileColumns колонки профиля автора, читаются после id, name, birthYear, deathYear
+var authorProfileColumns = []string{
+	"biography", "nationality", "movements", "birthPlace", "deathPlace", "portrait", "ulanID", "wikidataID",
+}
+
+// authorProfileRow значения колонок authorProfileColumns; направления хранятся через перевод строки
+type authorProfileRow struct {
+	biography, nationality, movements, birthPlace, deathPlace, portrait, ulanID, wikidataID string
+}
+
+func (r *authorProfileRow) dest() []any {
+	return []any{
+		&r.biography, &r.nationality, &r.movements, &r.birthPlace, &r.deathPlace, &r.portrait, &r.ulanID, &r.wikidataID,
+	}
+}
+
+func (r *authorProfileRow) apply(a *models.Author) error {
+	var movements []string
+	if r.movements != "" {
+		movements = strings.Split(r.movements, "\n")
+	}
+	err := a.SetProfile(models.AuthorProfile{
+		Biography:   r.biography,
+		Nationality: r.nationality,
+		Movements:   movements,
+		BirthPlace:  r.birthPlace,
+		DeathPlace:  r.deathPlace,
+		ULANID:      r.ulanID,
+		WikidataID:  r.wikidataID,
+	})
+	if err != nil {
+		return err
+	}
+	a.SetPortrait(r.portrait)
+	return nil
+}
+
+// authorProfileValues возвращает значения колонок authorProfileColumns
+func authorProfileValues(a *models.Author) []any {
+	p := a.GetProfile()
+	return []any{
+		p.Biography, p.Nationality, strings.Join(p.Movements, "\n"), p.BirthPlace, p.DeathPlace,
+		a.GetPortrait(), p.ULANID, p.WikidataID,
+	}
+}
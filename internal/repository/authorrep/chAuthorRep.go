@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/google/uuid"
 )
@@ -59,6 +61,9 @@ func NewCHAuthorRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, db
 	return chInstance, nil
 }
 
+// chAuthorColumns колонки автора вместе с профилем
+var chAuthorColumns = "id, name, birthYear, deathYear, " + strings.Join(authorProfileColumns, ", ")
+
 func (ch *CHAuthorRep) parseAuthorsRows(rows *sql.Rows) ([]*models.Author, error) {
 	var resAuthors []*models.Author
 	for rows.Next() {
@@ -66,7 +71,8 @@ func (ch *CHAuthorRep) parseAuthorsRows(rows *sql.Rows) ([]*models.Author, error
 		var name string
 		var birthYear int32
 		var deathYear sql.NullInt32
-		if err := rows.Scan(&id, &name, &birthYear, &deathYear); err != nil {
+		var profile authorProfileRow
+		if err := rows.Scan(append([]any{&id, &name, &birthYear, &deathYear}, profile.dest()...)...); err != nil {
 			return nil, fmt.Errorf("parseAuthorsRows: scan error: %v", err)
 		}
 
@@ -79,6 +85,9 @@ func (ch *CHAuthorRep) parseAuthorsRows(rows *sql.Rows) ([]*models.Author, error
 		if err != nil {
 			return nil, fmt.Errorf("parseAuthorsRows: %v", err)
 		}
+		if err := profile.apply(&author); err != nil {
+			return nil, fmt.Errorf("parseAuthorsRows: %v", err)
+		}
 		resAuthors = append(resAuthors, &author)
 	}
 	if err := rows.Err(); err != nil {
@@ -102,7 +111,7 @@ func (ch *CHAuthorRep) execSelectQuery(ctx context.Context, query string, args .
 }
 
 func (ch *CHAuthorRep) GetAll(ctx context.Context) ([]*models.Author, error) {
-	query := "SELECT id, name, birthYear, deathYear FROM Author"
+	query := "SELECT " + chAuthorColumns + " FROM Author WHERE deletedAt IS NULL"
 	res, err := ch.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("CHAuthorRep.GetAll: %v", err)
@@ -111,7 +120,7 @@ func (ch *CHAuthorRep) GetAll(ctx context.Context) ([]*models.Author, error) {
 }
 
 func (ch *CHAuthorRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Author, error) {
-	query := "SELECT id, name, birthYear, deathYear FROM Author WHERE id = ?"
+	query := "SELECT " + chAuthorColumns + " FROM Author WHERE id = ? AND deletedAt IS NULL"
 	res, err := ch.execSelectQuery(ctx, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("CHAuthorRep.GetByID: %v", err)
@@ -140,18 +149,19 @@ func (ch *CHAuthorRep) execChangeQuery(ctx context.Context, query string, args .
 }
 
 func (ch *CHAuthorRep) Add(ctx context.Context, a *models.Author) error {
-	query := "INSERT INTO Author (id, name, birthYear, deathYear) VALUES (?, ?, ?, ?)"
+	query := "INSERT INTO Author (" + chAuthorColumns + ") VALUES (?, ?, ?, ?" +
+		strings.Repeat(", ?", len(authorProfileColumns)) + ")"
 
 	var deathYear interface{} = nil
 	if a.GetDeathYear() != 0 {
 		deathYear = a.GetDeathYear()
 	}
 
-	err := ch.execChangeQuery(ctx, query,
+	err := ch.execChangeQuery(ctx, query, append([]any{
 		a.GetID(),
 		a.GetName(),
 		a.GetBirthYear(),
-		deathYear)
+		deathYear}, authorProfileValues(a)...)...)
 
 	if err != nil {
 		return fmt.Errorf("CHAuthorRep.Add: %w", err)
@@ -160,8 +170,27 @@ func (ch *CHAuthorRep) Add(ctx context.Context, a *models.Author) error {
 }
 
 func (ch *CHAuthorRep) Delete(ctx context.Context, idAuthor uuid.UUID) error {
-	query := "ALTER TABLE Author DELETE WHERE id = ?"
-	err := ch.execChangeQuery(ctx, query, idAuthor)
+	if _, err := ch.GetByID(ctx, idAuthor); err != nil {
+		return fmt.Errorf("CHAuthorRep.Delete: %w", err)
+	}
+	rows, err := ch.db.QueryContext(ctx, `
+		SELECT id, title
+		FROM Artworks
+		WHERE deletedAt IS NULL
+			AND (authorID = ? OR id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?))
+		ORDER BY title`, idAuthor, idAuthor)
+	if err != nil {
+		return fmt.Errorf("CHAuthorRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	dependents, err := parseDependentRows(rows)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("CHAuthorRep.Delete: %w", err)
+	}
+	if err := models.CheckDependents(dependents); err != nil {
+		return fmt.Errorf("CHAuthorRep.Delete: %w", err)
+	}
+	err = ch.execChangeQuery(ctx, "ALTER TABLE Author UPDATE deletedAt = now() WHERE id = ?", idAuthor)
 	if err != nil {
 		return fmt.Errorf("CHAuthorRep.Delete: %w", err)
 	}
@@ -172,33 +201,49 @@ func (ch *CHAuthorRep) Update(
 	ctx context.Context,
 	idAuthor uuid.UUID,
 	funcUpdate func(*models.Author) (*models.Author, error),
+	change *models.Change,
 ) error {
 	author, err := ch.GetByID(ctx, idAuthor)
 	if err != nil {
 		return fmt.Errorf("CHAuthorRep.Update %w", err)
 	}
 
+	before := author.Snapshot()
+	oldName := author.GetName()
 	updatedAuthor, err := funcUpdate(author)
 	if err != nil {
 		return fmt.Errorf("CHAuthorRep.Update: %w", ErrUpdateAuthor)
 	}
+	err = historyrep.CHAddChange(ctx, ch.db, models.EntityAuthor, idAuthor, change, before, updatedAuthor.Snapshot())
+	if err != nil {
+		return fmt.Errorf("CHAuthorRep.Update: %w", err)
+	}
 
-	query := "ALTER TABLE Author UPDATE name = ?, birthYear = ?, deathYear = ? WHERE id = ?"
+	query := "ALTER TABLE Author UPDATE name = ?, birthYear = ?, deathYear = ?, " +
+		strings.Join(authorProfileColumns, " = ?, ") + " = ? WHERE id = ?"
 
 	var deathYear interface{} = nil
 	if updatedAuthor.GetDeathYear() != 0 {
 		deathYear = updatedAuthor.GetDeathYear()
 	}
 
-	err = ch.execChangeQuery(ctx, query,
+	args := append([]any{
 		updatedAuthor.GetName(),
 		updatedAuthor.GetBirthYear(),
-		deathYear,
-		idAuthor)
+		deathYear}, authorProfileValues(updatedAuthor)...)
+	err = ch.execChangeQuery(ctx, query, append(args, idAuthor)...)
 
 	if err != nil {
 		return fmt.Errorf("CHAuthorRep.Update: %w", err)
 	}
+	// имя автора входит в запись произведения, переименование - изменение его произведений
+	if updatedAuthor.GetName() != oldName {
+		err = ch.execChangeQuery(ctx, "ALTER TABLE Artworks UPDATE updatedAt = now() "+
+			"WHERE authorID = ? OR id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)", idAuthor, idAuthor)
+		if err != nil {
+			return fmt.Errorf("CHAuthorRep.Update: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -219,3 +264,72 @@ func (ch *CHAuthorRep) Ping(ctx context.Context) error {
 func (ch *CHAuthorRep) Close() {
 	ch.db.Close()
 }
+
+// Merge в ClickHouse выполняется без транзакции последовательными мутациями. Мутации синхронные,
+// чтобы подзапросы следующих шагов видели результат предыдущих. authorID входит в ключ Artwork_authors
+// и не изменяется мутацией, поэтому связи дубля копируются основному автору и затем удаляются.
+// История обоих авторов записывается до мутаций, как и при Update
+func (ch *CHAuthorRep) Merge(
+	ctx context.Context, survivorID uuid.UUID, duplicateID uuid.UUID, change *models.Change,
+) (int, error) {
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 2}))
+
+	survivor, err := ch.GetByID(ctx, survivorID)
+	if err != nil {
+		return 0, fmt.Errorf("CHAuthorRep.Merge: %w", err)
+	}
+	duplicate, err := ch.GetByID(ctx, duplicateID)
+	if err != nil {
+		return 0, fmt.Errorf("CHAuthorRep.Merge: %w", err)
+	}
+	var moved uint64
+	err = ch.db.QueryRowContext(ctx,
+		"SELECT count() FROM Artworks WHERE authorID = ? OR id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)",
+		duplicateID, duplicateID).Scan(&moved)
+	if err != nil {
+		return 0, fmt.Errorf("CHAuthorRep.Merge: %w: %v", ErrQueryExec, err)
+	}
+	err = historyrep.CHAddChange(ctx, ch.db, models.EntityAuthor, duplicateID, change,
+		duplicate.Snapshot(), duplicate.MergedIntoSnapshot(survivorID, int(moved)))
+	if err != nil {
+		return 0, fmt.Errorf("CHAuthorRep.Merge: %w", err)
+	}
+	err = historyrep.CHAddChange(ctx, ch.db, models.EntityAuthor, survivorID, change,
+		survivor.Snapshot(), survivor.MergedFromSnapshot(duplicateID, int(moved)))
+	if err != nil {
+		return 0, fmt.Errorf("CHAuthorRep.Merge: %w", err)
+	}
+
+	steps := []struct {
+		query string
+		args  []any
+	}{
+		// смена автора - изменение произведения
+		{
+			"ALTER TABLE Artworks UPDATE updatedAt = now() " +
+				"WHERE authorID = ? OR id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)",
+			[]any{duplicateID, duplicateID},
+		},
+		// связи дубля, кроме произведений, где основной автор уже указан или дубль основной
+		{
+			"INSERT INTO Artwork_authors (artworkID, authorID, role, position) " +
+				"SELECT artworkID, ?, role, position FROM Artwork_authors WHERE authorID = ? " +
+				"AND artworkID NOT IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?) " +
+				"AND artworkID NOT IN (SELECT id FROM Artworks WHERE authorID IN (?, ?))",
+			[]any{survivorID, duplicateID, survivorID, survivorID, duplicateID},
+		},
+		{
+			"ALTER TABLE Artwork_authors DELETE WHERE authorID = ? " +
+				"OR (authorID = ? AND artworkID IN (SELECT id FROM Artworks WHERE authorID = ?))",
+			[]any{duplicateID, survivorID, duplicateID},
+		},
+		{"ALTER TABLE Artworks UPDATE authorID = ? WHERE authorID = ?", []any{survivorID, duplicateID}},
+		{"ALTER TABLE Author DELETE WHERE id = ?", []any{duplicateID}},
+	}
+	for _, step := range steps {
+		if err := ch.execChangeQuery(syncCtx, step.query, step.args...); err != nil {
+			return 0, fmt.Errorf("CHAuthorRep.Merge: %w", err)
+		}
+	}
+	return int(moved), nil
+}
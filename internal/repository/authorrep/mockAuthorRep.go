@@ -39,8 +39,10 @@ func (m *MockAuthorRep) Delete(ctx context.Context, idAuthor uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockAuthorRep) Update(ctx context.Context, idAuthor uuid.UUID, funcUpdate func(*models.Author) (*models.Author, error)) error {
-	args := m.Called(ctx, idAuthor, funcUpdate)
+func (m *MockAuthorRep) Update(
+	ctx context.Context, idAuthor uuid.UUID, funcUpdate func(*models.Author) (*models.Author, error), change *models.Change,
+) error {
+	args := m.Called(ctx, idAuthor, funcUpdate, change)
 	return args.Error(0)
 }
 
@@ -48,3 +50,10 @@ func (m *MockAuthorRep) HasArtworks(ctx context.Context, authorID uuid.UUID) (bo
 	args := m.Called(ctx, authorID)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockAuthorRep) Merge(
+	ctx context.Context, survivorID uuid.UUID, duplicateID uuid.UUID, change *models.Change,
+) (int, error) {
+	args := m.Called(ctx, survivorID, duplicateID, change)
+	return args.Int(0), args.Error(1)
+}
@@ -10,6 +10,7 @@ import (
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -69,7 +70,8 @@ func (pg *PgAuthorRep) parseAuthorsRows(rows *sql.Rows) ([]*models.Author, error
 		var name string
 		var birthYear int
 		var authorDeathYear sql.NullInt64
-		if err := rows.Scan(&id, &name, &birthYear, &authorDeathYear); err != nil {
+		var profile authorProfileRow
+		if err := rows.Scan(append([]any{&id, &name, &birthYear, &authorDeathYear}, profile.dest()...)...); err != nil {
 			return nil, fmt.Errorf("parseAuthorsRows: scan error: %v", err)
 		}
 		deathYear := 0
@@ -80,6 +82,9 @@ func (pg *PgAuthorRep) parseAuthorsRows(rows *sql.Rows) ([]*models.Author, error
 		if err != nil {
 			return nil, fmt.Errorf("parseAuthorsRows: %v", err)
 		}
+		if err := profile.apply(&author); err != nil {
+			return nil, fmt.Errorf("parseAuthorsRows: %v", err)
+		}
 		resAuthors = append(resAuthors, &author)
 	}
 	if err := rows.Err(); err != nil {
@@ -109,8 +114,9 @@ func (pg *PgAuthorRep) execSelectQuery(ctx context.Context, query sq.SelectBuild
 
 func (pg *PgAuthorRep) GetAll(ctx context.Context) ([]*models.Author, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Select("id", "name", "birthyear", "deathyear").
-		From("author")
+	query := psql.Select(append([]string{"id", "name", "birthyear", "deathyear"}, authorProfileColumns...)...).
+		From("author").
+		Where(sq.Eq{"deletedAt": nil})
 	res, err := pg.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("PgAuthorRep.GetAll: %v", err)
@@ -120,9 +126,9 @@ func (pg *PgAuthorRep) GetAll(ctx context.Context) ([]*models.Author, error) {
 
 func (pg *PgAuthorRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Author, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Select("id", "name", "birthyear", "deathyear").
+	query := psql.Select(append([]string{"id", "name", "birthyear", "deathyear"}, authorProfileColumns...)...).
 		From("Author").
-		Where(sq.Eq{"id": id})
+		Where(sq.Eq{"id": id, "deletedAt": nil})
 	res, err := pg.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("PgAuthorRep.GetByID: %v", err)
@@ -136,6 +142,33 @@ func (pg *PgAuthorRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Autho
 	return res[0], nil
 }
 
+// getForUpdate читает автора в транзакции tx и блокирует его строку до конца транзакции
+func (pg *PgAuthorRep) getForUpdate(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.Author, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select(append([]string{"id", "name", "birthyear", "deathyear"}, authorProfileColumns...)...).
+		From("Author").
+		Where(sq.Eq{"id": id, "deletedAt": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := tx.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := pg.parseAuthorsRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrAuthorNotFound
+	}
+	return res[0], nil
+}
+
 func (pg *PgAuthorRep) execChangeQuery(ctx context.Context, query sq.Sqlizer) error {
 	querySQL, args, err := query.ToSql()
 	if err != nil {
@@ -164,8 +197,8 @@ func (pg *PgAuthorRep) Add(ctx context.Context, a *models.Author) error {
 		deathYear = nil
 	}
 	query := psql.Insert("Author").
-		Columns("id", "name", "birthYear", "deathYear").
-		Values(a.GetID(), a.GetName(), a.GetBirthYear(), deathYear)
+		Columns(append([]string{"id", "name", "birthYear", "deathYear"}, authorProfileColumns...)...).
+		Values(append([]any{a.GetID(), a.GetName(), a.GetBirthYear(), deathYear}, authorProfileValues(a)...)...)
 	err := pg.execChangeQuery(ctx, query)
 	if err != nil {
 		return fmt.Errorf("PgAuthorRep.Add: %w", err)
@@ -175,12 +208,57 @@ func (pg *PgAuthorRep) Add(ctx context.Context, a *models.Author) error {
 
 func (pg *PgAuthorRep) Delete(ctx context.Context, idAuthor uuid.UUID) error {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Delete("Author").
-		Where(sq.Eq{"id": idAuthor})
-	err := pg.execChangeQuery(ctx, query)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	artworksSQL, artworksArgs, err := psql.Select("id", "title").
+		From("Artworks").
+		Where(sq.Eq{"deletedAt": nil}).
+		Where(sq.Or{
+			sq.Eq{"authorID": idAuthor},
+			sq.Expr("id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)", idAuthor),
+		}).
+		OrderBy("title").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := tx.QueryContext(ctx, artworksSQL, artworksArgs...)
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	dependents, err := parseDependentRows(rows)
+	rows.Close()
 	if err != nil {
 		return fmt.Errorf("PgAuthorRep.Delete: %w", err)
 	}
+	if err := models.CheckDependents(dependents); err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w", err)
+	}
+
+	deleteSQL, deleteArgs, err := psql.Update("Author").
+		Set("deletedAt", sq.Expr("now()")).
+		Where(sq.Eq{"id": idAuthor, "deletedAt": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	result, err := tx.ExecContext(ctx, deleteSQL, deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrRowsAffected, err)
+	} else if n == 0 {
+		return fmt.Errorf("PgAuthorRep.Delete: %w", ErrAuthorNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgAuthorRep.Delete: %w: %v", ErrQueryExec, err)
+	}
 	return nil
 }
 
@@ -188,13 +266,23 @@ func (pg *PgAuthorRep) Update(
 	ctx context.Context,
 	idAuthor uuid.UUID,
 	funcUpdate func(*models.Author) (*models.Author, error),
+	change *models.Change,
 ) error {
-	author, err := pg.GetByID(ctx, idAuthor)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	// состояние до изменения читается под блокировкой строки, чтобы параллельные изменения не искажали историю
+	author, err := pg.getForUpdate(ctx, tx, idAuthor)
 	if err != nil {
 		return fmt.Errorf("PgAuthorRep.Update %w", err)
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	before := author.Snapshot()
+	oldName := author.GetName()
 	updatedAuthor, err := funcUpdate(author)
 	if err != nil {
 		return fmt.Errorf("PgAuthorRep.Update: %w", ErrUpdateAuthor)
@@ -209,11 +297,47 @@ func (pg *PgAuthorRep) Update(
 	} else {
 		query = query.Set("deathYear", updatedAuthor.GetDeathYear())
 	}
-	query = query.Where(sq.Eq{"id": idAuthor})
-	err = pg.execChangeQuery(ctx, query)
+	for i, value := range authorProfileValues(updatedAuthor) {
+		query = query.Set(authorProfileColumns[i], value)
+	}
+	querySQL, args, err := query.Where(sq.Eq{"id": idAuthor}).ToSql()
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrQueryBuilds, err)
+	}
+
+	result, err := tx.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("PgAuthorRep.Update: %w", ErrRowsAffected)
+	}
+	// имя автора входит в запись произведения, переименование - изменение его произведений
+	if updatedAuthor.GetName() != oldName {
+		touchSQL, touchArgs, err := psql.Update("Artworks").
+			Set("updatedAt", sq.Expr("now()")).
+			Where(sq.Or{
+				sq.Eq{"authorID": idAuthor},
+				sq.Expr("id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)", idAuthor),
+			}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrQueryBuilds, err)
+		}
+		if _, err := tx.ExecContext(ctx, touchSQL, touchArgs...); err != nil {
+			return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrQueryExec, err)
+		}
+	}
+	err = historyrep.PgAddChange(ctx, tx, models.EntityAuthor, idAuthor, change, before, updatedAuthor.Snapshot())
 	if err != nil {
 		return fmt.Errorf("PgAuthorRep.Update: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgAuthorRep.Update: %w: %v", ErrQueryExec, err)
+	}
 	return nil
 }
 
@@ -233,3 +357,96 @@ func (pg *PgAuthorRep) HasArtworks(ctx context.Context, authorID uuid.UUID) (boo
 	defer rows.Close()
 	return rows.Next(), nil
 }
+
+// Merge выполняется в одной транзакции вместе с записями истории. Если основной автор уже указан
+// у произведения дубля, повторная связь не создается; триггеры датировки проверяют произведения
+// на годы жизни основного автора
+func (pg *PgAuthorRep) Merge(
+	ctx context.Context, survivorID uuid.UUID, duplicateID uuid.UUID, change *models.Change,
+) (int, error) {
+	survivor, err := pg.GetByID(ctx, survivorID)
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+	}
+	duplicate, err := pg.GetByID(ctx, duplicateID)
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+	}
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	dupCoauthored := sq.Expr("id IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)", duplicateID)
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	exec := func(query sq.Sqlizer) (int64, error) {
+		querySQL, args, err := query.ToSql()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+		}
+		result, err := tx.ExecContext(ctx, querySQL, args...)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrQueryExec, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrRowsAffected, err)
+		}
+		return rowsAffected, nil
+	}
+
+	// смена автора - изменение произведения
+	moved, err := exec(psql.Update("Artworks").
+		Set("updatedAt", sq.Expr("now()")).
+		Where(sq.Or{sq.Eq{"authorID": duplicateID}, dupCoauthored}))
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+	}
+	queries := []sq.Sqlizer{
+		// дубль - дополнительный автор произведения, где основной автор уже указан
+		psql.Delete("Artwork_authors").
+			Where(sq.Eq{"authorID": duplicateID}).
+			Where(sq.Or{
+				sq.Expr("artworkID IN (SELECT artworkID FROM Artwork_authors WHERE authorID = ?)", survivorID),
+				sq.Expr("artworkID IN (SELECT id FROM Artworks WHERE authorID = ?)", survivorID),
+			}),
+		// основной автор - дополнительный автор произведения, где дубль основной
+		psql.Delete("Artwork_authors").
+			Where(sq.Eq{"authorID": survivorID}).
+			Where(sq.Expr("artworkID IN (SELECT id FROM Artworks WHERE authorID = ?)", duplicateID)),
+		psql.Update("Artwork_authors").
+			Set("authorID", survivorID).
+			Where(sq.Eq{"authorID": duplicateID}),
+		psql.Update("Artworks").
+			Set("authorID", survivorID).
+			Where(sq.Eq{"authorID": duplicateID}),
+	}
+	for _, query := range queries {
+		if _, err := exec(query); err != nil {
+			return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+		}
+	}
+	deleted, err := exec(psql.Delete("Author").Where(sq.Eq{"id": duplicateID}))
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+	} else if deleted == 0 {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", ErrAuthorNotFound)
+	}
+	err = historyrep.PgAddChange(ctx, tx, models.EntityAuthor, duplicateID, change,
+		duplicate.Snapshot(), duplicate.MergedIntoSnapshot(survivorID, int(moved)))
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+	}
+	err = historyrep.PgAddChange(ctx, tx, models.EntityAuthor, survivorID, change,
+		survivor.Snapshot(), survivor.MergedFromSnapshot(duplicateID, int(moved)))
+	if err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("PgAuthorRep.Merge: %w: %v", ErrQueryExec, err)
+	}
+	return int(moved), nil
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/pgtest"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -196,6 +198,28 @@ func TestPgAuthorRep_Update(t *testing.T) {
 				assert.Equal(t, 1902, a.GetBirthYear())
 			},
 		},
+		{
+			name: "Should update profile and portrait",
+			updateFunc: func(a *models.Author) (*models.Author, error) {
+				err := a.SetProfile(models.AuthorProfile{
+					Biography:  "Первая строка\nВторая строка",
+					Movements:  []string{"Импрессионизм", "Пуантилизм"},
+					BirthPlace: "Париж",
+					ULANID:     "500019484",
+					WikidataID: "Q296",
+				})
+				a.SetPortrait(models.AuthorImagesDir(a.GetID()) + "/portrait.jpg")
+				return a, err
+			},
+			wantCheck: func(t *testing.T, a *models.Author) {
+				p := a.GetProfile()
+				assert.Equal(t, "Первая строка\nВторая строка", p.Biography)
+				assert.Equal(t, []string{"Импрессионизм", "Пуантилизм"}, p.Movements)
+				assert.Equal(t, "Париж", p.BirthPlace)
+				assert.Equal(t, "Q296", p.WikidataID)
+				assert.Equal(t, models.AuthorImagesDir(a.GetID())+"/portrait.jpg", a.GetPortrait())
+			},
+		},
 		{
 			name: "Should return error from updateFunc",
 			updateFunc: func(a *models.Author) (*models.Author, error) {
@@ -209,7 +233,7 @@ func TestPgAuthorRep_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			author := th.createAndAddAuthor(t, 1)
 
-			err := th.arep.Update(th.ctx, author.GetID(), tt.updateFunc)
+			err := th.arep.Update(th.ctx, author.GetID(), tt.updateFunc, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -243,3 +267,52 @@ func TestPgAuthorRep_HasArtworks(t *testing.T) {
 		t.Skip("Requires artwork relationship setup")
 	})
 }
+
+func TestPgAuthorRep_Merge(t *testing.T) {
+	th := setupTestHelper(t)
+
+	t.Run("Should delete duplicate without artworks", func(t *testing.T) {
+		survivor := th.createAndAddAuthor(t, 1)
+		duplicate := th.createAndAddAuthor(t, 2)
+
+		moved, err := th.arep.Merge(th.ctx, survivor.GetID(), duplicate.GetID(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, moved)
+
+		_, err = th.arep.GetByID(th.ctx, duplicate.GetID())
+		assert.ErrorIs(t, err, authorrep.ErrAuthorNotFound)
+		_, err = th.arep.GetByID(th.ctx, survivor.GetID())
+		require.NoError(t, err)
+	})
+
+	t.Run("Should record merge in history of both authors", func(t *testing.T) {
+		hrep, err := historyrep.NewPgHistoryRep(th.ctx, th.pgCreds, th.dbCnfg)
+		require.NoError(t, err)
+		survivor := th.createAndAddAuthor(t, 4)
+		duplicate := th.createAndAddAuthor(t, 5)
+		employeeID := uuid.New()
+		change := &models.Change{Action: models.ChangeMerge, ChangedBy: employeeID, ChangedAt: time.Now()}
+
+		_, err = th.arep.Merge(th.ctx, survivor.GetID(), duplicate.GetID(), change)
+		require.NoError(t, err)
+
+		history, err := hrep.GetHistory(th.ctx, models.EntityAuthor, duplicate.GetID())
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, models.ChangeMerge, history[0].GetAction())
+		assert.Equal(t, employeeID, history[0].GetChangedBy())
+		assert.Equal(t, survivor.GetID().String(), history[0].GetAfter()["mergedInto"])
+		history, err = hrep.GetHistory(th.ctx, models.EntityAuthor, survivor.GetID())
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, duplicate.GetID().String(), history[0].GetAfter()["mergedFrom"])
+		assert.Equal(t, "0", history[0].GetAfter()["movedArtworks"])
+	})
+
+	t.Run("Should return error for non-existent duplicate", func(t *testing.T) {
+		survivor := th.createAndAddAuthor(t, 3)
+
+		_, err := th.arep.Merge(th.ctx, survivor.GetID(), uuid.New(), nil)
+		assert.ErrorIs(t, err, authorrep.ErrAuthorNotFound)
+	})
+}
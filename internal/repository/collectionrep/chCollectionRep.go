@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/google/uuid"
 )
@@ -62,15 +64,18 @@ func NewCHCollectionRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials
 func (ch *CHCollectionRep) parseCollectionsRows(rows *sql.Rows) ([]*models.Collection, error) {
 	var resCollections []*models.Collection
 	for rows.Next() {
-		var id uuid.UUID
+		var id, parentID uuid.UUID
 		var title string
-		if err := rows.Scan(&id, &title); err != nil {
+		if err := rows.Scan(&id, &title, &parentID); err != nil {
 			return nil, fmt.Errorf("parseCollectionsRows: scan error: %v", err)
 		}
 		collection, err := models.NewCollection(id, title)
 		if err != nil {
 			return nil, fmt.Errorf("parseCollectionsRows: %v", err)
 		}
+		if err := collection.SetParent(parentID); err != nil {
+			return nil, fmt.Errorf("parseCollectionsRows: %v", err)
+		}
 		resCollections = append(resCollections, &collection)
 	}
 	if err := rows.Err(); err != nil {
@@ -94,7 +99,7 @@ func (ch *CHCollectionRep) execSelectQuery(ctx context.Context, query string, ar
 }
 
 func (ch *CHCollectionRep) GetAllCollections(ctx context.Context) ([]*models.Collection, error) {
-	query := "SELECT id, title FROM Collection"
+	query := "SELECT id, title, parentID FROM Collection WHERE deletedAt IS NULL"
 	res, err := ch.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("CHCollectionRep.GetAllCollections: %v", err)
@@ -103,7 +108,7 @@ func (ch *CHCollectionRep) GetAllCollections(ctx context.Context) ([]*models.Col
 }
 
 func (ch *CHCollectionRep) GetCollectionByID(ctx context.Context, id uuid.UUID) (*models.Collection, error) {
-	query := "SELECT id, title FROM Collection WHERE id = ?"
+	query := "SELECT id, title, parentID FROM Collection WHERE id = ? AND deletedAt IS NULL"
 	res, err := ch.execSelectQuery(ctx, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("CHCollectionRep.GetCollectionByID: %v", err)
@@ -131,11 +136,12 @@ func (ch *CHCollectionRep) execChangeQuery(ctx context.Context, query string, ar
 }
 
 func (ch *CHCollectionRep) AddCollection(ctx context.Context, c *models.Collection) error {
-	query := "INSERT INTO Collection (id, title) VALUES (?, ?)"
+	query := "INSERT INTO Collection (id, title, parentID) VALUES (?, ?, ?)"
 
 	err := ch.execChangeQuery(ctx, query,
 		c.GetID(),
-		c.GetTitle())
+		c.GetTitle(),
+		c.GetParentID())
 
 	if err != nil {
 		return fmt.Errorf("CHCollectionRep.AddCollection: %w", err)
@@ -144,8 +150,34 @@ func (ch *CHCollectionRep) AddCollection(ctx context.Context, c *models.Collecti
 }
 
 func (ch *CHCollectionRep) DeleteCollection(ctx context.Context, idCol uuid.UUID) error {
-	query := "ALTER TABLE Collection DELETE WHERE id = ?"
-	err := ch.execChangeQuery(ctx, query, idCol)
+	if _, err := ch.GetCollectionByID(ctx, idCol); err != nil {
+		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w", err)
+	}
+	rows, err := ch.db.QueryContext(ctx,
+		"SELECT id, title FROM Artworks WHERE collectionID = ? AND deletedAt IS NULL ORDER BY title", idCol)
+	if err != nil {
+		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w: %v", ErrQueryExec, err)
+	}
+	dependents, err := parseDependentRows(rows, models.EntityArtwork)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w", err)
+	}
+	rows, err = ch.db.QueryContext(ctx,
+		"SELECT id, title FROM Collection WHERE parentID = ? AND deletedAt IS NULL ORDER BY title", idCol)
+	if err != nil {
+		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w: %v", ErrQueryExec, err)
+	}
+	children, err := parseDependentRows(rows, models.EntityCollection)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w", err)
+	}
+	if err := models.CheckDependents(append(dependents, children...)); err != nil {
+		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w", err)
+	}
+	query := "ALTER TABLE Collection UPDATE deletedAt = now() WHERE id = ?"
+	err = ch.execChangeQuery(ctx, query, idCol)
 	if err != nil {
 		return fmt.Errorf("CHCollectionRep.DeleteCollection: %w", err)
 	}
@@ -156,28 +188,127 @@ func (ch *CHCollectionRep) UpdateCollection(
 	ctx context.Context,
 	idCol uuid.UUID,
 	funcUpdate func(*models.Collection) (*models.Collection, error),
+	change *models.Change,
 ) error {
 	col, err := ch.GetCollectionByID(ctx, idCol)
 	if err != nil {
 		return fmt.Errorf("CHCollectionRep.UpdateCollection %w", err)
 	}
 
+	before := col.Snapshot()
+	oldParentID := col.GetParentID()
+	oldTitle := col.GetTitle()
 	updatedCollection, err := funcUpdate(col)
 	if err != nil {
-		return fmt.Errorf("CHCollectionRep.UpdateCollection: %w", ErrUpdateCollection)
+		return fmt.Errorf("CHCollectionRep.UpdateCollection: %w: %w", ErrUpdateCollection, err)
+	}
+	// транзакций в ClickHouse нет, поэтому перенос проверяется еще раз по иерархии непосредственно перед мутацией
+	if updatedCollection.GetParentID() != oldParentID && updatedCollection.GetParentID() != uuid.Nil {
+		collections, err := ch.GetAllCollections(ctx)
+		if err != nil {
+			return fmt.Errorf("CHCollectionRep.UpdateCollection: %w", err)
+		}
+		if err := models.NewCollectionHierarchy(collections).CheckCollection(updatedCollection); err != nil {
+			return fmt.Errorf("CHCollectionRep.UpdateCollection: %w: %w", models.ErrValidateCollection, err)
+		}
+	}
+	err = historyrep.CHAddChange(ctx, ch.db, models.EntityCollection, idCol, change, before, updatedCollection.Snapshot())
+	if err != nil {
+		return fmt.Errorf("CHCollectionRep.UpdateCollection: %w", err)
 	}
 
-	query := "ALTER TABLE Collection UPDATE title = ? WHERE id = ?"
+	query := "ALTER TABLE Collection UPDATE title = ?, parentID = ? WHERE id = ?"
 	err = ch.execChangeQuery(ctx, query,
 		updatedCollection.GetTitle(),
+		updatedCollection.GetParentID(),
 		idCol)
 
 	if err != nil {
 		return fmt.Errorf("CHCollectionRep.UpdateCollection: %w", err)
 	}
+	// название коллекции входит в запись произведения, переименование - изменение ее произведений
+	if updatedCollection.GetTitle() != oldTitle {
+		err = ch.execChangeQuery(ctx, "ALTER TABLE Artworks UPDATE updatedAt = now() WHERE collectionID = ?", idCol)
+		if err != nil {
+			return fmt.Errorf("CHCollectionRep.UpdateCollection: %w", err)
+		}
+	}
 	return nil
 }
 
+func (ch *CHCollectionRep) GetCollectionStats(ctx context.Context, collectionIDs uuid.UUIDs) (*models.CollectionStats, error) {
+	const centuryExpr = "toString(intDiv(Artworks.creationYear - 1, 100) + 1)"
+	stats := &models.CollectionStats{}
+	if len(collectionIDs) == 0 {
+		return stats, nil
+	}
+	placeholders := make([]string, len(collectionIDs))
+	args := make([]interface{}, len(collectionIDs))
+	for i, id := range collectionIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	inCollections := "Artworks.collectionID IN (" + strings.Join(placeholders, ", ") + ") AND Artworks.deletedAt IS NULL"
+
+	var artworkCount, exhibitedCount uint64
+	err := ch.db.QueryRowContext(ctx, `
+		SELECT count(), countIf(Artworks.id IN (
+			SELECT ae.artworkID FROM Artwork_event ae JOIN Events ON ae.eventID = Events.id WHERE Events.valid = 1 AND Events.status = 'approved'))
+		FROM Artworks
+		WHERE `+inCollections, args...).Scan(&artworkCount, &exhibitedCount)
+	if err != nil {
+		return nil, fmt.Errorf("CHCollectionRep.GetCollectionStats: %w: %v", ErrQueryExec, err)
+	}
+	stats.ArtworkCount, stats.ExhibitedArtworkCount = int(artworkCount), int(exhibitedCount)
+
+	specs := []struct {
+		dest  *[]models.FacetBucket
+		query string
+	}{
+		{&stats.Authors, `
+			SELECT toString(Author.id), Author.name, count()
+			FROM Artworks
+			JOIN Author ON Artworks.authorID = Author.id
+			WHERE ` + inCollections + `
+			GROUP BY Author.id, Author.name
+			ORDER BY count() DESC, Author.name`},
+		{&stats.Centuries, `
+			SELECT ` + centuryExpr + `, ` + centuryExpr + `, count()
+			FROM Artworks
+			WHERE ` + inCollections + `
+			GROUP BY ` + centuryExpr + `
+			ORDER BY min(Artworks.creationYear)`},
+		{&stats.Technics, `
+			SELECT Artworks.technic, Artworks.technic, count()
+			FROM Artworks
+			WHERE ` + inCollections + `
+			GROUP BY Artworks.technic
+			ORDER BY count() DESC, Artworks.technic`},
+		{&stats.Exhibitions, `
+			SELECT toString(Events.id), Events.title, count()
+			FROM Artwork_event ae
+			JOIN Artworks ON ae.artworkID = Artworks.id
+			JOIN Events ON ae.eventID = Events.id
+			WHERE ` + inCollections + ` AND Events.valid = 1 AND Events.status = 'approved'
+			GROUP BY Events.id, Events.title, Events.dateBegin
+			ORDER BY Events.dateBegin DESC, Events.title`},
+	}
+	for _, spec := range specs {
+		rows, err := ch.db.QueryContext(ctx, spec.query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("CHCollectionRep.GetCollectionStats: %w: %v", ErrQueryExec, err)
+		}
+		buckets, err := parseStatBuckets(rows)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("CHCollectionRep.GetCollectionStats: %w", err)
+		}
+		*spec.dest = buckets
+	}
+	labelCenturies(stats.Centuries)
+	return stats, nil
+}
+
 func (ch *CHCollectionRep) Ping(ctx context.Context) error {
 	return ch.db.PingContext(ctx)
 }
@@ -2,8 +2,10 @@ package collectionrep
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
@@ -19,8 +21,17 @@ type CollectionRep interface {
 	GetAllCollections(ctx context.Context) ([]*models.Collection, error)
 	GetCollectionByID(ctx context.Context, id uuid.UUID) (*models.Collection, error)
 	AddCollection(ctx context.Context, e *models.Collection) error
+	// DeleteCollection переносит коллекцию в корзину. Если в коллекции есть произведения или вложенные коллекции,
+	// которые не в корзине, коллекция не удаляется, они возвращаются в models.DependencyError
 	DeleteCollection(ctx context.Context, idCol uuid.UUID) error
-	UpdateCollection(ctx context.Context, idCol uuid.UUID, funcUpdate func(*models.Collection) (*models.Collection, error)) error
+	// UpdateCollection сохраняет изменение вместе с версией change в истории изменений; nil - изменение без истории.
+	// Перенос в другую коллекцию повторно проверяется на циклы и глубину вложенности перед сохранением
+	UpdateCollection(
+		ctx context.Context, idCol uuid.UUID, funcUpdate func(*models.Collection) (*models.Collection, error), change *models.Change,
+	) error
+	// GetCollectionStats считает произведения из переданных коллекций по основному автору, веку, технике
+	// и участию в мероприятиях; произведения в корзине и отмененные мероприятия не учитываются
+	GetCollectionStats(ctx context.Context, collectionIDs uuid.UUIDs) (*models.CollectionStats, error)
 }
 
 func NewCollectionRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (CollectionRep, error) {
@@ -33,3 +44,47 @@ func NewCollectionRep(ctx context.Context, datebaseType string, pgCreds *cnfg.Da
 	}
 	// return &MockAdminRep{}, nil
 }
+
+// parseDependentRows читает пары (id, название) произведений или коллекций, которые мешают удалить коллекцию
+func parseDependentRows(rows *sql.Rows, entityType models.EntityType) ([]models.DependentRecord, error) {
+	var res []models.DependentRecord
+	for rows.Next() {
+		r := models.DependentRecord{EntityType: entityType}
+		if err := rows.Scan(&r.ID, &r.Title); err != nil {
+			return nil, fmt.Errorf("parseDependentRows: scan error: %v", err)
+		}
+		res = append(res, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return res, nil
+}
+
+// parseStatBuckets читает тройки (значение, подпись, количество) статистики коллекции
+func parseStatBuckets(rows *sql.Rows) ([]models.FacetBucket, error) {
+	var buckets []models.FacetBucket
+	for rows.Next() {
+		var bucket models.FacetBucket
+		var value, label sql.NullString
+		var count uint64
+		if err := rows.Scan(&value, &label, &count); err != nil {
+			return nil, fmt.Errorf("parseStatBuckets: scan error: %v", err)
+		}
+		bucket.Value, bucket.Label, bucket.Count = value.String, label.String, int(count)
+		buckets = append(buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return buckets, nil
+}
+
+// labelCenturies заменяет номер века в подписи значения его названием
+func labelCenturies(buckets []models.FacetBucket) {
+	for i := range buckets {
+		if century, err := strconv.Atoi(buckets[i].Value); err == nil {
+			buckets[i].Label = models.CenturyLabel(century)
+		}
+	}
+}
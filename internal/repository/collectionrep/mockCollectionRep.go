@@ -39,7 +39,17 @@ func (m *MockCollectionRep) DeleteCollection(ctx context.Context, idCol uuid.UUI
 	return args.Error(0)
 }
 
-func (m *MockCollectionRep) UpdateCollection(ctx context.Context, idCol uuid.UUID, funcUpdate func(*models.Collection) (*models.Collection, error)) error {
-	args := m.Called(ctx, idCol, funcUpdate)
+func (m *MockCollectionRep) UpdateCollection(
+	ctx context.Context, idCol uuid.UUID, funcUpdate func(*models.Collection) (*models.Collection, error), change *models.Change,
+) error {
+	args := m.Called(ctx, idCol, funcUpdate, change)
 	return args.Error(0)
 }
+
+func (m *MockCollectionRep) GetCollectionStats(ctx context.Context, collectionIDs uuid.UUIDs) (*models.CollectionStats, error) {
+	args := m.Called(ctx, collectionIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CollectionStats), args.Error(1)
+}
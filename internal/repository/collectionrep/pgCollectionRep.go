@@ -10,6 +10,7 @@ import (
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -67,13 +68,17 @@ func (pg *PgCollectionRep) parseCollectionsRows(rows *sql.Rows) ([]*models.Colle
 	for rows.Next() {
 		var id uuid.UUID
 		var title string
-		if err := rows.Scan(&id, &title); err != nil {
+		var parentID uuid.NullUUID
+		if err := rows.Scan(&id, &title, &parentID); err != nil {
 			return nil, fmt.Errorf("parseCollectionsRows: scan error: %v", err)
 		}
 		collection, err := models.NewCollection(id, title)
 		if err != nil {
 			return nil, fmt.Errorf("parseCollectionsRows: %v", err)
 		}
+		if err := collection.SetParent(parentID.UUID); err != nil {
+			return nil, fmt.Errorf("parseCollectionsRows: %v", err)
+		}
 		resCollections = append(resCollections, &collection)
 	}
 	if err := rows.Err(); err != nil {
@@ -103,8 +108,9 @@ func (pg *PgCollectionRep) execSelectQuery(ctx context.Context, query sq.SelectB
 
 func (pg *PgCollectionRep) GetAllCollections(ctx context.Context) ([]*models.Collection, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Select("id", "title").
-		From("collection")
+	query := psql.Select("id", "title", "parentID").
+		From("collection").
+		Where(sq.Eq{"deletedAt": nil})
 	res, err := pg.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("PgCollectionRep.GetAll: %v", err)
@@ -114,9 +120,9 @@ func (pg *PgCollectionRep) GetAllCollections(ctx context.Context) ([]*models.Col
 
 func (pg *PgCollectionRep) GetCollectionByID(ctx context.Context, id uuid.UUID) (*models.Collection, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Select("id", "title").
+	query := psql.Select("id", "title", "parentID").
 		From("Collection").
-		Where(sq.Eq{"id": id})
+		Where(sq.Eq{"id": id, "deletedAt": nil})
 	res, err := pg.execSelectQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("PgCollectionRep.GetByID: %v", err)
@@ -129,6 +135,33 @@ func (pg *PgCollectionRep) GetCollectionByID(ctx context.Context, id uuid.UUID)
 	return res[0], nil
 }
 
+// getForUpdate читает коллекцию в транзакции tx и блокирует ее строку до конца транзакции
+func (pg *PgCollectionRep) getForUpdate(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.Collection, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select("id", "title", "parentID").
+		From("Collection").
+		Where(sq.Eq{"id": id, "deletedAt": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := tx.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := pg.parseCollectionsRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrCollectionNotFound
+	}
+	return res[0], nil
+}
+
 func (pg *PgCollectionRep) execChangeQuery(ctx context.Context, query sq.Sqlizer) error {
 	querySQL, args, err := query.ToSql()
 	if err != nil {
@@ -149,12 +182,20 @@ func (pg *PgCollectionRep) execChangeQuery(ctx context.Context, query sq.Sqlizer
 	return nil
 }
 
+// parentValue возвращает родительскую коллекцию для записи, NULL - корневая коллекция
+func parentValue(c *models.Collection) interface{} {
+	if c.GetParentID() == uuid.Nil {
+		return nil
+	}
+	return c.GetParentID()
+}
+
 func (pg *PgCollectionRep) AddCollection(ctx context.Context, e *models.Collection) error {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 	query := psql.Insert("Collection").
-		Columns("id", "title").
-		Values(e.GetID(), e.GetTitle())
+		Columns("id", "title", "parentID").
+		Values(e.GetID(), e.GetTitle(), parentValue(e))
 	err := pg.execChangeQuery(ctx, query)
 	if err != nil {
 		return fmt.Errorf("PgCollectionRep.Add: %w", err)
@@ -164,12 +205,116 @@ func (pg *PgCollectionRep) AddCollection(ctx context.Context, e *models.Collecti
 
 func (pg *PgCollectionRep) DeleteCollection(ctx context.Context, idCol uuid.UUID) error {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Delete("Collection").
-		Where(sq.Eq{"id": idCol})
-	err := pg.execChangeQuery(ctx, query)
+	tx, err := pg.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	artworksSQL, artworksArgs, err := psql.Select("id", "title").
+		From("Artworks").
+		Where(sq.Eq{"collectionID": idCol, "deletedAt": nil}).
+		OrderBy("title").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := tx.QueryContext(ctx, artworksSQL, artworksArgs...)
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	dependents, err := parseDependentRows(rows, models.EntityArtwork)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w", err)
+	}
+
+	childrenSQL, childrenArgs, err := psql.Select("id", "title").
+		From("Collection").
+		Where(sq.Eq{"parentID": idCol, "deletedAt": nil}).
+		OrderBy("title").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err = tx.QueryContext(ctx, childrenSQL, childrenArgs...)
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	children, err := parseDependentRows(rows, models.EntityCollection)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w", err)
+	}
+	if err := models.CheckDependents(append(dependents, children...)); err != nil {
 		return fmt.Errorf("PgCollectionRep.Delete: %w", err)
 	}
+
+	deleteSQL, deleteArgs, err := psql.Update("Collection").
+		Set("deletedAt", sq.Expr("now()")).
+		Where(sq.Eq{"id": idCol, "deletedAt": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryBuilds, err)
+	}
+	result, err := tx.ExecContext(ctx, deleteSQL, deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrRowsAffected, err)
+	} else if n == 0 {
+		return fmt.Errorf("PgCollectionRep.Delete: %w", ErrCollectionNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgCollectionRep.Delete: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// checkMove повторяет проверку иерархии из models.CollectionHierarchy.CheckCollection в транзакции tx:
+// коллекция idCol не должна оказаться среди предков parentID, глубина вложенности не превышает
+// models.CollectionMaxDepth. Переносы коллекций выполняются по одному под блокировкой иерархии,
+// поэтому два одновременных переноса не создают цикл
+func checkMove(ctx context.Context, tx *sql.Tx, idCol uuid.UUID, parentID uuid.UUID) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtextextended('Collection:hierarchy', 0))"); err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	var cycle bool
+	var parentDepth int
+	err := tx.QueryRowContext(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parentID FROM Collection WHERE id = $1
+			UNION
+			SELECT c.id, c.parentID FROM Collection c JOIN ancestors a ON c.id = a.parentID
+		)
+		SELECT COALESCE(bool_or(id = $2), false), COUNT(*) FROM ancestors`,
+		parentID, idCol).Scan(&cycle, &parentDepth)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	if parentDepth == 0 {
+		return fmt.Errorf("%w: %w", models.ErrValidateCollection, models.ErrCollectionParentNotFound)
+	}
+	if cycle {
+		return fmt.Errorf("%w: %w", models.ErrValidateCollection, models.ErrCollectionCycle)
+	}
+	var subtreeDepth int
+	err = tx.QueryRowContext(ctx, `
+		WITH RECURSIVE subtree AS (
+			SELECT id, 1 AS depth FROM Collection WHERE id = $1
+			UNION ALL
+			SELECT c.id, s.depth + 1 FROM Collection c JOIN subtree s ON c.parentID = s.id WHERE s.depth <= $2
+		)
+		SELECT MAX(depth) FROM subtree`,
+		idCol, models.CollectionMaxDepth).Scan(&subtreeDepth)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	if parentDepth+subtreeDepth > models.CollectionMaxDepth {
+		return fmt.Errorf("%w: %w", models.ErrValidateCollection, models.ErrCollectionTooDeep)
+	}
 	return nil
 }
 
@@ -177,23 +322,139 @@ func (pg *PgCollectionRep) UpdateCollection(
 	ctx context.Context,
 	idCol uuid.UUID,
 	funcUpdate func(*models.Collection) (*models.Collection, error),
+	change *models.Change,
 ) error {
-	col, err := pg.GetCollectionByID(ctx, idCol)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	// состояние до изменения читается под блокировкой строки, чтобы параллельные изменения не искажали историю
+	col, err := pg.getForUpdate(ctx, tx, idCol)
 	if err != nil {
 		return fmt.Errorf("pgCollectionRep.Update %w", err)
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	before := col.Snapshot()
+	oldParentID := col.GetParentID()
+	oldTitle := col.GetTitle()
 	updatedEmployee, err := funcUpdate(col)
 	if err != nil {
-		return fmt.Errorf("pgCollectionRep.Update: %w", ErrUpdateCollection)
+		return fmt.Errorf("pgCollectionRep.Update: %w: %w", ErrUpdateCollection, err)
 	}
-	query := psql.Update("Collection").
+	query, args, err := psql.Update("Collection").
 		Set("title", updatedEmployee.GetTitle()).
-		Where(sq.Eq{"id": idCol})
-	err = pg.execChangeQuery(ctx, query)
+		Set("parentID", parentValue(updatedEmployee)).
+		Where(sq.Eq{"id": idCol}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrQueryBuilds, err)
+	}
+
+	if updatedEmployee.GetParentID() != oldParentID && updatedEmployee.GetParentID() != uuid.Nil {
+		if err := checkMove(ctx, tx, idCol, updatedEmployee.GetParentID()); err != nil {
+			return fmt.Errorf("pgCollectionRep.Update: %w", err)
+		}
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("pgCollectionRep.Update: %w", ErrRowsAffected)
+	}
+	// название коллекции входит в запись произведения, переименование - изменение ее произведений
+	if updatedEmployee.GetTitle() != oldTitle {
+		touchSQL, touchArgs, err := psql.Update("Artworks").
+			Set("updatedAt", sq.Expr("now()")).
+			Where(sq.Eq{"collectionID": idCol}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrQueryBuilds, err)
+		}
+		if _, err := tx.ExecContext(ctx, touchSQL, touchArgs...); err != nil {
+			return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrQueryExec, err)
+		}
+	}
+	err = historyrep.PgAddChange(ctx, tx, models.EntityCollection, idCol, change, before, updatedEmployee.Snapshot())
 	if err != nil {
 		return fmt.Errorf("pgCollectionRep.Update: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("pgCollectionRep.Update: %w: %v", ErrQueryExec, err)
+	}
 	return nil
 }
+
+func (pg *PgCollectionRep) GetCollectionStats(ctx context.Context, collectionIDs uuid.UUIDs) (*models.CollectionStats, error) {
+	const centuryExpr = "CAST((artworks.creationYear - 1) / 100 + 1 AS text)"
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	inCollections := sq.Eq{"artworks.collectionID": []uuid.UUID(collectionIDs), "artworks.deletedAt": nil}
+	stats := &models.CollectionStats{}
+
+	countSQL, countArgs, err := psql.Select("COUNT(*)",
+		"COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM Artwork_event ae JOIN Events ON ae.eventID = Events.id "+
+			"WHERE ae.artworkID = artworks.id AND Events.valid AND Events.status = 'approved'))").
+		From("artworks").
+		Where(inCollections).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgCollectionRep.GetCollectionStats: %w: %v", ErrQueryBuilds, err)
+	}
+	if err := pg.db.QueryRowContext(ctx, countSQL, countArgs...).Scan(&stats.ArtworkCount, &stats.ExhibitedArtworkCount); err != nil {
+		return nil, fmt.Errorf("PgCollectionRep.GetCollectionStats: %w: %v", ErrQueryExec, err)
+	}
+
+	specs := []struct {
+		dest  *[]models.FacetBucket
+		query sq.SelectBuilder
+	}{
+		{&stats.Authors, psql.Select("CAST(author.id AS text)", "author.name", "COUNT(*)").
+			From("artworks").
+			Join("author ON artworks.authorID = author.id").
+			Where(inCollections).
+			GroupBy("author.id", "author.name").
+			OrderBy("COUNT(*) DESC", "author.name")},
+		{&stats.Centuries, psql.Select(centuryExpr, centuryExpr, "COUNT(*)").
+			From("artworks").
+			Where(inCollections).
+			GroupBy(centuryExpr).
+			OrderBy("MIN(artworks.creationYear)")},
+		{&stats.Technics, psql.Select("artworks.technic", "artworks.technic", "COUNT(*)").
+			From("artworks").
+			Where(inCollections).
+			GroupBy("artworks.technic").
+			OrderBy("COUNT(*) DESC", "artworks.technic")},
+		{&stats.Exhibitions, psql.Select("CAST(Events.id AS text)", "Events.title", "COUNT(*)").
+			From("Artwork_event ae").
+			Join("artworks ON ae.artworkID = artworks.id").
+			Join("Events ON ae.eventID = Events.id").
+			Where(inCollections).
+			Where(sq.Eq{"Events.valid": true, "Events.status": string(models.EventStatusApproved)}).
+			GroupBy("Events.id", "Events.title", "Events.dateBegin").
+			OrderBy("Events.dateBegin DESC", "Events.title")},
+	}
+	for _, spec := range specs {
+		querySQL, args, err := spec.query.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("PgCollectionRep.GetCollectionStats: %w: %v", ErrQueryBuilds, err)
+		}
+		rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+		if err != nil {
+			return nil, fmt.Errorf("PgCollectionRep.GetCollectionStats: %w: %v", ErrQueryExec, err)
+		}
+		buckets, err := parseStatBuckets(rows)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("PgCollectionRep.GetCollectionStats: %w", err)
+		}
+		*spec.dest = buckets
+	}
+	labelCenturies(stats.Centuries)
+	return stats, nil
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/pgtest"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -171,6 +173,7 @@ func TestPgCollectionRep_GetByID(t *testing.T) {
 
 func TestPgCollectionRep_Update(t *testing.T) {
 	th := setupTestHelper(t)
+	parent := th.createAndAddCollection(t, 2)
 
 	tests := []struct {
 		name       string
@@ -191,6 +194,16 @@ func TestPgCollectionRep_Update(t *testing.T) {
 				assert.Equal(t, "Updated Title", c.GetTitle())
 			},
 		},
+		{
+			name: "Should move collection under parent",
+			updateFunc: func(c *models.Collection) (*models.Collection, error) {
+				err := c.Update(models.CollectionUpdateReq{Title: c.GetTitle(), ParentID: parent.GetID()})
+				return c, err
+			},
+			wantCheck: func(t *testing.T, c *models.Collection) {
+				assert.Equal(t, parent.GetID(), c.GetParentID())
+			},
+		},
 		{
 			name: "Should return error from updateFunc",
 			updateFunc: func(c *models.Collection) (*models.Collection, error) {
@@ -204,7 +217,7 @@ func TestPgCollectionRep_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			collection := th.createAndAddCollection(t, 1)
 
-			err := th.crep.UpdateCollection(th.ctx, collection.GetID(), tt.updateFunc)
+			err := th.crep.UpdateCollection(th.ctx, collection.GetID(), tt.updateFunc, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -220,3 +233,72 @@ func TestPgCollectionRep_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestPgCollectionRep_UpdateCollectionHierarchy(t *testing.T) {
+	th := setupTestHelper(t)
+
+	parent := th.createAndAddCollection(t, 1)
+	child := th.createAndAddCollection(t, 2)
+	moveUnder := func(parentID uuid.UUID) func(c *models.Collection) (*models.Collection, error) {
+		return func(c *models.Collection) (*models.Collection, error) {
+			return c, c.SetParent(parentID)
+		}
+	}
+	require.NoError(t, th.crep.UpdateCollection(th.ctx, child.GetID(), moveUnder(parent.GetID()), nil))
+
+	t.Run("Should reject move under own descendant", func(t *testing.T) {
+		err := th.crep.UpdateCollection(th.ctx, parent.GetID(), moveUnder(child.GetID()), nil)
+		assert.ErrorIs(t, err, models.ErrValidateCollection)
+		assert.ErrorIs(t, err, models.ErrCollectionCycle)
+
+		stored, err := th.crep.GetCollectionByID(th.ctx, parent.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, stored.GetParentID())
+	})
+
+	t.Run("Should reject too deep hierarchy", func(t *testing.T) {
+		deepest := child
+		for i := 3; i <= models.CollectionMaxDepth; i++ {
+			next := th.createAndAddCollection(t, i)
+			require.NoError(t, th.crep.UpdateCollection(th.ctx, next.GetID(), moveUnder(deepest.GetID()), nil))
+			deepest = next
+		}
+		other := th.createAndAddCollection(t, models.CollectionMaxDepth+1)
+
+		err := th.crep.UpdateCollection(th.ctx, other.GetID(), moveUnder(deepest.GetID()), nil)
+		assert.ErrorIs(t, err, models.ErrCollectionTooDeep)
+	})
+}
+
+func TestPgCollectionRep_UpdateCollectionWithHistory(t *testing.T) {
+	th := setupTestHelper(t)
+	hrep, err := historyrep.NewPgHistoryRep(th.ctx, th.pgCreds, th.dbCnfg)
+	require.NoError(t, err)
+
+	collection := th.createAndAddCollection(t, 1)
+	employeeID := uuid.New()
+	rename := func(title string) func(c *models.Collection) (*models.Collection, error) {
+		return func(c *models.Collection) (*models.Collection, error) {
+			return c, c.Update(models.CollectionUpdateReq{Title: title})
+		}
+	}
+	change := &models.Change{Action: models.ChangeUpdate, ChangedBy: employeeID, ChangedAt: time.Now()}
+
+	require.NoError(t, th.crep.UpdateCollection(th.ctx, collection.GetID(), rename("Графика"), change))
+	require.NoError(t, th.crep.UpdateCollection(th.ctx, collection.GetID(), rename("Гравюры"), change))
+	// без изменений полей новая версия не создается
+	require.NoError(t, th.crep.UpdateCollection(th.ctx, collection.GetID(), rename("Гравюры"), change))
+	// неудачное изменение не оставляет записи в истории
+	err = th.crep.UpdateCollection(th.ctx, collection.GetID(), func(*models.Collection) (*models.Collection, error) {
+		return nil, errors.New("update error")
+	}, change)
+	require.Error(t, err)
+
+	history, err := hrep.GetHistory(th.ctx, models.EntityCollection, collection.GetID())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].GetVersion())
+	assert.Equal(t, employeeID, history[0].GetChangedBy())
+	assert.Equal(t, 2, history[1].GetVersion())
+	assert.Equal(t, []models.FieldChange{{Field: "title", Old: "Графика", New: "Гравюры"}}, history[1].Changes())
+}
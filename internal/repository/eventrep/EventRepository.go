@@ -13,27 +13,56 @@ import (
 )
 
 var (
-	ErrEventNotFound        = errors.New("the Event was not found in the repository")
-	ErrEventArtowrkNotFound = errors.New("the Event_artwork was not found in the repository")
-	ErrAddNoEmployee        = errors.New("failed to add the Event, no employeee")
-	ErrUpdateEvent          = errors.New("err update Event params")
+	ErrEventNotFound         = errors.New("the Event was not found in the repository")
+	ErrEventArtowrkNotFound  = errors.New("the Event_artwork was not found in the repository")
+	ErrAddNoEmployee         = errors.New("failed to add the Event, no employeee")
+	ErrUpdateEvent           = errors.New("err update Event params")
+	ErrEventTemplateNotFound = errors.New("the EventTemplate was not found in the repository")
+	ErrOrganiserNotFound     = errors.New("the Event_organiser was not found in the repository")
 	// ErrUpdateNoEmployee     = errors.New("failed to update the Events, no employeee")
 )
 
 type EventRep interface {
 	GetAll(ctx context.Context, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	// GetPage возвращает страницу мероприятий и общее число подходящих под фильтр
+	GetPage(
+		ctx context.Context,
+		filterOps *jsonreqresp.EventFilter,
+		sortOps *jsonreqresp.EventSortOps,
+		page *jsonreqresp.PageRequest,
+	) ([]*models.Event, jsonreqresp.PageInfo, error)
+	// GetArtworkIDs возвращает произведения мероприятия, произведения в корзине не возвращаются
 	GetArtworkIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Event, error)
 	GetEventsOfArtworkOnDate(ctx context.Context, artworkID uuid.UUID, dateBeg time.Time, dateEnd time.Time) ([]*models.Event, error)
 	GetCollectionsStat(ctx context.Context, eventID uuid.UUID) ([]*models.StatCollections, error)
+	// история участия в мероприятиях; filterOps задает диапазон дат
+	GetEventsByArtwork(ctx context.Context, artworkID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	GetEventsByAuthor(ctx context.Context, authorID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	GetEventsByCollection(ctx context.Context, collectionID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	// GetEventsByArtworks одним запросом возвращает мероприятия, в которых участвует хотя бы одно из произведений
+	GetEventsByArtworks(ctx context.Context, artworkIDs uuid.UUIDs) ([]*models.Event, error)
 	CheckEmployeeByID(ctx context.Context, id uuid.UUID) (bool, error)
 	//
 	Add(ctx context.Context, e *models.Event) error
 	Delete(ctx context.Context, eventID uuid.UUID) error
 	RealDelete(ctx context.Context, eventID uuid.UUID) error
-	Update(ctx context.Context, eventID uuid.UUID, funcUpdate func(*models.Event) (*models.Event, error)) error
+	// Update сохраняет изменение вместе с версией change в истории изменений; nil - изменение без истории
+	Update(ctx context.Context, eventID uuid.UUID, funcUpdate func(*models.Event) (*models.Event, error), change *models.Change) error
 	AddArtworksToEvent(ctx context.Context, eventID uuid.UUID, artworkID uuid.UUIDs) error
 	DeleteArtworkFromEvent(ctx context.Context, eventID uuid.UUID, artworkID uuid.UUID) error
+	// согласование: смена статуса мероприятия с записью в историю
+	ChangeStatus(ctx context.Context, review *models.EventReview) error
+	GetReviews(ctx context.Context, eventID uuid.UUID) ([]*models.EventReview, error)
+	// соорганизаторы мероприятия (помимо создателя)
+	GetOrganiserIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error)
+	AddOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error
+	DeleteOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error
+	//
+	GetTemplates(ctx context.Context) ([]*models.EventTemplate, error)
+	GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.EventTemplate, error)
+	AddTemplate(ctx context.Context, t *models.EventTemplate) error
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
 	Ping(ctx context.Context) error
 	Close()
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/google/uuid"
 )
@@ -61,16 +63,22 @@ func NewCHEventRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, dbC
 	return chInstance, nil
 }
 
-func (ch *CHEventRep) parseEventsRows(rows *sql.Rows) ([]*models.Event, error) {
+// parseEventsRows разбирает строки выборки мероприятий.
+// Если sortKeys не nil, последним столбцом ожидается ключ сортировки для курсора страницы.
+func (ch *CHEventRep) parseEventsRows(rows *sql.Rows, sortKeys *[]string) ([]*models.Event, error) {
 	var resEvents []*models.Event
 	for rows.Next() {
 		var id, creatorID uuid.UUID
-		var title, address string
+		var title, address, status, sortKey string
 		var dateBegin, dateEnd time.Time
 		var canVisit, valid uint8
 		var cntTickets int32
 
-		if err := rows.Scan(&id, &title, &dateBegin, &dateEnd, &canVisit, &address, &cntTickets, &creatorID, &valid); err != nil {
+		dest := []any{&id, &title, &dateBegin, &dateEnd, &canVisit, &address, &cntTickets, &creatorID, &valid, &status}
+		if sortKeys != nil {
+			dest = append(dest, &sortKey)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("scan error: %v", err)
 		}
 
@@ -89,7 +97,13 @@ func (ch *CHEventRep) parseEventsRows(rows *sql.Rows) ([]*models.Event, error) {
 		if err != nil {
 			return nil, fmt.Errorf("parseEventsRows: %v", err)
 		}
+		if err := event.SetStatus(models.EventStatus(status)); err != nil {
+			return nil, fmt.Errorf("parseEventsRows: %v", err)
+		}
 		resEvents = append(resEvents, &event)
+		if sortKeys != nil {
+			*sortKeys = append(*sortKeys, sortKey)
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %v", err)
@@ -131,6 +145,11 @@ func (ch *CHEventRep) buildFilterConditions(filterOps *jsonreqresp.EventFilter)
 		args = append(args, canVisit)
 	}
 
+	if filterOps.Status != "" {
+		conditions = append(conditions, "Events.status = ?")
+		args = append(args, filterOps.Status)
+	}
+
 	conditions = append(conditions, "Events.valid = 1")
 
 	if len(conditions) == 0 {
@@ -155,8 +174,70 @@ func joinConditions(conditions []string, sep string) string {
 	return result
 }
 
+func (ch *CHEventRep) sortExpr(field string) string {
+	switch field {
+	case jsonreqresp.TitleSortFieldEvent:
+		return "Events.title"
+	case jsonreqresp.DateBeginSortFieldEvent:
+		return "Events.dateBegin"
+	case jsonreqresp.DateEndSortFieldEvent:
+		return "Events.dateEnd"
+	case jsonreqresp.TicketsAvailableSortFieldEvent:
+		return "(ifNull(Events.cntTickets, 0) - tp.sold)"
+	}
+	return ""
+}
+
+// buildPageClause возвращает условие keyset-пагинации и порядок (поле сортировки, id)
+func (ch *CHEventRep) buildPageClause(
+	sortOps *jsonreqresp.EventSortOps, cursor *jsonreqresp.PageCursor,
+) (string, string, []interface{}, error) {
+	expr := ch.sortExpr(sortOps.Field)
+	direction := jsonreqresp.NormSortDirection(sortOps.Direction)
+	op := ">"
+	if direction == jsonreqresp.DESCDirection {
+		op = "<"
+	}
+
+	var condition string
+	var args []interface{}
+	if cursor != nil {
+		valuePlaceholder := "?"
+		var value interface{} = cursor.Value
+		switch sortOps.Field {
+		case jsonreqresp.DateBeginSortFieldEvent, jsonreqresp.DateEndSortFieldEvent:
+			if _, err := cursor.TimeValue(); err != nil {
+				return "", "", nil, err
+			}
+			valuePlaceholder = "toDateTime(?)"
+		case jsonreqresp.TicketsAvailableSortFieldEvent:
+			v, err := cursor.IntValue()
+			if err != nil {
+				return "", "", nil, err
+			}
+			value = v
+		}
+
+		if expr == "" {
+			condition = "Events.id " + op + " toUUID(?)"
+			args = append(args, cursor.ID.String())
+		} else {
+			condition = "(" + expr + ", Events.id) " + op + " (" + valuePlaceholder + ", toUUID(?))"
+			args = append(args, value, cursor.ID.String())
+		}
+	}
+
+	order := "ORDER BY "
+	if expr != "" {
+		order += expr + " " + direction + ", "
+	}
+	order += "Events.id " + direction
+	return condition, order, args, nil
+}
+
 func (ch *CHEventRep) GetArtworkIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
-	query := "SELECT artworkID FROM Artwork_event WHERE eventID = ?"
+	query := "SELECT ae.artworkID FROM Artwork_event ae JOIN Artworks a ON a.id = ae.artworkID " +
+		"WHERE ae.eventID = ? AND a.deletedAt IS NULL"
 	rows, err := ch.db.QueryContext(ctx, query, eventID)
 	if err != nil {
 		return nil, fmt.Errorf("CHEventRep.GetArtworkIDs: %w: %v", ErrQueryExec, err)
@@ -194,7 +275,7 @@ func (ch *CHEventRep) GetAll(ctx context.Context, filterOps *jsonreqresp.EventFi
 	baseQuery := `
 		SELECT 
 			id, title, dateBegin, dateEnd, canVisit, 
-			adress, cntTickets, creatorID, valid
+			adress, cntTickets, creatorID, valid, status
 		FROM Events`
 
 	filterClause, filterArgs := ch.buildFilterConditions(filterOps)
@@ -210,7 +291,7 @@ func (ch *CHEventRep) GetAll(ctx context.Context, filterOps *jsonreqresp.EventFi
 	}
 	defer rows.Close()
 
-	events, err := ch.parseEventsRows(rows)
+	events, err := ch.parseEventsRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("CHEventRep.GetAll %w", err)
 	}
@@ -223,11 +304,79 @@ func (ch *CHEventRep) GetAll(ctx context.Context, filterOps *jsonreqresp.EventFi
 	return events, nil
 }
 
+func (ch *CHEventRep) GetPage(
+	ctx context.Context,
+	filterOps *jsonreqresp.EventFilter,
+	sortOps *jsonreqresp.EventSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Event, jsonreqresp.PageInfo, error) {
+	if sortOps == nil {
+		sortOps = &jsonreqresp.EventSortOps{}
+	}
+	limit := page.PageLimit()
+	pageInfo := jsonreqresp.PageInfo{Limit: limit, HasTotal: true}
+	cursor, err := page.DecodeCursor()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHEventRep.GetPage %w", err)
+	}
+
+	filterClause, filterArgs := ch.buildFilterConditions(filterOps)
+
+	var total uint64
+	countQuery := "SELECT count() FROM Events " + filterClause
+	if err := ch.db.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&total); err != nil {
+		return nil, pageInfo, fmt.Errorf("CHEventRep.GetPage %w: %v", ErrQueryExec, err)
+	}
+	pageInfo.Total = int(total)
+
+	pageCondition, orderClause, pageArgs, err := ch.buildPageClause(sortOps, cursor)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHEventRep.GetPage %w", err)
+	}
+	if pageCondition != "" {
+		filterClause += " AND " + pageCondition
+	}
+
+	keyExpr := "''"
+	if expr := ch.sortExpr(sortOps.Field); expr != "" {
+		keyExpr = "toString(" + expr + ")"
+	}
+	query := `
+		SELECT
+			Events.id, Events.title, Events.dateBegin, Events.dateEnd, Events.canVisit,
+			Events.adress, Events.cntTickets, Events.creatorID, Events.valid, Events.status, ` + keyExpr + `
+		FROM Events
+		LEFT JOIN (SELECT eventID, count() AS sold FROM TicketPurchases GROUP BY eventID) AS tp
+			ON Events.id = tp.eventID ` + filterClause + " " + orderClause + fmt.Sprintf(" LIMIT %d", limit+1)
+
+	rows, err := ch.db.QueryContext(ctx, query, append(filterArgs, pageArgs...)...)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHEventRep.GetPage %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var sortKeys []string
+	events, err := ch.parseEventsRows(rows, &sortKeys)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHEventRep.GetPage %w", err)
+	}
+	if len(events) > limit {
+		events = events[:limit]
+		pageInfo.NextCursor = jsonreqresp.EncodeCursor(sortKeys[limit-1], events[limit-1].GetID())
+	}
+
+	events, err = ch.joinArtworkIDsToEvents(ctx, events)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("CHEventRep.GetPage %w", err)
+	}
+	return events, pageInfo, nil
+}
+
 func (ch *CHEventRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Event, error) {
 	query := `
 		SELECT 
 			id, title, dateBegin, dateEnd, canVisit, 
-			adress, cntTickets, creatorID, valid
+			adress, cntTickets, creatorID, valid, status
 		FROM Events
 		WHERE id = ?`
 
@@ -237,7 +386,7 @@ func (ch *CHEventRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Event,
 	}
 	defer rows.Close()
 
-	events, err := ch.parseEventsRows(rows)
+	events, err := ch.parseEventsRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("CHEventRep.GetByID %w", err)
 	}
@@ -260,7 +409,7 @@ func (ch *CHEventRep) GetEventsOfArtworkOnDate(ctx context.Context, artworkID uu
 	query := `
 		SELECT 
 			e.id, e.title, e.dateBegin, e.dateEnd, e.canVisit, 
-			e.adress, e.cntTickets, e.creatorID, e.valid
+			e.adress, e.cntTickets, e.creatorID, e.valid, e.status
 		FROM Events e
 		JOIN Artwork_event ae ON e.id = ae.eventID
 		WHERE ae.artworkID = ?
@@ -273,7 +422,7 @@ func (ch *CHEventRep) GetEventsOfArtworkOnDate(ctx context.Context, artworkID uu
 	}
 	defer rows.Close()
 
-	events, err := ch.parseEventsRows(rows)
+	events, err := ch.parseEventsRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("CHEventRep.GetEventsOfArtworkOnDate: %v", err)
 	}
@@ -295,6 +444,79 @@ func (ch *CHEventRep) GetEventsOfArtworkOnDate(ctx context.Context, artworkID uu
 	return events, nil
 }
 
+// getEventsByArtworks возвращает мероприятия, в которых участвуют произведения, подходящие под artworkCond
+func (ch *CHEventRep) getEventsByArtworks(
+	ctx context.Context, artworkCond string, artworkArgs []interface{}, filterOps *jsonreqresp.EventFilter,
+) ([]*models.Event, error) {
+	filterClause, filterArgs := ch.buildFilterConditions(filterOps)
+	query := `
+		SELECT
+			id, title, dateBegin, dateEnd, canVisit,
+			adress, cntTickets, creatorID, valid, status
+		FROM Events ` + filterClause + `
+		AND Events.id IN (
+			SELECT ae.eventID
+			FROM Artwork_event ae
+			JOIN Artworks a ON a.id = ae.artworkID
+			WHERE a.deletedAt IS NULL AND ` + artworkCond + `
+		)
+		ORDER BY dateBegin ASC`
+
+	rows, err := ch.db.QueryContext(ctx, query, append(filterArgs, artworkArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	events, err := ch.parseEventsRows(rows, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ch.joinArtworkIDsToEvents(ctx, events)
+}
+
+func (ch *CHEventRep) GetEventsByArtwork(ctx context.Context, artworkID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	events, err := ch.getEventsByArtworks(ctx, "a.id = ?", []interface{}{artworkID}, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetEventsByArtwork %w", err)
+	}
+	return events, nil
+}
+
+func (ch *CHEventRep) GetEventsByArtworks(ctx context.Context, artworkIDs uuid.UUIDs) ([]*models.Event, error) {
+	if len(artworkIDs) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(artworkIDs))
+	args := make([]interface{}, len(artworkIDs))
+	for i, id := range artworkIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	cond := "a.id IN (" + strings.Join(placeholders, ", ") + ")"
+	events, err := ch.getEventsByArtworks(ctx, cond, args, &jsonreqresp.EventFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetEventsByArtworks %w", err)
+	}
+	return events, nil
+}
+
+func (ch *CHEventRep) GetEventsByAuthor(ctx context.Context, authorID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	events, err := ch.getEventsByArtworks(ctx, "a.authorID = ?", []interface{}{authorID}, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetEventsByAuthor %w", err)
+	}
+	return events, nil
+}
+
+func (ch *CHEventRep) GetEventsByCollection(ctx context.Context, collectionID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	events, err := ch.getEventsByArtworks(ctx, "a.collectionID = ?", []interface{}{collectionID}, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetEventsByCollection %w", err)
+	}
+	return events, nil
+}
+
 func (ch *CHEventRep) GetCollectionsStat(ctx context.Context, eventID uuid.UUID) ([]*models.StatCollections, error) {
 	query := `
 		SELECT 
@@ -304,7 +526,7 @@ func (ch *CHEventRep) GetCollectionsStat(ctx context.Context, eventID uuid.UUID)
 		FROM Artwork_event ae
 		JOIN Artworks a ON ae.artworkID = a.id
 		JOIN Collection c ON a.collectionID = c.id
-		WHERE ae.eventID = ?
+		WHERE ae.eventID = ? AND a.deletedAt IS NULL
 		GROUP BY c.id, c.title
 		ORDER BY artwork_count DESC`
 
@@ -365,8 +587,8 @@ func (ch *CHEventRep) execChangeQuery(ctx context.Context, query string, args ..
 func (ch *CHEventRep) Add(ctx context.Context, e *models.Event) error {
 	query := `
 		INSERT INTO Events 
-		(id, title, dateBegin, dateEnd, canVisit, adress, cntTickets, creatorID, valid) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1)`
+		(id, title, dateBegin, dateEnd, canVisit, adress, cntTickets, creatorID, valid, status) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1, ?)`
 
 	canVisit := uint8(0)
 	if e.GetAccess() {
@@ -382,6 +604,7 @@ func (ch *CHEventRep) Add(ctx context.Context, e *models.Event) error {
 		e.GetAddress(),
 		e.GetTicketCount(),
 		e.GetEmployeeID(),
+		string(e.GetStatus()),
 	)
 	if err != nil {
 		return fmt.Errorf("CHEventRep.Add: %w", err)
@@ -409,16 +632,22 @@ func (ch *CHEventRep) RealDelete(ctx context.Context, id uuid.UUID) error {
 
 func (ch *CHEventRep) Update(ctx context.Context,
 	id uuid.UUID,
-	funcUpdate func(*models.Event) (*models.Event, error)) error {
+	funcUpdate func(*models.Event) (*models.Event, error),
+	change *models.Change) error {
 	event, err := ch.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("CHEventRep.Update: %v", err)
 	}
 
+	before := event.Snapshot()
 	updatedEvent, err := funcUpdate(event)
 	if err != nil {
 		return fmt.Errorf("CHEventRep.Update: %w", ErrUpdateEvent)
 	}
+	err = historyrep.CHAddChange(ctx, ch.db, models.EntityEvent, id, change, before, updatedEvent.Snapshot())
+	if err != nil {
+		return fmt.Errorf("CHEventRep.Update: %w", err)
+	}
 
 	canVisit := uint8(0)
 	if updatedEvent.GetAccess() {
@@ -472,6 +701,240 @@ func (ch *CHEventRep) DeleteArtworkFromEvent(ctx context.Context, eventID uuid.U
 	return nil
 }
 
+func (ch *CHEventRep) GetOrganiserIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
+	query := "SELECT employeeID FROM Event_organisers WHERE eventID = ?"
+	rows, err := ch.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetOrganiserIDs: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var employeeIDs uuid.UUIDs
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("CHEventRep.GetOrganiserIDs: %v", err)
+		}
+		employeeIDs = append(employeeIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetOrganiserIDs rows iteration error: %v", err)
+	}
+	return employeeIDs, nil
+}
+
+func (ch *CHEventRep) AddOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	query := "INSERT INTO Event_organisers (eventID, employeeID) VALUES (?, ?)"
+	err := ch.execChangeQuery(ctx, query, eventID, employeeID)
+	if err != nil {
+		return fmt.Errorf("CHEventRep.AddOrganiser %w", err)
+	}
+	return nil
+}
+
+func (ch *CHEventRep) DeleteOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	query := "ALTER TABLE Event_organisers DELETE WHERE eventID = ? AND employeeID = ?"
+	err := ch.execChangeQuery(ctx, query, eventID, employeeID)
+	if err != nil {
+		return fmt.Errorf("CHEventRep.DeleteOrganiser %w", ErrOrganiserNotFound)
+	}
+	return nil
+}
+
+// ChangeStatus переводит мероприятие в статус записи review и сохраняет ее в истории согласования
+func (ch *CHEventRep) ChangeStatus(ctx context.Context, review *models.EventReview) error {
+	if _, err := ch.GetByID(ctx, review.GetEventID()); err != nil {
+		return fmt.Errorf("CHEventRep.ChangeStatus: %w", err)
+	}
+	query := "ALTER TABLE Events UPDATE status = ? WHERE id = ?"
+	if err := ch.execChangeQuery(ctx, query, string(review.GetStatus()), review.GetEventID()); err != nil {
+		return fmt.Errorf("CHEventRep.ChangeStatus: %w", err)
+	}
+	query = `
+		INSERT INTO Event_reviews 
+		(id, eventID, actorID, status, comment, createdAt) 
+		VALUES (?, ?, ?, ?, ?, ?)`
+	err := ch.execChangeQuery(ctx, query,
+		review.GetID(),
+		review.GetEventID(),
+		review.GetActorID(),
+		string(review.GetStatus()),
+		review.GetComment(),
+		review.GetCreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("CHEventRep.ChangeStatus: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHEventRep) GetReviews(ctx context.Context, eventID uuid.UUID) ([]*models.EventReview, error) {
+	query := `
+		SELECT id, eventID, actorID, status, comment, createdAt
+		FROM Event_reviews
+		WHERE eventID = ?
+		ORDER BY createdAt ASC`
+	rows, err := ch.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetReviews: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var reviews []*models.EventReview
+	for rows.Next() {
+		var id, evID, actorID uuid.UUID
+		var status, comment string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &evID, &actorID, &status, &comment, &createdAt); err != nil {
+			return nil, fmt.Errorf("CHEventRep.GetReviews scan error: %v", err)
+		}
+		review, err := models.NewEventReview(id, evID, actorID, models.EventStatus(status), comment, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("CHEventRep.GetReviews: %v", err)
+		}
+		reviews = append(reviews, &review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetReviews rows iteration error: %v", err)
+	}
+	return reviews, nil
+}
+
+func (ch *CHEventRep) getTemplateArtworkIDs(ctx context.Context, templateID uuid.UUID) (uuid.UUIDs, error) {
+	query := "SELECT artworkID FROM Artwork_event_template WHERE templateID = ?"
+	rows, err := ch.db.QueryContext(ctx, query, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.getTemplateArtworkIDs: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var artworkIDs uuid.UUIDs
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("CHEventRep.getTemplateArtworkIDs: %v", err)
+		}
+		artworkIDs = append(artworkIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("CHEventRep.getTemplateArtworkIDs rows iteration error: %v", err)
+	}
+	return artworkIDs, nil
+}
+
+func (ch *CHEventRep) selectTemplates(ctx context.Context, query string, args ...interface{}) ([]*models.EventTemplate, error) {
+	rows, err := ch.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var res []*models.EventTemplate
+	for rows.Next() {
+		var id, creatorID uuid.UUID
+		var name, title, address string
+		var canVisit uint8
+		var cntTickets int32
+		if err := rows.Scan(&id, &name, &title, &canVisit, &address, &cntTickets, &creatorID); err != nil {
+			return nil, fmt.Errorf("scan error: %v", err)
+		}
+		tmpl, err := models.NewEventTemplate(id, name, title, address, canVisit == 1, creatorID, int(cntTickets), nil)
+		if err != nil {
+			return nil, fmt.Errorf("selectTemplates: %v", err)
+		}
+		res = append(res, &tmpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+
+	for _, tmpl := range res {
+		artworkIDs, err := ch.getTemplateArtworkIDs(ctx, tmpl.GetID())
+		if err != nil {
+			return nil, fmt.Errorf("join ArtworkIds %w", err)
+		}
+		if err := tmpl.AddArtworks(artworkIDs); err != nil {
+			return nil, fmt.Errorf("join ArtworkIds %w", err)
+		}
+	}
+	return res, nil
+}
+
+func (ch *CHEventRep) GetTemplates(ctx context.Context) ([]*models.EventTemplate, error) {
+	query := `
+		SELECT id, name, title, canVisit, adress, cntTickets, creatorID
+		FROM Event_templates
+		ORDER BY name`
+	res, err := ch.selectTemplates(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetTemplates %w", err)
+	}
+	return res, nil
+}
+
+func (ch *CHEventRep) GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.EventTemplate, error) {
+	query := `
+		SELECT id, name, title, canVisit, adress, cntTickets, creatorID
+		FROM Event_templates
+		WHERE id = ?`
+	res, err := ch.selectTemplates(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("CHEventRep.GetTemplateByID %w", err)
+	}
+	if len(res) == 0 {
+		return nil, ErrEventTemplateNotFound
+	}
+	return res[0], nil
+}
+
+func (ch *CHEventRep) AddTemplate(ctx context.Context, t *models.EventTemplate) error {
+	query := `
+		INSERT INTO Event_templates
+		(id, name, title, canVisit, adress, cntTickets, creatorID)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	canVisit := uint8(0)
+	if t.GetAccess() {
+		canVisit = 1
+	}
+
+	err := ch.execChangeQuery(ctx, query,
+		t.GetID(),
+		t.GetName(),
+		t.GetTitle(),
+		canVisit,
+		t.GetAddress(),
+		t.GetTicketCount(),
+		t.GetEmployeeID(),
+	)
+	if err != nil {
+		return fmt.Errorf("CHEventRep.AddTemplate: %w", err)
+	}
+	for _, artworkID := range t.GetArtworkIDs() {
+		query := "INSERT INTO Artwork_event_template (templateID, artworkID) VALUES (?, ?)"
+		err := ch.execChangeQuery(ctx, query, t.GetID(), artworkID)
+		if err != nil {
+			return fmt.Errorf("CHEventRep.AddTemplate: %w", err)
+		}
+	}
+	return nil
+}
+
+func (ch *CHEventRep) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if _, err := ch.GetTemplateByID(ctx, id); err != nil {
+		return fmt.Errorf("CHEventRep.DeleteTemplate: %w", err)
+	}
+	query := "ALTER TABLE Event_templates DELETE WHERE id = ?"
+	if err := ch.execChangeQuery(ctx, query, id); err != nil {
+		return fmt.Errorf("CHEventRep.DeleteTemplate: %w", err)
+	}
+	query = "ALTER TABLE Artwork_event_template DELETE WHERE templateID = ?"
+	if err := ch.execChangeQuery(ctx, query, id); err != nil {
+		return fmt.Errorf("CHEventRep.DeleteTemplate: %w", err)
+	}
+	return nil
+}
+
 func (ch *CHEventRep) Ping(ctx context.Context) error {
 	return ch.db.PingContext(ctx)
 }
@@ -23,6 +23,51 @@ func (m *MockEventRep) GetAll(ctx context.Context, filterOps *jsonreqresp.EventF
 	return args.Get(0).([]*models.Event), args.Error(1)
 }
 
+func (m *MockEventRep) GetPage(
+	ctx context.Context,
+	filterOps *jsonreqresp.EventFilter,
+	sortOps *jsonreqresp.EventSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Event, jsonreqresp.PageInfo, error) {
+	args := m.Called(ctx, filterOps, sortOps, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(jsonreqresp.PageInfo), args.Error(2)
+	}
+	return args.Get(0).([]*models.Event), args.Get(1).(jsonreqresp.PageInfo), args.Error(2)
+}
+
+func (m *MockEventRep) GetEventsByArtwork(ctx context.Context, artworkID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	args := m.Called(ctx, artworkID, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockEventRep) GetEventsByAuthor(ctx context.Context, authorID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	args := m.Called(ctx, authorID, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockEventRep) GetEventsByCollection(ctx context.Context, collectionID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	args := m.Called(ctx, collectionID, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockEventRep) GetEventsByArtworks(ctx context.Context, artworkIDs uuid.UUIDs) ([]*models.Event, error) {
+	args := m.Called(ctx, artworkIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
 func (m *MockEventRep) GetArtworkIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
 	args := m.Called(ctx, eventID)
 	if args.Get(0) == nil {
@@ -75,8 +120,10 @@ func (m *MockEventRep) RealDelete(ctx context.Context, eventID uuid.UUID) error
 	return args.Error(0)
 }
 
-func (m *MockEventRep) Update(ctx context.Context, eventID uuid.UUID, funcUpdate func(*models.Event) (*models.Event, error)) error {
-	args := m.Called(ctx, eventID, funcUpdate)
+func (m *MockEventRep) Update(
+	ctx context.Context, eventID uuid.UUID, funcUpdate func(*models.Event) (*models.Event, error), change *models.Change,
+) error {
+	args := m.Called(ctx, eventID, funcUpdate, change)
 	return args.Error(0)
 }
 
@@ -90,6 +137,63 @@ func (m *MockEventRep) DeleteArtworkFromEvent(ctx context.Context, eventID uuid.
 	return args.Error(0)
 }
 
+func (m *MockEventRep) ChangeStatus(ctx context.Context, review *models.EventReview) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockEventRep) GetReviews(ctx context.Context, eventID uuid.UUID) ([]*models.EventReview, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.EventReview), args.Error(1)
+}
+
+func (m *MockEventRep) GetOrganiserIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(uuid.UUIDs), args.Error(1)
+}
+
+func (m *MockEventRep) AddOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	args := m.Called(ctx, eventID, employeeID)
+	return args.Error(0)
+}
+
+func (m *MockEventRep) DeleteOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	args := m.Called(ctx, eventID, employeeID)
+	return args.Error(0)
+}
+
+func (m *MockEventRep) GetTemplates(ctx context.Context) ([]*models.EventTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.EventTemplate), args.Error(1)
+}
+
+func (m *MockEventRep) GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.EventTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EventTemplate), args.Error(1)
+}
+
+func (m *MockEventRep) AddTemplate(ctx context.Context, t *models.EventTemplate) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockEventRep) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockEventRep) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
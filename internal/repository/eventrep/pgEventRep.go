@@ -12,6 +12,7 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -65,22 +66,34 @@ func NewPgEventRep(ctx context.Context, pgCreds *cnfg.DatebaseCredentials, dbCon
 	return pgInstance, nil
 }
 
-func (pg *PgEventRep) parseEventsRows(rows *sql.Rows) ([]*models.Event, error) {
+// parseEventsRows разбирает строки выборки мероприятий.
+// Если sortKeys не nil, последним столбцом ожидается ключ сортировки для курсора страницы.
+func (pg *PgEventRep) parseEventsRows(rows *sql.Rows, sortKeys *[]string) ([]*models.Event, error) {
 	var resEvents []*models.Event
 	for rows.Next() {
 		var id, creatorID uuid.UUID
-		var title, address string
+		var title, address, status, sortKey string
 		var dateBegin, dateEnd time.Time
 		var canVisit, valid bool
 		var cntTickets int
-		if err := rows.Scan(&id, &title, &dateBegin, &dateEnd, &canVisit, &address, &cntTickets, &creatorID, &valid); err != nil {
+		dest := []any{&id, &title, &dateBegin, &dateEnd, &canVisit, &address, &cntTickets, &creatorID, &valid, &status}
+		if sortKeys != nil {
+			dest = append(dest, &sortKey)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("scan error: %v", err)
 		}
 		user, err := models.NewEvent(id, title, dateBegin, dateEnd, address, canVisit, creatorID, cntTickets, valid, nil)
 		if err != nil {
 			return nil, fmt.Errorf("parseEventsRows: %v", err)
 		}
+		if err := user.SetStatus(models.EventStatus(status)); err != nil {
+			return nil, fmt.Errorf("parseEventsRows: %v", err)
+		}
 		resEvents = append(resEvents, &user)
+		if sortKeys != nil {
+			*sortKeys = append(*sortKeys, sortKey)
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %v", err)
@@ -113,10 +126,65 @@ func (pg *PgEventRep) addFilterParams(query sq.SelectBuilder, filterOps *jsonreq
 		query = query.Where(sq.Eq{"events.canVisit": canVisit})
 	}
 
+	if filterOps.Status != "" {
+		query = query.Where(sq.Eq{"events.status": filterOps.Status})
+	}
+
 	query = query.Where(sq.Eq{"events.Valid": true})
 	return query
 }
 
+func (pg *PgEventRep) sortExpr(field string) string {
+	switch field {
+	case jsonreqresp.TitleSortFieldEvent:
+		return "events.title"
+	case jsonreqresp.DateBeginSortFieldEvent:
+		return "events.dateBegin"
+	case jsonreqresp.DateEndSortFieldEvent:
+		return "events.dateEnd"
+	case jsonreqresp.TicketsAvailableSortFieldEvent:
+		return "(COALESCE(events.cntTickets, 0) - COALESCE(tp.sold, 0))"
+	}
+	return ""
+}
+
+// addPageParams добавляет к запросу условие keyset-пагинации и порядок (поле сортировки, id)
+func (pg *PgEventRep) addPageParams(
+	query sq.SelectBuilder, sortOps *jsonreqresp.EventSortOps, cursor *jsonreqresp.PageCursor, limit int,
+) (sq.SelectBuilder, error) {
+	expr := pg.sortExpr(sortOps.Field)
+	direction := jsonreqresp.NormSortDirection(sortOps.Direction)
+	op := ">"
+	if direction == jsonreqresp.DESCDirection {
+		op = "<"
+	}
+
+	if cursor != nil {
+		var value any = cursor.Value
+		var err error
+		switch sortOps.Field {
+		case jsonreqresp.DateBeginSortFieldEvent, jsonreqresp.DateEndSortFieldEvent:
+			value, err = cursor.TimeValue()
+		case jsonreqresp.TicketsAvailableSortFieldEvent:
+			value, err = cursor.IntValue()
+		}
+		if err != nil {
+			return query, err
+		}
+
+		if expr == "" {
+			query = query.Where(sq.Expr("events.id "+op+" ?", cursor.ID))
+		} else {
+			query = query.Where(sq.Expr("("+expr+", events.id) "+op+" (?, ?)", value, cursor.ID))
+		}
+	}
+
+	if expr != "" {
+		query = query.OrderBy(expr + " " + direction)
+	}
+	return query.OrderBy("events.id " + direction).Limit(uint64(limit + 1)), nil
+}
+
 func (pg *PgEventRep) execQuery(ctx context.Context, query sq.SelectBuilder) ([]*models.Event, error) {
 	querySQL, args, err := query.ToSql()
 	if err != nil {
@@ -129,7 +197,7 @@ func (pg *PgEventRep) execQuery(ctx context.Context, query sq.SelectBuilder) ([]
 	}
 	defer rows.Close()
 
-	events, err := pg.parseEventsRows(rows)
+	events, err := pg.parseEventsRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
@@ -138,9 +206,11 @@ func (pg *PgEventRep) execQuery(ctx context.Context, query sq.SelectBuilder) ([]
 
 func (pg *PgEventRep) GetArtworkIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query, args, err := psql.Select("artworkID").
-		From("Artwork_event").
-		Where(sq.Eq{"eventID": eventID}).
+	query, args, err := psql.Select("ae.artworkID").
+		From("Artwork_event ae").
+		Join("Artworks a ON a.id = ae.artworkID").
+		Where(sq.Eq{"ae.eventID": eventID}).
+		Where(sq.Eq{"a.deletedAt": nil}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
@@ -183,7 +253,7 @@ func (pg *PgEventRep) GetAll(ctx context.Context, filterOps *jsonreqresp.EventFi
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	query := psql.Select(
 		"events.id", "events.title", "events.dateBegin", "events.dateEnd", "events.canVisit",
-		"events.adress", "events.cntTickets", "events.creatorID", "events.valid").
+		"events.adress", "events.cntTickets", "events.creatorID", "events.valid", "events.status").
 		From("events")
 
 	query = pg.addFilterParams(query, filterOps)
@@ -200,11 +270,79 @@ func (pg *PgEventRep) GetAll(ctx context.Context, filterOps *jsonreqresp.EventFi
 	return events, nil
 }
 
+func (pg *PgEventRep) GetPage(
+	ctx context.Context,
+	filterOps *jsonreqresp.EventFilter,
+	sortOps *jsonreqresp.EventSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Event, jsonreqresp.PageInfo, error) {
+	if sortOps == nil {
+		sortOps = &jsonreqresp.EventSortOps{}
+	}
+	limit := page.PageLimit()
+	pageInfo := jsonreqresp.PageInfo{Limit: limit, HasTotal: true}
+	cursor, err := page.DecodeCursor()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	countQuery := pg.addFilterParams(psql.Select("COUNT(*)").From("events"), filterOps)
+	countSQL, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w: %v", ErrQueryBuilds, err)
+	}
+	if err := pg.db.QueryRowContext(ctx, countSQL, countArgs...).Scan(&pageInfo.Total); err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w: %v", ErrQueryExec, err)
+	}
+
+	keyExpr := "''"
+	if expr := pg.sortExpr(sortOps.Field); expr != "" {
+		keyExpr = "CAST(" + expr + " AS text)"
+	}
+	query := psql.Select(
+		"events.id", "events.title", "events.dateBegin", "events.dateEnd", "events.canVisit",
+		"events.adress", "events.cntTickets", "events.creatorID", "events.valid", "events.status", keyExpr).
+		From("events").
+		LeftJoin("(SELECT eventID, COUNT(*) AS sold FROM TicketPurchases GROUP BY eventID) tp ON tp.eventID = events.id")
+	query = pg.addFilterParams(query, filterOps)
+	query, err = pg.addPageParams(query, sortOps, cursor, limit)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w", err)
+	}
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var sortKeys []string
+	events, err := pg.parseEventsRows(rows, &sortKeys)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w", err)
+	}
+	if len(events) > limit {
+		events = events[:limit]
+		pageInfo.NextCursor = jsonreqresp.EncodeCursor(sortKeys[limit-1], events[limit-1].GetID())
+	}
+
+	events, err = pg.joinArtworkIDsToEvents(ctx, events)
+	if err != nil {
+		return nil, pageInfo, fmt.Errorf("PgEventRep.GetPage %w", err)
+	}
+	return events, pageInfo, nil
+}
+
 func (pg *PgEventRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Event, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	query := psql.Select(
 		"events.id", "events.title", "events.dateBegin", "events.dateEnd", "events.canVisit",
-		"events.adress", "events.cntTickets", "events.creatorID", "events.valid").
+		"events.adress", "events.cntTickets", "events.creatorID", "events.valid", "events.status").
 		From("events").
 		Where(sq.Eq{"id": id})
 
@@ -236,7 +374,7 @@ func (pg *PgEventRep) GetEventsOfArtworkOnDate(ctx context.Context, artworkID uu
 		formatTime(dateBeg),
 		formatTime(dateEnd),
 	))
-	query, args, err := psql.Select("event_id", "title", "dateBegin", "dateEnd", "canVisit", "adress", "cntTickets", "creatorID", "valid").
+	query, args, err := psql.Select("event_id", "title", "dateBegin", "dateEnd", "canVisit", "adress", "cntTickets", "creatorID", "valid", "status").
 		From(funcCall).
 		ToSql()
 	if err != nil {
@@ -249,7 +387,7 @@ func (pg *PgEventRep) GetEventsOfArtworkOnDate(ctx context.Context, artworkID uu
 	}
 	defer rows.Close()
 
-	events, err := pg.parseEventsRows(rows)
+	events, err := pg.parseEventsRows(rows, nil)
 	if err != nil {
 		return nil, fmt.Errorf("PgEventRep.GetEventsOfArtworkOnDate: %v", err)
 	}
@@ -268,6 +406,65 @@ func (pg *PgEventRep) GetEventsOfArtworkOnDate(ctx context.Context, artworkID uu
 	return events, nil
 }
 
+// getEventsByArtworks возвращает мероприятия, в которых участвуют произведения, подходящие под artworkCond
+func (pg *PgEventRep) getEventsByArtworks(
+	ctx context.Context, artworkCond sq.Sqlizer, filterOps *jsonreqresp.EventFilter,
+) ([]*models.Event, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	eventIDsSubQuery := sq.Select("ae.eventID").
+		From("Artwork_event ae").
+		Join("Artworks a ON a.id = ae.artworkID").
+		Where(artworkCond).
+		Where(sq.Eq{"a.deletedAt": nil})
+	query := psql.Select(
+		"events.id", "events.title", "events.dateBegin", "events.dateEnd", "events.canVisit",
+		"events.adress", "events.cntTickets", "events.creatorID", "events.valid", "events.status").
+		From("events").
+		Where(sq.Expr("events.id IN (?)", eventIDsSubQuery))
+	query = pg.addFilterParams(query, filterOps).OrderBy("events.dateBegin ASC")
+
+	events, err := pg.execQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return pg.joinArtworkIDsToEvents(ctx, events)
+}
+
+func (pg *PgEventRep) GetEventsByArtwork(ctx context.Context, artworkID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	events, err := pg.getEventsByArtworks(ctx, sq.Eq{"a.id": artworkID}, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetEventsByArtwork %w", err)
+	}
+	return events, nil
+}
+
+func (pg *PgEventRep) GetEventsByAuthor(ctx context.Context, authorID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	events, err := pg.getEventsByArtworks(ctx, sq.Eq{"a.authorID": authorID}, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetEventsByAuthor %w", err)
+	}
+	return events, nil
+}
+
+func (pg *PgEventRep) GetEventsByCollection(ctx context.Context, collectionID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	events, err := pg.getEventsByArtworks(ctx, sq.Eq{"a.collectionID": collectionID}, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetEventsByCollection %w", err)
+	}
+	return events, nil
+}
+
+func (pg *PgEventRep) GetEventsByArtworks(ctx context.Context, artworkIDs uuid.UUIDs) ([]*models.Event, error) {
+	if len(artworkIDs) == 0 {
+		return nil, nil
+	}
+	events, err := pg.getEventsByArtworks(ctx, sq.Eq{"a.id": artworkIDs}, &jsonreqresp.EventFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetEventsByArtworks %w", err)
+	}
+	return events, nil
+}
+
 func (pg *PgEventRep) GetCollectionsStat(ctx context.Context, eventID uuid.UUID) ([]*models.StatCollections, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
@@ -345,8 +542,8 @@ func (pg *PgEventRep) Add(ctx context.Context, e *models.Event) error {
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	query := psql.Insert("Events").
-		Columns("id", "title", "dateBegin", "dateEnd", "canVisit", "adress", "cntTickets", "creatorID").
-		Values(e.GetID(), e.GetTitle(), e.GetDateBegin(), e.GetDateEnd(), e.GetAccess(), e.GetAddress(), e.GetTicketCount(), e.GetEmployeeID())
+		Columns("id", "title", "dateBegin", "dateEnd", "canVisit", "adress", "cntTickets", "creatorID", "status").
+		Values(e.GetID(), e.GetTitle(), e.GetDateBegin(), e.GetDateEnd(), e.GetAccess(), e.GetAddress(), e.GetTicketCount(), e.GetEmployeeID(), string(e.GetStatus()))
 	err := pg.execChangeQuery(ctx, query)
 	if err != nil {
 		return fmt.Errorf("PgEventRep.Add: %w", err)
@@ -379,13 +576,15 @@ func (pg *PgEventRep) RealDelete(ctx context.Context, id uuid.UUID) error {
 
 func (pg *PgEventRep) Update(ctx context.Context,
 	id uuid.UUID,
-	funcUpdate func(*models.Event) (*models.Event, error)) error {
+	funcUpdate func(*models.Event) (*models.Event, error),
+	change *models.Change) error {
 	event, err := pg.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("PgEventRep.Update: %v", err)
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	before := event.Snapshot()
 	updatedEvent, err := funcUpdate(event)
 	if err != nil {
 		return fmt.Errorf("PgEventRep.Update: %w", ErrUpdateEvent)
@@ -394,15 +593,40 @@ func (pg *PgEventRep) Update(ctx context.Context,
 	query := psql.Update("Events").
 		Set("title", updatedEvent.GetTitle()).
 		Set("dateBegin", updatedEvent.GetDateBegin()).
+		Set("dateEnd", updatedEvent.GetDateEnd()).
 		Set("canVisit", updatedEvent.GetAccess()).
 		Set("adress", updatedEvent.GetAddress()).
 		Set("cntTickets", updatedEvent.GetTicketCount()).
 		Set("creatorID", updatedEvent.GetEmployeeID()).
 		// Set("valid", updatedEvent.IsValid()).
 		Where(sq.Eq{"id": id})
-	err = pg.execChangeQuery(ctx, query)
+	querySQL, args, err := query.ToSql()
 	if err != nil {
-		return fmt.Errorf("PgEventRep.Update %w", err)
+		return fmt.Errorf("PgEventRep.Update: %w: %v", ErrQueryBuilds, err)
+	}
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.Update: %w: %v", ErrQueryExec, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgEventRep.Update: %w: %v", ErrRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("PgEventRep.Update: %w", ErrRowsAffected)
+	}
+	err = historyrep.PgAddChange(ctx, tx, models.EntityEvent, id, change, before, updatedEvent.Snapshot())
+	if err != nil {
+		return fmt.Errorf("PgEventRep.Update: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgEventRep.Update: %w: %v", ErrQueryExec, err)
 	}
 	return nil
 }
@@ -436,6 +660,293 @@ func (pg *PgEventRep) DeleteArtworkFromEvent(ctx context.Context, eventID uuid.U
 	return nil
 }
 
+func (pg *PgEventRep) GetOrganiserIDs(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("employeeID").
+		From("Event_organisers").
+		Where(sq.Eq{"eventID": eventID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetOrganiserIDs %w: %v", ErrQueryBuilds, err)
+	}
+
+	rows, err := pg.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetOrganiserIDs %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var employeeIDs uuid.UUIDs
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("PgEventRep.GetOrganiserIDs: %v", err)
+		}
+		employeeIDs = append(employeeIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetOrganiserIDs rows iteration error: %v", err)
+	}
+	return employeeIDs, nil
+}
+
+func (pg *PgEventRep) AddOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Event_organisers").
+		Columns("eventID", "employeeID").
+		Values(eventID, employeeID)
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.AddOrganiser %w", err)
+	}
+	return nil
+}
+
+func (pg *PgEventRep) DeleteOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Delete("Event_organisers").
+		Where(sq.And{
+			sq.Eq{"eventID": eventID},
+			sq.Eq{"employeeID": employeeID},
+		})
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.DeleteOrganiser %w", ErrOrganiserNotFound)
+	}
+	return nil
+}
+
+// ChangeStatus переводит мероприятие в статус записи review и сохраняет ее в истории согласования
+func (pg *PgEventRep) ChangeStatus(ctx context.Context, review *models.EventReview) error {
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Update("Events").
+		Set("status", string(review.GetStatus())).
+		Where(sq.Eq{"id": review.GetEventID()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrQueryBuilds, err)
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrQueryExec, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w", ErrEventNotFound)
+	}
+
+	query, args, err = psql.Insert("Event_reviews").
+		Columns("id", "eventID", "actorID", "status", "comment", "createdAt").
+		Values(review.GetID(), review.GetEventID(), review.GetActorID(), string(review.GetStatus()), review.GetComment(), review.GetCreatedAt()).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrQueryExec, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgEventRep.ChangeStatus: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgEventRep) GetReviews(ctx context.Context, eventID uuid.UUID) ([]*models.EventReview, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("id", "eventID", "actorID", "status", "comment", "createdAt").
+		From("Event_reviews").
+		Where(sq.Eq{"eventID": eventID}).
+		OrderBy("createdAt ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetReviews %w: %v", ErrQueryBuilds, err)
+	}
+
+	rows, err := pg.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetReviews %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var reviews []*models.EventReview
+	for rows.Next() {
+		var id, evID, actorID uuid.UUID
+		var status, comment string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &evID, &actorID, &status, &comment, &createdAt); err != nil {
+			return nil, fmt.Errorf("PgEventRep.GetReviews scan error: %v", err)
+		}
+		review, err := models.NewEventReview(id, evID, actorID, models.EventStatus(status), comment, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("PgEventRep.GetReviews: %v", err)
+		}
+		reviews = append(reviews, &review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetReviews rows iteration error: %v", err)
+	}
+	return reviews, nil
+}
+
+func (pg *PgEventRep) getTemplateArtworkIDs(ctx context.Context, templateID uuid.UUID) (uuid.UUIDs, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("artworkID").
+		From("Artwork_event_template").
+		Where(sq.Eq{"templateID": templateID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+
+	rows, err := pg.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var artworkIDs uuid.UUIDs
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("PgEventRep.getTemplateArtworkIDs: %v", err)
+		}
+		artworkIDs = append(artworkIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PgEventRep.getTemplateArtworkIDs rows iteration error: %v", err)
+	}
+	return artworkIDs, nil
+}
+
+func (pg *PgEventRep) selectTemplates(ctx context.Context, query sq.SelectBuilder) ([]*models.EventTemplate, error) {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var res []*models.EventTemplate
+	for rows.Next() {
+		var id, creatorID uuid.UUID
+		var name, title, address string
+		var canVisit bool
+		var cntTickets int
+		if err := rows.Scan(&id, &name, &title, &canVisit, &address, &cntTickets, &creatorID); err != nil {
+			return nil, fmt.Errorf("scan error: %v", err)
+		}
+		tmpl, err := models.NewEventTemplate(id, name, title, address, canVisit, creatorID, cntTickets, nil)
+		if err != nil {
+			return nil, fmt.Errorf("selectTemplates: %v", err)
+		}
+		res = append(res, &tmpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+
+	for _, tmpl := range res {
+		artworkIDs, err := pg.getTemplateArtworkIDs(ctx, tmpl.GetID())
+		if err != nil {
+			return nil, fmt.Errorf("join ArtworkIds %w", err)
+		}
+		if err := tmpl.AddArtworks(artworkIDs); err != nil {
+			return nil, fmt.Errorf("join ArtworkIds %w", err)
+		}
+	}
+	return res, nil
+}
+
+func (pg *PgEventRep) GetTemplates(ctx context.Context) ([]*models.EventTemplate, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select("id", "name", "title", "canVisit", "adress", "cntTickets", "creatorID").
+		From("Event_templates").
+		OrderBy("name")
+	res, err := pg.selectTemplates(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetTemplates %w", err)
+	}
+	return res, nil
+}
+
+func (pg *PgEventRep) GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.EventTemplate, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select("id", "name", "title", "canVisit", "adress", "cntTickets", "creatorID").
+		From("Event_templates").
+		Where(sq.Eq{"id": id})
+	res, err := pg.selectTemplates(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgEventRep.GetTemplateByID %w", err)
+	}
+	if len(res) == 0 {
+		return nil, ErrEventTemplateNotFound
+	}
+	return res[0], nil
+}
+
+func (pg *PgEventRep) AddTemplate(ctx context.Context, t *models.EventTemplate) error {
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgEventRep.AddTemplate: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Insert("Event_templates").
+		Columns("id", "name", "title", "canVisit", "adress", "cntTickets", "creatorID").
+		Values(t.GetID(), t.GetName(), t.GetTitle(), t.GetAccess(), t.GetAddress(), t.GetTicketCount(), t.GetEmployeeID()).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgEventRep.AddTemplate: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("PgEventRep.AddTemplate: %w: %v", ErrQueryExec, err)
+	}
+
+	for _, artworkID := range t.GetArtworkIDs() {
+		query, args, err := psql.Insert("Artwork_event_template").
+			Columns("templateID", "artworkID").
+			Values(t.GetID(), artworkID).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("PgEventRep.AddTemplate: %w: %v", ErrQueryBuilds, err)
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("PgEventRep.AddTemplate: %w: %v", ErrQueryExec, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgEventRep.AddTemplate: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgEventRep) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Delete("Event_templates").
+		Where(sq.Eq{"id": id})
+	err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		if errors.Is(err, ErrRowsAffected) {
+			return fmt.Errorf("PgEventRep.DeleteTemplate: %w", ErrEventTemplateNotFound)
+		}
+		return fmt.Errorf("PgEventRep.DeleteTemplate: %w", err)
+	}
+	return nil
+}
+
 func (pg *PgEventRep) Ping(ctx context.Context) error {
 	return pg.db.PingContext(ctx)
 }
@@ -416,7 +416,7 @@ func TestEventRep_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			event := th.createAndAddEvent(t, 1)
 
-			err := th.erep.Update(th.ctx, event.GetID(), tt.updateFunc)
+			err := th.erep.Update(th.ctx, event.GetID(), tt.updateFunc, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -455,4 +455,226 @@ func TestEventRep_ArtworkOperations(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotContains(t, got.GetArtworkIDs(), artworkID)
 	})
+
+	t.Run("Artwork in trash is hidden from event", func(t *testing.T) {
+		trashed, _, _ := th.createAndAddArtwork(t, 2)
+		require.NoError(t, th.arep.Delete(th.ctx, trashed.GetID()))
+		err := th.erep.AddArtworksToEvent(th.ctx, event.GetID(), uuid.UUIDs{artworkID, trashed.GetID()})
+		require.NoError(t, err)
+
+		ids, err := th.erep.GetArtworkIDs(th.ctx, event.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, uuid.UUIDs{artworkID}, ids)
+		got, err := th.erep.GetByID(th.ctx, event.GetID())
+		require.NoError(t, err)
+		assert.NotContains(t, got.GetArtworkIDs(), trashed.GetID())
+	})
+}
+
+func TestEventRep_OrganiserOperations(t *testing.T) {
+	th := setupTestHelper(t)
+	event := th.createAndAddEvent(t, 1)
+
+	t.Run("Add organiser", func(t *testing.T) {
+		err := th.erep.AddOrganiser(th.ctx, event.GetID(), th.employeeID)
+		require.NoError(t, err)
+
+		got, err := th.erep.GetOrganiserIDs(th.ctx, event.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, uuid.UUIDs{th.employeeID}, got)
+	})
+
+	t.Run("Delete organiser", func(t *testing.T) {
+		err := th.erep.DeleteOrganiser(th.ctx, event.GetID(), th.employeeID)
+		require.NoError(t, err)
+
+		got, err := th.erep.GetOrganiserIDs(th.ctx, event.GetID())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		err = th.erep.DeleteOrganiser(th.ctx, event.GetID(), th.employeeID)
+		assert.ErrorIs(t, err, eventrep.ErrOrganiserNotFound)
+	})
+}
+
+func TestEventRep_ReviewOperations(t *testing.T) {
+	th := setupTestHelper(t)
+	event := th.createAndAddEvent(t, 1)
+
+	got, err := th.erep.GetByID(th.ctx, event.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, models.EventStatusDraft, got.GetStatus())
+
+	review, err := models.NewEventReview(
+		uuid.New(), event.GetID(), th.employeeID, models.EventStatusPending, "check please",
+		time.Now().UTC().Truncate(time.Microsecond))
+	require.NoError(t, err)
+	require.NoError(t, th.erep.ChangeStatus(th.ctx, &review))
+
+	got, err = th.erep.GetByID(th.ctx, event.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, models.EventStatusPending, got.GetStatus())
+
+	pending, err := th.erep.GetAll(th.ctx, &jsonreqresp.EventFilter{Status: string(models.EventStatusPending)})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, event.GetID(), pending[0].GetID())
+
+	reviews, err := th.erep.GetReviews(th.ctx, event.GetID())
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "check please", reviews[0].GetComment())
+	assert.Equal(t, th.employeeID, reviews[0].GetActorID())
+
+	missing, err := models.NewEventReview(
+		uuid.New(), uuid.New(), th.employeeID, models.EventStatusApproved, "", time.Now())
+	require.NoError(t, err)
+	err = th.erep.ChangeStatus(th.ctx, &missing)
+	assert.ErrorIs(t, err, eventrep.ErrEventNotFound)
+}
+
+// Похожие произведения учитывают только согласованные мероприятия
+func TestEventRep_SimilarArtworksSharedEvents(t *testing.T) {
+	th := setupTestHelper(t)
+	event := th.createAndAddEvent(t, 1)
+	first, _, _ := th.createAndAddArtwork(t, 1)
+	second, _, _ := th.createAndAddArtwork(t, 2)
+	require.NoError(t, th.erep.AddArtworksToEvent(th.ctx, event.GetID(), uuid.UUIDs{first.GetID(), second.GetID()}))
+
+	sharedEvents := func() int {
+		candidates, err := th.arep.GetSimilarCandidates(th.ctx, first.GetID(), 10)
+		require.NoError(t, err)
+		for _, c := range candidates {
+			if c.ArtworkID == second.GetID() {
+				return c.SharedEvents
+			}
+		}
+		return 0
+	}
+	assert.Equal(t, 0, sharedEvents())
+
+	review, err := models.NewEventReview(
+		uuid.New(), event.GetID(), th.employeeID, models.EventStatusApproved, "",
+		time.Now().UTC().Truncate(time.Microsecond))
+	require.NoError(t, err)
+	require.NoError(t, th.erep.ChangeStatus(th.ctx, &review))
+	assert.Equal(t, 1, sharedEvents())
+}
+
+func TestEventRep_CollectionStatsExhibitions(t *testing.T) {
+	th := setupTestHelper(t)
+	draft := th.createAndAddEvent(t, 1)
+	approved := th.createAndAddEvent(t, 2)
+	art, _, collection := th.createAndAddArtwork(t, 1)
+	require.NoError(t, th.erep.AddArtworksToEvent(th.ctx, draft.GetID(), uuid.UUIDs{art.GetID()}))
+	require.NoError(t, th.erep.AddArtworksToEvent(th.ctx, approved.GetID(), uuid.UUIDs{art.GetID()}))
+
+	stats, err := th.colRep.GetCollectionStats(th.ctx, uuid.UUIDs{collection.GetID()})
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.ExhibitedArtworkCount)
+	assert.Empty(t, stats.Exhibitions)
+
+	review, err := models.NewEventReview(
+		uuid.New(), approved.GetID(), th.employeeID, models.EventStatusApproved, "",
+		time.Now().UTC().Truncate(time.Microsecond))
+	require.NoError(t, err)
+	require.NoError(t, th.erep.ChangeStatus(th.ctx, &review))
+
+	stats, err = th.colRep.GetCollectionStats(th.ctx, uuid.UUIDs{collection.GetID()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.ExhibitedArtworkCount)
+	require.Len(t, stats.Exhibitions, 1)
+	assert.Equal(t, approved.GetTitle(), stats.Exhibitions[0].Label)
+}
+
+func TestEventRep_TemplateOperations(t *testing.T) {
+	th := setupTestHelper(t)
+	event := th.createAndAddEvent(t, 1)
+	art, _, _ := th.createAndAddArtwork(t, 1)
+	err := th.erep.AddArtworksToEvent(th.ctx, event.GetID(), uuid.UUIDs{art.GetID()})
+	require.NoError(t, err)
+	event, err = th.erep.GetByID(th.ctx, event.GetID())
+	require.NoError(t, err)
+
+	tmpl, err := models.NewEventTemplateFromEvent(uuid.New(), "Template 1", th.employeeID, event)
+	require.NoError(t, err)
+
+	t.Run("Add template", func(t *testing.T) {
+		err := th.erep.AddTemplate(th.ctx, &tmpl)
+		require.NoError(t, err)
+
+		got, err := th.erep.GetTemplateByID(th.ctx, tmpl.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, tmpl.GetName(), got.GetName())
+		assert.Equal(t, event.GetTitle(), got.GetTitle())
+		assert.Equal(t, uuid.UUIDs{art.GetID()}, got.GetArtworkIDs())
+
+		all, err := th.erep.GetTemplates(th.ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("Delete template", func(t *testing.T) {
+		err := th.erep.DeleteTemplate(th.ctx, tmpl.GetID())
+		require.NoError(t, err)
+
+		_, err = th.erep.GetTemplateByID(th.ctx, tmpl.GetID())
+		assert.ErrorIs(t, err, eventrep.ErrEventTemplateNotFound)
+
+		err = th.erep.DeleteTemplate(th.ctx, tmpl.GetID())
+		assert.ErrorIs(t, err, eventrep.ErrEventTemplateNotFound)
+	})
+}
+
+func TestEventRep_EventsHistory(t *testing.T) {
+	th := setupTestHelper(t)
+	event := th.createAndAddEvent(t, 1)
+	art, author, collection := th.createAndAddArtwork(t, 1)
+	otherArt, _, _ := th.createAndAddArtwork(t, 2)
+	err := th.erep.AddArtworksToEvent(th.ctx, event.GetID(), uuid.UUIDs{art.GetID()})
+	require.NoError(t, err)
+
+	filter := &jsonreqresp.EventFilter{}
+
+	t.Run("By artwork", func(t *testing.T) {
+		events, err := th.erep.GetEventsByArtwork(th.ctx, art.GetID(), filter)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, event.GetID(), events[0].GetID())
+
+		events, err = th.erep.GetEventsByArtwork(th.ctx, otherArt.GetID(), filter)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("By author and collection", func(t *testing.T) {
+		events, err := th.erep.GetEventsByAuthor(th.ctx, author.GetID(), filter)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+
+		events, err = th.erep.GetEventsByCollection(th.ctx, collection.GetID(), filter)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("By artwork list", func(t *testing.T) {
+		events, err := th.erep.GetEventsByArtworks(th.ctx, uuid.UUIDs{art.GetID(), otherArt.GetID()})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Contains(t, events[0].GetArtworkIDs(), art.GetID())
+
+		events, err = th.erep.GetEventsByArtworks(th.ctx, uuid.UUIDs{})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Out of date range", func(t *testing.T) {
+		rangeFilter := &jsonreqresp.EventFilter{
+			DateBegin: event.GetDateEnd().AddDate(1, 0, 0),
+			DateEnd:   event.GetDateEnd().AddDate(2, 0, 0),
+		}
+		events, err := th.erep.GetEventsByArtwork(th.ctx, art.GetID(), rangeFilter)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
 }
@@ -0,0 +1,134 @@
+package historyrep
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/google/uuid"
+)
+
+type CHHistoryRep struct {
+	db *sql.DB
+}
+
+var (
+	chInstance *CHHistoryRep
+	chOnce     sync.Once
+)
+
+func NewCHHistoryRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, dbConf *cnfg.DatebaseConfig) (*CHHistoryRep, error) {
+	var resErr error
+	chOnce.Do(func() {
+		conn := clickhouse.OpenDB(&clickhouse.Options{
+			Addr: []string{fmt.Sprintf("%s:%d", chCreds.Host, chCreds.Port)},
+			Auth: clickhouse.Auth{
+				Database: chCreds.DbName,
+				Username: chCreds.Username,
+				Password: chCreds.Password,
+			},
+			Settings: clickhouse.Settings{
+				"max_execution_time": 60,
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+		})
+
+		if err := conn.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewCHHistoryRep: %w: %v", ErrPing, err)
+			return
+		}
+
+		// Configure connection pool
+		conn.SetMaxOpenConns(dbConf.MaxOpenConns)
+		conn.SetMaxIdleConns(dbConf.MaxIdleConns)
+		conn.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		chInstance = &CHHistoryRep{db: conn}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return chInstance, nil
+}
+
+func (ch *CHHistoryRep) GetHistory(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID,
+) ([]*models.ChangeRecord, error) {
+	query := "SELECT " + strings.Join(historyColumns, ", ") +
+		" FROM Change_history WHERE entityType = ? AND entityID = ? ORDER BY version"
+	rows, err := ch.db.QueryContext(ctx, query, string(entityType), entityID)
+	if err != nil {
+		return nil, fmt.Errorf("CHHistoryRep.GetHistory: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := parseHistoryRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("CHHistoryRep.GetHistory: %w", err)
+	}
+	return res, nil
+}
+
+func (ch *CHHistoryRep) Add(ctx context.Context, record *models.ChangeRecord) error {
+	values, err := historyValues(record)
+	if err != nil {
+		return fmt.Errorf("CHHistoryRep.Add: %w", err)
+	}
+	query := "INSERT INTO Change_history (" + strings.Join(historyColumns, ", ") +
+		") VALUES (?" + strings.Repeat(", ?", len(historyColumns)-1) + ")"
+	if _, err := ch.db.ExecContext(ctx, query, values...); err != nil {
+		return fmt.Errorf("CHHistoryRep.Add: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (ch *CHHistoryRep) Ping(ctx context.Context) error {
+	return ch.db.PingContext(ctx)
+}
+
+func (ch *CHHistoryRep) Close() {
+	ch.db.Close()
+}
+
+// CHAddChange сохраняет изменение записи каталога. В ClickHouse нет транзакций, поэтому
+// хранилища записывают историю до мутации: примененное изменение не останется без версии.
+// Если поля не изменились или change равен nil, история не пополняется.
+func CHAddChange(
+	ctx context.Context, db *sql.DB, entityType models.EntityType, entityID uuid.UUID,
+	change *models.Change, before models.Snapshot, after models.Snapshot,
+) error {
+	if change == nil || len(models.DiffSnapshots(before, after)) == 0 {
+		return nil
+	}
+	// max по пустой выборке в ClickHouse равен 0
+	var version int64
+	err := db.QueryRowContext(ctx,
+		"SELECT max(version) + 1 FROM Change_history WHERE entityType = ? AND entityID = ?",
+		string(entityType), entityID).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("CHAddChange: %w: %v", ErrQueryExec, err)
+	}
+	record, err := change.NewRecord(entityType, entityID, int(version), before, after)
+	if err != nil {
+		return fmt.Errorf("CHAddChange: %w", err)
+	}
+	values, err := historyValues(record)
+	if err != nil {
+		return fmt.Errorf("CHAddChange: %w", err)
+	}
+	query := "INSERT INTO Change_history (" + strings.Join(historyColumns, ", ") +
+		") VALUES (?" + strings.Repeat(", ?", len(historyColumns)-1) + ")"
+	if _, err := db.ExecContext(ctx, query, values...); err != nil {
+		return fmt.Errorf("CHAddChange: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
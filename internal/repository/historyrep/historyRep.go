@@ -0,0 +1,115 @@
+package historyrep
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOpenConnect  = errors.New("open connect failed")
+	ErrPing         = errors.New("ping failed")
+	ErrQueryBuilds  = errors.New("query build failed")
+	ErrQueryExec    = errors.New("query execution failed")
+	ErrRowsAffected = errors.New("no rows affected")
+	ErrSnapshot     = errors.New("invalid stored snapshot")
+)
+
+// HistoryRep хранит версии записей каталога. Записи истории только добавляются.
+type HistoryRep interface {
+	// GetHistory возвращает изменения записи в порядке возрастания версии
+	GetHistory(ctx context.Context, entityType models.EntityType, entityID uuid.UUID) ([]*models.ChangeRecord, error)
+	Add(ctx context.Context, record *models.ChangeRecord) error
+}
+
+func NewHistoryRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (HistoryRep, error) {
+	if datebaseType == cnfg.PostgresDB {
+		return NewPgHistoryRep(ctx, pgCreds, dbConf)
+	} else if datebaseType == cnfg.ClickHouseDB {
+		return NewCHHistoryRep(ctx, (*cnfg.ClickHouseCredentials)(pgCreds), dbConf)
+	} else {
+		return nil, fmt.Errorf("NewHistoryRep: %w", cnfg.ErrUnknownDB)
+	}
+}
+
+var historyColumns = []string{
+	"id", "entityType", "entityID", "version", "action", "revertedTo", "changedBy", "changedAt", "before", "after",
+}
+
+// historyValues возвращает значения колонок historyColumns, снимки сериализуются в JSON
+func historyValues(r *models.ChangeRecord) ([]any, error) {
+	before, err := json.Marshal(r.GetBefore())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshot, err)
+	}
+	after, err := json.Marshal(r.GetAfter())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshot, err)
+	}
+	var changedBy *uuid.UUID
+	if r.GetChangedBy() != uuid.Nil {
+		id := r.GetChangedBy()
+		changedBy = &id
+	}
+	return []any{
+		r.GetID(),
+		string(r.GetEntityType()),
+		r.GetEntityID(),
+		r.GetVersion(),
+		string(r.GetAction()),
+		r.GetRevertedTo(),
+		changedBy,
+		r.GetChangedAt(),
+		string(before),
+		string(after),
+	}, nil
+}
+
+func parseHistoryRows(rows *sql.Rows) ([]*models.ChangeRecord, error) {
+	var res []*models.ChangeRecord
+	for rows.Next() {
+		var id, entityID uuid.UUID
+		var changedBy uuid.NullUUID
+		var entityType, action, before, after string
+		var version, revertedTo int
+		var changedAt time.Time
+		if err := rows.Scan(&id, &entityType, &entityID, &version, &action, &revertedTo,
+			&changedBy, &changedAt, &before, &after); err != nil {
+			return nil, fmt.Errorf("parseHistoryRows: scan error: %v", err)
+		}
+		var beforeSnap, afterSnap models.Snapshot
+		if err := json.Unmarshal([]byte(before), &beforeSnap); err != nil {
+			return nil, fmt.Errorf("parseHistoryRows: %w: %v", ErrSnapshot, err)
+		}
+		if err := json.Unmarshal([]byte(after), &afterSnap); err != nil {
+			return nil, fmt.Errorf("parseHistoryRows: %w: %v", ErrSnapshot, err)
+		}
+		record, err := models.NewChangeRecord(
+			id,
+			models.EntityType(entityType),
+			entityID,
+			version,
+			models.ChangeAction(action),
+			revertedTo,
+			changedBy.UUID,
+			changedAt,
+			beforeSnap,
+			afterSnap,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parseHistoryRows: %v", err)
+		}
+		res = append(res, &record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,29 @@
+package historyrep
+
+import (
+	"context"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHistoryRep реализует HistoryRep интерфейс для тестирования
+type MockHistoryRep struct {
+	mock.Mock
+}
+
+func (m *MockHistoryRep) GetHistory(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID,
+) ([]*models.ChangeRecord, error) {
+	args := m.Called(ctx, entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ChangeRecord), args.Error(1)
+}
+
+func (m *MockHistoryRep) Add(ctx context.Context, record *models.ChangeRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
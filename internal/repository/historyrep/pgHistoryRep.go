@@ -0,0 +1,158 @@
+package historyrep
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type PgHistoryRep struct {
+	db *sql.DB
+}
+
+var (
+	pgInstance *PgHistoryRep
+	pgOnce     sync.Once
+)
+
+func NewPgHistoryRep(ctx context.Context, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (*PgHistoryRep, error) {
+	var resErr error
+	pgOnce.Do(func() {
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s",
+			pgCreds.Username, pgCreds.Password, pgCreds.Host, pgCreds.Port, pgCreds.DbName)
+		db, err := sql.Open("pgx", connStr)
+		if err != nil {
+			resErr = fmt.Errorf("NewPgHistoryRep: %w: %w", ErrOpenConnect, err)
+			return
+		}
+		if err := db.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewPgHistoryRep: %w: %w", ErrPing, err)
+			db.Close()
+			return
+		}
+		// Настраиваем пул соединений
+		db.SetMaxOpenConns(dbConf.MaxOpenConns)
+		db.SetMaxIdleConns(dbConf.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		pgInstance = &PgHistoryRep{db: db}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return pgInstance, nil
+}
+
+func (pg *PgHistoryRep) GetHistory(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID,
+) ([]*models.ChangeRecord, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	// JSONB приводится к тексту, чтобы разбирать снимки одинаково для всех хранилищ
+	query, args, err := psql.Select(
+		"id", "entityType", "entityID", "version", "action", "revertedTo", "changedBy", "changedAt",
+		"before::text", "after::text").
+		From("Change_history").
+		Where(sq.Eq{"entityType": string(entityType), "entityID": entityID}).
+		OrderBy("version").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgHistoryRep.GetHistory: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgHistoryRep.GetHistory: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := parseHistoryRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("PgHistoryRep.GetHistory: %w", err)
+	}
+	return res, nil
+}
+
+func (pg *PgHistoryRep) Add(ctx context.Context, record *models.ChangeRecord) error {
+	values, err := historyValues(record)
+	if err != nil {
+		return fmt.Errorf("PgHistoryRep.Add: %w", err)
+	}
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Insert("Change_history").
+		Columns(historyColumns...).
+		Values(values...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgHistoryRep.Add: %w: %v", ErrQueryBuilds, err)
+	}
+	result, err := pg.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("PgHistoryRep.Add: %w: %v", ErrQueryExec, err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgHistoryRep.Add: %w: %v", ErrRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("PgHistoryRep.Add: %w", ErrRowsAffected)
+	}
+	return nil
+}
+
+func (pg *PgHistoryRep) Ping(ctx context.Context) error {
+	return pg.db.PingContext(ctx)
+}
+
+func (pg *PgHistoryRep) Close() {
+	pg.db.Close()
+}
+
+// PgAddChange сохраняет изменение записи каталога в транзакции tx, в которой изменяется сама запись.
+// Следующая версия вычисляется в той же транзакции под блокировкой истории записи,
+// поэтому одновременные изменения получают разные версии.
+// Если поля не изменились или change равен nil, история не пополняется.
+func PgAddChange(
+	ctx context.Context, tx *sql.Tx, entityType models.EntityType, entityID uuid.UUID,
+	change *models.Change, before models.Snapshot, after models.Snapshot,
+) error {
+	if change == nil || len(models.DiffSnapshots(before, after)) == 0 {
+		return nil
+	}
+	lockKey := string(entityType) + ":" + entityID.String()
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))", lockKey); err != nil {
+		return fmt.Errorf("PgAddChange: %w: %v", ErrQueryExec, err)
+	}
+	var version int
+	err := tx.QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(version), 0) + 1 FROM Change_history WHERE entityType = $1 AND entityID = $2",
+		string(entityType), entityID).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("PgAddChange: %w: %v", ErrQueryExec, err)
+	}
+	record, err := change.NewRecord(entityType, entityID, version, before, after)
+	if err != nil {
+		return fmt.Errorf("PgAddChange: %w", err)
+	}
+	values, err := historyValues(record)
+	if err != nil {
+		return fmt.Errorf("PgAddChange: %w", err)
+	}
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("Change_history").
+		Columns(historyColumns...).
+		Values(values...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgAddChange: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("PgAddChange: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package historyrep_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/pgtest"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	th     *testHelper
+	pgOnce sync.Once
+)
+
+type testHelper struct {
+	ctx     context.Context
+	hrep    *historyrep.PgHistoryRep
+	pgCreds *cnfg.DatebaseCredentials
+}
+
+func setupTestHelper(t *testing.T) *testHelper {
+	ctx := context.Background()
+	pgOnce.Do(func() {
+		dbCnfg := cnfg.GetTestDatebaseConfig()
+
+		_, pgCreds, err := pgtest.GetTestPostgres(ctx)
+		require.NoError(t, err)
+
+		hrep, err := historyrep.NewPgHistoryRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+
+		th = &testHelper{
+			ctx:     ctx,
+			hrep:    hrep,
+			pgCreds: &pgCreds,
+		}
+	})
+	pgTestConfig := cnfg.GetPgTestConfig()
+	err := pgtest.MigrateUp(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := pgtest.MigrateDown(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+		require.NoError(t, err)
+	})
+
+	return th
+}
+
+func createTestRecord(
+	t *testing.T, entityID uuid.UUID, version int, action models.ChangeAction, revertedTo int,
+	changedBy uuid.UUID, before, after string,
+) *models.ChangeRecord {
+	record, err := models.NewChangeRecord(uuid.New(), models.EntityCollection, entityID, version, action, revertedTo,
+		changedBy, time.Now().UTC().Truncate(time.Second),
+		models.Snapshot{"title": before}, models.Snapshot{"title": after})
+	require.NoError(t, err)
+	return &record
+}
+
+func TestPgHistoryRep_AddAndGet(t *testing.T) {
+	th := setupTestHelper(t)
+
+	collectionID := uuid.New()
+	employeeID := uuid.New()
+	first := createTestRecord(t, collectionID, 1, models.ChangeUpdate, 0, employeeID, "Графика", "Графика XIX века")
+	second := createTestRecord(t, collectionID, 2, models.ChangeRevert, 0, uuid.Nil, "Графика XIX века", "Графика")
+	// добавляем не по порядку, чтобы проверить сортировку по версии
+	require.NoError(t, th.hrep.Add(th.ctx, second))
+	require.NoError(t, th.hrep.Add(th.ctx, first))
+
+	history, err := th.hrep.GetHistory(th.ctx, models.EntityCollection, collectionID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	assert.Equal(t, first.GetID(), history[0].GetID())
+	assert.Equal(t, employeeID, history[0].GetChangedBy())
+	assert.Equal(t, first.GetAfter(), history[0].GetAfter())
+	assert.True(t, first.GetChangedAt().Equal(history[0].GetChangedAt()))
+
+	assert.Equal(t, models.ChangeRevert, history[1].GetAction())
+	assert.Equal(t, uuid.Nil, history[1].GetChangedBy())
+	assert.Equal(t, []models.FieldChange{{Field: "title", Old: "Графика XIX века", New: "Графика"}}, history[1].Changes())
+
+	t.Run("other entity type has separate history", func(t *testing.T) {
+		history, err := th.hrep.GetHistory(th.ctx, models.EntityAuthor, collectionID)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		dup := createTestRecord(t, collectionID, 2, models.ChangeUpdate, 0, employeeID, "Графика", "Гравюры")
+		err := th.hrep.Add(th.ctx, dup)
+		assert.ErrorIs(t, err, historyrep.ErrQueryExec)
+	})
+}
@@ -0,0 +1,23 @@
+package imagestorage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrImageNotFound = errors.New("the image was not found in the storage")
+	ErrInvalidKey    = errors.New("invalid image storage key")
+	ErrSaveImage     = errors.New("failed to save image")
+	ErrDeleteImage   = errors.New("failed to delete image")
+)
+
+// ImageStorage хранилище файлов изображений.
+// Ключ — относительный путь с разделителем "/", например "artworks/<id>/<id>/original.jpg"
+type ImageStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет файл или каталог со всеми вложенными файлами, отсутствие ключа не ошибка
+	Delete(ctx context.Context, key string) error
+}
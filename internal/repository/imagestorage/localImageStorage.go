@@ -0,0 +1,100 @@
+package imagestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalImageStorage хранит изображения в каталоге локальной файловой системы
+type LocalImageStorage struct {
+	root string
+}
+
+func NewLocalImageStorage(root string) (*LocalImageStorage, error) {
+	if root == "" {
+		return nil, fmt.Errorf("NewLocalImageStorage: %w: empty root dir", ErrInvalidKey)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("NewLocalImageStorage: %v", err)
+	}
+	return &LocalImageStorage{root: root}, nil
+}
+
+// Root возвращает корневой каталог хранилища
+func (s *LocalImageStorage) Root() string {
+	return s.root
+}
+
+// resolve переводит ключ в путь внутри корневого каталога, не давая выйти за его пределы
+func (s *LocalImageStorage) resolve(key string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (s *LocalImageStorage) Save(ctx context.Context, key string, r io.Reader) error {
+	filePath, err := s.resolve(key)
+	if err != nil {
+		return fmt.Errorf("LocalImageStorage.Save: %w", err)
+	}
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("LocalImageStorage.Save: %w: %v", ErrSaveImage, err)
+	}
+
+	// пишем во временный файл и переименовываем, чтобы не отдавать недописанные файлы
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("LocalImageStorage.Save: %w: %v", ErrSaveImage, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("LocalImageStorage.Save: %w: %v", ErrSaveImage, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("LocalImageStorage.Save: %w: %v", ErrSaveImage, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("LocalImageStorage.Save: %w: %v", ErrSaveImage, err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("LocalImageStorage.Save: %w: %v", ErrSaveImage, err)
+	}
+	return nil
+}
+
+func (s *LocalImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := s.resolve(key)
+	if err != nil {
+		return nil, fmt.Errorf("LocalImageStorage.Open: %w", err)
+	}
+	f, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrImageNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("LocalImageStorage.Open: %v", err)
+	}
+	return f, nil
+}
+
+func (s *LocalImageStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := s.resolve(key)
+	if err != nil {
+		return fmt.Errorf("LocalImageStorage.Delete: %w", err)
+	}
+	if err := os.RemoveAll(filePath); err != nil {
+		return fmt.Errorf("LocalImageStorage.Delete: %w: %v", ErrDeleteImage, err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package imagestorage
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImageStorage struct {
+	mock.Mock
+}
+
+func (m *MockImageStorage) Save(ctx context.Context, key string, r io.Reader) error {
+	args := m.Called(ctx, key, r)
+	return args.Error(0)
+}
+
+func (m *MockImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockImageStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,205 @@
+package tagrep
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/google/uuid"
+)
+
+type CHTagRep struct {
+	db *sql.DB
+}
+
+var (
+	chInstance *CHTagRep
+	chOnce     sync.Once
+)
+
+func NewCHTagRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, dbConf *cnfg.DatebaseConfig) (*CHTagRep, error) {
+	var resErr error
+	chOnce.Do(func() {
+		conn := clickhouse.OpenDB(&clickhouse.Options{
+			Addr: []string{fmt.Sprintf("%s:%d", chCreds.Host, chCreds.Port)},
+			Auth: clickhouse.Auth{
+				Database: chCreds.DbName,
+				Username: chCreds.Username,
+				Password: chCreds.Password,
+			},
+			Settings: clickhouse.Settings{
+				"max_execution_time": 60,
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+		})
+
+		if err := conn.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewCHTagRep: %w: %v", ErrPing, err)
+			return
+		}
+
+		// Configure connection pool
+		conn.SetMaxOpenConns(dbConf.MaxOpenConns)
+		conn.SetMaxIdleConns(dbConf.MaxIdleConns)
+		conn.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		chInstance = &CHTagRep{db: conn}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return chInstance, nil
+}
+
+func (ch *CHTagRep) execSelectQuery(ctx context.Context, query string, args ...interface{}) ([]*models.Tag, error) {
+	rows, err := ch.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	return parseTagRows(rows)
+}
+
+func (ch *CHTagRep) GetAll(ctx context.Context) ([]*models.Tag, error) {
+	query := "SELECT id, name, kind, parentID, synonyms FROM Tags ORDER BY kind, name"
+	res, err := ch.execSelectQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("CHTagRep.GetAll: %w", err)
+	}
+	return res, nil
+}
+
+func (ch *CHTagRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	query := "SELECT id, name, kind, parentID, synonyms FROM Tags WHERE id = ?"
+	res, err := ch.execSelectQuery(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("CHTagRep.GetByID: %w", err)
+	}
+	if len(res) == 0 {
+		return nil, ErrTagNotFound
+	} else if len(res) > 1 {
+		return nil, fmt.Errorf("CHTagRep.GetByID: %w", ErrExpectedOneTag)
+	}
+	return res[0], nil
+}
+
+func (ch *CHTagRep) execChangeQuery(ctx context.Context, query string, args ...interface{}) error {
+	result, err := ch.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+
+	// ClickHouse has limited RowsAffected support, but we can still check for errors
+	_, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRowsAffected, err)
+	}
+	return nil
+}
+
+func (ch *CHTagRep) Add(ctx context.Context, t *models.Tag) error {
+	query := "INSERT INTO Tags (id, name, kind, parentID, synonyms) VALUES (?, ?, ?, ?, ?)"
+	err := ch.execChangeQuery(ctx, query,
+		t.GetID(),
+		t.GetName(),
+		string(t.GetKind()),
+		t.GetParentID(),
+		joinSynonyms(t.GetSynonyms()))
+	if err != nil {
+		return fmt.Errorf("CHTagRep.Add: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHTagRep) Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Tag) (*models.Tag, error)) error {
+	tag, err := ch.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("CHTagRep.Update: %w", err)
+	}
+	updatedTag, err := funcUpdate(tag)
+	if err != nil {
+		return fmt.Errorf("CHTagRep.Update: %w: %w", ErrUpdateTag, err)
+	}
+
+	query := "ALTER TABLE Tags UPDATE name = ?, kind = ?, parentID = ?, synonyms = ? WHERE id = ?"
+	err = ch.execChangeQuery(ctx, query,
+		updatedTag.GetName(),
+		string(updatedTag.GetKind()),
+		updatedTag.GetParentID(),
+		joinSynonyms(updatedTag.GetSynonyms()),
+		id)
+	if err != nil {
+		return fmt.Errorf("CHTagRep.Update: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет тег вместе с отметками произведений, каскадного удаления в ClickHouse нет
+func (ch *CHTagRep) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := ch.execChangeQuery(ctx, "ALTER TABLE Artwork_tags DELETE WHERE tagID = ?", id); err != nil {
+		return fmt.Errorf("CHTagRep.Delete: %w", err)
+	}
+	if err := ch.execChangeQuery(ctx, "ALTER TABLE Tags DELETE WHERE id = ?", id); err != nil {
+		return fmt.Errorf("CHTagRep.Delete: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHTagRep) SetArtworkTags(ctx context.Context, artworkID uuid.UUID, tagIDs uuid.UUIDs) error {
+	err := ch.execChangeQuery(ctx, "ALTER TABLE Artwork_tags DELETE WHERE artworkID = ?", artworkID)
+	if err != nil {
+		return fmt.Errorf("CHTagRep.SetArtworkTags: %w", err)
+	}
+	// теги входят в запись произведения, смена тегов - изменение произведения
+	err = ch.execChangeQuery(ctx, "ALTER TABLE Artworks UPDATE updatedAt = now() WHERE id = ?", artworkID)
+	if err != nil {
+		return fmt.Errorf("CHTagRep.SetArtworkTags: %w", err)
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(tagIDs))
+	args := make([]interface{}, 0, 2*len(tagIDs))
+	for i, tagID := range tagIDs {
+		placeholders[i] = "(?, ?)"
+		args = append(args, artworkID, tagID)
+	}
+	query := "INSERT INTO Artwork_tags (artworkID, tagID) VALUES " + strings.Join(placeholders, ", ")
+	if err := ch.execChangeQuery(ctx, query, args...); err != nil {
+		return fmt.Errorf("CHTagRep.SetArtworkTags: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHTagRep) GetArtworkCounts(ctx context.Context) (map[uuid.UUID]int, error) {
+	rows, err := ch.db.QueryContext(ctx, "SELECT tagID, count() FROM Artwork_tags "+
+		"WHERE artworkID IN (SELECT id FROM Artworks WHERE deletedAt IS NULL) GROUP BY tagID")
+	if err != nil {
+		return nil, fmt.Errorf("CHTagRep.GetArtworkCounts: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	counts, err := parseCountRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("CHTagRep.GetArtworkCounts: %w", err)
+	}
+	return counts, nil
+}
+
+func (ch *CHTagRep) Ping(ctx context.Context) error {
+	return ch.db.PingContext(ctx)
+}
+
+func (ch *CHTagRep) Close() {
+	ch.db.Close()
+}
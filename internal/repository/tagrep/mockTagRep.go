@@ -0,0 +1,58 @@
+package tagrep
+
+import (
+	"context"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagRep реализует TagRep интерфейс для тестирования
+type MockTagRep struct {
+	mock.Mock
+}
+
+func (m *MockTagRep) GetAll(ctx context.Context) ([]*models.Tag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRep) Add(ctx context.Context, t *models.Tag) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockTagRep) Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Tag) (*models.Tag, error)) error {
+	args := m.Called(ctx, id, funcUpdate)
+	return args.Error(0)
+}
+
+func (m *MockTagRep) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTagRep) SetArtworkTags(ctx context.Context, artworkID uuid.UUID, tagIDs uuid.UUIDs) error {
+	args := m.Called(ctx, artworkID, tagIDs)
+	return args.Error(0)
+}
+
+func (m *MockTagRep) GetArtworkCounts(ctx context.Context) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
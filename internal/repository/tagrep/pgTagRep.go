@@ -0,0 +1,254 @@
+package tagrep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type PgTagRep struct {
+	db *sql.DB
+}
+
+var (
+	pgInstance *PgTagRep
+	pgOnce     sync.Once
+)
+
+var (
+	ErrOpenConnect    = errors.New("open connect failed")
+	ErrPing           = errors.New("ping failed")
+	ErrQueryBuilds    = errors.New("query build failed")
+	ErrQueryExec      = errors.New("query execution failed")
+	ErrExpectedOneTag = errors.New("expected one tag")
+	ErrRowsAffected   = errors.New("no rows affected")
+)
+
+func NewPgTagRep(ctx context.Context, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (*PgTagRep, error) {
+	var resErr error
+	pgOnce.Do(func() {
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s",
+			pgCreds.Username, pgCreds.Password, pgCreds.Host, pgCreds.Port, pgCreds.DbName)
+		db, err := sql.Open("pgx", connStr)
+		if err != nil {
+			resErr = fmt.Errorf("NewPgTagRep: %w: %w", ErrOpenConnect, err)
+			return
+		}
+		if err := db.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewPgTagRep: %w: %w", ErrPing, err)
+			db.Close()
+			return
+		}
+		// Настраиваем пул соединений
+		db.SetMaxOpenConns(dbConf.MaxOpenConns)
+		db.SetMaxIdleConns(dbConf.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		pgInstance = &PgTagRep{db: db}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return pgInstance, nil
+}
+
+func (pg *PgTagRep) execSelectQuery(ctx context.Context, query sq.SelectBuilder) ([]*models.Tag, error) {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	return parseTagRows(rows)
+}
+
+func (pg *PgTagRep) selectTags() sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select("id", "name", "kind", "parentID", "synonyms").
+		From("Tags")
+}
+
+func (pg *PgTagRep) GetAll(ctx context.Context) ([]*models.Tag, error) {
+	res, err := pg.execSelectQuery(ctx, pg.selectTags().OrderBy("kind", "name"))
+	if err != nil {
+		return nil, fmt.Errorf("PgTagRep.GetAll: %w", err)
+	}
+	return res, nil
+}
+
+func (pg *PgTagRep) GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	res, err := pg.execSelectQuery(ctx, pg.selectTags().Where(sq.Eq{"id": id}))
+	if err != nil {
+		return nil, fmt.Errorf("PgTagRep.GetByID: %w", err)
+	}
+	if len(res) == 0 {
+		return nil, ErrTagNotFound
+	} else if len(res) > 1 {
+		return nil, fmt.Errorf("PgTagRep.GetByID: %w", ErrExpectedOneTag)
+	}
+	return res[0], nil
+}
+
+func (pg *PgTagRep) execChangeQuery(ctx context.Context, query sq.Sqlizer) error {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	result, err := pg.db.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	// проверка количества затронутых строк
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRowsAffected, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: no changed", ErrRowsAffected)
+	}
+	return nil
+}
+
+// parentValue возвращает родительский тег для записи, NULL - корневой тег
+func parentValue(t *models.Tag) interface{} {
+	if t.GetParentID() == uuid.Nil {
+		return nil
+	}
+	return t.GetParentID()
+}
+
+func (pg *PgTagRep) Add(ctx context.Context, t *models.Tag) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("Tags").
+		Columns("id", "name", "kind", "parentID", "synonyms").
+		Values(t.GetID(), t.GetName(), string(t.GetKind()), parentValue(t), joinSynonyms(t.GetSynonyms()))
+	if err := pg.execChangeQuery(ctx, query); err != nil {
+		return fmt.Errorf("PgTagRep.Add: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgTagRep) Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Tag) (*models.Tag, error)) error {
+	tag, err := pg.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("PgTagRep.Update: %w", err)
+	}
+	updatedTag, err := funcUpdate(tag)
+	if err != nil {
+		return fmt.Errorf("PgTagRep.Update: %w: %w", ErrUpdateTag, err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Update("Tags").
+		Set("name", updatedTag.GetName()).
+		Set("kind", string(updatedTag.GetKind())).
+		Set("parentID", parentValue(updatedTag)).
+		Set("synonyms", joinSynonyms(updatedTag.GetSynonyms())).
+		Where(sq.Eq{"id": id})
+	if err := pg.execChangeQuery(ctx, query); err != nil {
+		return fmt.Errorf("PgTagRep.Update: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgTagRep) Delete(ctx context.Context, id uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Delete("Tags").
+		Where(sq.Eq{"id": id})
+	if err := pg.execChangeQuery(ctx, query); err != nil {
+		return fmt.Errorf("PgTagRep.Delete: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgTagRep) SetArtworkTags(ctx context.Context, artworkID uuid.UUID, tagIDs uuid.UUIDs) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	deleteSQL, deleteArgs, err := psql.Delete("Artwork_tags").Where(sq.Eq{"artworkID": artworkID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, deleteSQL, deleteArgs...); err != nil {
+		return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryExec, err)
+	}
+	if len(tagIDs) > 0 {
+		insert := psql.Insert("Artwork_tags").Columns("artworkID", "tagID")
+		for _, tagID := range tagIDs {
+			insert = insert.Values(artworkID, tagID)
+		}
+		insertSQL, insertArgs, err := insert.ToSql()
+		if err != nil {
+			return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryBuilds, err)
+		}
+		if _, err := tx.ExecContext(ctx, insertSQL, insertArgs...); err != nil {
+			return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryExec, err)
+		}
+	}
+	// теги входят в запись произведения, смена тегов - изменение произведения
+	touchSQL, touchArgs, err := psql.Update("Artworks").
+		Set("updatedAt", sq.Expr("now()")).
+		Where(sq.Eq{"id": artworkID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, touchSQL, touchArgs...); err != nil {
+		return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryExec, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgTagRep.SetArtworkTags: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgTagRep) GetArtworkCounts(ctx context.Context) (map[uuid.UUID]int, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select("tagID", "COUNT(*)").
+		From("Artwork_tags").
+		Where("artworkID IN (SELECT id FROM Artworks WHERE deletedAt IS NULL)").
+		GroupBy("tagID").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgTagRep.GetArtworkCounts: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgTagRep.GetArtworkCounts: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	counts, err := parseCountRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("PgTagRep.GetArtworkCounts: %w", err)
+	}
+	return counts, nil
+}
+
+func (pg *PgTagRep) Ping(ctx context.Context) error {
+	return pg.db.PingContext(ctx)
+}
+
+func (pg *PgTagRep) Close() {
+	pg.db.Close()
+}
@@ -0,0 +1,181 @@
+package tagrep_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/pgtest"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/tagrep"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	th     *testHelper
+	pgOnce sync.Once
+)
+
+type testHelper struct {
+	ctx       context.Context
+	trep      *tagrep.PgTagRep
+	arep      *artworkrep.PgArtworkRep
+	authorRep *authorrep.PgAuthorRep
+	colRep    *collectionrep.PgCollectionRep
+	pgCreds   *cnfg.DatebaseCredentials
+}
+
+func setupTestHelper(t *testing.T) *testHelper {
+	ctx := context.Background()
+	pgOnce.Do(func() {
+		dbCnfg := cnfg.GetTestDatebaseConfig()
+
+		_, pgCreds, err := pgtest.GetTestPostgres(ctx)
+		require.NoError(t, err)
+
+		trep, err := tagrep.NewPgTagRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+		arep, err := artworkrep.NewPgArtworkRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+		authorRep, err := authorrep.NewPgAuthorRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+		colRep, err := collectionrep.NewPgCollectionRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+
+		th = &testHelper{
+			ctx:       ctx,
+			trep:      trep,
+			arep:      arep,
+			authorRep: authorRep,
+			colRep:    colRep,
+			pgCreds:   &pgCreds,
+		}
+	})
+	pgTestConfig := cnfg.GetPgTestConfig()
+	err := pgtest.MigrateUp(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := pgtest.MigrateDown(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+		require.NoError(t, err)
+	})
+
+	return th
+}
+
+func (th *testHelper) createAndAddTag(t *testing.T, name string, parentID uuid.UUID, synonyms ...string) *models.Tag {
+	tag, err := models.NewTag(uuid.New(), name, models.TagSubject, parentID, synonyms)
+	require.NoError(t, err)
+	require.NoError(t, th.trep.Add(th.ctx, &tag))
+	return &tag
+}
+
+func (th *testHelper) createAndAddArtwork(t *testing.T, title string) *models.Artwork {
+	author, err := models.NewAuthor(uuid.New(), "Author "+title, 1900, 1980)
+	require.NoError(t, err)
+	require.NoError(t, th.authorRep.Add(th.ctx, &author))
+	collection, err := models.NewCollection(uuid.New(), "Collection "+title)
+	require.NoError(t, err)
+	require.NoError(t, th.colRep.AddCollection(th.ctx, &collection))
+	artwork, err := models.NewArtwork(uuid.New(), title, "Oil on canvas", "Canvas", "100x100 cm", 1950, &author, &collection)
+	require.NoError(t, err)
+	require.NoError(t, th.arep.Add(th.ctx, &artwork))
+	return &artwork
+}
+
+func TestPgTagRep_CRUD(t *testing.T) {
+	th := setupTestHelper(t)
+
+	root := th.createAndAddTag(t, "Портрет", uuid.Nil, "портретная живопись")
+	child := th.createAndAddTag(t, "Парадный портрет", root.GetID())
+
+	t.Run("get all", func(t *testing.T) {
+		tags, err := th.trep.GetAll(th.ctx)
+		require.NoError(t, err)
+		require.Len(t, tags, 2)
+	})
+
+	t.Run("get by id keeps parent and synonyms", func(t *testing.T) {
+		stored, err := th.trep.GetByID(th.ctx, root.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, stored.GetParentID())
+		assert.Equal(t, []string{"портретная живопись"}, stored.GetSynonyms())
+
+		stored, err = th.trep.GetByID(th.ctx, child.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, root.GetID(), stored.GetParentID())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := th.trep.Update(th.ctx, child.GetID(), func(tag *models.Tag) (*models.Tag, error) {
+			return tag, tag.Update(jsonreqresp.TagRequest{Name: "Групповой портрет", Kind: "subject", Synonyms: []string{"групповой"}})
+		})
+		require.NoError(t, err)
+		stored, err := th.trep.GetByID(th.ctx, child.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "Групповой портрет", stored.GetName())
+		assert.Equal(t, uuid.Nil, stored.GetParentID())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, th.trep.Delete(th.ctx, child.GetID()))
+		_, err := th.trep.GetByID(th.ctx, child.GetID())
+		assert.ErrorIs(t, err, tagrep.ErrTagNotFound)
+	})
+}
+
+func TestPgTagRep_ArtworkTags(t *testing.T) {
+	th := setupTestHelper(t)
+
+	landscape := th.createAndAddTag(t, "Пейзаж", uuid.Nil)
+	marine := th.createAndAddTag(t, "Марина", landscape.GetID())
+	portrait := th.createAndAddTag(t, "Портрет", uuid.Nil)
+	seaView := th.createAndAddArtwork(t, "Вид моря")
+	face := th.createAndAddArtwork(t, "Портрет дамы")
+
+	require.NoError(t, th.trep.SetArtworkTags(th.ctx, seaView.GetID(), uuid.UUIDs{marine.GetID()}))
+	require.NoError(t, th.trep.SetArtworkTags(th.ctx, face.GetID(), uuid.UUIDs{portrait.GetID(), landscape.GetID()}))
+
+	t.Run("artwork tags are loaded", func(t *testing.T) {
+		stored, err := th.arep.GetByID(th.ctx, face.GetID())
+		require.NoError(t, err)
+		require.Len(t, stored.GetTags(), 2)
+	})
+
+	t.Run("filter includes descendant tags", func(t *testing.T) {
+		arts, err := th.arep.GetAllArtworks(th.ctx,
+			&jsonreqresp.ArtworkFilter{TagIDs: uuid.UUIDs{landscape.GetID()}}, &jsonreqresp.ArtworkSortOps{})
+		require.NoError(t, err)
+		assert.Len(t, arts, 2)
+
+		arts, err = th.arep.GetAllArtworks(th.ctx,
+			&jsonreqresp.ArtworkFilter{TagIDs: uuid.UUIDs{marine.GetID()}}, &jsonreqresp.ArtworkSortOps{})
+		require.NoError(t, err)
+		require.Len(t, arts, 1)
+		assert.Equal(t, seaView.GetID(), arts[0].GetID())
+	})
+
+	t.Run("counts", func(t *testing.T) {
+		counts, err := th.trep.GetArtworkCounts(th.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int{
+			landscape.GetID(): 1,
+			marine.GetID():    1,
+			portrait.GetID():  1,
+		}, counts)
+	})
+
+	t.Run("set replaces tags", func(t *testing.T) {
+		require.NoError(t, th.trep.SetArtworkTags(th.ctx, face.GetID(), nil))
+		stored, err := th.arep.GetByID(th.ctx, face.GetID())
+		require.NoError(t, err)
+		assert.Empty(t, stored.GetTags())
+	})
+}
@@ -0,0 +1,90 @@
+package tagrep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTagNotFound = errors.New("the Tag was not found in the repository")
+	ErrUpdateTag   = errors.New("err update tag params")
+)
+
+type TagRep interface {
+	GetAll(ctx context.Context) ([]*models.Tag, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error)
+	Add(ctx context.Context, t *models.Tag) error
+	Update(ctx context.Context, id uuid.UUID, funcUpdate func(*models.Tag) (*models.Tag, error)) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetArtworkTags заменяет теги произведения переданным набором
+	SetArtworkTags(ctx context.Context, artworkID uuid.UUID, tagIDs uuid.UUIDs) error
+	// GetArtworkCounts возвращает число произведений, отмеченных непосредственно каждым тегом
+	GetArtworkCounts(ctx context.Context) (map[uuid.UUID]int, error)
+}
+
+func NewTagRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (TagRep, error) {
+	if datebaseType == cnfg.PostgresDB {
+		return NewPgTagRep(ctx, pgCreds, dbConf)
+	} else if datebaseType == cnfg.ClickHouseDB {
+		return NewCHTagRep(ctx, (*cnfg.ClickHouseCredentials)(pgCreds), dbConf)
+	} else {
+		return nil, fmt.Errorf("NewTagRep: %w", cnfg.ErrUnknownDB)
+	}
+}
+
+// parseTagRows разбирает строки выборки тегов: id, name, kind, parentID и синонимы через перевод строки
+func parseTagRows(rows *sql.Rows) ([]*models.Tag, error) {
+	var resTags []*models.Tag
+	for rows.Next() {
+		var id uuid.UUID
+		var parentID uuid.NullUUID
+		var name, kind, synonyms string
+		if err := rows.Scan(&id, &name, &kind, &parentID, &synonyms); err != nil {
+			return nil, fmt.Errorf("parseTagRows: scan error: %v", err)
+		}
+		tag, err := models.NewTag(id, name, models.TagKind(kind), parentID.UUID, splitSynonyms(synonyms))
+		if err != nil {
+			return nil, fmt.Errorf("parseTagRows: %w: %v", models.ErrValidateTag, err)
+		}
+		resTags = append(resTags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseTagRows: rows iteration error: %v", err)
+	}
+	return resTags, nil
+}
+
+// parseCountRows разбирает строки tagID, число произведений
+func parseCountRows(rows *sql.Rows) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var id uuid.UUID
+		var count int64
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("parseCountRows: scan error: %v", err)
+		}
+		counts[id] = int(count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseCountRows: rows iteration error: %v", err)
+	}
+	return counts, nil
+}
+
+func joinSynonyms(synonyms []string) string {
+	return strings.Join(synonyms, "\n")
+}
+
+func splitSynonyms(synonyms string) []string {
+	if synonyms == "" {
+		return nil
+	}
+	return strings.Split(synonyms, "\n")
+}
@@ -0,0 +1,188 @@
+package trashrep
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/google/uuid"
+)
+
+type CHTrashRep struct {
+	db *sql.DB
+}
+
+var (
+	chInstance *CHTrashRep
+	chOnce     sync.Once
+)
+
+func NewCHTrashRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, dbConf *cnfg.DatebaseConfig) (*CHTrashRep, error) {
+	var resErr error
+	chOnce.Do(func() {
+		conn := clickhouse.OpenDB(&clickhouse.Options{
+			Addr: []string{fmt.Sprintf("%s:%d", chCreds.Host, chCreds.Port)},
+			Auth: clickhouse.Auth{
+				Database: chCreds.DbName,
+				Username: chCreds.Username,
+				Password: chCreds.Password,
+			},
+			Settings: clickhouse.Settings{
+				"max_execution_time": 60,
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+		})
+
+		if err := conn.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewCHTrashRep: %w: %v", ErrPing, err)
+			return
+		}
+
+		// Configure connection pool
+		conn.SetMaxOpenConns(dbConf.MaxOpenConns)
+		conn.SetMaxIdleConns(dbConf.MaxIdleConns)
+		conn.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		chInstance = &CHTrashRep{db: conn}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return chInstance, nil
+}
+
+func (ch *CHTrashRep) GetItems(ctx context.Context, entityType models.EntityType) ([]*models.TrashItem, error) {
+	query, err := selectTrashQuery(entityType)
+	if err != nil {
+		return nil, fmt.Errorf("CHTrashRep.GetItems: %w", err)
+	}
+	rows, err := ch.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("CHTrashRep.GetItems: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := parseTrashRows(rows, "")
+	if err != nil {
+		return nil, fmt.Errorf("CHTrashRep.GetItems: %w", err)
+	}
+	return res, nil
+}
+
+func (ch *CHTrashRep) Restore(ctx context.Context, entityType models.EntityType, id uuid.UUID) error {
+	t, err := getTrashTable(entityType)
+	if err != nil {
+		return fmt.Errorf("CHTrashRep.Restore: %w", err)
+	}
+	var cnt uint64
+	err = ch.db.QueryRowContext(ctx,
+		"SELECT count() FROM "+t.table+" WHERE id = ? AND deletedAt IS NOT NULL", id).Scan(&cnt)
+	if err != nil {
+		return fmt.Errorf("CHTrashRep.Restore: %w: %v", ErrQueryExec, err)
+	}
+	if cnt == 0 {
+		return fmt.Errorf("CHTrashRep.Restore: %w", ErrTrashItemNotFound)
+	}
+
+	if blockersQuery, ok := restoreBlockersQueries[entityType]; ok {
+		args := make([]interface{}, strings.Count(blockersQuery, "%[1]s"))
+		for i := range args {
+			args[i] = id
+		}
+		rows, err := ch.db.QueryContext(ctx, fmt.Sprintf(blockersQuery, "?"), args...)
+		if err != nil {
+			return fmt.Errorf("CHTrashRep.Restore: %w: %v", ErrQueryExec, err)
+		}
+		blockers, err := parseRestoreBlockers(rows)
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("CHTrashRep.Restore: %w", err)
+		}
+		if err := models.CheckDependents(blockers); err != nil {
+			return fmt.Errorf("CHTrashRep.Restore: %w", err)
+		}
+	}
+
+	set := "deletedAt = NULL"
+	if t.touchUpdatedAt {
+		set += ", updatedAt = now()"
+	}
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 2}))
+	_, err = ch.db.ExecContext(syncCtx, "ALTER TABLE "+t.table+" UPDATE "+set+" WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("CHTrashRep.Restore: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// chArtworkChildTables таблицы, связанные с произведениями: в ClickHouse нет каскадного удаления
+var chArtworkChildTables = []string{
+	"Artwork_images", "Artwork_provenance", "Condition_reports", "Condition_report_photos",
+	"Artwork_authors", "Artwork_tags", "Artwork_event", "Artwork_event_template",
+}
+
+// chPurgeConditions условия окончательного удаления записей корзины старше ?.
+// Произведения удаляются первыми, чтобы следом можно было удалить их авторов и коллекции,
+// коллекция с вложенными коллекциями остается до следующей очистки
+var chPurgeConditions = []struct {
+	entityType models.EntityType
+	condition  string
+}{
+	{models.EntityArtwork, "deletedAt < ?"},
+	{models.EntityAuthor, "deletedAt < ? " +
+		"AND id NOT IN (SELECT authorID FROM Artworks) AND id NOT IN (SELECT authorID FROM Artwork_authors)"},
+	{models.EntityCollection, "deletedAt < ? " +
+		"AND id NOT IN (SELECT collectionID FROM Artworks) AND id NOT IN (SELECT parentID FROM Collection)"},
+}
+
+// Purge в ClickHouse выполняется без транзакции последовательными синхронными мутациями:
+// сначала выбираются удаляемые записи, затем удаляются связи произведений и сами записи
+func (ch *CHTrashRep) Purge(ctx context.Context, deletedBefore time.Time) ([]*models.TrashItem, error) {
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 2}))
+
+	var res []*models.TrashItem
+	for _, p := range chPurgeConditions {
+		t, err := getTrashTable(p.entityType)
+		if err != nil {
+			return nil, fmt.Errorf("CHTrashRep.Purge: %w", err)
+		}
+		rows, err := ch.db.QueryContext(ctx,
+			fmt.Sprintf("SELECT id, %s, deletedAt FROM %s WHERE %s", t.titleColumn, t.table, p.condition), deletedBefore)
+		if err != nil {
+			return nil, fmt.Errorf("CHTrashRep.Purge: %w: %v", ErrQueryExec, err)
+		}
+		items, err := parseTrashRows(rows, p.entityType)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("CHTrashRep.Purge: %w", err)
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		var queries []string
+		if p.entityType == models.EntityArtwork {
+			for _, child := range chArtworkChildTables {
+				queries = append(queries, "ALTER TABLE "+child+
+					" DELETE WHERE artworkID IN (SELECT id FROM Artworks WHERE "+p.condition+")")
+			}
+		}
+		queries = append(queries, "ALTER TABLE "+t.table+" DELETE WHERE "+p.condition)
+		for _, query := range queries {
+			if _, err := ch.db.ExecContext(syncCtx, query, deletedBefore); err != nil {
+				return nil, fmt.Errorf("CHTrashRep.Purge: %w: %v", ErrQueryExec, err)
+			}
+		}
+		res = append(res, items...)
+	}
+	return res, nil
+}
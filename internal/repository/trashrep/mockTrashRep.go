@@ -0,0 +1,36 @@
+package trashrep
+
+import (
+	"context"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTrashRep реализует TrashRep интерфейс для тестирования
+type MockTrashRep struct {
+	mock.Mock
+}
+
+func (m *MockTrashRep) GetItems(ctx context.Context, entityType models.EntityType) ([]*models.TrashItem, error) {
+	args := m.Called(ctx, entityType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TrashItem), args.Error(1)
+}
+
+func (m *MockTrashRep) Restore(ctx context.Context, entityType models.EntityType, id uuid.UUID) error {
+	args := m.Called(ctx, entityType, id)
+	return args.Error(0)
+}
+
+func (m *MockTrashRep) Purge(ctx context.Context, deletedBefore time.Time) ([]*models.TrashItem, error) {
+	args := m.Called(ctx, deletedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TrashItem), args.Error(1)
+}
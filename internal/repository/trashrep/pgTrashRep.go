@@ -0,0 +1,177 @@
+package trashrep
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type PgTrashRep struct {
+	db *sql.DB
+}
+
+var (
+	pgInstance *PgTrashRep
+	pgOnce     sync.Once
+)
+
+func NewPgTrashRep(ctx context.Context, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (*PgTrashRep, error) {
+	var resErr error
+	pgOnce.Do(func() {
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s",
+			pgCreds.Username, pgCreds.Password, pgCreds.Host, pgCreds.Port, pgCreds.DbName)
+		db, err := sql.Open("pgx", connStr)
+		if err != nil {
+			resErr = fmt.Errorf("NewPgTrashRep: %w: %w", ErrOpenConnect, err)
+			return
+		}
+		if err := db.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewPgTrashRep: %w: %w", ErrPing, err)
+			db.Close()
+			return
+		}
+		// Настраиваем пул соединений
+		db.SetMaxOpenConns(dbConf.MaxOpenConns)
+		db.SetMaxIdleConns(dbConf.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		pgInstance = &PgTrashRep{db: db}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return pgInstance, nil
+}
+
+func (pg *PgTrashRep) GetItems(ctx context.Context, entityType models.EntityType) ([]*models.TrashItem, error) {
+	query, err := selectTrashQuery(entityType)
+	if err != nil {
+		return nil, fmt.Errorf("PgTrashRep.GetItems: %w", err)
+	}
+	rows, err := pg.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("PgTrashRep.GetItems: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := parseTrashRows(rows, "")
+	if err != nil {
+		return nil, fmt.Errorf("PgTrashRep.GetItems: %w", err)
+	}
+	return res, nil
+}
+
+func (pg *PgTrashRep) Restore(ctx context.Context, entityType models.EntityType, id uuid.UUID) error {
+	t, err := getTrashTable(entityType)
+	if err != nil {
+		return fmt.Errorf("PgTrashRep.Restore: %w", err)
+	}
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgTrashRep.Restore: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	if blockersQuery, ok := restoreBlockersQueries[entityType]; ok {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(blockersQuery, "$1"), id)
+		if err != nil {
+			return fmt.Errorf("PgTrashRep.Restore: %w: %v", ErrQueryExec, err)
+		}
+		blockers, err := parseRestoreBlockers(rows)
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("PgTrashRep.Restore: %w", err)
+		}
+		if err := models.CheckDependents(blockers); err != nil {
+			return fmt.Errorf("PgTrashRep.Restore: %w", err)
+		}
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	restore := psql.Update(t.table).
+		Set("deletedAt", nil).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deletedAt": nil})
+	if t.touchUpdatedAt {
+		restore = restore.Set("updatedAt", sq.Expr("now()"))
+	}
+	query, args, err := restore.ToSql()
+	if err != nil {
+		return fmt.Errorf("PgTrashRep.Restore: %w: %v", ErrQueryBuilds, err)
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("PgTrashRep.Restore: %w: %v", ErrQueryExec, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("PgTrashRep.Restore: %w: %v", ErrRowsAffected, err)
+	} else if n == 0 {
+		return fmt.Errorf("PgTrashRep.Restore: %w", ErrTrashItemNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgTrashRep.Restore: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+// pgPurgeQueries удаляют записи корзины старше $1; связанные с произведениями записи удаляются каскадно.
+// Произведения удаляются первыми, чтобы следом можно было удалить их авторов и коллекции.
+// Коллекция с вложенными коллекциями остается до следующей очистки, после удаления вложенных
+var pgPurgeQueries = []struct {
+	entityType models.EntityType
+	query      string
+}{
+	{models.EntityArtwork, `
+		DELETE FROM Artworks
+		WHERE deletedAt < $1
+		RETURNING id, title, deletedAt`},
+	{models.EntityAuthor, `
+		DELETE FROM Author
+		WHERE deletedAt < $1
+			AND NOT EXISTS (SELECT 1 FROM Artworks WHERE Artworks.authorID = Author.id)
+			AND NOT EXISTS (SELECT 1 FROM Artwork_authors WHERE Artwork_authors.authorID = Author.id)
+		RETURNING id, name, deletedAt`},
+	{models.EntityCollection, `
+		DELETE FROM Collection
+		WHERE deletedAt < $1
+			AND NOT EXISTS (SELECT 1 FROM Artworks WHERE Artworks.collectionID = Collection.id)
+			AND NOT EXISTS (SELECT 1 FROM Collection child WHERE child.parentID = Collection.id)
+		RETURNING id, title, deletedAt`},
+}
+
+func (pg *PgTrashRep) Purge(ctx context.Context, deletedBefore time.Time) ([]*models.TrashItem, error) {
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("PgTrashRep.Purge: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	var res []*models.TrashItem
+	for _, q := range pgPurgeQueries {
+		rows, err := tx.QueryContext(ctx, q.query, deletedBefore)
+		if err != nil {
+			return nil, fmt.Errorf("PgTrashRep.Purge: %w: %v", ErrQueryExec, err)
+		}
+		items, err := parseTrashRows(rows, q.entityType)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("PgTrashRep.Purge: %w", err)
+		}
+		res = append(res, items...)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("PgTrashRep.Purge: %w: %v", ErrQueryExec, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package trashrep_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/pgtest"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/trashrep"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	th     *testHelper
+	pgOnce sync.Once
+)
+
+type testHelper struct {
+	ctx     context.Context
+	trep    *trashrep.PgTrashRep
+	arep    *authorrep.PgAuthorRep
+	pgCreds *cnfg.DatebaseCredentials
+}
+
+func setupTestHelper(t *testing.T) *testHelper {
+	ctx := context.Background()
+	pgOnce.Do(func() {
+		dbCnfg := cnfg.GetTestDatebaseConfig()
+
+		_, pgCreds, err := pgtest.GetTestPostgres(ctx)
+		require.NoError(t, err)
+
+		trep, err := trashrep.NewPgTrashRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+		arep, err := authorrep.NewPgAuthorRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+
+		th = &testHelper{
+			ctx:     ctx,
+			trep:    trep,
+			arep:    arep,
+			pgCreds: &pgCreds,
+		}
+	})
+	pgTestConfig := cnfg.GetPgTestConfig()
+	err := pgtest.MigrateUp(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := pgtest.MigrateDown(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+		require.NoError(t, err)
+	})
+
+	return th
+}
+
+// trashAuthor добавляет автора и переносит его в корзину
+func (th *testHelper) trashAuthor(t *testing.T, name string) *models.Author {
+	author, err := models.NewAuthor(uuid.New(), name, 1840, 1926)
+	require.NoError(t, err)
+	require.NoError(t, th.arep.Add(th.ctx, &author))
+	require.NoError(t, th.arep.Delete(th.ctx, author.GetID()))
+	return &author
+}
+
+func TestPgTrashRep_GetItemsAndRestore(t *testing.T) {
+	th := setupTestHelper(t)
+
+	author := th.trashAuthor(t, "Клод Моне")
+
+	_, err := th.arep.GetByID(th.ctx, author.GetID())
+	assert.ErrorIs(t, err, authorrep.ErrAuthorNotFound)
+
+	items, err := th.trep.GetItems(th.ctx, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, models.EntityAuthor, items[0].GetEntityType())
+	assert.Equal(t, author.GetID(), items[0].GetID())
+	assert.Equal(t, "Клод Моне", items[0].GetTitle())
+
+	items, err = th.trep.GetItems(th.ctx, models.EntityCollection)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	require.NoError(t, th.trep.Restore(th.ctx, models.EntityAuthor, author.GetID()))
+	_, err = th.arep.GetByID(th.ctx, author.GetID())
+	require.NoError(t, err)
+
+	err = th.trep.Restore(th.ctx, models.EntityAuthor, author.GetID())
+	assert.ErrorIs(t, err, trashrep.ErrTrashItemNotFound)
+}
+
+func TestPgTrashRep_Purge(t *testing.T) {
+	th := setupTestHelper(t)
+
+	author := th.trashAuthor(t, "Клод Моне")
+
+	purged, err := th.trep.Purge(th.ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	purged, err = th.trep.Purge(th.ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.Equal(t, author.GetID(), purged[0].GetID())
+
+	items, err := th.trep.GetItems(th.ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
@@ -0,0 +1,157 @@
+package trashrep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOpenConnect       = errors.New("open connect failed")
+	ErrPing              = errors.New("ping failed")
+	ErrQueryBuilds       = errors.New("query build failed")
+	ErrQueryExec         = errors.New("query execution failed")
+	ErrRowsAffected      = errors.New("no rows affected")
+	ErrTrashItemNotFound = errors.New("the record was not found in the trash")
+)
+
+// TrashRep работает с записями каталога, перенесёнными в корзину (deletedAt не NULL).
+// Перенос в корзину выполняют репозитории самих записей
+type TrashRep interface {
+	// GetItems возвращает записи корзины от недавно удалённых к давним; пустой entityType - записи всех типов
+	GetItems(ctx context.Context, entityType models.EntityType) ([]*models.TrashItem, error)
+	// Restore возвращает запись из корзины. Произведение нельзя восстановить, пока его автор
+	// или коллекция в корзине: они возвращаются в models.DependencyError
+	Restore(ctx context.Context, entityType models.EntityType, id uuid.UUID) error
+	// Purge окончательно удаляет записи, перенесённые в корзину раньше deletedBefore, и возвращает их.
+	// Авторы и коллекции, на которые ссылаются произведения из корзины, остаются до удаления этих произведений
+	Purge(ctx context.Context, deletedBefore time.Time) ([]*models.TrashItem, error)
+}
+
+func NewTrashRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (TrashRep, error) {
+	if datebaseType == cnfg.PostgresDB {
+		return NewPgTrashRep(ctx, pgCreds, dbConf)
+	} else if datebaseType == cnfg.ClickHouseDB {
+		return NewCHTrashRep(ctx, (*cnfg.ClickHouseCredentials)(pgCreds), dbConf)
+	} else {
+		return nil, fmt.Errorf("NewTrashRep: %w", cnfg.ErrUnknownDB)
+	}
+}
+
+// trashTable таблица записей, которые переносятся в корзину
+type trashTable struct {
+	entityType  models.EntityType
+	table       string
+	titleColumn string
+	// touchUpdatedAt восстановление из корзины отмечается в updatedAt, чтобы запись попала в выгрузку изменений
+	touchUpdatedAt bool
+}
+
+var trashTables = []trashTable{
+	{entityType: models.EntityArtwork, table: "Artworks", titleColumn: "title", touchUpdatedAt: true},
+	{entityType: models.EntityAuthor, table: "Author", titleColumn: "name"},
+	{entityType: models.EntityCollection, table: "Collection", titleColumn: "title"},
+}
+
+func getTrashTable(entityType models.EntityType) (trashTable, error) {
+	for _, t := range trashTables {
+		if t.entityType == entityType {
+			return t, nil
+		}
+	}
+	return trashTable{}, models.ErrTrashItemEntityType
+}
+
+// selectTrashQuery строит запрос записей корзины с колонками (entityType, id, title, deletedAt)
+func selectTrashQuery(entityType models.EntityType) (string, error) {
+	tables := trashTables
+	if entityType != "" {
+		t, err := getTrashTable(entityType)
+		if err != nil {
+			return "", err
+		}
+		tables = []trashTable{t}
+	}
+	parts := make([]string, len(tables))
+	for i, t := range tables {
+		parts[i] = fmt.Sprintf("SELECT '%s' AS entityType, id, %s AS title, deletedAt FROM %s WHERE deletedAt IS NOT NULL",
+			t.entityType, t.titleColumn, t.table)
+	}
+	return "SELECT entityType, id, title, deletedAt FROM (" + strings.Join(parts, " UNION ALL ") +
+		") AS trash ORDER BY deletedAt DESC", nil
+}
+
+// parseTrashRows читает записи корзины; entityType пустой, если тип задаёт первая колонка запроса
+func parseTrashRows(rows *sql.Rows, entityType models.EntityType) ([]*models.TrashItem, error) {
+	var res []*models.TrashItem
+	for rows.Next() {
+		var id uuid.UUID
+		var rowType, title string
+		var deletedAt sql.NullTime
+		var err error
+		if entityType == "" {
+			err = rows.Scan(&rowType, &id, &title, &deletedAt)
+		} else {
+			rowType = string(entityType)
+			err = rows.Scan(&id, &title, &deletedAt)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parseTrashRows: scan error: %v", err)
+		}
+		item, err := models.NewTrashItem(models.EntityType(rowType), id, title, deletedAt.Time)
+		if err != nil {
+			return nil, fmt.Errorf("parseTrashRows: %v", err)
+		}
+		res = append(res, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return res, nil
+}
+
+// restoreBlockersQueries записи в корзине, без которых нельзя восстановить запись:
+// для произведения - автор (основной или дополнительный) и коллекция, для коллекции - родительская коллекция.
+// Все параметры запроса - id восстанавливаемой записи
+var restoreBlockersQueries = map[models.EntityType]string{
+	models.EntityArtwork: `
+	SELECT 'author' AS entityType, id, name AS title
+	FROM Author
+	WHERE deletedAt IS NOT NULL
+		AND (id IN (SELECT authorID FROM Artworks WHERE id = %[1]s)
+			OR id IN (SELECT authorID FROM Artwork_authors WHERE artworkID = %[1]s))
+	UNION ALL
+	SELECT 'collection' AS entityType, id, title
+	FROM Collection
+	WHERE deletedAt IS NOT NULL
+		AND id IN (SELECT collectionID FROM Artworks WHERE id = %[1]s)`,
+	models.EntityCollection: `
+	SELECT 'collection' AS entityType, id, title
+	FROM Collection
+	WHERE deletedAt IS NOT NULL
+		AND id IN (SELECT parentID FROM Collection WHERE id = %[1]s)`,
+}
+
+func parseRestoreBlockers(rows *sql.Rows) ([]models.DependentRecord, error) {
+	var res []models.DependentRecord
+	for rows.Next() {
+		r := models.DependentRecord{InTrash: true}
+		var entityType string
+		if err := rows.Scan(&entityType, &r.ID, &r.Title); err != nil {
+			return nil, fmt.Errorf("parseRestoreBlockers: scan error: %v", err)
+		}
+		r.EntityType = models.EntityType(entityType)
+		res = append(res, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return res, nil
+}
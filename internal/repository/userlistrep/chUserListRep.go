@@ -0,0 +1,269 @@
+package userlistrep
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/google/uuid"
+)
+
+type CHUserListRep struct {
+	db *sql.DB
+}
+
+var (
+	chInstance *CHUserListRep
+	chOnce     sync.Once
+)
+
+func NewCHUserListRep(ctx context.Context, chCreds *cnfg.ClickHouseCredentials, dbConf *cnfg.DatebaseConfig) (*CHUserListRep, error) {
+	var resErr error
+	chOnce.Do(func() {
+		conn := clickhouse.OpenDB(&clickhouse.Options{
+			Addr: []string{fmt.Sprintf("%s:%d", chCreds.Host, chCreds.Port)},
+			Auth: clickhouse.Auth{
+				Database: chCreds.DbName,
+				Username: chCreds.Username,
+				Password: chCreds.Password,
+			},
+			Settings: clickhouse.Settings{
+				"max_execution_time": 60,
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+		})
+
+		if err := conn.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewCHUserListRep: %w: %v", ErrPing, err)
+			return
+		}
+
+		// Configure connection pool
+		conn.SetMaxOpenConns(dbConf.MaxOpenConns)
+		conn.SetMaxIdleConns(dbConf.MaxIdleConns)
+		conn.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		chInstance = &CHUserListRep{db: conn}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return chInstance, nil
+}
+
+func (ch *CHUserListRep) execChangeQuery(ctx context.Context, query string, args ...interface{}) error {
+	result, err := ch.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+
+	// ClickHouse has limited RowsAffected support, but we can still check for errors
+	_, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRowsAffected, err)
+	}
+	return nil
+}
+
+// count возвращает число строк по запросу SELECT count()
+func (ch *CHUserListRep) count(ctx context.Context, query string, args ...interface{}) (uint64, error) {
+	var n uint64
+	if err := ch.db.QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return n, nil
+}
+
+func (ch *CHUserListRep) GetFavourites(ctx context.Context, userID uuid.UUID) ([]*models.Favourite, error) {
+	query := "SELECT userID, entityType, entityID, createdAt FROM User_favourites WHERE userID = ? ORDER BY createdAt DESC"
+	rows, err := ch.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("CHUserListRep.GetFavourites: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := parseFavouriteRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("CHUserListRep.GetFavourites: %w", err)
+	}
+	return res, nil
+}
+
+// AddFavourite в ClickHouse проверяет наличие записи перед вставкой: MergeTree не обеспечивает уникальность ключа
+func (ch *CHUserListRep) AddFavourite(ctx context.Context, f *models.Favourite) error {
+	n, err := ch.count(ctx,
+		"SELECT count() FROM User_favourites WHERE userID = ? AND entityType = ? AND entityID = ?",
+		f.GetUserID(), string(f.GetEntityType()), f.GetEntityID())
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.AddFavourite: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+	query := "INSERT INTO User_favourites (userID, entityType, entityID, createdAt) VALUES (?, ?, ?, ?)"
+	if err := ch.execChangeQuery(ctx, query, f.GetUserID(), string(f.GetEntityType()), f.GetEntityID(), f.GetCreatedAt()); err != nil {
+		return fmt.Errorf("CHUserListRep.AddFavourite: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHUserListRep) DeleteFavourite(ctx context.Context, userID uuid.UUID, entityType models.EntityType, entityID uuid.UUID) error {
+	n, err := ch.count(ctx,
+		"SELECT count() FROM User_favourites WHERE userID = ? AND entityType = ? AND entityID = ?",
+		userID, string(entityType), entityID)
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.DeleteFavourite: %w", err)
+	}
+	if n == 0 {
+		return ErrFavouriteNotFound
+	}
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 2}))
+	query := "ALTER TABLE User_favourites DELETE WHERE userID = ? AND entityType = ? AND entityID = ?"
+	if err := ch.execChangeQuery(syncCtx, query, userID, string(entityType), entityID); err != nil {
+		return fmt.Errorf("CHUserListRep.DeleteFavourite: %w", err)
+	}
+	return nil
+}
+
+// selectLists загружает подборки по условию вместе с элементами
+func (ch *CHUserListRep) selectLists(ctx context.Context, where string, arg interface{}) ([]*models.UserList, error) {
+	query := "SELECT " + strings.Join(userListColumns, ", ") + " FROM User_lists WHERE " + where + " ORDER BY updatedAt DESC"
+	rows, err := ch.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var headers []userListRow
+	ids := uuid.UUIDs{}
+	for rows.Next() {
+		var h userListRow
+		if err := rows.Scan(h.dest()...); err != nil {
+			return nil, fmt.Errorf("%w: scan error: %v", ErrQueryExec, err)
+		}
+		headers = append(headers, h)
+		ids = append(ids, h.id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: rows iteration error: %v", ErrQueryExec, err)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	itemRows, err := ch.db.QueryContext(ctx,
+		"SELECT listID, entityType, entityID, note FROM User_list_items WHERE listID IN ? ORDER BY listID, position",
+		ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer itemRows.Close()
+
+	items, err := parseItemRows(itemRows)
+	if err != nil {
+		return nil, err
+	}
+	return buildLists(headers, items)
+}
+
+func (ch *CHUserListRep) GetLists(ctx context.Context, userID uuid.UUID) ([]*models.UserList, error) {
+	res, err := ch.selectLists(ctx, "userID = ?", userID)
+	if err != nil {
+		return nil, fmt.Errorf("CHUserListRep.GetLists: %w", err)
+	}
+	return res, nil
+}
+
+func (ch *CHUserListRep) GetListByID(ctx context.Context, id uuid.UUID) (*models.UserList, error) {
+	res, err := ch.selectLists(ctx, "id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("CHUserListRep.GetListByID: %w", err)
+	}
+	if len(res) == 0 {
+		return nil, ErrUserListNotFound
+	}
+	return res[0], nil
+}
+
+// insertItems записывает элементы подборки, позиции считаются с 1
+func (ch *CHUserListRep) insertItems(ctx context.Context, l *models.UserList) error {
+	if len(l.GetItems()) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(l.GetItems()))
+	args := make([]interface{}, 0, len(l.GetItems())*5)
+	for i, item := range l.GetItems() {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?)")
+		args = append(args, l.GetID(), string(item.GetEntityType()), item.GetEntityID(), int32(i+1), item.GetNote())
+	}
+	query := "INSERT INTO User_list_items (listID, entityType, entityID, position, note) VALUES " + strings.Join(placeholders, ", ")
+	return ch.execChangeQuery(ctx, query, args...)
+}
+
+func (ch *CHUserListRep) AddList(ctx context.Context, l *models.UserList) error {
+	query := "INSERT INTO User_lists (" + strings.Join(userListColumns, ", ") + ") VALUES (?, ?, ?, ?, ?, ?, ?)"
+	err := ch.execChangeQuery(ctx, query,
+		l.GetID(), l.GetUserID(), l.GetTitle(), l.GetDescription(), l.IsPublic(), l.GetCreatedAt(), l.GetUpdatedAt())
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.AddList: %w", err)
+	}
+	if err := ch.insertItems(ctx, l); err != nil {
+		return fmt.Errorf("CHUserListRep.AddList: %w", err)
+	}
+	return nil
+}
+
+// UpdateList в ClickHouse выполняется синхронными мутациями: элементы подборки удаляются и записываются заново
+func (ch *CHUserListRep) UpdateList(ctx context.Context, id uuid.UUID, funcUpdate func(*models.UserList) (*models.UserList, error)) error {
+	l, err := ch.GetListByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.UpdateList: %w", err)
+	}
+	updated, err := funcUpdate(l)
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.UpdateList: %w: %w", ErrUpdateUserList, err)
+	}
+
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 2}))
+	err = ch.execChangeQuery(syncCtx,
+		"ALTER TABLE User_lists UPDATE title = ?, description = ?, isPublic = ?, updatedAt = ? WHERE id = ?",
+		updated.GetTitle(), updated.GetDescription(), updated.IsPublic(), updated.GetUpdatedAt(), id)
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.UpdateList: %w", err)
+	}
+	if err := ch.execChangeQuery(syncCtx, "ALTER TABLE User_list_items DELETE WHERE listID = ?", id); err != nil {
+		return fmt.Errorf("CHUserListRep.UpdateList: %w", err)
+	}
+	if err := ch.insertItems(ctx, updated); err != nil {
+		return fmt.Errorf("CHUserListRep.UpdateList: %w", err)
+	}
+	return nil
+}
+
+func (ch *CHUserListRep) DeleteList(ctx context.Context, id uuid.UUID) error {
+	n, err := ch.count(ctx, "SELECT count() FROM User_lists WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("CHUserListRep.DeleteList: %w", err)
+	}
+	if n == 0 {
+		return ErrUserListNotFound
+	}
+	// в ClickHouse нет каскадного удаления, элементы подборки удаляются отдельно
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 2}))
+	if err := ch.execChangeQuery(syncCtx, "ALTER TABLE User_list_items DELETE WHERE listID = ?", id); err != nil {
+		return fmt.Errorf("CHUserListRep.DeleteList: %w", err)
+	}
+	if err := ch.execChangeQuery(syncCtx, "ALTER TABLE User_lists DELETE WHERE id = ?", id); err != nil {
+		return fmt.Errorf("CHUserListRep.DeleteList: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package userlistrep
+
+import (
+	"context"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserListRep реализует UserListRep интерфейс для тестирования
+type MockUserListRep struct {
+	mock.Mock
+}
+
+func (m *MockUserListRep) GetFavourites(ctx context.Context, userID uuid.UUID) ([]*models.Favourite, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Favourite), args.Error(1)
+}
+
+func (m *MockUserListRep) AddFavourite(ctx context.Context, f *models.Favourite) error {
+	args := m.Called(ctx, f)
+	return args.Error(0)
+}
+
+func (m *MockUserListRep) DeleteFavourite(ctx context.Context, userID uuid.UUID, entityType models.EntityType, entityID uuid.UUID) error {
+	args := m.Called(ctx, userID, entityType, entityID)
+	return args.Error(0)
+}
+
+func (m *MockUserListRep) GetLists(ctx context.Context, userID uuid.UUID) ([]*models.UserList, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserList), args.Error(1)
+}
+
+func (m *MockUserListRep) GetListByID(ctx context.Context, id uuid.UUID) (*models.UserList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserList), args.Error(1)
+}
+
+func (m *MockUserListRep) AddList(ctx context.Context, l *models.UserList) error {
+	args := m.Called(ctx, l)
+	return args.Error(0)
+}
+
+func (m *MockUserListRep) UpdateList(ctx context.Context, id uuid.UUID, funcUpdate func(*models.UserList) (*models.UserList, error)) error {
+	args := m.Called(ctx, id, funcUpdate)
+	return args.Error(0)
+}
+
+func (m *MockUserListRep) DeleteList(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
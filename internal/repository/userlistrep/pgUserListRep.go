@@ -0,0 +1,310 @@
+package userlistrep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type PgUserListRep struct {
+	db *sql.DB
+}
+
+var (
+	pgInstance *PgUserListRep
+	pgOnce     sync.Once
+)
+
+var (
+	ErrOpenConnect  = errors.New("open connect failed")
+	ErrPing         = errors.New("ping failed")
+	ErrQueryBuilds  = errors.New("query build failed")
+	ErrQueryExec    = errors.New("query execution failed")
+	ErrRowsAffected = errors.New("no rows affected")
+)
+
+func NewPgUserListRep(ctx context.Context, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (*PgUserListRep, error) {
+	var resErr error
+	pgOnce.Do(func() {
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s",
+			pgCreds.Username, pgCreds.Password, pgCreds.Host, pgCreds.Port, pgCreds.DbName)
+		db, err := sql.Open("pgx", connStr)
+		if err != nil {
+			resErr = fmt.Errorf("NewPgUserListRep: %w: %w", ErrOpenConnect, err)
+			return
+		}
+		if err := db.PingContext(ctx); err != nil {
+			resErr = fmt.Errorf("NewPgUserListRep: %w: %w", ErrPing, err)
+			db.Close()
+			return
+		}
+		// Настраиваем пул соединений
+		db.SetMaxOpenConns(dbConf.MaxOpenConns)
+		db.SetMaxIdleConns(dbConf.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(dbConf.ConnMaxLifetime.Hours()))
+
+		pgInstance = &PgUserListRep{db: db}
+	})
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return pgInstance, nil
+}
+
+func (pg *PgUserListRep) execChangeQuery(ctx context.Context, query sq.Sqlizer) (int64, error) {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	result, err := pg.db.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrRowsAffected, err)
+	}
+	return rowsAffected, nil
+}
+
+func (pg *PgUserListRep) GetFavourites(ctx context.Context, userID uuid.UUID) ([]*models.Favourite, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select("userID", "entityType", "entityID", "createdAt").
+		From("User_favourites").
+		Where(sq.Eq{"userID": userID}).
+		OrderBy("createdAt DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PgUserListRep.GetFavourites: %w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PgUserListRep.GetFavourites: %w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res, err := parseFavouriteRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("PgUserListRep.GetFavourites: %w", err)
+	}
+	return res, nil
+}
+
+func (pg *PgUserListRep) AddFavourite(ctx context.Context, f *models.Favourite) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Insert("User_favourites").
+		Columns("userID", "entityType", "entityID", "createdAt").
+		Values(f.GetUserID(), string(f.GetEntityType()), f.GetEntityID(), f.GetCreatedAt()).
+		Suffix("ON CONFLICT DO NOTHING")
+	if _, err := pg.execChangeQuery(ctx, query); err != nil {
+		return fmt.Errorf("PgUserListRep.AddFavourite: %w", err)
+	}
+	return nil
+}
+
+func (pg *PgUserListRep) DeleteFavourite(ctx context.Context, userID uuid.UUID, entityType models.EntityType, entityID uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Delete("User_favourites").
+		Where(sq.Eq{"userID": userID, "entityType": string(entityType), "entityID": entityID})
+	rowsAffected, err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.DeleteFavourite: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFavouriteNotFound
+	}
+	return nil
+}
+
+// selectLists загружает подборки по условию вместе с элементами
+func (pg *PgUserListRep) selectLists(ctx context.Context, where sq.Eq) ([]*models.UserList, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	querySQL, args, err := psql.Select(userListColumns...).
+		From("User_lists").
+		Where(where).
+		OrderBy("updatedAt DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	rows, err := pg.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	var headers []userListRow
+	ids := uuid.UUIDs{}
+	for rows.Next() {
+		var h userListRow
+		if err := rows.Scan(h.dest()...); err != nil {
+			return nil, fmt.Errorf("%w: scan error: %v", ErrQueryExec, err)
+		}
+		headers = append(headers, h)
+		ids = append(ids, h.id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: rows iteration error: %v", ErrQueryExec, err)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	itemsSQL, itemsArgs, err := psql.Select("listID", "entityType", "entityID", "note").
+		From("User_list_items").
+		Where(sq.Eq{"listID": ids}).
+		OrderBy("listID", "position").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	itemRows, err := pg.db.QueryContext(ctx, itemsSQL, itemsArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	defer itemRows.Close()
+
+	items, err := parseItemRows(itemRows)
+	if err != nil {
+		return nil, err
+	}
+	return buildLists(headers, items)
+}
+
+func (pg *PgUserListRep) GetLists(ctx context.Context, userID uuid.UUID) ([]*models.UserList, error) {
+	res, err := pg.selectLists(ctx, sq.Eq{"userID": userID})
+	if err != nil {
+		return nil, fmt.Errorf("PgUserListRep.GetLists: %w", err)
+	}
+	return res, nil
+}
+
+func (pg *PgUserListRep) GetListByID(ctx context.Context, id uuid.UUID) (*models.UserList, error) {
+	res, err := pg.selectLists(ctx, sq.Eq{"id": id})
+	if err != nil {
+		return nil, fmt.Errorf("PgUserListRep.GetListByID: %w", err)
+	}
+	if len(res) == 0 {
+		return nil, ErrUserListNotFound
+	}
+	return res[0], nil
+}
+
+// insertItems записывает элементы подборки, позиции считаются с 1
+func insertItems(ctx context.Context, tx *sql.Tx, l *models.UserList) error {
+	if len(l.GetItems()) == 0 {
+		return nil
+	}
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	insert := psql.Insert("User_list_items").Columns("listID", "entityType", "entityID", "position", "note")
+	for i, item := range l.GetItems() {
+		insert = insert.Values(l.GetID(), string(item.GetEntityType()), item.GetEntityID(), i+1, item.GetNote())
+	}
+	insertSQL, insertArgs, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, insertSQL, insertArgs...); err != nil {
+		return fmt.Errorf("%w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgUserListRep) AddList(ctx context.Context, l *models.UserList) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.AddList: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	insertSQL, insertArgs, err := psql.Insert("User_lists").
+		Columns(userListColumns...).
+		Values(l.GetID(), l.GetUserID(), l.GetTitle(), l.GetDescription(), l.IsPublic(), l.GetCreatedAt(), l.GetUpdatedAt()).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.AddList: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, insertSQL, insertArgs...); err != nil {
+		return fmt.Errorf("PgUserListRep.AddList: %w: %v", ErrQueryExec, err)
+	}
+	if err := insertItems(ctx, tx, l); err != nil {
+		return fmt.Errorf("PgUserListRep.AddList: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgUserListRep.AddList: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgUserListRep) UpdateList(ctx context.Context, id uuid.UUID, funcUpdate func(*models.UserList) (*models.UserList, error)) error {
+	l, err := pg.GetListByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w", err)
+	}
+	updated, err := funcUpdate(l)
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %w", ErrUpdateUserList, err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %v", ErrQueryExec, err)
+	}
+	defer tx.Rollback()
+
+	updateSQL, updateArgs, err := psql.Update("User_lists").
+		Set("title", updated.GetTitle()).
+		Set("description", updated.GetDescription()).
+		Set("isPublic", updated.IsPublic()).
+		Set("updatedAt", updated.GetUpdatedAt()).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, updateSQL, updateArgs...); err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %v", ErrQueryExec, err)
+	}
+	deleteSQL, deleteArgs, err := psql.Delete("User_list_items").Where(sq.Eq{"listID": id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %v", ErrQueryBuilds, err)
+	}
+	if _, err := tx.ExecContext(ctx, deleteSQL, deleteArgs...); err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %v", ErrQueryExec, err)
+	}
+	if err := insertItems(ctx, tx, updated); err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PgUserListRep.UpdateList: %w: %v", ErrQueryExec, err)
+	}
+	return nil
+}
+
+func (pg *PgUserListRep) DeleteList(ctx context.Context, id uuid.UUID) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	// элементы подборки удаляются каскадно
+	query := psql.Delete("User_lists").Where(sq.Eq{"id": id})
+	rowsAffected, err := pg.execChangeQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("PgUserListRep.DeleteList: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrUserListNotFound
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package userlistrep_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/pgtest"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userrep"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	th     *testHelper
+	pgOnce sync.Once
+)
+
+type testHelper struct {
+	ctx     context.Context
+	lrep    *userlistrep.PgUserListRep
+	urep    *userrep.PgUserRep
+	pgCreds *cnfg.DatebaseCredentials
+}
+
+func setupTestHelper(t *testing.T) *testHelper {
+	ctx := context.Background()
+	pgOnce.Do(func() {
+		dbCnfg := cnfg.GetTestDatebaseConfig()
+
+		_, pgCreds, err := pgtest.GetTestPostgres(ctx)
+		require.NoError(t, err)
+
+		lrep, err := userlistrep.NewPgUserListRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+		urep, err := userrep.NewPgUserRep(ctx, &pgCreds, dbCnfg)
+		require.NoError(t, err)
+
+		th = &testHelper{
+			ctx:     ctx,
+			lrep:    lrep,
+			urep:    urep,
+			pgCreds: &pgCreds,
+		}
+	})
+	pgTestConfig := cnfg.GetPgTestConfig()
+	err := pgtest.MigrateUp(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := pgtest.MigrateDown(ctx, pgTestConfig.MigrationDir, th.pgCreds)
+		require.NoError(t, err)
+	})
+
+	return th
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func (th *testHelper) createAndAddUser(t *testing.T, login string) *models.User {
+	user, err := models.NewUser(uuid.New(), "User "+login, login, "hashedPassword", now(), login+"@test.com", false)
+	require.NoError(t, err)
+	require.NoError(t, th.urep.Add(th.ctx, &user))
+	return &user
+}
+
+func (th *testHelper) createAndAddList(t *testing.T, user *models.User, title string, isPublic bool) *models.UserList {
+	l, err := models.NewUserListFromRequest(uuid.New(), user.GetID(),
+		jsonreqresp.UserListRequest{Title: title, IsPublic: isPublic}, now())
+	require.NoError(t, err)
+	require.NoError(t, th.lrep.AddList(th.ctx, &l))
+	return &l
+}
+
+func TestPgUserListRep_Favourites(t *testing.T) {
+	th := setupTestHelper(t)
+
+	user := th.createAndAddUser(t, "visitor")
+	artworkID, eventID := uuid.New(), uuid.New()
+	first, err := models.NewFavourite(user.GetID(), models.EntityArtwork, artworkID, now().Add(-time.Hour))
+	require.NoError(t, err)
+	second, err := models.NewFavourite(user.GetID(), models.EntityEvent, eventID, now())
+	require.NoError(t, err)
+
+	require.NoError(t, th.lrep.AddFavourite(th.ctx, &first))
+	require.NoError(t, th.lrep.AddFavourite(th.ctx, &second))
+
+	t.Run("repeated add is ignored", func(t *testing.T) {
+		require.NoError(t, th.lrep.AddFavourite(th.ctx, &first))
+		favourites, err := th.lrep.GetFavourites(th.ctx, user.GetID())
+		require.NoError(t, err)
+		require.Len(t, favourites, 2)
+		assert.Equal(t, eventID, favourites[0].GetEntityID())
+		assert.Equal(t, artworkID, favourites[1].GetEntityID())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, th.lrep.DeleteFavourite(th.ctx, user.GetID(), models.EntityEvent, eventID))
+		err := th.lrep.DeleteFavourite(th.ctx, user.GetID(), models.EntityEvent, eventID)
+		assert.ErrorIs(t, err, userlistrep.ErrFavouriteNotFound)
+
+		favourites, err := th.lrep.GetFavourites(th.ctx, user.GetID())
+		require.NoError(t, err)
+		assert.Len(t, favourites, 1)
+	})
+}
+
+func TestPgUserListRep_Lists(t *testing.T) {
+	th := setupTestHelper(t)
+
+	owner := th.createAndAddUser(t, "owner")
+	other := th.createAndAddUser(t, "other")
+	impressionists := th.createAndAddList(t, owner, "Мои импрессионисты", true)
+	th.createAndAddList(t, other, "Чужая подборка", false)
+
+	first, err := models.NewUserListItem(models.EntityArtwork, uuid.New(), "Начать с этой")
+	require.NoError(t, err)
+	second, err := models.NewUserListItem(models.EntityEvent, uuid.New(), "")
+	require.NoError(t, err)
+
+	t.Run("update keeps item order", func(t *testing.T) {
+		err := th.lrep.UpdateList(th.ctx, impressionists.GetID(), func(l *models.UserList) (*models.UserList, error) {
+			return l, l.SetItems([]models.UserListItem{second, first}, now())
+		})
+		require.NoError(t, err)
+
+		stored, err := th.lrep.GetListByID(th.ctx, impressionists.GetID())
+		require.NoError(t, err)
+		require.Len(t, stored.GetItems(), 2)
+		assert.Equal(t, second.GetEntityID(), stored.GetItems()[0].GetEntityID())
+		assert.Equal(t, "Начать с этой", stored.GetItems()[1].GetNote())
+		assert.True(t, stored.IsPublic())
+	})
+
+	t.Run("get lists of user", func(t *testing.T) {
+		lists, err := th.lrep.GetLists(th.ctx, owner.GetID())
+		require.NoError(t, err)
+		require.Len(t, lists, 1)
+		assert.Equal(t, "Мои импрессионисты", lists[0].GetTitle())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, th.lrep.DeleteList(th.ctx, impressionists.GetID()))
+		_, err := th.lrep.GetListByID(th.ctx, impressionists.GetID())
+		assert.ErrorIs(t, err, userlistrep.ErrUserListNotFound)
+		assert.ErrorIs(t, th.lrep.DeleteList(th.ctx, impressionists.GetID()), userlistrep.ErrUserListNotFound)
+	})
+}
@@ -0,0 +1,119 @@
+package userlistrep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/cnfg"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFavouriteNotFound    = errors.New("the Favourite was not found in the repository")
+	ErrUserListNotFound     = errors.New("the User list was not found in the repository")
+	ErrUserListItemNotFound = errors.New("the item was not found in the User list")
+	ErrUpdateUserList       = errors.New("err update User list params")
+)
+
+// UserListRep хранит избранное и подборки пользователей
+type UserListRep interface {
+	// GetFavourites возвращает избранное пользователя, добавленные последними - первыми
+	GetFavourites(ctx context.Context, userID uuid.UUID) ([]*models.Favourite, error)
+	// AddFavourite добавляет запись в избранное, повторное добавление не ошибка
+	AddFavourite(ctx context.Context, f *models.Favourite) error
+	DeleteFavourite(ctx context.Context, userID uuid.UUID, entityType models.EntityType, entityID uuid.UUID) error
+	// GetLists возвращает подборки пользователя с элементами, недавно измененные - первыми
+	GetLists(ctx context.Context, userID uuid.UUID) ([]*models.UserList, error)
+	GetListByID(ctx context.Context, id uuid.UUID) (*models.UserList, error)
+	AddList(ctx context.Context, l *models.UserList) error
+	// UpdateList сохраняет подборку вместе с элементами
+	UpdateList(ctx context.Context, id uuid.UUID, funcUpdate func(*models.UserList) (*models.UserList, error)) error
+	DeleteList(ctx context.Context, id uuid.UUID) error
+}
+
+func NewUserListRep(ctx context.Context, datebaseType string, pgCreds *cnfg.DatebaseCredentials, dbConf *cnfg.DatebaseConfig) (UserListRep, error) {
+	if datebaseType == cnfg.PostgresDB {
+		return NewPgUserListRep(ctx, pgCreds, dbConf)
+	} else if datebaseType == cnfg.ClickHouseDB {
+		return NewCHUserListRep(ctx, (*cnfg.ClickHouseCredentials)(pgCreds), dbConf)
+	} else {
+		return nil, fmt.Errorf("NewUserListRep: %w", cnfg.ErrUnknownDB)
+	}
+}
+
+func parseFavouriteRows(rows *sql.Rows) ([]*models.Favourite, error) {
+	var res []*models.Favourite
+	for rows.Next() {
+		var userID, entityID uuid.UUID
+		var entityType string
+		var createdAt time.Time
+		if err := rows.Scan(&userID, &entityType, &entityID, &createdAt); err != nil {
+			return nil, fmt.Errorf("parseFavouriteRows: scan error: %v", err)
+		}
+		f, err := models.NewFavourite(userID, models.EntityType(entityType), entityID, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parseFavouriteRows: %v", err)
+		}
+		res = append(res, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseFavouriteRows: rows iteration error: %v", err)
+	}
+	return res, nil
+}
+
+// userListRow заголовок подборки, элементы подгружаются отдельным запросом
+type userListRow struct {
+	id          uuid.UUID
+	userID      uuid.UUID
+	title       string
+	description string
+	isPublic    bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// userListColumns столбцы User_lists в порядке userListRow.dest
+var userListColumns = []string{"id", "userID", "title", "description", "isPublic", "createdAt", "updatedAt"}
+
+func (r *userListRow) dest() []any {
+	return []any{&r.id, &r.userID, &r.title, &r.description, &r.isPublic, &r.createdAt, &r.updatedAt}
+}
+
+// parseItemRows разбирает строки listID, entityType, entityID, note в порядке позиций
+func parseItemRows(rows *sql.Rows) (map[uuid.UUID][]models.UserListItem, error) {
+	res := make(map[uuid.UUID][]models.UserListItem)
+	for rows.Next() {
+		var listID, entityID uuid.UUID
+		var entityType, note string
+		if err := rows.Scan(&listID, &entityType, &entityID, &note); err != nil {
+			return nil, fmt.Errorf("parseItemRows: scan error: %v", err)
+		}
+		item, err := models.NewUserListItem(models.EntityType(entityType), entityID, note)
+		if err != nil {
+			return nil, fmt.Errorf("parseItemRows: %v", err)
+		}
+		res[listID] = append(res[listID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("parseItemRows: rows iteration error: %v", err)
+	}
+	return res, nil
+}
+
+// buildLists собирает подборки из заголовков и элементов
+func buildLists(headers []userListRow, items map[uuid.UUID][]models.UserListItem) ([]*models.UserList, error) {
+	res := make([]*models.UserList, 0, len(headers))
+	for _, h := range headers {
+		l, err := models.NewUserList(h.id, h.userID, h.title, h.description, h.isPublic, h.createdAt, h.updatedAt, items[h.id])
+		if err != nil {
+			return nil, fmt.Errorf("buildLists: %w: %v", models.ErrValidateUserList, err)
+		}
+		res = append(res, &l)
+	}
+	return res, nil
+}
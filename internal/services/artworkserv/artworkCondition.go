@@ -0,0 +1,174 @@
+package artworkserv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+)
+
+func (a *artworkService) GetConditionReports(ctx context.Context, idArt uuid.UUID) ([]*models.ConditionReport, error) {
+	if _, err := a.artworkRep.GetByID(ctx, idArt); err != nil {
+		return nil, fmt.Errorf("artworkService.GetConditionReports: %w", err)
+	}
+	reports, err := a.artworkRep.GetConditionReports(ctx, idArt)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.GetConditionReports: %w", err)
+	}
+	return reports, nil
+}
+
+// newConditionReport создает отчет по запросу, дата осмотра по умолчанию - текущее время
+func newConditionReport(
+	id uuid.UUID, idArt uuid.UUID, req jsonreqresp.ConditionReportRequest, photos []*models.ConditionPhoto,
+) (models.ConditionReport, error) {
+	checkedAt := req.CheckedAt
+	if checkedAt.IsZero() {
+		checkedAt = time.Now()
+	}
+	var treatmentEnd time.Time
+	if req.TreatmentEnd != nil {
+		treatmentEnd = *req.TreatmentEnd
+	}
+	report, err := models.NewConditionReport(
+		id,
+		idArt,
+		checkedAt,
+		req.Examiner,
+		models.ConditionGrade(req.Grade),
+		req.Notes,
+		req.Treatment,
+		treatmentEnd,
+		photos,
+	)
+	if err != nil {
+		return models.ConditionReport{}, fmt.Errorf("%w: %w", models.ErrValidateConditionReport, err)
+	}
+	return report, nil
+}
+
+// AddConditionReport сохраняет отчет об осмотре или о начатой реставрации
+func (a *artworkService) AddConditionReport(
+	ctx context.Context, idArt uuid.UUID, req jsonreqresp.ConditionReportRequest,
+) (*models.ConditionReport, error) {
+	if _, err := a.artworkRep.GetByID(ctx, idArt); err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionReport: %w", err)
+	}
+	report, err := newConditionReport(uuid.New(), idArt, req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionReport: %w", err)
+	}
+	if err := a.artworkRep.AddConditionReport(ctx, &report); err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionReport: %w", err)
+	}
+	return &report, nil
+}
+
+// UpdateConditionReport заменяет поля отчета, приложенные фотографии сохраняются
+func (a *artworkService) UpdateConditionReport(
+	ctx context.Context, idArt uuid.UUID, reportID uuid.UUID, req jsonreqresp.ConditionReportRequest,
+) (*models.ConditionReport, error) {
+	current, err := a.artworkRep.GetConditionReport(ctx, idArt, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.UpdateConditionReport: %w", err)
+	}
+	if req.CheckedAt.IsZero() {
+		req.CheckedAt = current.GetCheckedAt()
+	}
+	report, err := newConditionReport(reportID, idArt, req, current.GetPhotos())
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.UpdateConditionReport: %w", err)
+	}
+	if err := a.artworkRep.UpdateConditionReport(ctx, &report); err != nil {
+		return nil, fmt.Errorf("artworkService.UpdateConditionReport: %w", err)
+	}
+	return &report, nil
+}
+
+// CloseTreatment отмечает завершение реставрации, дата по умолчанию - текущее время
+func (a *artworkService) CloseTreatment(
+	ctx context.Context, idArt uuid.UUID, reportID uuid.UUID, end time.Time,
+) (*models.ConditionReport, error) {
+	report, err := a.artworkRep.GetConditionReport(ctx, idArt, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.CloseTreatment: %w", err)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if err := report.CloseTreatment(end); err != nil {
+		return nil, fmt.Errorf("artworkService.CloseTreatment: %w: %w", models.ErrValidateConditionReport, err)
+	}
+	if err := a.artworkRep.UpdateConditionReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("artworkService.CloseTreatment: %w", err)
+	}
+	return report, nil
+}
+
+// AddConditionPhoto проверяет загруженный файл и прикладывает его к отчету.
+// Фотографии хранятся в оригинале, без миниатюр.
+func (a *artworkService) AddConditionPhoto(
+	ctx context.Context, idArt uuid.UUID, reportID uuid.UUID, r io.Reader,
+) (*models.ConditionPhoto, error) {
+	report, err := a.artworkRep.GetConditionReport(ctx, idArt, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w", err)
+	}
+	if len(report.GetPhotos()) >= models.ConditionReportMaxPhotos {
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w: %w",
+			models.ErrValidateConditionReport, models.ErrConditionReportTooManyPhotos)
+	}
+
+	data, cfg, format, err := readImage(r)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w", err)
+	}
+	if cfg.Width*cfg.Height > models.ArtworkImageMaxPixels {
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w: %w",
+			models.ErrValidateArtworkImage, models.ErrArtworkImageTooLarge)
+	}
+	photo, err := models.NewConditionPhoto(uuid.New(), reportID, idArt, format, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w: %w", models.ErrValidateConditionPhoto, err)
+	}
+
+	if err := a.imageStorage.Save(ctx, photo.Key(), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w", err)
+	}
+	if err := a.artworkRep.AddConditionPhoto(ctx, &photo); err != nil {
+		a.imageStorage.Delete(ctx, photo.Key())
+		return nil, fmt.Errorf("artworkService.AddConditionPhoto: %w", err)
+	}
+	return &photo, nil
+}
+
+// GetConditionReportsNeedingAttention возвращает по одному, самому свежему, отчету на каждое произведение,
+// которому требуется реставратор: с незавершенной реставрацией или плохой оценкой последнего осмотра
+func (a *artworkService) GetConditionReportsNeedingAttention(ctx context.Context) ([]*models.ConditionReport, error) {
+	reports, err := a.artworkRep.GetConditionReportsNeedingAttention(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.GetConditionReportsNeedingAttention: %w", err)
+	}
+
+	latest := make(map[uuid.UUID]*models.ConditionReport, len(reports))
+	var artworkIDs uuid.UUIDs
+	for _, r := range reports {
+		prev, ok := latest[r.GetArtworkID()]
+		if !ok {
+			artworkIDs = append(artworkIDs, r.GetArtworkID())
+		}
+		if !ok || r.GetCheckedAt().After(prev.GetCheckedAt()) {
+			latest[r.GetArtworkID()] = r
+		}
+	}
+	res := make([]*models.ConditionReport, len(artworkIDs))
+	for i, id := range artworkIDs {
+		res[i] = latest[id]
+	}
+	return res, nil
+}
@@ -0,0 +1,197 @@
+package artworkserv_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestConditionReport(
+	t *testing.T, artworkID uuid.UUID, checkedAt time.Time, grade models.ConditionGrade, treatment string,
+) *models.ConditionReport {
+	report, err := models.NewConditionReport(uuid.New(), artworkID, checkedAt, "Анна Смирнова",
+		grade, "", treatment, time.Time{}, nil)
+	require.NoError(t, err)
+	return &report
+}
+
+func TestArtworkService_AddConditionReport(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	end := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		req           jsonreqresp.ConditionReportRequest
+		setupMocks    func(*artworkrep.MockArtworkRep)
+		expectedError error
+	}{
+		{
+			name: "open treatment, check date defaults to now",
+			req:  jsonreqresp.ConditionReportRequest{Examiner: "Анна Смирнова", Grade: "poor", Treatment: "Укрепление красочного слоя"},
+			setupMocks: func(art *artworkrep.MockArtworkRep) {
+				art.On("AddConditionReport", ctx, mock.MatchedBy(func(r *models.ConditionReport) bool {
+					return r.GetArtworkID() == artwork.GetID() && !r.GetCheckedAt().IsZero() &&
+						r.IsTreatmentOpen() && r.NeedsAttention()
+				})).Return(nil)
+			},
+		},
+		{
+			name: "treatment end before start",
+			req: jsonreqresp.ConditionReportRequest{
+				CheckedAt: time.Now(), Examiner: "Анна Смирнова", Grade: "good",
+				Treatment: "Чистка", TreatmentEnd: &end,
+			},
+			setupMocks:    func(*artworkrep.MockArtworkRep) {},
+			expectedError: models.ErrConditionReportInvalidEnd,
+		},
+		{
+			name:          "end without treatment",
+			req:           jsonreqresp.ConditionReportRequest{Examiner: "Анна Смирнова", Grade: "good", TreatmentEnd: &end},
+			setupMocks:    func(*artworkrep.MockArtworkRep) {},
+			expectedError: models.ErrConditionReportNoTreatment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artMock := &artworkrep.MockArtworkRep{}
+			artMock.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+			tt.setupMocks(artMock)
+			service := newArtworkTestService(artMock)
+
+			report, err := service.AddConditionReport(ctx, artwork.GetID(), tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.ErrorIs(t, err, models.ErrValidateConditionReport)
+				assert.Nil(t, report)
+			} else {
+				require.NoError(t, err)
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_CloseTreatment(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	checkedAt := time.Now().AddDate(0, -1, 0)
+
+	t.Run("success", func(t *testing.T) {
+		report := createTestConditionReport(t, artwork.GetID(), checkedAt, models.ConditionFair, "Реставрация рамы")
+		end := time.Now()
+		artMock := &artworkrep.MockArtworkRep{}
+		artMock.On("GetConditionReport", ctx, artwork.GetID(), report.GetID()).Return(report, nil)
+		artMock.On("UpdateConditionReport", ctx, mock.MatchedBy(func(r *models.ConditionReport) bool {
+			return !r.IsTreatmentOpen() && r.GetTreatmentEnd().Equal(end)
+		})).Return(nil)
+		service := newArtworkTestService(artMock)
+
+		closed, err := service.CloseTreatment(ctx, artwork.GetID(), report.GetID(), end)
+		require.NoError(t, err)
+		assert.False(t, closed.NeedsAttention())
+		artMock.AssertExpectations(t)
+	})
+
+	t.Run("report without treatment", func(t *testing.T) {
+		report := createTestConditionReport(t, artwork.GetID(), checkedAt, models.ConditionGood, "")
+		artMock := &artworkrep.MockArtworkRep{}
+		artMock.On("GetConditionReport", ctx, artwork.GetID(), report.GetID()).Return(report, nil)
+		service := newArtworkTestService(artMock)
+
+		_, err := service.CloseTreatment(ctx, artwork.GetID(), report.GetID(), time.Time{})
+		assert.ErrorIs(t, err, models.ErrConditionReportNoTreatment)
+		artMock.AssertNotCalled(t, "UpdateConditionReport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("report not found", func(t *testing.T) {
+		reportID := uuid.New()
+		artMock := &artworkrep.MockArtworkRep{}
+		artMock.On("GetConditionReport", ctx, artwork.GetID(), reportID).Return(nil, artworkrep.ErrConditionReportNotFound)
+		service := newArtworkTestService(artMock)
+
+		_, err := service.CloseTreatment(ctx, artwork.GetID(), reportID, time.Time{})
+		assert.ErrorIs(t, err, artworkrep.ErrConditionReportNotFound)
+	})
+}
+
+func TestArtworkService_AddConditionPhoto(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	pngData := createTestPNG(t, 40, 30)
+
+	t.Run("success", func(t *testing.T) {
+		report := createTestConditionReport(t, artwork.GetID(), time.Now(), models.ConditionPoor, "")
+		artMock := &artworkrep.MockArtworkRep{}
+		storage := &imagestorage.MockImageStorage{}
+		service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, storage, &historyserv.MockHistoryServ{})
+
+		var savedKey string
+		artMock.On("GetConditionReport", ctx, artwork.GetID(), report.GetID()).Return(report, nil)
+		storage.On("Save", ctx, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedKey = args.String(1)
+		}).Once()
+		artMock.On("AddConditionPhoto", ctx, mock.MatchedBy(func(p *models.ConditionPhoto) bool {
+			return p.GetReportID() == report.GetID() && p.GetFormat() == models.ImageFormatPNG
+		})).Return(nil)
+
+		photo, err := service.AddConditionPhoto(ctx, artwork.GetID(), report.GetID(), bytes.NewReader(pngData))
+		require.NoError(t, err)
+		assert.Equal(t, photo.Key(), savedKey)
+		artMock.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("too many photos", func(t *testing.T) {
+		report := createTestConditionReport(t, artwork.GetID(), time.Now(), models.ConditionPoor, "")
+		for i := 0; i < models.ConditionReportMaxPhotos; i++ {
+			photo, err := models.NewConditionPhoto(uuid.New(), report.GetID(), artwork.GetID(), models.ImageFormatJPEG, time.Now())
+			require.NoError(t, err)
+			require.NoError(t, report.AddPhoto(&photo))
+		}
+		artMock := &artworkrep.MockArtworkRep{}
+		storage := &imagestorage.MockImageStorage{}
+		service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, storage, &historyserv.MockHistoryServ{})
+		artMock.On("GetConditionReport", ctx, artwork.GetID(), report.GetID()).Return(report, nil)
+
+		_, err := service.AddConditionPhoto(ctx, artwork.GetID(), report.GetID(), bytes.NewReader(pngData))
+		assert.ErrorIs(t, err, models.ErrConditionReportTooManyPhotos)
+		storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestArtworkService_GetConditionReportsNeedingAttention(t *testing.T) {
+	ctx := context.Background()
+	first, second := uuid.New(), uuid.New()
+	now := time.Now()
+	oldTreatment := createTestConditionReport(t, first, now.AddDate(-1, 0, 0), models.ConditionFair, "Консервация")
+	latestCheck := createTestConditionReport(t, first, now, models.ConditionCritical, "")
+	otherCheck := createTestConditionReport(t, second, now.AddDate(0, -1, 0), models.ConditionPoor, "")
+
+	artMock := &artworkrep.MockArtworkRep{}
+	artMock.On("GetConditionReportsNeedingAttention", ctx).
+		Return([]*models.ConditionReport{oldTreatment, otherCheck, latestCheck}, nil)
+	service := newArtworkTestService(artMock)
+
+	reports, err := service.GetConditionReportsNeedingAttention(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, latestCheck.GetID(), reports[0].GetID())
+	assert.Equal(t, otherCheck.GetID(), reports[1].GetID())
+}
@@ -0,0 +1,309 @@
+package artworkserv_test
+
+import (
+	"context"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtworkService_AddDating(t *testing.T) {
+	ctx := context.Background()
+	testAuthor := createTestAuthor() // 1900 - 2000
+	testCollection := createTestCollection()
+	master, err := models.NewAuthor(uuid.New(), "Master", 1850, 1890)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		dating        *jsonreqresp.ArtworkDatingRequest
+		creationYear  int
+		authorRole    string
+		coAuthors     []jsonreqresp.AttributionRequest
+		expectedYear  int
+		expectedLabel string
+		expectedError error
+	}{
+		{
+			name:          "exact year without dating",
+			creationYear:  1950,
+			expectedYear:  1950,
+			expectedLabel: "1950",
+		},
+		{
+			name:          "circa before birth within tolerance",
+			dating:        &jsonreqresp.ArtworkDatingRequest{YearFrom: 1895, Qualifier: "circa"},
+			expectedYear:  1895,
+			expectedLabel: "ок. 1895",
+		},
+		{
+			name:          "period",
+			dating:        &jsonreqresp.ArtworkDatingRequest{YearFrom: 1990, YearTo: 1995},
+			expectedYear:  1992,
+			expectedLabel: "1990–1995",
+		},
+		{
+			name:          "decade",
+			dating:        &jsonreqresp.ArtworkDatingRequest{YearFrom: 1953, Precision: "decade"},
+			expectedYear:  1954,
+			expectedLabel: "1950-е",
+		},
+		{
+			name:          "follower after author's death",
+			dating:        &jsonreqresp.ArtworkDatingRequest{YearFrom: 2010},
+			authorRole:    "follower",
+			expectedYear:  2010,
+			expectedLabel: "2010",
+		},
+		{
+			name:          "workshop co-author after master's death",
+			creationYear:  1950,
+			coAuthors:     []jsonreqresp.AttributionRequest{{AuthorID: master.GetID().String(), Role: "workshop"}},
+			expectedYear:  1950,
+			expectedLabel: "1950",
+		},
+		{
+			name:          "author after death",
+			creationYear:  2010,
+			expectedError: models.ErrArtworkYearNotInRange,
+		},
+		{
+			name:          "before birth",
+			dating:        &jsonreqresp.ArtworkDatingRequest{YearFrom: 1850, YearTo: 1880},
+			expectedError: models.ErrArtworkYearNotInRange,
+		},
+		{
+			name:          "co-author after death",
+			creationYear:  1950,
+			coAuthors:     []jsonreqresp.AttributionRequest{{AuthorID: master.GetID().String()}},
+			expectedError: models.ErrArtworkYearNotInRange,
+		},
+		{
+			name:          "primary author repeated as co-author",
+			creationYear:  1950,
+			coAuthors:     []jsonreqresp.AttributionRequest{{AuthorID: testAuthor.GetID().String(), Role: "workshop"}},
+			expectedError: models.ErrArtworkDuplicateAuthor,
+		},
+		{
+			name:          "invalid qualifier",
+			dating:        &jsonreqresp.ArtworkDatingRequest{YearFrom: 1950, Qualifier: "maybe"},
+			expectedError: models.ErrDatingInvalidQualifier,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artMock := &artworkrep.MockArtworkRep{}
+			authMock := &authorrep.MockAuthorRep{}
+			colMock := &collectionrep.MockCollectionRep{}
+			authMock.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
+			authMock.On("GetByID", ctx, master.GetID()).Return(&master, nil)
+			colMock.On("GetCollectionByID", ctx, testCollection.GetID()).Return(testCollection, nil)
+			if tt.expectedError == nil {
+				artMock.On("Add", ctx, mock.MatchedBy(func(a *models.Artwork) bool {
+					return a.GetCreationYear() == tt.expectedYear &&
+						a.GetDating().Label() == tt.expectedLabel &&
+						len(a.GetCoAuthors()) == len(tt.coAuthors)
+				})).Return(nil)
+			}
+
+			req := createTestAddRequest(testAuthor.GetID(), testCollection.GetID())
+			req.CreationYear = tt.creationYear
+			req.Dating = tt.dating
+			req.AuthorRole = tt.authorRole
+			req.CoAuthors = tt.coAuthors
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
+			err := service.Add(ctx, req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, models.ErrValidateArtwork)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_UpdateDating(t *testing.T) {
+	ctx := context.Background()
+	testAuthor := createTestAuthor()
+	testCollection := createTestCollection()
+	pupil, err := models.NewAuthor(uuid.New(), "Pupil", 1930, 0)
+	require.NoError(t, err)
+
+	createDatedArtwork := func(t *testing.T) *models.Artwork {
+		dating, err := models.NewArtworkDating(1950, 1955, models.DateCirca, models.PrecisionYear)
+		require.NoError(t, err)
+		author, err := models.NewAttribution(testAuthor, models.RoleAttributed)
+		require.NoError(t, err)
+		coAuthor, err := models.NewAttribution(&pupil, models.RoleWorkshop)
+		require.NoError(t, err)
+		artwork, err := models.NewDatedArtwork(uuid.New(), "Test Artwork", "oil on canvas", "canvas", "100x100 cm",
+			dating, author, []models.Attribution{coAuthor}, testCollection)
+		require.NoError(t, err)
+		return &artwork
+	}
+
+	tests := []struct {
+		name           string
+		creationYear   int
+		coAuthors      []jsonreqresp.AttributionRequest
+		expectedLabel  string
+		expectedYear   int
+		expectedAuthor int
+	}{
+		{
+			name:           "unchanged year keeps dating and co-authors",
+			creationYear:   1952,
+			expectedLabel:  "ок. 1950–1955",
+			expectedYear:   1952,
+			expectedAuthor: 2,
+		},
+		{
+			name:           "changed year becomes exact",
+			creationYear:   1960,
+			expectedLabel:  "1960",
+			expectedYear:   1960,
+			expectedAuthor: 2,
+		},
+		{
+			name:           "empty co-authors removes them",
+			creationYear:   1952,
+			coAuthors:      []jsonreqresp.AttributionRequest{},
+			expectedLabel:  "ок. 1950–1955",
+			expectedYear:   1952,
+			expectedAuthor: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artwork := createDatedArtwork(t)
+			artMock := &artworkrep.MockArtworkRep{}
+			authMock := &authorrep.MockAuthorRep{}
+			colMock := &collectionrep.MockCollectionRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			authMock.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
+			colMock.On("GetCollectionByID", ctx, testCollection.GetID()).Return(testCollection, nil)
+			var updated *models.Artwork
+			change := &models.Change{Action: models.ChangeUpdate}
+			historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+			artMock.On("Update", ctx, artwork.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+				res, err := args.Get(2).(func(*models.Artwork) (*models.Artwork, error))(artwork)
+				require.NoError(t, err)
+				updated = res
+			})
+
+			req := createTestUpdateRequest(testAuthor.GetID(), testCollection.GetID())
+			req.CreationYear = tt.creationYear
+			req.CoAuthors = tt.coAuthors
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, historyMock)
+			err := service.Update(ctx, artwork.GetID(), req)
+
+			require.NoError(t, err)
+			require.NotNil(t, updated)
+			assert.Equal(t, tt.expectedLabel, updated.GetDating().Label())
+			assert.Equal(t, tt.expectedYear, updated.GetCreationYear())
+			assert.Equal(t, models.RoleAttributed, updated.GetAuthorRole())
+			assert.Len(t, updated.GetAttributions(), tt.expectedAuthor)
+		})
+	}
+}
+
+func TestArtworkService_RevertCoAuthors(t *testing.T) {
+	ctx := context.Background()
+	testAuthor := createTestAuthor()
+	testCollection := createTestCollection()
+	pupil, err := models.NewAuthor(uuid.New(), "Pupil", 1930, 0)
+	require.NoError(t, err)
+	follower, err := models.NewAuthor(uuid.New(), "Follower", 1940, 0)
+	require.NoError(t, err)
+
+	createArtwork := func(t *testing.T) *models.Artwork {
+		dating, err := models.NewArtworkDating(1950, 1955, models.DateCirca, models.PrecisionYear)
+		require.NoError(t, err)
+		author, err := models.NewAttribution(testAuthor, models.RoleAuthor)
+		require.NoError(t, err)
+		coAuthor, err := models.NewAttribution(&pupil, models.RoleWorkshop)
+		require.NoError(t, err)
+		artwork, err := models.NewDatedArtwork(uuid.New(), "Test Artwork", "oil on canvas", "canvas", "100x100 cm",
+			dating, author, []models.Attribution{coAuthor}, testCollection)
+		require.NoError(t, err)
+		return &artwork
+	}
+
+	tests := []struct {
+		name      string
+		coAuthors string
+		// oldVersion снимок сделан до появления дополнительных авторов в истории
+		oldVersion bool
+		wantIDs    uuid.UUIDs
+		wantRoles  []models.AttributionRole
+	}{
+		{
+			name:      "restores co-authors in saved order",
+			coAuthors: follower.GetID().String() + ":follower:2\n" + pupil.GetID().String() + ":circle:1",
+			wantIDs:   uuid.UUIDs{pupil.GetID(), follower.GetID()},
+			wantRoles: []models.AttributionRole{models.RoleCircle, models.RoleFollower},
+		},
+		{
+			name: "version without co-authors removes them",
+		},
+		{
+			name:       "snapshot before co-author history keeps current",
+			oldVersion: true,
+			wantIDs:    uuid.UUIDs{pupil.GetID()},
+			wantRoles:  []models.AttributionRole{models.RoleWorkshop},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artwork := createArtwork(t)
+			snapshot := artwork.Snapshot()
+			snapshot["title"] = "Old title"
+			snapshot["coAuthors"] = tt.coAuthors
+			if tt.oldVersion {
+				delete(snapshot, "coAuthors")
+			}
+			artMock := &artworkrep.MockArtworkRep{}
+			authMock := &authorrep.MockAuthorRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			authMock.On("GetByID", ctx, pupil.GetID()).Return(&pupil, nil).Maybe()
+			authMock.On("GetByID", ctx, follower.GetID()).Return(&follower, nil).Maybe()
+			historyMock.On("GetSnapshot", ctx, models.EntityArtwork, artwork.GetID(), 1).Return(snapshot, nil)
+			change := &models.Change{Action: models.ChangeRevert, RevertedTo: 1}
+			historyMock.On("Change", ctx, models.ChangeRevert, 1).Return(change)
+			artMock.On("Update", ctx, artwork.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+				_, err := args.Get(2).(func(*models.Artwork) (*models.Artwork, error))(artwork)
+				require.NoError(t, err)
+			})
+
+			service := artworkserv.NewArtworkService(artMock, authMock, &collectionrep.MockCollectionRep{},
+				&imagestorage.MockImageStorage{}, historyMock)
+			require.NoError(t, service.Revert(ctx, artwork.GetID(), 1))
+
+			assert.Equal(t, "Old title", artwork.GetTitle())
+			coAuthors := artwork.GetCoAuthors()
+			require.Len(t, coAuthors, len(tt.wantIDs))
+			for i, co := range coAuthors {
+				assert.Equal(t, tt.wantIDs[i], co.GetAuthor().GetID())
+				assert.Equal(t, tt.wantRoles[i], co.GetRole())
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package artworkserv
+
+import (
+	"context"
+	"fmt"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// MigrateDimensions разбирает текстовые размеры произведений, у которых нет структурированного размера.
+// Текстовый размер перезаписывается единообразным представлением, изменение сохраняется в истории.
+// При dryRun ничего не сохраняется. Нераспознанные размеры возвращаются в отчете.
+func (a *artworkService) MigrateDimensions(ctx context.Context, dryRun bool) (*models.DimensionsMigration, error) {
+	artworks, err := a.artworkRep.GetAllArtworks(ctx, &jsonreqresp.ArtworkFilter{}, &jsonreqresp.ArtworkSortOps{
+		Field:     jsonreqresp.TitleSortFieldArtwork,
+		Direction: jsonreqresp.ASCDirection,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.MigrateDimensions: %w", err)
+	}
+
+	report := &models.DimensionsMigration{DryRun: dryRun, Total: len(artworks)}
+	for _, art := range artworks {
+		if !art.GetDimensions().IsZero() {
+			report.Structured++
+			continue
+		}
+		dimensions, err := models.ParseDimensions(art.GetSize())
+		if err != nil {
+			report.Unparsed = append(report.Unparsed, models.UnparsedSize{
+				ArtworkID: art.GetID(),
+				Title:     art.GetTitle(),
+				Size:      art.GetSize(),
+				Reason:    err.Error(),
+			})
+			continue
+		}
+		report.Migrated++
+		if dryRun {
+			continue
+		}
+
+		err = a.artworkRep.Update(ctx, art.GetID(), func(a *models.Artwork) (*models.Artwork, error) {
+			a.SetDimensions(dimensions)
+			return a, nil
+		}, a.historyServ.Change(ctx, models.ChangeUpdate, 0))
+		if err != nil {
+			return nil, fmt.Errorf("artworkService.MigrateDimensions: %s: %w", art.GetID(), err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,288 @@
+package artworkserv_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArtworkWithSize(t *testing.T, size string) *models.Artwork {
+	artwork, err := models.NewArtwork(uuid.New(), "Test Artwork", "oil on canvas", "canvas", size, 1950,
+		createTestAuthor(), createTestCollection())
+	require.NoError(t, err)
+	return &artwork
+}
+
+func TestArtworkService_AddDimensions(t *testing.T) {
+	ctx := context.Background()
+	testAuthor := createTestAuthor()
+	testCollection := createTestCollection()
+
+	tests := []struct {
+		name           string
+		size           string
+		dimensions     *jsonreqresp.DimensionsRequest
+		expectedSize   string
+		expectedHeight float64
+		expectedError  error
+	}{
+		{
+			name:           "dimensions from request",
+			dimensions:     &jsonreqresp.DimensionsRequest{Height: 29, Width: 36, LengthUnit: "in", Weight: 3, WeightUnit: "kg"},
+			expectedSize:   "29 × 36 дюйм., 3 кг",
+			expectedHeight: 73.66,
+		},
+		{
+			name:           "dimensions parsed from size",
+			size:           "73,7 x 92,1 см",
+			expectedSize:   "73.7 × 92.1 см",
+			expectedHeight: 73.7,
+		},
+		{
+			name:         "unrecognized size kept as text",
+			size:         "диаметр 82 см",
+			expectedSize: "диаметр 82 см",
+		},
+		{
+			name:          "invalid weight unit",
+			dimensions:    &jsonreqresp.DimensionsRequest{Height: 10, Width: 10, LengthUnit: "cm", Weight: 1, WeightUnit: "oz"},
+			expectedError: models.ErrDimensionsWeightUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artMock := &artworkrep.MockArtworkRep{}
+			authMock := &authorrep.MockAuthorRep{}
+			colMock := &collectionrep.MockCollectionRep{}
+			authMock.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
+			colMock.On("GetCollectionByID", ctx, testCollection.GetID()).Return(testCollection, nil)
+			if tt.expectedError == nil {
+				artMock.On("Add", ctx, mock.MatchedBy(func(a *models.Artwork) bool {
+					return a.GetSize() == tt.expectedSize &&
+						math.Abs(a.GetDimensions().GetHeightCm()-tt.expectedHeight) < 1e-9
+				})).Return(nil)
+			}
+
+			req := createTestAddRequest(testAuthor.GetID(), testCollection.GetID())
+			req.Size = tt.size
+			req.Dimensions = tt.dimensions
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
+			err := service.Add(ctx, req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, models.ErrValidateArtwork)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_MigrateDimensions(t *testing.T) {
+	ctx := context.Background()
+
+	structured := createTestArtworkWithSize(t, "50 x 40 cm")
+	dimensions, err := models.ParseDimensions("50 x 40 cm")
+	require.NoError(t, err)
+	structured.SetDimensions(dimensions)
+	parsable := createTestArtworkWithSize(t, "120 x 80 x 5 cm; 12 kg")
+	unparsable := createTestArtworkWithSize(t, "диаметр 82 см")
+
+	tests := []struct {
+		name   string
+		dryRun bool
+	}{
+		{name: "dry run", dryRun: true},
+		{name: "migrate", dryRun: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artMock := &artworkrep.MockArtworkRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			artMock.On("GetAllArtworks", ctx, mock.Anything, mock.Anything).
+				Return([]*models.Artwork{structured, parsable, unparsable}, nil)
+
+			var updated *models.Artwork
+			if !tt.dryRun {
+				change := &models.Change{Action: models.ChangeUpdate}
+				historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+				artMock.On("Update", ctx, parsable.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+					art := *parsable
+					res, err := args.Get(2).(func(*models.Artwork) (*models.Artwork, error))(&art)
+					require.NoError(t, err)
+					updated = res
+				})
+			}
+
+			service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{},
+				&imagestorage.MockImageStorage{}, historyMock)
+			report, err := service.MigrateDimensions(ctx, tt.dryRun)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.dryRun, report.DryRun)
+			assert.Equal(t, 3, report.Total)
+			assert.Equal(t, 1, report.Structured)
+			assert.Equal(t, 1, report.Migrated)
+			require.Len(t, report.Unparsed, 1)
+			assert.Equal(t, unparsable.GetID(), report.Unparsed[0].ArtworkID)
+			assert.Equal(t, "диаметр 82 см", report.Unparsed[0].Size)
+			if !tt.dryRun {
+				require.NotNil(t, updated)
+				assert.Equal(t, "120 × 80 × 5 см, 12 кг", updated.GetSize())
+				assert.InDelta(t, 120, updated.GetDimensions().GetHeightCm(), 1e-9)
+				assert.InDelta(t, 5, updated.GetDimensions().GetDepthCm(), 1e-9)
+				assert.InDelta(t, 12, updated.GetDimensions().GetWeightKg(), 1e-9)
+			}
+			artMock.AssertExpectations(t)
+			historyMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_UpdateDimensions(t *testing.T) {
+	ctx := context.Background()
+	testAuthor := createTestAuthor()
+	testCollection := createTestCollection()
+
+	tests := []struct {
+		name           string
+		size           string
+		keepSize       bool
+		expectedSize   string
+		expectedHeight float64
+	}{
+		{
+			name:           "unchanged size keeps entered dimensions",
+			keepSize:       true,
+			expectedSize:   "73.67 × 92.1 см",
+			expectedHeight: 73.666,
+		},
+		{
+			name:           "changed size is parsed",
+			size:           "50 x 40 cm",
+			expectedSize:   "50 × 40 см",
+			expectedHeight: 50,
+		},
+		{
+			name:         "changed unrecognized size clears dimensions",
+			size:         "диаметр 82 см",
+			expectedSize: "диаметр 82 см",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artwork := createTestArtworkWithSize(t, "100x100 cm")
+			dimensions, err := models.NewDimensionsFromRequest(&jsonreqresp.DimensionsRequest{
+				Height: 73.666, Width: 92.1, LengthUnit: "cm",
+			})
+			require.NoError(t, err)
+			artwork.SetDimensions(dimensions)
+
+			artMock := &artworkrep.MockArtworkRep{}
+			authMock := &authorrep.MockAuthorRep{}
+			colMock := &collectionrep.MockCollectionRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			authMock.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
+			colMock.On("GetCollectionByID", ctx, testCollection.GetID()).Return(testCollection, nil)
+			change := &models.Change{Action: models.ChangeUpdate}
+			historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+			var updated *models.Artwork
+			artMock.On("Update", ctx, artwork.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+				res, err := args.Get(2).(func(*models.Artwork) (*models.Artwork, error))(artwork)
+				require.NoError(t, err)
+				updated = res
+			})
+
+			req := createTestUpdateRequest(testAuthor.GetID(), testCollection.GetID())
+			req.CreationYear = artwork.GetCreationYear()
+			req.Size = tt.size
+			if tt.keepSize {
+				req.Size = artwork.GetSize()
+			}
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, historyMock)
+
+			require.NoError(t, service.Update(ctx, artwork.GetID(), req))
+			require.NotNil(t, updated)
+			assert.Equal(t, tt.expectedSize, updated.GetSize())
+			assert.InDelta(t, tt.expectedHeight, updated.GetDimensions().GetHeightCm(), 1e-9)
+		})
+	}
+}
+
+func TestArtworkService_RevertDimensions(t *testing.T) {
+	ctx := context.Background()
+
+	entered, err := models.NewDimensionsFromRequest(&jsonreqresp.DimensionsRequest{
+		Height: 29.004, Width: 36, Depth: 2, LengthUnit: "in", Weight: 3, WeightUnit: "lb",
+	})
+	require.NoError(t, err)
+	edited, err := models.NewDimensionsFromRequest(&jsonreqresp.DimensionsRequest{
+		Height: 29, Width: 36, Depth: 2, LengthUnit: "in", Weight: 3, WeightUnit: "lb",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		saved models.Dimensions
+		size  string
+	}{
+		{name: "restores structured dimensions", saved: entered},
+		{name: "restores text-only size", size: "диаметр 82 см"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artwork := createTestArtworkWithSize(t, "100x100 cm")
+			if tt.size != "" {
+				artwork = createTestArtworkWithSize(t, tt.size)
+			}
+			artwork.SetDimensions(tt.saved)
+			before := *artwork
+			snapshot := artwork.Snapshot()
+
+			artwork.SetDimensions(edited)
+			fields := []string{}
+			for _, change := range models.DiffSnapshots(snapshot, artwork.Snapshot()) {
+				fields = append(fields, change.Field)
+			}
+			assert.Contains(t, fields, "heightCm")
+
+			artMock := &artworkrep.MockArtworkRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			historyMock.On("GetSnapshot", ctx, models.EntityArtwork, artwork.GetID(), 1).Return(snapshot, nil)
+			change := &models.Change{Action: models.ChangeRevert, RevertedTo: 1}
+			historyMock.On("Change", ctx, models.ChangeRevert, 1).Return(change)
+			artMock.On("Update", ctx, artwork.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+				_, err := args.Get(2).(func(*models.Artwork) (*models.Artwork, error))(artwork)
+				require.NoError(t, err)
+			})
+
+			service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{},
+				&imagestorage.MockImageStorage{}, historyMock)
+			require.NoError(t, service.Revert(ctx, artwork.GetID(), 1))
+
+			assert.Equal(t, before.GetSize(), artwork.GetSize())
+			assert.Equal(t, before.GetDimensions(), artwork.GetDimensions())
+			assert.Equal(t, snapshot, artwork.Snapshot())
+			artMock.AssertExpectations(t)
+		})
+	}
+}
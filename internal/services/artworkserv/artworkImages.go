@@ -0,0 +1,235 @@
+package artworkserv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrImageTooLarge      = errors.New("image file exceeds maximum size (10 MB)")
+	ErrImageDecode        = errors.New("failed to decode image")
+	ErrTooManyImages      = errors.New("artwork already has maximum number of images")
+	ErrImageOrderMismatch = errors.New("image order must list every image of the artwork exactly once")
+)
+
+const thumbnailJPEGQuality = 85
+
+func (a *artworkService) GetImages(ctx context.Context, idArt uuid.UUID) ([]*models.ArtworkImage, error) {
+	art, err := a.artworkRep.GetByID(ctx, idArt)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.GetImages: %w", err)
+	}
+	return art.GetImages(), nil
+}
+
+// AddImage проверяет загруженный файл, сохраняет оригинал и миниатюры в хранилище.
+// Первое изображение произведения становится основным.
+func (a *artworkService) AddImage(ctx context.Context, idArt uuid.UUID, r io.Reader) (*models.ArtworkImage, error) {
+	art, err := a.artworkRep.GetByID(ctx, idArt)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddImage: %w", err)
+	}
+	images := art.GetImages()
+	if len(images) >= models.ArtworkImageMaxCount {
+		return nil, fmt.Errorf("artworkService.AddImage: %w", ErrTooManyImages)
+	}
+
+	data, cfg, format, err := readImage(r)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddImage: %w", err)
+	}
+	img, err := models.NewArtworkImage(
+		uuid.New(),
+		idArt,
+		len(images),
+		len(images) == 0,
+		format,
+		cfg.Width,
+		cfg.Height,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddImage: %w: %w", models.ErrValidateArtworkImage, err)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddImage: %w: %v", ErrImageDecode, err)
+	}
+
+	if err := a.saveImageFiles(ctx, &img, data, decoded); err != nil {
+		a.imageStorage.Delete(ctx, img.StorageDir())
+		return nil, fmt.Errorf("artworkService.AddImage: %w", err)
+	}
+	if err := a.artworkRep.AddImage(ctx, &img); err != nil {
+		a.imageStorage.Delete(ctx, img.StorageDir())
+		return nil, fmt.Errorf("artworkService.AddImage: %w", err)
+	}
+	return &img, nil
+}
+
+// readImage читает загруженный файл с ограничением размера и определяет формат и размеры изображения.
+// Размеры проверяются до полного декодирования.
+func readImage(r io.Reader) ([]byte, image.Config, models.ImageFormat, error) {
+	data, err := io.ReadAll(io.LimitReader(r, models.ArtworkImageMaxSize+1))
+	if err != nil {
+		return nil, image.Config{}, "", fmt.Errorf("%w: %v", ErrImageDecode, err)
+	}
+	if len(data) > models.ArtworkImageMaxSize {
+		return nil, image.Config{}, "", ErrImageTooLarge
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, image.Config{}, "", fmt.Errorf("%w: %w", models.ErrValidateArtworkImage, models.ErrArtworkImageInvalidFormat)
+	} else if err != nil {
+		return nil, image.Config{}, "", fmt.Errorf("%w: %v", ErrImageDecode, err)
+	}
+	return data, cfg, models.ImageFormat(format), nil
+}
+
+func (a *artworkService) saveImageFiles(ctx context.Context, img *models.ArtworkImage, original []byte, decoded image.Image) error {
+	if err := a.imageStorage.Save(ctx, img.OriginalKey(), bytes.NewReader(original)); err != nil {
+		return err
+	}
+	for _, width := range models.ArtworkThumbnailWidths {
+		var buf bytes.Buffer
+		thumb := makeThumbnail(decoded, width)
+		if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return err
+		}
+		if err := a.imageStorage.Save(ctx, img.ThumbnailKey(width), &buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// makeThumbnail уменьшает изображение до заданной ширины с сохранением пропорций.
+// Изображения уже заданной ширины не увеличиваются, прозрачный фон заменяется белым.
+func makeThumbnail(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	if width > bounds.Dx() {
+		width = bounds.Dx()
+	}
+	height := int(math.Round(float64(bounds.Dy()) * float64(width) / float64(bounds.Dx())))
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+// DeleteImage удаляет изображение и перенумеровывает оставшиеся.
+// Если удалено основное изображение, основным становится первое из оставшихся.
+func (a *artworkService) DeleteImage(ctx context.Context, idArt uuid.UUID, imageID uuid.UUID) error {
+	art, err := a.artworkRep.GetByID(ctx, idArt)
+	if err != nil {
+		return fmt.Errorf("artworkService.DeleteImage: %w", err)
+	}
+
+	var deleted *models.ArtworkImage
+	remaining := make([]*models.ArtworkImage, 0, len(art.GetImages()))
+	for _, img := range art.GetImages() {
+		if img.GetID() == imageID {
+			deleted = img
+		} else {
+			remaining = append(remaining, img)
+		}
+	}
+	if deleted == nil {
+		return fmt.Errorf("artworkService.DeleteImage: %w", artworkrep.ErrArtworkImageNotFound)
+	}
+
+	if err := a.artworkRep.DeleteImage(ctx, idArt, imageID); err != nil {
+		return fmt.Errorf("artworkService.DeleteImage: %w", err)
+	}
+	if err := a.imageStorage.Delete(ctx, deleted.StorageDir()); err != nil {
+		return fmt.Errorf("artworkService.DeleteImage: %w", err)
+	}
+
+	if len(remaining) == 0 {
+		return nil
+	}
+	hasPrimary := false
+	for i, img := range remaining {
+		img.SetPosition(i)
+		hasPrimary = hasPrimary || img.IsPrimary()
+	}
+	if !hasPrimary {
+		remaining[0].SetPrimary(true)
+	}
+	if err := a.artworkRep.UpdateImages(ctx, idArt, remaining); err != nil {
+		return fmt.Errorf("artworkService.DeleteImage: %w", err)
+	}
+	return nil
+}
+
+// ReorderImages задает новый порядок изображений, imageIDs должен содержать все изображения произведения
+func (a *artworkService) ReorderImages(ctx context.Context, idArt uuid.UUID, imageIDs uuid.UUIDs) error {
+	art, err := a.artworkRep.GetByID(ctx, idArt)
+	if err != nil {
+		return fmt.Errorf("artworkService.ReorderImages: %w", err)
+	}
+
+	images := art.GetImages()
+	if len(imageIDs) != len(images) {
+		return fmt.Errorf("artworkService.ReorderImages: %w", ErrImageOrderMismatch)
+	}
+	byID := make(map[uuid.UUID]*models.ArtworkImage, len(images))
+	for _, img := range images {
+		byID[img.GetID()] = img
+	}
+	ordered := make([]*models.ArtworkImage, len(imageIDs))
+	for i, id := range imageIDs {
+		img, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("artworkService.ReorderImages: %w", ErrImageOrderMismatch)
+		}
+		delete(byID, id)
+		img.SetPosition(i)
+		ordered[i] = img
+	}
+
+	if err := a.artworkRep.UpdateImages(ctx, idArt, ordered); err != nil {
+		return fmt.Errorf("artworkService.ReorderImages: %w", err)
+	}
+	return nil
+}
+
+func (a *artworkService) SetPrimaryImage(ctx context.Context, idArt uuid.UUID, imageID uuid.UUID) error {
+	art, err := a.artworkRep.GetByID(ctx, idArt)
+	if err != nil {
+		return fmt.Errorf("artworkService.SetPrimaryImage: %w", err)
+	}
+
+	found := false
+	for _, img := range art.GetImages() {
+		img.SetPrimary(img.GetID() == imageID)
+		found = found || img.GetID() == imageID
+	}
+	if !found {
+		return fmt.Errorf("artworkService.SetPrimaryImage: %w", artworkrep.ErrArtworkImageNotFound)
+	}
+
+	if err := a.artworkRep.UpdateImages(ctx, idArt, art.GetImages()); err != nil {
+		return fmt.Errorf("artworkService.SetPrimaryImage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package artworkserv_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 100, 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func createTestImages(t *testing.T, artworkID uuid.UUID, cnt int) []*models.ArtworkImage {
+	images := make([]*models.ArtworkImage, cnt)
+	for i := range images {
+		img, err := models.NewArtworkImage(uuid.New(), artworkID, i, i == 0, models.ImageFormatJPEG, 800, 600, time.Now())
+		require.NoError(t, err)
+		images[i] = &img
+	}
+	return images
+}
+
+func TestArtworkService_AddImage(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	pngData := createTestPNG(t, 600, 300)
+
+	tests := []struct {
+		name          string
+		data          []byte
+		images        int
+		setupMocks    func(*artworkrep.MockArtworkRep, *imagestorage.MockImageStorage, map[string][]byte)
+		expectedError error
+		check         func(*testing.T, *models.ArtworkImage, map[string][]byte)
+	}{
+		{
+			name: "success, first image is primary",
+			data: pngData,
+			setupMocks: func(art *artworkrep.MockArtworkRep, s *imagestorage.MockImageStorage, saved map[string][]byte) {
+				s.On("Save", ctx, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					data, _ := io.ReadAll(args.Get(2).(io.Reader))
+					saved[args.String(1)] = data
+				}).Times(1 + len(models.ArtworkThumbnailWidths))
+				art.On("AddImage", ctx, mock.MatchedBy(func(img *models.ArtworkImage) bool {
+					return img.GetArtworkID() == artwork.GetID() && img.IsPrimary() && img.GetPosition() == 0
+				})).Return(nil)
+			},
+			check: func(t *testing.T, img *models.ArtworkImage, saved map[string][]byte) {
+				assert.Equal(t, models.ImageFormatPNG, img.GetFormat())
+				assert.Equal(t, 600, img.GetWidth())
+				assert.Equal(t, 300, img.GetHeight())
+				assert.Equal(t, pngData, saved[img.OriginalKey()])
+
+				// миниатюры уменьшаются с сохранением пропорций, но не увеличиваются
+				expected := map[int][2]int{160: {160, 80}, 480: {480, 240}, 1024: {600, 300}}
+				for width, size := range expected {
+					thumb, err := jpeg.Decode(bytes.NewReader(saved[img.ThumbnailKey(width)]))
+					require.NoError(t, err)
+					assert.Equal(t, size[0], thumb.Bounds().Dx())
+					assert.Equal(t, size[1], thumb.Bounds().Dy())
+				}
+			},
+		},
+		{
+			name:   "next image is appended",
+			data:   pngData,
+			images: 2,
+			setupMocks: func(art *artworkrep.MockArtworkRep, s *imagestorage.MockImageStorage, saved map[string][]byte) {
+				s.On("Save", ctx, mock.Anything, mock.Anything).Return(nil)
+				art.On("AddImage", ctx, mock.MatchedBy(func(img *models.ArtworkImage) bool {
+					return !img.IsPrimary() && img.GetPosition() == 2
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "unsupported format",
+			data:          []byte("GIF89a not really an image"),
+			setupMocks:    func(*artworkrep.MockArtworkRep, *imagestorage.MockImageStorage, map[string][]byte) {},
+			expectedError: models.ErrArtworkImageInvalidFormat,
+		},
+		{
+			name:          "file too large",
+			data:          make([]byte, models.ArtworkImageMaxSize+1),
+			setupMocks:    func(*artworkrep.MockArtworkRep, *imagestorage.MockImageStorage, map[string][]byte) {},
+			expectedError: artworkserv.ErrImageTooLarge,
+		},
+		{
+			name:          "too many images",
+			data:          pngData,
+			images:        models.ArtworkImageMaxCount,
+			setupMocks:    func(*artworkrep.MockArtworkRep, *imagestorage.MockImageStorage, map[string][]byte) {},
+			expectedError: artworkserv.ErrTooManyImages,
+		},
+		{
+			name: "repository error removes files",
+			data: pngData,
+			setupMocks: func(art *artworkrep.MockArtworkRep, s *imagestorage.MockImageStorage, saved map[string][]byte) {
+				s.On("Save", ctx, mock.Anything, mock.Anything).Return(nil)
+				art.On("AddImage", ctx, mock.Anything).Return(artworkrep.ErrQueryExec)
+				s.On("Delete", ctx, mock.Anything).Return(nil).Once()
+			},
+			expectedError: artworkrep.ErrQueryExec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artMock := &artworkrep.MockArtworkRep{}
+			storageMock := &imagestorage.MockImageStorage{}
+			saved := make(map[string][]byte)
+
+			testArtwork := *artwork
+			testArtwork.SetImages(createTestImages(t, artwork.GetID(), tt.images))
+			artMock.On("GetByID", ctx, artwork.GetID()).Return(&testArtwork, nil)
+			tt.setupMocks(artMock, storageMock, saved)
+
+			service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, storageMock, &historyserv.MockHistoryServ{})
+			img, err := service.AddImage(ctx, artwork.GetID(), bytes.NewReader(tt.data))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, img)
+			} else {
+				require.NoError(t, err)
+				if tt.check != nil {
+					tt.check(t, img, saved)
+				}
+			}
+
+			artMock.AssertExpectations(t)
+			storageMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_DeleteImage(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+
+	t.Run("primary deleted, next becomes primary", func(t *testing.T) {
+		images := createTestImages(t, artwork.GetID(), 3)
+		testArtwork := *artwork
+		testArtwork.SetImages(images)
+
+		artMock := &artworkrep.MockArtworkRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		artMock.On("GetByID", ctx, artwork.GetID()).Return(&testArtwork, nil)
+		artMock.On("DeleteImage", ctx, artwork.GetID(), images[0].GetID()).Return(nil)
+		storageMock.On("Delete", ctx, images[0].StorageDir()).Return(nil)
+		artMock.On("UpdateImages", ctx, artwork.GetID(), mock.MatchedBy(func(rest []*models.ArtworkImage) bool {
+			return len(rest) == 2 &&
+				rest[0].GetID() == images[1].GetID() && rest[0].GetPosition() == 0 && rest[0].IsPrimary() &&
+				rest[1].GetID() == images[2].GetID() && rest[1].GetPosition() == 1 && !rest[1].IsPrimary()
+		})).Return(nil)
+
+		service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, storageMock, &historyserv.MockHistoryServ{})
+		err := service.DeleteImage(ctx, artwork.GetID(), images[0].GetID())
+
+		require.NoError(t, err)
+		artMock.AssertExpectations(t)
+		storageMock.AssertExpectations(t)
+	})
+
+	t.Run("image not found", func(t *testing.T) {
+		testArtwork := *artwork
+		testArtwork.SetImages(createTestImages(t, artwork.GetID(), 1))
+
+		artMock := &artworkrep.MockArtworkRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		artMock.On("GetByID", ctx, artwork.GetID()).Return(&testArtwork, nil)
+
+		service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, storageMock, &historyserv.MockHistoryServ{})
+		err := service.DeleteImage(ctx, artwork.GetID(), uuid.New())
+
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkImageNotFound)
+		artMock.AssertNotCalled(t, "DeleteImage", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestArtworkService_ReorderImages(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+
+	tests := []struct {
+		name          string
+		order         func([]*models.ArtworkImage) uuid.UUIDs
+		expectedError error
+	}{
+		{
+			name: "success",
+			order: func(images []*models.ArtworkImage) uuid.UUIDs {
+				return uuid.UUIDs{images[2].GetID(), images[0].GetID(), images[1].GetID()}
+			},
+		},
+		{
+			name: "missing image",
+			order: func(images []*models.ArtworkImage) uuid.UUIDs {
+				return uuid.UUIDs{images[2].GetID(), images[0].GetID()}
+			},
+			expectedError: artworkserv.ErrImageOrderMismatch,
+		},
+		{
+			name: "duplicate image",
+			order: func(images []*models.ArtworkImage) uuid.UUIDs {
+				return uuid.UUIDs{images[2].GetID(), images[2].GetID(), images[1].GetID()}
+			},
+			expectedError: artworkserv.ErrImageOrderMismatch,
+		},
+		{
+			name: "foreign image",
+			order: func(images []*models.ArtworkImage) uuid.UUIDs {
+				return uuid.UUIDs{images[2].GetID(), uuid.New(), images[1].GetID()}
+			},
+			expectedError: artworkserv.ErrImageOrderMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := createTestImages(t, artwork.GetID(), 3)
+			testArtwork := *artwork
+			testArtwork.SetImages(images)
+			order := tt.order(images)
+
+			artMock := &artworkrep.MockArtworkRep{}
+			artMock.On("GetByID", ctx, artwork.GetID()).Return(&testArtwork, nil)
+			if tt.expectedError == nil {
+				artMock.On("UpdateImages", ctx, artwork.GetID(), mock.MatchedBy(func(ordered []*models.ArtworkImage) bool {
+					for i, img := range ordered {
+						if img.GetID() != order[i] || img.GetPosition() != i {
+							return false
+						}
+					}
+					return len(ordered) == len(order)
+				})).Return(nil)
+			}
+
+			service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
+			err := service.ReorderImages(ctx, artwork.GetID(), order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_SetPrimaryImage(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+
+	tests := []struct {
+		name          string
+		imageIdx      int
+		expectedError error
+	}{
+		{name: "success", imageIdx: 1},
+		{name: "not found", imageIdx: -1, expectedError: artworkrep.ErrArtworkImageNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := createTestImages(t, artwork.GetID(), 2)
+			testArtwork := *artwork
+			testArtwork.SetImages(images)
+			imageID := uuid.New()
+			if tt.imageIdx >= 0 {
+				imageID = images[tt.imageIdx].GetID()
+			}
+
+			artMock := &artworkrep.MockArtworkRep{}
+			artMock.On("GetByID", ctx, artwork.GetID()).Return(&testArtwork, nil)
+			if tt.expectedError == nil {
+				artMock.On("UpdateImages", ctx, artwork.GetID(), mock.MatchedBy(func(updated []*models.ArtworkImage) bool {
+					return !updated[0].IsPrimary() && updated[1].IsPrimary()
+				})).Return(nil)
+			}
+
+			service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
+			err := service.SetPrimaryImage(ctx, artwork.GetID(), imageID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_GetImagesNotFound(t *testing.T) {
+	ctx := context.Background()
+	artworkID := uuid.New()
+	artMock := &artworkrep.MockArtworkRep{}
+	artMock.On("GetByID", ctx, artworkID).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+
+	service := artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
+	_, err := service.GetImages(ctx, artworkID)
+
+	assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+}
@@ -0,0 +1,179 @@
+package artworkserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTooManyProvenanceEntries = errors.New("artwork already has maximum number of provenance entries")
+	ErrProvenanceOrderMismatch  = errors.New("provenance order must list every entry of the artwork exactly once")
+)
+
+// getProvenance возвращает записи провенанса существующего произведения
+func (a *artworkService) getProvenance(ctx context.Context, idArt uuid.UUID) ([]*models.ProvenanceEntry, error) {
+	if _, err := a.artworkRep.GetByID(ctx, idArt); err != nil {
+		return nil, err
+	}
+	return a.artworkRep.GetProvenance(ctx, idArt)
+}
+
+func (a *artworkService) GetProvenance(ctx context.Context, idArt uuid.UUID) ([]*models.ProvenanceEntry, error) {
+	entries, err := a.getProvenance(ctx, idArt)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.GetProvenance: %w", err)
+	}
+	return entries, nil
+}
+
+func newProvenanceEntry(
+	id uuid.UUID, idArt uuid.UUID, position int, req jsonreqresp.ProvenanceEntryRequest,
+) (models.ProvenanceEntry, error) {
+	entry, err := models.NewProvenanceEntry(
+		id,
+		idArt,
+		position,
+		req.Owner,
+		req.OwnerUncertain,
+		req.Location,
+		req.PeriodFrom,
+		req.PeriodTo,
+		models.DateQualifier(req.DateQualifier),
+		models.TransferMethod(req.TransferMethod),
+		req.Sources,
+		req.Notes,
+		req.IsPublic,
+	)
+	if err != nil {
+		return models.ProvenanceEntry{}, fmt.Errorf("%w: %w", models.ErrValidateProvenance, err)
+	}
+	return entry, nil
+}
+
+// AddProvenanceEntry добавляет запись в конец истории владения
+func (a *artworkService) AddProvenanceEntry(
+	ctx context.Context, idArt uuid.UUID, req jsonreqresp.ProvenanceEntryRequest,
+) (*models.ProvenanceEntry, error) {
+	entries, err := a.getProvenance(ctx, idArt)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddProvenanceEntry: %w", err)
+	}
+	if len(entries) >= models.ProvenanceMaxEntries {
+		return nil, fmt.Errorf("artworkService.AddProvenanceEntry: %w", ErrTooManyProvenanceEntries)
+	}
+
+	entry, err := newProvenanceEntry(uuid.New(), idArt, len(entries), req)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.AddProvenanceEntry: %w", err)
+	}
+	if err := a.artworkRep.AddProvenanceEntry(ctx, &entry); err != nil {
+		return nil, fmt.Errorf("artworkService.AddProvenanceEntry: %w", err)
+	}
+	return &entry, nil
+}
+
+// UpdateProvenanceEntry заменяет поля записи, позиция записи сохраняется
+func (a *artworkService) UpdateProvenanceEntry(
+	ctx context.Context, idArt uuid.UUID, entryID uuid.UUID, req jsonreqresp.ProvenanceEntryRequest,
+) (*models.ProvenanceEntry, error) {
+	entries, err := a.getProvenance(ctx, idArt)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.UpdateProvenanceEntry: %w", err)
+	}
+	var current *models.ProvenanceEntry
+	for _, e := range entries {
+		if e.GetID() == entryID {
+			current = e
+			break
+		}
+	}
+	if current == nil {
+		return nil, fmt.Errorf("artworkService.UpdateProvenanceEntry: %w", artworkrep.ErrProvenanceNotFound)
+	}
+
+	entry, err := newProvenanceEntry(entryID, idArt, current.GetPosition(), req)
+	if err != nil {
+		return nil, fmt.Errorf("artworkService.UpdateProvenanceEntry: %w", err)
+	}
+	if err := a.artworkRep.UpdateProvenance(ctx, idArt, []*models.ProvenanceEntry{&entry}); err != nil {
+		return nil, fmt.Errorf("artworkService.UpdateProvenanceEntry: %w", err)
+	}
+	return &entry, nil
+}
+
+// DeleteProvenanceEntry удаляет запись и перенумеровывает оставшиеся
+func (a *artworkService) DeleteProvenanceEntry(ctx context.Context, idArt uuid.UUID, entryID uuid.UUID) error {
+	entries, err := a.getProvenance(ctx, idArt)
+	if err != nil {
+		return fmt.Errorf("artworkService.DeleteProvenanceEntry: %w", err)
+	}
+
+	found := false
+	remaining := make([]*models.ProvenanceEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.GetID() == entryID {
+			found = true
+		} else {
+			remaining = append(remaining, e)
+		}
+	}
+	if !found {
+		return fmt.Errorf("artworkService.DeleteProvenanceEntry: %w", artworkrep.ErrProvenanceNotFound)
+	}
+
+	if err := a.artworkRep.DeleteProvenanceEntry(ctx, idArt, entryID); err != nil {
+		return fmt.Errorf("artworkService.DeleteProvenanceEntry: %w", err)
+	}
+
+	var moved []*models.ProvenanceEntry
+	for i, e := range remaining {
+		if e.GetPosition() != i {
+			e.SetPosition(i)
+			moved = append(moved, e)
+		}
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	if err := a.artworkRep.UpdateProvenance(ctx, idArt, moved); err != nil {
+		return fmt.Errorf("artworkService.DeleteProvenanceEntry: %w", err)
+	}
+	return nil
+}
+
+// ReorderProvenance задает новый порядок записей, entryIDs должен содержать все записи произведения
+func (a *artworkService) ReorderProvenance(ctx context.Context, idArt uuid.UUID, entryIDs uuid.UUIDs) error {
+	entries, err := a.getProvenance(ctx, idArt)
+	if err != nil {
+		return fmt.Errorf("artworkService.ReorderProvenance: %w", err)
+	}
+
+	if len(entryIDs) != len(entries) {
+		return fmt.Errorf("artworkService.ReorderProvenance: %w", ErrProvenanceOrderMismatch)
+	}
+	byID := make(map[uuid.UUID]*models.ProvenanceEntry, len(entries))
+	for _, e := range entries {
+		byID[e.GetID()] = e
+	}
+	ordered := make([]*models.ProvenanceEntry, len(entryIDs))
+	for i, id := range entryIDs {
+		e, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("artworkService.ReorderProvenance: %w", ErrProvenanceOrderMismatch)
+		}
+		delete(byID, id)
+		e.SetPosition(i)
+		ordered[i] = e
+	}
+
+	if err := a.artworkRep.UpdateProvenance(ctx, idArt, ordered); err != nil {
+		return fmt.Errorf("artworkService.ReorderProvenance: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package artworkserv_test
+
+import (
+	"context"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestProvenance(t *testing.T, artworkID uuid.UUID, cnt int) []*models.ProvenanceEntry {
+	entries := make([]*models.ProvenanceEntry, cnt)
+	for i := range entries {
+		entry, err := models.NewProvenanceEntry(uuid.New(), artworkID, i, "Owner", false, "Paris",
+			1900+i, 1901+i, models.DateExact, models.TransferPurchase, "", "", true)
+		require.NoError(t, err)
+		entries[i] = &entry
+	}
+	return entries
+}
+
+func newArtworkTestService(artMock *artworkrep.MockArtworkRep) artworkserv.ArtworkService {
+	return artworkserv.NewArtworkService(artMock, &authorrep.MockAuthorRep{}, &collectionrep.MockCollectionRep{}, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
+}
+
+func TestArtworkService_AddProvenanceEntry(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+
+	tests := []struct {
+		name          string
+		req           jsonreqresp.ProvenanceEntryRequest
+		existing      int
+		setupMocks    func(*artworkrep.MockArtworkRep)
+		expectedError error
+	}{
+		{
+			name: "success, appended to the end",
+			req: jsonreqresp.ProvenanceEntryRequest{
+				Owner: "Сергей Щукин", PeriodFrom: 1908, PeriodTo: 1918,
+				DateQualifier: "circa", TransferMethod: "purchase", IsPublic: true,
+			},
+			existing: 2,
+			setupMocks: func(art *artworkrep.MockArtworkRep) {
+				art.On("AddProvenanceEntry", ctx, mock.MatchedBy(func(e *models.ProvenanceEntry) bool {
+					return e.GetArtworkID() == artwork.GetID() && e.GetPosition() == 2 &&
+						e.GetDateQualifier() == models.DateCirca && e.PeriodLabel() == "ок. 1908–1918"
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "invalid period",
+			req:           jsonreqresp.ProvenanceEntryRequest{Owner: "Owner", PeriodFrom: 1920, PeriodTo: 1900},
+			setupMocks:    func(*artworkrep.MockArtworkRep) {},
+			expectedError: models.ErrProvenanceInvalidPeriod,
+		},
+		{
+			name:          "too many entries",
+			req:           jsonreqresp.ProvenanceEntryRequest{Owner: "Owner"},
+			existing:      models.ProvenanceMaxEntries,
+			setupMocks:    func(*artworkrep.MockArtworkRep) {},
+			expectedError: artworkserv.ErrTooManyProvenanceEntries,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artMock := &artworkrep.MockArtworkRep{}
+			artMock.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+			artMock.On("GetProvenance", ctx, artwork.GetID()).Return(createTestProvenance(t, artwork.GetID(), tt.existing), nil)
+			tt.setupMocks(artMock)
+			service := newArtworkTestService(artMock)
+
+			entry, err := service.AddProvenanceEntry(ctx, artwork.GetID(), tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, entry)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.existing, entry.GetPosition())
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtworkService_UpdateProvenanceEntry(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	req := jsonreqresp.ProvenanceEntryRequest{Owner: "Иван Морозов", TransferMethod: "gift"}
+
+	t.Run("position kept", func(t *testing.T) {
+		entries := createTestProvenance(t, artwork.GetID(), 3)
+		artMock := &artworkrep.MockArtworkRep{}
+		artMock.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+		artMock.On("GetProvenance", ctx, artwork.GetID()).Return(entries, nil)
+		artMock.On("UpdateProvenance", ctx, artwork.GetID(), mock.MatchedBy(func(es []*models.ProvenanceEntry) bool {
+			return len(es) == 1 && es[0].GetID() == entries[1].GetID() && es[0].GetPosition() == 1 &&
+				es[0].GetOwner() == "Иван Морозов" && es[0].GetTransferMethod() == models.TransferGift
+		})).Return(nil)
+		service := newArtworkTestService(artMock)
+
+		_, err := service.UpdateProvenanceEntry(ctx, artwork.GetID(), entries[1].GetID(), req)
+		require.NoError(t, err)
+		artMock.AssertExpectations(t)
+	})
+
+	t.Run("entry not found", func(t *testing.T) {
+		artMock := &artworkrep.MockArtworkRep{}
+		artMock.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+		artMock.On("GetProvenance", ctx, artwork.GetID()).Return(createTestProvenance(t, artwork.GetID(), 1), nil)
+		service := newArtworkTestService(artMock)
+
+		_, err := service.UpdateProvenanceEntry(ctx, artwork.GetID(), uuid.New(), req)
+		assert.ErrorIs(t, err, artworkrep.ErrProvenanceNotFound)
+	})
+}
+
+func TestArtworkService_DeleteProvenanceEntry(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	entries := createTestProvenance(t, artwork.GetID(), 3)
+
+	artMock := &artworkrep.MockArtworkRep{}
+	artMock.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+	artMock.On("GetProvenance", ctx, artwork.GetID()).Return(entries, nil)
+	artMock.On("DeleteProvenanceEntry", ctx, artwork.GetID(), entries[0].GetID()).Return(nil)
+	// сдвигаются только записи после удаленной
+	artMock.On("UpdateProvenance", ctx, artwork.GetID(), mock.MatchedBy(func(es []*models.ProvenanceEntry) bool {
+		return len(es) == 2 && es[0].GetID() == entries[1].GetID() && es[0].GetPosition() == 0 &&
+			es[1].GetID() == entries[2].GetID() && es[1].GetPosition() == 1
+	})).Return(nil)
+	service := newArtworkTestService(artMock)
+
+	require.NoError(t, service.DeleteProvenanceEntry(ctx, artwork.GetID(), entries[0].GetID()))
+	artMock.AssertExpectations(t)
+}
+
+func TestArtworkService_ReorderProvenance(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+
+	tests := []struct {
+		name          string
+		order         func([]*models.ProvenanceEntry) uuid.UUIDs
+		expectedError error
+	}{
+		{
+			name: "reversed",
+			order: func(es []*models.ProvenanceEntry) uuid.UUIDs {
+				return uuid.UUIDs{es[2].GetID(), es[1].GetID(), es[0].GetID()}
+			},
+		},
+		{
+			name: "missing entry",
+			order: func(es []*models.ProvenanceEntry) uuid.UUIDs {
+				return uuid.UUIDs{es[2].GetID(), es[1].GetID()}
+			},
+			expectedError: artworkserv.ErrProvenanceOrderMismatch,
+		},
+		{
+			name: "duplicate entry",
+			order: func(es []*models.ProvenanceEntry) uuid.UUIDs {
+				return uuid.UUIDs{es[2].GetID(), es[2].GetID(), es[0].GetID()}
+			},
+			expectedError: artworkserv.ErrProvenanceOrderMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := createTestProvenance(t, artwork.GetID(), 3)
+			order := tt.order(entries)
+			artMock := &artworkrep.MockArtworkRep{}
+			artMock.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+			artMock.On("GetProvenance", ctx, artwork.GetID()).Return(entries, nil)
+			if tt.expectedError == nil {
+				artMock.On("UpdateProvenance", ctx, artwork.GetID(), mock.MatchedBy(func(es []*models.ProvenanceEntry) bool {
+					for i, e := range es {
+						if e.GetID() != order[i] || e.GetPosition() != i {
+							return false
+						}
+					}
+					return len(es) == len(order)
+				})).Return(nil)
+			}
+			service := newArtworkTestService(artMock)
+
+			err := service.ReorderProvenance(ctx, artwork.GetID(), order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			artMock.AssertExpectations(t)
+		})
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +22,34 @@ type ArtworkService interface {
 	Add(ctx context.Context, artworkReq jsonreqresp.AddArtworkRequest) error
 	Delete(ctx context.Context, idArt uuid.UUID) error
 	Update(ctx context.Context, idArt uuid.UUID, updateFields jsonreqresp.ArtworkUpdate) error
+	// изображения произведения
+	GetImages(ctx context.Context, idArt uuid.UUID) ([]*models.ArtworkImage, error)
+	AddImage(ctx context.Context, idArt uuid.UUID, r io.Reader) (*models.ArtworkImage, error)
+	DeleteImage(ctx context.Context, idArt uuid.UUID, imageID uuid.UUID) error
+	ReorderImages(ctx context.Context, idArt uuid.UUID, imageIDs uuid.UUIDs) error
+	SetPrimaryImage(ctx context.Context, idArt uuid.UUID, imageID uuid.UUID) error
+	// история владения (провенанс) произведения
+	GetProvenance(ctx context.Context, idArt uuid.UUID) ([]*models.ProvenanceEntry, error)
+	AddProvenanceEntry(ctx context.Context, idArt uuid.UUID, req jsonreqresp.ProvenanceEntryRequest) (*models.ProvenanceEntry, error)
+	UpdateProvenanceEntry(
+		ctx context.Context, idArt uuid.UUID, entryID uuid.UUID, req jsonreqresp.ProvenanceEntryRequest,
+	) (*models.ProvenanceEntry, error)
+	DeleteProvenanceEntry(ctx context.Context, idArt uuid.UUID, entryID uuid.UUID) error
+	ReorderProvenance(ctx context.Context, idArt uuid.UUID, entryIDs uuid.UUIDs) error
+	// осмотры и реставрации произведения
+	GetConditionReports(ctx context.Context, idArt uuid.UUID) ([]*models.ConditionReport, error)
+	AddConditionReport(ctx context.Context, idArt uuid.UUID, req jsonreqresp.ConditionReportRequest) (*models.ConditionReport, error)
+	UpdateConditionReport(
+		ctx context.Context, idArt uuid.UUID, reportID uuid.UUID, req jsonreqresp.ConditionReportRequest,
+	) (*models.ConditionReport, error)
+	CloseTreatment(ctx context.Context, idArt uuid.UUID, reportID uuid.UUID, end time.Time) (*models.ConditionReport, error)
+	AddConditionPhoto(ctx context.Context, idArt uuid.UUID, reportID uuid.UUID, r io.Reader) (*models.ConditionPhoto, error)
+	GetConditionReportsNeedingAttention(ctx context.Context) ([]*models.ConditionReport, error)
+	// история изменений
+	GetHistory(ctx context.Context, idArt uuid.UUID) ([]*models.ChangeRecord, error)
+	Revert(ctx context.Context, idArt uuid.UUID, version int) error
+	// перевод текстовых размеров в структурированные
+	MigrateDimensions(ctx context.Context, dryRun bool) (*models.DimensionsMigration, error)
 }
 
 var (
@@ -29,13 +61,23 @@ type artworkService struct {
 	artworkRep    artworkrep.ArtworkRep
 	authorRep     authorrep.AuthorRep
 	collectionRep collectionrep.CollectionRep
+	imageStorage  imagestorage.ImageStorage
+	historyServ   historyserv.HistoryServ
 }
 
-func NewArtworkService(artRep artworkrep.ArtworkRep, authorRep authorrep.AuthorRep, collectionRep collectionrep.CollectionRep) ArtworkService {
+func NewArtworkService(
+	artRep artworkrep.ArtworkRep,
+	authorRep authorrep.AuthorRep,
+	collectionRep collectionrep.CollectionRep,
+	imageStorage imagestorage.ImageStorage,
+	historyServ historyserv.HistoryServ,
+) ArtworkService {
 	return &artworkService{
 		artworkRep:    artRep,
 		authorRep:     authorRep,
 		collectionRep: collectionRep,
+		imageStorage:  imageStorage,
+		historyServ:   historyServ,
 	}
 }
 
@@ -53,19 +95,42 @@ func (a *artworkService) Add(ctx context.Context, artworkReq jsonreqresp.AddArtw
 		return fmt.Errorf("artworkService.Add: %w", err)
 	}
 
-	artwork, err := models.NewArtwork(
+	coAuthors, err := a.resolveCoAuthors(ctx, artworkReq.CoAuthors)
+	if err != nil {
+		return fmt.Errorf("artworkService.Add: %w", err)
+	}
+	primary, err := models.NewAttribution(author, models.AttributionRole(artworkReq.AuthorRole))
+	if err != nil {
+		return fmt.Errorf("artworkService.Add: %w: %w", models.ErrValidateArtwork, err)
+	}
+	dating, err := models.ResolveDating(artworkReq.Dating, artworkReq.CreationYear)
+	if err != nil {
+		return fmt.Errorf("artworkService.Add: %w: %w", models.ErrValidateArtwork, err)
+	}
+
+	dimensions, err := models.ResolveDimensions(artworkReq.Dimensions, artworkReq.Size)
+	if err != nil {
+		return fmt.Errorf("artworkService.Add: %w: %w", models.ErrValidateArtwork, err)
+	}
+	size := artworkReq.Size
+	if !dimensions.IsZero() {
+		size = dimensions.String()
+	}
+	artwork, err := models.NewDatedArtwork(
 		uuid.New(),
 		artworkReq.Title,
 		artworkReq.Technic,
 		artworkReq.Material,
-		artworkReq.Size,
-		artworkReq.CreationYear,
-		author,
+		size,
+		dating,
+		primary,
+		coAuthors,
 		collection,
 	)
 	if err != nil {
 		return fmt.Errorf("artworkService.Add: %w: %w", models.ErrValidateArtwork, err)
 	}
+	artwork.SetDimensions(dimensions)
 
 	err = a.artworkRep.Add(ctx, &artwork)
 	if err != nil {
@@ -75,6 +140,7 @@ func (a *artworkService) Add(ctx context.Context, artworkReq jsonreqresp.AddArtw
 	return nil
 }
 
+// Delete переносит произведение в корзину; изображения удаляются при окончательной очистке корзины
 func (a *artworkService) Delete(ctx context.Context, idArt uuid.UUID) error {
 	return a.artworkRep.Delete(ctx, idArt)
 }
@@ -88,11 +154,73 @@ func (a *artworkService) Update(ctx context.Context, idArt uuid.UUID, updateFiel
 	if err != nil {
 		return fmt.Errorf("artworkService.Add: %w", err)
 	}
+	coAuthors, err := a.resolveCoAuthors(ctx, updateFields.CoAuthors)
+	if err != nil {
+		return fmt.Errorf("artworkService.Update: %w", err)
+	}
 	return a.artworkRep.Update(
 		ctx,
 		idArt,
 		func(a *models.Artwork) (*models.Artwork, error) {
-			err := a.Update(updateFields)
+			if updateFields.CoAuthors == nil {
+				coAuthors = a.GetCoAuthors()
+			}
+			err := a.UpdateWithCoAuthors(updateFields, coAuthors)
 			return a, err
-		})
+		},
+		a.historyServ.Change(ctx, models.ChangeUpdate, 0))
+}
+
+// resolveCoAuthors находит дополнительных авторов произведения по запросу
+func (a *artworkService) resolveCoAuthors(ctx context.Context, reqs []jsonreqresp.AttributionRequest) ([]models.Attribution, error) {
+	if reqs == nil {
+		return nil, nil
+	}
+	coAuthors := make([]models.Attribution, len(reqs))
+	for i, req := range reqs {
+		author, err := a.authorRep.GetByID(ctx, uuid.MustParse(req.AuthorID))
+		if err != nil {
+			return nil, err
+		}
+		if coAuthors[i], err = models.NewAttribution(author, models.AttributionRole(req.Role)); err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrValidateArtwork, err)
+		}
+	}
+	return coAuthors, nil
+}
+
+func (a *artworkService) GetHistory(ctx context.Context, idArt uuid.UUID) ([]*models.ChangeRecord, error) {
+	return a.historyServ.GetHistory(ctx, models.EntityArtwork, idArt)
+}
+
+// Revert возвращает поля и дополнительных авторов произведения к версии из истории изменений,
+// возврат сохраняется как новая версия. Основной автор и коллекция в истории не хранятся и остаются текущими.
+func (a *artworkService) Revert(ctx context.Context, idArt uuid.UUID, version int) error {
+	snapshot, err := a.historyServ.GetSnapshot(ctx, models.EntityArtwork, idArt, version)
+	if err != nil {
+		return fmt.Errorf("artworkService.Revert: %w", err)
+	}
+	coAuthorReqs, err := models.SnapshotCoAuthors(snapshot)
+	if err != nil {
+		return fmt.Errorf("artworkService.Revert: %w", err)
+	}
+	coAuthors, err := a.resolveCoAuthors(ctx, coAuthorReqs)
+	if err != nil {
+		return fmt.Errorf("artworkService.Revert: %w", err)
+	}
+	err = a.artworkRep.Update(
+		ctx,
+		idArt,
+		func(a *models.Artwork) (*models.Artwork, error) {
+			if coAuthorReqs == nil {
+				coAuthors = a.GetCoAuthors()
+			}
+			err := a.RestoreWithCoAuthors(snapshot, coAuthors)
+			return a, err
+		},
+		a.historyServ.Change(ctx, models.ChangeRevert, version))
+	if err != nil {
+		return fmt.Errorf("artworkService.Revert: %w", err)
+	}
+	return nil
 }
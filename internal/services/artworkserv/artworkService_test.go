@@ -9,7 +9,9 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/artworkserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -104,7 +106,7 @@ func TestArtworkService_GetAllArtworks(t *testing.T) {
 
 			tt.setupMocks(artMock)
 
-			service := artworkserv.NewArtworkService(artMock, authMock, colMock)
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
 			result, err := service.GetAll(ctx)
 
 			if tt.expectedError != nil {
@@ -164,7 +166,7 @@ func TestArtworkService_Add(t *testing.T) {
 
 			tt.setupMocks(artMock, authMock, colMock)
 
-			service := artworkserv.NewArtworkService(artMock, authMock, colMock)
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, &historyserv.MockHistoryServ{})
 			err := service.Add(ctx, testRequest)
 
 			if tt.expectedError != nil {
@@ -186,18 +188,27 @@ func TestArtworkService_Delete(t *testing.T) {
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*artworkrep.MockArtworkRep)
+		setupMocks    func(*artworkrep.MockArtworkRep, *imagestorage.MockImageStorage)
 		expectedError error
 	}{
 		{
 			name: "success",
-			setupMocks: func(m *artworkrep.MockArtworkRep) {
+			setupMocks: func(m *artworkrep.MockArtworkRep, s *imagestorage.MockImageStorage) {
 				m.On("Delete", ctx, artworkID).Return(nil)
 			},
 		},
+		{
+			name: "has upcoming events",
+			setupMocks: func(m *artworkrep.MockArtworkRep, s *imagestorage.MockImageStorage) {
+				m.On("Delete", ctx, artworkID).Return(&models.DependencyError{Records: []models.DependentRecord{
+					{EntityType: models.EntityEvent, ID: uuid.New(), Title: "Выставка"},
+				}})
+			},
+			expectedError: models.ErrHasDependents,
+		},
 		{
 			name: "not found",
-			setupMocks: func(m *artworkrep.MockArtworkRep) {
+			setupMocks: func(m *artworkrep.MockArtworkRep, s *imagestorage.MockImageStorage) {
 				m.On("Delete", ctx, artworkID).Return(artworkrep.ErrArtworkNotFound)
 			},
 			expectedError: artworkrep.ErrArtworkNotFound,
@@ -209,10 +220,11 @@ func TestArtworkService_Delete(t *testing.T) {
 			artMock := &artworkrep.MockArtworkRep{}
 			authMock := &authorrep.MockAuthorRep{}
 			colMock := &collectionrep.MockCollectionRep{}
+			storageMock := &imagestorage.MockImageStorage{}
 
-			tt.setupMocks(artMock)
+			tt.setupMocks(artMock, storageMock)
 
-			service := artworkserv.NewArtworkService(artMock, authMock, colMock)
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, storageMock, &historyserv.MockHistoryServ{})
 			err := service.Delete(ctx, artworkID)
 
 			if tt.expectedError != nil {
@@ -222,6 +234,7 @@ func TestArtworkService_Delete(t *testing.T) {
 			}
 
 			artMock.AssertExpectations(t)
+			storageMock.AssertExpectations(t)
 		})
 	}
 }
@@ -232,30 +245,38 @@ func TestArtworkService_Update(t *testing.T) {
 	testAuthor := createTestAuthor()
 	testCollection := createTestCollection()
 	testRequest := createTestUpdateRequest(testAuthor.GetID(), testCollection.GetID())
+	change := &models.Change{Action: models.ChangeUpdate}
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*artworkrep.MockArtworkRep, *authorrep.MockAuthorRep, *collectionrep.MockCollectionRep)
+		setupMocks    func(*artworkrep.MockArtworkRep, *authorrep.MockAuthorRep, *collectionrep.MockCollectionRep, *historyserv.MockHistoryServ)
 		expectedError error
 	}{
 		{
 			name: "success",
-			setupMocks: func(art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep) {
+			setupMocks: func(
+				art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep, history *historyserv.MockHistoryServ,
+			) {
 				auth.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
 				col.On("GetCollectionByID", ctx, testCollection.GetID()).Return(testCollection, nil)
-				art.On("Update", ctx, artworkID, mock.Anything).Return(nil)
+				history.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+				art.On("Update", ctx, artworkID, mock.Anything, change).Return(nil)
 			},
 		},
 		{
 			name: "author not found",
-			setupMocks: func(art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep) {
+			setupMocks: func(
+				art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep, history *historyserv.MockHistoryServ,
+			) {
 				auth.On("GetByID", ctx, testAuthor.GetID()).Return(nil, authorrep.ErrAuthorNotFound)
 			},
 			expectedError: authorrep.ErrAuthorNotFound,
 		},
 		{
 			name: "collection not found",
-			setupMocks: func(art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep) {
+			setupMocks: func(
+				art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep, history *historyserv.MockHistoryServ,
+			) {
 				auth.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
 				col.On("GetCollectionByID", ctx, testCollection.GetID()).Return(nil, collectionrep.ErrCollectionNotFound)
 			},
@@ -263,10 +284,13 @@ func TestArtworkService_Update(t *testing.T) {
 		},
 		{
 			name: "update error",
-			setupMocks: func(art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep) {
+			setupMocks: func(
+				art *artworkrep.MockArtworkRep, auth *authorrep.MockAuthorRep, col *collectionrep.MockCollectionRep, history *historyserv.MockHistoryServ,
+			) {
 				auth.On("GetByID", ctx, testAuthor.GetID()).Return(testAuthor, nil)
 				col.On("GetCollectionByID", ctx, testCollection.GetID()).Return(testCollection, nil)
-				art.On("Update", ctx, artworkID, mock.Anything).Return(artworkrep.ErrUpdateArtwork)
+				history.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+				art.On("Update", ctx, artworkID, mock.Anything, change).Return(artworkrep.ErrUpdateArtwork)
 			},
 			expectedError: artworkrep.ErrUpdateArtwork,
 		},
@@ -278,9 +302,11 @@ func TestArtworkService_Update(t *testing.T) {
 			authMock := &authorrep.MockAuthorRep{}
 			colMock := &collectionrep.MockCollectionRep{}
 
-			tt.setupMocks(artMock, authMock, colMock)
+			historyMock := &historyserv.MockHistoryServ{}
+
+			tt.setupMocks(artMock, authMock, colMock, historyMock)
 
-			service := artworkserv.NewArtworkService(artMock, authMock, colMock)
+			service := artworkserv.NewArtworkService(artMock, authMock, colMock, &imagestorage.MockImageStorage{}, historyMock)
 			err := service.Update(ctx, artworkID, testRequest)
 
 			if tt.expectedError != nil {
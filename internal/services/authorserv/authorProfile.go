@@ -0,0 +1,103 @@
+package authorserv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrPortraitTooLarge = errors.New("portrait file exceeds maximum size (5 MB)")
+	ErrPortraitDecode   = errors.New("failed to decode portrait")
+)
+
+func (s *authorServ) GetByID(ctx context.Context, idAuthor uuid.UUID) (*models.Author, error) {
+	return s.authorRep.GetByID(ctx, idAuthor)
+}
+
+// UpdateProfile заменяет профиль автора целиком, изменение сохраняется в истории
+func (s *authorServ) UpdateProfile(ctx context.Context, idAuthor uuid.UUID, req jsonreqresp.AuthorProfileRequest) error {
+	profile, err := models.NewAuthorProfile(req)
+	if err != nil {
+		return fmt.Errorf("authorServ.UpdateProfile: %w: %w", models.ErrValidateAuthorProfile, err)
+	}
+	err = s.authorRep.Update(ctx, idAuthor, func(a *models.Author) (*models.Author, error) {
+		err := a.SetProfile(profile)
+		return a, err
+	}, s.historyServ.Change(ctx, models.ChangeUpdate, 0))
+	if err != nil {
+		return fmt.Errorf("authorServ.UpdateProfile: %w", err)
+	}
+	return nil
+}
+
+// SetPortrait сохраняет новый портрет автора под новым ключом и удаляет прежний
+func (s *authorServ) SetPortrait(ctx context.Context, idAuthor uuid.UUID, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, models.AuthorPortraitMaxSize+1))
+	if err != nil {
+		return fmt.Errorf("authorServ.SetPortrait: %w: %v", ErrPortraitDecode, err)
+	}
+	if len(data) > models.AuthorPortraitMaxSize {
+		return fmt.Errorf("authorServ.SetPortrait: %w", ErrPortraitTooLarge)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) || (err == nil && !models.ImageFormat(format).IsValid()) {
+		return fmt.Errorf("authorServ.SetPortrait: %w: %w", models.ErrValidateAuthorProfile, models.ErrAuthorPortraitInvalidImage)
+	} else if err != nil {
+		return fmt.Errorf("authorServ.SetPortrait: %w: %v", ErrPortraitDecode, err)
+	}
+	if cfg.Width*cfg.Height > models.ArtworkImageMaxPixels {
+		return fmt.Errorf("authorServ.SetPortrait: %w: %w", models.ErrValidateAuthorProfile, models.ErrAuthorPortraitInvalidImage)
+	}
+
+	key := models.AuthorPortraitKey(idAuthor, models.ImageFormat(format))
+	if err := s.imageStorage.Save(ctx, key, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("authorServ.SetPortrait: %w", err)
+	}
+	oldKey, err := s.replacePortrait(ctx, idAuthor, key)
+	if err != nil {
+		s.imageStorage.Delete(ctx, key)
+		return fmt.Errorf("authorServ.SetPortrait: %w", err)
+	}
+	if oldKey != "" {
+		if err := s.imageStorage.Delete(ctx, oldKey); err != nil {
+			return fmt.Errorf("authorServ.SetPortrait: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeletePortrait убирает портрет автора, отсутствие портрета не ошибка
+func (s *authorServ) DeletePortrait(ctx context.Context, idAuthor uuid.UUID) error {
+	oldKey, err := s.replacePortrait(ctx, idAuthor, "")
+	if err != nil {
+		return fmt.Errorf("authorServ.DeletePortrait: %w", err)
+	}
+	if oldKey != "" {
+		if err := s.imageStorage.Delete(ctx, oldKey); err != nil {
+			return fmt.Errorf("authorServ.DeletePortrait: %w", err)
+		}
+	}
+	return nil
+}
+
+// replacePortrait записывает автору новый ключ портрета и возвращает прежний
+func (s *authorServ) replacePortrait(ctx context.Context, idAuthor uuid.UUID, key string) (string, error) {
+	var oldKey string
+	err := s.authorRep.Update(ctx, idAuthor, func(a *models.Author) (*models.Author, error) {
+		oldKey = a.GetPortrait()
+		a.SetPortrait(key)
+		return a, nil
+	}, nil)
+	return oldKey, err
+}
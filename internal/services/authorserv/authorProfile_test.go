@@ -0,0 +1,230 @@
+package authorserv_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/authorserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPortrait(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 30, 40))))
+	return buf.Bytes()
+}
+
+// applyUpdate применяет функцию изменения, переданную в MockAuthorRep.Update, к автору
+func applyUpdate(t *testing.T, author *models.Author) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		funcUpdate := args.Get(2).(func(*models.Author) (*models.Author, error))
+		_, err := funcUpdate(author)
+		require.NoError(t, err)
+	}
+}
+
+func TestAuthorService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+	change := &models.Change{Action: models.ChangeUpdate}
+
+	tests := []struct {
+		name          string
+		req           jsonreqresp.AuthorProfileRequest
+		setupMocks    func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ, *models.Author)
+		expectedError error
+		check         func(*testing.T, *models.Author)
+	}{
+		{
+			name: "success",
+			req: jsonreqresp.AuthorProfileRequest{
+				Biography:  "  Художник  ",
+				Movements:  []string{"Импрессионизм", "импрессионизм", "Пуантилизм"},
+				ULANID:     "500115588",
+				WikidataID: "q5582",
+			},
+			setupMocks: func(m *authorrep.MockAuthorRep, h *historyserv.MockHistoryServ, a *models.Author) {
+				h.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+				m.On("Update", ctx, authorID, mock.Anything, change).Return(nil).Run(applyUpdate(t, a))
+			},
+			check: func(t *testing.T, a *models.Author) {
+				p := a.GetProfile()
+				assert.Equal(t, "Художник", p.Biography)
+				assert.Equal(t, []string{"Импрессионизм", "Пуантилизм"}, p.Movements)
+				assert.Equal(t, "Q5582", p.WikidataID)
+				assert.Equal(t, "https://www.wikidata.org/wiki/Q5582", p.WikidataURL())
+				assert.Equal(t, "https://vocab.getty.edu/page/ulan/500115588", p.ULANURL())
+			},
+		},
+		{
+			name:          "invalid ULAN ID",
+			req:           jsonreqresp.AuthorProfileRequest{ULANID: "12345"},
+			setupMocks:    func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ, *models.Author) {},
+			expectedError: models.ErrAuthorInvalidULANID,
+		},
+		{
+			name:          "multiline place",
+			req:           jsonreqresp.AuthorProfileRequest{BirthPlace: "Зюндерт\nНидерланды"},
+			setupMocks:    func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ, *models.Author) {},
+			expectedError: models.ErrValidateAuthorProfile,
+		},
+		{
+			name:          "biography too long",
+			req:           jsonreqresp.AuthorProfileRequest{Biography: strings.Repeat("а", 20001)},
+			setupMocks:    func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ, *models.Author) {},
+			expectedError: models.ErrAuthorBiographyTooLong,
+		},
+		{
+			name: "author not found",
+			req:  jsonreqresp.AuthorProfileRequest{Nationality: "Франция"},
+			setupMocks: func(m *authorrep.MockAuthorRep, h *historyserv.MockHistoryServ, _ *models.Author) {
+				h.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+				m.On("Update", ctx, authorID, mock.Anything, change).Return(authorrep.ErrAuthorNotFound)
+			},
+			expectedError: authorrep.ErrAuthorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &authorrep.MockAuthorRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			author := createTestAuthor()
+			tt.setupMocks(mockRepo, historyMock, author)
+
+			service := authorserv.NewAuthorServ(mockRepo, historyMock, &imagestorage.MockImageStorage{})
+			err := service.UpdateProfile(ctx, authorID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				tt.check(t, author)
+			}
+
+			mockRepo.AssertExpectations(t)
+			historyMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthorService_SetPortrait(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+	portrait := createTestPortrait(t)
+	oldKey := models.AuthorPortraitKey(authorID, models.ImageFormatJPEG)
+
+	tests := []struct {
+		name          string
+		data          []byte
+		oldPortrait   string
+		setupMocks    func(*authorrep.MockAuthorRep, *imagestorage.MockImageStorage, *models.Author)
+		expectedError error
+	}{
+		{
+			name:        "success replaces old portrait",
+			data:        portrait,
+			oldPortrait: oldKey,
+			setupMocks: func(m *authorrep.MockAuthorRep, s *imagestorage.MockImageStorage, a *models.Author) {
+				s.On("Save", ctx, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, models.AuthorImagesDir(authorID)+"/portrait-") && strings.HasSuffix(key, ".png")
+				}), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					data, _ := io.ReadAll(args.Get(2).(io.Reader))
+					assert.Equal(t, portrait, data)
+				})
+				m.On("Update", ctx, authorID, mock.Anything, (*models.Change)(nil)).Return(nil).Run(applyUpdate(t, a))
+				s.On("Delete", ctx, oldKey).Return(nil)
+			},
+		},
+		{
+			name:          "not an image",
+			data:          []byte("GIF89a not really an image"),
+			setupMocks:    func(*authorrep.MockAuthorRep, *imagestorage.MockImageStorage, *models.Author) {},
+			expectedError: models.ErrAuthorPortraitInvalidImage,
+		},
+		{
+			name:          "file too large",
+			data:          make([]byte, models.AuthorPortraitMaxSize+1),
+			setupMocks:    func(*authorrep.MockAuthorRep, *imagestorage.MockImageStorage, *models.Author) {},
+			expectedError: authorserv.ErrPortraitTooLarge,
+		},
+		{
+			name: "repository error removes new file",
+			data: portrait,
+			setupMocks: func(m *authorrep.MockAuthorRep, s *imagestorage.MockImageStorage, _ *models.Author) {
+				s.On("Save", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("Update", ctx, authorID, mock.Anything, (*models.Change)(nil)).Return(authorrep.ErrAuthorNotFound)
+				s.On("Delete", ctx, mock.Anything).Return(nil).Once()
+			},
+			expectedError: authorrep.ErrAuthorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &authorrep.MockAuthorRep{}
+			storageMock := &imagestorage.MockImageStorage{}
+			author := createTestAuthor()
+			author.SetPortrait(tt.oldPortrait)
+			tt.setupMocks(mockRepo, storageMock, author)
+
+			service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, storageMock)
+			err := service.SetPortrait(ctx, authorID, bytes.NewReader(tt.data))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, oldKey, author.GetPortrait())
+				assert.NotEmpty(t, author.PortraitURL())
+			}
+
+			mockRepo.AssertExpectations(t)
+			storageMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthorService_DeletePortrait(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	t.Run("removes portrait file", func(t *testing.T) {
+		mockRepo := &authorrep.MockAuthorRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		author := createTestAuthor()
+		key := models.AuthorPortraitKey(authorID, models.ImageFormatPNG)
+		author.SetPortrait(key)
+		mockRepo.On("Update", ctx, authorID, mock.Anything, (*models.Change)(nil)).Return(nil).Run(applyUpdate(t, author))
+		storageMock.On("Delete", ctx, key).Return(nil)
+
+		service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, storageMock)
+		require.NoError(t, service.DeletePortrait(ctx, authorID))
+		assert.Empty(t, author.GetPortrait())
+		mockRepo.AssertExpectations(t)
+		storageMock.AssertExpectations(t)
+	})
+
+	t.Run("no portrait", func(t *testing.T) {
+		mockRepo := &authorrep.MockAuthorRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		mockRepo.On("Update", ctx, authorID, mock.Anything, (*models.Change)(nil)).Return(nil).Run(applyUpdate(t, createTestAuthor()))
+
+		service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, storageMock)
+		require.NoError(t, service.DeletePortrait(ctx, authorID))
+		storageMock.AssertExpectations(t)
+	})
+}
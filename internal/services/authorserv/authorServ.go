@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
 	"github.com/google/uuid"
 )
 
@@ -15,18 +19,35 @@ type AuthorServ interface {
 	Add(ctx context.Context, author *models.Author) error
 	Update(ctx context.Context, idAuthor uuid.UUID, updateReq models.AuthorUpdateReq) error
 	Delete(ctx context.Context, idAuthor uuid.UUID) error
+	// история изменений
+	GetHistory(ctx context.Context, idAuthor uuid.UUID) ([]*models.ChangeRecord, error)
+	Revert(ctx context.Context, idAuthor uuid.UUID, version int) error
+	// поиск и объединение дублей
+	FindDuplicates(ctx context.Context) ([]models.AuthorDuplicate, error)
+	Merge(ctx context.Context, survivorID uuid.UUID, duplicateID uuid.UUID) (int, error)
+	// профиль и портрет для публичной страницы автора
+	GetByID(ctx context.Context, idAuthor uuid.UUID) (*models.Author, error)
+	UpdateProfile(ctx context.Context, idAuthor uuid.UUID, req jsonreqresp.AuthorProfileRequest) error
+	SetPortrait(ctx context.Context, idAuthor uuid.UUID, r io.Reader) error
+	DeletePortrait(ctx context.Context, idAuthor uuid.UUID) error
 }
 
 var (
-	ErrHasLinkedArtworks = errors.New("author has linked artworks")
+	ErrMergeSameAuthor = errors.New("cannot merge author with itself")
 )
 
-func NewAuthorServ(authorRep authorrep.AuthorRep) AuthorServ {
-	return &authorServ{authorRep: authorRep}
+func NewAuthorServ(
+	authorRep authorrep.AuthorRep,
+	historyServ historyserv.HistoryServ,
+	imageStorage imagestorage.ImageStorage,
+) AuthorServ {
+	return &authorServ{authorRep: authorRep, historyServ: historyServ, imageStorage: imageStorage}
 }
 
 type authorServ struct {
-	authorRep authorrep.AuthorRep
+	authorRep    authorrep.AuthorRep
+	historyServ  historyserv.HistoryServ
+	imageStorage imagestorage.ImageStorage
 }
 
 func (s *authorServ) GetAll(ctx context.Context) ([]*models.Author, error) {
@@ -41,15 +62,53 @@ func (s *authorServ) Update(ctx context.Context, idAuthor uuid.UUID, updateReq m
 	return s.authorRep.Update(ctx, idAuthor, func(a *models.Author) (*models.Author, error) {
 		err := a.Update(updateReq)
 		return a, err
-	})
+	}, s.historyServ.Change(ctx, models.ChangeUpdate, 0))
 }
 
+// Delete переносит автора в корзину; произведения автора, которые мешают удалению, возвращаются в models.DependencyError
 func (s *authorServ) Delete(ctx context.Context, idAuthor uuid.UUID) error {
-	has, err := s.authorRep.HasArtworks(ctx, idAuthor)
+	return s.authorRep.Delete(ctx, idAuthor)
+}
+
+func (s *authorServ) GetHistory(ctx context.Context, idAuthor uuid.UUID) ([]*models.ChangeRecord, error) {
+	return s.historyServ.GetHistory(ctx, models.EntityAuthor, idAuthor)
+}
+
+// Revert возвращает поля автора к версии из истории изменений, возврат сохраняется как новая версия
+func (s *authorServ) Revert(ctx context.Context, idAuthor uuid.UUID, version int) error {
+	snapshot, err := s.historyServ.GetSnapshot(ctx, models.EntityAuthor, idAuthor, version)
 	if err != nil {
-		return fmt.Errorf("authorServ.Delete %w", err)
-	} else if has {
-		return ErrHasLinkedArtworks
+		return fmt.Errorf("authorServ.Revert: %w", err)
 	}
-	return s.authorRep.Delete(ctx, idAuthor)
+	err = s.authorRep.Update(ctx, idAuthor, func(a *models.Author) (*models.Author, error) {
+		err := a.Restore(snapshot)
+		return a, err
+	}, s.historyServ.Change(ctx, models.ChangeRevert, version))
+	if err != nil {
+		return fmt.Errorf("authorServ.Revert: %w", err)
+	}
+	return nil
+}
+
+// FindDuplicates возвращает пары вероятных дублей для проверки сотрудником, самые похожие первыми
+func (s *authorServ) FindDuplicates(ctx context.Context) ([]models.AuthorDuplicate, error) {
+	authors, err := s.authorRep.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authorServ.FindDuplicates: %w", err)
+	}
+	return models.FindAuthorDuplicates(authors, models.AuthorDuplicateMinScore), nil
+}
+
+// Merge передает произведения дубля основному автору и удаляет дубль. Объединение сохраняется
+// в истории обоих авторов: у дубля - ID основного автора, у основного автора - ID дубля.
+// Возвращает число произведений, у которых сменился автор
+func (s *authorServ) Merge(ctx context.Context, survivorID uuid.UUID, duplicateID uuid.UUID) (int, error) {
+	if survivorID == duplicateID {
+		return 0, ErrMergeSameAuthor
+	}
+	moved, err := s.authorRep.Merge(ctx, survivorID, duplicateID, s.historyServ.Change(ctx, models.ChangeMerge, 0))
+	if err != nil {
+		return 0, fmt.Errorf("authorServ.Merge: %w", err)
+	}
+	return moved, nil
 }
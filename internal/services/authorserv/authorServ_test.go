@@ -6,7 +6,9 @@ import (
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/authorserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -66,7 +68,7 @@ func TestAuthorService_GetAll(t *testing.T) {
 			mockRepo := &authorrep.MockAuthorRep{}
 			tt.setupMocks(mockRepo)
 
-			service := authorserv.NewAuthorServ(mockRepo)
+			service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, &imagestorage.MockImageStorage{})
 			result, err := service.GetAll(ctx)
 
 			if tt.expectedError != nil {
@@ -114,7 +116,7 @@ func TestAuthorService_Add(t *testing.T) {
 			mockRepo := &authorrep.MockAuthorRep{}
 			tt.setupMocks(mockRepo)
 
-			service := authorserv.NewAuthorServ(mockRepo)
+			service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, &imagestorage.MockImageStorage{})
 			err := service.Add(ctx, tt.author)
 
 			if tt.expectedError != nil {
@@ -132,29 +134,35 @@ func TestAuthorService_Update(t *testing.T) {
 	ctx := context.Background()
 	authorID := uuid.New()
 	testRequest := createTestUpdateRequest()
+	change := &models.Change{Action: models.ChangeUpdate}
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*authorrep.MockAuthorRep)
+		setupMocks    func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ)
 		expectedError error
 	}{
 		{
 			name: "success",
-			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("Update", ctx, authorID, mock.Anything).Return(nil)
+			setupMocks: func(m *authorrep.MockAuthorRep, h *historyserv.MockHistoryServ) {
+				m.On("Update", ctx, authorID, mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+					funcUpdate := args.Get(2).(func(*models.Author) (*models.Author, error))
+					updated, err := funcUpdate(createTestAuthor())
+					require.NoError(t, err)
+					assert.Equal(t, "Updated Author", updated.GetName())
+				})
 			},
 		},
 		{
 			name: "repository error",
-			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("Update", ctx, authorID, mock.Anything).Return(assert.AnError)
+			setupMocks: func(m *authorrep.MockAuthorRep, _ *historyserv.MockHistoryServ) {
+				m.On("Update", ctx, authorID, mock.Anything, change).Return(assert.AnError)
 			},
 			expectedError: assert.AnError,
 		},
 		{
 			name: "validation error",
-			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("Update", ctx, authorID, mock.Anything).
+			setupMocks: func(m *authorrep.MockAuthorRep, _ *historyserv.MockHistoryServ) {
+				m.On("Update", ctx, authorID, mock.Anything, change).
 					Return(models.ErrAuthorBirthAfterDeath)
 			},
 			expectedError: models.ErrAuthorBirthAfterDeath,
@@ -164,9 +172,11 @@ func TestAuthorService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := &authorrep.MockAuthorRep{}
-			tt.setupMocks(mockRepo)
+			historyMock := &historyserv.MockHistoryServ{}
+			historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+			tt.setupMocks(mockRepo, historyMock)
 
-			service := authorserv.NewAuthorServ(mockRepo)
+			service := authorserv.NewAuthorServ(mockRepo, historyMock, &imagestorage.MockImageStorage{})
 			err := service.Update(ctx, authorID, testRequest)
 
 			if tt.expectedError != nil {
@@ -176,6 +186,7 @@ func TestAuthorService_Update(t *testing.T) {
 			}
 
 			mockRepo.AssertExpectations(t)
+			historyMock.AssertExpectations(t)
 		})
 	}
 }
@@ -192,28 +203,21 @@ func TestAuthorService_Delete(t *testing.T) {
 		{
 			name: "success",
 			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("HasArtworks", ctx, authorID).Return(false, nil)
 				m.On("Delete", ctx, authorID).Return(nil)
 			},
 		},
 		{
 			name: "has linked artworks",
 			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("HasArtworks", ctx, authorID).Return(true, nil)
+				m.On("Delete", ctx, authorID).Return(&models.DependencyError{Records: []models.DependentRecord{
+					{EntityType: models.EntityArtwork, ID: uuid.New(), Title: "Test Artwork"},
+				}})
 			},
-			expectedError: authorserv.ErrHasLinkedArtworks,
-		},
-		{
-			name: "has artworks check error",
-			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("HasArtworks", ctx, authorID).Return(false, assert.AnError)
-			},
-			expectedError: assert.AnError,
+			expectedError: models.ErrHasDependents,
 		},
 		{
 			name: "delete error",
 			setupMocks: func(m *authorrep.MockAuthorRep) {
-				m.On("HasArtworks", ctx, authorID).Return(false, nil)
 				m.On("Delete", ctx, authorID).Return(assert.AnError)
 			},
 			expectedError: assert.AnError,
@@ -225,7 +229,7 @@ func TestAuthorService_Delete(t *testing.T) {
 			mockRepo := &authorrep.MockAuthorRep{}
 			tt.setupMocks(mockRepo)
 
-			service := authorserv.NewAuthorServ(mockRepo)
+			service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, &imagestorage.MockImageStorage{})
 			err := service.Delete(ctx, authorID)
 
 			if tt.expectedError != nil {
@@ -238,3 +242,202 @@ func TestAuthorService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorService_Revert(t *testing.T) {
+	ctx := context.Background()
+	author := createTestAuthor()
+	version1 := models.Snapshot{"name": "Old Name", "birthYear": "1899", "deathYear": "1950"}
+
+	runUpdate := func(args mock.Arguments) {
+		funcUpdate := args.Get(2).(func(*models.Author) (*models.Author, error))
+		_, _ = funcUpdate(author)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := &authorrep.MockAuthorRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		historyMock.On("GetSnapshot", ctx, models.EntityAuthor, author.GetID(), 1).Return(version1, nil)
+		change := &models.Change{Action: models.ChangeRevert, RevertedTo: 1}
+		historyMock.On("Change", ctx, models.ChangeRevert, 1).Return(change)
+		mockRepo.On("Update", ctx, author.GetID(), mock.Anything, change).Return(nil).Run(runUpdate)
+		service := authorserv.NewAuthorServ(mockRepo, historyMock, &imagestorage.MockImageStorage{})
+
+		err := service.Revert(ctx, author.GetID(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "Old Name", author.GetName())
+		assert.Equal(t, 1899, author.GetBirthYear())
+		assert.Equal(t, 1950, author.GetDeathYear())
+		// версия до появления профиля восстанавливается без изменения профиля
+		assert.Empty(t, author.Snapshot()["biography"])
+		mockRepo.AssertExpectations(t)
+		historyMock.AssertExpectations(t)
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		mockRepo := &authorrep.MockAuthorRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		historyMock.On("GetSnapshot", ctx, models.EntityAuthor, author.GetID(), 5).
+			Return(nil, historyserv.ErrVersionNotFound)
+		service := authorserv.NewAuthorServ(mockRepo, historyMock, &imagestorage.MockImageStorage{})
+
+		err := service.Revert(ctx, author.GetID(), 5)
+		assert.ErrorIs(t, err, historyserv.ErrVersionNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthorService_FindDuplicates(t *testing.T) {
+	ctx := context.Background()
+	newAuthor := func(name string, birthYear int, deathYear int) *models.Author {
+		author, err := models.NewAuthor(uuid.New(), name, birthYear, deathYear)
+		require.NoError(t, err)
+		return &author
+	}
+	repin := newAuthor("Илья Репин", 1844, 1930)
+	repinInitials := newAuthor("И. Репин", 1844, 1930)
+	repinLatin := newAuthor("Repin, Ilya", 1844, 0)
+	repinYo := newAuthor("Илья Ефимович Рёпин", 1845, 1930)
+	otherRepin := newAuthor("Иван Репин", 1844, 1930)
+	youngRepin := newAuthor("Илья Репин", 1950, 0)
+	shishkin := newAuthor("Иван Шишкин", 1832, 1898)
+
+	tests := []struct {
+		name      string
+		authors   []*models.Author
+		wantPairs [][2]*models.Author
+	}{
+		{
+			name:      "initials",
+			authors:   []*models.Author{repin, repinInitials},
+			wantPairs: [][2]*models.Author{{repin, repinInitials}},
+		},
+		{
+			name:      "latin surname first with unknown death year",
+			authors:   []*models.Author{repin, repinLatin},
+			wantPairs: [][2]*models.Author{{repin, repinLatin}},
+		},
+		{
+			name:      "patronymic, yo and near birth year",
+			authors:   []*models.Author{repin, repinYo},
+			wantPairs: [][2]*models.Author{{repin, repinYo}},
+		},
+		{
+			name:    "different first name",
+			authors: []*models.Author{repin, otherRepin},
+		},
+		{
+			name:    "incompatible years",
+			authors: []*models.Author{repin, youngRepin},
+		},
+		{
+			name:    "different authors",
+			authors: []*models.Author{repin, shishkin},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &authorrep.MockAuthorRep{}
+			mockRepo.On("GetAll", ctx).Return(tt.authors, nil)
+			service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, &imagestorage.MockImageStorage{})
+
+			duplicates, err := service.FindDuplicates(ctx)
+			require.NoError(t, err)
+			require.Len(t, duplicates, len(tt.wantPairs))
+			for i, pair := range tt.wantPairs {
+				assert.Equal(t, pair[0].GetID(), duplicates[i].Author.GetID())
+				assert.Equal(t, pair[1].GetID(), duplicates[i].Duplicate.GetID())
+				assert.GreaterOrEqual(t, duplicates[i].Score, models.AuthorDuplicateMinScore)
+			}
+		})
+	}
+
+	t.Run("most similar first", func(t *testing.T) {
+		mockRepo := &authorrep.MockAuthorRep{}
+		mockRepo.On("GetAll", ctx).Return([]*models.Author{repinYo, repinInitials, repin}, nil)
+		service := authorserv.NewAuthorServ(mockRepo, &historyserv.MockHistoryServ{}, &imagestorage.MockImageStorage{})
+
+		duplicates, err := service.FindDuplicates(ctx)
+		require.NoError(t, err)
+		require.Len(t, duplicates, 3)
+		assert.Equal(t, repinInitials.GetID(), duplicates[0].Author.GetID())
+		assert.Equal(t, repin.GetID(), duplicates[0].Duplicate.GetID())
+		assert.True(t, duplicates[0].SameYears)
+		for i := 1; i < len(duplicates); i++ {
+			assert.LessOrEqual(t, duplicates[i].Score, duplicates[i-1].Score)
+		}
+	})
+}
+
+func TestAuthorService_Merge(t *testing.T) {
+	ctx := context.Background()
+	survivorID := uuid.New()
+	duplicateID := uuid.New()
+	change := &models.Change{Action: models.ChangeMerge}
+
+	tests := []struct {
+		name        string
+		survivorID  uuid.UUID
+		duplicateID uuid.UUID
+		setupMocks  func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ)
+		wantMoved   int
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			survivorID:  survivorID,
+			duplicateID: duplicateID,
+			setupMocks: func(rep *authorrep.MockAuthorRep, history *historyserv.MockHistoryServ) {
+				history.On("Change", ctx, models.ChangeMerge, 0).Return(change)
+				rep.On("Merge", ctx, survivorID, duplicateID, change).Return(3, nil)
+			},
+			wantMoved: 3,
+		},
+		{
+			name:        "same author",
+			survivorID:  survivorID,
+			duplicateID: survivorID,
+			setupMocks:  func(*authorrep.MockAuthorRep, *historyserv.MockHistoryServ) {},
+			wantErr:     authorserv.ErrMergeSameAuthor,
+		},
+		{
+			name:        "duplicate not found",
+			survivorID:  survivorID,
+			duplicateID: duplicateID,
+			setupMocks: func(rep *authorrep.MockAuthorRep, history *historyserv.MockHistoryServ) {
+				history.On("Change", ctx, models.ChangeMerge, 0).Return(change)
+				rep.On("Merge", ctx, survivorID, duplicateID, change).Return(0, authorrep.ErrAuthorNotFound)
+			},
+			wantErr: authorrep.ErrAuthorNotFound,
+		},
+		{
+			name:        "merge failed",
+			survivorID:  survivorID,
+			duplicateID: duplicateID,
+			setupMocks: func(rep *authorrep.MockAuthorRep, history *historyserv.MockHistoryServ) {
+				history.On("Change", ctx, models.ChangeMerge, 0).Return(change)
+				rep.On("Merge", ctx, survivorID, duplicateID, change).Return(0, authorrep.ErrQueryExec)
+			},
+			wantErr: authorrep.ErrQueryExec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &authorrep.MockAuthorRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			tt.setupMocks(mockRepo, historyMock)
+			service := authorserv.NewAuthorServ(mockRepo, historyMock, &imagestorage.MockImageStorage{})
+
+			moved, err := service.Merge(ctx, tt.survivorID, tt.duplicateID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantMoved, moved)
+			}
+			mockRepo.AssertExpectations(t)
+			historyMock.AssertExpectations(t)
+		})
+	}
+}
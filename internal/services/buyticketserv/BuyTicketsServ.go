@@ -19,6 +19,7 @@ import (
 var (
 	ErrBuyTicketsServ = errors.New("buyTicketsServ")
 	ErrNoFreeTicket   = errors.New("no free ticket")
+	ErrEventNotOnSale = errors.New("event is not approved for ticket sales")
 	ErrNoUserData     = errors.New("no info about user (customerName, customerEmail)")
 )
 
@@ -29,6 +30,9 @@ type BuyTicketsServ interface {
 	CancelBuyTicket(ctx context.Context, TxID uuid.UUID) error
 	GetAllTicketPurchasesOfUser(ctx context.Context) ([]*models.TicketPurchase, error)
 	GetBuyTicketTransactionDuration() time.Duration
+	// GetFreeTicketCount возвращает число билетов мероприятия, которые еще можно купить,
+	// с учетом незавершенных покупок
+	GetFreeTicketCount(ctx context.Context, eventID uuid.UUID) (int, error)
 }
 
 type buyTicketsServ struct {
@@ -63,6 +67,9 @@ func (b *buyTicketsServ) cntFreeTickets(ctx context.Context, eventID uuid.UUID)
 	if err != nil {
 		return 0, fmt.Errorf("checkCntTickets: %v", err)
 	}
+	if !event.IsApproved() {
+		return 0, fmt.Errorf("checkCntTickets: %w", ErrEventNotOnSale)
+	}
 	txCnt, err := b.txRep.GetCntTxByEventID(ctx, event.GetID())
 	if err != nil {
 		return 0, fmt.Errorf("checkCntTickets: %v", err)
@@ -78,6 +85,14 @@ func (b *buyTicketsServ) cntFreeTickets(ctx context.Context, eventID uuid.UUID)
 	return freeCnt, nil
 }
 
+func (b *buyTicketsServ) GetFreeTicketCount(ctx context.Context, eventID uuid.UUID) (int, error) {
+	freeCnt, err := b.cntFreeTickets(ctx, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("buyTicketsServ.GetFreeTicketCount: %w", err)
+	}
+	return freeCnt, nil
+}
+
 // Если в ctx есть информация об аутентифицированном пользователе то поля customerName, customerEmail не используются
 // not sesrver errors: ErrNoFreeTicket, ErrNoUserData, ErrExpireTx
 func (b *buyTicketsServ) BuyTicket(
@@ -90,7 +105,7 @@ func (b *buyTicketsServ) BuyTicket(
 	var err error
 	ticketsFree, err := b.cntFreeTickets(ctx, eventID)
 	if err != nil {
-		return nil, fmt.Errorf("BuyTicket: %v", err)
+		return nil, fmt.Errorf("BuyTicket: %w", err)
 	}
 	if ticketsFree <= 0 {
 		return nil, fmt.Errorf("BuyTicket: %w", ErrNoFreeTicket)
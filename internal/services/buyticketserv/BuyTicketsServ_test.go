@@ -49,6 +49,7 @@ func createTestEvent(eventID uuid.UUID, ticketCount int) *models.Event {
 		true,
 		make(uuid.UUIDs, 0),
 	)
+	_ = event.SetStatus(models.EventStatusApproved)
 	return &event
 }
 
@@ -196,6 +197,27 @@ func TestBuyTicketsServ_BuyTicket(t *testing.T) {
 		ticketMock.AssertExpectations(t)
 	})
 
+	t.Run("error when event is not approved", func(t *testing.T) {
+		eventMock := new(eventrep.MockEventRep)
+		draft := createTestEvent(td.eventID, 10)
+		require.NoError(t, draft.SetStatus(models.EventStatusPending))
+		eventMock.On("GetByID", td.ctx, td.eventID).Return(draft, nil)
+
+		service, err := buyticketserv.NewBuyTicketsServ(
+			new(buyticketstxrep.MockBuyTicketsTxRep),
+			new(ticketpurchasesrep.MockTicketPurchasesRep),
+			td.config,
+			new(auth.MockAuthZ),
+			new(userrep.MockUserRep),
+			eventMock,
+		)
+		require.NoError(t, err)
+
+		_, err = service.BuyTicket(td.ctx, td.eventID, cntTickets, "name", "mail@test.ru")
+		assert.ErrorIs(t, err, buyticketserv.ErrEventNotOnSale)
+		eventMock.AssertExpectations(t)
+	})
+
 	t.Run("error when no user data for unauthenticated user", func(t *testing.T) {
 		authMock := new(auth.MockAuthZ)
 		eventMock := new(eventrep.MockEventRep)
@@ -229,6 +251,35 @@ func TestBuyTicketsServ_BuyTicket(t *testing.T) {
 	})
 }
 
+func TestBuyTicketsServ_GetFreeTicketCount(t *testing.T) {
+	td := setupTestData()
+	event := createTestEvent(td.eventID, 10)
+
+	eventMock := new(eventrep.MockEventRep)
+	txMock := new(buyticketstxrep.MockBuyTicketsTxRep)
+	ticketMock := new(ticketpurchasesrep.MockTicketPurchasesRep)
+	eventMock.On("GetByID", td.ctx, td.eventID).Return(event, nil)
+	txMock.On("GetCntTxByEventID", td.ctx, td.eventID).Return(3, nil)
+	ticketMock.On("GetCntTPurchasesForEvent", td.ctx, td.eventID).Return(4, nil)
+
+	service, err := buyticketserv.NewBuyTicketsServ(
+		txMock,
+		ticketMock,
+		td.config,
+		new(auth.MockAuthZ),
+		new(userrep.MockUserRep),
+		eventMock,
+	)
+	require.NoError(t, err)
+
+	freeCnt, err := service.GetFreeTicketCount(td.ctx, td.eventID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, freeCnt)
+	eventMock.AssertExpectations(t)
+	txMock.AssertExpectations(t)
+	ticketMock.AssertExpectations(t)
+}
+
 func TestBuyTicketsServ_ConfirmBuyTicket(t *testing.T) {
 	td := setupTestData()
 	tx := createTestTicketPurchaseTx(td.eventID, td.userID, td.config, 2)
@@ -2,35 +2,63 @@ package collectionserv
 
 import (
 	"context"
+	"fmt"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
 	"github.com/google/uuid"
 )
 
 type CollectionServ interface {
 	GetAll(ctx context.Context) ([]*models.Collection, error)
+	// Add добавляет коллекцию, родительская коллекция должна существовать
 	Add(ctx context.Context, col *models.Collection) error
 	Update(ctx context.Context, idCol uuid.UUID, updateReq models.CollectionUpdateReq) error
 	Delete(ctx context.Context, idCol uuid.UUID) error
+	// GetStats статистика произведений коллекции, при includeSubcollections - вместе с вложенными коллекциями
+	GetStats(ctx context.Context, idCol uuid.UUID, includeSubcollections bool) (*models.CollectionStats, error)
+	// история изменений
+	GetHistory(ctx context.Context, idCol uuid.UUID) ([]*models.ChangeRecord, error)
+	Revert(ctx context.Context, idCol uuid.UUID, version int) error
 }
 
-func NewCollectionServ(collectionRep collectionrep.CollectionRep) CollectionServ {
+func NewCollectionServ(collectionRep collectionrep.CollectionRep, historyServ historyserv.HistoryServ) CollectionServ {
 	return &collectionServ{
 		collectionRep: collectionRep,
+		historyServ:   historyServ,
 	}
 }
 
 type collectionServ struct {
 	collectionRep collectionrep.CollectionRep
+	historyServ   historyserv.HistoryServ
 }
 
 func (s *collectionServ) GetAll(ctx context.Context) ([]*models.Collection, error) {
 	return s.collectionRep.GetAllCollections(ctx)
 }
 
+func (s *collectionServ) hierarchy(ctx context.Context) (*models.CollectionHierarchy, error) {
+	collections, err := s.collectionRep.GetAllCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewCollectionHierarchy(collections), nil
+}
+
 func (s *collectionServ) Add(ctx context.Context, col *models.Collection) error {
-	return s.collectionRep.AddCollection(ctx, col)
+	hierarchy, err := s.hierarchy(ctx)
+	if err != nil {
+		return fmt.Errorf("collectionServ.Add: %w", err)
+	}
+	if err := hierarchy.CheckCollection(col); err != nil {
+		return fmt.Errorf("collectionServ.Add: %w: %w", models.ErrValidateCollection, err)
+	}
+	if err := s.collectionRep.AddCollection(ctx, col); err != nil {
+		return fmt.Errorf("collectionServ.Add: %w", err)
+	}
+	return nil
 }
 
 func (s *collectionServ) Delete(ctx context.Context, idCol uuid.UUID) error {
@@ -42,11 +70,82 @@ func (s *collectionServ) Update(
 	idCol uuid.UUID,
 	updateReq models.CollectionUpdateReq,
 ) error {
+	hierarchy, err := s.hierarchy(ctx)
+	if err != nil {
+		return fmt.Errorf("collectionServ.Update: %w", err)
+	}
 	return s.collectionRep.UpdateCollection(
 		ctx,
 		idCol,
 		func(c *models.Collection) (*models.Collection, error) {
-			err := c.Update(updateReq)
-			return c, err
-		})
+			if err := c.Update(updateReq); err != nil {
+				return nil, fmt.Errorf("%w: %w", models.ErrValidateCollection, err)
+			}
+			if err := hierarchy.CheckCollection(c); err != nil {
+				return nil, fmt.Errorf("%w: %w", models.ErrValidateCollection, err)
+			}
+			return c, nil
+		},
+		s.historyServ.Change(ctx, models.ChangeUpdate, 0))
+}
+
+func (s *collectionServ) GetStats(
+	ctx context.Context,
+	idCol uuid.UUID,
+	includeSubcollections bool,
+) (*models.CollectionStats, error) {
+	hierarchy, err := s.hierarchy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("collectionServ.GetStats: %w", err)
+	}
+	col, ok := hierarchy.Get(idCol)
+	if !ok {
+		return nil, fmt.Errorf("collectionServ.GetStats: %w", collectionrep.ErrCollectionNotFound)
+	}
+	subtree := hierarchy.Descendants(uuid.UUIDs{idCol})
+	ids := uuid.UUIDs{idCol}
+	if includeSubcollections {
+		ids = subtree
+	}
+	stats, err := s.collectionRep.GetCollectionStats(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("collectionServ.GetStats: %w", err)
+	}
+	stats.Collection = col
+	stats.IncludesSubcollections = includeSubcollections
+	stats.SubcollectionCount = len(subtree) - 1
+	return stats, nil
+}
+
+func (s *collectionServ) GetHistory(ctx context.Context, idCol uuid.UUID) ([]*models.ChangeRecord, error) {
+	return s.historyServ.GetHistory(ctx, models.EntityCollection, idCol)
+}
+
+// Revert возвращает поля коллекции к версии из истории изменений, возврат сохраняется как новая версия
+func (s *collectionServ) Revert(ctx context.Context, idCol uuid.UUID, version int) error {
+	snapshot, err := s.historyServ.GetSnapshot(ctx, models.EntityCollection, idCol, version)
+	if err != nil {
+		return fmt.Errorf("collectionServ.Revert: %w", err)
+	}
+	hierarchy, err := s.hierarchy(ctx)
+	if err != nil {
+		return fmt.Errorf("collectionServ.Revert: %w", err)
+	}
+	err = s.collectionRep.UpdateCollection(
+		ctx,
+		idCol,
+		func(c *models.Collection) (*models.Collection, error) {
+			if err := c.Restore(snapshot); err != nil {
+				return nil, fmt.Errorf("%w: %w", models.ErrValidateCollection, err)
+			}
+			if err := hierarchy.CheckCollection(c); err != nil {
+				return nil, fmt.Errorf("%w: %w", models.ErrValidateCollection, err)
+			}
+			return c, nil
+		},
+		s.historyServ.Change(ctx, models.ChangeRevert, version))
+	if err != nil {
+		return fmt.Errorf("collectionServ.Revert: %w", err)
+	}
+	return nil
 }
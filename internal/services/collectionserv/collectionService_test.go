@@ -0,0 +1,228 @@
+package collectionserv_test
+
+import (
+	"context"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/collectionserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCollection(t *testing.T, title string, parent *models.Collection) *models.Collection {
+	col, err := models.NewCollection(uuid.New(), title)
+	require.NoError(t, err)
+	if parent != nil {
+		require.NoError(t, col.SetParent(parent.GetID()))
+	}
+	return &col
+}
+
+// createTestHierarchy возвращает коллекции: Живопись -> Европейская живопись -> Голландская живопись, Графика
+func createTestHierarchy(t *testing.T) []*models.Collection {
+	painting := createTestCollection(t, "Живопись", nil)
+	european := createTestCollection(t, "Европейская живопись", painting)
+	dutch := createTestCollection(t, "Голландская живопись", european)
+	graphics := createTestCollection(t, "Графика", nil)
+	return []*models.Collection{painting, european, dutch, graphics}
+}
+
+func TestCollectionService_Add(t *testing.T) {
+	ctx := context.Background()
+	collections := createTestHierarchy(t)
+	painting, dutch := collections[0], collections[2]
+
+	deep := dutch
+	for i := 0; i < models.CollectionMaxDepth-3; i++ {
+		deep = createTestCollection(t, "Уровень "+string(rune('A'+i)), deep)
+		collections = append(collections, deep)
+	}
+	unknownParent := createTestCollection(t, "Неизвестная", nil)
+
+	tests := []struct {
+		name          string
+		col           *models.Collection
+		expectedError error
+	}{
+		{name: "root collection", col: createTestCollection(t, "Скульптура", nil)},
+		{name: "nested collection", col: createTestCollection(t, "Русская живопись", painting)},
+		{
+			name:          "unknown parent",
+			col:           createTestCollection(t, "Сироты", unknownParent),
+			expectedError: models.ErrCollectionParentNotFound,
+		},
+		{
+			name:          "too deep",
+			col:           createTestCollection(t, "Слишком глубоко", deep),
+			expectedError: models.ErrCollectionTooDeep,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := &collectionrep.MockCollectionRep{}
+			repoMock.On("GetAllCollections", ctx).Return(collections, nil)
+			if tt.expectedError == nil {
+				repoMock.On("AddCollection", ctx, tt.col).Return(nil)
+			}
+
+			service := collectionserv.NewCollectionServ(repoMock, &historyserv.MockHistoryServ{})
+			err := service.Add(ctx, tt.col)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, models.ErrValidateCollection)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCollectionService_Update(t *testing.T) {
+	ctx := context.Background()
+	collections := createTestHierarchy(t)
+	painting, european, dutch, graphics := collections[0], collections[1], collections[2], collections[3]
+
+	tests := []struct {
+		name          string
+		col           *models.Collection
+		req           models.CollectionUpdateReq
+		expectedError error
+	}{
+		{
+			name: "move to another parent",
+			col:  dutch,
+			req:  models.CollectionUpdateReq{Title: "Голландская живопись", ParentID: painting.GetID()},
+		},
+		{
+			name: "make root",
+			col:  european,
+			req:  models.CollectionUpdateReq{Title: "Европейская живопись"},
+		},
+		{
+			name:          "move under own descendant",
+			col:           painting,
+			req:           models.CollectionUpdateReq{Title: "Живопись", ParentID: dutch.GetID()},
+			expectedError: models.ErrCollectionCycle,
+		},
+		{
+			name:          "own parent",
+			col:           graphics,
+			req:           models.CollectionUpdateReq{Title: "Графика", ParentID: graphics.GetID()},
+			expectedError: models.ErrCollectionSelfParent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := &collectionrep.MockCollectionRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			repoMock.On("GetAllCollections", ctx).Return(collections, nil)
+			var updateErr error
+			var updated *models.Collection
+			change := &models.Change{Action: models.ChangeUpdate}
+			historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+			repoMock.On("UpdateCollection", ctx, tt.col.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+				col := *tt.col
+				updated, updateErr = args.Get(2).(func(*models.Collection) (*models.Collection, error))(&col)
+			})
+
+			service := collectionserv.NewCollectionServ(repoMock, historyMock)
+			require.NoError(t, service.Update(ctx, tt.col.GetID(), tt.req))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, updateErr, models.ErrValidateCollection)
+				assert.ErrorIs(t, updateErr, tt.expectedError)
+				return
+			}
+			require.NoError(t, updateErr)
+			assert.Equal(t, tt.req.ParentID, updated.GetParentID())
+		})
+	}
+}
+
+// Цикл, уже записанный в хранилище, не должен зацикливать проверку глубины при переносе
+func TestCollectionService_UpdateWithCycleInData(t *testing.T) {
+	ctx := context.Background()
+	first := createTestCollection(t, "Первая", nil)
+	second := createTestCollection(t, "Вторая", first)
+	require.NoError(t, first.SetParent(second.GetID()))
+	root := createTestCollection(t, "Корневая", nil)
+
+	repoMock := &collectionrep.MockCollectionRep{}
+	historyMock := &historyserv.MockHistoryServ{}
+	repoMock.On("GetAllCollections", ctx).Return([]*models.Collection{first, second, root}, nil)
+	change := &models.Change{Action: models.ChangeUpdate}
+	historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(change)
+	var updateErr error
+	repoMock.On("UpdateCollection", ctx, first.GetID(), mock.Anything, change).Return(nil).Run(func(args mock.Arguments) {
+		col := *first
+		_, updateErr = args.Get(2).(func(*models.Collection) (*models.Collection, error))(&col)
+	})
+
+	service := collectionserv.NewCollectionServ(repoMock, historyMock)
+	require.NoError(t, service.Update(ctx, first.GetID(), models.CollectionUpdateReq{Title: "Первая", ParentID: root.GetID()}))
+	require.NoError(t, updateErr)
+}
+
+func TestCollectionService_GetStats(t *testing.T) {
+	ctx := context.Background()
+	collections := createTestHierarchy(t)
+	painting, european, dutch := collections[0], collections[1], collections[2]
+
+	tests := []struct {
+		name                  string
+		id                    uuid.UUID
+		includeSubcollections bool
+		expectedIDs           uuid.UUIDs
+		expectedError         error
+	}{
+		{
+			name:        "only collection",
+			id:          painting.GetID(),
+			expectedIDs: uuid.UUIDs{painting.GetID()},
+		},
+		{
+			name:                  "with subcollections",
+			id:                    painting.GetID(),
+			includeSubcollections: true,
+			expectedIDs:           uuid.UUIDs{painting.GetID(), european.GetID(), dutch.GetID()},
+		},
+		{
+			name:          "unknown collection",
+			id:            uuid.New(),
+			expectedError: collectionrep.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := &collectionrep.MockCollectionRep{}
+			repoMock.On("GetAllCollections", ctx).Return(collections, nil)
+			if tt.expectedError == nil {
+				repoMock.On("GetCollectionStats", ctx, tt.expectedIDs).Return(&models.CollectionStats{ArtworkCount: 7}, nil)
+			}
+
+			service := collectionserv.NewCollectionServ(repoMock, &historyserv.MockHistoryServ{})
+			stats, err := service.GetStats(ctx, tt.id, tt.includeSubcollections)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, painting, stats.Collection)
+			assert.Equal(t, 2, stats.SubcollectionCount)
+			assert.Equal(t, tt.includeSubcollections, stats.IncludesSubcollections)
+			assert.Equal(t, 7, stats.ArtworkCount)
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
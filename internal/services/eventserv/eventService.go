@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
 	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
 	"github.com/google/uuid"
 )
 
@@ -20,21 +23,54 @@ type EventService interface {
 	Update(ctx context.Context, eventID uuid.UUID, updateFields *jsonreqresp.EventUpdate) error
 	AddArtworksToEvent(ctx context.Context, eventID uuid.UUID, artworkIDs uuid.UUIDs) error
 	DeleteArtworkFromEvent(ctx context.Context, eventID uuid.UUID, artworkID uuid.UUID) error
+	Clone(ctx context.Context, eventID uuid.UUID, copyReq *jsonreqresp.EventCopy) (uuid.UUID, error)
+	//
+	Submit(ctx context.Context, eventID uuid.UUID, comment string) error
+	Approve(ctx context.Context, eventID uuid.UUID, comment string) error
+	Reject(ctx context.Context, eventID uuid.UUID, comment string) error
+	GetReviews(ctx context.Context, eventID uuid.UUID) ([]*models.EventReview, error)
+	GetPendingReview(ctx context.Context) ([]*models.Event, error)
+	//
+	GetOrganisers(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error)
+	AddOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error
+	DeleteOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error
+	//
+	GetTemplates(ctx context.Context) ([]*models.EventTemplate, error)
+	SaveAsTemplate(ctx context.Context, eventID uuid.UUID, name string, employeeID uuid.UUID) (uuid.UUID, error)
+	AddFromTemplate(ctx context.Context, templateID uuid.UUID, copyReq *jsonreqresp.EventCopy) (uuid.UUID, error)
+	DeleteTemplate(ctx context.Context, templateID uuid.UUID) error
+	// история изменений
+	GetHistory(ctx context.Context, eventID uuid.UUID) ([]*models.ChangeRecord, error)
+	Revert(ctx context.Context, eventID uuid.UUID, version int) error
 }
 
+// reopenComment - комментарий к записи истории согласования при изменении одобренного мероприятия
+const reopenComment = "Мероприятие изменено после одобрения и отправлено на повторное согласование"
+
 var (
-	ErrArtworkBusy = errors.New("artowrk can't participate in event")
+	ErrArtworkBusy     = errors.New("artowrk can't participate in event")
+	ErrEventForbidden  = errors.New("no rights to change event")
+	ErrOrganiserExists = errors.New("employee already organises event")
 )
 
 type eventService struct {
-	eventRep   eventrep.EventRep
-	artworkRep artworkrep.ArtworkRep
+	eventRep    eventrep.EventRep
+	artworkRep  artworkrep.ArtworkRep
+	authZ       auth.AuthZ
+	historyServ historyserv.HistoryServ
 }
 
-func NewEventService(eventRep eventrep.EventRep, artworkRep artworkrep.ArtworkRep) EventService {
+func NewEventService(
+	eventRep eventrep.EventRep,
+	artworkRep artworkrep.ArtworkRep,
+	authZ auth.AuthZ,
+	historyServ historyserv.HistoryServ,
+) EventService {
 	return &eventService{
-		eventRep:   eventRep,
-		artworkRep: artworkRep,
+		eventRep:    eventRep,
+		artworkRep:  artworkRep,
+		authZ:       authZ,
+		historyServ: historyServ,
 	}
 }
 
@@ -59,11 +95,12 @@ func (e *eventService) GetArtworksFromEvent(ctx context.Context, eventID uuid.UU
 }
 
 func (e *eventService) Add(ctx context.Context, eventReq *jsonreqresp.EventAdd) error {
-	employeeExist, err := e.eventRep.CheckEmployeeByID(ctx, eventReq.EmployeeID)
+	creatorID, err := e.resolveCreator(ctx, eventReq.EmployeeID)
 	if err != nil {
-		return fmt.Errorf("eventService.Add check employee: %v", err)
-	} else if !employeeExist {
-		return fmt.Errorf("eventService.Add check employee: %w", eventrep.ErrAddNoEmployee)
+		return fmt.Errorf("eventService.Add: %w", err)
+	}
+	if err := e.checkEmployee(ctx, creatorID); err != nil {
+		return fmt.Errorf("eventService.Add check employee: %w", err)
 	}
 
 	var artworkIDs uuid.UUIDs
@@ -77,7 +114,7 @@ func (e *eventService) Add(ctx context.Context, eventReq *jsonreqresp.EventAdd)
 		eventReq.DateEnd,
 		eventReq.Address,
 		eventReq.CanVisit,
-		eventReq.EmployeeID,
+		creatorID,
 		eventReq.CntTickets,
 		true,
 		artworkIDs,
@@ -86,52 +123,260 @@ func (e *eventService) Add(ctx context.Context, eventReq *jsonreqresp.EventAdd)
 		return fmt.Errorf("eventService.Add %w: %v", models.ErrValidateEvent, err)
 	}
 
-	for _, id := range artworkIDs {
-		_, err := e.eventRep.GetEventsOfArtworkOnDate(ctx, id, eventReq.DateBegin, eventReq.DateEnd)
+	if err := e.addEvent(ctx, &event); err != nil {
+		return fmt.Errorf("eventService.Add: %w", err)
+	}
+	return nil
+}
+
+// addEvent проверяет занятость произведений на даты мероприятия и сохраняет его вместе со списком произведений
+func (e *eventService) addEvent(ctx context.Context, event *models.Event) error {
+	for _, id := range event.GetArtworkIDs() {
+		_, err := e.eventRep.GetEventsOfArtworkOnDate(ctx, id, event.GetDateBegin(), event.GetDateEnd())
 		if err == nil {
-			return fmt.Errorf("eventService.Add: %w", ErrArtworkBusy)
-		} else if err != eventrep.ErrEventNotFound {
-			return fmt.Errorf("eventService.Add: %w", err)
+			return ErrArtworkBusy
+		} else if !errors.Is(err, eventrep.ErrEventNotFound) {
+			return err
 		}
 	}
+	if err := e.checkNotInTreatment(ctx, event.GetDateBegin(), event.GetDateEnd(), event.GetArtworkIDs()); err != nil {
+		return err
+	}
 
-	err = e.eventRep.Add(ctx, &event)
+	err := e.eventRep.Add(ctx, event)
 	if err != nil {
-		return fmt.Errorf("eventService.Add: %v", err)
+		return err
 	}
-	err = e.eventRep.AddArtworksToEvent(ctx, event.GetID(), artworkIDs)
+	err = e.eventRep.AddArtworksToEvent(ctx, event.GetID(), event.GetArtworkIDs())
 	if err != nil {
-		return fmt.Errorf("eventService.Add: %v", err)
+		return err
 	}
 	return nil
 }
 
+// checkNotInTreatment проверяет, что произведения не находятся на реставрации в даты мероприятия
+func (e *eventService) checkNotInTreatment(
+	ctx context.Context, dateBegin time.Time, dateEnd time.Time, artworkIDs uuid.UUIDs,
+) error {
+	for _, id := range artworkIDs {
+		treatments, err := e.artworkRep.GetTreatmentsOnDate(ctx, id, dateBegin, dateEnd)
+		if err != nil {
+			return err
+		}
+		if len(treatments) > 0 {
+			return fmt.Errorf("%w: artwork %s is under treatment", ErrArtworkBusy, id)
+		}
+	}
+	return nil
+}
+
+// checkMovedDates при переносе мероприятия на новые даты проверяет, что его произведения не на реставрации
+func (e *eventService) checkMovedDates(ctx context.Context, event *models.Event, dateBegin time.Time, dateEnd time.Time) error {
+	if event.GetDateBegin().Equal(dateBegin) && event.GetDateEnd().Equal(dateEnd) {
+		return nil
+	}
+	artworkIDs, err := e.eventRep.GetArtworkIDs(ctx, event.GetID())
+	if err != nil {
+		return err
+	}
+	return e.checkNotInTreatment(ctx, dateBegin, dateEnd, artworkIDs)
+}
+
+// currentEditor возвращает ID сотрудника из контекста и признак того, что это администратор
+func (e *eventService) currentEditor(ctx context.Context) (uuid.UUID, bool, error) {
+	employeeID, err := e.authZ.EmployeeIDFromContext(ctx)
+	if err == nil {
+		return employeeID, false, nil
+	} else if !errors.Is(err, auth.ErrHasNoRights) {
+		return uuid.Nil, false, err
+	}
+	// AdminIDFromContext пропускает и сотрудников, но этот случай уже обработан выше
+	adminID, err := e.authZ.AdminIDFromContext(ctx)
+	if errors.Is(err, auth.ErrHasNoRights) {
+		return uuid.Nil, false, fmt.Errorf("%w: only employees can change events", ErrEventForbidden)
+	} else if err != nil {
+		return uuid.Nil, false, err
+	}
+	return adminID, true, nil
+}
+
+// resolveCreator определяет создателя нового мероприятия или шаблона.
+// Сотрудник создает их только от своего имени, requested может быть пустым или совпадать с ним.
+// Администратор не является сотрудником и указывает создателя явно.
+func (e *eventService) resolveCreator(ctx context.Context, requested uuid.UUID) (uuid.UUID, error) {
+	editorID, isAdmin, err := e.currentEditor(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isAdmin {
+		if requested != uuid.Nil && requested != editorID {
+			return uuid.Nil, fmt.Errorf("%w: employee can create events only on own behalf", ErrEventForbidden)
+		}
+		return editorID, nil
+	}
+	if requested == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", models.ErrValidateEvent, models.ErrEventInvalidEmployee)
+	}
+	return requested, nil
+}
+
+// checkCanEdit разрешает изменение мероприятия создателю, соорганизаторам и администратору.
+// Возвращает ID того, кто вносит изменение.
+func (e *eventService) checkCanEdit(ctx context.Context, event *models.Event) (uuid.UUID, error) {
+	employeeID, isAdmin, err := e.currentEditor(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if isAdmin || employeeID == event.GetEmployeeID() {
+		return employeeID, nil
+	}
+	organiserIDs, err := e.eventRep.GetOrganiserIDs(ctx, event.GetID())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, id := range organiserIDs {
+		if id == employeeID {
+			return employeeID, nil
+		}
+	}
+	return uuid.Nil, fmt.Errorf("%w: employee is neither creator nor organiser of event", ErrEventForbidden)
+}
+
+// checkReviewer разрешает согласование мероприятий только администратору
+func (e *eventService) checkReviewer(ctx context.Context) (uuid.UUID, error) {
+	adminID, isAdmin, err := e.currentEditor(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isAdmin {
+		return uuid.Nil, fmt.Errorf("%w: only admin can review events", ErrEventForbidden)
+	}
+	return adminID, nil
+}
+
+// checkIsOwner разрешает управление соорганизаторами только создателю и администратору
+func (e *eventService) checkIsOwner(ctx context.Context, event *models.Event) error {
+	employeeID, isAdmin, err := e.currentEditor(ctx)
+	if err != nil {
+		return err
+	}
+	if isAdmin || employeeID == event.GetEmployeeID() {
+		return nil
+	}
+	return fmt.Errorf("%w: only creator of event can manage organisers", ErrEventForbidden)
+}
+
+// checkTemplateOwner разрешает удаление шаблона только его создателю и администратору
+func (e *eventService) checkTemplateOwner(ctx context.Context, tmpl *models.EventTemplate) error {
+	employeeID, isAdmin, err := e.currentEditor(ctx)
+	if err != nil {
+		return err
+	}
+	if isAdmin || employeeID == tmpl.GetEmployeeID() {
+		return nil
+	}
+	return fmt.Errorf("%w: only creator of template can delete it", ErrEventForbidden)
+}
+
 func (e *eventService) Delete(ctx context.Context, id uuid.UUID) error {
+	event, err := e.eventRep.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("eventService.Delete: %w", err)
+	}
+	if _, err := e.checkCanEdit(ctx, event); err != nil {
+		return fmt.Errorf("eventService.Delete: %w", err)
+	}
 	return e.eventRep.Delete(ctx, id)
 }
 
 func (e *eventService) Update(ctx context.Context, eventID uuid.UUID, updateFields *jsonreqresp.EventUpdate) error {
-	_, err := e.eventRep.GetByID(ctx, eventID)
+	event, err := e.eventRep.GetByID(ctx, eventID)
 	if err != nil {
-		return fmt.Errorf("eventService.Update: %v", err)
+		return fmt.Errorf("eventService.Update: %w", err)
+	}
+	actorID, err := e.checkCanEdit(ctx, event)
+	if err != nil {
+		return fmt.Errorf("eventService.Update: %w", err)
+	}
+	if err := e.checkMovedDates(ctx, event, updateFields.DateBegin, updateFields.DateEnd); err != nil {
+		return fmt.Errorf("eventService.Update: %w", err)
 	}
 
-	return e.eventRep.Update(
+	err = e.eventRep.Update(
 		ctx,
 		eventID,
 		func(event *models.Event) (*models.Event, error) {
 			err := event.Update(updateFields)
 			return event, err
-		})
+		},
+		e.historyServ.Change(ctx, models.ChangeUpdate, 0))
+	if err != nil {
+		return err
+	}
+	if err := e.reopenIfApproved(ctx, event, actorID); err != nil {
+		return fmt.Errorf("eventService.Update: %w", err)
+	}
+	return nil
+}
+
+func (e *eventService) GetHistory(ctx context.Context, eventID uuid.UUID) ([]*models.ChangeRecord, error) {
+	return e.historyServ.GetHistory(ctx, models.EntityEvent, eventID)
+}
+
+// Revert возвращает поля мероприятия к версии из истории изменений, возврат сохраняется как новая версия
+func (e *eventService) Revert(ctx context.Context, eventID uuid.UUID, version int) error {
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	actorID, err := e.checkCanEdit(ctx, event)
+	if err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	snapshot, err := e.historyServ.GetSnapshot(ctx, models.EntityEvent, eventID, version)
+	if err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	dateBegin, err := snapshot.Time("dateBegin")
+	if err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	dateEnd, err := snapshot.Time("dateEnd")
+	if err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	if err := e.checkMovedDates(ctx, event, dateBegin, dateEnd); err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+
+	err = e.eventRep.Update(
+		ctx,
+		eventID,
+		func(event *models.Event) (*models.Event, error) {
+			err := event.Restore(snapshot)
+			return event, err
+		},
+		e.historyServ.Change(ctx, models.ChangeRevert, version))
+	if err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	if err := e.reopenIfApproved(ctx, event, actorID); err != nil {
+		return fmt.Errorf("eventService.Revert: %w", err)
+	}
+	return nil
 }
 
 func (e *eventService) AddArtworksToEvent(ctx context.Context, eventID uuid.UUID, artworkIDs uuid.UUIDs) error {
 	if models.HasDuplicateUUIDs(artworkIDs) {
 		return fmt.Errorf("PgEventRep.AddArtworkToEvent: %v", models.ErrDuplicateArtwokIDs)
 	}
-	_, err := e.eventRep.GetByID(ctx, eventID)
+	event, err := e.eventRep.GetByID(ctx, eventID)
 	if err != nil {
-		return fmt.Errorf("PgEventRep.AddArtworkToEvent: %v", err)
+		return fmt.Errorf("PgEventRep.AddArtworkToEvent: %w", err)
+	}
+	actorID, err := e.checkCanEdit(ctx, event)
+	if err != nil {
+		return fmt.Errorf("eventService.AddArtworksToEvent: %w", err)
 	}
 	oldArtworksIDs, err := e.eventRep.GetArtworkIDs(ctx, eventID)
 	if err != nil {
@@ -145,9 +390,258 @@ func (e *eventService) AddArtworksToEvent(ctx context.Context, eventID uuid.UUID
 		}
 
 	}
-	return e.eventRep.AddArtworksToEvent(ctx, eventID, artworkIDs)
+	if err := e.checkNotInTreatment(ctx, event.GetDateBegin(), event.GetDateEnd(), artworkIDs); err != nil {
+		return fmt.Errorf("eventService.AddArtworksToEvent: %w", err)
+	}
+	if err := e.eventRep.AddArtworksToEvent(ctx, eventID, artworkIDs); err != nil {
+		return fmt.Errorf("eventService.AddArtworksToEvent: %w", err)
+	}
+	if err := e.reopenIfApproved(ctx, event, actorID); err != nil {
+		return fmt.Errorf("eventService.AddArtworksToEvent: %w", err)
+	}
+	return nil
 }
 
 func (e *eventService) DeleteArtworkFromEvent(ctx context.Context, eventID uuid.UUID, artworkID uuid.UUID) error {
-	return e.eventRep.DeleteArtworkFromEvent(ctx, eventID, artworkID)
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.DeleteArtworkFromEvent: %w", err)
+	}
+	actorID, err := e.checkCanEdit(ctx, event)
+	if err != nil {
+		return fmt.Errorf("eventService.DeleteArtworkFromEvent: %w", err)
+	}
+	if err := e.eventRep.DeleteArtworkFromEvent(ctx, eventID, artworkID); err != nil {
+		return fmt.Errorf("eventService.DeleteArtworkFromEvent: %w", err)
+	}
+	if err := e.reopenIfApproved(ctx, event, actorID); err != nil {
+		return fmt.Errorf("eventService.DeleteArtworkFromEvent: %w", err)
+	}
+	return nil
+}
+
+// changeStatus переводит мероприятие в новый статус через change и сохраняет запись в истории согласования
+func (e *eventService) changeStatus(
+	ctx context.Context, event *models.Event, actorID uuid.UUID, comment string, change func() error,
+) error {
+	if err := change(); err != nil {
+		return err
+	}
+	review, err := models.NewEventReview(uuid.New(), event.GetID(), actorID, event.GetStatus(), comment, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrValidateEventReview, err)
+	}
+	return e.eventRep.ChangeStatus(ctx, &review)
+}
+
+// reopenIfApproved снимает одобрение с мероприятия, измененного организатором, и записывает это в историю согласования.
+// Изменения администратора, который сам согласует мероприятия, повторной проверки не требуют.
+func (e *eventService) reopenIfApproved(ctx context.Context, event *models.Event, actorID uuid.UUID) error {
+	if !event.IsApproved() {
+		return nil
+	}
+	_, isAdmin, err := e.currentEditor(ctx)
+	if err != nil {
+		return err
+	} else if isAdmin {
+		return nil
+	}
+	return e.changeStatus(ctx, event, actorID, reopenComment, event.Reopen)
+}
+
+func (e *eventService) Submit(ctx context.Context, eventID uuid.UUID, comment string) error {
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.Submit: %w", err)
+	}
+	actorID, err := e.checkCanEdit(ctx, event)
+	if err != nil {
+		return fmt.Errorf("eventService.Submit: %w", err)
+	}
+	if err := e.changeStatus(ctx, event, actorID, comment, event.Submit); err != nil {
+		return fmt.Errorf("eventService.Submit: %w", err)
+	}
+	return nil
+}
+
+func (e *eventService) Approve(ctx context.Context, eventID uuid.UUID, comment string) error {
+	adminID, err := e.checkReviewer(ctx)
+	if err != nil {
+		return fmt.Errorf("eventService.Approve: %w", err)
+	}
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.Approve: %w", err)
+	}
+	if err := e.changeStatus(ctx, event, adminID, comment, event.Approve); err != nil {
+		return fmt.Errorf("eventService.Approve: %w", err)
+	}
+	return nil
+}
+
+func (e *eventService) Reject(ctx context.Context, eventID uuid.UUID, comment string) error {
+	adminID, err := e.checkReviewer(ctx)
+	if err != nil {
+		return fmt.Errorf("eventService.Reject: %w", err)
+	}
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.Reject: %w", err)
+	}
+	if err := e.changeStatus(ctx, event, adminID, comment, event.Reject); err != nil {
+		return fmt.Errorf("eventService.Reject: %w", err)
+	}
+	return nil
+}
+
+func (e *eventService) GetReviews(ctx context.Context, eventID uuid.UUID) ([]*models.EventReview, error) {
+	if _, err := e.eventRep.GetByID(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("eventService.GetReviews: %w", err)
+	}
+	return e.eventRep.GetReviews(ctx, eventID)
+}
+
+func (e *eventService) GetPendingReview(ctx context.Context) ([]*models.Event, error) {
+	if _, err := e.checkReviewer(ctx); err != nil {
+		return nil, fmt.Errorf("eventService.GetPendingReview: %w", err)
+	}
+	return e.eventRep.GetAll(ctx, &jsonreqresp.EventFilter{Status: string(models.EventStatusPending)})
+}
+
+func (e *eventService) GetOrganisers(ctx context.Context, eventID uuid.UUID) (uuid.UUIDs, error) {
+	if _, err := e.eventRep.GetByID(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("eventService.GetOrganisers: %w", err)
+	}
+	return e.eventRep.GetOrganiserIDs(ctx, eventID)
+}
+
+func (e *eventService) AddOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.AddOrganiser: %w", err)
+	}
+	if err := e.checkIsOwner(ctx, event); err != nil {
+		return fmt.Errorf("eventService.AddOrganiser: %w", err)
+	}
+	if err := e.checkEmployee(ctx, employeeID); err != nil {
+		return fmt.Errorf("eventService.AddOrganiser check employee: %w", err)
+	}
+	if employeeID == event.GetEmployeeID() {
+		return fmt.Errorf("eventService.AddOrganiser: %w", ErrOrganiserExists)
+	}
+	organiserIDs, err := e.eventRep.GetOrganiserIDs(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.AddOrganiser: %w", err)
+	}
+	for _, id := range organiserIDs {
+		if id == employeeID {
+			return fmt.Errorf("eventService.AddOrganiser: %w", ErrOrganiserExists)
+		}
+	}
+	return e.eventRep.AddOrganiser(ctx, eventID, employeeID)
+}
+
+func (e *eventService) DeleteOrganiser(ctx context.Context, eventID uuid.UUID, employeeID uuid.UUID) error {
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("eventService.DeleteOrganiser: %w", err)
+	}
+	if err := e.checkIsOwner(ctx, event); err != nil {
+		return fmt.Errorf("eventService.DeleteOrganiser: %w", err)
+	}
+	return e.eventRep.DeleteOrganiser(ctx, eventID, employeeID)
+}
+
+func (e *eventService) checkEmployee(ctx context.Context, employeeID uuid.UUID) error {
+	employeeExist, err := e.eventRep.CheckEmployeeByID(ctx, employeeID)
+	if err != nil {
+		return err
+	} else if !employeeExist {
+		return eventrep.ErrAddNoEmployee
+	}
+	return nil
+}
+
+func (e *eventService) Clone(ctx context.Context, eventID uuid.UUID, copyReq *jsonreqresp.EventCopy) (uuid.UUID, error) {
+	creatorID, err := e.resolveCreator(ctx, copyReq.EmployeeID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.Clone: %w", err)
+	}
+	if err := e.checkEmployee(ctx, creatorID); err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.Clone check employee: %w", err)
+	}
+	src, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.Clone: %w", err)
+	}
+
+	event, err := src.Clone(uuid.New(), copyReq.Title, copyReq.DateBegin, copyReq.DateEnd, creatorID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.Clone %w: %v", models.ErrValidateEvent, err)
+	}
+	if err := e.addEvent(ctx, &event); err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.Clone: %w", err)
+	}
+	return event.GetID(), nil
+}
+
+func (e *eventService) GetTemplates(ctx context.Context) ([]*models.EventTemplate, error) {
+	return e.eventRep.GetTemplates(ctx)
+}
+
+func (e *eventService) SaveAsTemplate(ctx context.Context, eventID uuid.UUID, name string, employeeID uuid.UUID) (uuid.UUID, error) {
+	creatorID, err := e.resolveCreator(ctx, employeeID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.SaveAsTemplate: %w", err)
+	}
+	if err := e.checkEmployee(ctx, creatorID); err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.SaveAsTemplate check employee: %w", err)
+	}
+	event, err := e.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.SaveAsTemplate: %w", err)
+	}
+
+	tmpl, err := models.NewEventTemplateFromEvent(uuid.New(), name, creatorID, event)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.SaveAsTemplate %w: %v", models.ErrValidateEventTemplate, err)
+	}
+	if err := e.eventRep.AddTemplate(ctx, &tmpl); err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.SaveAsTemplate: %w", err)
+	}
+	return tmpl.GetID(), nil
+}
+
+func (e *eventService) AddFromTemplate(ctx context.Context, templateID uuid.UUID, copyReq *jsonreqresp.EventCopy) (uuid.UUID, error) {
+	creatorID, err := e.resolveCreator(ctx, copyReq.EmployeeID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.AddFromTemplate: %w", err)
+	}
+	if err := e.checkEmployee(ctx, creatorID); err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.AddFromTemplate check employee: %w", err)
+	}
+	tmpl, err := e.eventRep.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.AddFromTemplate: %w", err)
+	}
+
+	event, err := tmpl.NewEvent(uuid.New(), copyReq.Title, copyReq.DateBegin, copyReq.DateEnd, creatorID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.AddFromTemplate %w: %v", models.ErrValidateEvent, err)
+	}
+	if err := e.addEvent(ctx, &event); err != nil {
+		return uuid.Nil, fmt.Errorf("eventService.AddFromTemplate: %w", err)
+	}
+	return event.GetID(), nil
+}
+
+func (e *eventService) DeleteTemplate(ctx context.Context, templateID uuid.UUID) error {
+	tmpl, err := e.eventRep.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("eventService.DeleteTemplate: %w", err)
+	}
+	if err := e.checkTemplateOwner(ctx, tmpl); err != nil {
+		return fmt.Errorf("eventService.DeleteTemplate: %w", err)
+	}
+	return e.eventRep.DeleteTemplate(ctx, templateID)
 }
@@ -0,0 +1,762 @@
+package eventserv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth/token"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/eventserv"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestEvent(artworkIDs uuid.UUIDs) *models.Event {
+	event, _ := models.NewEvent(
+		uuid.New(),
+		"Test Event",
+		time.Now(),
+		time.Now().Add(24*time.Hour),
+		"Test Address",
+		true,
+		uuid.New(),
+		100,
+		true,
+		artworkIDs,
+	)
+	return &event
+}
+
+func createTestCopyRequest(employeeID uuid.UUID) *jsonreqresp.EventCopy {
+	return &jsonreqresp.EventCopy{
+		DateBegin:  time.Now().AddDate(0, 1, 0),
+		DateEnd:    time.Now().AddDate(0, 1, 1),
+		EmployeeID: employeeID,
+	}
+}
+
+func TestEventService_Clone(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	employeeID := uuid.New()
+	ctx := authorizedCtx(t, authZ, employeeID, token.EmployeeRole)
+	artworkIDs := uuid.UUIDs{uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*eventrep.MockEventRep, *artworkrep.MockArtworkRep, *models.Event)
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupMocks: func(m *eventrep.MockEventRep, art *artworkrep.MockArtworkRep, src *models.Event) {
+				m.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+				m.On("GetByID", ctx, src.GetID()).Return(src, nil)
+				m.On("GetEventsOfArtworkOnDate", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, eventrep.ErrEventNotFound)
+				art.On("GetTreatmentsOnDate", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return([]*models.ConditionReport{}, nil)
+				m.On("Add", ctx, mock.MatchedBy(func(e *models.Event) bool {
+					return e.GetID() != src.GetID() &&
+						e.GetTitle() == src.GetTitle() &&
+						e.GetEmployeeID() == employeeID
+				})).Return(nil)
+				m.On("AddArtworksToEvent", ctx, mock.Anything, artworkIDs).Return(nil)
+			},
+		},
+		{
+			name: "artwork busy",
+			setupMocks: func(m *eventrep.MockEventRep, _ *artworkrep.MockArtworkRep, src *models.Event) {
+				m.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+				m.On("GetByID", ctx, src.GetID()).Return(src, nil)
+				m.On("GetEventsOfArtworkOnDate", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return([]*models.Event{createTestEvent(nil)}, nil)
+			},
+			expectedError: eventserv.ErrArtworkBusy,
+		},
+		{
+			name: "artwork under treatment",
+			setupMocks: func(m *eventrep.MockEventRep, art *artworkrep.MockArtworkRep, src *models.Event) {
+				m.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+				m.On("GetByID", ctx, src.GetID()).Return(src, nil)
+				m.On("GetEventsOfArtworkOnDate", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, eventrep.ErrEventNotFound)
+				treatment, err := models.NewConditionReport(uuid.New(), artworkIDs[0], time.Now(), "Restorer",
+					models.ConditionPoor, "", "Консервация холста", time.Time{}, nil)
+				require.NoError(t, err)
+				art.On("GetTreatmentsOnDate", ctx, artworkIDs[0], mock.Anything, mock.Anything).
+					Return([]*models.ConditionReport{&treatment}, nil)
+			},
+			expectedError: eventserv.ErrArtworkBusy,
+		},
+		{
+			name: "event not found",
+			setupMocks: func(m *eventrep.MockEventRep, _ *artworkrep.MockArtworkRep, src *models.Event) {
+				m.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+				m.On("GetByID", ctx, src.GetID()).Return(nil, eventrep.ErrEventNotFound)
+			},
+			expectedError: eventrep.ErrEventNotFound,
+		},
+		{
+			name: "no employee",
+			setupMocks: func(m *eventrep.MockEventRep, _ *artworkrep.MockArtworkRep, src *models.Event) {
+				m.On("CheckEmployeeByID", ctx, employeeID).Return(false, nil)
+			},
+			expectedError: eventrep.ErrAddNoEmployee,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEvent := &eventrep.MockEventRep{}
+			mockArt := &artworkrep.MockArtworkRep{}
+			service := eventserv.NewEventService(mockEvent, mockArt, authZ, &historyserv.MockHistoryServ{})
+			src := createTestEvent(artworkIDs)
+			tt.setupMocks(mockEvent, mockArt, src)
+
+			newID, err := service.Clone(ctx, src.GetID(), createTestCopyRequest(employeeID))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, uuid.Nil, newID)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, src.GetID(), newID)
+			}
+			mockEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventService_SaveAsTemplate(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	employeeID := uuid.New()
+	ctx := authorizedCtx(t, authZ, employeeID, token.EmployeeRole)
+	src := createTestEvent(uuid.UUIDs{uuid.New()})
+
+	mockEvent := &eventrep.MockEventRep{}
+	mockArt := &artworkrep.MockArtworkRep{}
+	service := eventserv.NewEventService(mockEvent, mockArt, authZ, &historyserv.MockHistoryServ{})
+
+	mockEvent.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+	mockEvent.On("GetByID", ctx, src.GetID()).Return(src, nil)
+	mockEvent.On("AddTemplate", ctx, mock.MatchedBy(func(tmpl *models.EventTemplate) bool {
+		return tmpl.GetName() == "School tour" &&
+			tmpl.GetTitle() == src.GetTitle() &&
+			tmpl.GetAddress() == src.GetAddress() &&
+			tmpl.GetEmployeeID() == employeeID &&
+			assert.ObjectsAreEqual(src.GetArtworkIDs(), tmpl.GetArtworkIDs())
+	})).Return(nil)
+
+	tmplID, err := service.SaveAsTemplate(ctx, src.GetID(), "School tour", uuid.Nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, tmplID)
+	mockEvent.AssertExpectations(t)
+}
+
+func TestEventService_DeleteTemplate(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	ownerID := uuid.New()
+	tmpl, err := models.NewEventTemplate(
+		uuid.New(), "School tour", "Tour", "Test Address", true, ownerID, 30, uuid.UUIDs{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expectedError error
+	}{
+		{
+			name: "owner",
+			ctx:  authorizedCtx(t, authZ, ownerID, token.EmployeeRole),
+		},
+		{
+			name: "admin",
+			ctx:  authorizedCtx(t, authZ, uuid.New(), token.AdminRole),
+		},
+		{
+			name:          "another employee",
+			ctx:           authorizedCtx(t, authZ, uuid.New(), token.EmployeeRole),
+			expectedError: eventserv.ErrEventForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEvent := &eventrep.MockEventRep{}
+			mockEvent.On("GetTemplateByID", tt.ctx, tmpl.GetID()).Return(&tmpl, nil)
+			if tt.expectedError == nil {
+				mockEvent.On("DeleteTemplate", tt.ctx, tmpl.GetID()).Return(nil)
+			}
+			service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+			err := service.DeleteTemplate(tt.ctx, tmpl.GetID())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				mockEvent.AssertNotCalled(t, "DeleteTemplate", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+			mockEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventService_AddFromTemplate(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	employeeID := uuid.New()
+	ctx := authorizedCtx(t, authZ, employeeID, token.EmployeeRole)
+	artworkIDs := uuid.UUIDs{uuid.New()}
+	tmpl, err := models.NewEventTemplate(
+		uuid.New(), "School tour", "Tour", "Test Address", true, uuid.New(), 30, artworkIDs)
+	require.NoError(t, err)
+
+	t.Run("success with new title", func(t *testing.T) {
+		mockEvent := &eventrep.MockEventRep{}
+		mockArt := &artworkrep.MockArtworkRep{}
+		service := eventserv.NewEventService(mockEvent, mockArt, authZ, &historyserv.MockHistoryServ{})
+		mockArt.On("GetTreatmentsOnDate", ctx, artworkIDs[0], mock.Anything, mock.Anything).
+			Return([]*models.ConditionReport{}, nil)
+
+		mockEvent.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+		mockEvent.On("GetTemplateByID", ctx, tmpl.GetID()).Return(&tmpl, nil)
+		mockEvent.On("GetEventsOfArtworkOnDate", ctx, artworkIDs[0], mock.Anything, mock.Anything).
+			Return(nil, eventrep.ErrEventNotFound)
+		mockEvent.On("Add", ctx, mock.MatchedBy(func(e *models.Event) bool {
+			return e.GetTitle() == "Tour 2" && e.GetTicketCount() == 30
+		})).Return(nil)
+		mockEvent.On("AddArtworksToEvent", ctx, mock.Anything, artworkIDs).Return(nil)
+
+		copyReq := createTestCopyRequest(employeeID)
+		copyReq.Title = "Tour 2"
+		_, err := service.AddFromTemplate(ctx, tmpl.GetID(), copyReq)
+		require.NoError(t, err)
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("invalid dates", func(t *testing.T) {
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+		mockEvent.On("GetTemplateByID", ctx, tmpl.GetID()).Return(&tmpl, nil)
+
+		copyReq := createTestCopyRequest(employeeID)
+		copyReq.DateBegin, copyReq.DateEnd = copyReq.DateEnd, copyReq.DateBegin
+		_, err := service.AddFromTemplate(ctx, tmpl.GetID(), copyReq)
+		assert.ErrorIs(t, err, models.ErrValidateEvent)
+		mockEvent.AssertExpectations(t)
+	})
+}
+
+func authorizedCtx(t *testing.T, authZ auth.AuthZ, personID uuid.UUID, role string) context.Context {
+	t.Helper()
+	return authZ.Authorize(context.Background(), token.Payload{PersonID: personID, Role: role})
+}
+
+func TestEventService_UpdatePermissions(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	event := createTestEvent(nil)
+	organiserID := uuid.New()
+	update := &jsonreqresp.EventUpdate{
+		Title:      "New title",
+		DateBegin:  event.GetDateBegin(),
+		DateEnd:    event.GetDateEnd(),
+		Address:    event.GetAddress(),
+		CanVisit:   true,
+		CntTickets: 10,
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		organisers    uuid.UUIDs
+		expectedError error
+	}{
+		{
+			name: "creator",
+			ctx:  authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole),
+		},
+		{
+			name:       "organiser",
+			ctx:        authorizedCtx(t, authZ, organiserID, token.EmployeeRole),
+			organisers: uuid.UUIDs{organiserID},
+		},
+		{
+			name: "admin",
+			ctx:  authorizedCtx(t, authZ, uuid.New(), token.AdminRole),
+		},
+		{
+			name:          "other employee",
+			ctx:           authorizedCtx(t, authZ, uuid.New(), token.EmployeeRole),
+			organisers:    uuid.UUIDs{organiserID},
+			expectedError: eventserv.ErrEventForbidden,
+		},
+		{
+			name:          "user",
+			ctx:           authorizedCtx(t, authZ, uuid.New(), token.UserRole),
+			expectedError: eventserv.ErrEventForbidden,
+		},
+		{
+			name:          "not authorized",
+			ctx:           context.Background(),
+			expectedError: auth.ErrNotAuthZ,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEvent := &eventrep.MockEventRep{}
+			historyMock := &historyserv.MockHistoryServ{}
+			service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, historyMock)
+
+			mockEvent.On("GetByID", tt.ctx, event.GetID()).Return(event, nil)
+			mockEvent.On("GetOrganiserIDs", tt.ctx, event.GetID()).Return(tt.organisers, nil).Maybe()
+			if tt.expectedError == nil {
+				mockEvent.On("Update", tt.ctx, event.GetID(), mock.Anything, mock.AnythingOfType("*models.Change")).Return(nil)
+				historyMock.On("Change", tt.ctx, models.ChangeUpdate, 0).Return(&models.Change{Action: models.ChangeUpdate})
+			}
+
+			err := service.Update(tt.ctx, event.GetID(), update)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				mockEvent.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+			mockEvent.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventService_ArtworksPermissions(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	event := createTestEvent(nil)
+	artworkID := uuid.New()
+	ctx := authorizedCtx(t, authZ, uuid.New(), token.EmployeeRole)
+
+	mockEvent := &eventrep.MockEventRep{}
+	service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+	mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+	mockEvent.On("GetOrganiserIDs", ctx, event.GetID()).Return(uuid.UUIDs{}, nil)
+
+	err = service.AddArtworksToEvent(ctx, event.GetID(), uuid.UUIDs{artworkID})
+	assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+	err = service.DeleteArtworkFromEvent(ctx, event.GetID(), artworkID)
+	assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+	err = service.Delete(ctx, event.GetID())
+	assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+
+	mockEvent.AssertNotCalled(t, "AddArtworksToEvent", mock.Anything, mock.Anything, mock.Anything)
+	mockEvent.AssertNotCalled(t, "DeleteArtworkFromEvent", mock.Anything, mock.Anything, mock.Anything)
+	mockEvent.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestEventService_AddOrganiser(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	event := createTestEvent(nil)
+	organiserID := uuid.New()
+
+	t.Run("creator adds organiser", func(t *testing.T) {
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("CheckEmployeeByID", ctx, organiserID).Return(true, nil)
+		mockEvent.On("GetOrganiserIDs", ctx, event.GetID()).Return(uuid.UUIDs{}, nil)
+		mockEvent.On("AddOrganiser", ctx, event.GetID(), organiserID).Return(nil)
+
+		require.NoError(t, service.AddOrganiser(ctx, event.GetID(), organiserID))
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("already organiser", func(t *testing.T) {
+		ctx := authorizedCtx(t, authZ, uuid.New(), token.AdminRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("CheckEmployeeByID", ctx, organiserID).Return(true, nil)
+		mockEvent.On("GetOrganiserIDs", ctx, event.GetID()).Return(uuid.UUIDs{organiserID}, nil)
+
+		err := service.AddOrganiser(ctx, event.GetID(), organiserID)
+		assert.ErrorIs(t, err, eventserv.ErrOrganiserExists)
+		mockEvent.AssertNotCalled(t, "AddOrganiser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("organiser can't add organisers", func(t *testing.T) {
+		ctx := authorizedCtx(t, authZ, organiserID, token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+
+		err := service.AddOrganiser(ctx, event.GetID(), uuid.New())
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		mockEvent.AssertExpectations(t)
+	})
+}
+
+func TestEventService_ReviewWorkflow(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	adminCtx := authorizedCtx(t, authZ, uuid.New(), token.AdminRole)
+
+	t.Run("creator submits draft", func(t *testing.T) {
+		event := createTestEvent(nil)
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("ChangeStatus", ctx, mock.MatchedBy(func(r *models.EventReview) bool {
+			return r.GetEventID() == event.GetID() &&
+				r.GetActorID() == event.GetEmployeeID() &&
+				r.GetStatus() == models.EventStatusPending
+		})).Return(nil)
+
+		require.NoError(t, service.Submit(ctx, event.GetID(), ""))
+		assert.Equal(t, models.EventStatusPending, event.GetStatus())
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("admin approves pending", func(t *testing.T) {
+		event := createTestEvent(nil)
+		require.NoError(t, event.SetStatus(models.EventStatusPending))
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", adminCtx, event.GetID()).Return(event, nil)
+		mockEvent.On("ChangeStatus", adminCtx, mock.MatchedBy(func(r *models.EventReview) bool {
+			return r.GetStatus() == models.EventStatusApproved && r.GetComment() == "ok"
+		})).Return(nil)
+
+		require.NoError(t, service.Approve(adminCtx, event.GetID(), "ok"))
+		assert.True(t, event.IsApproved())
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("reject requires comment", func(t *testing.T) {
+		event := createTestEvent(nil)
+		require.NoError(t, event.SetStatus(models.EventStatusPending))
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", adminCtx, event.GetID()).Return(event, nil)
+
+		err := service.Reject(adminCtx, event.GetID(), "  ")
+		assert.ErrorIs(t, err, models.ErrValidateEventReview)
+		mockEvent.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("approve draft", func(t *testing.T) {
+		event := createTestEvent(nil)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		mockEvent.On("GetByID", adminCtx, event.GetID()).Return(event, nil)
+
+		err := service.Approve(adminCtx, event.GetID(), "")
+		assert.ErrorIs(t, err, models.ErrEventStatusChange)
+		mockEvent.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("employee can't review", func(t *testing.T) {
+		event := createTestEvent(nil)
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		err := service.Approve(ctx, event.GetID(), "")
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		_, err = service.GetPendingReview(ctx)
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		mockEvent.AssertExpectations(t)
+	})
+}
+
+func TestEventService_Revert(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+
+	t.Run("creator reverts title and dates", func(t *testing.T) {
+		event := createTestEvent(nil)
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		version := event.Snapshot()
+		version["title"] = "Old title"
+		version["dateEnd"] = event.GetDateEnd().Add(24 * time.Hour).UTC().Format(time.RFC3339Nano)
+
+		mockEvent := &eventrep.MockEventRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, historyMock)
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		historyMock.On("GetSnapshot", ctx, models.EntityEvent, event.GetID(), 2).Return(version, nil)
+		mockEvent.On("GetArtworkIDs", ctx, event.GetID()).Return(uuid.UUIDs{}, nil)
+		mockEvent.On("Update", ctx, event.GetID(), mock.Anything, mock.AnythingOfType("*models.Change")).Return(nil).Run(func(args mock.Arguments) {
+			funcUpdate := args.Get(2).(func(*models.Event) (*models.Event, error))
+			_, err := funcUpdate(event)
+			require.NoError(t, err)
+		})
+		historyMock.On("Change", ctx, models.ChangeRevert, 2).Return(&models.Change{Action: models.ChangeRevert, RevertedTo: 2})
+
+		require.NoError(t, service.Revert(ctx, event.GetID(), 2))
+		assert.Equal(t, "Old title", event.GetTitle())
+		assert.Equal(t, version, event.Snapshot())
+		mockEvent.AssertExpectations(t)
+		historyMock.AssertExpectations(t)
+	})
+
+	t.Run("other employee", func(t *testing.T) {
+		event := createTestEvent(nil)
+		ctx := authorizedCtx(t, authZ, uuid.New(), token.EmployeeRole)
+
+		mockEvent := &eventrep.MockEventRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, historyMock)
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("GetOrganiserIDs", ctx, event.GetID()).Return(uuid.UUIDs{}, nil)
+
+		err := service.Revert(ctx, event.GetID(), 1)
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		historyMock.AssertNotCalled(t, "GetSnapshot", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockEvent.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEventService_EditApprovedEvent(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	reopened := func(actorID uuid.UUID) interface{} {
+		return mock.MatchedBy(func(r *models.EventReview) bool {
+			return r.GetStatus() == models.EventStatusPending && r.GetActorID() == actorID && r.GetComment() != ""
+		})
+	}
+
+	t.Run("creator update returns event to review", func(t *testing.T) {
+		event := createTestEvent(nil)
+		require.NoError(t, event.SetStatus(models.EventStatusApproved))
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		update := &jsonreqresp.EventUpdate{
+			Title:      "New title",
+			DateBegin:  event.GetDateBegin(),
+			DateEnd:    event.GetDateEnd(),
+			Address:    event.GetAddress(),
+			CanVisit:   true,
+			CntTickets: 10,
+		}
+		mockEvent := &eventrep.MockEventRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, historyMock)
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("Update", ctx, event.GetID(), mock.Anything, mock.AnythingOfType("*models.Change")).Return(nil)
+		historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(&models.Change{Action: models.ChangeUpdate})
+		mockEvent.On("ChangeStatus", ctx, reopened(event.GetEmployeeID())).Return(nil)
+
+		require.NoError(t, service.Update(ctx, event.GetID(), update))
+		assert.Equal(t, models.EventStatusPending, event.GetStatus())
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("organiser changes artworks", func(t *testing.T) {
+		artworkID := uuid.New()
+		event := createTestEvent(uuid.UUIDs{artworkID})
+		require.NoError(t, event.SetStatus(models.EventStatusApproved))
+		organiserID := uuid.New()
+		ctx := authorizedCtx(t, authZ, organiserID, token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("GetOrganiserIDs", ctx, event.GetID()).Return(uuid.UUIDs{organiserID}, nil)
+		mockEvent.On("DeleteArtworkFromEvent", ctx, event.GetID(), artworkID).Return(nil)
+		mockEvent.On("ChangeStatus", ctx, reopened(organiserID)).Return(nil)
+
+		require.NoError(t, service.DeleteArtworkFromEvent(ctx, event.GetID(), artworkID))
+		assert.False(t, event.IsApproved())
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("admin edit keeps approval", func(t *testing.T) {
+		event := createTestEvent(nil)
+		require.NoError(t, event.SetStatus(models.EventStatusApproved))
+		ctx := authorizedCtx(t, authZ, uuid.New(), token.AdminRole)
+		artworkID := uuid.New()
+		mockEvent := &eventrep.MockEventRep{}
+		mockArt := &artworkrep.MockArtworkRep{}
+		service := eventserv.NewEventService(mockEvent, mockArt, authZ, &historyserv.MockHistoryServ{})
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("GetArtworkIDs", ctx, event.GetID()).Return(uuid.UUIDs{}, nil)
+		mockArt.On("GetTreatmentsOnDate", ctx, artworkID, mock.Anything, mock.Anything).
+			Return([]*models.ConditionReport{}, nil)
+		mockEvent.On("AddArtworksToEvent", ctx, event.GetID(), uuid.UUIDs{artworkID}).Return(nil)
+
+		require.NoError(t, service.AddArtworksToEvent(ctx, event.GetID(), uuid.UUIDs{artworkID}))
+		assert.True(t, event.IsApproved())
+		mockEvent.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEventService_MoveDatesIntoTreatment(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	artworkID := uuid.New()
+	treatment, err := models.NewConditionReport(uuid.New(), artworkID, time.Now(), "Restorer",
+		models.ConditionPoor, "", "Консервация холста", time.Time{}, nil)
+	require.NoError(t, err)
+
+	t.Run("update", func(t *testing.T) {
+		event := createTestEvent(uuid.UUIDs{artworkID})
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		update := &jsonreqresp.EventUpdate{
+			Title:      event.GetTitle(),
+			DateBegin:  event.GetDateBegin().AddDate(0, 1, 0),
+			DateEnd:    event.GetDateEnd().AddDate(0, 1, 0),
+			Address:    event.GetAddress(),
+			CanVisit:   true,
+			CntTickets: 10,
+		}
+		mockEvent := &eventrep.MockEventRep{}
+		mockArt := &artworkrep.MockArtworkRep{}
+		service := eventserv.NewEventService(mockEvent, mockArt, authZ, &historyserv.MockHistoryServ{})
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("GetArtworkIDs", ctx, event.GetID()).Return(uuid.UUIDs{artworkID}, nil)
+		mockArt.On("GetTreatmentsOnDate", ctx, artworkID, update.DateBegin, update.DateEnd).
+			Return([]*models.ConditionReport{&treatment}, nil)
+
+		err := service.Update(ctx, event.GetID(), update)
+		assert.ErrorIs(t, err, eventserv.ErrArtworkBusy)
+		mockEvent.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockArt.AssertExpectations(t)
+	})
+
+	t.Run("same dates are not rechecked", func(t *testing.T) {
+		event := createTestEvent(uuid.UUIDs{artworkID})
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		update := &jsonreqresp.EventUpdate{
+			Title:      "New title",
+			DateBegin:  event.GetDateBegin(),
+			DateEnd:    event.GetDateEnd(),
+			Address:    event.GetAddress(),
+			CanVisit:   true,
+			CntTickets: 10,
+		}
+		mockEvent := &eventrep.MockEventRep{}
+		mockArt := &artworkrep.MockArtworkRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		service := eventserv.NewEventService(mockEvent, mockArt, authZ, historyMock)
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		mockEvent.On("Update", ctx, event.GetID(), mock.Anything, mock.AnythingOfType("*models.Change")).Return(nil)
+		historyMock.On("Change", ctx, models.ChangeUpdate, 0).Return(&models.Change{Action: models.ChangeUpdate})
+
+		require.NoError(t, service.Update(ctx, event.GetID(), update))
+		mockArt.AssertNotCalled(t, "GetTreatmentsOnDate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		event := createTestEvent(uuid.UUIDs{artworkID})
+		ctx := authorizedCtx(t, authZ, event.GetEmployeeID(), token.EmployeeRole)
+		version := event.Snapshot()
+		version["dateBegin"] = event.GetDateBegin().AddDate(0, 0, -7).UTC().Format(time.RFC3339Nano)
+		mockEvent := &eventrep.MockEventRep{}
+		mockArt := &artworkrep.MockArtworkRep{}
+		historyMock := &historyserv.MockHistoryServ{}
+		service := eventserv.NewEventService(mockEvent, mockArt, authZ, historyMock)
+		mockEvent.On("GetByID", ctx, event.GetID()).Return(event, nil)
+		historyMock.On("GetSnapshot", ctx, models.EntityEvent, event.GetID(), 1).Return(version, nil)
+		mockEvent.On("GetArtworkIDs", ctx, event.GetID()).Return(uuid.UUIDs{artworkID}, nil)
+		mockArt.On("GetTreatmentsOnDate", ctx, artworkID, mock.Anything, mock.Anything).
+			Return([]*models.ConditionReport{&treatment}, nil)
+
+		err := service.Revert(ctx, event.GetID(), 1)
+		assert.ErrorIs(t, err, eventserv.ErrArtworkBusy)
+		mockEvent.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEventService_CreatorFromContext(t *testing.T) {
+	authZ, err := auth.NewAuthZ()
+	require.NoError(t, err)
+	employeeID, otherID := uuid.New(), uuid.New()
+
+	t.Run("employee creates on own behalf", func(t *testing.T) {
+		ctx := authorizedCtx(t, authZ, employeeID, token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+		mockEvent.On("CheckEmployeeByID", ctx, employeeID).Return(true, nil)
+		mockEvent.On("Add", ctx, mock.MatchedBy(func(e *models.Event) bool {
+			return e.GetEmployeeID() == employeeID
+		})).Return(nil)
+		mockEvent.On("AddArtworksToEvent", ctx, mock.Anything, mock.Anything).Return(nil)
+
+		err := service.Add(ctx, &jsonreqresp.EventAdd{
+			Title:      "Event",
+			DateBegin:  time.Now(),
+			DateEnd:    time.Now().Add(time.Hour),
+			Address:    "Address",
+			CntTickets: 10,
+		})
+		require.NoError(t, err)
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("employee can't create for another", func(t *testing.T) {
+		ctx := authorizedCtx(t, authZ, employeeID, token.EmployeeRole)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+
+		err := service.Add(ctx, &jsonreqresp.EventAdd{
+			Title:      "Event",
+			DateBegin:  time.Now(),
+			DateEnd:    time.Now().Add(time.Hour),
+			Address:    "Address",
+			EmployeeID: otherID,
+		})
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		_, err = service.Clone(ctx, uuid.New(), createTestCopyRequest(otherID))
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		_, err = service.SaveAsTemplate(ctx, uuid.New(), "Tour", otherID)
+		assert.ErrorIs(t, err, eventserv.ErrEventForbidden)
+		mockEvent.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+		mockEvent.AssertNotCalled(t, "AddTemplate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin names creator", func(t *testing.T) {
+		ctx := authorizedCtx(t, authZ, uuid.New(), token.AdminRole)
+		src := createTestEvent(nil)
+		mockEvent := &eventrep.MockEventRep{}
+		service := eventserv.NewEventService(mockEvent, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+		mockEvent.On("CheckEmployeeByID", ctx, otherID).Return(true, nil)
+		mockEvent.On("GetByID", ctx, src.GetID()).Return(src, nil)
+		mockEvent.On("Add", ctx, mock.MatchedBy(func(e *models.Event) bool {
+			return e.GetEmployeeID() == otherID
+		})).Return(nil)
+		mockEvent.On("AddArtworksToEvent", ctx, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.Clone(ctx, src.GetID(), createTestCopyRequest(otherID))
+		require.NoError(t, err)
+		_, err = service.Clone(ctx, src.GetID(), createTestCopyRequest(uuid.Nil))
+		assert.ErrorIs(t, err, models.ErrValidateEvent)
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("not authorized", func(t *testing.T) {
+		service := eventserv.NewEventService(&eventrep.MockEventRep{}, &artworkrep.MockArtworkRep{}, authZ, &historyserv.MockHistoryServ{})
+		_, err := service.AddFromTemplate(context.Background(), uuid.New(), createTestCopyRequest(uuid.Nil))
+		assert.ErrorIs(t, err, auth.ErrNotAuthZ)
+	})
+}
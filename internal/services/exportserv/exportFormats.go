@@ -0,0 +1,118 @@
+package exportserv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+)
+
+// exportDateLayout формат дат мероприятий в выгрузке
+const exportDateLayout = "2006-01-02"
+
+var csvHeader = []string{
+	"id", "title", "dating", "yearFrom", "yearTo", "creationYear",
+	"technic", "material", "size", "heightCm", "widthCm", "depthCm", "weightKg",
+	"authorID", "author", "authorRole", "coAuthors",
+	"collectionID", "collection", "tags", "events",
+}
+
+// csvWriter пишет одну строку на произведение; соавторы, теги и мероприятия перечисляются через "; "
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Begin() error {
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) Write(rec *models.ArtworkExport) error {
+	art := rec.Artwork
+	dating := art.GetDating()
+	dimensions := art.GetDimensions()
+
+	coAuthors := make([]string, len(art.GetCoAuthors()))
+	for i, co := range art.GetCoAuthors() {
+		coAuthors[i] = co.Label()
+	}
+	tags := make([]string, len(art.GetTags()))
+	for i, tag := range art.GetTags() {
+		tags[i] = tag.GetName()
+	}
+	events := make([]string, len(rec.Events))
+	for i, event := range rec.Events {
+		events[i] = eventLabel(event)
+	}
+
+	return c.w.Write([]string{
+		art.GetID().String(),
+		art.GetTitle(),
+		dating.Label(),
+		strconv.Itoa(dating.GetYearFrom()),
+		strconv.Itoa(dating.GetYearTo()),
+		strconv.Itoa(art.GetCreationYear()),
+		art.GetTechnic(),
+		art.GetMaterial(),
+		art.GetSize(),
+		formatMeasure(dimensions.GetHeightCm()),
+		formatMeasure(dimensions.GetWidthCm()),
+		formatMeasure(dimensions.GetDepthCm()),
+		formatMeasure(dimensions.GetWeightKg()),
+		art.GetAuthor().GetID().String(),
+		art.GetAuthor().GetName(),
+		string(art.GetAuthorRole()),
+		strings.Join(coAuthors, "; "),
+		art.GetCollection().GetID().String(),
+		art.GetCollection().GetTitle(),
+		strings.Join(tags, "; "),
+		strings.Join(events, "; "),
+	})
+}
+
+func (c *csvWriter) End() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlWriter пишет по одному JSON-объекту jsonreqresp.ExportArtworkResponse в строке
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &jsonlWriter{enc: enc}
+}
+
+func (j *jsonlWriter) Begin() error {
+	return nil
+}
+
+func (j *jsonlWriter) Write(rec *models.ArtworkExport) error {
+	return j.enc.Encode(rec.ToExportArtworkResponse())
+}
+
+func (j *jsonlWriter) End() error {
+	return nil
+}
+
+// formatMeasure возвращает пустую строку для незаданного размера
+func formatMeasure(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func eventLabel(e *models.Event) string {
+	return e.GetTitle() + " (" + e.GetDateBegin().Format(exportDateLayout) + " – " +
+		e.GetDateEnd().Format(exportDateLayout) + ")"
+}
@@ -0,0 +1,122 @@
+package exportserv
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"github.com/google/uuid"
+)
+
+// exportPageSize число произведений, читаемых из хранилища за один запрос
+const exportPageSize = jsonreqresp.MaxPageSize
+
+type ExportServ interface {
+	// Export пишет в w произведения, подходящие под фильтр, вместе с авторами, коллекциями и
+	// согласованными мероприятиями. Каталог читается страницами, память не зависит от его размера
+	Export(ctx context.Context, w io.Writer, format models.ExportFormat, filter *jsonreqresp.ArtworkFilter) error
+}
+
+func NewExportServ(artworkRep artworkrep.ArtworkRep, eventRep eventrep.EventRep) ExportServ {
+	return &exportServ{
+		artworkRep: artworkRep,
+		eventRep:   eventRep,
+	}
+}
+
+type exportServ struct {
+	artworkRep artworkrep.ArtworkRep
+	eventRep   eventrep.EventRep
+}
+
+// recordWriter пишет записи выгрузки в одном из форматов
+type recordWriter interface {
+	Begin() error
+	Write(rec *models.ArtworkExport) error
+	End() error
+}
+
+func newRecordWriter(w io.Writer, format models.ExportFormat) (recordWriter, error) {
+	switch format {
+	case models.ExportCSV:
+		return newCSVWriter(w), nil
+	case models.ExportJSONL:
+		return newJSONLWriter(w), nil
+	case models.ExportDublinCore:
+		return newDublinCoreWriter(w), nil
+	case models.ExportLIDO:
+		return newLIDOWriter(w), nil
+	}
+	return nil, models.ErrExportFormat
+}
+
+func (s *exportServ) Export(
+	ctx context.Context, w io.Writer, format models.ExportFormat, filter *jsonreqresp.ArtworkFilter,
+) error {
+	writer, err := newRecordWriter(w, format)
+	if err != nil {
+		return fmt.Errorf("exportServ.Export: %w", err)
+	}
+	if filter == nil {
+		filter = &jsonreqresp.ArtworkFilter{}
+	}
+	if err := filter.Check(); err != nil {
+		return fmt.Errorf("exportServ.Export: %w", err)
+	}
+	if err := writer.Begin(); err != nil {
+		return fmt.Errorf("exportServ.Export: %w", err)
+	}
+
+	// сортировка по id: порядок устойчив к изменениям каталога во время выгрузки
+	page := &jsonreqresp.PageRequest{Limit: exportPageSize}
+	for {
+		arts, pageInfo, err := s.artworkRep.GetArtworksPage(ctx, filter, &jsonreqresp.ArtworkSortOps{}, page)
+		if err != nil {
+			return fmt.Errorf("exportServ.Export: %w", err)
+		}
+		events, err := s.approvedEvents(ctx, arts)
+		if err != nil {
+			return fmt.Errorf("exportServ.Export: %w", err)
+		}
+		for _, art := range arts {
+			if err := writer.Write(&models.ArtworkExport{Artwork: art, Events: events[art.GetID()]}); err != nil {
+				return fmt.Errorf("exportServ.Export: %w", err)
+			}
+		}
+		if pageInfo.NextCursor == "" {
+			break
+		}
+		page.Cursor = pageInfo.NextCursor
+	}
+
+	if err := writer.End(); err != nil {
+		return fmt.Errorf("exportServ.Export: %w", err)
+	}
+	return nil
+}
+
+// approvedEvents возвращает согласованные мероприятия по произведениям; черновики и отклоненные не выгружаются
+func (s *exportServ) approvedEvents(ctx context.Context, arts []*models.Artwork) (map[uuid.UUID][]*models.Event, error) {
+	ids := make(uuid.UUIDs, len(arts))
+	for i, art := range arts {
+		ids[i] = art.GetID()
+	}
+	events, err := s.eventRep.GetEventsByArtworks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byArtwork := make(map[uuid.UUID][]*models.Event, len(arts))
+	for _, event := range events {
+		if !event.IsApproved() {
+			continue
+		}
+		for _, id := range event.GetArtworkIDs() {
+			byArtwork[id] = append(byArtwork[id], event)
+		}
+	}
+	return byArtwork, nil
+}
@@ -0,0 +1,199 @@
+package exportserv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/exportserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type exportFixture struct {
+	starryNight  *models.Artwork
+	potatoEaters *models.Artwork
+	exhibition   *models.Event
+	draft        *models.Event
+}
+
+func newExportFixture(t *testing.T) exportFixture {
+	author, err := models.NewAuthor(uuid.New(), "Винсент Ван Гог", 1853, 1890)
+	require.NoError(t, err)
+	collection, err := models.NewCollection(uuid.New(), "Постимпрессионизм")
+	require.NoError(t, err)
+
+	starryNight, err := models.NewArtwork(uuid.New(), "Звёздная ночь", "Масло", "Холст",
+		"73,7 × 92,1 см", 1889, &author, &collection)
+	require.NoError(t, err)
+	dimensions, err := models.ParseDimensions(starryNight.GetSize())
+	require.NoError(t, err)
+	starryNight.SetDimensions(dimensions)
+	tag, err := models.NewTag(uuid.New(), "пейзаж", models.TagSubject, uuid.Nil, nil)
+	require.NoError(t, err)
+	starryNight.SetTags([]*models.Tag{&tag})
+
+	dating, err := models.NewArtworkDating(1884, 1885, models.DateCirca, models.PrecisionYear)
+	require.NoError(t, err)
+	primary, err := models.NewAttribution(&author, models.RoleWorkshop)
+	require.NoError(t, err)
+	potatoEaters, err := models.NewDatedArtwork(uuid.New(), "Едоки картофеля", "Масло", "Холст",
+		"82 × 114 см", dating, primary, nil, &collection)
+	require.NoError(t, err)
+
+	begin := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	exhibition, err := models.NewEvent(uuid.New(), "Ван Гог & современники", begin, begin.AddDate(0, 3, 0),
+		"Волхонка, 12", true, uuid.New(), 100, true, uuid.UUIDs{starryNight.GetID(), potatoEaters.GetID()})
+	require.NoError(t, err)
+	require.NoError(t, exhibition.Submit())
+	require.NoError(t, exhibition.Approve())
+	draft, err := models.NewEvent(uuid.New(), "Черновик", begin, begin.AddDate(0, 1, 0),
+		"Волхонка, 12", true, uuid.New(), 100, true, uuid.UUIDs{starryNight.GetID()})
+	require.NoError(t, err)
+
+	return exportFixture{&starryNight, &potatoEaters, &exhibition, &draft}
+}
+
+// newExportServ отдает произведения двумя страницами по одному
+func newExportServ(t *testing.T, f exportFixture, filter *jsonreqresp.ArtworkFilter) exportserv.ExportServ {
+	artMock := new(artworkrep.MockArtworkRep)
+	eventMock := new(eventrep.MockEventRep)
+	firstPage := mock.MatchedBy(func(p *jsonreqresp.PageRequest) bool { return p.Cursor == "" })
+	nextPage := mock.MatchedBy(func(p *jsonreqresp.PageRequest) bool { return p.Cursor == "next" })
+	artMock.On("GetArtworksPage", mock.Anything, filter, mock.Anything, firstPage).
+		Return([]*models.Artwork{f.starryNight}, jsonreqresp.PageInfo{Total: 2, HasTotal: true, Limit: 1, NextCursor: "next"}, nil).Once()
+	artMock.On("GetArtworksPage", mock.Anything, filter, mock.Anything, nextPage).
+		Return([]*models.Artwork{f.potatoEaters}, jsonreqresp.PageInfo{Limit: 1}, nil).Once()
+	eventMock.On("GetEventsByArtworks", mock.Anything, uuid.UUIDs{f.starryNight.GetID()}).
+		Return([]*models.Event{f.exhibition, f.draft}, nil).Once()
+	eventMock.On("GetEventsByArtworks", mock.Anything, uuid.UUIDs{f.potatoEaters.GetID()}).
+		Return([]*models.Event{f.exhibition}, nil).Once()
+	t.Cleanup(func() {
+		artMock.AssertExpectations(t)
+		eventMock.AssertExpectations(t)
+	})
+	return exportserv.NewExportServ(artMock, eventMock)
+}
+
+func TestExportService_Export(t *testing.T) {
+	ctx := context.Background()
+	f := newExportFixture(t)
+	filter := &jsonreqresp.ArtworkFilter{Collection: "Постимпрессионизм"}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newExportServ(t, f, filter).Export(ctx, &buf, models.ExportCSV, filter))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		header := make(map[string]int, len(rows[0]))
+		for i, name := range rows[0] {
+			header[name] = i
+		}
+		first, second := rows[1], rows[2]
+		assert.Equal(t, f.starryNight.GetID().String(), first[header["id"]])
+		assert.Equal(t, f.starryNight.GetSize(), first[header["size"]])
+		assert.Equal(t, "73.7", first[header["heightCm"]])
+		assert.Equal(t, "пейзаж", first[header["tags"]])
+		assert.Equal(t, "Ван Гог & современники (2024-06-01 – 2024-09-01)", first[header["events"]])
+		assert.Equal(t, "1884", second[header["yearFrom"]])
+		assert.Equal(t, "workshop", second[header["authorRole"]])
+		assert.Empty(t, second[header["heightCm"]])
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newExportServ(t, f, filter).Export(ctx, &buf, models.ExportJSONL, filter))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var rec jsonreqresp.ExportArtworkResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &rec))
+		assert.Equal(t, f.starryNight.GetID().String(), rec.ID)
+		require.Len(t, rec.Events, 1)
+		assert.Equal(t, f.exhibition.GetID().String(), rec.Events[0].ID)
+		assert.Contains(t, lines[0], "Ван Гог & современники")
+	})
+
+	t.Run("dublin core", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newExportServ(t, f, filter).Export(ctx, &buf, models.ExportDublinCore, filter))
+
+		var doc struct {
+			Records []struct {
+				Identifier string   `xml:"identifier"`
+				Creators   []string `xml:"creator"`
+				Date       string   `xml:"date"`
+				Subjects   []string `xml:"subject"`
+				Relations  []string `xml:"relation"`
+			} `xml:"record"`
+		}
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+		require.Len(t, doc.Records, 2)
+		assert.Equal(t, "urn:uuid:"+f.starryNight.GetID().String(), doc.Records[0].Identifier)
+		assert.Equal(t, "1889", doc.Records[0].Date)
+		assert.Equal(t, []string{"пейзаж"}, doc.Records[0].Subjects)
+		assert.Len(t, doc.Records[0].Relations, 1)
+		assert.Equal(t, "1884~/1885~", doc.Records[1].Date)
+		assert.Equal(t, []string{"Винсент Ван Гог (мастерская)"}, doc.Records[1].Creators)
+	})
+
+	t.Run("lido", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newExportServ(t, f, filter).Export(ctx, &buf, models.ExportLIDO, filter))
+
+		var doc struct {
+			Records []struct {
+				RecID  string   `xml:"lidoRecID"`
+				Title  string   `xml:"descriptiveMetadata>objectIdentificationWrap>titleWrap>titleSet>appellationValue"`
+				Events []string `xml:"descriptiveMetadata>eventWrap>eventSet>event>eventType>term"`
+				Units  []string `xml:"descriptiveMetadata>objectIdentificationWrap>objectMeasurementsWrap>objectMeasurementsSet>objectMeasurements>measurementsSet>measurementUnit"`
+			} `xml:"lido"`
+		}
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+		require.Len(t, doc.Records, 2)
+		assert.Equal(t, f.starryNight.GetID().String(), doc.Records[0].RecID)
+		assert.Equal(t, "Звёздная ночь", doc.Records[0].Title)
+		assert.Equal(t, []string{"Production", "Exhibition"}, doc.Records[0].Events)
+		assert.Equal(t, []string{"cm", "cm"}, doc.Records[0].Units)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		s := exportserv.NewExportServ(new(artworkrep.MockArtworkRep), new(eventrep.MockEventRep))
+		var buf bytes.Buffer
+		err := s.Export(ctx, &buf, "xlsx", filter)
+		assert.ErrorIs(t, err, models.ErrExportFormat)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		s := exportserv.NewExportServ(new(artworkrep.MockArtworkRep), new(eventrep.MockEventRep))
+		var buf bytes.Buffer
+		err := s.Export(ctx, &buf, models.ExportLIDO, &jsonreqresp.ArtworkFilter{YearFrom: 1900, YearTo: 1800})
+		assert.ErrorIs(t, err, jsonreqresp.ErrArtworkYearRange)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		artMock := new(artworkrep.MockArtworkRep)
+		repErr := errors.New("db down")
+		artMock.On("GetArtworksPage", ctx, filter, mock.Anything, mock.Anything).
+			Return([]*models.Artwork(nil), jsonreqresp.PageInfo{}, repErr)
+		s := exportserv.NewExportServ(artMock, new(eventrep.MockEventRep))
+		err := s.Export(ctx, new(bytes.Buffer), models.ExportCSV, filter)
+		assert.ErrorIs(t, err, repErr)
+	})
+}
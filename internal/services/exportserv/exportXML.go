@@ -0,0 +1,316 @@
+package exportserv
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+)
+
+// xmlWriter пишет записи внутри корневого элемента; записи кодируются по одной,
+// поэтому выгрузка не накапливается в памяти
+type xmlWriter struct {
+	w      io.Writer
+	enc    *xml.Encoder
+	root   xml.StartElement
+	record func(rec *models.ArtworkExport) any
+}
+
+func (x *xmlWriter) Begin() error {
+	if _, err := io.WriteString(x.w, xml.Header); err != nil {
+		return err
+	}
+	return x.enc.EncodeToken(x.root)
+}
+
+func (x *xmlWriter) Write(rec *models.ArtworkExport) error {
+	return x.enc.Encode(x.record(rec))
+}
+
+func (x *xmlWriter) End() error {
+	if err := x.enc.EncodeToken(x.root.End()); err != nil {
+		return err
+	}
+	return x.enc.Flush()
+}
+
+func newXMLWriter(w io.Writer, root xml.StartElement, record func(rec *models.ArtworkExport) any) *xmlWriter {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &xmlWriter{w: w, enc: enc, root: root, record: record}
+}
+
+func xmlAttr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+// ----- Dublin Core -----
+
+type dcRecord struct {
+	XMLName    xml.Name `xml:"record"`
+	Identifier string   `xml:"dc:identifier"`
+	Title      string   `xml:"dc:title"`
+	Creators   []string `xml:"dc:creator"`
+	Date       string   `xml:"dc:date,omitempty"`
+	Type       string   `xml:"dc:type"`
+	Format     string   `xml:"dc:format,omitempty"`
+	Medium     string   `xml:"dcterms:medium,omitempty"`
+	Extent     string   `xml:"dcterms:extent,omitempty"`
+	Subjects   []string `xml:"dc:subject"`
+	IsPartOf   string   `xml:"dcterms:isPartOf,omitempty"`
+	Relations  []string `xml:"dc:relation"`
+}
+
+func newDublinCoreWriter(w io.Writer) *xmlWriter {
+	root := xml.StartElement{
+		Name: xml.Name{Local: "records"},
+		Attr: []xml.Attr{
+			xmlAttr("xmlns:dc", "http://purl.org/dc/elements/1.1/"),
+			xmlAttr("xmlns:dcterms", "http://purl.org/dc/terms/"),
+		},
+	}
+	return newXMLWriter(w, root, func(rec *models.ArtworkExport) any { return toDCRecord(rec) })
+}
+
+func toDCRecord(rec *models.ArtworkExport) *dcRecord {
+	art := rec.Artwork
+	r := &dcRecord{
+		Identifier: "urn:uuid:" + art.GetID().String(),
+		Title:      art.GetTitle(),
+		Date:       art.GetDating().EDTF(),
+		// тип по словарю DCMI Type
+		Type:     "PhysicalObject",
+		Format:   art.GetTechnic(),
+		Medium:   art.GetMaterial(),
+		Extent:   art.GetSize(),
+		IsPartOf: art.GetCollection().GetTitle(),
+	}
+	for _, a := range art.GetAttributions() {
+		r.Creators = append(r.Creators, a.Label())
+	}
+	for _, tag := range art.GetTags() {
+		r.Subjects = append(r.Subjects, tag.GetName())
+	}
+	for _, event := range rec.Events {
+		r.Relations = append(r.Relations, eventLabel(event))
+	}
+	return r
+}
+
+// ----- LIDO -----
+
+const (
+	lidoNamespace      = "http://www.lido-schema.org"
+	lidoSchemaLocation = "http://www.lido-schema.org http://www.lido-schema.org/schema/v1.0/lido-v1.0.xsd"
+)
+
+type lidoTerm struct {
+	Term string `xml:"lido:term"`
+}
+
+type lidoAppellation struct {
+	Value string `xml:"lido:appellationValue"`
+}
+
+type lidoLocalID struct {
+	Type  string `xml:"lido:type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func localID(value string) lidoLocalID {
+	return lidoLocalID{Type: "local", Value: value}
+}
+
+type lidoDate struct {
+	Earliest string `xml:"lido:earliestDate"`
+	Latest   string `xml:"lido:latestDate"`
+}
+
+type lidoEventDate struct {
+	Display string   `xml:"lido:displayDate,omitempty"`
+	Date    lidoDate `xml:"lido:date"`
+}
+
+type lidoActor struct {
+	ID         lidoLocalID     `xml:"lido:actorID"`
+	Name       lidoAppellation `xml:"lido:nameActorSet"`
+	VitalDates *lidoDate       `xml:"lido:vitalDatesActor,omitempty"`
+}
+
+type lidoActorInRole struct {
+	Actor     lidoActor `xml:"lido:actor"`
+	Role      lidoTerm  `xml:"lido:roleActor"`
+	Qualifier string    `xml:"lido:attributionQualifierActor,omitempty"`
+}
+
+type lidoEvent struct {
+	ID            *lidoLocalID      `xml:"lido:eventID,omitempty"`
+	Type          lidoTerm          `xml:"lido:eventType"`
+	Name          *lidoAppellation  `xml:"lido:eventName,omitempty"`
+	Actors        []lidoActorInRole `xml:"lido:eventActor>lido:actorInRole"`
+	Date          *lidoEventDate    `xml:"lido:eventDate,omitempty"`
+	Place         string            `xml:"lido:eventPlace>lido:displayPlace,omitempty"`
+	MaterialsTech string            `xml:"lido:eventMaterialsTech>lido:displayMaterialsTech,omitempty"`
+}
+
+// lidoEventSet по схеме LIDO содержит ровно одно мероприятие
+type lidoEventSet struct {
+	Event lidoEvent `xml:"lido:event"`
+}
+
+type lidoMeasurement struct {
+	Type  string `xml:"lido:measurementType"`
+	Unit  string `xml:"lido:measurementUnit"`
+	Value string `xml:"lido:measurementValue"`
+}
+
+type lidoMeasurements struct {
+	Display      string            `xml:"lido:displayObjectMeasurements,omitempty"`
+	Measurements []lidoMeasurement `xml:"lido:objectMeasurements>lido:measurementsSet"`
+}
+
+type lidoRelatedWork struct {
+	Display string      `xml:"lido:relatedWork>lido:displayObject"`
+	ID      lidoLocalID `xml:"lido:relatedWork>lido:object>lido:objectID"`
+	RelType lidoTerm    `xml:"lido:relatedWorkRelType"`
+}
+
+type lidoRecord struct {
+	XMLName xml.Name    `xml:"lido:lido"`
+	RecID   lidoLocalID `xml:"lido:lidoRecID"`
+
+	Descriptive struct {
+		Lang         string            `xml:"xml:lang,attr"`
+		WorkType     lidoTerm          `xml:"lido:objectClassificationWrap>lido:objectWorkTypeWrap>lido:objectWorkType"`
+		Title        lidoAppellation   `xml:"lido:objectIdentificationWrap>lido:titleWrap>lido:titleSet"`
+		Measurements *lidoMeasurements `xml:"lido:objectIdentificationWrap>lido:objectMeasurementsWrap>lido:objectMeasurementsSet,omitempty"`
+		Events       []lidoEventSet    `xml:"lido:eventWrap>lido:eventSet"`
+		Subjects     []lidoTerm        `xml:"lido:objectRelationWrap>lido:subjectWrap>lido:subjectSet>lido:subject>lido:subjectConcept"`
+		RelatedWork  lidoRelatedWork   `xml:"lido:objectRelationWrap>lido:relatedWorksWrap>lido:relatedWorkSet"`
+	} `xml:"lido:descriptiveMetadata"`
+
+	Administrative struct {
+		Lang       string          `xml:"xml:lang,attr"`
+		RecordID   lidoLocalID     `xml:"lido:recordWrap>lido:recordID"`
+		RecordType lidoTerm        `xml:"lido:recordWrap>lido:recordType"`
+		Source     lidoAppellation `xml:"lido:recordWrap>lido:recordSource>lido:legalBodyName"`
+	} `xml:"lido:administrativeMetadata"`
+}
+
+func newLIDOWriter(w io.Writer) *xmlWriter {
+	root := xml.StartElement{
+		Name: xml.Name{Local: "lido:lidoWrap"},
+		Attr: []xml.Attr{
+			xmlAttr("xmlns:lido", lidoNamespace),
+			xmlAttr("xmlns:xsi", "http://www.w3.org/2001/XMLSchema-instance"),
+			xmlAttr("xsi:schemaLocation", lidoSchemaLocation),
+		},
+	}
+	return newXMLWriter(w, root, func(rec *models.ArtworkExport) any { return toLIDORecord(rec) })
+}
+
+func toLIDORecord(rec *models.ArtworkExport) *lidoRecord {
+	art := rec.Artwork
+	id := art.GetID().String()
+	dating := art.GetDating()
+
+	r := &lidoRecord{RecID: localID(id)}
+	d := &r.Descriptive
+	d.Lang = "ru"
+	d.WorkType = lidoTerm{Term: art.GetTechnic()}
+	d.Title = lidoAppellation{Value: art.GetTitle()}
+	d.Measurements = lidoObjectMeasurements(art)
+
+	production := lidoEvent{
+		Type: lidoTerm{Term: "Production"},
+		Date: &lidoEventDate{
+			Display: dating.Label(),
+			Date: lidoDate{
+				Earliest: strconv.Itoa(dating.GetYearFrom()),
+				Latest:   strconv.Itoa(dating.GetYearTo()),
+			},
+		},
+		MaterialsTech: materialsTech(art),
+	}
+	for _, a := range art.GetAttributions() {
+		production.Actors = append(production.Actors, lidoActorRole(a))
+	}
+	d.Events = append(d.Events, lidoEventSet{Event: production})
+	for _, event := range rec.Events {
+		eventID := localID(event.GetID().String())
+		d.Events = append(d.Events, lidoEventSet{Event: lidoEvent{
+			ID:   &eventID,
+			Type: lidoTerm{Term: "Exhibition"},
+			Name: &lidoAppellation{Value: event.GetTitle()},
+			Date: &lidoEventDate{Date: lidoDate{
+				Earliest: event.GetDateBegin().Format(exportDateLayout),
+				Latest:   event.GetDateEnd().Format(exportDateLayout),
+			}},
+			Place: event.GetAddress(),
+		}})
+	}
+	for _, tag := range art.GetTags() {
+		d.Subjects = append(d.Subjects, lidoTerm{Term: tag.GetName()})
+	}
+	collection := art.GetCollection()
+	d.RelatedWork = lidoRelatedWork{
+		Display: collection.GetTitle(),
+		ID:      localID(collection.GetID().String()),
+		RelType: lidoTerm{Term: "part of"},
+	}
+
+	a := &r.Administrative
+	a.Lang = "ru"
+	a.RecordID = localID(id)
+	a.RecordType = lidoTerm{Term: "item"}
+	a.Source = lidoAppellation{Value: collection.GetTitle()}
+	return r
+}
+
+func lidoActorRole(a models.Attribution) lidoActorInRole {
+	author := a.GetAuthor()
+	actor := lidoActor{
+		ID:   localID(author.GetID().String()),
+		Name: lidoAppellation{Value: author.GetName()},
+	}
+	if author.GetBirthYear() != 0 {
+		actor.VitalDates = &lidoDate{Earliest: strconv.Itoa(author.GetBirthYear())}
+		if author.GetDeathYear() != 0 {
+			actor.VitalDates.Latest = strconv.Itoa(author.GetDeathYear())
+		}
+	}
+	inRole := lidoActorInRole{Actor: actor, Role: lidoTerm{Term: "автор"}}
+	if a.GetRole() != models.RoleAuthor {
+		inRole.Qualifier = a.GetRole().Label()
+	}
+	return inRole
+}
+
+func lidoObjectMeasurements(art *models.Artwork) *lidoMeasurements {
+	dimensions := art.GetDimensions()
+	if art.GetSize() == "" && dimensions.IsZero() {
+		return nil
+	}
+	m := &lidoMeasurements{Display: art.GetSize()}
+	add := func(kind, unit string, v float64) {
+		if v != 0 {
+			m.Measurements = append(m.Measurements, lidoMeasurement{Type: kind, Unit: unit, Value: formatMeasure(v)})
+		}
+	}
+	add("height", "cm", dimensions.GetHeightCm())
+	add("width", "cm", dimensions.GetWidthCm())
+	add("depth", "cm", dimensions.GetDepthCm())
+	add("weight", "kg", dimensions.GetWeightKg())
+	return m
+}
+
+func materialsTech(art *models.Artwork) string {
+	switch {
+	case art.GetTechnic() == "":
+		return art.GetMaterial()
+	case art.GetMaterial() == "":
+		return art.GetTechnic()
+	}
+	return art.GetTechnic() + ", " + art.GetMaterial()
+}
@@ -0,0 +1,83 @@
+package historyserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"github.com/google/uuid"
+)
+
+// HistoryServ ведет историю изменений записей каталога.
+// Хранилища сущностей сохраняют снимки полей до и после изменения, сервисы восстанавливают записи по номеру версии.
+type HistoryServ interface {
+	GetHistory(ctx context.Context, entityType models.EntityType, entityID uuid.UUID) ([]*models.ChangeRecord, error)
+	// Change описывает изменение от имени сотрудника из контекста. Хранилище сущности
+	// записывает его в историю в той же транзакции, что и само изменение
+	Change(ctx context.Context, action models.ChangeAction, revertedTo int) *models.Change
+	// GetSnapshot возвращает состояние записи в версии version, версия 0 - состояние до первого изменения
+	GetSnapshot(ctx context.Context, entityType models.EntityType, entityID uuid.UUID, version int) (models.Snapshot, error)
+}
+
+var (
+	ErrVersionNotFound = errors.New("version was not found in change history")
+)
+
+type historyServ struct {
+	historyRep historyrep.HistoryRep
+	authZ      auth.AuthZ
+}
+
+func NewHistoryServ(historyRep historyrep.HistoryRep, authZ auth.AuthZ) HistoryServ {
+	return &historyServ{
+		historyRep: historyRep,
+		authZ:      authZ,
+	}
+}
+
+func (h *historyServ) GetHistory(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID,
+) ([]*models.ChangeRecord, error) {
+	history, err := h.historyRep.GetHistory(ctx, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("historyServ.GetHistory: %w", err)
+	}
+	return history, nil
+}
+
+// Change определяет автора изменения: сотрудник или администратор из контекста,
+// без авторизации автор не указывается.
+func (h *historyServ) Change(ctx context.Context, action models.ChangeAction, revertedTo int) *models.Change {
+	changedBy, err := h.authZ.AdminIDFromContext(ctx)
+	if err != nil {
+		changedBy = uuid.Nil
+	}
+	return &models.Change{
+		Action:     action,
+		RevertedTo: revertedTo,
+		ChangedBy:  changedBy,
+		ChangedAt:  time.Now(),
+	}
+}
+
+func (h *historyServ) GetSnapshot(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID, version int,
+) (models.Snapshot, error) {
+	history, err := h.historyRep.GetHistory(ctx, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("historyServ.GetSnapshot: %w", err)
+	}
+	if version == 0 && len(history) > 0 {
+		return history[0].GetBefore(), nil
+	}
+	for _, r := range history {
+		if r.GetVersion() == version {
+			return r.GetAfter(), nil
+		}
+	}
+	return nil, fmt.Errorf("historyServ.GetSnapshot: %w: %d", ErrVersionNotFound, version)
+}
@@ -0,0 +1,120 @@
+package historyserv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/historyrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/historyserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestHistory(t *testing.T, entityID uuid.UUID, titles ...string) []*models.ChangeRecord {
+	history := make([]*models.ChangeRecord, len(titles)-1)
+	for i := range history {
+		record, err := models.NewChangeRecord(uuid.New(), models.EntityCollection, entityID, i+1, models.ChangeUpdate, 0,
+			uuid.Nil, time.Now(), models.Snapshot{"title": titles[i]}, models.Snapshot{"title": titles[i+1]})
+		require.NoError(t, err)
+		history[i] = &record
+	}
+	return history
+}
+
+func TestHistoryServ_Change(t *testing.T) {
+	ctx := context.Background()
+	employeeID := uuid.New()
+
+	tests := []struct {
+		name          string
+		action        models.ChangeAction
+		revertedTo    int
+		setupMocks    func(*auth.MockAuthZ)
+		wantChangedBy uuid.UUID
+	}{
+		{
+			name:   "author of change from context",
+			action: models.ChangeUpdate,
+			setupMocks: func(authZ *auth.MockAuthZ) {
+				authZ.On("AdminIDFromContext", ctx).Return(employeeID, nil)
+			},
+			wantChangedBy: employeeID,
+		},
+		{
+			name:       "unauthorized revert",
+			action:     models.ChangeRevert,
+			revertedTo: 2,
+			setupMocks: func(authZ *auth.MockAuthZ) {
+				authZ.On("AdminIDFromContext", ctx).Return(uuid.Nil, auth.ErrNotAuthZ)
+			},
+			wantChangedBy: uuid.Nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authZMock := &auth.MockAuthZ{}
+			tt.setupMocks(authZMock)
+			service := historyserv.NewHistoryServ(&historyrep.MockHistoryRep{}, authZMock)
+
+			change := service.Change(ctx, tt.action, tt.revertedTo)
+			require.NotNil(t, change)
+			assert.Equal(t, tt.action, change.Action)
+			assert.Equal(t, tt.revertedTo, change.RevertedTo)
+			assert.Equal(t, tt.wantChangedBy, change.ChangedBy)
+			assert.False(t, change.ChangedAt.IsZero())
+
+			record, err := change.NewRecord(models.EntityCollection, uuid.New(), 3,
+				models.Snapshot{"title": "Графика"}, models.Snapshot{"title": "Гравюры"})
+			require.NoError(t, err)
+			assert.Equal(t, 3, record.GetVersion())
+			assert.Equal(t, tt.wantChangedBy, record.GetChangedBy())
+
+			record, err = change.NewRecord(models.EntityCollection, uuid.New(), 3,
+				models.Snapshot{"title": "Гравюры"}, models.Snapshot{"title": "Гравюры"})
+			require.NoError(t, err)
+			assert.Nil(t, record)
+			authZMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHistoryServ_GetSnapshot(t *testing.T) {
+	ctx := context.Background()
+	collectionID := uuid.New()
+	history := createTestHistory(t, collectionID, "Графика", "Гравюры", "Офорты")
+
+	tests := []struct {
+		name          string
+		history       []*models.ChangeRecord
+		version       int
+		expected      models.Snapshot
+		expectedError error
+	}{
+		{name: "initial state", history: history, version: 0, expected: models.Snapshot{"title": "Графика"}},
+		{name: "intermediate version", history: history, version: 1, expected: models.Snapshot{"title": "Гравюры"}},
+		{name: "latest version", history: history, version: 2, expected: models.Snapshot{"title": "Офорты"}},
+		{name: "unknown version", history: history, version: 3, expectedError: historyserv.ErrVersionNotFound},
+		{name: "empty history", history: nil, version: 0, expectedError: historyserv.ErrVersionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repMock := &historyrep.MockHistoryRep{}
+			repMock.On("GetHistory", ctx, models.EntityCollection, collectionID).Return(tt.history, nil)
+			service := historyserv.NewHistoryServ(repMock, &auth.MockAuthZ{})
+
+			snapshot, err := service.GetSnapshot(ctx, models.EntityCollection, collectionID, tt.version)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, snapshot)
+		})
+	}
+}
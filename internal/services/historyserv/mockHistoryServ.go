@@ -0,0 +1,42 @@
+package historyserv
+
+import (
+	"context"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHistoryServ реализует HistoryServ интерфейс для тестирования
+type MockHistoryServ struct {
+	mock.Mock
+}
+
+func (m *MockHistoryServ) GetHistory(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID,
+) ([]*models.ChangeRecord, error) {
+	args := m.Called(ctx, entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ChangeRecord), args.Error(1)
+}
+
+func (m *MockHistoryServ) Change(ctx context.Context, action models.ChangeAction, revertedTo int) *models.Change {
+	args := m.Called(ctx, action, revertedTo)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*models.Change)
+}
+
+func (m *MockHistoryServ) GetSnapshot(
+	ctx context.Context, entityType models.EntityType, entityID uuid.UUID, version int,
+) (models.Snapshot, error) {
+	args := m.Called(ctx, entityType, entityID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.Snapshot), args.Error(1)
+}
@@ -0,0 +1,76 @@
+package iiifserv
+
+import (
+	"context"
+	"fmt"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"github.com/google/uuid"
+)
+
+// IIIFServ строит документы IIIF Presentation 3.0 для просмотрщиков IIIF
+type IIIFServ interface {
+	GetManifest(ctx context.Context, artworkID uuid.UUID) (jsonreqresp.IIIFManifest, error)
+	// GetCollection возвращает коллекцию IIIF с манифестами всех произведений коллекции
+	GetCollection(ctx context.Context, collectionID uuid.UUID) (jsonreqresp.IIIFCollection, error)
+	// GetCollections возвращает коллекцию IIIF верхнего уровня со всеми коллекциями
+	GetCollections(ctx context.Context) (jsonreqresp.IIIFCollection, error)
+}
+
+// NewIIIFServ создает сервис; baseURL - внешний адрес сервиса без завершающего "/"
+func NewIIIFServ(artworkRep artworkrep.ArtworkRep, collectionRep collectionrep.CollectionRep, baseURL string) IIIFServ {
+	return &iiifServ{
+		artworkRep:    artworkRep,
+		collectionRep: collectionRep,
+		baseURL:       baseURL,
+	}
+}
+
+type iiifServ struct {
+	artworkRep    artworkrep.ArtworkRep
+	collectionRep collectionrep.CollectionRep
+	baseURL       string
+}
+
+func (s *iiifServ) GetManifest(ctx context.Context, artworkID uuid.UUID) (jsonreqresp.IIIFManifest, error) {
+	art, err := s.artworkRep.GetByID(ctx, artworkID)
+	if err != nil {
+		return jsonreqresp.IIIFManifest{}, fmt.Errorf("iiifServ.GetManifest: %w", err)
+	}
+	return art.ToIIIFManifest(s.baseURL), nil
+}
+
+func (s *iiifServ) GetCollection(ctx context.Context, collectionID uuid.UUID) (jsonreqresp.IIIFCollection, error) {
+	collection, err := s.collectionRep.GetCollectionByID(ctx, collectionID)
+	if err != nil {
+		return jsonreqresp.IIIFCollection{}, fmt.Errorf("iiifServ.GetCollection: %w", err)
+	}
+
+	// произведения читаются страницами в порядке id, чтобы не зависеть от размера коллекции в одном запросе
+	filter := &jsonreqresp.ArtworkFilter{CollectionIDs: uuid.UUIDs{collectionID}}
+	page := &jsonreqresp.PageRequest{Limit: jsonreqresp.MaxPageSize}
+	var artworks []*models.Artwork
+	for {
+		arts, pageInfo, err := s.artworkRep.GetArtworksPage(ctx, filter, &jsonreqresp.ArtworkSortOps{}, page)
+		if err != nil {
+			return jsonreqresp.IIIFCollection{}, fmt.Errorf("iiifServ.GetCollection: %w", err)
+		}
+		artworks = append(artworks, arts...)
+		if pageInfo.NextCursor == "" {
+			break
+		}
+		page.Cursor = pageInfo.NextCursor
+	}
+	return collection.ToIIIFCollection(s.baseURL, artworks), nil
+}
+
+func (s *iiifServ) GetCollections(ctx context.Context) (jsonreqresp.IIIFCollection, error) {
+	collections, err := s.collectionRep.GetAllCollections(ctx)
+	if err != nil {
+		return jsonreqresp.IIIFCollection{}, fmt.Errorf("iiifServ.GetCollections: %w", err)
+	}
+	return models.ToIIIFCollections(s.baseURL, collections), nil
+}
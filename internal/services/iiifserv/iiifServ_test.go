@@ -0,0 +1,164 @@
+package iiifserv_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/iiifserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const baseURL = "https://museum.example"
+
+func createTestArtwork(t *testing.T, title string, year int, collection *models.Collection) *models.Artwork {
+	author, err := models.NewAuthor(uuid.New(), "Винсент Ван Гог", 1853, 1890)
+	require.NoError(t, err)
+	art, err := models.NewArtwork(uuid.New(), title, "Масло", "Холст", "73.7 × 92.1 см", year, &author, collection)
+	require.NoError(t, err)
+	return &art
+}
+
+func TestIIIFService_GetManifest(t *testing.T) {
+	ctx := context.Background()
+	collection, err := models.NewCollection(uuid.New(), "Постимпрессионизм")
+	require.NoError(t, err)
+
+	t.Run("canvas per image", func(t *testing.T) {
+		art := createTestArtwork(t, "Звёздная ночь", 1889, &collection)
+		images := make([]*models.ArtworkImage, 2)
+		for i := range images {
+			img, err := models.NewArtworkImage(uuid.New(), art.GetID(), i, i == 1, models.ImageFormatPNG, 2000, 1500, time.Now())
+			require.NoError(t, err)
+			images[i] = &img
+		}
+		art.SetImages(images)
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetByID", ctx, art.GetID()).Return(art, nil)
+		s := iiifserv.NewIIIFServ(artMock, new(collectionrep.MockCollectionRep), baseURL)
+
+		manifest, err := s.GetManifest(ctx, art.GetID())
+		require.NoError(t, err)
+		manifestID := baseURL + "/api/v1/iiif/artworks/" + art.GetID().String() + "/manifest"
+		assert.Equal(t, jsonreqresp.IIIFContext, manifest.Context)
+		assert.Equal(t, manifestID, manifest.ID)
+		assert.Equal(t, jsonreqresp.IIIFLangMap{"ru": {"Звёздная ночь"}}, manifest.Label)
+		assert.Equal(t, "1889-01-01T00:00:00Z", manifest.NavDate)
+		require.Len(t, manifest.PartOf, 1)
+		assert.Equal(t, baseURL+"/api/v1/iiif/collections/"+collection.GetID().String(), manifest.PartOf[0].ID)
+
+		values := make(map[string][]string, len(manifest.Metadata))
+		for _, m := range manifest.Metadata {
+			values[m.Label["en"][0]] = append(m.Value["ru"], m.Value["none"]...)
+		}
+		assert.Equal(t, []string{"Винсент Ван Гог"}, values["Creator"])
+		assert.Equal(t, []string{"1889"}, values["Date"])
+		assert.Equal(t, []string{"Масло"}, values["Technique"])
+		assert.Equal(t, []string{"Холст"}, values["Material"])
+		assert.Equal(t, []string{"73.7 × 92.1 см"}, values["Dimensions"])
+		assert.Equal(t, []string{"Постимпрессионизм"}, values["Collection"])
+
+		require.Len(t, manifest.Items, 2)
+		canvas := manifest.Items[1]
+		assert.Equal(t, manifestID+"/canvas/p2", canvas.ID)
+		assert.Equal(t, 2000, canvas.Width)
+		require.Len(t, canvas.Items, 1)
+		require.Len(t, canvas.Items[0].Items, 1)
+		body := canvas.Items[0].Items[0].Body
+		assert.Equal(t, baseURL+"/images/"+images[1].OriginalKey(), body.ID)
+		assert.Equal(t, "image/png", body.Format)
+		assert.Equal(t, canvas.ID, canvas.Items[0].Items[0].Target)
+
+		require.Len(t, manifest.Thumbnail, 1)
+		assert.Equal(t, baseURL+"/images/"+images[1].ThumbnailKey(480), manifest.Thumbnail[0].ID)
+		assert.Equal(t, 360, manifest.Thumbnail[0].Height)
+
+		raw, err := json.Marshal(manifest)
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), `"@context":"http://iiif.io/api/presentation/3/context.json"`)
+	})
+
+	t.Run("artwork without images", func(t *testing.T) {
+		art := createTestArtwork(t, "Подсолнухи", 1888, &collection)
+		dimensions, err := models.ParseDimensions(art.GetSize())
+		require.NoError(t, err)
+		art.SetDimensions(dimensions)
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetByID", ctx, art.GetID()).Return(art, nil)
+		s := iiifserv.NewIIIFServ(artMock, new(collectionrep.MockCollectionRep), baseURL)
+
+		manifest, err := s.GetManifest(ctx, art.GetID())
+		require.NoError(t, err)
+		require.Len(t, manifest.Items, 1)
+		assert.Equal(t, 921, manifest.Items[0].Width)
+		assert.Equal(t, 737, manifest.Items[0].Height)
+		assert.Empty(t, manifest.Items[0].Items[0].Items)
+		assert.Empty(t, manifest.Thumbnail)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		id := uuid.New()
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetByID", ctx, id).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+		s := iiifserv.NewIIIFServ(artMock, new(collectionrep.MockCollectionRep), baseURL)
+
+		_, err := s.GetManifest(ctx, id)
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+	})
+}
+
+func TestIIIFService_GetCollection(t *testing.T) {
+	ctx := context.Background()
+	collection, err := models.NewCollection(uuid.New(), "Постимпрессионизм")
+	require.NoError(t, err)
+	first := createTestArtwork(t, "Звёздная ночь", 1889, &collection)
+	second := createTestArtwork(t, "Подсолнухи", 1888, &collection)
+
+	artMock := new(artworkrep.MockArtworkRep)
+	colMock := new(collectionrep.MockCollectionRep)
+	colMock.On("GetCollectionByID", ctx, collection.GetID()).Return(&collection, nil)
+	colMock.On("GetAllCollections", ctx).Return([]*models.Collection{&collection}, nil)
+	filter := &jsonreqresp.ArtworkFilter{CollectionIDs: uuid.UUIDs{collection.GetID()}}
+	artMock.On("GetArtworksPage", ctx, filter, mock.Anything,
+		mock.MatchedBy(func(p *jsonreqresp.PageRequest) bool { return p.Cursor == "" })).
+		Return([]*models.Artwork{first}, jsonreqresp.PageInfo{Total: 2, HasTotal: true, Limit: 1, NextCursor: "next"}, nil).Once()
+	artMock.On("GetArtworksPage", ctx, filter, mock.Anything,
+		mock.MatchedBy(func(p *jsonreqresp.PageRequest) bool { return p.Cursor == "next" })).
+		Return([]*models.Artwork{second}, jsonreqresp.PageInfo{Limit: 1}, nil).Once()
+	s := iiifserv.NewIIIFServ(artMock, colMock, baseURL)
+
+	t.Run("collection manifests", func(t *testing.T) {
+		col, err := s.GetCollection(ctx, collection.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "Collection", col.Type)
+		assert.Equal(t, jsonreqresp.IIIFLangMap{"ru": {"Постимпрессионизм"}}, col.Label)
+		require.Len(t, col.Items, 2)
+		assert.Equal(t, "Manifest", col.Items[0].Type)
+		assert.Equal(t, baseURL+"/api/v1/iiif/artworks/"+second.GetID().String()+"/manifest", col.Items[1].ID)
+		artMock.AssertExpectations(t)
+	})
+
+	t.Run("top-level collection", func(t *testing.T) {
+		col, err := s.GetCollections(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, baseURL+"/api/v1/iiif/collections", col.ID)
+		require.Len(t, col.Items, 1)
+		assert.Equal(t, "Collection", col.Items[0].Type)
+		assert.Equal(t, baseURL+"/api/v1/iiif/collections/"+collection.GetID().String(), col.Items[0].ID)
+	})
+
+	t.Run("collection not found", func(t *testing.T) {
+		id := uuid.New()
+		colMock.On("GetCollectionByID", ctx, id).Return(nil, collectionrep.ErrCollectionNotFound)
+		_, err := s.GetCollection(ctx, id)
+		assert.ErrorIs(t, err, collectionrep.ErrCollectionNotFound)
+	})
+}
@@ -0,0 +1,148 @@
+package importserv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+)
+
+// csvColumn записывает значение столбца CSV в поле строки импорта
+type csvColumn[T any] func(row *T, value string) error
+
+func textColumn[T any](field func(*T) *string) csvColumn[T] {
+	return func(row *T, value string) error {
+		*field(row) = value
+		return nil
+	}
+}
+
+func intColumn[T any](field func(*T) *int) csvColumn[T] {
+	return func(row *T, value string) error {
+		if value = strings.TrimSpace(value); value == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		*field(row) = n
+		return nil
+	}
+}
+
+// столбцы CSV называются так же, как поля JSON
+var authorCSVColumns = map[string]csvColumn[jsonreqresp.ImportAuthorRow]{
+	"id":        textColumn(func(r *jsonreqresp.ImportAuthorRow) *string { return &r.ID }),
+	"name":      textColumn(func(r *jsonreqresp.ImportAuthorRow) *string { return &r.Name }),
+	"birthyear": intColumn(func(r *jsonreqresp.ImportAuthorRow) *int { return &r.BirthYear }),
+	"deathyear": intColumn(func(r *jsonreqresp.ImportAuthorRow) *int { return &r.DeathYear }),
+}
+
+var collectionCSVColumns = map[string]csvColumn[jsonreqresp.ImportCollectionRow]{
+	"id":    textColumn(func(r *jsonreqresp.ImportCollectionRow) *string { return &r.ID }),
+	"title": textColumn(func(r *jsonreqresp.ImportCollectionRow) *string { return &r.Title }),
+}
+
+var artworkCSVColumns = map[string]csvColumn[jsonreqresp.ImportArtworkRow]{
+	"id":            textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.ID }),
+	"title":         textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.Title }),
+	"technic":       textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.Technic }),
+	"material":      textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.Material }),
+	"size":          textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.Size }),
+	"creationyear":  intColumn(func(r *jsonreqresp.ImportArtworkRow) *int { return &r.CreationYear }),
+	"yearfrom":      intColumn(func(r *jsonreqresp.ImportArtworkRow) *int { return &r.YearFrom }),
+	"yearto":        intColumn(func(r *jsonreqresp.ImportArtworkRow) *int { return &r.YearTo }),
+	"datequalifier": textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.DateQualifier }),
+	"dateprecision": textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.DatePrecision }),
+	"author":        textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.Author }),
+	"authorrole":    textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.AuthorRole }),
+	"collection":    textColumn(func(r *jsonreqresp.ImportArtworkRow) *string { return &r.Collection }),
+}
+
+// FormatByName определяет формат файла импорта по расширению имени файла
+func FormatByName(filename string) models.ImportFormat {
+	return models.ImportFormat(strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")))
+}
+
+// DecodeImport читает файл импорта. JSON - объект CatalogImportRequest со всеми разделами,
+// CSV - строки одного раздела entity с заголовком из имен полей JSON (без учета регистра).
+// Номера строк в отчете импорта совпадают с номерами строк данных CSV без заголовка
+func DecodeImport(r io.Reader, format models.ImportFormat, entity models.ImportEntity) (jsonreqresp.CatalogImportRequest, error) {
+	var req jsonreqresp.CatalogImportRequest
+	switch format {
+	case models.ImportJSON:
+		if err := json.NewDecoder(r).Decode(&req); err != nil {
+			return req, fmt.Errorf("DecodeImport: %w: %v", models.ErrImportFormat, err)
+		}
+		return req, nil
+	case models.ImportCSV:
+	default:
+		return req, fmt.Errorf("DecodeImport: %w: unknown format %q", models.ErrImportFormat, format)
+	}
+
+	var err error
+	switch entity {
+	case models.ImportAuthors:
+		req.Authors, err = decodeCSV(r, authorCSVColumns)
+	case models.ImportCollections:
+		req.Collections, err = decodeCSV(r, collectionCSVColumns)
+	case models.ImportArtworks:
+		req.Artworks, err = decodeCSV(r, artworkCSVColumns)
+	default:
+		err = fmt.Errorf("%w: unknown entity %q", models.ErrImportFormat, entity)
+	}
+	if err != nil {
+		return req, fmt.Errorf("DecodeImport: %w", err)
+	}
+	return req, nil
+}
+
+func decodeCSV[T any](r io.Reader, columns map[string]csvColumn[T]) ([]T, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrImportFormat, err)
+	}
+
+	setters := make([]csvColumn[T], len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Excel сохраняет CSV в UTF-8 с BOM
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		setter, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", models.ErrImportFormat, name)
+		}
+		setters[i] = setter
+	}
+
+	var rows []T
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrImportFormat, err)
+		}
+		var row T
+		for i, value := range record {
+			if err := setters[i](&row, value); err != nil {
+				line, _ := reader.FieldPos(i)
+				return nil, fmt.Errorf("%w: line %d, column %q: %v", models.ErrImportFormat, line, header[i], err)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,89 @@
+package importserv_test
+
+import (
+	"strings"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/importserv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeImport(t *testing.T) {
+	t.Run("csv artworks", func(t *testing.T) {
+		data := "\ufefftitle,Author,collection,technic,material,size,creationYear,yearFrom,yearTo,dateQualifier\n" +
+			"Звёздная ночь,Винсент Ван Гог,Постимпрессионизм,Масло,Холст,\"73,7 × 92,1 см\",1889,,,\n" +
+			"Едоки картофеля,Винсент Ван Гог,Постимпрессионизм,Масло,Холст,82 × 114 см,,1884,1885,circa\n"
+		req, err := importserv.DecodeImport(strings.NewReader(data), models.ImportCSV, models.ImportArtworks)
+		require.NoError(t, err)
+		assert.Empty(t, req.Authors)
+		require.Len(t, req.Artworks, 2)
+		assert.Equal(t, jsonreqresp.ImportArtworkRow{
+			Title:        "Звёздная ночь",
+			Author:       "Винсент Ван Гог",
+			Collection:   "Постимпрессионизм",
+			Technic:      "Масло",
+			Material:     "Холст",
+			Size:         "73,7 × 92,1 см",
+			CreationYear: 1889,
+		}, req.Artworks[0])
+		assert.Equal(t, 1884, req.Artworks[1].YearFrom)
+		assert.Equal(t, 1885, req.Artworks[1].YearTo)
+		assert.Equal(t, "circa", req.Artworks[1].DateQualifier)
+	})
+
+	t.Run("csv authors", func(t *testing.T) {
+		data := "name,birthYear,deathYear\nКлод Моне,1840,1926\nДэвид Хокни,1937,\n"
+		req, err := importserv.DecodeImport(strings.NewReader(data), models.ImportCSV, models.ImportAuthors)
+		require.NoError(t, err)
+		assert.Equal(t, []jsonreqresp.ImportAuthorRow{
+			{Name: "Клод Моне", BirthYear: 1840, DeathYear: 1926},
+			{Name: "Дэвид Хокни", BirthYear: 1937},
+		}, req.Authors)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data := `{"collections": [{"title": "Импрессионизм"}], "artworks": [{"title": "Впечатление", "author": "Клод Моне"}]}`
+		req, err := importserv.DecodeImport(strings.NewReader(data), models.ImportJSON, "")
+		require.NoError(t, err)
+		require.Len(t, req.Collections, 1)
+		require.Len(t, req.Artworks, 1)
+		assert.Equal(t, "Клод Моне", req.Artworks[0].Author)
+	})
+
+	errorCases := []struct {
+		name   string
+		data   string
+		format models.ImportFormat
+		entity models.ImportEntity
+	}{
+		{"unknown column", "name,born\nКлод Моне,1840\n", models.ImportCSV, models.ImportAuthors},
+		{"invalid number", "name,birthYear\nКлод Моне,1840\nЭдгар Дега,1834 г.\n", models.ImportCSV, models.ImportAuthors},
+		{"wrong field count", "id,title\n,Импрессионизм,лишнее\n", models.ImportCSV, models.ImportCollections},
+		{"csv without entity", "title\nИмпрессионизм\n", models.ImportCSV, ""},
+		{"unknown format", "title\nИмпрессионизм\n", "xlsx", models.ImportCollections},
+		{"malformed json", `{"authors": [`, models.ImportJSON, ""},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := importserv.DecodeImport(strings.NewReader(tc.data), tc.format, tc.entity)
+			assert.ErrorIs(t, err, models.ErrImportFormat)
+		})
+	}
+
+	t.Run("invalid number reports line", func(t *testing.T) {
+		data := "name,birthYear\nКлод Моне,1840\nЭдгар Дега,1834 г.\n"
+		_, err := importserv.DecodeImport(strings.NewReader(data), models.ImportCSV, models.ImportAuthors)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 3")
+		assert.Contains(t, err.Error(), "birthYear")
+	})
+}
+
+func TestFormatByName(t *testing.T) {
+	assert.Equal(t, models.ImportCSV, importserv.FormatByName("artworks.CSV"))
+	assert.Equal(t, models.ImportJSON, importserv.FormatByName("/tmp/catalog.json"))
+	assert.Equal(t, models.ImportFormat(""), importserv.FormatByName("catalog"))
+}
@@ -0,0 +1,356 @@
+package importserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"github.com/google/uuid"
+)
+
+type ImportServ interface {
+	// Import проверяет все строки импорта и возвращает отчет с ошибками по строкам.
+	// Если dryRun = false и ошибок нет, записи сохраняются одним импортом
+	Import(ctx context.Context, req jsonreqresp.CatalogImportRequest, dryRun bool) (*models.ImportReport, error)
+}
+
+func NewImportServ(
+	artworkRep artworkrep.ArtworkRep,
+	authorRep authorrep.AuthorRep,
+	collectionRep collectionrep.CollectionRep,
+) ImportServ {
+	return &importServ{
+		artworkRep:    artworkRep,
+		authorRep:     authorRep,
+		collectionRep: collectionRep,
+	}
+}
+
+type importServ struct {
+	artworkRep    artworkrep.ArtworkRep
+	authorRep     authorrep.AuthorRep
+	collectionRep collectionrep.CollectionRep
+}
+
+var errNotFound = errors.New("not found")
+
+// catalogIndex записи каталога по id и по имени без учета регистра.
+// Содержит существующие записи и записи, создаваемые импортом
+type catalogIndex[T any] struct {
+	byID   map[uuid.UUID]T
+	byName map[string][]T
+	// created - id записей, создаваемых импортом
+	created map[uuid.UUID]struct{}
+}
+
+func newCatalogIndex[T any]() *catalogIndex[T] {
+	return &catalogIndex[T]{
+		byID:    make(map[uuid.UUID]T),
+		byName:  make(map[string][]T),
+		created: make(map[uuid.UUID]struct{}),
+	}
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func (x *catalogIndex[T]) add(id uuid.UUID, name string, v T, created bool) {
+	x.byID[id] = v
+	x.byName[nameKey(name)] = append(x.byName[nameKey(name)], v)
+	if created {
+		x.created[id] = struct{}{}
+	}
+}
+
+// match ищет запись с тем же id или именем. Совпадение с записью этого же импорта - ошибка.
+// Если запись найдена по имени, явно заданный id строки становится ссылкой на нее,
+// чтобы строки произведений могли ссылаться на этот id
+func (x *catalogIndex[T]) match(id uuid.UUID, explicit bool, name string, getID func(T) uuid.UUID) (bool, error) {
+	found, ok := x.byID[id]
+	if !ok {
+		if same := x.byName[nameKey(name)]; len(same) > 0 {
+			found, ok = same[0], true
+		}
+	}
+	if !ok {
+		return false, nil
+	}
+	if _, created := x.created[getID(found)]; created {
+		return false, models.ErrImportDuplicateRow
+	}
+	if explicit {
+		x.byID[id] = found
+	}
+	return true, nil
+}
+
+// resolve находит запись по ссылке: id или имени
+func (x *catalogIndex[T]) resolve(ref string) (T, error) {
+	var zero T
+	if id, err := uuid.Parse(strings.TrimSpace(ref)); err == nil {
+		if v, ok := x.byID[id]; ok {
+			return v, nil
+		}
+		return zero, errNotFound
+	}
+	switch same := x.byName[nameKey(ref)]; len(same) {
+	case 0:
+		return zero, errNotFound
+	case 1:
+		return same[0], nil
+	default:
+		return zero, models.ErrImportAmbiguous
+	}
+}
+
+// parseRowID возвращает id строки импорта, для пустого - новый
+func parseRowID(id string) (uuid.UUID, bool, error) {
+	if strings.TrimSpace(id) == "" {
+		return uuid.New(), false, nil
+	}
+	parsed, err := uuid.Parse(strings.TrimSpace(id))
+	if err != nil {
+		return uuid.Nil, false, models.ErrImportInvalidID
+	}
+	return parsed, true, nil
+}
+
+func (s *importServ) Import(
+	ctx context.Context, req jsonreqresp.CatalogImportRequest, dryRun bool,
+) (*models.ImportReport, error) {
+	if len(req.Authors) == 0 && len(req.Collections) == 0 && len(req.Artworks) == 0 {
+		return nil, fmt.Errorf("importServ.Import: %w", models.ErrImportEmpty)
+	}
+	authors, err := s.authorRep.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("importServ.Import: %w", err)
+	}
+	collections, err := s.collectionRep.GetAllCollections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("importServ.Import: %w", err)
+	}
+	authorIndex := newCatalogIndex[*models.Author]()
+	for _, a := range authors {
+		authorIndex.add(a.GetID(), a.GetName(), a, false)
+	}
+	collectionIndex := newCatalogIndex[*models.Collection]()
+	for _, c := range collections {
+		collectionIndex.add(c.GetID(), c.GetTitle(), c, false)
+	}
+
+	report := &models.ImportReport{DryRun: dryRun}
+	batch := &models.CatalogImport{}
+	s.importAuthors(req.Authors, authorIndex, batch, report)
+	s.importCollections(req.Collections, collectionIndex, batch, report)
+	if err := s.importArtworks(ctx, req.Artworks, authorIndex, collectionIndex, batch, report); err != nil {
+		return nil, fmt.Errorf("importServ.Import: %w", err)
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+	if err := s.artworkRep.ImportCatalog(ctx, batch); err != nil {
+		return nil, fmt.Errorf("importServ.Import: %w", err)
+	}
+	report.Committed = true
+	return report, nil
+}
+
+func (s *importServ) importAuthors(
+	rows []jsonreqresp.ImportAuthorRow,
+	index *catalogIndex[*models.Author],
+	batch *models.CatalogImport,
+	report *models.ImportReport,
+) {
+	report.Authors.Total = len(rows)
+	for i, row := range rows {
+		id, explicit, err := parseRowID(row.ID)
+		if err != nil {
+			report.AddError(models.ImportAuthors, i+1, err)
+			continue
+		}
+		author, err := models.NewAuthor(id, row.Name, row.BirthYear, row.DeathYear)
+		if err != nil {
+			report.AddError(models.ImportAuthors, i+1, err)
+			continue
+		}
+		matched, err := index.match(id, explicit, author.GetName(), (*models.Author).GetID)
+		switch {
+		case err != nil:
+			report.AddError(models.ImportAuthors, i+1, err)
+		case matched:
+			report.Authors.Matched++
+		default:
+			index.add(id, author.GetName(), &author, true)
+			batch.Authors = append(batch.Authors, &author)
+			report.Authors.Created++
+		}
+	}
+}
+
+func (s *importServ) importCollections(
+	rows []jsonreqresp.ImportCollectionRow,
+	index *catalogIndex[*models.Collection],
+	batch *models.CatalogImport,
+	report *models.ImportReport,
+) {
+	report.Collections.Total = len(rows)
+	for i, row := range rows {
+		id, explicit, err := parseRowID(row.ID)
+		if err != nil {
+			report.AddError(models.ImportCollections, i+1, err)
+			continue
+		}
+		collection, err := models.NewCollection(id, row.Title)
+		if err != nil {
+			report.AddError(models.ImportCollections, i+1, err)
+			continue
+		}
+		matched, err := index.match(id, explicit, collection.GetTitle(), (*models.Collection).GetID)
+		switch {
+		case err != nil:
+			report.AddError(models.ImportCollections, i+1, err)
+		case matched:
+			report.Collections.Matched++
+		default:
+			index.add(id, collection.GetTitle(), &collection, true)
+			batch.Collections = append(batch.Collections, &collection)
+			report.Collections.Created++
+		}
+	}
+}
+
+func (s *importServ) importArtworks(
+	ctx context.Context,
+	rows []jsonreqresp.ImportArtworkRow,
+	authors *catalogIndex[*models.Author],
+	collections *catalogIndex[*models.Collection],
+	batch *models.CatalogImport,
+	report *models.ImportReport,
+) error {
+	report.Artworks.Total = len(rows)
+	existing, err := s.existingArtworkIDs(ctx, rows)
+	if err != nil {
+		return err
+	}
+	ids := make(map[uuid.UUID]struct{}, len(rows))
+	for i, row := range rows {
+		id, explicit, err := parseRowID(row.ID)
+		if err != nil {
+			report.AddError(models.ImportArtworks, i+1, err)
+			continue
+		}
+		if explicit {
+			if _, ok := ids[id]; ok {
+				report.AddError(models.ImportArtworks, i+1, models.ErrImportDuplicateRow)
+				continue
+			}
+			ids[id] = struct{}{}
+			if _, ok := existing[id]; ok {
+				report.AddError(models.ImportArtworks, i+1, models.ErrImportIDExists)
+				continue
+			}
+		}
+
+		artwork, err := newImportArtwork(id, row, authors, collections)
+		if err != nil {
+			report.AddError(models.ImportArtworks, i+1, err)
+			continue
+		}
+		batch.Artworks = append(batch.Artworks, artwork)
+		report.Artworks.Created++
+	}
+	return nil
+}
+
+// existingArtworkIDs возвращает явно заданные в строках импорта id, уже занятые произведениями,
+// включая произведения в корзине
+func (s *importServ) existingArtworkIDs(
+	ctx context.Context, rows []jsonreqresp.ImportArtworkRow,
+) (map[uuid.UUID]struct{}, error) {
+	var ids uuid.UUIDs
+	for _, row := range rows {
+		if id, explicit, err := parseRowID(row.ID); err == nil && explicit {
+			ids = append(ids, id)
+		}
+	}
+	existing := make(map[uuid.UUID]struct{})
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	found, err := s.artworkRep.GetExistingIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range found {
+		existing[id] = struct{}{}
+	}
+	return existing, nil
+}
+
+// newImportArtwork создает произведение из строки импорта
+func newImportArtwork(
+	id uuid.UUID,
+	row jsonreqresp.ImportArtworkRow,
+	authors *catalogIndex[*models.Author],
+	collections *catalogIndex[*models.Collection],
+) (*models.Artwork, error) {
+	author, err := authors.resolve(row.Author)
+	if errors.Is(err, errNotFound) {
+		return nil, models.ErrImportAuthorNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	collection, err := collections.resolve(row.Collection)
+	if errors.Is(err, errNotFound) {
+		return nil, models.ErrImportCollectionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	var datingReq *jsonreqresp.ArtworkDatingRequest
+	if row.YearFrom != 0 {
+		datingReq = &jsonreqresp.ArtworkDatingRequest{
+			YearFrom:  row.YearFrom,
+			YearTo:    row.YearTo,
+			Qualifier: row.DateQualifier,
+			Precision: row.DatePrecision,
+		}
+	}
+	dating, err := models.ResolveDating(datingReq, row.CreationYear)
+	if err != nil {
+		return nil, err
+	}
+	primary, err := models.NewAttribution(author, models.AttributionRole(row.AuthorRole))
+	if err != nil {
+		return nil, err
+	}
+	dimensions, err := models.ResolveDimensions(nil, row.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	artwork, err := models.NewDatedArtwork(
+		id,
+		row.Title,
+		row.Technic,
+		row.Material,
+		row.Size,
+		dating,
+		primary,
+		nil,
+		collection,
+	)
+	if err != nil {
+		return nil, err
+	}
+	artwork.SetDimensions(dimensions)
+	return &artwork, nil
+}
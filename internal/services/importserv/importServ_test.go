@@ -0,0 +1,243 @@
+package importserv_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/authorrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/importserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type importMocks struct {
+	artworkRep    *artworkrep.MockArtworkRep
+	authorRep     *authorrep.MockAuthorRep
+	collectionRep *collectionrep.MockCollectionRep
+}
+
+func newImportServ(t *testing.T, authors []*models.Author, collections []*models.Collection) (importserv.ImportServ, importMocks) {
+	m := importMocks{
+		artworkRep:    new(artworkrep.MockArtworkRep),
+		authorRep:     new(authorrep.MockAuthorRep),
+		collectionRep: new(collectionrep.MockCollectionRep),
+	}
+	m.authorRep.On("GetAll", mock.Anything).Return(authors, nil)
+	m.collectionRep.On("GetAllCollections", mock.Anything).Return(collections, nil)
+	t.Cleanup(func() {
+		m.authorRep.AssertExpectations(t)
+		m.collectionRep.AssertExpectations(t)
+		m.artworkRep.AssertExpectations(t)
+	})
+	return importserv.NewImportServ(m.artworkRep, m.authorRep, m.collectionRep), m
+}
+
+func createTestAuthor(t *testing.T, name string, birth, death int) *models.Author {
+	author, err := models.NewAuthor(uuid.New(), name, birth, death)
+	require.NoError(t, err)
+	return &author
+}
+
+func createTestCollection(t *testing.T, title string) *models.Collection {
+	collection, err := models.NewCollection(uuid.New(), title)
+	require.NoError(t, err)
+	return &collection
+}
+
+func artworkRow(title, author, collection string, year int) jsonreqresp.ImportArtworkRow {
+	return jsonreqresp.ImportArtworkRow{
+		Title:        title,
+		Technic:      "Масло",
+		Material:     "Холст",
+		Size:         "73.7 × 92.1 см",
+		CreationYear: year,
+		Author:       author,
+		Collection:   collection,
+	}
+}
+
+func TestImportService_Import(t *testing.T) {
+	ctx := context.Background()
+	vanGogh := createTestAuthor(t, "Винсент Ван Гог", 1853, 1890)
+	monet := createTestAuthor(t, "Клод Моне", 1840, 1926)
+	impressionism := createTestCollection(t, "Импрессионизм")
+
+	t.Run("commit with matched and created records", func(t *testing.T) {
+		s, m := newImportServ(t, []*models.Author{vanGogh, monet}, []*models.Collection{impressionism})
+		var saved *models.CatalogImport
+		m.artworkRep.On("ImportCatalog", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*models.CatalogImport) }).
+			Return(nil)
+
+		report, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{
+			Authors: []jsonreqresp.ImportAuthorRow{
+				{Name: "винсент ван гог", BirthYear: 1853, DeathYear: 1890},
+				{Name: "Поль Сезанн", BirthYear: 1839, DeathYear: 1906},
+			},
+			Collections: []jsonreqresp.ImportCollectionRow{
+				{ID: impressionism.GetID().String(), Title: "Импрессионизм"},
+				{Title: "Постимпрессионизм"},
+			},
+			Artworks: []jsonreqresp.ImportArtworkRow{
+				artworkRow("Звёздная ночь", "Винсент Ван Гог", "постимпрессионизм", 1889),
+				artworkRow("Гора Сент-Виктуар", "Поль Сезанн", impressionism.GetID().String(), 1904),
+				artworkRow("Руанский собор", monet.GetID().String(), "Импрессионизм", 1894),
+			},
+		}, false)
+		require.NoError(t, err)
+
+		assert.True(t, report.Committed)
+		assert.Empty(t, report.Errors)
+		assert.Equal(t, models.ImportCounts{Total: 2, Created: 1, Matched: 1}, report.Authors)
+		assert.Equal(t, models.ImportCounts{Total: 2, Created: 1, Matched: 1}, report.Collections)
+		assert.Equal(t, models.ImportCounts{Total: 3, Created: 3}, report.Artworks)
+
+		require.NotNil(t, saved)
+		require.Len(t, saved.Authors, 1)
+		require.Len(t, saved.Collections, 1)
+		require.Len(t, saved.Artworks, 3)
+		assert.Equal(t, vanGogh.GetID(), saved.Artworks[0].GetAuthor().GetID())
+		assert.Equal(t, saved.Collections[0].GetID(), saved.Artworks[0].GetCollection().GetID())
+		assert.Equal(t, saved.Authors[0].GetID(), saved.Artworks[1].GetAuthor().GetID())
+		assert.Equal(t, monet.GetID(), saved.Artworks[2].GetAuthor().GetID())
+		assert.False(t, saved.Artworks[0].GetDimensions().IsZero())
+	})
+
+	t.Run("dry run reports errors by row", func(t *testing.T) {
+		namesake := createTestAuthor(t, "Клод Моне", 1900, 0)
+		s, _ := newImportServ(t, []*models.Author{vanGogh, monet, namesake}, []*models.Collection{impressionism})
+
+		report, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{
+			Authors: []jsonreqresp.ImportAuthorRow{
+				{Name: "Эдгар Дега", BirthYear: 1834, DeathYear: 1917},
+				{Name: "", BirthYear: 1834},
+				{Name: "эдгар дега", BirthYear: 1834, DeathYear: 1917},
+				{ID: "not-a-uuid", Name: "Камиль Писсарро", BirthYear: 1830},
+			},
+			Artworks: []jsonreqresp.ImportArtworkRow{
+				artworkRow("Голубые танцовщицы", "Эдгар Дега", "Импрессионизм", 1897),
+				artworkRow("Неизвестное", "Неизвестный автор", "Импрессионизм", 1897),
+				artworkRow("Впечатление", "Клод Моне", "Импрессионизм", 1872),
+				artworkRow("Подсолнухи", "Винсент Ван Гог", "Нет такой", 1888),
+				artworkRow("Ирисы", "Винсент Ван Гог", "Импрессионизм", 1900),
+				artworkRow("", "Винсент Ван Гог", "Импрессионизм", 1889),
+			},
+		}, true)
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		assert.False(t, report.Committed)
+		assert.Equal(t, models.ImportCounts{Total: 4, Created: 1, Failed: 3}, report.Authors)
+		assert.Equal(t, models.ImportCounts{Total: 6, Created: 1, Failed: 5}, report.Artworks)
+
+		expected := []struct {
+			entity models.ImportEntity
+			row    int
+			err    error
+		}{
+			{models.ImportAuthors, 2, models.ErrAuthorEmptyName},
+			{models.ImportAuthors, 3, models.ErrImportDuplicateRow},
+			{models.ImportAuthors, 4, models.ErrImportInvalidID},
+			{models.ImportArtworks, 2, models.ErrImportAuthorNotFound},
+			{models.ImportArtworks, 3, models.ErrImportAmbiguous},
+			{models.ImportArtworks, 4, models.ErrImportCollectionNotFound},
+			{models.ImportArtworks, 5, models.ErrArtworkYearNotInRange},
+			{models.ImportArtworks, 6, models.ErrArtworkEmptyTitle},
+		}
+		require.Len(t, report.Errors, len(expected))
+		for i, e := range expected {
+			assert.Equal(t, e.entity, report.Errors[i].Entity)
+			assert.Equal(t, e.row, report.Errors[i].Row)
+			assert.ErrorIs(t, report.Errors[i].Err, e.err)
+		}
+	})
+
+	t.Run("errors block commit", func(t *testing.T) {
+		s, _ := newImportServ(t, []*models.Author{vanGogh}, []*models.Collection{impressionism})
+
+		report, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{
+			Artworks: []jsonreqresp.ImportArtworkRow{
+				artworkRow("Звёздная ночь", "Винсент Ван Гог", "Импрессионизм", 1889),
+				artworkRow("Подсолнухи", "Винсент Ван Гог", "Импрессионизм", 0),
+			},
+		}, false)
+		require.NoError(t, err)
+		assert.False(t, report.Committed)
+		require.Len(t, report.Errors, 1)
+		assert.Equal(t, 2, report.Errors[0].Row)
+	})
+
+	t.Run("explicit artwork ids", func(t *testing.T) {
+		s, m := newImportServ(t, []*models.Author{vanGogh}, []*models.Collection{impressionism})
+		existing, fresh := uuid.New(), uuid.New()
+		m.artworkRep.On("GetExistingIDs", ctx, uuid.UUIDs{fresh, fresh, existing}).Return(uuid.UUIDs{existing}, nil).Once()
+
+		row := artworkRow("Звёздная ночь", "Винсент Ван Гог", "Импрессионизм", 1889)
+		withID := func(id uuid.UUID) jsonreqresp.ImportArtworkRow {
+			r := row
+			r.ID = id.String()
+			return r
+		}
+		report, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{
+			Artworks: []jsonreqresp.ImportArtworkRow{withID(fresh), withID(fresh), withID(existing)},
+		}, true)
+		require.NoError(t, err)
+		require.Len(t, report.Errors, 2)
+		assert.ErrorIs(t, report.Errors[0].Err, models.ErrImportDuplicateRow)
+		assert.ErrorIs(t, report.Errors[1].Err, models.ErrImportIDExists)
+	})
+
+	t.Run("explicit id of matched record", func(t *testing.T) {
+		s, m := newImportServ(t, []*models.Author{vanGogh}, []*models.Collection{impressionism})
+		var saved *models.CatalogImport
+		m.artworkRep.On("ImportCatalog", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*models.CatalogImport) }).
+			Return(nil)
+		authorID, collectionID := uuid.New(), uuid.New()
+
+		report, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{
+			Authors:     []jsonreqresp.ImportAuthorRow{{ID: authorID.String(), Name: "Винсент Ван Гог", BirthYear: 1853, DeathYear: 1890}},
+			Collections: []jsonreqresp.ImportCollectionRow{{ID: collectionID.String(), Title: "импрессионизм"}},
+			Artworks: []jsonreqresp.ImportArtworkRow{
+				artworkRow("Звёздная ночь", authorID.String(), collectionID.String(), 1889),
+			},
+		}, false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Errors)
+		assert.Equal(t, models.ImportCounts{Total: 1, Matched: 1}, report.Authors)
+		assert.Equal(t, models.ImportCounts{Total: 1, Matched: 1}, report.Collections)
+
+		require.NotNil(t, saved)
+		require.Len(t, saved.Artworks, 1)
+		assert.Empty(t, saved.Authors)
+		assert.Equal(t, vanGogh.GetID(), saved.Artworks[0].GetAuthor().GetID())
+		assert.Equal(t, impressionism.GetID(), saved.Artworks[0].GetCollection().GetID())
+	})
+
+	t.Run("empty import", func(t *testing.T) {
+		s := importserv.NewImportServ(
+			new(artworkrep.MockArtworkRep), new(authorrep.MockAuthorRep), new(collectionrep.MockCollectionRep))
+		_, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{}, true)
+		assert.ErrorIs(t, err, models.ErrImportEmpty)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		s, m := newImportServ(t, []*models.Author{vanGogh}, []*models.Collection{impressionism})
+		repErr := errors.New("db down")
+		m.artworkRep.On("ImportCatalog", ctx, mock.Anything).Return(repErr)
+
+		_, err := s.Import(ctx, jsonreqresp.CatalogImportRequest{
+			Artworks: []jsonreqresp.ImportArtworkRow{
+				artworkRow("Звёздная ночь", "Винсент Ван Гог", "Импрессионизм", 1889),
+			},
+		}, false)
+		assert.ErrorIs(t, err, repErr)
+	})
+}
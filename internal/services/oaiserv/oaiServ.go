@@ -0,0 +1,414 @@
+package oaiserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"github.com/google/uuid"
+)
+
+// OAIPath путь точки OAI-PMH относительно адреса сервиса
+const OAIPath = "/api/v1/oai"
+
+const (
+	repositoryName   = "Каталог произведений музея"
+	protocolVersion  = "2.0"
+	oaiDCPrefix      = "oai_dc"
+	datestampLayout  = "2006-01-02T15:04:05Z"
+	dayLayout        = "2006-01-02"
+	granularity      = "YYYY-MM-DDThh:mm:ssZ"
+	deletedRecordsNo = "no"
+)
+
+// коды ошибок OAI-PMH 2.0
+const (
+	ErrCodeBadArgument             = "badArgument"
+	ErrCodeBadResumptionToken      = "badResumptionToken"
+	ErrCodeBadVerb                 = "badVerb"
+	ErrCodeCannotDisseminateFormat = "cannotDisseminateFormat"
+	ErrCodeIDDoesNotExist          = "idDoesNotExist"
+	ErrCodeNoRecordsMatch          = "noRecordsMatch"
+)
+
+// protocolError ошибка протокола, возвращается сборщику в ответе, а не как ошибка HTTP
+type protocolError struct {
+	code    string
+	message string
+}
+
+func (e *protocolError) Error() string {
+	return e.code + ": " + e.message
+}
+
+func newProtocolError(code, message string) error {
+	return &protocolError{code: code, message: message}
+}
+
+// OAIServ поставщик данных OAI-PMH 2.0 для сбора каталога агрегаторами
+type OAIServ interface {
+	// Handle выполняет запрос OAI-PMH. Ошибки протокола возвращаются в ответе,
+	// ошибка возвращается только при сбое хранилища
+	Handle(ctx context.Context, args url.Values) (*Response, error)
+}
+
+// NewOAIServ создает сервис; baseURL - внешний адрес сервиса без завершающего "/"
+func NewOAIServ(artworkRep artworkrep.ArtworkRep, collectionRep collectionrep.CollectionRep, baseURL string, adminEmail string) OAIServ {
+	namespace := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Hostname() != "" {
+		namespace = u.Hostname()
+	}
+	return &oaiServ{
+		artworkRep:    artworkRep,
+		collectionRep: collectionRep,
+		baseURL:       baseURL,
+		adminEmail:    adminEmail,
+		idPrefix:      "oai:" + namespace + ":",
+	}
+}
+
+type oaiServ struct {
+	artworkRep    artworkrep.ArtworkRep
+	collectionRep collectionrep.CollectionRep
+	baseURL       string
+	adminEmail    string
+	// idPrefix начало идентификаторов записей oai:<домен>:
+	idPrefix string
+}
+
+// verbArgs допустимые аргументы глаголов, true - обязательный;
+// resumptionToken исключает остальные аргументы
+var verbArgs = map[string]map[string]bool{
+	"Identify":            {},
+	"ListMetadataFormats": {"identifier": false},
+	"ListSets":            {"resumptionToken": false},
+	"GetRecord":           {"identifier": true, "metadataPrefix": true},
+	"ListIdentifiers":     {"metadataPrefix": true, "from": false, "until": false, "set": false, "resumptionToken": false},
+	"ListRecords":         {"metadataPrefix": true, "from": false, "until": false, "set": false, "resumptionToken": false},
+}
+
+func (s *oaiServ) Handle(ctx context.Context, args url.Values) (*Response, error) {
+	resp := &Response{
+		Xmlns:          oaiNamespace,
+		XmlnsXsi:       xsiNamespace,
+		SchemaLocation: oaiSchemaLocation,
+		ResponseDate:   time.Now().UTC().Format(datestampLayout),
+		Request:        Request{BaseURL: s.baseURL + OAIPath},
+	}
+
+	verb := args.Get("verb")
+	if _, ok := verbArgs[verb]; !ok || len(args["verb"]) > 1 {
+		resp.Errors = []Error{{Code: ErrCodeBadVerb, Message: "illegal or missing verb"}}
+		return resp, nil
+	}
+	if err := checkArgs(verb, args); err != nil {
+		resp.Errors = []Error{{Code: ErrCodeBadArgument, Message: err.Error()}}
+		return resp, nil
+	}
+	resp.Request = Request{
+		Verb:            verb,
+		Identifier:      args.Get("identifier"),
+		MetadataPrefix:  args.Get("metadataPrefix"),
+		From:            args.Get("from"),
+		Until:           args.Get("until"),
+		Set:             args.Get("set"),
+		ResumptionToken: args.Get("resumptionToken"),
+		BaseURL:         resp.Request.BaseURL,
+	}
+
+	var err error
+	switch verb {
+	case "Identify":
+		resp.Identify, err = s.identify(ctx)
+	case "ListMetadataFormats":
+		resp.ListMetadataFormats, err = s.listMetadataFormats(ctx, args.Get("identifier"))
+	case "ListSets":
+		resp.ListSets, err = s.listSets(ctx, args.Get("resumptionToken"))
+	case "GetRecord":
+		resp.GetRecord, err = s.getRecord(ctx, args.Get("identifier"), args.Get("metadataPrefix"))
+	case "ListIdentifiers":
+		var records []Record
+		var token *ResumptionToken
+		if records, token, err = s.list(ctx, args); err == nil {
+			resp.ListIdentifiers = &ListIdentifiers{ResumptionToken: token}
+			for _, r := range records {
+				resp.ListIdentifiers.Headers = append(resp.ListIdentifiers.Headers, r.Header)
+			}
+		}
+	case "ListRecords":
+		var records []Record
+		var token *ResumptionToken
+		if records, token, err = s.list(ctx, args); err == nil {
+			resp.ListRecords = &ListRecords{Records: records, ResumptionToken: token}
+		}
+	}
+
+	var protoErr *protocolError
+	if errors.As(err, &protoErr) {
+		resp.Errors = []Error{{Code: protoErr.code, Message: protoErr.message}}
+		if protoErr.code == ErrCodeBadArgument {
+			resp.Request = Request{BaseURL: resp.Request.BaseURL}
+		}
+		return resp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("oaiServ.Handle: %s: %w", verb, err)
+	}
+	return resp, nil
+}
+
+// checkArgs проверяет, что переданы только допустимые для глагола аргументы, каждый не более одного раза
+func checkArgs(verb string, args url.Values) error {
+	allowed := verbArgs[verb]
+	for name, values := range args {
+		if name == "verb" {
+			continue
+		}
+		if _, ok := allowed[name]; !ok {
+			return fmt.Errorf("illegal argument %q", name)
+		}
+		if len(values) > 1 {
+			return fmt.Errorf("repeated argument %q", name)
+		}
+	}
+	if args.Has("resumptionToken") {
+		if len(args) > 2 {
+			return errors.New("resumptionToken is an exclusive argument")
+		}
+		return nil
+	}
+	for name, required := range allowed {
+		if required && !args.Has(name) {
+			return fmt.Errorf("missing argument %q", name)
+		}
+	}
+	return nil
+}
+
+func (s *oaiServ) identify(ctx context.Context) (*Identify, error) {
+	earliest, err := s.artworkRep.GetEarliestUpdatedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if earliest.IsZero() {
+		earliest = time.Unix(0, 0)
+	}
+	identify := &Identify{
+		RepositoryName:    repositoryName,
+		BaseURL:           s.baseURL + OAIPath,
+		ProtocolVersion:   protocolVersion,
+		EarliestDatestamp: earliest.UTC().Format(datestampLayout),
+		DeletedRecord:     deletedRecordsNo,
+		Granularity:       granularity,
+	}
+	if s.adminEmail != "" {
+		identify.AdminEmails = []string{s.adminEmail}
+	}
+	return identify, nil
+}
+
+func (s *oaiServ) listMetadataFormats(ctx context.Context, identifier string) (*ListMetadataFormats, error) {
+	if identifier != "" {
+		if _, err := s.getArtwork(ctx, identifier); err != nil {
+			return nil, err
+		}
+	}
+	return &ListMetadataFormats{Formats: []MetadataFormat{{
+		MetadataPrefix:    oaiDCPrefix,
+		Schema:            oaiDCSchema,
+		MetadataNamespace: oaiDCNamespace,
+	}}}, nil
+}
+
+// listSets возвращает по набору на коллекцию; коллекций немного, поэтому список отдается целиком
+func (s *oaiServ) listSets(ctx context.Context, token string) (*ListSets, error) {
+	if token != "" {
+		return nil, newProtocolError(ErrCodeBadResumptionToken, "set list is never split")
+	}
+	collections, err := s.collectionRep.GetAllCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sets := &ListSets{Sets: make([]Set, len(collections))}
+	for i, c := range collections {
+		sets.Sets[i] = Set{SetSpec: c.GetID().String(), SetName: c.GetTitle()}
+	}
+	return sets, nil
+}
+
+func (s *oaiServ) getRecord(ctx context.Context, identifier string, prefix string) (*GetRecord, error) {
+	if prefix != oaiDCPrefix {
+		return nil, newProtocolError(ErrCodeCannotDisseminateFormat, "only oai_dc is supported")
+	}
+	art, err := s.getArtwork(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRecord{Record: s.toRecord(art)}, nil
+}
+
+func (s *oaiServ) getArtwork(ctx context.Context, identifier string) (*models.Artwork, error) {
+	id, err := uuid.Parse(strings.TrimPrefix(identifier, s.idPrefix))
+	if err != nil || !strings.HasPrefix(identifier, s.idPrefix) {
+		return nil, newProtocolError(ErrCodeIDDoesNotExist, "unknown identifier "+identifier)
+	}
+	art, err := s.artworkRep.GetByID(ctx, id)
+	if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+		return nil, newProtocolError(ErrCodeIDDoesNotExist, "unknown identifier "+identifier)
+	}
+	return art, err
+}
+
+// list возвращает часть списка ListIdentifiers/ListRecords и токен продолжения.
+// Страницы читаются в порядке id, токен хранит курсор хранилища и число уже выданных записей
+func (s *oaiServ) list(ctx context.Context, args url.Values) ([]Record, *ResumptionToken, error) {
+	token := resumptionToken{
+		MetadataPrefix: args.Get("metadataPrefix"),
+		Set:            args.Get("set"),
+		From:           args.Get("from"),
+		Until:          args.Get("until"),
+	}
+	if value := args.Get("resumptionToken"); value != "" {
+		var err error
+		if token, err = decodeResumptionToken(value); err != nil {
+			return nil, nil, newProtocolError(ErrCodeBadResumptionToken, err.Error())
+		}
+	}
+	if token.MetadataPrefix != oaiDCPrefix {
+		return nil, nil, newProtocolError(ErrCodeCannotDisseminateFormat, "only oai_dc is supported")
+	}
+	filter, err := listFilter(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &jsonreqresp.PageRequest{Limit: jsonreqresp.MaxPageSize, Cursor: token.Cursor}
+	arts, pageInfo, err := s.artworkRep.GetArtworksPage(ctx, filter, &jsonreqresp.ArtworkSortOps{}, page)
+	if errors.Is(err, jsonreqresp.ErrPageCursor) {
+		return nil, nil, newProtocolError(ErrCodeBadResumptionToken, err.Error())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(arts) == 0 && token.Offset == 0 {
+		return nil, nil, newProtocolError(ErrCodeNoRecordsMatch, "no records match the request")
+	}
+
+	records := make([]Record, len(arts))
+	for i, art := range arts {
+		records[i] = s.toRecord(art)
+	}
+
+	if pageInfo.HasTotal {
+		token.Total = pageInfo.Total
+	}
+	var resumption *ResumptionToken
+	if pageInfo.NextCursor != "" {
+		next := token
+		next.Cursor = pageInfo.NextCursor
+		next.Offset = token.Offset + len(arts)
+		resumption = &ResumptionToken{CompleteListSize: token.Total, Cursor: token.Offset, Value: next.encode()}
+	} else if token.Offset > 0 {
+		// последняя часть разбитого списка отмечается пустым токеном
+		resumption = &ResumptionToken{CompleteListSize: token.Total, Cursor: token.Offset}
+	}
+	return records, resumption, nil
+}
+
+// listFilter переводит аргументы выборочного сбора в фильтр произведений:
+// набор - коллекция, from и until включительно с точностью до дня или секунды
+func listFilter(token resumptionToken) (*jsonreqresp.ArtworkFilter, error) {
+	filter := &jsonreqresp.ArtworkFilter{}
+	if token.Set != "" {
+		collectionID, err := uuid.Parse(token.Set)
+		if err != nil {
+			return nil, newProtocolError(ErrCodeBadArgument, "unknown set "+token.Set)
+		}
+		filter.CollectionIDs = uuid.UUIDs{collectionID}
+	}
+
+	var fromDay, untilDay bool
+	var err error
+	if token.From != "" {
+		if filter.UpdatedFrom, fromDay, err = parseDatestamp(token.From); err != nil {
+			return nil, newProtocolError(ErrCodeBadArgument, "illegal from "+token.From)
+		}
+	}
+	if token.Until != "" {
+		var until time.Time
+		if until, untilDay, err = parseDatestamp(token.Until); err != nil {
+			return nil, newProtocolError(ErrCodeBadArgument, "illegal until "+token.Until)
+		}
+		if untilDay {
+			filter.UpdatedBefore = until.AddDate(0, 0, 1)
+		} else {
+			filter.UpdatedBefore = until.Add(time.Second)
+		}
+	}
+	if token.From != "" && token.Until != "" {
+		if fromDay != untilDay {
+			return nil, newProtocolError(ErrCodeBadArgument, "from and until have different granularity")
+		}
+		if !filter.UpdatedFrom.Before(filter.UpdatedBefore) {
+			return nil, newProtocolError(ErrCodeBadArgument, "from is later than until")
+		}
+	}
+	return filter, nil
+}
+
+// parseDatestamp разбирает дату UTC с точностью до дня или секунды; day - точность до дня
+func parseDatestamp(value string) (t time.Time, day bool, err error) {
+	if t, err = time.Parse(dayLayout, value); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(datestampLayout, value)
+	return t, false, err
+}
+
+func (s *oaiServ) toRecord(art *models.Artwork) Record {
+	return Record{
+		Header: Header{
+			Identifier: s.idPrefix + art.GetID().String(),
+			Datestamp:  art.GetUpdatedAt().UTC().Format(datestampLayout),
+			SetSpecs:   []string{art.GetCollection().GetID().String()},
+		},
+		Metadata: Metadata{DC: s.toDublinCore(art)},
+	}
+}
+
+func (s *oaiServ) toDublinCore(art *models.Artwork) *DublinCore {
+	dc := &DublinCore{
+		XmlnsOAIDC:     oaiDCNamespace,
+		XmlnsDC:        dcNamespace,
+		XmlnsXsi:       xsiNamespace,
+		SchemaLocation: oaiDCNamespace + " " + oaiDCSchema,
+		Titles:         []string{art.GetTitle()},
+		Dates:          []string{art.GetDating().EDTF()},
+		// тип по словарю DCMI Type
+		Types: []string{"PhysicalObject"},
+		Identifiers: []string{
+			"urn:uuid:" + art.GetID().String(),
+			models.IIIFManifestID(s.baseURL, art.GetID()),
+		},
+		Relations: []string{art.GetCollection().GetTitle()},
+	}
+	for _, a := range art.GetAttributions() {
+		dc.Creators = append(dc.Creators, a.Label())
+	}
+	for _, tag := range art.GetTags() {
+		dc.Subjects = append(dc.Subjects, tag.GetName())
+	}
+	for _, format := range []string{art.GetTechnic(), art.GetMaterial(), art.GetSize()} {
+		if format != "" {
+			dc.Formats = append(dc.Formats, format)
+		}
+	}
+	return dc
+}
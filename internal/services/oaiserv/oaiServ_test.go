@@ -0,0 +1,271 @@
+package oaiserv_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/collectionrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/oaiserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	baseURL    = "https://museum.example:8443"
+	idPrefix   = "oai:museum.example:"
+	adminEmail = "admin@museum.example"
+)
+
+var updatedAt = time.Date(2026, 3, 14, 9, 26, 53, 500, time.UTC)
+
+func createTestArtwork(t *testing.T, title string, collection *models.Collection) *models.Artwork {
+	author, err := models.NewAuthor(uuid.New(), "Винсент Ван Гог", 1853, 1890)
+	require.NoError(t, err)
+	art, err := models.NewArtwork(uuid.New(), title, "Масло", "Холст", "73.7 × 92.1 см", 1889, &author, collection)
+	require.NoError(t, err)
+	art.SetUpdatedAt(updatedAt)
+	return &art
+}
+
+func query(args ...string) url.Values {
+	values := url.Values{}
+	for i := 0; i+1 < len(args); i += 2 {
+		values.Add(args[i], args[i+1])
+	}
+	return values
+}
+
+func requireError(t *testing.T, resp *oaiserv.Response, code string) {
+	t.Helper()
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, code, resp.Errors[0].Code)
+}
+
+func TestOAIService_Identify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("identify", func(t *testing.T) {
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetEarliestUpdatedAt", ctx).Return(updatedAt, nil)
+		s := oaiserv.NewOAIServ(artMock, new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+		resp, err := s.Handle(ctx, query("verb", "Identify"))
+		require.NoError(t, err)
+		require.Empty(t, resp.Errors)
+		require.NotNil(t, resp.Identify)
+		assert.Equal(t, baseURL+"/api/v1/oai", resp.Identify.BaseURL)
+		assert.Equal(t, "2.0", resp.Identify.ProtocolVersion)
+		assert.Equal(t, []string{adminEmail}, resp.Identify.AdminEmails)
+		assert.Equal(t, "2026-03-14T09:26:53Z", resp.Identify.EarliestDatestamp)
+		assert.Equal(t, "YYYY-MM-DDThh:mm:ssZ", resp.Identify.Granularity)
+		assert.Equal(t, "Identify", resp.Request.Verb)
+	})
+
+	t.Run("empty catalog", func(t *testing.T) {
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetEarliestUpdatedAt", ctx).Return(time.Time{}, nil)
+		s := oaiserv.NewOAIServ(artMock, new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+		resp, err := s.Handle(ctx, query("verb", "Identify"))
+		require.NoError(t, err)
+		assert.Equal(t, "1970-01-01T00:00:00Z", resp.Identify.EarliestDatestamp)
+	})
+}
+
+func TestOAIService_BadRequests(t *testing.T) {
+	ctx := context.Background()
+	s := oaiserv.NewOAIServ(new(artworkrep.MockArtworkRep), new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+	tests := []struct {
+		name string
+		args url.Values
+		code string
+	}{
+		{name: "missing verb", args: query(), code: oaiserv.ErrCodeBadVerb},
+		{name: "unknown verb", args: query("verb", "ListEverything"), code: oaiserv.ErrCodeBadVerb},
+		{name: "repeated verb", args: query("verb", "Identify", "verb", "Identify"), code: oaiserv.ErrCodeBadVerb},
+		{name: "illegal argument", args: query("verb", "Identify", "set", "x"), code: oaiserv.ErrCodeBadArgument},
+		{name: "missing prefix", args: query("verb", "ListRecords"), code: oaiserv.ErrCodeBadArgument},
+		{name: "token is exclusive", args: query("verb", "ListRecords", "metadataPrefix", "oai_dc", "resumptionToken", "x"),
+			code: oaiserv.ErrCodeBadArgument},
+		{name: "illegal from", args: query("verb", "ListIdentifiers", "metadataPrefix", "oai_dc", "from", "14.03.2026"),
+			code: oaiserv.ErrCodeBadArgument},
+		{name: "different granularity", args: query("verb", "ListIdentifiers", "metadataPrefix", "oai_dc",
+			"from", "2026-03-01", "until", "2026-03-14T00:00:00Z"), code: oaiserv.ErrCodeBadArgument},
+		{name: "from later than until", args: query("verb", "ListIdentifiers", "metadataPrefix", "oai_dc",
+			"from", "2026-03-14", "until", "2026-03-01"), code: oaiserv.ErrCodeBadArgument},
+		{name: "unknown format", args: query("verb", "ListRecords", "metadataPrefix", "lido"),
+			code: oaiserv.ErrCodeCannotDisseminateFormat},
+		{name: "bad token", args: query("verb", "ListRecords", "resumptionToken", "%%%"),
+			code: oaiserv.ErrCodeBadResumptionToken},
+		{name: "foreign identifier", args: query("verb", "GetRecord", "metadataPrefix", "oai_dc",
+			"identifier", "oai:other.example:"+uuid.NewString()), code: oaiserv.ErrCodeIDDoesNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Handle(ctx, tt.args)
+			require.NoError(t, err)
+			requireError(t, resp, tt.code)
+			if tt.code == oaiserv.ErrCodeBadVerb || tt.code == oaiserv.ErrCodeBadArgument {
+				assert.Equal(t, oaiserv.Request{BaseURL: baseURL + "/api/v1/oai"}, resp.Request)
+			}
+		})
+	}
+}
+
+func TestOAIService_GetRecord(t *testing.T) {
+	ctx := context.Background()
+	collection, err := models.NewCollection(uuid.New(), "Постимпрессионизм")
+	require.NoError(t, err)
+	art := createTestArtwork(t, "Звёздная ночь", &collection)
+	missing := uuid.New()
+
+	artMock := new(artworkrep.MockArtworkRep)
+	artMock.On("GetByID", ctx, art.GetID()).Return(art, nil)
+	artMock.On("GetByID", ctx, missing).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+	s := oaiserv.NewOAIServ(artMock, new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+	t.Run("oai_dc record", func(t *testing.T) {
+		resp, err := s.Handle(ctx, query("verb", "GetRecord", "metadataPrefix", "oai_dc",
+			"identifier", idPrefix+art.GetID().String()))
+		require.NoError(t, err)
+		require.Empty(t, resp.Errors)
+		record := resp.GetRecord.Record
+		assert.Equal(t, idPrefix+art.GetID().String(), record.Header.Identifier)
+		assert.Equal(t, "2026-03-14T09:26:53Z", record.Header.Datestamp)
+		assert.Equal(t, []string{collection.GetID().String()}, record.Header.SetSpecs)
+
+		dc := record.Metadata.DC
+		assert.Equal(t, []string{"Звёздная ночь"}, dc.Titles)
+		assert.Equal(t, []string{"Винсент Ван Гог"}, dc.Creators)
+		assert.Equal(t, []string{"1889"}, dc.Dates)
+		assert.Equal(t, []string{"Масло", "Холст", "73.7 × 92.1 см"}, dc.Formats)
+		assert.Contains(t, dc.Identifiers, baseURL+"/api/v1/iiif/artworks/"+art.GetID().String()+"/manifest")
+		assert.Equal(t, []string{"Постимпрессионизм"}, dc.Relations)
+
+		raw, err := xml.Marshal(resp)
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), `<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/"`)
+		assert.Contains(t, string(raw), `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"`)
+		assert.Contains(t, string(raw), `<dc:title>Звёздная ночь</dc:title>`)
+	})
+
+	t.Run("metadata formats of record", func(t *testing.T) {
+		resp, err := s.Handle(ctx, query("verb", "ListMetadataFormats", "identifier", idPrefix+art.GetID().String()))
+		require.NoError(t, err)
+		require.Empty(t, resp.Errors)
+		require.Len(t, resp.ListMetadataFormats.Formats, 1)
+		assert.Equal(t, "oai_dc", resp.ListMetadataFormats.Formats[0].MetadataPrefix)
+	})
+
+	t.Run("unknown record", func(t *testing.T) {
+		resp, err := s.Handle(ctx, query("verb", "GetRecord", "metadataPrefix", "oai_dc",
+			"identifier", idPrefix+missing.String()))
+		require.NoError(t, err)
+		requireError(t, resp, oaiserv.ErrCodeIDDoesNotExist)
+	})
+}
+
+func TestOAIService_ListSets(t *testing.T) {
+	ctx := context.Background()
+	first, err := models.NewCollection(uuid.New(), "Постимпрессионизм")
+	require.NoError(t, err)
+	second, err := models.NewCollection(uuid.New(), "Авангард")
+	require.NoError(t, err)
+	colMock := new(collectionrep.MockCollectionRep)
+	colMock.On("GetAllCollections", ctx).Return([]*models.Collection{&first, &second}, nil)
+	s := oaiserv.NewOAIServ(new(artworkrep.MockArtworkRep), colMock, baseURL, adminEmail)
+
+	resp, err := s.Handle(ctx, query("verb", "ListSets"))
+	require.NoError(t, err)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, []oaiserv.Set{
+		{SetSpec: first.GetID().String(), SetName: "Постимпрессионизм"},
+		{SetSpec: second.GetID().String(), SetName: "Авангард"},
+	}, resp.ListSets.Sets)
+}
+
+func TestOAIService_ListRecords(t *testing.T) {
+	ctx := context.Background()
+	collection, err := models.NewCollection(uuid.New(), "Постимпрессионизм")
+	require.NoError(t, err)
+	first := createTestArtwork(t, "Звёздная ночь", &collection)
+	second := createTestArtwork(t, "Подсолнухи", &collection)
+
+	filter := &jsonreqresp.ArtworkFilter{
+		CollectionIDs: uuid.UUIDs{collection.GetID()},
+		UpdatedFrom:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedBefore: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+	artMock := new(artworkrep.MockArtworkRep)
+	artMock.On("GetArtworksPage", ctx, filter, mock.Anything,
+		mock.MatchedBy(func(p *jsonreqresp.PageRequest) bool { return p.Cursor == "" })).
+		Return([]*models.Artwork{first}, jsonreqresp.PageInfo{Total: 2, HasTotal: true, Limit: 1, NextCursor: "next"}, nil).Once()
+	artMock.On("GetArtworksPage", ctx, filter, mock.Anything,
+		mock.MatchedBy(func(p *jsonreqresp.PageRequest) bool { return p.Cursor == "next" })).
+		Return([]*models.Artwork{second}, jsonreqresp.PageInfo{Limit: 1}, nil).Once()
+	s := oaiserv.NewOAIServ(artMock, new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+	t.Run("selective harvest with resumption", func(t *testing.T) {
+		resp, err := s.Handle(ctx, query("verb", "ListRecords", "metadataPrefix", "oai_dc",
+			"set", collection.GetID().String(), "from", "2026-03-01", "until", "2026-03-14"))
+		require.NoError(t, err)
+		require.Empty(t, resp.Errors)
+		require.Len(t, resp.ListRecords.Records, 1)
+		assert.Equal(t, idPrefix+first.GetID().String(), resp.ListRecords.Records[0].Header.Identifier)
+		token := resp.ListRecords.ResumptionToken
+		require.NotNil(t, token)
+		require.NotEmpty(t, token.Value)
+		assert.Equal(t, 2, token.CompleteListSize)
+		assert.Equal(t, 0, token.Cursor)
+
+		resp, err = s.Handle(ctx, query("verb", "ListRecords", "resumptionToken", token.Value))
+		require.NoError(t, err)
+		require.Empty(t, resp.Errors)
+		require.Len(t, resp.ListRecords.Records, 1)
+		assert.Equal(t, idPrefix+second.GetID().String(), resp.ListRecords.Records[0].Header.Identifier)
+		last := resp.ListRecords.ResumptionToken
+		require.NotNil(t, last)
+		assert.Empty(t, last.Value)
+		assert.Equal(t, 1, last.Cursor)
+		assert.Equal(t, 2, last.CompleteListSize)
+		artMock.AssertExpectations(t)
+	})
+
+	t.Run("no records match", func(t *testing.T) {
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetArtworksPage", ctx, &jsonreqresp.ArtworkFilter{}, mock.Anything, mock.Anything).
+			Return([]*models.Artwork{}, jsonreqresp.PageInfo{}, nil)
+		s := oaiserv.NewOAIServ(artMock, new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+		resp, err := s.Handle(ctx, query("verb", "ListIdentifiers", "metadataPrefix", "oai_dc"))
+		require.NoError(t, err)
+		requireError(t, resp, oaiserv.ErrCodeNoRecordsMatch)
+	})
+
+	t.Run("identifiers at second granularity", func(t *testing.T) {
+		filter := &jsonreqresp.ArtworkFilter{
+			UpdatedFrom:   time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC),
+			UpdatedBefore: time.Date(2026, 3, 14, 10, 0, 1, 0, time.UTC),
+		}
+		artMock := new(artworkrep.MockArtworkRep)
+		artMock.On("GetArtworksPage", ctx, filter, mock.Anything, mock.Anything).
+			Return([]*models.Artwork{first}, jsonreqresp.PageInfo{Total: 1, HasTotal: true}, nil)
+		s := oaiserv.NewOAIServ(artMock, new(collectionrep.MockCollectionRep), baseURL, adminEmail)
+
+		resp, err := s.Handle(ctx, query("verb", "ListIdentifiers", "metadataPrefix", "oai_dc",
+			"from", "2026-03-14T09:00:00Z", "until", "2026-03-14T10:00:00Z"))
+		require.NoError(t, err)
+		require.Empty(t, resp.Errors)
+		require.Len(t, resp.ListIdentifiers.Headers, 1)
+		assert.Nil(t, resp.ListIdentifiers.ResumptionToken)
+	})
+}
@@ -0,0 +1,39 @@
+package oaiserv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var errResumptionToken = errors.New("invalid resumption token")
+
+// resumptionToken хранит аргументы исходного запроса списка и курсор следующей страницы,
+// поэтому продолжение не требует состояния на сервере
+type resumptionToken struct {
+	MetadataPrefix string `json:"p"`
+	Set            string `json:"s,omitempty"`
+	From           string `json:"f,omitempty"`
+	Until          string `json:"u,omitempty"`
+	Cursor         string `json:"c"`
+	Offset         int    `json:"o"`
+	// Total - размер полного списка, посчитанный на первой странице
+	Total int `json:"n"`
+}
+
+func (t resumptionToken) encode() string {
+	raw, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeResumptionToken(value string) (resumptionToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return resumptionToken{}, errResumptionToken
+	}
+	var t resumptionToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.Cursor == "" || t.Offset <= 0 {
+		return resumptionToken{}, errResumptionToken
+	}
+	return t, nil
+}
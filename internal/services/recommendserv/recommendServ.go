@@ -0,0 +1,119 @@
+package recommendserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"github.com/google/uuid"
+)
+
+type RecommendServ interface {
+	// GetSimilarArtworks возвращает произведения, похожие на исходное, с причинами рекомендации.
+	// Для авторизованного посетителя учитывается его избранное, произведения из избранного не рекомендуются
+	GetSimilarArtworks(ctx context.Context, artworkID uuid.UUID, limit int) ([]*models.Recommendation, error)
+}
+
+func NewRecommendServ(artworkRep artworkrep.ArtworkRep, userListRep userlistrep.UserListRep, authZ auth.AuthZ) RecommendServ {
+	return &recommendServ{
+		artworkRep:  artworkRep,
+		userListRep: userListRep,
+		authZ:       authZ,
+		weights:     models.DefaultSimilarityWeights,
+	}
+}
+
+type recommendServ struct {
+	artworkRep  artworkrep.ArtworkRep
+	userListRep userlistrep.UserListRep
+	authZ       auth.AuthZ
+	weights     models.SimilarityWeights
+}
+
+// userTaste собирает авторов и коллекции последних произведений из избранного посетителя,
+// nil - посетитель не авторизован
+func (s *recommendServ) userTaste(ctx context.Context) (*models.UserTaste, error) {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	favourites, err := s.userListRep.GetFavourites(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	taste := models.NewUserTaste()
+	cnt := 0
+	for _, f := range favourites {
+		if f.GetEntityType() != models.EntityArtwork {
+			continue
+		}
+		if cnt == models.SimilarMaxUserFavourites {
+			break
+		}
+		cnt++
+		art, err := s.artworkRep.GetByID(ctx, f.GetEntityID())
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		taste.AddArtwork(art)
+	}
+	return taste, nil
+}
+
+func (s *recommendServ) GetSimilarArtworks(ctx context.Context, artworkID uuid.UUID, limit int) ([]*models.Recommendation, error) {
+	if limit <= 0 {
+		limit = models.SimilarDefaultLimit
+	} else if limit > models.SimilarMaxLimit {
+		limit = models.SimilarMaxLimit
+	}
+	if _, err := s.artworkRep.GetByID(ctx, artworkID); err != nil {
+		return nil, fmt.Errorf("recommendServ.GetSimilarArtworks: %w", err)
+	}
+	candidates, err := s.artworkRep.GetSimilarCandidates(ctx, artworkID, limit*models.SimilarCandidateFactor)
+	if err != nil {
+		return nil, fmt.Errorf("recommendServ.GetSimilarArtworks: %w", err)
+	}
+	taste, err := s.userTaste(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recommendServ.GetSimilarArtworks: %w", err)
+	}
+
+	type scored struct {
+		candidate *models.SimilarityCandidate
+		reasons   []models.RecommendationReason
+		score     float64
+	}
+	ranked := make([]scored, 0, len(candidates))
+	for _, c := range candidates {
+		if taste != nil && taste.Contains(c.ArtworkID) {
+			continue
+		}
+		reasons := c.Reasons(s.weights, taste)
+		ranked = append(ranked, scored{candidate: c, reasons: reasons, score: models.Score(reasons)})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	res := make([]*models.Recommendation, 0, limit)
+	for _, r := range ranked {
+		if len(res) == limit {
+			break
+		}
+		art, err := s.artworkRep.GetByID(ctx, r.candidate.ArtworkID)
+		if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("recommendServ.GetSimilarArtworks: %w", err)
+		}
+		res = append(res, &models.Recommendation{Artwork: art, Score: r.score, Reasons: r.reasons})
+	}
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package recommendserv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/recommendserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArtwork(t *testing.T, title string, author *models.Author, collection *models.Collection) *models.Artwork {
+	artwork, err := models.NewArtwork(uuid.New(), title, "Масло", "Холст", "100x100 cm", 1850, author, collection)
+	require.NoError(t, err)
+	return &artwork
+}
+
+type testCatalog struct {
+	source, sameAuthor, sameTechnic, liked *models.Artwork
+	candidates                             []*models.SimilarityCandidate
+}
+
+// newTestCatalog возвращает исходное произведение и кандидатов: того же автора, той же техники
+// и произведение из коллекции, которая есть в избранном посетителя
+func newTestCatalog(t *testing.T) testCatalog {
+	aivazovsky, err := models.NewAuthor(uuid.New(), "Иван Айвазовский", 1817, 1900)
+	require.NoError(t, err)
+	shishkin, err := models.NewAuthor(uuid.New(), "Иван Шишкин", 1832, 1898)
+	require.NoError(t, err)
+	marine, err := models.NewCollection(uuid.New(), "Марина")
+	require.NoError(t, err)
+	forest, err := models.NewCollection(uuid.New(), "Лес")
+	require.NoError(t, err)
+
+	c := testCatalog{
+		source:      createTestArtwork(t, "Девятый вал", &aivazovsky, &marine),
+		sameAuthor:  createTestArtwork(t, "Радуга", &aivazovsky, &marine),
+		sameTechnic: createTestArtwork(t, "Рожь", &shishkin, &forest),
+		liked:       createTestArtwork(t, "Утро в сосновом лесу", &shishkin, &forest),
+	}
+	c.candidates = []*models.SimilarityCandidate{
+		{
+			ArtworkID: c.sameAuthor.GetID(), AuthorID: aivazovsky.GetID(), CollectionID: marine.GetID(),
+			SameAuthor: true, SameCollection: true, SameTechnic: true, YearDiff: 23,
+		},
+		{
+			ArtworkID: c.sameTechnic.GetID(), AuthorID: shishkin.GetID(), CollectionID: forest.GetID(),
+			SameTechnic: true, YearDiff: 80, CoFavourites: 2,
+		},
+		{
+			ArtworkID: c.liked.GetID(), AuthorID: shishkin.GetID(), CollectionID: forest.GetID(),
+			SameTechnic: true, YearDiff: 39,
+		},
+	}
+	return c
+}
+
+func setup(ctx context.Context, c testCatalog) (*artworkrep.MockArtworkRep, *userlistrep.MockUserListRep, *auth.MockAuthZ) {
+	artworkRep := &artworkrep.MockArtworkRep{}
+	for _, art := range []*models.Artwork{c.source, c.sameAuthor, c.sameTechnic, c.liked} {
+		artworkRep.On("GetByID", ctx, art.GetID()).Return(art, nil).Maybe()
+	}
+	return artworkRep, &userlistrep.MockUserListRep{}, &auth.MockAuthZ{}
+}
+
+func reasonKinds(r *models.Recommendation) []models.SimilarityReason {
+	kinds := make([]models.SimilarityReason, len(r.Reasons))
+	for i, reason := range r.Reasons {
+		kinds[i] = reason.Kind
+	}
+	return kinds
+}
+
+func TestRecommendServ_GetSimilarArtworks(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCatalog(t)
+
+	t.Run("anonymous visitor", func(t *testing.T) {
+		artworkRep, userListRep, authZ := setup(ctx, c)
+		authZ.On("UserIDFromContext", ctx).Return(uuid.Nil, auth.ErrNotAuthZ)
+		artworkRep.On("GetSimilarCandidates", ctx, c.source.GetID(), 2*models.SimilarCandidateFactor).Return(c.candidates, nil)
+
+		service := recommendserv.NewRecommendServ(artworkRep, userListRep, authZ)
+		res, err := service.GetSimilarArtworks(ctx, c.source.GetID(), 2)
+		require.NoError(t, err)
+
+		require.Len(t, res, 2)
+		assert.Equal(t, c.sameAuthor.GetID(), res[0].Artwork.GetID())
+		assert.Equal(t, []models.SimilarityReason{
+			models.ReasonSameAuthor, models.ReasonSameCollection, models.ReasonSameTechnic, models.ReasonClosePeriod,
+		}, reasonKinds(res[0]))
+		assert.InDelta(t, 5+2+1.5+2*27.0/50, res[0].Score, 1e-9)
+		// совместное избранное других посетителей поднимает произведение выше более близкого по времени
+		assert.Equal(t, c.sameTechnic.GetID(), res[1].Artwork.GetID())
+		assert.Equal(t, []models.SimilarityReason{models.ReasonCoFavourites, models.ReasonSameTechnic}, reasonKinds(res[1]))
+		userListRep.AssertNotCalled(t, "GetFavourites")
+	})
+
+	t.Run("visitor favourites", func(t *testing.T) {
+		artworkRep, userListRep, authZ := setup(ctx, c)
+		userID := uuid.New()
+		authZ.On("UserIDFromContext", ctx).Return(userID, nil)
+		favourite, err := models.NewFavourite(userID, models.EntityArtwork, c.liked.GetID(), time.Now())
+		require.NoError(t, err)
+		userListRep.On("GetFavourites", ctx, userID).Return([]*models.Favourite{&favourite}, nil)
+		artworkRep.On("GetSimilarCandidates", ctx, c.source.GetID(), models.SimilarDefaultLimit*models.SimilarCandidateFactor).
+			Return(c.candidates, nil)
+
+		service := recommendserv.NewRecommendServ(artworkRep, userListRep, authZ)
+		res, err := service.GetSimilarArtworks(ctx, c.source.GetID(), 0)
+		require.NoError(t, err)
+
+		// произведение из избранного не рекомендуется, а произведение того же автора получает причину избранного
+		require.Len(t, res, 2)
+		assert.Equal(t, c.sameTechnic.GetID(), res[1].Artwork.GetID())
+		assert.Contains(t, reasonKinds(res[1]), models.ReasonYourFavourites)
+		assert.NotContains(t, reasonKinds(res[0]), models.ReasonYourFavourites)
+	})
+
+	t.Run("unknown artwork", func(t *testing.T) {
+		artworkRep, userListRep, authZ := setup(ctx, c)
+		id := uuid.New()
+		artworkRep.On("GetByID", ctx, id).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+
+		service := recommendserv.NewRecommendServ(artworkRep, userListRep, authZ)
+		_, err := service.GetSimilarArtworks(ctx, id, 3)
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+		artworkRep.AssertNotCalled(t, "GetSimilarCandidates")
+	})
+}
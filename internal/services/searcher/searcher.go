@@ -12,11 +12,29 @@ import (
 )
 
 type Searcher interface {
-	GetAllArtworks(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps) ([]*models.Artwork, error)
-	GetAllEvents(ctx context.Context, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	GetAllArtworks(
+		ctx context.Context,
+		filterOps *jsonreqresp.ArtworkFilter,
+		sortOps *jsonreqresp.ArtworkSortOps,
+		page *jsonreqresp.PageRequest,
+	) ([]*models.Artwork, jsonreqresp.PageInfo, error)
+	// GetArtworkFacets возвращает значения фасетов с количеством произведений для фильтра GetAllArtworks
+	GetArtworkFacets(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter) (*models.ArtworkFacets, error)
+	GetAllEvents(
+		ctx context.Context,
+		filterOps *jsonreqresp.EventFilter,
+		sortOps *jsonreqresp.EventSortOps,
+		page *jsonreqresp.PageRequest,
+	) ([]*models.Event, jsonreqresp.PageInfo, error)
 	GetEvent(ctx context.Context, eventID uuid.UUID) (*models.Event, error)
 	GetArtworksFromEvent(ctx context.Context, eventID uuid.UUID) ([]*models.Artwork, error)
 	GetCollectionsStat(ctx context.Context, eventID uuid.UUID) ([]*models.StatCollections, error)
+	GetArtwork(ctx context.Context, artworkID uuid.UUID) (*models.Artwork, error)
+	// GetArtworkProvenance возвращает опубликованные записи истории владения произведением
+	GetArtworkProvenance(ctx context.Context, artworkID uuid.UUID) ([]*models.ProvenanceEntry, error)
+	GetArtworkEvents(ctx context.Context, artworkID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	GetAuthorEvents(ctx context.Context, authorID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
+	GetCollectionEvents(ctx context.Context, collectionID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error)
 }
 
 type searcher struct {
@@ -31,22 +49,77 @@ func NewSearcher(artRep artworkrep.ArtworkRep, eventRep eventrep.EventRep) Searc
 	}
 }
 
-func (s *searcher) GetAllArtworks(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter, sortOps *jsonreqresp.ArtworkSortOps) ([]*models.Artwork, error) {
-	return s.artworkRep.GetAllArtworks(ctx, filterOps, sortOps)
+func (s *searcher) GetAllArtworks(
+	ctx context.Context,
+	filterOps *jsonreqresp.ArtworkFilter,
+	sortOps *jsonreqresp.ArtworkSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Artwork, jsonreqresp.PageInfo, error) {
+	if page.Limit < 0 {
+		return nil, jsonreqresp.PageInfo{}, fmt.Errorf("searcher.GetAllArtworks: %w", jsonreqresp.ErrPageLimit)
+	}
+	if err := filterOps.Check(); err != nil {
+		return nil, jsonreqresp.PageInfo{}, fmt.Errorf("searcher.GetAllArtworks: %w", err)
+	}
+	// результаты поиска по умолчанию упорядочены по релевантности
+	if filterOps.Query != "" && sortOps.Field == "" {
+		sortOps.Field = jsonreqresp.RelevanceSortFieldArtwork
+		sortOps.Direction = jsonreqresp.DESCDirection
+	}
+	return s.artworkRep.GetArtworksPage(ctx, filterOps, sortOps, page)
+}
+
+func (s *searcher) GetArtworkFacets(ctx context.Context, filterOps *jsonreqresp.ArtworkFilter) (*models.ArtworkFacets, error) {
+	if err := filterOps.Check(); err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkFacets: %w", err)
+	}
+	facets, err := s.artworkRep.GetArtworkFacets(ctx, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkFacets: %w", err)
+	}
+	return facets, nil
 }
 
-func (s *searcher) GetAllEvents(ctx context.Context, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+func (s *searcher) GetAllEvents(
+	ctx context.Context,
+	filterOps *jsonreqresp.EventFilter,
+	sortOps *jsonreqresp.EventSortOps,
+	page *jsonreqresp.PageRequest,
+) ([]*models.Event, jsonreqresp.PageInfo, error) {
 	if filterOps.DateBegin.After(filterOps.DateEnd) {
-		return nil, fmt.Errorf("searcher.GetAllEvents : %w", jsonreqresp.ErrEventFilterDate)
+		return nil, jsonreqresp.PageInfo{}, fmt.Errorf("searcher.GetAllEvents : %w", jsonreqresp.ErrEventFilterDate)
+	}
+	if page.Limit < 0 {
+		return nil, jsonreqresp.PageInfo{}, fmt.Errorf("searcher.GetAllEvents : %w", jsonreqresp.ErrPageLimit)
 	}
-	return s.eventRep.GetAll(ctx, filterOps)
+	filterOps.Status = string(models.EventStatusApproved)
+	return s.eventRep.GetPage(ctx, filterOps, sortOps, page)
 }
 
+// GetEvent возвращает только одобренные мероприятия, остальные для посетителей не существуют
 func (s *searcher) GetEvent(ctx context.Context, eventID uuid.UUID) (*models.Event, error) {
-	return s.eventRep.GetByID(ctx, eventID)
+	event, err := s.getApprovedEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("searcher.GetEvent: %w", err)
+	}
+	return event, nil
+}
+
+func (s *searcher) getApprovedEvent(ctx context.Context, eventID uuid.UUID) (*models.Event, error) {
+	event, err := s.eventRep.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsApproved() {
+		return nil, eventrep.ErrEventNotFound
+	}
+	return event, nil
 }
 
 func (s *searcher) GetArtworksFromEvent(ctx context.Context, eventID uuid.UUID) ([]*models.Artwork, error) {
+	if _, err := s.getApprovedEvent(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkFromEvent: %w", err)
+	}
 	artworkIDs, err := s.eventRep.GetArtworkIDs(ctx, eventID)
 	if err != nil {
 		return nil, fmt.Errorf("searcher.GetArtworkFromEvent: %w", err)
@@ -63,8 +136,7 @@ func (s *searcher) GetArtworksFromEvent(ctx context.Context, eventID uuid.UUID)
 }
 
 func (s *searcher) GetCollectionsStat(ctx context.Context, eventID uuid.UUID) ([]*models.StatCollections, error) {
-	_, err := s.eventRep.GetByID(ctx, eventID)
-	if err != nil {
+	if _, err := s.getApprovedEvent(ctx, eventID); err != nil {
 		return nil, fmt.Errorf("searcher.GetCollectionsStat: %w", err)
 	}
 	statCols, err := s.eventRep.GetCollectionsStat(ctx, eventID)
@@ -73,3 +145,70 @@ func (s *searcher) GetCollectionsStat(ctx context.Context, eventID uuid.UUID) ([
 	}
 	return statCols, nil
 }
+
+func (s *searcher) GetArtwork(ctx context.Context, artworkID uuid.UUID) (*models.Artwork, error) {
+	return s.artworkRep.GetByID(ctx, artworkID)
+}
+
+func (s *searcher) GetArtworkProvenance(ctx context.Context, artworkID uuid.UUID) ([]*models.ProvenanceEntry, error) {
+	if _, err := s.artworkRep.GetByID(ctx, artworkID); err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkProvenance: %w", err)
+	}
+	entries, err := s.artworkRep.GetProvenance(ctx, artworkID)
+	if err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkProvenance: %w", err)
+	}
+	public := make([]*models.ProvenanceEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.IsPublic() {
+			public = append(public, e)
+		}
+	}
+	return public, nil
+}
+
+// checkPublicFilter проверяет диапазон дат, если заданы обе границы, и ограничивает выборку одобренными мероприятиями
+func checkPublicFilter(filterOps *jsonreqresp.EventFilter) error {
+	if !filterOps.DateBegin.IsZero() && !filterOps.DateEnd.IsZero() &&
+		filterOps.DateBegin.After(filterOps.DateEnd) {
+		return jsonreqresp.ErrEventFilterDate
+	}
+	filterOps.Status = string(models.EventStatusApproved)
+	return nil
+}
+
+func (s *searcher) GetArtworkEvents(ctx context.Context, artworkID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	if err := checkPublicFilter(filterOps); err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkEvents: %w", err)
+	}
+	if _, err := s.artworkRep.GetByID(ctx, artworkID); err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkEvents: %w", err)
+	}
+	events, err := s.eventRep.GetEventsByArtwork(ctx, artworkID, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("searcher.GetArtworkEvents: %w", err)
+	}
+	return events, nil
+}
+
+func (s *searcher) GetAuthorEvents(ctx context.Context, authorID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	if err := checkPublicFilter(filterOps); err != nil {
+		return nil, fmt.Errorf("searcher.GetAuthorEvents: %w", err)
+	}
+	events, err := s.eventRep.GetEventsByAuthor(ctx, authorID, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("searcher.GetAuthorEvents: %w", err)
+	}
+	return events, nil
+}
+
+func (s *searcher) GetCollectionEvents(ctx context.Context, collectionID uuid.UUID, filterOps *jsonreqresp.EventFilter) ([]*models.Event, error) {
+	if err := checkPublicFilter(filterOps); err != nil {
+		return nil, fmt.Errorf("searcher.GetCollectionEvents: %w", err)
+	}
+	events, err := s.eventRep.GetEventsByCollection(ctx, collectionID, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("searcher.GetCollectionEvents: %w", err)
+	}
+	return events, nil
+}
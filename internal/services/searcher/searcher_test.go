@@ -2,6 +2,7 @@ package searcher_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/searcher"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -61,6 +63,7 @@ func TestSearcher_GetAllArtworks(t *testing.T) {
 	ctx := context.Background()
 	filter := &jsonreqresp.ArtworkFilter{}
 	sort := &jsonreqresp.ArtworkSortOps{}
+	page := &jsonreqresp.PageRequest{Limit: 10}
 
 	tests := []struct {
 		name           string
@@ -92,9 +95,10 @@ func TestSearcher_GetAllArtworks(t *testing.T) {
 			mockEvent := &eventrep.MockEventRep{}
 			service := searcher.NewSearcher(mockArt, mockEvent)
 
-			mockArt.On("GetAllArtworks", ctx, filter, sort).Return(tt.mockArtworks, tt.mockError)
+			pageInfo := jsonreqresp.PageInfo{Total: tt.expectedLength, Limit: page.Limit}
+			mockArt.On("GetArtworksPage", ctx, filter, sort, page).Return(tt.mockArtworks, pageInfo, tt.mockError)
 
-			result, err := service.GetAllArtworks(ctx, filter, sort)
+			result, info, err := service.GetAllArtworks(ctx, filter, sort, page)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
@@ -102,6 +106,7 @@ func TestSearcher_GetAllArtworks(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.Equal(t, tt.expectedLength, len(result))
+				assert.Equal(t, tt.expectedLength, info.Total)
 			}
 
 			mockArt.AssertExpectations(t)
@@ -109,12 +114,180 @@ func TestSearcher_GetAllArtworks(t *testing.T) {
 	}
 }
 
+func TestSearcher_GetAllArtworksFullText(t *testing.T) {
+	ctx := context.Background()
+	page := &jsonreqresp.PageRequest{Limit: 10}
+
+	tests := []struct {
+		name          string
+		filter        jsonreqresp.ArtworkFilter
+		sort          jsonreqresp.ArtworkSortOps
+		expectedQuery string
+		expectedSort  jsonreqresp.ArtworkSortOps
+		expectedError error
+	}{
+		{
+			name:          "relevance by default",
+			filter:        jsonreqresp.ArtworkFilter{Query: "  портрет маслом "},
+			expectedQuery: "портрет маслом",
+			expectedSort: jsonreqresp.ArtworkSortOps{
+				Field:     jsonreqresp.RelevanceSortFieldArtwork,
+				Direction: jsonreqresp.DESCDirection,
+			},
+		},
+		{
+			name:          "explicit sort kept",
+			filter:        jsonreqresp.ArtworkFilter{Query: "Ван Гог"},
+			sort:          jsonreqresp.ArtworkSortOps{Field: jsonreqresp.TitleSortFieldArtwork, Direction: jsonreqresp.ASCDirection},
+			expectedQuery: "Ван Гог",
+			expectedSort:  jsonreqresp.ArtworkSortOps{Field: jsonreqresp.TitleSortFieldArtwork, Direction: jsonreqresp.ASCDirection},
+		},
+		{
+			name:          "query too long",
+			filter:        jsonreqresp.ArtworkFilter{Query: strings.Repeat("я", jsonreqresp.MaxSearchQueryLen+1)},
+			expectedError: jsonreqresp.ErrSearchQueryTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArt := &artworkrep.MockArtworkRep{}
+			service := searcher.NewSearcher(mockArt, &eventrep.MockEventRep{})
+			if tt.expectedError == nil {
+				mockArt.On("GetArtworksPage", ctx,
+					mock.MatchedBy(func(f *jsonreqresp.ArtworkFilter) bool { return f.Query == tt.expectedQuery }),
+					&tt.expectedSort, page).
+					Return([]*models.Artwork{createTestArtwork()}, jsonreqresp.PageInfo{Total: 1}, nil)
+			}
+
+			_, _, err := service.GetAllArtworks(ctx, &tt.filter, &tt.sort, page)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockArt.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearcher_GetArtworkFacets(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	tests := []struct {
+		name          string
+		filter        jsonreqresp.ArtworkFilter
+		mockFacets    *models.ArtworkFacets
+		mockError     error
+		expectedError error
+	}{
+		{
+			name: "facets for filter",
+			filter: jsonreqresp.ArtworkFilter{
+				Technics:  []string{"oil on canvas"},
+				AuthorIDs: uuid.UUIDs{authorID},
+				YearFrom:  1900,
+				YearTo:    1999,
+			},
+			mockFacets: &models.ArtworkFacets{
+				Technics: []models.FacetBucket{{Value: "oil on canvas", Label: "oil on canvas", Count: 2}},
+				Authors:  []models.FacetBucket{{Value: authorID.String(), Label: "Test Author", Count: 2}},
+				YearMin:  1950,
+				YearMax:  1960,
+			},
+		},
+		{
+			name:          "inverted year range",
+			filter:        jsonreqresp.ArtworkFilter{YearFrom: 1900, YearTo: 1800},
+			expectedError: jsonreqresp.ErrArtworkYearRange,
+		},
+		{
+			name:          "inverted height range",
+			filter:        jsonreqresp.ArtworkFilter{Height: jsonreqresp.DimensionRange{Min: 200, Max: 100}},
+			expectedError: jsonreqresp.ErrArtworkDimensionRange,
+		},
+		{
+			name:          "query too long",
+			filter:        jsonreqresp.ArtworkFilter{Query: strings.Repeat("я", jsonreqresp.MaxSearchQueryLen+1)},
+			expectedError: jsonreqresp.ErrSearchQueryTooLong,
+		},
+		{
+			name:          "repository error",
+			filter:        jsonreqresp.ArtworkFilter{},
+			mockFacets:    (*models.ArtworkFacets)(nil),
+			mockError:     artworkrep.ErrQueryExec,
+			expectedError: artworkrep.ErrQueryExec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArt := &artworkrep.MockArtworkRep{}
+			service := searcher.NewSearcher(mockArt, &eventrep.MockEventRep{})
+			if tt.mockFacets != nil || tt.mockError != nil {
+				mockArt.On("GetArtworkFacets", ctx, &tt.filter).Return(tt.mockFacets, tt.mockError)
+			}
+
+			facets, err := service.GetArtworkFacets(ctx, &tt.filter)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, facets)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.mockFacets, facets)
+			}
+			mockArt.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearcher_GetArtworkProvenance(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+
+	newEntry := func(position int, isPublic bool) *models.ProvenanceEntry {
+		entry, err := models.NewProvenanceEntry(uuid.New(), artwork.GetID(), position, "Owner", false, "",
+			0, 0, models.DateExact, models.TransferUnknown, "", "", isPublic)
+		require.NoError(t, err)
+		return &entry
+	}
+	public, hidden, publicLast := newEntry(0, true), newEntry(1, false), newEntry(2, true)
+
+	t.Run("only public entries", func(t *testing.T) {
+		mockArt := &artworkrep.MockArtworkRep{}
+		mockArt.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+		mockArt.On("GetProvenance", ctx, artwork.GetID()).
+			Return([]*models.ProvenanceEntry{public, hidden, publicLast}, nil)
+		service := searcher.NewSearcher(mockArt, &eventrep.MockEventRep{})
+
+		entries, err := service.GetArtworkProvenance(ctx, artwork.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, []*models.ProvenanceEntry{public, publicLast}, entries)
+		mockArt.AssertExpectations(t)
+	})
+
+	t.Run("artwork not found", func(t *testing.T) {
+		mockArt := &artworkrep.MockArtworkRep{}
+		mockArt.On("GetByID", ctx, artwork.GetID()).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+		service := searcher.NewSearcher(mockArt, &eventrep.MockEventRep{})
+
+		_, err := service.GetArtworkProvenance(ctx, artwork.GetID())
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+		mockArt.AssertExpectations(t)
+	})
+}
+
 func TestSearcher_GetAllEvents(t *testing.T) {
 	ctx := context.Background()
 	filter := &jsonreqresp.EventFilter{
 		DateBegin: time.Now(),
 		DateEnd:   time.Now().Add(24 * time.Hour),
 	}
+	sort := &jsonreqresp.EventSortOps{Field: jsonreqresp.DateBeginSortFieldEvent, Direction: jsonreqresp.ASCDirection}
+	page := &jsonreqresp.PageRequest{}
 
 	tests := []struct {
 		name          string
@@ -156,15 +329,18 @@ func TestSearcher_GetAllEvents(t *testing.T) {
 					DateBegin: time.Now().Add(24 * time.Hour),
 					DateEnd:   time.Now(),
 				}
-				result, err := service.GetAllEvents(ctx, invalidFilter)
+				result, _, err := service.GetAllEvents(ctx, invalidFilter, sort, page)
 				assert.ErrorIs(t, err, jsonreqresp.ErrEventFilterDate)
 				assert.Nil(t, result)
 				return
 			}
 
-			mockEvent.On("GetAll", ctx, filter).Return(tt.mockEvents, tt.mockError)
+			mockEvent.On("GetPage", ctx, mock.MatchedBy(func(f *jsonreqresp.EventFilter) bool {
+				return f.Status == string(models.EventStatusApproved)
+			}), sort, page).
+				Return(tt.mockEvents, jsonreqresp.PageInfo{Total: tt.expectedCount}, tt.mockError)
 
-			result, err := service.GetAllEvents(ctx, filter)
+			result, _, err := service.GetAllEvents(ctx, filter, sort, page)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
@@ -178,3 +354,170 @@ func TestSearcher_GetAllEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestSearcher_InvalidPageLimit(t *testing.T) {
+	ctx := context.Background()
+	mockArt := &artworkrep.MockArtworkRep{}
+	mockEvent := &eventrep.MockEventRep{}
+	service := searcher.NewSearcher(mockArt, mockEvent)
+	page := &jsonreqresp.PageRequest{Limit: -1}
+
+	arts, _, err := service.GetAllArtworks(ctx, &jsonreqresp.ArtworkFilter{}, &jsonreqresp.ArtworkSortOps{}, page)
+	assert.ErrorIs(t, err, jsonreqresp.ErrPageLimit)
+	assert.Nil(t, arts)
+
+	events, _, err := service.GetAllEvents(ctx, &jsonreqresp.EventFilter{}, &jsonreqresp.EventSortOps{}, page)
+	assert.ErrorIs(t, err, jsonreqresp.ErrPageLimit)
+	assert.Nil(t, events)
+
+	mockArt.AssertExpectations(t)
+	mockEvent.AssertExpectations(t)
+}
+
+// approvedOnly проверяет, что публичная выборка ограничена одобренными мероприятиями
+func approvedOnly(f *jsonreqresp.EventFilter) bool {
+	return f.Status == string(models.EventStatusApproved)
+}
+
+func TestSearcher_GetArtworkEvents(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	filter := &jsonreqresp.EventFilter{}
+
+	t.Run("success", func(t *testing.T) {
+		mockArt := &artworkrep.MockArtworkRep{}
+		mockEvent := &eventrep.MockEventRep{}
+		service := searcher.NewSearcher(mockArt, mockEvent)
+
+		mockArt.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+		mockEvent.On("GetEventsByArtwork", ctx, artwork.GetID(), mock.MatchedBy(approvedOnly)).
+			Return([]*models.Event{createTestEvent(), createTestEvent()}, nil)
+
+		events, err := service.GetArtworkEvents(ctx, artwork.GetID(), filter)
+		require.NoError(t, err)
+		assert.Len(t, events, 2)
+		mockArt.AssertExpectations(t)
+		mockEvent.AssertExpectations(t)
+	})
+
+	t.Run("artwork not found", func(t *testing.T) {
+		mockArt := &artworkrep.MockArtworkRep{}
+		mockEvent := &eventrep.MockEventRep{}
+		service := searcher.NewSearcher(mockArt, mockEvent)
+
+		mockArt.On("GetByID", ctx, artwork.GetID()).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+
+		events, err := service.GetArtworkEvents(ctx, artwork.GetID(), filter)
+		assert.ErrorIs(t, err, artworkrep.ErrArtworkNotFound)
+		assert.Nil(t, events)
+		mockEvent.AssertNotCalled(t, "GetEventsByArtwork")
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		service := searcher.NewSearcher(&artworkrep.MockArtworkRep{}, &eventrep.MockEventRep{})
+		invalidFilter := &jsonreqresp.EventFilter{
+			DateBegin: time.Now().Add(24 * time.Hour),
+			DateEnd:   time.Now(),
+		}
+
+		_, err := service.GetArtworkEvents(ctx, artwork.GetID(), invalidFilter)
+		assert.ErrorIs(t, err, jsonreqresp.ErrEventFilterDate)
+	})
+}
+
+func TestSearcher_GetAuthorAndCollectionEvents(t *testing.T) {
+	ctx := context.Background()
+	authorID, collectionID := uuid.New(), uuid.New()
+	filter := &jsonreqresp.EventFilter{DateBegin: time.Now()}
+
+	mockArt := &artworkrep.MockArtworkRep{}
+	mockEvent := &eventrep.MockEventRep{}
+	service := searcher.NewSearcher(mockArt, mockEvent)
+
+	mockEvent.On("GetEventsByAuthor", ctx, authorID, mock.MatchedBy(approvedOnly)).
+		Return([]*models.Event{createTestEvent()}, nil)
+	mockEvent.On("GetEventsByCollection", ctx, collectionID, mock.MatchedBy(approvedOnly)).
+		Return(nil, eventrep.ErrQueryExec)
+
+	events, err := service.GetAuthorEvents(ctx, authorID, filter)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = service.GetCollectionEvents(ctx, collectionID, filter)
+	assert.ErrorIs(t, err, eventrep.ErrQueryExec)
+	assert.Nil(t, events)
+
+	mockEvent.AssertExpectations(t)
+}
+
+func TestSearcher_GetEvent(t *testing.T) {
+	ctx := context.Background()
+	mockArt := &artworkrep.MockArtworkRep{}
+	mockEvent := &eventrep.MockEventRep{}
+	service := searcher.NewSearcher(mockArt, mockEvent)
+
+	approved := createTestEvent()
+	require.NoError(t, approved.SetStatus(models.EventStatusApproved))
+	draft := createTestEvent()
+	mockEvent.On("GetByID", ctx, approved.GetID()).Return(approved, nil)
+	mockEvent.On("GetByID", ctx, draft.GetID()).Return(draft, nil)
+
+	event, err := service.GetEvent(ctx, approved.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, approved.GetID(), event.GetID())
+
+	event, err = service.GetEvent(ctx, draft.GetID())
+	assert.ErrorIs(t, err, eventrep.ErrEventNotFound)
+	assert.Nil(t, event)
+	mockEvent.AssertExpectations(t)
+}
+
+func TestSearcher_PublicEventFilterOverridesStatus(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+	mockEvent := &eventrep.MockEventRep{}
+	service := searcher.NewSearcher(&artworkrep.MockArtworkRep{}, mockEvent)
+
+	// посетитель не может запросить черновики, подставив статус в фильтр
+	filter := &jsonreqresp.EventFilter{Status: string(models.EventStatusDraft)}
+	mockEvent.On("GetEventsByAuthor", ctx, authorID, mock.MatchedBy(approvedOnly)).Return([]*models.Event{}, nil)
+
+	_, err := service.GetAuthorEvents(ctx, authorID, filter)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.EventStatusApproved), filter.Status)
+	mockEvent.AssertExpectations(t)
+}
+
+func TestSearcher_EventDetailsHideUnapproved(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork()
+	approved := createTestEvent()
+	require.NoError(t, approved.SetStatus(models.EventStatusApproved))
+	pending := createTestEvent()
+	require.NoError(t, pending.SetStatus(models.EventStatusPending))
+
+	mockArt := &artworkrep.MockArtworkRep{}
+	mockEvent := &eventrep.MockEventRep{}
+	service := searcher.NewSearcher(mockArt, mockEvent)
+	mockEvent.On("GetByID", ctx, approved.GetID()).Return(approved, nil)
+	mockEvent.On("GetByID", ctx, pending.GetID()).Return(pending, nil)
+	mockEvent.On("GetArtworkIDs", ctx, approved.GetID()).Return(uuid.UUIDs{artwork.GetID()}, nil)
+	mockEvent.On("GetCollectionsStat", ctx, approved.GetID()).
+		Return([]*models.StatCollections{}, nil)
+	mockArt.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+
+	artworks, err := service.GetArtworksFromEvent(ctx, approved.GetID())
+	require.NoError(t, err)
+	assert.Len(t, artworks, 1)
+	_, err = service.GetCollectionsStat(ctx, approved.GetID())
+	require.NoError(t, err)
+
+	artworks, err = service.GetArtworksFromEvent(ctx, pending.GetID())
+	assert.ErrorIs(t, err, eventrep.ErrEventNotFound)
+	assert.Nil(t, artworks)
+	stat, err := service.GetCollectionsStat(ctx, pending.GetID())
+	assert.ErrorIs(t, err, eventrep.ErrEventNotFound)
+	assert.Nil(t, stat)
+	mockEvent.AssertNotCalled(t, "GetArtworkIDs", ctx, pending.GetID())
+	mockEvent.AssertNotCalled(t, "GetCollectionsStat", ctx, pending.GetID())
+}
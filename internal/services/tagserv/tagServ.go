@@ -0,0 +1,180 @@
+package tagserv
+
+import (
+	"context"
+	"fmt"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/tagrep"
+	"github.com/google/uuid"
+)
+
+type TagServ interface {
+	GetAll(ctx context.Context) ([]*models.Tag, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error)
+	// Search возвращает теги, в название или синонимы которых входит термин
+	Search(ctx context.Context, term string) ([]*models.Tag, error)
+	Add(ctx context.Context, req jsonreqresp.TagRequest) (uuid.UUID, error)
+	Update(ctx context.Context, id uuid.UUID, req jsonreqresp.TagRequest) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetArtworkTags заменяет теги произведения
+	SetArtworkTags(ctx context.Context, artworkID uuid.UUID, tagIDs uuid.UUIDs) error
+	// публичный просмотр словаря
+	GetTree(ctx context.Context) ([]*models.TagNode, error)
+	GetNode(ctx context.Context, id uuid.UUID) (*models.TagNode, []*models.Tag, error)
+}
+
+func NewTagServ(tagRep tagrep.TagRep, artworkRep artworkrep.ArtworkRep) TagServ {
+	return &tagServ{tagRep: tagRep, artworkRep: artworkRep}
+}
+
+type tagServ struct {
+	tagRep     tagrep.TagRep
+	artworkRep artworkrep.ArtworkRep
+}
+
+func (s *tagServ) GetAll(ctx context.Context) ([]*models.Tag, error) {
+	return s.tagRep.GetAll(ctx)
+}
+
+func (s *tagServ) GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	return s.tagRep.GetByID(ctx, id)
+}
+
+func (s *tagServ) Search(ctx context.Context, term string) ([]*models.Tag, error) {
+	tags, err := s.tagRep.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tagServ.Search: %w", err)
+	}
+	var res []*models.Tag
+	for _, t := range tags {
+		if t.Contains(term) {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+func (s *tagServ) vocabulary(ctx context.Context) (*models.TagVocabulary, error) {
+	tags, err := s.tagRep.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewTagVocabulary(tags), nil
+}
+
+func (s *tagServ) Add(ctx context.Context, req jsonreqresp.TagRequest) (uuid.UUID, error) {
+	tag, err := models.NewTagFromRequest(uuid.New(), req)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("tagServ.Add: %w: %w", models.ErrValidateTag, err)
+	}
+	vocabulary, err := s.vocabulary(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("tagServ.Add: %w", err)
+	}
+	if err := vocabulary.CheckTag(&tag); err != nil {
+		return uuid.Nil, fmt.Errorf("tagServ.Add: %w: %w", models.ErrValidateTag, err)
+	}
+	if err := s.tagRep.Add(ctx, &tag); err != nil {
+		return uuid.Nil, fmt.Errorf("tagServ.Add: %w", err)
+	}
+	return tag.GetID(), nil
+}
+
+func (s *tagServ) Update(ctx context.Context, id uuid.UUID, req jsonreqresp.TagRequest) error {
+	vocabulary, err := s.vocabulary(ctx)
+	if err != nil {
+		return fmt.Errorf("tagServ.Update: %w", err)
+	}
+	err = s.tagRep.Update(ctx, id, func(t *models.Tag) (*models.Tag, error) {
+		if err := t.Update(req); err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrValidateTag, err)
+		}
+		if err := vocabulary.CheckTag(t); err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrValidateTag, err)
+		}
+		return t, nil
+	})
+	if err != nil {
+		return fmt.Errorf("tagServ.Update: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет тег вместе с отметками произведений; тег с дочерними тегами удалить нельзя
+func (s *tagServ) Delete(ctx context.Context, id uuid.UUID) error {
+	vocabulary, err := s.vocabulary(ctx)
+	if err != nil {
+		return fmt.Errorf("tagServ.Delete: %w", err)
+	}
+	if _, ok := vocabulary.Get(id); !ok {
+		return fmt.Errorf("tagServ.Delete: %w", tagrep.ErrTagNotFound)
+	}
+	if len(vocabulary.Children(id)) > 0 {
+		return fmt.Errorf("tagServ.Delete: %w", models.ErrTagHasChildren)
+	}
+	if err := s.tagRep.Delete(ctx, id); err != nil {
+		return fmt.Errorf("tagServ.Delete: %w", err)
+	}
+	return nil
+}
+
+func (s *tagServ) SetArtworkTags(ctx context.Context, artworkID uuid.UUID, tagIDs uuid.UUIDs) error {
+	if _, err := s.artworkRep.GetByID(ctx, artworkID); err != nil {
+		return fmt.Errorf("tagServ.SetArtworkTags: %w", err)
+	}
+	vocabulary, err := s.vocabulary(ctx)
+	if err != nil {
+		return fmt.Errorf("tagServ.SetArtworkTags: %w", err)
+	}
+	unique := make(uuid.UUIDs, 0, len(tagIDs))
+	seen := make(map[uuid.UUID]struct{}, len(tagIDs))
+	for _, id := range tagIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		if _, ok := vocabulary.Get(id); !ok {
+			return fmt.Errorf("tagServ.SetArtworkTags: %w: %s", tagrep.ErrTagNotFound, id)
+		}
+		unique = append(unique, id)
+	}
+	if len(unique) > models.ArtworkMaxTags {
+		return fmt.Errorf("tagServ.SetArtworkTags: %w: %w", models.ErrValidateArtwork, models.ErrArtworkTooManyTags)
+	}
+	if err := s.tagRep.SetArtworkTags(ctx, artworkID, unique); err != nil {
+		return fmt.Errorf("tagServ.SetArtworkTags: %w", err)
+	}
+	return nil
+}
+
+func (s *tagServ) GetTree(ctx context.Context) ([]*models.TagNode, error) {
+	vocabulary, err := s.vocabulary(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tagServ.GetTree: %w", err)
+	}
+	counts, err := s.tagRep.GetArtworkCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tagServ.GetTree: %w", err)
+	}
+	return vocabulary.BuildTagTree(counts), nil
+}
+
+// GetNode возвращает узел тега с потомками и цепочку его предков от корня
+func (s *tagServ) GetNode(ctx context.Context, id uuid.UUID) (*models.TagNode, []*models.Tag, error) {
+	vocabulary, err := s.vocabulary(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tagServ.GetNode: %w", err)
+	}
+	counts, err := s.tagRep.GetArtworkCounts(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tagServ.GetNode: %w", err)
+	}
+	node := models.FindTagNode(vocabulary.BuildTagTree(counts), id)
+	if node == nil {
+		return nil, nil, fmt.Errorf("tagServ.GetNode: %w", tagrep.ErrTagNotFound)
+	}
+	return node, vocabulary.Ancestors(id), nil
+}
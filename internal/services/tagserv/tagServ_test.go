@@ -0,0 +1,336 @@
+package tagserv_test
+
+import (
+	"context"
+	"testing"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/tagrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/tagserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTag(t *testing.T, name string, kind models.TagKind, parent *models.Tag, synonyms ...string) *models.Tag {
+	parentID := uuid.Nil
+	if parent != nil {
+		parentID = parent.GetID()
+	}
+	tag, err := models.NewTag(uuid.New(), name, kind, parentID, synonyms)
+	require.NoError(t, err)
+	return &tag
+}
+
+// createTestVocabulary возвращает словарь: Пейзаж -> Марина -> Шторм, Портрет, Импрессионизм
+func createTestVocabulary(t *testing.T) []*models.Tag {
+	landscape := createTestTag(t, "Пейзаж", models.TagSubject, nil, "ландшафт")
+	marine := createTestTag(t, "Марина", models.TagSubject, landscape, "морской пейзаж")
+	storm := createTestTag(t, "Шторм", models.TagSubject, marine)
+	portrait := createTestTag(t, "Портрет", models.TagSubject, nil)
+	impressionism := createTestTag(t, "Импрессионизм", models.TagMovement, nil)
+	return []*models.Tag{landscape, marine, storm, portrait, impressionism}
+}
+
+func TestTagService_Add(t *testing.T) {
+	ctx := context.Background()
+	tags := createTestVocabulary(t)
+	landscape, storm, impressionism := tags[0], tags[2], tags[4]
+
+	parentID := func(tag *models.Tag) string { return tag.GetID().String() }
+	deep := storm
+	for i := 0; i < models.TagMaxDepth-3; i++ {
+		deep = createTestTag(t, "Уровень "+string(rune('A'+i)), models.TagSubject, deep)
+		tags = append(tags, deep)
+	}
+
+	tests := []struct {
+		name          string
+		req           jsonreqresp.TagRequest
+		expectedError error
+	}{
+		{
+			name: "root tag",
+			req:  jsonreqresp.TagRequest{Name: "Натюрморт", Kind: "subject", Synonyms: []string{"still life"}},
+		},
+		{
+			name: "child tag",
+			req:  jsonreqresp.TagRequest{Name: "Горный пейзаж", Kind: "subject", ParentID: parentID(landscape)},
+		},
+		{
+			name: "same name of another kind",
+			req:  jsonreqresp.TagRequest{Name: "Пейзаж", Kind: "keyword"},
+		},
+		{
+			name:          "name taken by synonym",
+			req:           jsonreqresp.TagRequest{Name: "Ландшафт", Kind: "subject"},
+			expectedError: models.ErrTagDuplicateName,
+		},
+		{
+			name:          "synonym equals name",
+			req:           jsonreqresp.TagRequest{Name: "Жанр", Kind: "subject", Synonyms: []string{"жанр"}},
+			expectedError: models.ErrTagDuplicateName,
+		},
+		{
+			name:          "parent of another kind",
+			req:           jsonreqresp.TagRequest{Name: "Пуантилизм", Kind: "subject", ParentID: parentID(impressionism)},
+			expectedError: models.ErrTagParentKind,
+		},
+		{
+			name:          "unknown parent",
+			req:           jsonreqresp.TagRequest{Name: "Пуантилизм", Kind: "movement", ParentID: uuid.NewString()},
+			expectedError: models.ErrTagParentNotFound,
+		},
+		{
+			name:          "too deep",
+			req:           jsonreqresp.TagRequest{Name: "Слишком глубоко", Kind: "subject", ParentID: parentID(deep)},
+			expectedError: models.ErrTagTooDeep,
+		},
+		{
+			name:          "invalid kind",
+			req:           jsonreqresp.TagRequest{Name: "Тег", Kind: "genre"},
+			expectedError: models.ErrTagInvalidKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagMock := &tagrep.MockTagRep{}
+			tagMock.On("GetAll", ctx).Return(tags, nil).Maybe()
+			if tt.expectedError == nil {
+				tagMock.On("Add", ctx, mock.AnythingOfType("*models.Tag")).Return(nil)
+			}
+
+			service := tagserv.NewTagServ(tagMock, &artworkrep.MockArtworkRep{})
+			id, err := service.Add(ctx, tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, models.ErrValidateTag)
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, id)
+			tagMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_Update(t *testing.T) {
+	ctx := context.Background()
+	tags := createTestVocabulary(t)
+	landscape, marine, storm := tags[0], tags[1], tags[2]
+
+	tests := []struct {
+		name          string
+		tag           *models.Tag
+		req           jsonreqresp.TagRequest
+		expectedError error
+	}{
+		{
+			name: "rename and add synonyms",
+			tag:  marine,
+			req:  jsonreqresp.TagRequest{Name: "Морской вид", Kind: "subject", ParentID: landscape.GetID().String(), Synonyms: []string{"марина"}},
+		},
+		{
+			name:          "move under own descendant",
+			tag:           landscape,
+			req:           jsonreqresp.TagRequest{Name: "Пейзаж", Kind: "subject", ParentID: storm.GetID().String()},
+			expectedError: models.ErrTagCycle,
+		},
+		{
+			name:          "change kind of tag with children",
+			tag:           landscape,
+			req:           jsonreqresp.TagRequest{Name: "Пейзаж", Kind: "keyword"},
+			expectedError: models.ErrTagParentKind,
+		},
+		{
+			name:          "own parent",
+			tag:           storm,
+			req:           jsonreqresp.TagRequest{Name: "Шторм", Kind: "subject", ParentID: storm.GetID().String()},
+			expectedError: models.ErrTagSelfParent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagMock := &tagrep.MockTagRep{}
+			tagMock.On("GetAll", ctx).Return(tags, nil)
+			var updateErr error
+			var updated *models.Tag
+			tagMock.On("Update", ctx, tt.tag.GetID(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				tag := *tt.tag
+				updated, updateErr = args.Get(2).(func(*models.Tag) (*models.Tag, error))(&tag)
+			})
+
+			service := tagserv.NewTagServ(tagMock, &artworkrep.MockArtworkRep{})
+			require.NoError(t, service.Update(ctx, tt.tag.GetID(), tt.req))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, updateErr, models.ErrValidateTag)
+				assert.ErrorIs(t, updateErr, tt.expectedError)
+				return
+			}
+			require.NoError(t, updateErr)
+			assert.Equal(t, tt.req.Name, updated.GetName())
+			assert.Equal(t, tt.req.Synonyms, updated.GetSynonyms())
+		})
+	}
+}
+
+func TestTagService_Delete(t *testing.T) {
+	ctx := context.Background()
+	tags := createTestVocabulary(t)
+	landscape, storm := tags[0], tags[2]
+
+	tests := []struct {
+		name          string
+		id            uuid.UUID
+		expectedError error
+	}{
+		{name: "leaf tag", id: storm.GetID()},
+		{name: "tag with children", id: landscape.GetID(), expectedError: models.ErrTagHasChildren},
+		{name: "unknown tag", id: uuid.New(), expectedError: tagrep.ErrTagNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagMock := &tagrep.MockTagRep{}
+			tagMock.On("GetAll", ctx).Return(tags, nil)
+			if tt.expectedError == nil {
+				tagMock.On("Delete", ctx, tt.id).Return(nil)
+			}
+
+			service := tagserv.NewTagServ(tagMock, &artworkrep.MockArtworkRep{})
+			err := service.Delete(ctx, tt.id)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			tagMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_SetArtworkTags(t *testing.T) {
+	ctx := context.Background()
+	tags := createTestVocabulary(t)
+	artworkID := uuid.New()
+
+	tooMany := make(uuid.UUIDs, 0, models.ArtworkMaxTags+1)
+	for i := 0; i <= models.ArtworkMaxTags; i++ {
+		tag := createTestTag(t, "Ключ "+uuid.NewString(), models.TagKeyword, nil)
+		tags = append(tags, tag)
+		tooMany = append(tooMany, tag.GetID())
+	}
+
+	tests := []struct {
+		name          string
+		tagIDs        uuid.UUIDs
+		artworkErr    error
+		expectedSet   uuid.UUIDs
+		expectedError error
+	}{
+		{
+			name:        "duplicates are dropped",
+			tagIDs:      uuid.UUIDs{tags[0].GetID(), tags[3].GetID(), tags[0].GetID()},
+			expectedSet: uuid.UUIDs{tags[0].GetID(), tags[3].GetID()},
+		},
+		{
+			name:        "empty list removes tags",
+			tagIDs:      uuid.UUIDs{},
+			expectedSet: uuid.UUIDs{},
+		},
+		{
+			name:          "unknown tag",
+			tagIDs:        uuid.UUIDs{uuid.New()},
+			expectedError: tagrep.ErrTagNotFound,
+		},
+		{
+			name:          "too many tags",
+			tagIDs:        tooMany,
+			expectedError: models.ErrArtworkTooManyTags,
+		},
+		{
+			name:          "unknown artwork",
+			tagIDs:        uuid.UUIDs{tags[0].GetID()},
+			artworkErr:    artworkrep.ErrArtworkNotFound,
+			expectedError: artworkrep.ErrArtworkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagMock := &tagrep.MockTagRep{}
+			artMock := &artworkrep.MockArtworkRep{}
+			artMock.On("GetByID", ctx, artworkID).Return(&models.Artwork{}, tt.artworkErr)
+			tagMock.On("GetAll", ctx).Return(tags, nil).Maybe()
+			if tt.expectedError == nil {
+				tagMock.On("SetArtworkTags", ctx, artworkID, tt.expectedSet).Return(nil)
+			}
+
+			service := tagserv.NewTagServ(tagMock, artMock)
+			err := service.SetArtworkTags(ctx, artworkID, tt.tagIDs)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			tagMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_GetTree(t *testing.T) {
+	ctx := context.Background()
+	tags := createTestVocabulary(t)
+	landscape, marine, storm, portrait := tags[0], tags[1], tags[2], tags[3]
+
+	tagMock := &tagrep.MockTagRep{}
+	tagMock.On("GetAll", ctx).Return(tags, nil)
+	tagMock.On("GetArtworkCounts", ctx).Return(map[uuid.UUID]int{
+		landscape.GetID(): 1,
+		marine.GetID():    2,
+		storm.GetID():     3,
+		portrait.GetID():  4,
+	}, nil)
+	service := tagserv.NewTagServ(tagMock, &artworkrep.MockArtworkRep{})
+
+	t.Run("tree counts include descendants", func(t *testing.T) {
+		tree, err := service.GetTree(ctx)
+		require.NoError(t, err)
+		require.Len(t, tree, 3)
+		node := models.FindTagNode(tree, landscape.GetID())
+		require.NotNil(t, node)
+		assert.Equal(t, 6, node.Count)
+		require.Len(t, node.Children, 1)
+		assert.Equal(t, 5, node.Children[0].Count)
+	})
+
+	t.Run("node with ancestors", func(t *testing.T) {
+		node, ancestors, err := service.GetNode(ctx, storm.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, 3, node.Count)
+		require.Len(t, ancestors, 2)
+		assert.Equal(t, landscape.GetID(), ancestors[0].GetID())
+		assert.Equal(t, marine.GetID(), ancestors[1].GetID())
+	})
+
+	t.Run("unknown node", func(t *testing.T) {
+		_, _, err := service.GetNode(ctx, uuid.New())
+		assert.ErrorIs(t, err, tagrep.ErrTagNotFound)
+	})
+
+	t.Run("search by synonym", func(t *testing.T) {
+		found, err := service.Search(ctx, "МОРСКОЙ")
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, marine.GetID(), found[0].GetID())
+	})
+}
@@ -0,0 +1,110 @@
+package trashserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/trashrep"
+	"github.com/google/uuid"
+)
+
+// TrashServ корзина удалённых произведений, авторов и коллекций.
+// Записи хранятся в корзине retention, после чего удаляются окончательно вместе с изображениями
+type TrashServ interface {
+	// GetItems возвращает записи корзины; пустой entityType - записи всех типов
+	GetItems(ctx context.Context, entityType models.EntityType) ([]*models.TrashItem, error)
+	Restore(ctx context.Context, entityType models.EntityType, id uuid.UUID) error
+	// Purge окончательно удаляет записи, которые пробыли в корзине дольше срока хранения
+	Purge(ctx context.Context) ([]*models.TrashItem, error)
+	// Retention срок хранения записей в корзине
+	Retention() time.Duration
+	// RunPurge очищает корзину каждые interval до отмены ctx
+	RunPurge(ctx context.Context, interval time.Duration)
+}
+
+func NewTrashServ(trashRep trashrep.TrashRep, imageStorage imagestorage.ImageStorage, retention time.Duration) TrashServ {
+	return &trashServ{
+		trashRep:     trashRep,
+		imageStorage: imageStorage,
+		retention:    retention,
+	}
+}
+
+type trashServ struct {
+	trashRep     trashrep.TrashRep
+	imageStorage imagestorage.ImageStorage
+	retention    time.Duration
+}
+
+func (s *trashServ) GetItems(ctx context.Context, entityType models.EntityType) ([]*models.TrashItem, error) {
+	if entityType != "" && !entityType.IsTrashable() {
+		return nil, fmt.Errorf("trashServ.GetItems: %w", models.ErrTrashItemEntityType)
+	}
+	items, err := s.trashRep.GetItems(ctx, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("trashServ.GetItems: %w", err)
+	}
+	return items, nil
+}
+
+func (s *trashServ) Restore(ctx context.Context, entityType models.EntityType, id uuid.UUID) error {
+	if !entityType.IsTrashable() {
+		return fmt.Errorf("trashServ.Restore: %w", models.ErrTrashItemEntityType)
+	}
+	if err := s.trashRep.Restore(ctx, entityType, id); err != nil {
+		return fmt.Errorf("trashServ.Restore: %w", err)
+	}
+	return nil
+}
+
+// Purge удаляет изображения окончательно удалённых произведений и портреты авторов; ошибка удаления изображений
+// не отменяет очистку, удалённые записи возвращаются вместе с ошибкой
+func (s *trashServ) Purge(ctx context.Context) ([]*models.TrashItem, error) {
+	purged, err := s.trashRep.Purge(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return nil, fmt.Errorf("trashServ.Purge: %w", err)
+	}
+	var errs []error
+	for _, item := range purged {
+		var dir string
+		switch item.GetEntityType() {
+		case models.EntityArtwork:
+			dir = models.ArtworkImagesDir(item.GetID())
+		case models.EntityAuthor:
+			dir = models.AuthorImagesDir(item.GetID())
+		default:
+			continue
+		}
+		if err := s.imageStorage.Delete(ctx, dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return purged, fmt.Errorf("trashServ.Purge: %w", err)
+	}
+	return purged, nil
+}
+
+func (s *trashServ) Retention() time.Duration {
+	return s.retention
+}
+
+func (s *trashServ) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Purge(ctx); err != nil {
+				log.Printf("trash purge: %v", err)
+			}
+		}
+	}
+}
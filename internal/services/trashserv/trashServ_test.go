@@ -0,0 +1,158 @@
+package trashserv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/imagestorage"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/trashrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/trashserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const retention = 30 * 24 * time.Hour
+
+func createTrashItem(t *testing.T, entityType models.EntityType, title string) *models.TrashItem {
+	item, err := models.NewTrashItem(entityType, uuid.New(), title, time.Now().Add(-2*retention))
+	require.NoError(t, err)
+	return &item
+}
+
+func TestTrashServ_GetItems(t *testing.T) {
+	ctx := context.Background()
+	items := []*models.TrashItem{createTrashItem(t, models.EntityAuthor, "Клод Моне")}
+
+	t.Run("all types", func(t *testing.T) {
+		trashMock := &trashrep.MockTrashRep{}
+		trashMock.On("GetItems", ctx, models.EntityType("")).Return(items, nil)
+		service := trashserv.NewTrashServ(trashMock, &imagestorage.MockImageStorage{}, retention)
+
+		got, err := service.GetItems(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, items, got)
+		trashMock.AssertExpectations(t)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		trashMock := &trashrep.MockTrashRep{}
+		service := trashserv.NewTrashServ(trashMock, &imagestorage.MockImageStorage{}, retention)
+
+		_, err := service.GetItems(ctx, models.EntityEvent)
+		assert.ErrorIs(t, err, models.ErrTrashItemEntityType)
+		trashMock.AssertNotCalled(t, "GetItems", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTrashServ_Restore(t *testing.T) {
+	ctx := context.Background()
+	artworkID := uuid.New()
+
+	tests := []struct {
+		name          string
+		entityType    models.EntityType
+		setupMocks    func(*trashrep.MockTrashRep)
+		expectedError error
+	}{
+		{
+			name:       "success",
+			entityType: models.EntityArtwork,
+			setupMocks: func(m *trashrep.MockTrashRep) {
+				m.On("Restore", ctx, models.EntityArtwork, artworkID).Return(nil)
+			},
+		},
+		{
+			name:       "author in trash",
+			entityType: models.EntityArtwork,
+			setupMocks: func(m *trashrep.MockTrashRep) {
+				m.On("Restore", ctx, models.EntityArtwork, artworkID).Return(&models.DependencyError{
+					Records: []models.DependentRecord{{EntityType: models.EntityAuthor, ID: uuid.New(), InTrash: true}},
+				})
+			},
+			expectedError: models.ErrHasDependents,
+		},
+		{
+			name:       "not in trash",
+			entityType: models.EntityArtwork,
+			setupMocks: func(m *trashrep.MockTrashRep) {
+				m.On("Restore", ctx, models.EntityArtwork, artworkID).Return(trashrep.ErrTrashItemNotFound)
+			},
+			expectedError: trashrep.ErrTrashItemNotFound,
+		},
+		{
+			name:          "invalid type",
+			entityType:    models.EntityEvent,
+			setupMocks:    func(m *trashrep.MockTrashRep) {},
+			expectedError: models.ErrTrashItemEntityType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trashMock := &trashrep.MockTrashRep{}
+			tt.setupMocks(trashMock)
+			service := trashserv.NewTrashServ(trashMock, &imagestorage.MockImageStorage{}, retention)
+
+			err := service.Restore(ctx, tt.entityType, artworkID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			trashMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrashServ_Purge(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTrashItem(t, models.EntityArtwork, "Звёздная ночь")
+	author := createTrashItem(t, models.EntityAuthor, "Клод Моне")
+	// граница очистки - текущее время минус срок хранения
+	deletedBefore := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before.Add(retention)) < time.Minute
+	})
+
+	t.Run("deletes artwork images and author portraits", func(t *testing.T) {
+		trashMock := &trashrep.MockTrashRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		trashMock.On("Purge", ctx, deletedBefore).Return([]*models.TrashItem{artwork, author}, nil)
+		storageMock.On("Delete", ctx, models.ArtworkImagesDir(artwork.GetID())).Return(nil).Once()
+		storageMock.On("Delete", ctx, models.AuthorImagesDir(author.GetID())).Return(nil).Once()
+		service := trashserv.NewTrashServ(trashMock, storageMock, retention)
+
+		purged, err := service.Purge(ctx)
+		require.NoError(t, err)
+		assert.Len(t, purged, 2)
+		trashMock.AssertExpectations(t)
+		storageMock.AssertExpectations(t)
+	})
+
+	t.Run("image storage error", func(t *testing.T) {
+		trashMock := &trashrep.MockTrashRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		trashMock.On("Purge", ctx, deletedBefore).Return([]*models.TrashItem{artwork}, nil)
+		storageMock.On("Delete", ctx, models.ArtworkImagesDir(artwork.GetID())).Return(imagestorage.ErrDeleteImage)
+		service := trashserv.NewTrashServ(trashMock, storageMock, retention)
+
+		purged, err := service.Purge(ctx)
+		assert.ErrorIs(t, err, imagestorage.ErrDeleteImage)
+		assert.Len(t, purged, 1)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		trashMock := &trashrep.MockTrashRep{}
+		storageMock := &imagestorage.MockImageStorage{}
+		trashMock.On("Purge", ctx, deletedBefore).Return(nil, assert.AnError)
+		service := trashserv.NewTrashServ(trashMock, storageMock, retention)
+
+		_, err := service.Purge(ctx)
+		assert.ErrorIs(t, err, assert.AnError)
+		storageMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
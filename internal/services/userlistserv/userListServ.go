@@ -0,0 +1,345 @@
+package userlistserv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"github.com/google/uuid"
+)
+
+// UserListServ избранное и подборки авторизованного пользователя.
+// Чужая личная подборка для пользователя не существует
+type UserListServ interface {
+	// GetFavourites возвращает избранное и доступные посетителям записи каталога из него
+	GetFavourites(ctx context.Context) ([]*models.Favourite, *models.UserItemRecords, error)
+	AddFavourite(ctx context.Context, req jsonreqresp.FavouriteRequest) error
+	DeleteFavourite(ctx context.Context, entityType models.EntityType, entityID uuid.UUID) error
+
+	GetLists(ctx context.Context) ([]*models.UserList, error)
+	GetList(ctx context.Context, id uuid.UUID) (*models.UserList, *models.UserItemRecords, error)
+	AddList(ctx context.Context, req jsonreqresp.UserListRequest) (uuid.UUID, error)
+	UpdateList(ctx context.Context, id uuid.UUID, req jsonreqresp.UserListRequest) error
+	DeleteList(ctx context.Context, id uuid.UUID) error
+	// SetItems заменяет элементы подборки, порядок элементов в запросе - порядок показа
+	SetItems(ctx context.Context, id uuid.UUID, req jsonreqresp.UserListItemsRequest) error
+	AddItem(ctx context.Context, id uuid.UUID, req jsonreqresp.UserListItemRequest) error
+	RemoveItem(ctx context.Context, id uuid.UUID, entityType models.EntityType, entityID uuid.UUID) error
+
+	// GetPublicList возвращает публичную подборку любому посетителю
+	GetPublicList(ctx context.Context, id uuid.UUID) (*models.UserList, *models.UserItemRecords, error)
+}
+
+func NewUserListServ(
+	userListRep userlistrep.UserListRep,
+	artworkRep artworkrep.ArtworkRep,
+	eventRep eventrep.EventRep,
+	authZ auth.AuthZ,
+) UserListServ {
+	return &userListServ{
+		userListRep: userListRep,
+		artworkRep:  artworkRep,
+		eventRep:    eventRep,
+		authZ:       authZ,
+	}
+}
+
+type userListServ struct {
+	userListRep userlistrep.UserListRep
+	artworkRep  artworkrep.ArtworkRep
+	eventRep    eventrep.EventRep
+	authZ       auth.AuthZ
+}
+
+// checkItem проверяет, что запись каталога доступна посетителям: мероприятие должно быть одобрено
+func (s *userListServ) checkItem(ctx context.Context, entityType models.EntityType, entityID uuid.UUID) error {
+	switch entityType {
+	case models.EntityArtwork:
+		if _, err := s.artworkRep.GetByID(ctx, entityID); err != nil {
+			return err
+		}
+	case models.EntityEvent:
+		event, err := s.eventRep.GetByID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+		if !event.IsApproved() {
+			return eventrep.ErrEventNotFound
+		}
+	default:
+		return models.ErrUserItemEntityType
+	}
+	return nil
+}
+
+// loadRecords загружает записи каталога; удаленные и скрытые записи пропускаются
+func (s *userListServ) loadRecords(ctx context.Context, entityTypes []models.EntityType, entityIDs uuid.UUIDs) (*models.UserItemRecords, error) {
+	records := models.NewUserItemRecords()
+	for i, entityID := range entityIDs {
+		switch entityTypes[i] {
+		case models.EntityArtwork:
+			art, err := s.artworkRep.GetByID(ctx, entityID)
+			if errors.Is(err, artworkrep.ErrArtworkNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			records.Artworks[entityID] = art
+		case models.EntityEvent:
+			event, err := s.eventRep.GetByID(ctx, entityID)
+			if errors.Is(err, eventrep.ErrEventNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if event.IsApproved() {
+				records.Events[entityID] = event
+			}
+		}
+	}
+	return records, nil
+}
+
+func (s *userListServ) listRecords(ctx context.Context, l *models.UserList) (*models.UserItemRecords, error) {
+	entityTypes := make([]models.EntityType, 0, len(l.GetItems()))
+	entityIDs := make(uuid.UUIDs, 0, len(l.GetItems()))
+	for _, item := range l.GetItems() {
+		entityTypes = append(entityTypes, item.GetEntityType())
+		entityIDs = append(entityIDs, item.GetEntityID())
+	}
+	return s.loadRecords(ctx, entityTypes, entityIDs)
+}
+
+func (s *userListServ) GetFavourites(ctx context.Context) ([]*models.Favourite, *models.UserItemRecords, error) {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetFavourites: %w", err)
+	}
+	favourites, err := s.userListRep.GetFavourites(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetFavourites: %w", err)
+	}
+	entityTypes := make([]models.EntityType, 0, len(favourites))
+	entityIDs := make(uuid.UUIDs, 0, len(favourites))
+	for _, f := range favourites {
+		entityTypes = append(entityTypes, f.GetEntityType())
+		entityIDs = append(entityIDs, f.GetEntityID())
+	}
+	records, err := s.loadRecords(ctx, entityTypes, entityIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetFavourites: %w", err)
+	}
+	return favourites, records, nil
+}
+
+func (s *userListServ) AddFavourite(ctx context.Context, req jsonreqresp.FavouriteRequest) error {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("userListServ.AddFavourite: %w", err)
+	}
+	entityID, err := uuid.Parse(req.EntityID)
+	if err != nil {
+		return fmt.Errorf("userListServ.AddFavourite: %w: %v", models.ErrValidateUserList, err)
+	}
+	f, err := models.NewFavourite(userID, models.EntityType(req.EntityType), entityID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("userListServ.AddFavourite: %w: %w", models.ErrValidateUserList, err)
+	}
+	if err := s.checkItem(ctx, f.GetEntityType(), entityID); err != nil {
+		return fmt.Errorf("userListServ.AddFavourite: %w", err)
+	}
+	if err := s.userListRep.AddFavourite(ctx, &f); err != nil {
+		return fmt.Errorf("userListServ.AddFavourite: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) DeleteFavourite(ctx context.Context, entityType models.EntityType, entityID uuid.UUID) error {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("userListServ.DeleteFavourite: %w", err)
+	}
+	if err := s.userListRep.DeleteFavourite(ctx, userID, entityType, entityID); err != nil {
+		return fmt.Errorf("userListServ.DeleteFavourite: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) GetLists(ctx context.Context) ([]*models.UserList, error) {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("userListServ.GetLists: %w", err)
+	}
+	lists, err := s.userListRep.GetLists(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("userListServ.GetLists: %w", err)
+	}
+	return lists, nil
+}
+
+// ownList возвращает подборку текущего пользователя
+func (s *userListServ) ownList(ctx context.Context, id uuid.UUID) (*models.UserList, error) {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l, err := s.userListRep.GetListByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if l.GetUserID() != userID {
+		return nil, userlistrep.ErrUserListNotFound
+	}
+	return l, nil
+}
+
+func (s *userListServ) GetList(ctx context.Context, id uuid.UUID) (*models.UserList, *models.UserItemRecords, error) {
+	l, err := s.ownList(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetList: %w", err)
+	}
+	records, err := s.listRecords(ctx, l)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetList: %w", err)
+	}
+	return l, records, nil
+}
+
+func (s *userListServ) AddList(ctx context.Context, req jsonreqresp.UserListRequest) (uuid.UUID, error) {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("userListServ.AddList: %w", err)
+	}
+	lists, err := s.userListRep.GetLists(ctx, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("userListServ.AddList: %w", err)
+	}
+	if len(lists) >= models.UserMaxLists {
+		return uuid.Nil, fmt.Errorf("userListServ.AddList: %w: %w", models.ErrValidateUserList, models.ErrUserTooManyLists)
+	}
+	l, err := models.NewUserListFromRequest(uuid.New(), userID, req, time.Now().UTC())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("userListServ.AddList: %w: %w", models.ErrValidateUserList, err)
+	}
+	if err := s.userListRep.AddList(ctx, &l); err != nil {
+		return uuid.Nil, fmt.Errorf("userListServ.AddList: %w", err)
+	}
+	return l.GetID(), nil
+}
+
+// updateOwnList изменяет подборку текущего пользователя
+func (s *userListServ) updateOwnList(ctx context.Context, id uuid.UUID, change func(*models.UserList) error) error {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.userListRep.UpdateList(ctx, id, func(l *models.UserList) (*models.UserList, error) {
+		if l.GetUserID() != userID {
+			return nil, userlistrep.ErrUserListNotFound
+		}
+		if err := change(l); err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrValidateUserList, err)
+		}
+		return l, nil
+	})
+}
+
+func (s *userListServ) UpdateList(ctx context.Context, id uuid.UUID, req jsonreqresp.UserListRequest) error {
+	err := s.updateOwnList(ctx, id, func(l *models.UserList) error {
+		return l.Update(req, time.Now().UTC())
+	})
+	if err != nil {
+		return fmt.Errorf("userListServ.UpdateList: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) DeleteList(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.ownList(ctx, id); err != nil {
+		return fmt.Errorf("userListServ.DeleteList: %w", err)
+	}
+	if err := s.userListRep.DeleteList(ctx, id); err != nil {
+		return fmt.Errorf("userListServ.DeleteList: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) SetItems(ctx context.Context, id uuid.UUID, req jsonreqresp.UserListItemsRequest) error {
+	items := make([]models.UserListItem, 0, len(req.Items))
+	for _, itemReq := range req.Items {
+		item, err := models.NewUserListItemFromRequest(itemReq)
+		if err != nil {
+			return fmt.Errorf("userListServ.SetItems: %w: %w", models.ErrValidateUserList, err)
+		}
+		if err := s.checkItem(ctx, item.GetEntityType(), item.GetEntityID()); err != nil {
+			return fmt.Errorf("userListServ.SetItems: %w", err)
+		}
+		items = append(items, item)
+	}
+	err := s.updateOwnList(ctx, id, func(l *models.UserList) error {
+		return l.SetItems(items, time.Now().UTC())
+	})
+	if err != nil {
+		return fmt.Errorf("userListServ.SetItems: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) AddItem(ctx context.Context, id uuid.UUID, req jsonreqresp.UserListItemRequest) error {
+	item, err := models.NewUserListItemFromRequest(req)
+	if err != nil {
+		return fmt.Errorf("userListServ.AddItem: %w: %w", models.ErrValidateUserList, err)
+	}
+	if err := s.checkItem(ctx, item.GetEntityType(), item.GetEntityID()); err != nil {
+		return fmt.Errorf("userListServ.AddItem: %w", err)
+	}
+	err = s.updateOwnList(ctx, id, func(l *models.UserList) error {
+		return l.AddItem(item, time.Now().UTC())
+	})
+	if err != nil {
+		return fmt.Errorf("userListServ.AddItem: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) RemoveItem(ctx context.Context, id uuid.UUID, entityType models.EntityType, entityID uuid.UUID) error {
+	userID, err := s.authZ.UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("userListServ.RemoveItem: %w", err)
+	}
+	err = s.userListRep.UpdateList(ctx, id, func(l *models.UserList) (*models.UserList, error) {
+		if l.GetUserID() != userID {
+			return nil, userlistrep.ErrUserListNotFound
+		}
+		if !l.RemoveItem(entityType, entityID, time.Now().UTC()) {
+			return nil, userlistrep.ErrUserListItemNotFound
+		}
+		return l, nil
+	})
+	if err != nil {
+		return fmt.Errorf("userListServ.RemoveItem: %w", err)
+	}
+	return nil
+}
+
+func (s *userListServ) GetPublicList(ctx context.Context, id uuid.UUID) (*models.UserList, *models.UserItemRecords, error) {
+	l, err := s.userListRep.GetListByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetPublicList: %w", err)
+	}
+	if !l.IsPublic() {
+		return nil, nil, fmt.Errorf("userListServ.GetPublicList: %w", userlistrep.ErrUserListNotFound)
+	}
+	records, err := s.listRecords(ctx, l)
+	if err != nil {
+		return nil, nil, fmt.Errorf("userListServ.GetPublicList: %w", err)
+	}
+	return l, records, nil
+}
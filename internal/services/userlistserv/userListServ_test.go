@@ -0,0 +1,302 @@
+package userlistserv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models"
+	jsonreqresp "git.iu7.bmstu.ru/ped22u691/PPO.git/internal/models/json_req_resp"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/artworkrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/eventrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/repository/userlistrep"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/auth"
+	"git.iu7.bmstu.ru/ped22u691/PPO.git/internal/services/userlistserv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testMocks struct {
+	listRep    *userlistrep.MockUserListRep
+	artworkRep *artworkrep.MockArtworkRep
+	eventRep   *eventrep.MockEventRep
+	authZ      *auth.MockAuthZ
+}
+
+func setup(ctx context.Context, userID uuid.UUID) (userlistserv.UserListServ, *testMocks) {
+	m := &testMocks{
+		listRep:    &userlistrep.MockUserListRep{},
+		artworkRep: &artworkrep.MockArtworkRep{},
+		eventRep:   &eventrep.MockEventRep{},
+		authZ:      &auth.MockAuthZ{},
+	}
+	m.authZ.On("UserIDFromContext", ctx).Return(userID, nil).Maybe()
+	return userlistserv.NewUserListServ(m.listRep, m.artworkRep, m.eventRep, m.authZ), m
+}
+
+func createTestArtwork(t *testing.T) *models.Artwork {
+	author, err := models.NewAuthor(uuid.New(), "Клод Моне", 1840, 1926)
+	require.NoError(t, err)
+	collection, err := models.NewCollection(uuid.New(), "Импрессионизм")
+	require.NoError(t, err)
+	artwork, err := models.NewArtwork(uuid.New(), "Кувшинки", "oil on canvas", "canvas", "100x100 cm", 1916, &author, &collection)
+	require.NoError(t, err)
+	return &artwork
+}
+
+func createTestEvent(t *testing.T, status models.EventStatus) *models.Event {
+	event, err := models.NewEvent(uuid.New(), "Выставка", time.Now(), time.Now().Add(24*time.Hour),
+		"Москва", true, uuid.New(), 100, true, uuid.UUIDs{})
+	require.NoError(t, err)
+	require.NoError(t, event.SetStatus(status))
+	return &event
+}
+
+func createTestList(t *testing.T, userID uuid.UUID, isPublic bool, items ...models.UserListItem) *models.UserList {
+	l, err := models.NewUserList(uuid.New(), userID, "Мои импрессионисты", "", isPublic, time.Now(), time.Now(), items)
+	require.NoError(t, err)
+	return &l
+}
+
+// runUpdate вызывает функцию изменения подборки, переданную в репозиторий, на копии подборки
+func runUpdate(m *testMocks, ctx context.Context, l *models.UserList, updated **models.UserList, updateErr *error) {
+	m.listRep.On("UpdateList", ctx, l.GetID(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		copyL := *l
+		*updated, *updateErr = args.Get(2).(func(*models.UserList) (*models.UserList, error))(&copyL)
+	})
+}
+
+func TestUserListServ_AddFavourite(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	artwork := createTestArtwork(t)
+	approved := createTestEvent(t, models.EventStatusApproved)
+	pending := createTestEvent(t, models.EventStatusPending)
+
+	tests := []struct {
+		name          string
+		req           jsonreqresp.FavouriteRequest
+		expectedError error
+	}{
+		{name: "artwork", req: jsonreqresp.FavouriteRequest{EntityType: "artwork", EntityID: artwork.GetID().String()}},
+		{name: "approved event", req: jsonreqresp.FavouriteRequest{EntityType: "event", EntityID: approved.GetID().String()}},
+		{
+			name:          "not approved event",
+			req:           jsonreqresp.FavouriteRequest{EntityType: "event", EntityID: pending.GetID().String()},
+			expectedError: eventrep.ErrEventNotFound,
+		},
+		{
+			name:          "unsupported entity",
+			req:           jsonreqresp.FavouriteRequest{EntityType: "author", EntityID: uuid.NewString()},
+			expectedError: models.ErrValidateUserList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := setup(ctx, userID)
+			m.artworkRep.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil).Maybe()
+			m.eventRep.On("GetByID", ctx, approved.GetID()).Return(approved, nil).Maybe()
+			m.eventRep.On("GetByID", ctx, pending.GetID()).Return(pending, nil).Maybe()
+			if tt.expectedError == nil {
+				m.listRep.On("AddFavourite", ctx, mock.MatchedBy(func(f *models.Favourite) bool {
+					return f.GetUserID() == userID && f.GetEntityID().String() == tt.req.EntityID
+				})).Return(nil)
+			}
+
+			err := service.AddFavourite(ctx, tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			m.listRep.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserListServ_GetFavourites(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	service, m := setup(ctx, userID)
+
+	artwork := createTestArtwork(t)
+	deletedID := uuid.New()
+	pending := createTestEvent(t, models.EventStatusPending)
+	favourites := make([]*models.Favourite, 0, 3)
+	for _, item := range []struct {
+		entityType models.EntityType
+		entityID   uuid.UUID
+	}{
+		{models.EntityArtwork, artwork.GetID()},
+		{models.EntityArtwork, deletedID},
+		{models.EntityEvent, pending.GetID()},
+	} {
+		f, err := models.NewFavourite(userID, item.entityType, item.entityID, time.Now())
+		require.NoError(t, err)
+		favourites = append(favourites, &f)
+	}
+	m.listRep.On("GetFavourites", ctx, userID).Return(favourites, nil)
+	m.artworkRep.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+	m.artworkRep.On("GetByID", ctx, deletedID).Return((*models.Artwork)(nil), artworkrep.ErrArtworkNotFound)
+	m.eventRep.On("GetByID", ctx, pending.GetID()).Return(pending, nil)
+
+	res, records, err := service.GetFavourites(ctx)
+	require.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	resp := models.ToFavouritesResponse(res, records)
+	require.Len(t, resp.Artworks, 1)
+	assert.Equal(t, artwork.GetID().String(), resp.Artworks[0].ID)
+	assert.Empty(t, resp.Events)
+}
+
+func TestUserListServ_AddList(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	full := make([]*models.UserList, models.UserMaxLists)
+	for i := range full {
+		full[i] = createTestList(t, userID, false)
+	}
+
+	tests := []struct {
+		name          string
+		existing      []*models.UserList
+		req           jsonreqresp.UserListRequest
+		expectedError error
+	}{
+		{name: "first list", req: jsonreqresp.UserListRequest{Title: " Мои импрессионисты ", IsPublic: true}},
+		{
+			name:          "empty title",
+			req:           jsonreqresp.UserListRequest{Title: "  "},
+			expectedError: models.ErrUserListEmptyTitle,
+		},
+		{
+			name:          "too many lists",
+			existing:      full,
+			req:           jsonreqresp.UserListRequest{Title: "Еще одна"},
+			expectedError: models.ErrUserTooManyLists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := setup(ctx, userID)
+			m.listRep.On("GetLists", ctx, userID).Return(tt.existing, nil)
+			if tt.expectedError == nil {
+				m.listRep.On("AddList", ctx, mock.MatchedBy(func(l *models.UserList) bool {
+					return l.GetUserID() == userID && l.GetTitle() == "Мои импрессионисты" && l.IsPublic()
+				})).Return(nil)
+			}
+
+			id, err := service.AddList(ctx, tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, models.ErrValidateUserList)
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, id)
+			m.listRep.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserListServ_Items(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	artwork := createTestArtwork(t)
+	inList, err := models.NewUserListItem(models.EntityArtwork, artwork.GetID(), "")
+	require.NoError(t, err)
+
+	t.Run("add item to own list", func(t *testing.T) {
+		service, m := setup(ctx, userID)
+		l := createTestList(t, userID, false)
+		other := createTestArtwork(t)
+		m.artworkRep.On("GetByID", ctx, other.GetID()).Return(other, nil)
+		var updated *models.UserList
+		var updateErr error
+		runUpdate(m, ctx, l, &updated, &updateErr)
+
+		err := service.AddItem(ctx, l.GetID(), jsonreqresp.UserListItemRequest{
+			EntityType: "artwork", EntityID: other.GetID().String(), Note: "любимая",
+		})
+		require.NoError(t, err)
+		require.NoError(t, updateErr)
+		require.Len(t, updated.GetItems(), 1)
+		assert.Equal(t, "любимая", updated.GetItems()[0].GetNote())
+	})
+
+	t.Run("duplicate item", func(t *testing.T) {
+		service, m := setup(ctx, userID)
+		l := createTestList(t, userID, false, inList)
+		m.artworkRep.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+		var updated *models.UserList
+		var updateErr error
+		runUpdate(m, ctx, l, &updated, &updateErr)
+
+		require.NoError(t, service.AddItem(ctx, l.GetID(), jsonreqresp.UserListItemRequest{
+			EntityType: "artwork", EntityID: artwork.GetID().String(),
+		}))
+		assert.ErrorIs(t, updateErr, models.ErrValidateUserList)
+		assert.ErrorIs(t, updateErr, models.ErrUserListDuplicateItem)
+	})
+
+	t.Run("list of another user", func(t *testing.T) {
+		service, m := setup(ctx, userID)
+		l := createTestList(t, uuid.New(), true)
+		m.artworkRep.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+		var updated *models.UserList
+		var updateErr error
+		runUpdate(m, ctx, l, &updated, &updateErr)
+
+		require.NoError(t, service.AddItem(ctx, l.GetID(), jsonreqresp.UserListItemRequest{
+			EntityType: "artwork", EntityID: artwork.GetID().String(),
+		}))
+		assert.ErrorIs(t, updateErr, userlistrep.ErrUserListNotFound)
+	})
+
+	t.Run("remove missing item", func(t *testing.T) {
+		service, m := setup(ctx, userID)
+		l := createTestList(t, userID, false, inList)
+		var updated *models.UserList
+		var updateErr error
+		runUpdate(m, ctx, l, &updated, &updateErr)
+
+		require.NoError(t, service.RemoveItem(ctx, l.GetID(), models.EntityEvent, artwork.GetID()))
+		assert.ErrorIs(t, updateErr, userlistrep.ErrUserListItemNotFound)
+
+		require.NoError(t, service.RemoveItem(ctx, l.GetID(), models.EntityArtwork, artwork.GetID()))
+		require.NoError(t, updateErr)
+		assert.Empty(t, updated.GetItems())
+	})
+}
+
+func TestUserListServ_GetPublicList(t *testing.T) {
+	ctx := context.Background()
+	artwork := createTestArtwork(t)
+	item, err := models.NewUserListItem(models.EntityArtwork, artwork.GetID(), "")
+	require.NoError(t, err)
+	public := createTestList(t, uuid.New(), true, item)
+	private := createTestList(t, uuid.New(), false, item)
+
+	service, m := setup(ctx, uuid.Nil)
+	m.listRep.On("GetListByID", ctx, public.GetID()).Return(public, nil)
+	m.listRep.On("GetListByID", ctx, private.GetID()).Return(private, nil)
+	m.artworkRep.On("GetByID", ctx, artwork.GetID()).Return(artwork, nil)
+
+	l, records, err := service.GetPublicList(ctx, public.GetID())
+	require.NoError(t, err)
+	resp := l.ToUserListResponse("https://museum.example", records)
+	assert.Equal(t, "https://museum.example/museum/lists/"+public.GetID().String(), resp.ShareURL)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, 1, resp.Items[0].Position)
+
+	_, _, err = service.GetPublicList(ctx, private.GetID())
+	assert.ErrorIs(t, err, userlistrep.ErrUserListNotFound)
+}
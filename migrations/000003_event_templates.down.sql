@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Artwork_event_template CASCADE;
+DROP TABLE IF EXISTS Event_templates CASCADE;
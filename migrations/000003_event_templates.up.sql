@@ -0,0 +1,24 @@
+CREATE TABLE Event_templates (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    canVisit BOOLEAN,
+    adress VARCHAR(255),
+    cntTickets INT,
+    creatorID UUID NOT NULL,
+    FOREIGN KEY (creatorID) REFERENCES Employees(id)
+);
+ALTER TABLE Event_templates ADD CONSTRAINT emptyCheck 
+    CHECK(name != '' AND title != '' AND adress != ''); 
+
+CREATE TABLE Artwork_event_template (
+    artworkID UUID NOT NULL,
+    templateID UUID NOT NULL,
+    PRIMARY KEY (artworkID, templateID),
+    FOREIGN KEY (artworkID) REFERENCES Artworks(id) ON DELETE CASCADE,
+    FOREIGN KEY (templateID) REFERENCES Event_templates(id) ON DELETE CASCADE
+);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Event_templates, Artwork_event_template
+TO employee_role;
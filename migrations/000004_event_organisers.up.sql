@@ -0,0 +1,11 @@
+CREATE TABLE Event_organisers (
+    eventID UUID NOT NULL,
+    employeeID UUID NOT NULL,
+    PRIMARY KEY (eventID, employeeID),
+    FOREIGN KEY (eventID) REFERENCES Events(id) ON DELETE CASCADE,
+    FOREIGN KEY (employeeID) REFERENCES Employees(id) ON DELETE CASCADE
+);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Event_organisers
+TO employee_role;
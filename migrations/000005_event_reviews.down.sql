@@ -0,0 +1,28 @@
+DROP FUNCTION IF EXISTS get_event_of_artwork(UUID, TIMESTAMP, TIMESTAMP);
+CREATE FUNCTION get_event_of_artwork(
+    idArtwork UUID, 
+    dateBeginSee TIMESTAMP, 
+    dateEndSee TIMESTAMP)
+RETURNS TABLE (
+    event_id UUID,
+    title VARCHAR(255),
+    dateBegin TIMESTAMP,
+    dateEnd TIMESTAMP,
+    canVisit BOOLEAN,
+    adress VARCHAR(255),
+    cntTickets INT,
+    creatorID UUID
+) AS $$
+
+    SELECT e.id, e.title, e.dateBegin, e.dateEnd, e.canVisit, e.adress, e.cntTickets, e.creatorID
+    FROM Events e
+    JOIN Artwork_event ae ON e.id = ae.eventID
+    WHERE ae.artworkID = idArtwork
+      AND e.dateBegin <= dateEndSee
+      AND e.dateEnd >= dateBeginSee;
+
+$$ LANGUAGE sql;
+
+DROP TABLE IF EXISTS Event_reviews CASCADE;
+ALTER TABLE Events DROP CONSTRAINT IF EXISTS statusCheck;
+ALTER TABLE Events DROP COLUMN IF EXISTS status;
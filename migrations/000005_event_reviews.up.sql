@@ -0,0 +1,47 @@
+-- уже существующие мероприятия считаются опубликованными
+ALTER TABLE Events ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'approved';
+ALTER TABLE Events ADD CONSTRAINT statusCheck 
+    CHECK(status IN ('draft', 'pending', 'approved', 'rejected'));
+
+CREATE TABLE Event_reviews (
+    id UUID PRIMARY KEY,
+    eventID UUID NOT NULL,
+    actorID UUID NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    createdAt TIMESTAMP NOT NULL,
+    FOREIGN KEY (eventID) REFERENCES Events(id) ON DELETE CASCADE
+);
+ALTER TABLE Event_reviews ADD CONSTRAINT statusCheck 
+    CHECK(status IN ('draft', 'pending', 'approved', 'rejected'));
+
+GRANT SELECT, INSERT 
+ON TABLE Event_reviews
+TO employee_role;
+
+DROP FUNCTION IF EXISTS get_event_of_artwork(UUID, TIMESTAMP, TIMESTAMP);
+CREATE FUNCTION get_event_of_artwork(
+    idArtwork UUID, 
+    dateBeginSee TIMESTAMP, 
+    dateEndSee TIMESTAMP)
+RETURNS TABLE (
+    event_id UUID,
+    title VARCHAR(255),
+    dateBegin TIMESTAMP,
+    dateEnd TIMESTAMP,
+    canVisit BOOLEAN,
+    adress VARCHAR(255),
+    cntTickets INT,
+    creatorID UUID,
+    valid BOOLEAN,
+    status VARCHAR(16)
+) AS $$
+
+    SELECT e.id, e.title, e.dateBegin, e.dateEnd, e.canVisit, e.adress, e.cntTickets, e.creatorID, e.valid, e.status
+    FROM Events e
+    JOIN Artwork_event ae ON e.id = ae.eventID
+    WHERE ae.artworkID = idArtwork
+      AND e.dateBegin <= dateEndSee
+      AND e.dateEnd >= dateBeginSee;
+
+$$ LANGUAGE sql;
@@ -0,0 +1,23 @@
+CREATE TABLE Artwork_images (
+    id UUID PRIMARY KEY,
+    artworkID UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    isPrimary BOOLEAN NOT NULL DEFAULT false,
+    format VARCHAR(10) NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (artworkID) REFERENCES Artworks(id) ON DELETE CASCADE
+);
+ALTER TABLE Artwork_images ADD CONSTRAINT formatCheck
+    CHECK (format IN ('jpeg', 'png', 'webp'));
+ALTER TABLE Artwork_images ADD CONSTRAINT sizeCheck
+    CHECK (width > 0 AND height > 0 AND position >= 0);
+
+-- у произведения не больше одного основного изображения
+CREATE UNIQUE INDEX artwork_images_primary_idx ON Artwork_images (artworkID) WHERE isPrimary;
+CREATE INDEX artwork_images_artwork_idx ON Artwork_images (artworkID, position);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Artwork_images
+TO employee_role;
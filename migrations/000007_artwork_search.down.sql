@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS author_name_trgm_idx;
+DROP INDEX IF EXISTS artworks_title_trgm_idx;
+DROP INDEX IF EXISTS artworks_search_idx;
+
+DROP TRIGGER IF EXISTS collection_search_vector_trg ON Collection;
+DROP TRIGGER IF EXISTS author_search_vector_trg ON Author;
+DROP TRIGGER IF EXISTS artworks_search_vector_trg ON Artworks;
+DROP FUNCTION IF EXISTS collection_search_vector_update();
+DROP FUNCTION IF EXISTS author_search_vector_update();
+DROP FUNCTION IF EXISTS artworks_search_vector_update();
+
+ALTER TABLE Artworks DROP COLUMN IF EXISTS searchVector;
+DROP FUNCTION IF EXISTS artwork_search_vector(TEXT, TEXT, TEXT, TEXT, TEXT);
@@ -0,0 +1,69 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Конфигурация russian применяет russian_stem к словам на кириллице
+-- и english_stem к словам на латинице
+CREATE OR REPLACE FUNCTION artwork_search_vector(
+    artTitle TEXT, artTechnic TEXT, artMaterial TEXT, authorName TEXT, collectionTitle TEXT
+) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('russian', COALESCE(artTitle, '')), 'A') ||
+           setweight(to_tsvector('russian', COALESCE(authorName, '')), 'B') ||
+           setweight(to_tsvector('russian', COALESCE(collectionTitle, '')), 'C') ||
+           setweight(to_tsvector('russian', COALESCE(artTechnic, '') || ' ' || COALESCE(artMaterial, '')), 'D');
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE Artworks ADD COLUMN searchVector tsvector;
+
+CREATE OR REPLACE FUNCTION artworks_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.searchVector := artwork_search_vector(
+        NEW.title, NEW.technic, NEW.material,
+        (SELECT name FROM Author WHERE id = NEW.authorID),
+        (SELECT title FROM Collection WHERE id = NEW.collectionID));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER artworks_search_vector_trg
+BEFORE INSERT OR UPDATE OF title, technic, material, authorID, collectionID ON Artworks
+FOR EACH ROW EXECUTE FUNCTION artworks_search_vector_update();
+
+-- имя автора и название коллекции входят в поисковый вектор произведений
+CREATE OR REPLACE FUNCTION author_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    UPDATE Artworks SET searchVector = artwork_search_vector(
+        Artworks.title, Artworks.technic, Artworks.material, NEW.name,
+        (SELECT title FROM Collection WHERE id = Artworks.collectionID))
+    WHERE Artworks.authorID = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER author_search_vector_trg
+AFTER UPDATE OF name ON Author
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION author_search_vector_update();
+
+CREATE OR REPLACE FUNCTION collection_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    UPDATE Artworks SET searchVector = artwork_search_vector(
+        Artworks.title, Artworks.technic, Artworks.material,
+        (SELECT name FROM Author WHERE id = Artworks.authorID), NEW.title)
+    WHERE Artworks.collectionID = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER collection_search_vector_trg
+AFTER UPDATE OF title ON Collection
+FOR EACH ROW WHEN (OLD.title IS DISTINCT FROM NEW.title)
+EXECUTE FUNCTION collection_search_vector_update();
+
+UPDATE Artworks SET searchVector = artwork_search_vector(
+    Artworks.title, Artworks.technic, Artworks.material, Author.name, Collection.title)
+FROM Author, Collection
+WHERE Artworks.authorID = Author.id AND Artworks.collectionID = Collection.id;
+
+CREATE INDEX artworks_search_idx ON Artworks USING GIN (searchVector);
+-- триграммы для поиска с опечатками
+CREATE INDEX artworks_title_trgm_idx ON Artworks USING GIN (title gin_trgm_ops);
+CREATE INDEX author_name_trgm_idx ON Author USING GIN (name gin_trgm_ops);
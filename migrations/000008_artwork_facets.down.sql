@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_artworks_creation_year;
+DROP INDEX IF EXISTS idx_artworks_material;
+DROP INDEX IF EXISTS idx_artworks_technic;
@@ -0,0 +1,4 @@
+-- индексы для фасетных фильтров каталога произведений
+CREATE INDEX IF NOT EXISTS idx_artworks_technic ON Artworks (technic);
+CREATE INDEX IF NOT EXISTS idx_artworks_material ON Artworks (material);
+CREATE INDEX IF NOT EXISTS idx_artworks_creation_year ON Artworks (creationYear);
@@ -0,0 +1,34 @@
+CREATE TABLE Artwork_provenance (
+    id UUID PRIMARY KEY,
+    artworkID UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    owner VARCHAR(255) NOT NULL,
+    ownerUncertain BOOLEAN NOT NULL DEFAULT false,
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    periodFrom INT NOT NULL DEFAULT 0,
+    periodTo INT NOT NULL DEFAULT 0,
+    dateQualifier VARCHAR(10) NOT NULL DEFAULT 'exact',
+    transferMethod VARCHAR(20) NOT NULL DEFAULT 'unknown',
+    sources TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    isPublic BOOLEAN NOT NULL DEFAULT false,
+    FOREIGN KEY (artworkID) REFERENCES Artworks(id) ON DELETE CASCADE
+);
+ALTER TABLE Artwork_provenance ADD CONSTRAINT ownerCheck
+    CHECK (owner != '');
+-- 0 - год неизвестен
+ALTER TABLE Artwork_provenance ADD CONSTRAINT periodCheck
+    CHECK (periodFrom >= 0 AND periodTo >= 0 AND (periodFrom = 0 OR periodTo = 0 OR periodFrom <= periodTo));
+ALTER TABLE Artwork_provenance ADD CONSTRAINT dateQualifierCheck
+    CHECK (dateQualifier IN ('exact', 'circa', 'before', 'after'));
+ALTER TABLE Artwork_provenance ADD CONSTRAINT transferMethodCheck
+    CHECK (transferMethod IN ('purchase', 'auction', 'gift', 'bequest', 'inheritance',
+                              'exchange', 'commission', 'confiscation', 'restitution', 'unknown'));
+ALTER TABLE Artwork_provenance ADD CONSTRAINT positionCheck
+    CHECK (position >= 0);
+
+CREATE INDEX artwork_provenance_artwork_idx ON Artwork_provenance (artworkID, position);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Artwork_provenance
+TO employee_role;
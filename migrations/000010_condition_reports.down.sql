@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Condition_report_photos CASCADE;
+DROP TABLE IF EXISTS Condition_reports CASCADE;
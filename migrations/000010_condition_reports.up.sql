@@ -0,0 +1,41 @@
+CREATE TABLE Condition_reports (
+    id UUID PRIMARY KEY,
+    artworkID UUID NOT NULL,
+    checkedAt TIMESTAMP NOT NULL,
+    examiner VARCHAR(255) NOT NULL,
+    grade VARCHAR(10) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    -- пусто - осмотр без реставрации
+    treatment TEXT NOT NULL DEFAULT '',
+    -- NULL - реставрация не завершена
+    treatmentEnd TIMESTAMP NULL,
+    FOREIGN KEY (artworkID) REFERENCES Artworks(id) ON DELETE CASCADE
+);
+ALTER TABLE Condition_reports ADD CONSTRAINT examinerCheck
+    CHECK (examiner != '');
+ALTER TABLE Condition_reports ADD CONSTRAINT gradeCheck
+    CHECK (grade IN ('excellent', 'good', 'fair', 'poor', 'critical'));
+ALTER TABLE Condition_reports ADD CONSTRAINT treatmentEndCheck
+    CHECK (treatmentEnd IS NULL OR (treatment != '' AND treatmentEnd >= checkedAt));
+
+CREATE INDEX condition_reports_artwork_idx ON Condition_reports (artworkID, checkedAt);
+-- незавершенные реставрации проверяются при составлении мероприятий
+CREATE INDEX condition_reports_open_idx ON Condition_reports (artworkID)
+    WHERE treatment != '' AND treatmentEnd IS NULL;
+
+CREATE TABLE Condition_report_photos (
+    id UUID PRIMARY KEY,
+    reportID UUID NOT NULL,
+    artworkID UUID NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (reportID) REFERENCES Condition_reports(id) ON DELETE CASCADE
+);
+ALTER TABLE Condition_report_photos ADD CONSTRAINT formatCheck
+    CHECK (format IN ('jpeg', 'png', 'webp'));
+
+CREATE INDEX condition_report_photos_report_idx ON Condition_report_photos (reportID);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Condition_reports, Condition_report_photos
+TO employee_role;
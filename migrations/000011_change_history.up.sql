@@ -0,0 +1,26 @@
+CREATE TABLE Change_history (
+    id UUID PRIMARY KEY,
+    entityType VARCHAR(20) NOT NULL,
+    -- без внешнего ключа: история сохраняется и после удаления записи каталога
+    entityID UUID NOT NULL,
+    version INT NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    -- версия, к которой вернулись, только для action = 'revert'
+    revertedTo INT NOT NULL DEFAULT 0,
+    -- NULL - изменение сделано без авторизации
+    changedBy UUID NULL,
+    changedAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    before JSONB NOT NULL,
+    after JSONB NOT NULL,
+    UNIQUE (entityType, entityID, version)
+);
+ALTER TABLE Change_history ADD CONSTRAINT entityTypeCheck
+    CHECK (entityType IN ('artwork', 'author', 'collection', 'event'));
+ALTER TABLE Change_history ADD CONSTRAINT actionCheck
+    CHECK (action IN ('update', 'revert'));
+ALTER TABLE Change_history ADD CONSTRAINT versionCheck
+    CHECK (version > 0 AND revertedTo >= 0 AND revertedTo < version);
+
+GRANT SELECT, INSERT
+ON TABLE Change_history
+TO employee_role;
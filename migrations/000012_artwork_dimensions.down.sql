@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_artworks_weight;
+DROP INDEX IF EXISTS idx_artworks_depth;
+DROP INDEX IF EXISTS idx_artworks_width;
+DROP INDEX IF EXISTS idx_artworks_height;
+ALTER TABLE Artworks DROP CONSTRAINT IF EXISTS weightUnitCheck;
+ALTER TABLE Artworks DROP CONSTRAINT IF EXISTS lengthUnitCheck;
+ALTER TABLE Artworks DROP CONSTRAINT IF EXISTS dimensionsCheck;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS weightUnit;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS lengthUnit;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS weightKg;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS depthCm;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS widthCm;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS heightCm;
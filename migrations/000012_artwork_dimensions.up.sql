@@ -0,0 +1,21 @@
+-- структурированные размеры произведений: значения в сантиметрах и килограммах,
+-- единицы - только для представления; NULL - размер задан лишь текстом в size
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS heightCm DOUBLE PRECISION NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS widthCm DOUBLE PRECISION NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS depthCm DOUBLE PRECISION NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS weightKg DOUBLE PRECISION NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS lengthUnit VARCHAR(2) NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS weightUnit VARCHAR(2) NULL;
+ALTER TABLE Artworks ADD CONSTRAINT dimensionsCheck
+    CHECK((heightCm IS NULL AND widthCm IS NULL AND depthCm IS NULL AND weightKg IS NULL)
+        OR (heightCm > 0 AND widthCm > 0 AND depthCm >= 0 AND weightKg >= 0));
+ALTER TABLE Artworks ADD CONSTRAINT lengthUnitCheck
+    CHECK(lengthUnit IN ('mm', 'cm', 'm', 'in'));
+ALTER TABLE Artworks ADD CONSTRAINT weightUnitCheck
+    CHECK(weightUnit IN ('g', 'kg', 'lb'));
+
+-- индексы для фильтров и сортировки по размерам
+CREATE INDEX IF NOT EXISTS idx_artworks_height ON Artworks (heightCm);
+CREATE INDEX IF NOT EXISTS idx_artworks_width ON Artworks (widthCm);
+CREATE INDEX IF NOT EXISTS idx_artworks_depth ON Artworks (depthCm);
+CREATE INDEX IF NOT EXISTS idx_artworks_weight ON Artworks (weightKg);
@@ -0,0 +1,146 @@
+-- датировка произведения: период создания, уточнение и точность;
+-- creationYear остается действующим годом (серединой периода) для поиска и сортировки
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS yearFrom INT NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS yearTo INT NULL;
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS dateQualifier VARCHAR(10) NOT NULL DEFAULT 'exact';
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS datePrecision VARCHAR(10) NOT NULL DEFAULT 'year';
+-- роль основного автора (authorID)
+ALTER TABLE Artworks ADD COLUMN IF NOT EXISTS authorRole VARCHAR(20) NOT NULL DEFAULT 'author';
+
+UPDATE Artworks SET yearFrom = creationYear, yearTo = creationYear;
+ALTER TABLE Artworks ALTER COLUMN yearFrom SET NOT NULL;
+ALTER TABLE Artworks ALTER COLUMN yearTo SET NOT NULL;
+
+ALTER TABLE Artworks ADD CONSTRAINT datingCheck
+    CHECK (yearFrom > 0 AND yearFrom <= yearTo);
+ALTER TABLE Artworks ADD CONSTRAINT artworkDateQualifierCheck
+    CHECK (dateQualifier IN ('exact', 'circa', 'before', 'after'));
+ALTER TABLE Artworks ADD CONSTRAINT datePrecisionCheck
+    CHECK (datePrecision IN ('year', 'decade', 'century'));
+ALTER TABLE Artworks ADD CONSTRAINT authorRoleCheck
+    CHECK (authorRole IN ('author', 'attributed', 'workshop', 'circle', 'follower', 'copy_after'));
+
+-- Дополнительные авторы произведения (соавторы, мастерская, круг и т.д.)
+CREATE TABLE Artwork_authors (
+    artworkID UUID NOT NULL,
+    authorID UUID NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'author',
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (artworkID, authorID),
+    FOREIGN KEY (artworkID) REFERENCES Artworks(id) ON DELETE CASCADE,
+    FOREIGN KEY (authorID) REFERENCES Author(id) ON DELETE CASCADE
+);
+ALTER TABLE Artwork_authors ADD CONSTRAINT roleCheck
+    CHECK (role IN ('author', 'attributed', 'workshop', 'circle', 'follower', 'copy_after'));
+
+CREATE INDEX artwork_authors_author_idx ON Artwork_authors (authorID);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Artwork_authors
+TO employee_role;
+
+-- Совместима ли датировка с годами жизни автора.
+-- Произведение не может быть создано до рождения автора; для ролей author и attributed - и после смерти.
+-- circa расширяет период на 10 лет, before и after снимают нижнюю и верхнюю границу соответственно.
+CREATE OR REPLACE FUNCTION artwork_dating_fits_author(
+    year_from INT, year_to INT, qualifier VARCHAR, author_role VARCHAR,
+    birth_year INT, death_year INT
+)
+RETURNS BOOLEAN AS $$
+    SELECT (qualifier = 'after' OR year_to + CASE WHEN qualifier = 'circa' THEN 10 ELSE 0 END >= birth_year)
+       AND (author_role NOT IN ('author', 'attributed') OR death_year IS NULL OR qualifier = 'before'
+            OR year_from - CASE WHEN qualifier = 'circa' THEN 10 ELSE 0 END <= death_year);
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION validate_artwork_creation_year()
+RETURNS TRIGGER AS $$
+DECLARE
+    author_birth INT;
+    author_death INT;
+BEGIN
+    -- Получаем годы жизни автора
+    SELECT birthYear, deathYear INTO author_birth, author_death
+    FROM Author
+    WHERE id = NEW.authorID;
+
+    IF NOT artwork_dating_fits_author(NEW.yearFrom, NEW.yearTo, NEW.dateQualifier, NEW.authorRole,
+                                      author_birth, author_death) THEN
+        RAISE EXCEPTION 'Датировка произведения (% - %) не соответствует годам жизни автора (рождение: %, смерть: %)',
+                        NEW.yearFrom, NEW.yearTo, author_birth, author_death;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS check_artwork_year_on_update ON Artworks;
+CREATE TRIGGER check_artwork_year_on_update
+BEFORE UPDATE ON Artworks
+FOR EACH ROW
+WHEN (NEW.yearFrom IS DISTINCT FROM OLD.yearFrom OR NEW.yearTo IS DISTINCT FROM OLD.yearTo
+   OR NEW.dateQualifier IS DISTINCT FROM OLD.dateQualifier OR NEW.authorRole IS DISTINCT FROM OLD.authorRole
+   OR NEW.authorID IS DISTINCT FROM OLD.authorID)
+EXECUTE FUNCTION validate_artwork_creation_year();
+
+CREATE OR REPLACE FUNCTION validate_artwork_coauthor()
+RETURNS TRIGGER AS $$
+DECLARE
+    art RECORD;
+    author_birth INT;
+    author_death INT;
+BEGIN
+    SELECT yearFrom, yearTo, dateQualifier INTO art
+    FROM Artworks
+    WHERE id = NEW.artworkID;
+
+    SELECT birthYear, deathYear INTO author_birth, author_death
+    FROM Author
+    WHERE id = NEW.authorID;
+
+    IF NOT artwork_dating_fits_author(art.yearFrom, art.yearTo, art.dateQualifier, NEW.role,
+                                      author_birth, author_death) THEN
+        RAISE EXCEPTION 'Датировка произведения (% - %) не соответствует годам жизни автора (рождение: %, смерть: %)',
+                        art.yearFrom, art.yearTo, author_birth, author_death;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER check_artwork_coauthor
+BEFORE INSERT OR UPDATE ON Artwork_authors
+FOR EACH ROW
+EXECUTE FUNCTION validate_artwork_coauthor();
+
+CREATE OR REPLACE FUNCTION validate_existing_artworks_on_author_update()
+RETURNS TRIGGER AS $$
+DECLARE
+    invalid_artwork RECORD;
+BEGIN
+    -- Проверяем все произведения этого автора, в том числе дополнительного, при изменении дат жизни
+    IF (NEW.birthYear IS DISTINCT FROM OLD.birthYear) OR 
+       (NEW.deathYear IS DISTINCT FROM OLD.deathYear) THEN
+
+        FOR invalid_artwork IN 
+            SELECT a.title, a.yearFrom, a.yearTo
+            FROM Artworks a
+            WHERE a.authorID = NEW.id
+              AND NOT artwork_dating_fits_author(a.yearFrom, a.yearTo, a.dateQualifier, a.authorRole,
+                                                 NEW.birthYear, NEW.deathYear)
+            UNION ALL
+            SELECT a.title, a.yearFrom, a.yearTo
+            FROM Artworks a
+            JOIN Artwork_authors aa ON aa.artworkID = a.id
+            WHERE aa.authorID = NEW.id
+              AND NOT artwork_dating_fits_author(a.yearFrom, a.yearTo, a.dateQualifier, aa.role,
+                                                 NEW.birthYear, NEW.deathYear)
+        LOOP
+            RAISE EXCEPTION 
+                'Произведение "%" (датировка: % - %) не соответствует новым датам жизни автора (рождение: %, смерть: %)',
+                invalid_artwork.title, invalid_artwork.yearFrom, invalid_artwork.yearTo, NEW.birthYear, NEW.deathYear;
+        END LOOP;
+    END IF;
+    
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,36 @@
+-- Контролируемый словарь тегов: сюжеты, направления и ключевые слова.
+-- Теги одного вида образуют иерархию (parentID), синонимы хранятся через перевод строки
+CREATE TABLE Tags (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    parentID UUID NULL,
+    synonyms TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (parentID) REFERENCES Tags(id) ON DELETE RESTRICT
+);
+ALTER TABLE Tags ADD CONSTRAINT tagKindCheck
+    CHECK (kind IN ('subject', 'movement', 'keyword'));
+ALTER TABLE Tags ADD CONSTRAINT tagParentCheck
+    CHECK (parentID IS NULL OR parentID <> id);
+
+CREATE UNIQUE INDEX tags_kind_name_idx ON Tags (kind, lower(name));
+CREATE INDEX tags_parent_idx ON Tags (parentID);
+
+-- Теги произведений
+CREATE TABLE Artwork_tags (
+    artworkID UUID NOT NULL,
+    tagID UUID NOT NULL,
+    PRIMARY KEY (artworkID, tagID),
+    FOREIGN KEY (artworkID) REFERENCES Artworks(id) ON DELETE CASCADE,
+    FOREIGN KEY (tagID) REFERENCES Tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX artwork_tags_tag_idx ON Artwork_tags (tagID);
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Tags
+TO employee_role;
+
+GRANT SELECT, INSERT, UPDATE, DELETE 
+ON TABLE Artwork_tags
+TO employee_role;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS artworks_updated_at_idx;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS updatedAt;
@@ -0,0 +1,4 @@
+-- время последнего изменения произведения для выборочной выгрузки по OAI-PMH
+ALTER TABLE Artworks ADD COLUMN updatedAt TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX artworks_updated_at_idx ON Artworks (updatedAt, id);
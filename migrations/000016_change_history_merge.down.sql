@@ -0,0 +1,4 @@
+DELETE FROM Change_history WHERE action = 'merge';
+ALTER TABLE Change_history DROP CONSTRAINT actionCheck;
+ALTER TABLE Change_history ADD CONSTRAINT actionCheck
+    CHECK (action IN ('update', 'revert'));
@@ -0,0 +1,4 @@
+-- объединение дублей записей каталога сохраняется в истории как отдельное действие
+ALTER TABLE Change_history DROP CONSTRAINT actionCheck;
+ALTER TABLE Change_history ADD CONSTRAINT actionCheck
+    CHECK (action IN ('update', 'revert', 'merge'));
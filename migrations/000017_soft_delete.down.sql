@@ -0,0 +1,37 @@
+CREATE OR REPLACE FUNCTION get_event_collection_stats(event_id UUID)
+RETURNS TABLE (
+    collection_id UUID,
+    collection_title VARCHAR(255),
+    artwork_count BIGINT
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        c.id AS collection_id,
+        c.title AS collection_title,
+        COUNT(a.id) AS artwork_count
+    FROM 
+        Artwork_event ae
+        JOIN Artworks a ON ae.artworkID = a.id
+        JOIN Collection c ON a.collectionID = c.id
+    WHERE 
+        ae.eventID = event_id
+    GROUP BY 
+        c.id, c.title
+    ORDER BY 
+        artwork_count DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS artworks_deleted_at_idx;
+DROP INDEX IF EXISTS author_deleted_at_idx;
+DROP INDEX IF EXISTS collection_deleted_at_idx;
+
+-- записи из корзины при откате удаляются окончательно
+DELETE FROM Artworks WHERE deletedAt IS NOT NULL;
+DELETE FROM Author WHERE deletedAt IS NOT NULL AND NOT EXISTS (SELECT 1 FROM Artworks WHERE authorID = Author.id);
+DELETE FROM Collection WHERE deletedAt IS NOT NULL AND NOT EXISTS (SELECT 1 FROM Artworks WHERE collectionID = Collection.id);
+
+ALTER TABLE Artworks DROP COLUMN IF EXISTS deletedAt;
+ALTER TABLE Author DROP COLUMN IF EXISTS deletedAt;
+ALTER TABLE Collection DROP COLUMN IF EXISTS deletedAt;
@@ -0,0 +1,36 @@
+-- корзина: удаленные произведения, авторы и коллекции помечаются временем удаления
+-- и окончательно удаляются после срока хранения
+ALTER TABLE Artworks ADD COLUMN deletedAt TIMESTAMPTZ NULL;
+ALTER TABLE Author ADD COLUMN deletedAt TIMESTAMPTZ NULL;
+ALTER TABLE Collection ADD COLUMN deletedAt TIMESTAMPTZ NULL;
+
+CREATE INDEX artworks_deleted_at_idx ON Artworks (deletedAt) WHERE deletedAt IS NOT NULL;
+CREATE INDEX author_deleted_at_idx ON Author (deletedAt) WHERE deletedAt IS NOT NULL;
+CREATE INDEX collection_deleted_at_idx ON Collection (deletedAt) WHERE deletedAt IS NOT NULL;
+
+-- произведения в корзине не учитываются в статистике мероприятия
+CREATE OR REPLACE FUNCTION get_event_collection_stats(event_id UUID)
+RETURNS TABLE (
+    collection_id UUID,
+    collection_title VARCHAR(255),
+    artwork_count BIGINT
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        c.id AS collection_id,
+        c.title AS collection_title,
+        COUNT(a.id) AS artwork_count
+    FROM 
+        Artwork_event ae
+        JOIN Artworks a ON ae.artworkID = a.id
+        JOIN Collection c ON a.collectionID = c.id
+    WHERE 
+        ae.eventID = event_id
+        AND a.deletedAt IS NULL
+    GROUP BY 
+        c.id, c.title
+    ORDER BY 
+        artwork_count DESC;
+END;
+$$ LANGUAGE plpgsql;
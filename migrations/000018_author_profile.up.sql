@@ -0,0 +1,10 @@
+-- профиль автора для публичной страницы: биография, происхождение, направления (через перевод строки),
+-- ключ портрета в хранилище изображений и идентификаторы во внешних справочниках
+ALTER TABLE Author ADD COLUMN biography TEXT NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN nationality VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN movements TEXT NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN birthPlace VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN deathPlace VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN portrait VARCHAR(300) NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN ulanID VARCHAR(9) NOT NULL DEFAULT '';
+ALTER TABLE Author ADD COLUMN wikidataID VARCHAR(20) NOT NULL DEFAULT '';
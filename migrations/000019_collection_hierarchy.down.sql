@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS collection_parent_idx;
+ALTER TABLE Collection DROP CONSTRAINT IF EXISTS collectionParentCheck;
+ALTER TABLE Collection DROP CONSTRAINT IF EXISTS collectionParentFK;
+ALTER TABLE Collection DROP COLUMN IF EXISTS parentID;
@@ -0,0 +1,9 @@
+-- вложенные коллекции: parentID = NULL - корневая коллекция.
+-- Коллекцию нельзя окончательно удалить, пока в ней есть вложенные коллекции
+ALTER TABLE Collection ADD COLUMN parentID UUID NULL;
+ALTER TABLE Collection ADD CONSTRAINT collectionParentFK
+    FOREIGN KEY (parentID) REFERENCES Collection(id) ON DELETE RESTRICT;
+ALTER TABLE Collection ADD CONSTRAINT collectionParentCheck
+    CHECK (parentID IS NULL OR parentID <> id);
+
+CREATE INDEX collection_parent_idx ON Collection (parentID);
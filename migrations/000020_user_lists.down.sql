@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS User_list_items;
+DROP TABLE IF EXISTS User_lists;
+DROP TABLE IF EXISTS User_favourites;
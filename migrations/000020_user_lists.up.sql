@@ -0,0 +1,42 @@
+-- Избранные произведения и мероприятия пользователей.
+-- Без внешнего ключа на запись каталога: удаленные записи просто не показываются
+CREATE TABLE User_favourites (
+    userID UUID NOT NULL,
+    entityType VARCHAR(20) NOT NULL,
+    entityID UUID NOT NULL,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (userID, entityType, entityID),
+    FOREIGN KEY (userID) REFERENCES Users(id) ON DELETE CASCADE
+);
+ALTER TABLE User_favourites ADD CONSTRAINT userFavouriteTypeCheck
+    CHECK (entityType IN ('artwork', 'event'));
+
+-- Подборки пользователей, публичная подборка доступна всем по ссылке
+CREATE TABLE User_lists (
+    id UUID PRIMARY KEY,
+    userID UUID NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    isPublic BOOLEAN NOT NULL DEFAULT FALSE,
+    createdAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    updatedAt TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (userID) REFERENCES Users(id) ON DELETE CASCADE
+);
+CREATE INDEX user_lists_user_idx ON User_lists (userID);
+
+-- Элементы подборки в порядке показа с заметкой пользователя
+CREATE TABLE User_list_items (
+    listID UUID NOT NULL,
+    entityType VARCHAR(20) NOT NULL,
+    entityID UUID NOT NULL,
+    position INT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (listID, entityType, entityID),
+    FOREIGN KEY (listID) REFERENCES User_lists(id) ON DELETE CASCADE
+);
+ALTER TABLE User_list_items ADD CONSTRAINT userListItemTypeCheck
+    CHECK (entityType IN ('artwork', 'event'));
+
+GRANT SELECT, INSERT, UPDATE, DELETE
+ON TABLE User_favourites, User_lists, User_list_items
+TO user_role;
@@ -0,0 +1,25 @@
+-- Таблица Event_templates
+CREATE TABLE IF NOT EXISTS artworks.Event_templates
+(
+    id UUID,
+    name String,
+    title String,
+    canVisit Nullable(UInt8),
+    adress Nullable(String),
+    cntTickets Nullable(Int32),
+    creatorID UUID,
+    CONSTRAINT emptyCheck CHECK empty(name) = 0 AND empty(title) = 0 AND empty(adress) = 0
+)
+ENGINE = MergeTree()
+ORDER BY id
+PRIMARY KEY id;
+
+-- Таблица Artwork_event_template (многие-ко-многим)
+CREATE TABLE IF NOT EXISTS artworks.Artwork_event_template
+(
+    artworkID UUID,
+    templateID UUID
+)
+ENGINE = MergeTree()
+ORDER BY (artworkID, templateID)
+PRIMARY KEY (artworkID, templateID);
@@ -0,0 +1,9 @@
+-- Таблица Event_organisers (соорганизаторы мероприятий)
+CREATE TABLE IF NOT EXISTS artworks.Event_organisers
+(
+    eventID UUID,
+    employeeID UUID
+)
+ENGINE = MergeTree()
+ORDER BY (eventID, employeeID)
+PRIMARY KEY (eventID, employeeID);
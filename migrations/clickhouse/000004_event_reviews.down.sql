@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Event_reviews;
+ALTER TABLE Events DROP COLUMN IF EXISTS status;
@@ -0,0 +1,16 @@
+-- уже существующие мероприятия считаются опубликованными
+ALTER TABLE artworks.Events ADD COLUMN IF NOT EXISTS status String DEFAULT 'approved';
+
+-- Таблица Event_reviews (история согласования мероприятий)
+CREATE TABLE IF NOT EXISTS artworks.Event_reviews
+(
+    id UUID,
+    eventID UUID,
+    actorID UUID,
+    status String,
+    comment String DEFAULT '',
+    createdAt DateTime
+)
+ENGINE = MergeTree()
+ORDER BY (eventID, createdAt, id)
+PRIMARY KEY (eventID, createdAt, id);
@@ -0,0 +1,15 @@
+-- Таблица Artwork_images (изображения произведений)
+CREATE TABLE IF NOT EXISTS artworks.Artwork_images
+(
+    id UUID,
+    artworkID UUID,
+    position Int32,
+    isPrimary Bool DEFAULT false,
+    format String,
+    width Int32,
+    height Int32,
+    createdAt DateTime
+)
+ENGINE = MergeTree()
+ORDER BY (artworkID, id)
+PRIMARY KEY (artworkID, id);
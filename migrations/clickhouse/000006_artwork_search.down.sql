@@ -0,0 +1,3 @@
+ALTER TABLE Author DROP INDEX IF EXISTS author_name_ngrams;
+ALTER TABLE Artworks DROP INDEX IF EXISTS artworks_search_ngrams;
+ALTER TABLE Artworks DROP INDEX IF EXISTS artworks_search_tokens;
@@ -0,0 +1,12 @@
+-- Индексы пропуска для полнотекстового поиска: токены для поиска по словам
+-- и n-граммы для поиска по основам слов и с опечатками
+ALTER TABLE artworks.Artworks ADD INDEX IF NOT EXISTS artworks_search_tokens
+    lowerUTF8(concat(title, ' ', ifNull(technic, ''), ' ', ifNull(material, ''))) TYPE tokenbf_v1(4096, 3, 0) GRANULARITY 1;
+ALTER TABLE artworks.Artworks ADD INDEX IF NOT EXISTS artworks_search_ngrams
+    lowerUTF8(concat(title, ' ', ifNull(technic, ''), ' ', ifNull(material, ''))) TYPE ngrambf_v1(3, 4096, 3, 0) GRANULARITY 1;
+ALTER TABLE artworks.Author ADD INDEX IF NOT EXISTS author_name_ngrams
+    lowerUTF8(name) TYPE ngrambf_v1(3, 1024, 3, 0) GRANULARITY 1;
+
+ALTER TABLE artworks.Artworks MATERIALIZE INDEX artworks_search_tokens;
+ALTER TABLE artworks.Artworks MATERIALIZE INDEX artworks_search_ngrams;
+ALTER TABLE artworks.Author MATERIALIZE INDEX author_name_ngrams;
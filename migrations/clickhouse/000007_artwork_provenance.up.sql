@@ -0,0 +1,20 @@
+-- Таблица Artwork_provenance (история владения произведениями)
+CREATE TABLE IF NOT EXISTS artworks.Artwork_provenance
+(
+    id UUID,
+    artworkID UUID,
+    position Int32,
+    owner String,
+    ownerUncertain Bool DEFAULT false,
+    location String DEFAULT '',
+    periodFrom Int32 DEFAULT 0,
+    periodTo Int32 DEFAULT 0,
+    dateQualifier String DEFAULT 'exact',
+    transferMethod String DEFAULT 'unknown',
+    sources String DEFAULT '',
+    notes String DEFAULT '',
+    isPublic Bool DEFAULT false
+)
+ENGINE = MergeTree()
+ORDER BY (artworkID, id)
+PRIMARY KEY (artworkID, id);
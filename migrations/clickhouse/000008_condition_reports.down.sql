@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Condition_report_photos;
+DROP TABLE IF EXISTS Condition_reports;
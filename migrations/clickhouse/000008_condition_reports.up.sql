@@ -0,0 +1,28 @@
+-- Таблица Condition_reports (осмотры и реставрации произведений)
+CREATE TABLE IF NOT EXISTS artworks.Condition_reports
+(
+    id UUID,
+    artworkID UUID,
+    checkedAt DateTime,
+    examiner String,
+    grade String,
+    notes String DEFAULT '',
+    treatment String DEFAULT '',
+    treatmentEnd Nullable(DateTime)
+)
+ENGINE = MergeTree()
+ORDER BY (artworkID, id)
+PRIMARY KEY (artworkID, id);
+
+-- Таблица Condition_report_photos (фотографии к отчетам о состоянии)
+CREATE TABLE IF NOT EXISTS artworks.Condition_report_photos
+(
+    id UUID,
+    reportID UUID,
+    artworkID UUID,
+    format String,
+    createdAt DateTime
+)
+ENGINE = MergeTree()
+ORDER BY (reportID, id)
+PRIMARY KEY (reportID, id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS Change_history;
@@ -0,0 +1,17 @@
+-- Таблица Change_history (версии записей каталога)
+CREATE TABLE IF NOT EXISTS artworks.Change_history
+(
+    id UUID,
+    entityType String,
+    entityID UUID,
+    version Int32,
+    action String,
+    revertedTo Int32 DEFAULT 0,
+    changedBy Nullable(UUID),
+    changedAt DateTime,
+    before String,
+    after String
+)
+ENGINE = MergeTree()
+ORDER BY (entityType, entityID, version)
+PRIMARY KEY (entityType, entityID, version);
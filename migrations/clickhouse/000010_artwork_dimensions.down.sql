@@ -0,0 +1,6 @@
+ALTER TABLE Artworks DROP COLUMN IF EXISTS weightUnit;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS lengthUnit;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS weightKg;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS depthCm;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS widthCm;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS heightCm;
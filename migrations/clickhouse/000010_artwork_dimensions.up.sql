@@ -0,0 +1,8 @@
+-- структурированные размеры произведений: значения в сантиметрах и килограммах,
+-- единицы - только для представления; NULL - размер задан лишь текстом в size
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS heightCm Nullable(Float64);
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS widthCm Nullable(Float64);
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS depthCm Nullable(Float64);
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS weightKg Nullable(Float64);
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS lengthUnit Nullable(String);
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS weightUnit Nullable(String);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS Artwork_authors;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS authorRole;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS datePrecision;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS dateQualifier;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS yearTo;
+ALTER TABLE Artworks DROP COLUMN IF EXISTS yearFrom;
@@ -0,0 +1,20 @@
+-- датировка произведения: период создания, уточнение и точность;
+-- creationYear остается действующим годом (серединой периода) для поиска и сортировки
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS yearFrom Int32 DEFAULT creationYear;
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS yearTo Int32 DEFAULT creationYear;
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS dateQualifier String DEFAULT 'exact';
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS datePrecision String DEFAULT 'year';
+-- роль основного автора (authorID)
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS authorRole String DEFAULT 'author';
+
+-- Таблица Artwork_authors (дополнительные авторы произведения)
+CREATE TABLE IF NOT EXISTS artworks.Artwork_authors
+(
+    artworkID UUID,
+    authorID UUID,
+    role String DEFAULT 'author',
+    position Int32 DEFAULT 0
+)
+ENGINE = MergeTree()
+ORDER BY (artworkID, authorID)
+PRIMARY KEY (artworkID, authorID);
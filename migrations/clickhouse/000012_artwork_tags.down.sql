@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Artwork_tags;
+DROP TABLE IF EXISTS Tags;
@@ -0,0 +1,22 @@
+-- Таблица Tags (контролируемый словарь тегов, нулевой parentID - корневой тег, синонимы через перевод строки)
+CREATE TABLE IF NOT EXISTS artworks.Tags
+(
+    id UUID,
+    name String,
+    kind String,
+    parentID UUID DEFAULT '00000000-0000-0000-0000-000000000000',
+    synonyms String DEFAULT ''
+)
+ENGINE = MergeTree()
+ORDER BY id
+PRIMARY KEY id;
+
+-- Таблица Artwork_tags (теги произведений)
+CREATE TABLE IF NOT EXISTS artworks.Artwork_tags
+(
+    artworkID UUID,
+    tagID UUID
+)
+ENGINE = MergeTree()
+ORDER BY (artworkID, tagID)
+PRIMARY KEY (artworkID, tagID);
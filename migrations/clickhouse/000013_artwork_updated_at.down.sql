@@ -0,0 +1 @@
+ALTER TABLE Artworks DROP COLUMN IF EXISTS updatedAt;
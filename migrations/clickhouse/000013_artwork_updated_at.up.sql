@@ -0,0 +1,3 @@
+-- время последнего изменения произведения для выборочной выгрузки по OAI-PMH
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS updatedAt DateTime('UTC') DEFAULT now();
+ALTER TABLE artworks.Artworks MATERIALIZE COLUMN updatedAt;
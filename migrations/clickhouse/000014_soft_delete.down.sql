@@ -0,0 +1,3 @@
+ALTER TABLE Artworks DROP COLUMN IF EXISTS deletedAt;
+ALTER TABLE Author DROP COLUMN IF EXISTS deletedAt;
+ALTER TABLE Collection DROP COLUMN IF EXISTS deletedAt;
@@ -0,0 +1,4 @@
+-- корзина: время удаления произведения, автора или коллекции, NULL - запись не удалена
+ALTER TABLE artworks.Artworks ADD COLUMN IF NOT EXISTS deletedAt Nullable(DateTime('UTC'));
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS deletedAt Nullable(DateTime('UTC'));
+ALTER TABLE artworks.Collection ADD COLUMN IF NOT EXISTS deletedAt Nullable(DateTime('UTC'));
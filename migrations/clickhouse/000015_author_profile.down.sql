@@ -0,0 +1,8 @@
+ALTER TABLE Author DROP COLUMN IF EXISTS wikidataID;
+ALTER TABLE Author DROP COLUMN IF EXISTS ulanID;
+ALTER TABLE Author DROP COLUMN IF EXISTS portrait;
+ALTER TABLE Author DROP COLUMN IF EXISTS deathPlace;
+ALTER TABLE Author DROP COLUMN IF EXISTS birthPlace;
+ALTER TABLE Author DROP COLUMN IF EXISTS movements;
+ALTER TABLE Author DROP COLUMN IF EXISTS nationality;
+ALTER TABLE Author DROP COLUMN IF EXISTS biography;
@@ -0,0 +1,9 @@
+-- профиль автора: направления через перевод строки, portrait - ключ портрета в хранилище изображений
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS biography String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS nationality String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS movements String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS birthPlace String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS deathPlace String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS portrait String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS ulanID String DEFAULT '';
+ALTER TABLE artworks.Author ADD COLUMN IF NOT EXISTS wikidataID String DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE Collection DROP COLUMN IF EXISTS parentID;
@@ -0,0 +1,2 @@
+-- вложенные коллекции, нулевой parentID - корневая коллекция
+ALTER TABLE artworks.Collection ADD COLUMN IF NOT EXISTS parentID UUID DEFAULT '00000000-0000-0000-0000-000000000000';
@@ -0,0 +1,39 @@
+-- Таблица User_favourites (избранные произведения и мероприятия пользователей)
+CREATE TABLE IF NOT EXISTS artworks.User_favourites
+(
+    userID UUID,
+    entityType String,
+    entityID UUID,
+    createdAt DateTime
+)
+ENGINE = MergeTree()
+ORDER BY (userID, entityType, entityID)
+PRIMARY KEY (userID, entityType, entityID);
+
+-- Таблица User_lists (подборки пользователей)
+CREATE TABLE IF NOT EXISTS artworks.User_lists
+(
+    id UUID,
+    userID UUID,
+    title String,
+    description String DEFAULT '',
+    isPublic Bool DEFAULT false,
+    createdAt DateTime,
+    updatedAt DateTime
+)
+ENGINE = MergeTree()
+ORDER BY id
+PRIMARY KEY id;
+
+-- Таблица User_list_items (элементы подборок в порядке показа)
+CREATE TABLE IF NOT EXISTS artworks.User_list_items
+(
+    listID UUID,
+    entityType String,
+    entityID UUID,
+    position Int32,
+    note String DEFAULT ''
+)
+ENGINE = MergeTree()
+ORDER BY (listID, position)
+PRIMARY KEY (listID, position);